@@ -1,6 +1,7 @@
 package ai
 
 import (
+	"bytes"
 	"embed"
 	"encoding/json"
 	"fmt"
@@ -12,115 +13,68 @@ import (
 //go:embed templates/*.json
 var embeddedTemplates embed.FS
 
-// TemplateExerciseSet подход в упражнении
+// TemplateSchemaVersion версия схемы шаблонов (docs/pl_template_format.md)
+const TemplateSchemaVersion = 1
+
+// TemplateExerciseSet группа подходов: sets × reps на percent% от базы шаблона
+// (1ПМ или тренировочного максимума). Без percent — подсобка без расчёта веса
 type TemplateExerciseSet struct {
-	Percent float64 `json:"percent"` // Процент от 1ПМ
-	Reps    int     `json:"reps"`    // Повторения
-	Sets    int     `json:"sets"`    // Подходы
-	Weight  float64 `json:"weight"`  // Абсолютный вес (если указан)
+	Percent float64 `json:"percent,omitempty"` // Процент от 1ПМ (или ТМ)
+	Reps    int     `json:"reps"`              // Повторения
+	Sets    int     `json:"sets"`              // Подходы
+	AMRAP   bool    `json:"amrap,omitempty"`   // На максимум повторений, reps — минимум
 }
 
 // TemplateExercise упражнение в шаблоне
 type TemplateExercise struct {
 	Name     string                `json:"name"`
-	Type     string                `json:"type"`      // competition, accessory
-	LoadType string                `json:"load_type"` // Легкая, Средняя, Тяжелая
+	Type     string                `json:"type"`                // competition, accessory
+	LoadType string                `json:"load_type,omitempty"` // Легкая, Средняя, RPE 7...
 	Sets     []TemplateExerciseSet `json:"sets"`
-	SetsReps []int                 `json:"sets_reps"` // Для подсобки (просто повторения)
-	Stats    *ExerciseStats        `json:"stats,omitempty"`
-}
-
-// ExerciseStats статистика упражнения
-type ExerciseStats struct {
-	Tonnage   float64 `json:"tonnage"`
-	AvgWeight float64 `json:"avg_weight"`
-	Intensity float64 `json:"intensity"`
-	PM        float64 `json:"pm"`  // 1ПМ для этого упражнения
-	KPS       int     `json:"kps"` // Количество подъёмов штанги
 }
 
 // TemplateWorkout тренировка
 type TemplateWorkout struct {
-	WorkoutNum int                `json:"workout_num"`
-	Date       string             `json:"date,omitempty"`
-	Exercises  []TemplateExercise `json:"exercises"`
+	Day       int                `json:"day"` // День недели 1–7
+	Name      string             `json:"name"`
+	Exercises []TemplateExercise `json:"exercises"`
 }
 
 // TemplateWeek неделя
 type TemplateWeek struct {
-	WeekNum  int               `json:"week_num"`
-	Workouts []TemplateWorkout `json:"workouts,omitempty"`
-	Days     []TemplateDay     `json:"days,omitempty"` // Альтернативный формат (Шейко КМС)
-}
-
-// TemplateDay день тренировки (для Головинского)
-type TemplateDay struct {
-	Date      string             `json:"date"`
-	Exercises []TemplateExercise `json:"exercises"`
-}
-
-// TemplateMicrocycle микроцикл (для Головинского)
-type TemplateMicrocycle struct {
-	MicrocycleNum int                `json:"microcycle_num,omitempty"` // Старый формат
-	MicroNum      int                `json:"micro_num,omitempty"`      // Новый формат
-	Stats         *CycleStats        `json:"stats,omitempty"`
-	Days          []TemplateDay      `json:"days,omitempty"`
-	Workouts      []MicrocycleWorkout `json:"workouts,omitempty"` // Новый формат с workouts
-}
-
-// MicrocycleWorkout тренировка в микроцикле
-type MicrocycleWorkout struct {
-	Day       int                `json:"day"`
-	Exercises []TemplateExercise `json:"exercises"`
-}
-
-// CycleStats статистика цикла
-type CycleStats struct {
-	Tonnage   float64 `json:"tonnage"`
-	AvgWeight float64 `json:"avg_weight"`
-	KPS       int     `json:"kps"`
-}
-
-// TemplatePhase фаза (для Шейко)
-type TemplatePhase struct {
-	Name  string         `json:"name"`
-	Weeks []TemplateWeek `json:"weeks"`
-}
-
-// RussianCycleExercise упражнение в формате Русского цикла
-type RussianCycleExercise struct {
-	Name  string                 `json:"name"`
-	Weeks []RussianCycleWeekData `json:"weeks"`
-}
-
-// RussianCycleWeekData данные недели в Русском цикле
-type RussianCycleWeekData struct {
-	WeekNum     int                   `json:"week_num"`
-	TrainingNum int                   `json:"training_num,omitempty"` // 1 или 2 (для Муравьёва)
-	Sets        []TemplateExerciseSet `json:"sets"`
-}
-
-// RussianCycleExercises структура exercises для Русского цикла
-type RussianCycleExercises struct {
-	Squat    *RussianCycleExercise `json:"squat,omitempty"`
-	Bench    *RussianCycleExercise `json:"bench,omitempty"`
-	Deadlift *RussianCycleExercise `json:"deadlift,omitempty"`
+	Week     int               `json:"week"`
+	Phase    string            `json:"phase"`
+	Workouts []TemplateWorkout `json:"workouts"`
 }
 
 // PowerliftingTemplate шаблон программы
 type PowerliftingTemplate struct {
-	Name        string   `json:"name"`
-	Author      string   `json:"author"`
-	Level       []string `json:"level"` // 2_разряд, 1_разряд, КМС, МС, МСМК
-	Weeks       int      `json:"weeks"`
-	DaysPerWeek int      `json:"days_per_week"`
-	Type        string   `json:"type"` // троеборье, жим, присед, тяга
-
-	// Разные форматы хранения
-	Phases      []TemplatePhase        `json:"phases,omitempty"`      // Шейко 12 недель
-	WeeksData   []TemplateWeek         `json:"weeks_data,omitempty"`  // Шейко КМС/МС
-	Microcycles []TemplateMicrocycle   `json:"microcycles,omitempty"` // Головинский
-	Exercises   *RussianCycleExercises `json:"exercises,omitempty"`   // Русский цикл
+	Schema      int            `json:"schema"`
+	Name        string         `json:"name"`
+	Author      string         `json:"author"`
+	Description string         `json:"description,omitempty"`
+	Notes       []string       `json:"notes,omitempty"` // Разминка, слабые места, пояснения к блокам
+	Level       []string       `json:"level"`           // novice, 2_разряд, 1_разряд, КМС, МС, МСМК
+	Type        string         `json:"type"`            // powerlifting, bench, squat, deadlift, hip_thrust
+	Weeks       int            `json:"weeks"`
+	DaysPerWeek int            `json:"days_per_week"`
+	TrainingMax float64        `json:"training_max,omitempty"` // ТМ в % от 1ПМ; проценты подходов — от ТМ
+	Plan        []TemplateWeek `json:"plan"`
+}
+
+// ParseTemplate разбирает шаблон; неизвестные поля и чужая версия схемы — ошибка
+func ParseTemplate(data []byte) (*PowerliftingTemplate, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var t PowerliftingTemplate
+	if err := dec.Decode(&t); err != nil {
+		return nil, err
+	}
+	if t.Schema != TemplateSchemaVersion {
+		return nil, fmt.Errorf("schema %d не поддерживается (ожидается %d)", t.Schema, TemplateSchemaVersion)
+	}
+	return &t, nil
 }
 
 // TemplateManager менеджер шаблонов
@@ -158,12 +112,15 @@ func (tm *TemplateManager) loadTemplates() error {
 			return fmt.Errorf("не удалось прочитать файл %s: %w", file.Name(), err)
 		}
 
-		var template PowerliftingTemplate
-		if err := json.Unmarshal(data, &template); err != nil {
+		template, err := ParseTemplate(data)
+		if err != nil {
 			return fmt.Errorf("не удалось распарсить %s: %w", file.Name(), err)
 		}
+		if _, dup := tm.templates[template.Name]; dup {
+			return fmt.Errorf("%s: шаблон «%s» уже загружен из другого файла", file.Name(), template.Name)
+		}
 
-		tm.templates[template.Name] = &template
+		tm.templates[template.Name] = template
 	}
 
 	return nil
@@ -245,6 +202,15 @@ type PLGeneratedSet struct {
 	Reps     int     // Повторения
 	Sets     int     // Подходы
 	WeightKg float64 // Вес в кг (рассчитанный)
+	AMRAP    bool    // На максимум повторений, Reps — минимум
+}
+
+// RepsLabel повторения для вывода: «5» или «5+» для подхода на максимум
+func (s PLGeneratedSet) RepsLabel() string {
+	if s.AMRAP {
+		return fmt.Sprintf("%d+", s.Reps)
+	}
+	return fmt.Sprintf("%d", s.Reps)
 }
 
 // PLGeneratedExercise сгенерированное упражнение (пауэрлифтинг)
@@ -280,6 +246,7 @@ type PLGeneratedWeek struct {
 type PLGeneratedProgram struct {
 	Name         string           // Название программы
 	AthleteMaxes AthleteMaxes     // 1ПМ атлета
+	TrainingMax  float64          // ТМ шаблона в % от 1ПМ (0 — проценты от 1ПМ)
 	Weeks        []PLGeneratedWeek  // Недели
 	TotalKPS     int              // Общий КПШ
 	TotalTonnage float64          // Общий тоннаж
@@ -316,9 +283,15 @@ func (pg *ProgramGenerator) roundWeight(weight float64) float64 {
 	return math.Round(weight/pg.roundingKg) * pg.roundingKg
 }
 
+// ForExercise возвращает 1ПМ, от которого считаются проценты упражнения:
+// свой максимум для соревновательных движений, долю от него — для вариаций и подсобки
+func (m AthleteMaxes) ForExercise(exerciseName string) float64 {
+	return getMaxForExercise(exerciseName, m)
+}
+
 // getMaxForExercise возвращает 1ПМ для упражнения
 // Если основной максимум не указан, пытается рассчитать от других (для подсобки)
-func (pg *ProgramGenerator) getMaxForExercise(exerciseName string, maxes AthleteMaxes) float64 {
+func getMaxForExercise(exerciseName string, maxes AthleteMaxes) float64 {
 	name := normalizeExerciseName(exerciseName)
 
 	// Ягодичный мост (Hip Thrust) — соревновательное движение
@@ -404,8 +377,8 @@ func (pg *ProgramGenerator) getMaxForExercise(exerciseName string, maxes Athlete
 		return maxes.Bench * 0.7 // Остальные жимы
 	}
 
-	// Французский жим, трицепс — от жима лёжа
-	if plContains(name, "франц") || plContains(name, "трицепс") {
+	// Французский жим, трицепс, разгибания из-за головы — от жима лёжа
+	if plContains(name, "франц") || plContains(name, "трицепс") || plContains(name, "из-за головы") {
 		if maxes.Bench > 0 {
 			return maxes.Bench * 0.4
 		}
@@ -520,6 +493,14 @@ func (pg *ProgramGenerator) getMaxForExercise(exerciseName string, maxes Athlete
 		return 0
 	}
 
+	// Подъёмы и махи гантелей — изоляция для дельт
+	if (plContains(name, "подъем") || plContains(name, "мах")) && plContains(name, "гантел") {
+		if maxes.Bench > 0 {
+			return maxes.Bench * 0.2 // На каждую руку
+		}
+		return 0
+	}
+
 	// Пресс и прочая подсобка — без веса или с минимальным
 	return 0
 }
@@ -561,6 +542,7 @@ func (pg *ProgramGenerator) GenerateFromTemplateWithOptions(templateName string,
 	program := &PLGeneratedProgram{
 		Name:         template.Name,
 		AthleteMaxes: maxes,
+		TrainingMax:  template.TrainingMax,
 		Weeks:        make([]PLGeneratedWeek, 0),
 		Stats:        make(map[string]int),
 	}
@@ -568,19 +550,8 @@ func (pg *ProgramGenerator) GenerateFromTemplateWithOptions(templateName string,
 	// Сохраняем опции для использования в генерации
 	pg.currentOpts = &opts
 
-	// Генерируем в зависимости от формата шаблона
-	if len(template.Phases) > 0 {
-		// Шейко 12 недель
-		pg.generateFromPhases(template, maxes, program)
-	} else if len(template.WeeksData) > 0 {
-		// Шейко КМС/МС, Верхошанский
-		pg.generateFromWeeksData(template, maxes, program)
-	} else if len(template.Microcycles) > 0 {
-		// Головинский
-		pg.generateFromMicrocycles(template, maxes, program)
-	} else if template.Exercises != nil {
-		// Русский цикл
-		pg.generateFromRussianCycle(template, maxes, program)
+	for _, week := range template.Plan {
+		program.Weeks = append(program.Weeks, pg.generateWeek(week, maxes, template.TrainingMax))
 	}
 
 	// Фильтруем по типу движения
@@ -605,326 +576,15 @@ func (pg *ProgramGenerator) GenerateFromTemplateWithOptions(templateName string,
 	return program, nil
 }
 
-// generateFromPhases генерирует из фаз (Шейко 12 недель)
-func (pg *ProgramGenerator) generateFromPhases(template *PowerliftingTemplate, maxes AthleteMaxes, program *PLGeneratedProgram) {
-	weekNum := 0
-	for _, phase := range template.Phases {
-		for _, week := range phase.Weeks {
-			weekNum++
-			genWeek := pg.generateWeek(week, maxes, phase.Name)
-			genWeek.WeekNum = weekNum
-			program.Weeks = append(program.Weeks, genWeek)
-		}
-	}
-}
-
-// generateFromWeeksData генерирует из недель (Шейко КМС/МС)
-func (pg *ProgramGenerator) generateFromWeeksData(template *PowerliftingTemplate, maxes AthleteMaxes, program *PLGeneratedProgram) {
-	for _, week := range template.WeeksData {
-		genWeek := pg.generateWeekFromKMS(week, maxes)
-		program.Weeks = append(program.Weeks, genWeek)
-	}
-}
-
-// generateFromMicrocycles генерирует из микроциклов (Головинский)
-func (pg *ProgramGenerator) generateFromMicrocycles(template *PowerliftingTemplate, maxes AthleteMaxes, program *PLGeneratedProgram) {
-	weekNum := 0
-	for _, micro := range template.Microcycles {
-		weekNum++
-		genWeek := pg.generateWeekFromMicrocycle(micro, maxes)
-		// Используем номер микроцикла из любого поля
-		if micro.MicroNum > 0 {
-			genWeek.WeekNum = micro.MicroNum
-		} else if micro.MicrocycleNum > 0 {
-			genWeek.WeekNum = micro.MicrocycleNum
-		} else {
-			genWeek.WeekNum = weekNum
-		}
-		program.Weeks = append(program.Weeks, genWeek)
-	}
-}
-
-// generateFromRussianCycle генерирует из формата Русского цикла
-func (pg *ProgramGenerator) generateFromRussianCycle(template *PowerliftingTemplate, maxes AthleteMaxes, program *PLGeneratedProgram) {
-	ex := template.Exercises
-	if ex == nil {
-		return
-	}
-
-	// Определяем количество недель
-	numWeeks := template.Weeks
-	if numWeeks == 0 {
-		numWeeks = 12 // По умолчанию
-	}
-
-	// Определяем формат: Муравьёв (2 тренировки/неделю для приседа/жима, 1 для тяги)
-	// или классический Русский цикл (1 тренировка/неделю для каждого)
-	hasMuravyevFormat := pg.isMuravyevFormat(ex)
-
-	// Создаём недели
-	for weekNum := 1; weekNum <= numWeeks; weekNum++ {
-		genWeek := PLGeneratedWeek{
-			WeekNum: weekNum,
-			Phase:   pg.getRussianCyclePhase(weekNum, numWeeks),
-		}
-
-		if hasMuravyevFormat {
-			// Муравьёв: 3 тренировки в неделю
-			// Тренировка I (Пн): Присед + Жим (тренировка 1)
-			// Тренировка II (Ср): Тяга
-			// Тренировка III (Пт): Присед + Жим (тренировка 2)
-
-			// Тренировка I: Присед (трен.1) + Жим (трен.1)
-			workout1 := PLGeneratedWorkout{
-				DayNum: 1,
-				Name:   "Тренировка I (Пн)",
-			}
-			if ex.Squat != nil {
-				squatEx := pg.generateRussianCycleExerciseByTraining("Присед", ex.Squat, weekNum, 1, maxes.Squat)
-				if squatEx != nil {
-					workout1.Exercises = append(workout1.Exercises, *squatEx)
-					workout1.TotalKPS += squatEx.TotalReps
-					workout1.Tonnage += squatEx.Tonnage
-				}
-			}
-			if ex.Bench != nil {
-				benchEx := pg.generateRussianCycleExerciseByTraining("Жим лёжа", ex.Bench, weekNum, 1, maxes.Bench)
-				if benchEx != nil {
-					workout1.Exercises = append(workout1.Exercises, *benchEx)
-					workout1.TotalKPS += benchEx.TotalReps
-					workout1.Tonnage += benchEx.Tonnage
-				}
-			}
-			if len(workout1.Exercises) > 0 {
-				genWeek.Workouts = append(genWeek.Workouts, workout1)
-				genWeek.TotalKPS += workout1.TotalKPS
-				genWeek.Tonnage += workout1.Tonnage
-			}
-
-			// Тренировка II: Тяга (только 1 тренировка в неделю)
-			if ex.Deadlift != nil {
-				workout2 := PLGeneratedWorkout{
-					DayNum: 2,
-					Name:   "Тренировка II (Ср)",
-				}
-				deadliftEx := pg.generateRussianCycleExerciseByTraining("Становая тяга", ex.Deadlift, weekNum, 1, maxes.Deadlift)
-				if deadliftEx != nil {
-					workout2.Exercises = append(workout2.Exercises, *deadliftEx)
-					workout2.TotalKPS += deadliftEx.TotalReps
-					workout2.Tonnage += deadliftEx.Tonnage
-				}
-				if len(workout2.Exercises) > 0 {
-					genWeek.Workouts = append(genWeek.Workouts, workout2)
-					genWeek.TotalKPS += workout2.TotalKPS
-					genWeek.Tonnage += workout2.Tonnage
-				}
-			}
-
-			// Тренировка III: Присед (трен.2) + Жим (трен.2)
-			workout3 := PLGeneratedWorkout{
-				DayNum: 3,
-				Name:   "Тренировка III (Пт)",
-			}
-			if ex.Squat != nil {
-				squatEx := pg.generateRussianCycleExerciseByTraining("Присед", ex.Squat, weekNum, 2, maxes.Squat)
-				if squatEx != nil {
-					workout3.Exercises = append(workout3.Exercises, *squatEx)
-					workout3.TotalKPS += squatEx.TotalReps
-					workout3.Tonnage += squatEx.Tonnage
-				}
-			}
-			if ex.Bench != nil {
-				benchEx := pg.generateRussianCycleExerciseByTraining("Жим лёжа", ex.Bench, weekNum, 2, maxes.Bench)
-				if benchEx != nil {
-					workout3.Exercises = append(workout3.Exercises, *benchEx)
-					workout3.TotalKPS += benchEx.TotalReps
-					workout3.Tonnage += benchEx.Tonnage
-				}
-			}
-			if len(workout3.Exercises) > 0 {
-				genWeek.Workouts = append(genWeek.Workouts, workout3)
-				genWeek.TotalKPS += workout3.TotalKPS
-				genWeek.Tonnage += workout3.Tonnage
-			}
-		} else {
-			// Классический Русский цикл: 2 тренировки
-
-			// Тренировка 1: Присед + Жим
-			workout1 := PLGeneratedWorkout{
-				DayNum: 1,
-				Name:   "Тренировка 1 (Присед + Жим)",
-			}
-			if ex.Squat != nil {
-				squatEx := pg.generateRussianCycleExercise("Присед", ex.Squat, weekNum, maxes.Squat)
-				if squatEx != nil {
-					workout1.Exercises = append(workout1.Exercises, *squatEx)
-					workout1.TotalKPS += squatEx.TotalReps
-					workout1.Tonnage += squatEx.Tonnage
-				}
-			}
-			if ex.Bench != nil {
-				benchEx := pg.generateRussianCycleExercise("Жим лёжа", ex.Bench, weekNum, maxes.Bench)
-				if benchEx != nil {
-					workout1.Exercises = append(workout1.Exercises, *benchEx)
-					workout1.TotalKPS += benchEx.TotalReps
-					workout1.Tonnage += benchEx.Tonnage
-				}
-			}
-			if len(workout1.Exercises) > 0 {
-				genWeek.Workouts = append(genWeek.Workouts, workout1)
-				genWeek.TotalKPS += workout1.TotalKPS
-				genWeek.Tonnage += workout1.Tonnage
-			}
-
-			// Тренировка 2: Тяга
-			if ex.Deadlift != nil {
-				workout2 := PLGeneratedWorkout{
-					DayNum: 2,
-					Name:   "Тренировка 2 (Тяга)",
-				}
-				deadliftEx := pg.generateRussianCycleExercise("Становая тяга", ex.Deadlift, weekNum, maxes.Deadlift)
-				if deadliftEx != nil {
-					workout2.Exercises = append(workout2.Exercises, *deadliftEx)
-					workout2.TotalKPS += deadliftEx.TotalReps
-					workout2.Tonnage += deadliftEx.Tonnage
-				}
-				if len(workout2.Exercises) > 0 {
-					genWeek.Workouts = append(genWeek.Workouts, workout2)
-					genWeek.TotalKPS += workout2.TotalKPS
-					genWeek.Tonnage += workout2.Tonnage
-				}
-			}
-		}
-
-		if len(genWeek.Workouts) > 0 {
-			program.Weeks = append(program.Weeks, genWeek)
-		}
-	}
-}
-
-// isMuravyevFormat проверяет, является ли шаблон форматом Муравьёва
-// (2 тренировки в неделю для жима/приседа с одинаковым week_num)
-func (pg *ProgramGenerator) isMuravyevFormat(ex *RussianCycleExercises) bool {
-	if ex == nil || ex.Bench == nil || len(ex.Bench.Weeks) < 2 {
-		return false
-	}
-	// Если есть 2 записи с week_num=1, это Муравьёв
-	count := 0
-	for _, w := range ex.Bench.Weeks {
-		if w.WeekNum == 1 {
-			count++
-		}
-	}
-	return count >= 2
-}
-
-// generateRussianCycleExerciseByTraining генерирует упражнение с учётом номера тренировки
-func (pg *ProgramGenerator) generateRussianCycleExerciseByTraining(name string, rcEx *RussianCycleExercise, weekNum, trainingNum int, oneRM float64) *PLGeneratedExercise {
-	if rcEx == nil || len(rcEx.Weeks) == 0 {
-		return nil
-	}
-
-	// Ищем данные для нужной недели и тренировки
-	// В текущем формате JSON записи идут подряд: week_num=1 (трен.1), week_num=1 (трен.2), week_num=2 (трен.1), ...
-	var weekData *RussianCycleWeekData
-	matchCount := 0
-	for i := range rcEx.Weeks {
-		if rcEx.Weeks[i].WeekNum == weekNum {
-			matchCount++
-			if matchCount == trainingNum {
-				weekData = &rcEx.Weeks[i]
-				break
-			}
-		}
-	}
-
-	if weekData == nil || len(weekData.Sets) == 0 {
-		return nil
-	}
-
-	return pg.buildExerciseFromWeekData(name, weekData, oneRM)
-}
-
-// generateRussianCycleExercise генерирует упражнение из Русского цикла (первая тренировка недели)
-func (pg *ProgramGenerator) generateRussianCycleExercise(name string, rcEx *RussianCycleExercise, weekNum int, oneRM float64) *PLGeneratedExercise {
-	if rcEx == nil || len(rcEx.Weeks) == 0 {
-		return nil
-	}
-
-	// Ищем данные для нужной недели (берём первую запись)
-	var weekData *RussianCycleWeekData
-	for i := range rcEx.Weeks {
-		if rcEx.Weeks[i].WeekNum == weekNum {
-			weekData = &rcEx.Weeks[i]
-			break
-		}
-	}
-
-	if weekData == nil || len(weekData.Sets) == 0 {
-		return nil
-	}
-
-	return pg.buildExerciseFromWeekData(name, weekData, oneRM)
-}
-
-// buildExerciseFromWeekData создаёт упражнение из данных недели
-func (pg *ProgramGenerator) buildExerciseFromWeekData(name string, weekData *RussianCycleWeekData, oneRM float64) *PLGeneratedExercise {
-	genEx := &PLGeneratedExercise{
-		Name: name,
-		Type: "competition",
-	}
-
-	for _, set := range weekData.Sets {
-		sets := set.Sets
-		if sets == 0 {
-			sets = 1
-		}
-
-		weight := pg.roundWeight(oneRM * set.Percent / 100)
-
-		genSet := PLGeneratedSet{
-			Percent:  set.Percent,
-			Reps:     set.Reps,
-			Sets:     sets,
-			WeightKg: weight,
-		}
-
-		genEx.Sets = append(genEx.Sets, genSet)
-
-		reps := set.Reps * sets
-		genEx.TotalReps += reps
-		genEx.Tonnage += weight * float64(reps) / 1000
-	}
-
-	if genEx.TotalReps > 0 && oneRM > 0 {
-		genEx.AvgPercent = (genEx.Tonnage * 1000 / float64(genEx.TotalReps)) / oneRM * 100
-	}
-
-	return genEx
-}
-
-// getRussianCyclePhase определяет фазу Русского цикла
-func (pg *ProgramGenerator) getRussianCyclePhase(weekNum, totalWeeks int) string {
-	progress := float64(weekNum) / float64(totalWeeks)
-	switch {
-	case progress <= 0.33:
-		return "Накопление объёма"
-	case progress <= 0.66:
-		return "Интенсификация"
-	default:
-		return "Реализация"
-	}
-}
-
-// generateWeek генерирует неделю из шаблона Шейко 12 недель
-func (pg *ProgramGenerator) generateWeek(week TemplateWeek, maxes AthleteMaxes, phaseName string) PLGeneratedWeek {
+// generateWeek генерирует неделю из шаблона
+func (pg *ProgramGenerator) generateWeek(week TemplateWeek, maxes AthleteMaxes, trainingMax float64) PLGeneratedWeek {
 	genWeek := PLGeneratedWeek{
-		WeekNum: week.WeekNum,
-		Phase:   phaseName,
+		WeekNum: week.Week,
+		Phase:   week.Phase,
 	}
 
 	for _, workout := range week.Workouts {
-		genWorkout := pg.generateWorkout(workout, maxes)
+		genWorkout := pg.generateWorkout(workout, maxes, trainingMax)
 		genWeek.Workouts = append(genWeek.Workouts, genWorkout)
 		genWeek.TotalKPS += genWorkout.TotalKPS
 		genWeek.Tonnage += genWorkout.Tonnage
@@ -940,14 +600,17 @@ func (pg *ProgramGenerator) generateWeek(week TemplateWeek, maxes AthleteMaxes,
 }
 
 // generateWorkout генерирует тренировку
-func (pg *ProgramGenerator) generateWorkout(workout TemplateWorkout, maxes AthleteMaxes) PLGeneratedWorkout {
+func (pg *ProgramGenerator) generateWorkout(workout TemplateWorkout, maxes AthleteMaxes, trainingMax float64) PLGeneratedWorkout {
 	genWorkout := PLGeneratedWorkout{
-		DayNum: workout.WorkoutNum,
-		Name:   fmt.Sprintf("Тренировка %d", workout.WorkoutNum),
+		DayNum: workout.Day,
+		Name:   workout.Name,
+	}
+	if genWorkout.Name == "" {
+		genWorkout.Name = fmt.Sprintf("Тренировка %d", workout.Day)
 	}
 
 	for _, ex := range workout.Exercises {
-		genEx := pg.generateExercise(ex, maxes)
+		genEx := pg.generateExercise(ex, maxes, trainingMax)
 		genWorkout.Exercises = append(genWorkout.Exercises, genEx)
 		genWorkout.TotalKPS += genEx.TotalReps
 		genWorkout.Tonnage += genEx.Tonnage
@@ -956,23 +619,29 @@ func (pg *ProgramGenerator) generateWorkout(workout TemplateWorkout, maxes Athle
 	return genWorkout
 }
 
-// generateExercise генерирует упражнение
-func (pg *ProgramGenerator) generateExercise(ex TemplateExercise, maxes AthleteMaxes) PLGeneratedExercise {
+// generateExercise генерирует упражнение. Проценты шаблона считаются от тренировочного
+// максимума, если он задан, и переводятся в проценты от 1ПМ
+func (pg *ProgramGenerator) generateExercise(ex TemplateExercise, maxes AthleteMaxes, trainingMax float64) PLGeneratedExercise {
 	genEx := PLGeneratedExercise{
 		Name: ex.Name,
 		Type: ex.Type,
 	}
 
-	oneRM := pg.getMaxForExercise(ex.Name, maxes)
+	oneRM := getMaxForExercise(ex.Name, maxes)
+	base := 1.0
+	if trainingMax > 0 {
+		base = trainingMax / 100
+	}
 
 	var totalWeight float64
 	var totalReps int
 
 	for _, set := range ex.Sets {
 		genSet := PLGeneratedSet{
-			Percent: set.Percent,
+			Percent: set.Percent * base,
 			Reps:    set.Reps,
 			Sets:    set.Sets,
+			AMRAP:   set.AMRAP,
 		}
 
 		if set.Sets == 0 {
@@ -980,10 +649,8 @@ func (pg *ProgramGenerator) generateExercise(ex TemplateExercise, maxes AthleteM
 		}
 
 		// Рассчитываем вес
-		if set.Percent > 0 && oneRM > 0 {
-			genSet.WeightKg = pg.roundWeight(oneRM * set.Percent / 100)
-		} else if set.Weight > 0 {
-			genSet.WeightKg = pg.roundWeight(set.Weight)
+		if genSet.Percent > 0 && oneRM > 0 {
+			genSet.WeightKg = pg.roundWeight(oneRM * genSet.Percent / 100)
 		}
 
 		genEx.Sets = append(genEx.Sets, genSet)
@@ -994,17 +661,6 @@ func (pg *ProgramGenerator) generateExercise(ex TemplateExercise, maxes AthleteM
 		totalWeight += genSet.WeightKg * float64(repsInSet)
 	}
 
-	// Подсобка без процентов
-	if len(ex.SetsReps) > 0 {
-		for _, reps := range ex.SetsReps {
-			genEx.Sets = append(genEx.Sets, PLGeneratedSet{
-				Reps: reps,
-				Sets: 1,
-			})
-			totalReps += reps
-		}
-	}
-
 	genEx.TotalReps = totalReps
 	genEx.Tonnage = totalWeight / 1000 // в тоннах
 
@@ -1015,262 +671,6 @@ func (pg *ProgramGenerator) generateExercise(ex TemplateExercise, maxes AthleteM
 	return genEx
 }
 
-// generateWeekFromKMS генерирует неделю из формата КМС/МС
-func (pg *ProgramGenerator) generateWeekFromKMS(week TemplateWeek, maxes AthleteMaxes) PLGeneratedWeek {
-	genWeek := PLGeneratedWeek{
-		WeekNum: week.WeekNum,
-		Phase:   "Соревновательная подготовка",
-	}
-
-	// Формат с Workouts
-	if len(week.Workouts) > 0 {
-		for _, workout := range week.Workouts {
-			genWorkout := pg.generateWorkout(workout, maxes)
-			genWeek.Workouts = append(genWeek.Workouts, genWorkout)
-			genWeek.TotalKPS += genWorkout.TotalKPS
-			genWeek.Tonnage += genWorkout.Tonnage
-		}
-		return genWeek
-	}
-
-	// Формат с Days (Шейко КМС/МС с абсолютными весами)
-	if len(week.Days) > 0 {
-		for dayIdx, day := range week.Days {
-			genWorkout := PLGeneratedWorkout{
-				DayNum: dayIdx + 1,
-				Name:   fmt.Sprintf("Тренировка %d", dayIdx+1),
-			}
-
-			for _, ex := range day.Exercises {
-				genEx := pg.generateExerciseWithAbsoluteWeight(ex, maxes)
-				genWorkout.Exercises = append(genWorkout.Exercises, genEx)
-				genWorkout.TotalKPS += genEx.TotalReps
-				genWorkout.Tonnage += genEx.Tonnage
-			}
-
-			genWeek.Workouts = append(genWeek.Workouts, genWorkout)
-			genWeek.TotalKPS += genWorkout.TotalKPS
-			genWeek.Tonnage += genWorkout.Tonnage
-		}
-	}
-
-	return genWeek
-}
-
-// generateExerciseWithAbsoluteWeight генерирует упражнение с абсолютными весами
-// Пересчитывает веса пропорционально 1ПМ атлета
-func (pg *ProgramGenerator) generateExerciseWithAbsoluteWeight(ex TemplateExercise, maxes AthleteMaxes) PLGeneratedExercise {
-	genEx := PLGeneratedExercise{
-		Name: ex.Name,
-		Type: ex.Type,
-	}
-
-	// Определяем базовый 1ПМ из шаблона (по первому тяжёлому подходу)
-	var templateMax float64
-	for _, set := range ex.Sets {
-		if set.Weight > templateMax {
-			templateMax = set.Weight
-		}
-	}
-
-	// Определяем 1ПМ атлета для этого упражнения
-	athleteMax := pg.getMaxForExercise(ex.Name, maxes)
-
-	// Коэффициент пересчёта
-	ratio := 1.0
-	if templateMax > 0 && athleteMax > 0 {
-		// Предполагаем что максимальный вес в шаблоне ~90-95% от 1ПМ шаблона
-		estimatedTemplateRM := templateMax / 0.9
-		ratio = athleteMax / estimatedTemplateRM
-	}
-
-	var totalReps int
-	var totalWeight float64
-
-	for _, set := range ex.Sets {
-		sets := set.Sets
-		if sets == 0 {
-			sets = 1
-		}
-
-		// Пересчитываем вес
-		weight := pg.roundWeight(set.Weight * ratio)
-
-		// Рассчитываем процент от 1ПМ атлета
-		percent := 0.0
-		if athleteMax > 0 {
-			percent = weight / athleteMax * 100
-		}
-
-		genSet := PLGeneratedSet{
-			Percent:  percent,
-			Reps:     set.Reps,
-			Sets:     sets,
-			WeightKg: weight,
-		}
-
-		genEx.Sets = append(genEx.Sets, genSet)
-
-		repsInSet := set.Reps * sets
-		totalReps += repsInSet
-		totalWeight += weight * float64(repsInSet)
-	}
-
-	genEx.TotalReps = totalReps
-	genEx.Tonnage = totalWeight / 1000
-
-	if totalReps > 0 && athleteMax > 0 {
-		genEx.AvgPercent = (totalWeight / float64(totalReps)) / athleteMax * 100
-	}
-
-	return genEx
-}
-
-// generateWeekFromMicrocycle генерирует неделю из микроцикла Головинского
-func (pg *ProgramGenerator) generateWeekFromMicrocycle(micro TemplateMicrocycle, maxes AthleteMaxes) PLGeneratedWeek {
-	// Определяем номер микроцикла
-	microNum := micro.MicroNum
-	if microNum == 0 {
-		microNum = micro.MicrocycleNum
-	}
-
-	genWeek := PLGeneratedWeek{
-		Phase: fmt.Sprintf("Микроцикл %d", microNum),
-	}
-
-	// Поддержка нового формата с workouts
-	if len(micro.Workouts) > 0 {
-		for _, workout := range micro.Workouts {
-			genWorkout := PLGeneratedWorkout{
-				DayNum: workout.Day,
-				Name:   fmt.Sprintf("День %d", workout.Day),
-			}
-
-			for _, ex := range workout.Exercises {
-				genEx := pg.generateExerciseFromGolovinsky(ex, maxes)
-				// Пропускаем упражнения без сетов
-				if len(genEx.Sets) == 0 {
-					continue
-				}
-				// НЕ фильтруем здесь по isCompetitionLift — это делается в filterByLiftType
-				// чтобы присед мог остаться как подсобка для тяговика
-				genWorkout.Exercises = append(genWorkout.Exercises, genEx)
-				genWorkout.TotalKPS += genEx.TotalReps
-				genWorkout.Tonnage += genEx.Tonnage
-			}
-
-			// Пропускаем пустые тренировки
-			if len(genWorkout.Exercises) == 0 {
-				continue
-			}
-
-			genWeek.Workouts = append(genWeek.Workouts, genWorkout)
-			genWeek.TotalKPS += genWorkout.TotalKPS
-			genWeek.Tonnage += genWorkout.Tonnage
-		}
-		return genWeek
-	}
-
-	// Старый формат с Days
-	for dayIdx, day := range micro.Days {
-		genWorkout := PLGeneratedWorkout{
-			DayNum: dayIdx + 1,
-			Name:   fmt.Sprintf("День %d (%s)", dayIdx+1, day.Date),
-		}
-
-		for _, ex := range day.Exercises {
-			genEx := pg.generateExerciseFromGolovinsky(ex, maxes)
-			// Пропускаем упражнения без сетов
-			if len(genEx.Sets) == 0 {
-				continue
-			}
-			// НЕ фильтруем здесь — это делается в filterByLiftType
-			genWorkout.Exercises = append(genWorkout.Exercises, genEx)
-			genWorkout.TotalKPS += genEx.TotalReps
-			genWorkout.Tonnage += genEx.Tonnage
-		}
-
-		// Пропускаем пустые тренировки
-		if len(genWorkout.Exercises) == 0 {
-			continue
-		}
-
-		genWeek.Workouts = append(genWeek.Workouts, genWorkout)
-		genWeek.TotalKPS += genWorkout.TotalKPS
-		genWeek.Tonnage += genWorkout.Tonnage
-	}
-
-	return genWeek
-}
-
-// generateExerciseFromGolovinsky генерирует упражнение из формата Головинского
-func (pg *ProgramGenerator) generateExerciseFromGolovinsky(ex TemplateExercise, maxes AthleteMaxes) PLGeneratedExercise {
-	genEx := PLGeneratedExercise{
-		Name: ex.Name,
-		Type: "competition",
-	}
-
-	// Определяем 1ПМ из шаблона или по имени
-	var oneRM float64
-	if ex.Stats != nil && ex.Stats.PM > 0 {
-		// Есть 1ПМ в шаблоне — пересчитываем пропорционально
-		templatePM := ex.Stats.PM
-		athletePM := pg.getMaxForExercise(ex.Name, maxes)
-		if athletePM > 0 && templatePM > 0 {
-			ratio := athletePM / templatePM
-			oneRM = athletePM
-
-			for _, set := range ex.Sets {
-				newWeight := pg.roundWeight(set.Weight * ratio)
-				sets := set.Sets
-				if sets == 0 {
-					sets = 1
-				}
-				genSet := PLGeneratedSet{
-					Percent:  set.Percent,
-					Reps:     set.Reps,
-					Sets:     sets,
-					WeightKg: newWeight,
-				}
-				genEx.Sets = append(genEx.Sets, genSet)
-				genEx.TotalReps += set.Reps * sets
-				genEx.Tonnage += newWeight * float64(set.Reps*sets) / 1000
-			}
-		}
-	} else {
-		// Нет 1ПМ — используем проценты
-		oneRM = pg.getMaxForExercise(ex.Name, maxes)
-		for _, set := range ex.Sets {
-			// Проценты могут быть в формате 0.5 (50%) или 50
-			percent := set.Percent
-			if percent > 0 && percent < 1 {
-				percent = percent * 100 // Конвертируем 0.5 -> 50
-			}
-
-			weight := pg.roundWeight(oneRM * percent / 100)
-			sets := set.Sets
-			if sets == 0 {
-				sets = 1
-			}
-			genSet := PLGeneratedSet{
-				Percent:  percent,
-				Reps:     set.Reps,
-				Sets:     sets,
-				WeightKg: weight,
-			}
-			genEx.Sets = append(genEx.Sets, genSet)
-			genEx.TotalReps += set.Reps * sets
-			genEx.Tonnage += weight * float64(set.Reps*sets) / 1000
-		}
-	}
-
-	if genEx.TotalReps > 0 && oneRM > 0 {
-		genEx.AvgPercent = (genEx.Tonnage * 1000 / float64(genEx.TotalReps)) / oneRM * 100
-	}
-
-	return genEx
-}
-
 // ListTemplates возвращает список шаблонов
 func (pg *ProgramGenerator) ListTemplates() []string {
 	return pg.tm.ListTemplates()
@@ -1302,7 +702,7 @@ func (pg *ProgramGenerator) RecommendTemplate(level AthleteLevel, liftType LiftT
 
 	case LevelMS, LevelMSMC:
 		// Для МС/МСМК - Муравьёв или индивидуальный подбор
-		return "Цикл Муравьёва (16 недель)"
+		return "Цикл Муравьёва"
 
 	default:
 		return "Шейко 12 недель (разрядники)"
@@ -1370,8 +770,8 @@ func (pg *ProgramGenerator) estimateLevel(maxes AthleteMaxes) AthleteLevel {
 // Вспомогательные функции
 
 func normalizeExerciseName(name string) string {
-	// Приводим к нижнему регистру для сравнения
-	return strings.ToLower(name)
+	// Приводим к нижнему регистру для сравнения, «лёжа» и «лежа» — одно и то же
+	return strings.ReplaceAll(strings.ToLower(name), "ё", "е")
 }
 
 func plContains(s, substr string) bool {
@@ -1583,7 +983,7 @@ func (tm *TemplateManager) GetTemplatesForLiftType(liftType LiftType) []*Powerli
 	var result []*PowerliftingTemplate
 	for _, t := range tm.templates {
 		// Проверяем совместимость шаблона с типом
-		if t.Type == string(liftType) || t.Type == "троеборье" || t.Type == "" {
+		if t.Type == string(liftType) || t.Type == string(LiftTypeFull) {
 			result = append(result, t)
 		}
 	}
@@ -1770,7 +1170,7 @@ func (pg *ProgramGenerator) ValidateProgram(program *PLGeneratedProgram) PLValid
 	for name := range result.Stats.KPSByExercise {
 		normalized := normalizeExerciseName(name)
 		// Жим лёжа (включая вариации с бруском)
-		if (plContains(normalized, "жим лёжа") || plContains(normalized, "жим лежа") ||
+		if (plContains(normalized, "жим лежа") ||
 			(plContains(normalized, "жим") && !plContains(normalized, "стоя") && !plContains(normalized, "сидя"))) {
 			hasCompetition = true
 			break
@@ -1805,6 +1205,9 @@ func FormatPLProgram(program *PLGeneratedProgram) string {
 	sb.WriteString(fmt.Sprintf("• Присед: %.1f кг\n", program.AthleteMaxes.Squat))
 	sb.WriteString(fmt.Sprintf("• Жим лёжа: %.1f кг\n", program.AthleteMaxes.Bench))
 	sb.WriteString(fmt.Sprintf("• Тяга: %.1f кг\n\n", program.AthleteMaxes.Deadlift))
+	if program.TrainingMax > 0 {
+		sb.WriteString(fmt.Sprintf("Тренировочный максимум: %.0f%% от 1ПМ, проценты ниже — от 1ПМ\n\n", program.TrainingMax))
+	}
 
 	for _, week := range program.Weeks {
 		sb.WriteString("┌─────────────────────────────────\n")
@@ -1820,10 +1223,10 @@ func FormatPLProgram(program *PLGeneratedProgram) string {
 
 				for _, set := range ex.Sets {
 					if set.WeightKg > 0 {
-						sb.WriteString(fmt.Sprintf("   %.0f кг × %d повт × %d подх (%.0f%%)\n",
-							set.WeightKg, set.Reps, set.Sets, set.Percent))
+						sb.WriteString(fmt.Sprintf("   %.0f кг × %s повт × %d подх (%.0f%%)\n",
+							set.WeightKg, set.RepsLabel(), set.Sets, set.Percent))
 					} else {
-						sb.WriteString(fmt.Sprintf("   %d повт × %d подх\n", set.Reps, set.Sets))
+						sb.WriteString(fmt.Sprintf("   %s повт × %d подх\n", set.RepsLabel(), set.Sets))
 					}
 				}
 			}
@@ -2076,7 +1479,7 @@ func FormatWeekCompact(week PLGeneratedWeek) string {
 					sb.WriteString(", ")
 				}
 				if set.WeightKg > 0 {
-					sb.WriteString(fmt.Sprintf("%.0f×%d×%d", set.WeightKg, set.Reps, set.Sets))
+					sb.WriteString(fmt.Sprintf("%.0f×%s×%d", set.WeightKg, set.RepsLabel(), set.Sets))
 				}
 			}
 			sb.WriteString("\n")
@@ -0,0 +1,151 @@
+package ai
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// go test ./clients/ai -run TestTemplatesGolden -update — перезаписать эталон после
+// намеренного изменения шаблона
+var updateGolden = flag.Bool("update", false, "перезаписать testdata/template_programs.golden.json")
+
+// goldenMaxes — 1ПМ, для которых снят эталон
+var goldenMaxes = AthleteMaxes{Squat: 200, Bench: 140, Deadlift: 230, HipThrust: 180}
+
+const goldenFile = "template_programs.golden.json"
+
+// goldenProgram — программа в сравниваемом виде: недели → тренировки → строки упражнений
+// «Присед: 127.5×3, 152.5×3×2». Номера и названия дней, пустые дни отдыха и разбиение
+// одинаковых подходов на группы не сравниваются — это оформление, а не нагрузка
+type goldenProgram [][][]string
+
+// TestTemplatesGolden сравнивает программы по встроенным шаблонам с эталоном.
+// Любое изменение упражнений или весов — в шаблонах, генераторе или резолвере 1ПМ —
+// должно быть намеренным и видно в диффе эталона
+func TestTemplatesGolden(t *testing.T) {
+	pg, err := NewProgramGenerator()
+	if err != nil {
+		t.Fatalf("NewProgramGenerator: %v", err)
+	}
+
+	got := make(map[string]goldenProgram)
+	for _, name := range pg.ListTemplates() {
+		program, err := pg.GenerateFromTemplate(name, goldenMaxes)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		got[name] = goldenView(program)
+	}
+
+	path := filepath.Join("testdata", goldenFile)
+	if *updateGolden {
+		data, err := json.MarshalIndent(got, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("эталон: %v (go test -run TestTemplatesGolden -update)", err)
+	}
+	var want map[string]goldenProgram
+	if err := json.Unmarshal(data, &want); err != nil {
+		t.Fatalf("эталон: %v", err)
+	}
+
+	for name, program := range got {
+		if _, ok := want[name]; !ok {
+			t.Errorf("%s: нет в эталоне", name)
+			continue
+		}
+		if diff := goldenDiff(want[name], program); diff != "" {
+			t.Errorf("%s: %s", name, diff)
+		}
+	}
+	for name := range want {
+		if _, ok := got[name]; !ok {
+			t.Errorf("%s: шаблон из эталона не загружен", name)
+		}
+	}
+}
+
+// goldenView переводит программу в сравниваемый вид
+func goldenView(program *PLGeneratedProgram) goldenProgram {
+	view := make(goldenProgram, 0, len(program.Weeks))
+	for _, week := range program.Weeks {
+		workouts := make([][]string, 0, len(week.Workouts))
+		for _, workout := range week.Workouts {
+			if len(workout.Exercises) == 0 {
+				continue
+			}
+			var lines []string
+			for _, ex := range workout.Exercises {
+				lines = append(lines, ex.Name+": "+goldenSets(ex.Sets))
+			}
+			workouts = append(workouts, lines)
+		}
+		view = append(view, workouts)
+	}
+	return view
+}
+
+// goldenSets — подходы «вес×повторы×подходы»; одинаковые подходы подряд объединяются
+func goldenSets(sets []PLGeneratedSet) string {
+	type group struct {
+		label string
+		count int
+	}
+	var groups []group
+	for _, s := range sets {
+		weight := "—"
+		if s.WeightKg > 0 {
+			weight = strconv.FormatFloat(s.WeightKg, 'f', -1, 64)
+		}
+		label := weight + "×" + s.RepsLabel()
+		if n := len(groups); n > 0 && groups[n-1].label == label {
+			groups[n-1].count += s.Sets
+			continue
+		}
+		groups = append(groups, group{label, s.Sets})
+	}
+
+	parts := make([]string, len(groups))
+	for i, g := range groups {
+		parts[i] = g.label
+		if g.count > 1 {
+			parts[i] += fmt.Sprintf("×%d", g.count)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// goldenDiff описывает первое расхождение с эталоном
+func goldenDiff(want, got goldenProgram) string {
+	if len(want) != len(got) {
+		return fmt.Sprintf("недель %d, в эталоне %d", len(got), len(want))
+	}
+	for w := range want {
+		if len(want[w]) != len(got[w]) {
+			return fmt.Sprintf("неделя %d: тренировок %d, в эталоне %d", w+1, len(got[w]), len(want[w]))
+		}
+		for d := range want[w] {
+			if !reflect.DeepEqual(want[w][d], got[w][d]) {
+				return fmt.Sprintf("неделя %d, тренировка %d:\n  получено %q\n  эталон   %q",
+					w+1, d+1, got[w][d], want[w][d])
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,219 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Допустимые значения в шаблонах программ
+const (
+	minTemplatePercent     = 20
+	maxTemplatePercent     = 110
+	maxVariationPercent    = 100
+	maxTemplateReps        = 30
+	maxTemplateSets        = 20
+	minTemplateTrainingMax = 50
+)
+
+// lintMaxes — 1ПМ, по которым проверяется, что резолвер находит максимум для упражнения
+var lintMaxes = AthleteMaxes{Squat: 100, Bench: 100, Deadlift: 100, HipThrust: 100}
+
+// TemplateIssue ошибка шаблона с путём к полю, например plan[0].workouts[1].exercises[2].sets[0].percent
+type TemplateIssue struct {
+	Path    string
+	Message string
+}
+
+func (e TemplateIssue) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// TemplateIssues список ошибок шаблона
+type TemplateIssues []TemplateIssue
+
+func (e TemplateIssues) Error() string {
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// templateLinter накапливает ошибки шаблона
+type templateLinter struct {
+	issues TemplateIssues
+}
+
+func (l *templateLinter) add(path, format string, args ...interface{}) {
+	l.issues = append(l.issues, TemplateIssue{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// LintTemplate проверяет шаблон: шапку, число недель, проценты, повторения и то,
+// что резолвер упражнений находит 1ПМ для каждого упражнения с процентами
+func LintTemplate(t *PowerliftingTemplate) TemplateIssues {
+	l := &templateLinter{}
+
+	if t.Schema != TemplateSchemaVersion {
+		l.add("schema", "версия %d не поддерживается (ожидается %d)", t.Schema, TemplateSchemaVersion)
+	}
+	if strings.TrimSpace(t.Name) == "" {
+		l.add("name", "обязательное поле")
+	}
+	if strings.TrimSpace(t.Author) == "" {
+		l.add("author", "обязательное поле")
+	}
+	if len(t.Level) == 0 {
+		l.add("level", "укажите хотя бы один уровень")
+	}
+	for i, level := range t.Level {
+		if !knownLevel(AthleteLevel(level)) {
+			l.add(fmt.Sprintf("level[%d]", i), "неизвестный уровень %q", level)
+		}
+	}
+	switch LiftType(t.Type) {
+	case LiftTypeFull, LiftTypeBench, LiftTypeSquat, LiftTypeDeadlift, LiftTypeHipThrust:
+	default:
+		l.add("type", "неизвестный тип %q (powerlifting, bench, squat, deadlift, hip_thrust)", t.Type)
+	}
+	if t.DaysPerWeek < 1 || t.DaysPerWeek > 7 {
+		l.add("days_per_week", "должно быть от 1 до 7, получено %d", t.DaysPerWeek)
+	}
+	if t.TrainingMax != 0 && (t.TrainingMax < minTemplateTrainingMax || t.TrainingMax > 100) {
+		l.add("training_max", "должно быть от %d до 100%% от 1ПМ, получено %g", minTemplateTrainingMax, t.TrainingMax)
+	}
+
+	if t.Weeks < 1 {
+		l.add("weeks", "должно быть положительным, получено %d", t.Weeks)
+	} else if len(t.Plan) != t.Weeks {
+		l.add("plan", "заявлено недель: %d, расписано: %d", t.Weeks, len(t.Plan))
+	}
+	for i, w := range t.Plan {
+		path := fmt.Sprintf("plan[%d]", i)
+		if w.Week != i+1 {
+			l.add(path+".week", "ожидается неделя %d, получено %d", i+1, w.Week)
+		}
+		l.lintWeek(path, &w, t.DaysPerWeek)
+	}
+
+	return l.issues
+}
+
+func (l *templateLinter) lintWeek(path string, w *TemplateWeek, daysPerWeek int) {
+	if len(w.Workouts) == 0 {
+		l.add(path+".workouts", "неделя должна содержать хотя бы одну тренировку")
+	}
+	if daysPerWeek > 0 && len(w.Workouts) > daysPerWeek {
+		l.add(path+".workouts", "тренировок %d при days_per_week %d", len(w.Workouts), daysPerWeek)
+	}
+	seenDays := make(map[int]bool)
+	for i, wo := range w.Workouts {
+		woPath := fmt.Sprintf("%s.workouts[%d]", path, i)
+		if wo.Day < 1 || wo.Day > 7 {
+			l.add(woPath+".day", "номер дня должен быть от 1 до 7, получено %d", wo.Day)
+		} else if seenDays[wo.Day] {
+			l.add(woPath+".day", "день %d повторяется", wo.Day)
+		}
+		seenDays[wo.Day] = true
+
+		if len(wo.Exercises) == 0 {
+			l.add(woPath+".exercises", "тренировка должна содержать хотя бы одно упражнение")
+		}
+		for j, ex := range wo.Exercises {
+			l.lintExercise(fmt.Sprintf("%s.exercises[%d]", woPath, j), &ex)
+		}
+	}
+}
+
+func (l *templateLinter) lintExercise(path string, ex *TemplateExercise) {
+	if strings.TrimSpace(ex.Name) == "" {
+		l.add(path+".name", "обязательное поле")
+	}
+	switch ex.Type {
+	case "competition":
+		if classifyExercise(ex.Name) == "" {
+			l.add(path+".type", "«%s» не распознано как присед, жим лёжа, тяга или ягодичный мост", ex.Name)
+		}
+	case "accessory":
+		if isCompetitionLiftName(ex.Name) {
+			l.add(path+".type", "«%s» — соревновательное движение, ожидается competition", ex.Name)
+		}
+	default:
+		l.add(path+".type", "ожидается competition или accessory, получено %q", ex.Type)
+	}
+	if len(ex.Sets) == 0 {
+		l.add(path+".sets", "упражнение должно содержать хотя бы одну группу подходов")
+	}
+
+	// Вариация считается от 1ПМ соревновательного движения (или его доли),
+	// поэтому больше 100% — почти всегда ошибка пересчёта
+	variation := !isCompetitionLiftName(ex.Name) && getMaxForExercise(ex.Name, lintMaxes) > 0
+
+	hasPercent := false
+	for i, s := range ex.Sets {
+		setPath := fmt.Sprintf("%s.sets[%d]", path, i)
+		if s.Sets < 1 || s.Sets > maxTemplateSets {
+			l.add(setPath+".sets", "должно быть от 1 до %d, получено %d", maxTemplateSets, s.Sets)
+		}
+		if s.Reps < 1 || s.Reps > maxTemplateReps {
+			l.add(setPath+".reps", "должно быть от 1 до %d, получено %d", maxTemplateReps, s.Reps)
+		}
+		switch {
+		case s.Percent == 0 && ex.Type == "competition":
+			l.add(setPath+".percent", "у соревновательного упражнения обязателен процент")
+		case s.Percent != 0 && (s.Percent < minTemplatePercent || s.Percent > maxTemplatePercent):
+			l.add(setPath+".percent", "должно быть от %d до %d, получено %g", minTemplatePercent, maxTemplatePercent, s.Percent)
+		case variation && s.Percent > maxVariationPercent:
+			l.add(setPath+".percent", "у вариации соревновательного движения не больше %d%%, получено %g", maxVariationPercent, s.Percent)
+		}
+		hasPercent = hasPercent || s.Percent > 0
+	}
+	if hasPercent && ex.Name != "" && getMaxForExercise(ex.Name, lintMaxes) == 0 {
+		l.add(path+".name", "1ПМ для «%s» не определяется по названию — проценты не к чему применить", ex.Name)
+	}
+}
+
+// competitionLiftNames — названия соревновательных движений после normalizeExerciseName
+var competitionLiftNames = map[string]bool{
+	"присед":                    true,
+	"приседания":                true,
+	"жим лежа":                  true,
+	"становая тяга":             true,
+	"тяга становая":             true,
+	"ягодичный мост":            true,
+	"ягодичный мост со штангой": true,
+}
+
+// isCompetitionLiftName проверяет, что упражнение — само соревновательное движение, а не вариация.
+// Пояснение после тире («Ягодичный мост — 1 подход») не учитывается
+func isCompetitionLiftName(name string) bool {
+	n := normalizeExerciseName(name)
+	if i := strings.Index(n, " — "); i >= 0 {
+		n = n[:i]
+	}
+	return competitionLiftNames[strings.TrimSpace(n)]
+}
+
+// knownLevel проверяет, что уровень входит в AthleteLevel
+func knownLevel(level AthleteLevel) bool {
+	switch level {
+	case LevelNovice, Level3Rank, Level2Rank, Level1Rank, LevelKMS, LevelMS, LevelMSMC:
+		return true
+	}
+	return false
+}
+
+// LintTemplates проверяет все загруженные шаблоны; в ответе — только шаблоны с ошибками
+func (tm *TemplateManager) LintTemplates() map[string]TemplateIssues {
+	result := make(map[string]TemplateIssues)
+	for name, t := range tm.templates {
+		if issues := LintTemplate(t); len(issues) > 0 {
+			result[name] = issues
+		}
+	}
+	return result
+}
+
+// LintTemplates проверяет все встроенные шаблоны
+func (pg *ProgramGenerator) LintTemplates() map[string]TemplateIssues {
+	return pg.tm.LintTemplates()
+}
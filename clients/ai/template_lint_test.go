@@ -0,0 +1,130 @@
+package ai
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEmbeddedTemplatesLint(t *testing.T) {
+	tm, err := NewTemplateManager()
+	if err != nil {
+		t.Fatalf("NewTemplateManager: %v", err)
+	}
+	for name, issues := range tm.LintTemplates() {
+		t.Errorf("%s:\n%v", name, issues)
+	}
+
+	pg := &ProgramGenerator{tm: tm, roundingKg: 2.5}
+	for _, level := range []AthleteLevel{LevelNovice, Level3Rank, Level2Rank, Level1Rank, LevelKMS, LevelMS, LevelMSMC} {
+		for _, lt := range []LiftType{LiftTypeFull, LiftTypeDeadlift} {
+			if name := pg.RecommendTemplate(level, lt); !hasTemplate(tm, name) {
+				t.Errorf("RecommendTemplate(%s, %s) = %q: шаблона нет", level, lt, name)
+			}
+		}
+	}
+}
+
+func hasTemplate(tm *TemplateManager, name string) bool {
+	_, ok := tm.GetTemplate(name)
+	return ok
+}
+
+func TestLintTemplate(t *testing.T) {
+	tpl := &PowerliftingTemplate{
+		Schema: TemplateSchemaVersion, Name: "Тест", Author: "Автор",
+		Level: []string{"КМС", "pro"}, Type: "powerlifting", Weeks: 2, DaysPerWeek: 2,
+		Plan: []TemplateWeek{{
+			Week: 1,
+			Workouts: []TemplateWorkout{
+				{Day: 1, Name: "A", Exercises: []TemplateExercise{
+					{Name: "Жим лёжа", Type: "competition", Sets: []TemplateExerciseSet{{Percent: 130, Reps: 3, Sets: 1}, {Reps: 5, Sets: 1}}},
+					{Name: "Махи руками", Type: "accessory", Sets: []TemplateExerciseSet{{Percent: 50, Reps: 10, Sets: 3}}},
+					{Name: "Присед", Type: "accessory", Sets: []TemplateExerciseSet{{Percent: 70, Reps: 5, Sets: 3}}},
+					{Name: "Наклонный жим", Type: "accessory", Sets: []TemplateExerciseSet{{Percent: 102.9, Reps: 3, Sets: 1}}},
+					{Name: "Ягодичный мост — 3 подход", Type: "competition", Sets: []TemplateExerciseSet{{Percent: 105, Reps: 1, Sets: 1}}},
+				}},
+				{Day: 1, Name: "B", Exercises: []TemplateExercise{
+					{Name: "Планка", Type: "competition", Sets: []TemplateExerciseSet{{Reps: 0, Sets: 3}}},
+				}},
+			},
+		}},
+	}
+
+	var paths []string
+	for _, issue := range LintTemplate(tpl) {
+		paths = append(paths, issue.Path)
+	}
+	want := []string{
+		"level[1]",
+		"plan",
+		"plan[0].workouts[0].exercises[0].sets[0].percent",
+		"plan[0].workouts[0].exercises[0].sets[1].percent",
+		"plan[0].workouts[0].exercises[1].name",
+		"plan[0].workouts[0].exercises[2].type",
+		"plan[0].workouts[0].exercises[3].sets[0].percent",
+		"plan[0].workouts[1].day",
+		"plan[0].workouts[1].exercises[0].type",
+		"plan[0].workouts[1].exercises[0].sets[0].reps",
+		"plan[0].workouts[1].exercises[0].sets[0].percent",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("пути ошибок:\n%v\nwant\n%v", paths, want)
+	}
+}
+
+func TestParseTemplateStrict(t *testing.T) {
+	if _, err := ParseTemplate([]byte(`{"schema": 1, "name": "X", "weeks_data": []}`)); err == nil {
+		t.Error("неизвестное поле weeks_data должно быть ошибкой")
+	}
+	if _, err := ParseTemplate([]byte(`{"name": "X"}`)); err == nil {
+		t.Error("шаблон без schema должен быть ошибкой")
+	}
+}
+
+func TestGenerateTrainingMaxAMRAP(t *testing.T) {
+	pg, err := NewProgramGenerator()
+	if err != nil {
+		t.Fatalf("NewProgramGenerator: %v", err)
+	}
+	program, err := pg.GenerateFromTemplate("5/3/1 Вендлера (Boring But Big)", AthleteMaxes{Squat: 140, Bench: 100, Deadlift: 180})
+	if err != nil {
+		t.Fatalf("GenerateFromTemplate: %v", err)
+	}
+	if len(program.Weeks) != 4 || program.TrainingMax != 90 {
+		t.Fatalf("недель %d, ТМ %g", len(program.Weeks), program.TrainingMax)
+	}
+
+	var bench *PLGeneratedExercise
+	for i, w := range program.Weeks[0].Workouts {
+		if w.Name == "Жим лёжа" {
+			bench = &program.Weeks[0].Workouts[i].Exercises[0]
+		}
+	}
+	if bench == nil {
+		t.Fatal("нет тренировки жима")
+	}
+	// 85% ТМ = 76.5% 1ПМ → 76.5 кг, округление до 2.5
+	last := bench.Sets[len(bench.Sets)-1]
+	if last.Percent != 76.5 || last.WeightKg != 77.5 || !last.AMRAP || last.RepsLabel() != "5+" {
+		t.Errorf("последний подход = %+v", last)
+	}
+	if first := bench.Sets[0]; first.AMRAP {
+		t.Errorf("разминочный подход помечен AMRAP: %+v", first)
+	}
+}
+
+func TestGetMaxForExerciseYo(t *testing.T) {
+	maxes := AthleteMaxes{Squat: 200, Bench: 140, Deadlift: 230}
+	tests := map[string]float64{
+		"Жим лёжа": 140,
+		"Жим лежа": 140,
+		"Жим стоя": 140 * 0.65,
+		"Присед":   200,
+		"Разгибания с гантелью из-за головы": 140 * 0.4,
+	}
+	for name, want := range tests {
+		if got := getMaxForExercise(name, maxes); got != want {
+			t.Errorf("getMaxForExercise(%q) = %g, want %g", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package knowledge
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ============================================
+// АНАЛИЗАТОР ТЕКСТА
+// Токены → нижний регистр → стоп-слова → основа слова
+// ============================================
+
+// AnalyzerVersion — версия токенизации и стемминга. Записывается в индекс:
+// если анализатор изменился, BM25 пересчитывается при загрузке
+const AnalyzerVersion = "ru-en-1"
+
+// stopWords — служебные слова, которые не участвуют в поиске
+var stopWords = makeSet(
+	// русские
+	"и", "в", "во", "не", "что", "он", "на", "я", "с", "со", "как", "а", "то", "все", "она", "так",
+	"его", "но", "да", "ты", "к", "у", "же", "вы", "за", "бы", "по", "только", "ее", "её", "мне",
+	"было", "вот", "от", "меня", "еще", "ещё", "нет", "о", "из", "ему", "теперь", "когда", "даже",
+	"ну", "ли", "если", "уже", "или", "ни", "быть", "был", "него", "до", "вас", "нибудь", "опять",
+	"уж", "вам", "ведь", "там", "потом", "себя", "ничего", "ей", "может", "они", "тут", "где",
+	"есть", "надо", "ней", "для", "мы", "тебя", "их", "чем", "была", "сам", "чтоб", "без", "будто",
+	"чего", "раз", "тоже", "себе", "под", "будет", "ж", "тогда", "кто", "этот", "того", "потому",
+	"этого", "какой", "совсем", "ним", "здесь", "этом", "один", "почти", "мой", "тем", "чтобы",
+	"нее", "были", "куда", "зачем", "всех", "никогда", "можно", "при", "наконец", "два", "об",
+	"другой", "хоть", "после", "над", "больше", "тот", "через", "эти", "нас", "про", "всего",
+	"них", "какая", "много", "разве", "три", "эту", "моя", "впрочем", "хорошо", "свою", "этой",
+	"перед", "иногда", "лучше", "чуть", "том", "нельзя", "такой", "им", "более", "всегда",
+	"конечно", "всю", "между", "это", "также", "каждый",
+	// английские
+	"a", "an", "the", "and", "or", "but", "if", "of", "at", "by", "for", "with", "about", "to",
+	"from", "in", "on", "is", "are", "was", "were", "be", "been", "being", "it", "its", "this",
+	"that", "these", "those", "as", "do", "does", "did", "not", "no", "so", "than", "too", "very",
+	"can", "will", "just", "should", "now", "you", "your", "we", "our", "they", "their", "he",
+	"she", "his", "her", "them", "i", "me", "my", "there", "then", "which", "who", "what", "when",
+	"how", "all", "any", "each", "more", "most", "other", "some", "such", "only", "own", "same",
+)
+
+func makeSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// Tokenize разбивает текст на термы для поиска: слова в нижнем регистре
+// без стоп-слов, приведённые к основе. Числа сохраняются («5х5», «80%» → «5х5», «80»)
+func Tokenize(text string) []string {
+	var terms []string
+	for _, word := range splitWords(text) {
+		if term := normalizeTerm(word); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// splitWords разбивает текст на слова из букв и цифр
+func splitWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// normalizeTerm приводит слово к терму; пустая строка — слово не индексируется
+func normalizeTerm(word string) string {
+	w := strings.ReplaceAll(strings.ToLower(word), "ё", "е")
+	if stopWords[w] {
+		return ""
+	}
+	runes := []rune(w)
+	if len(runes) < 2 && !unicode.IsDigit(runes[0]) {
+		return ""
+	}
+	switch {
+	case hasCyrillic(w):
+		return stemRussian(w)
+	case isASCIIWord(w):
+		return stemEnglish(w)
+	default:
+		return w
+	}
+}
+
+func hasCyrillic(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Cyrillic, r) {
+			return true
+		}
+	}
+	return false
+}
+
+func isASCIIWord(s string) bool {
+	for _, r := range s {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
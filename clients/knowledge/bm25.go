@@ -0,0 +1,111 @@
+package knowledge
+
+import (
+	"math"
+	"sort"
+)
+
+// ============================================
+// BM25 - ранжирование по ключевым словам
+// score = Σ idf(t) · tf·(k1+1) / (tf + k1·(1 − b + b·|d|/avgdl))
+// ============================================
+
+// Параметры BM25 (значения по умолчанию Lucene/Elasticsearch)
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Posting — вхождение терма в документ: номер документа в индексе и частота
+type Posting [2]int
+
+// BM25Index — обратный индекс: терм → документы, где он встречается
+type BM25Index struct {
+	Postings map[string][]Posting `json:"postings"`
+	// DocLengths — число термов в каждом документе, в порядке Documents
+	DocLengths []int `json:"doc_lengths"`
+}
+
+// NewBM25Index строит индекс по текстам документов
+func NewBM25Index(docs []Document) *BM25Index {
+	idx := &BM25Index{
+		Postings:   make(map[string][]Posting),
+		DocLengths: make([]int, len(docs)),
+	}
+	for i, doc := range docs {
+		terms := Tokenize(doc.Content)
+		idx.DocLengths[i] = len(terms)
+
+		tf := make(map[string]int, len(terms))
+		for _, t := range terms {
+			tf[t]++
+		}
+		for t, n := range tf {
+			idx.Postings[t] = append(idx.Postings[t], Posting{i, n})
+		}
+	}
+	return idx
+}
+
+// scored — документ с оценкой
+type scored struct {
+	doc   int
+	score float64
+}
+
+// Search возвращает не больше topK документов по убыванию оценки BM25
+func (idx *BM25Index) Search(query string, topK int) []scored {
+	n := len(idx.DocLengths)
+	if n == 0 || topK <= 0 {
+		return nil
+	}
+	var total int
+	for _, l := range idx.DocLengths {
+		total += l
+	}
+	avgLen := float64(total) / float64(n)
+	if avgLen == 0 {
+		return nil
+	}
+
+	// Повтор слова в запросе не удваивает его вес
+	seen := make(map[string]bool)
+	scores := make(map[int]float64)
+	for _, term := range Tokenize(query) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		postings := idx.Postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+		df := float64(len(postings))
+		idf := math.Log(1 + (float64(n)-df+0.5)/(df+0.5))
+		for _, p := range postings {
+			tf := float64(p[1])
+			norm := 1 - bm25B + bm25B*float64(idx.DocLengths[p[0]])/avgLen
+			scores[p[0]] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+	}
+
+	results := make([]scored, 0, len(scores))
+	for doc, score := range scores {
+		results = append(results, scored{doc, score})
+	}
+	sortScored(results)
+	if len(results) > topK {
+		results = results[:topK]
+	}
+	return results
+}
+
+// sortScored упорядочивает по убыванию оценки, при равенстве — по номеру документа
+func sortScored(results []scored) {
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		return results[i].doc < results[j].doc
+	})
+}
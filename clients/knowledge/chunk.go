@@ -0,0 +1,158 @@
+package knowledge
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ============================================
+// НАРЕЗКА ТЕКСТА НА ЧАНКИ С ПЕРЕКРЫТИЕМ
+// Границы чанков — по концам предложений; соседние чанки
+// повторяют последние предложения предыдущего
+// ============================================
+
+// Размеры чанков по умолчанию, в словах
+const (
+	DefaultChunkWords   = 220
+	DefaultOverlapWords = 40
+)
+
+// sentence — предложение и страница, на которой оно начинается
+type sentence struct {
+	text  string
+	words int
+	page  int
+}
+
+// Chunk — фрагмент документа для индекса
+type Chunk struct {
+	Text    string
+	Page    int    // страница начала фрагмента; 0 — у документа нет страниц
+	Section string // заголовок раздела Markdown
+}
+
+// Section — часть документа: страница PDF или раздел Markdown
+type Section struct {
+	Title string // заголовок раздела; пусто у страниц PDF и текста
+	Page  int
+	Text  string
+}
+
+// ChunkSections нарезает разделы на фрагменты примерно по size слов, соседние
+// фрагменты перекрываются на overlap слов. Фрагмент не пересекает границу
+// раздела с заголовком, а страницы PDF склеиваются: абзац часто переходит
+// на следующую страницу
+func ChunkSections(sections []Section, size, overlap int) []Chunk {
+	if size <= 0 {
+		size = DefaultChunkWords
+	}
+	if overlap < 0 || overlap >= size {
+		overlap = 0
+	}
+
+	var chunks []Chunk
+	var run []sentence
+	var title string
+	flush := func() {
+		for _, c := range packSentences(run, size, overlap) {
+			c.Section = title
+			chunks = append(chunks, c)
+		}
+		run = nil
+	}
+	for _, s := range sections {
+		if s.Title != "" {
+			flush()
+			title = s.Title
+			run = append(run, sentence{text: s.Title + ".", words: len(strings.Fields(s.Title)), page: s.Page})
+		}
+		run = append(run, splitSentences(s.Text, s.Page, size)...)
+	}
+	flush()
+	return chunks
+}
+
+// packSentences собирает предложения во фрагменты
+func packSentences(sentences []sentence, size, overlap int) []Chunk {
+	var chunks []Chunk
+	for start := 0; start < len(sentences); {
+		end, words := start, 0
+		for end < len(sentences) && (words == 0 || words+sentences[end].words <= size) {
+			words += sentences[end].words
+			end++
+		}
+
+		parts := make([]string, 0, end-start)
+		for _, s := range sentences[start:end] {
+			parts = append(parts, s.text)
+		}
+		chunks = append(chunks, Chunk{Text: strings.Join(parts, " "), Page: sentences[start].page})
+		if end == len(sentences) {
+			break
+		}
+
+		// Следующий фрагмент начинается с последних предложений этого
+		next, kept := end, 0
+		for next > start+1 && kept+sentences[next-1].words <= overlap {
+			next--
+			kept += sentences[next].words
+		}
+		start = next
+	}
+	return chunks
+}
+
+// splitSentences разбивает текст на предложения; предложения длиннее
+// maxWords режутся по словам
+func splitSentences(text string, page, maxWords int) []sentence {
+	var out []sentence
+	add := func(s string) {
+		words := strings.Fields(s)
+		for len(words) > maxWords {
+			out = append(out, sentence{text: strings.Join(words[:maxWords], " "), words: maxWords, page: page})
+			words = words[maxWords:]
+		}
+		if len(words) > 0 {
+			out = append(out, sentence{text: strings.Join(words, " "), words: len(words), page: page})
+		}
+	}
+
+	runes := []rune(text)
+	begin := 0
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		paragraph := r == '\n' && i+1 < len(runes) && runes[i+1] == '\n'
+		end := (r == '.' || r == '!' || r == '?' || r == '…') &&
+			(i+1 == len(runes) || unicode.IsSpace(runes[i+1])) && !isAbbreviation(runes[begin:i])
+		if paragraph || end {
+			add(string(runes[begin : i+1]))
+			begin = i + 1
+		}
+	}
+	add(string(runes[begin:]))
+	return out
+}
+
+// abbreviations — сокращения, после которых точка не заканчивает предложение
+var abbreviations = makeSet("т", "е", "д", "п", "см", "рис", "напр", "др", "пр", "гл", "стр", "табл", "мин", "сек",
+	"e.g", "i.e", "etc", "fig", "vs", "approx", "cf")
+
+// isAbbreviation — точка после сокращения или инициала: «т.е.», «напр.», «рис. 2», «А. С.»
+func isAbbreviation(before []rune) bool {
+	i := len(before)
+	for i > 0 && !unicode.IsSpace(before[i-1]) {
+		i--
+	}
+	word := strings.TrimLeft(string(before[i:]), "(«\"'")
+	if word == "" {
+		return false
+	}
+	if r := []rune(word); len(r) == 1 && unicode.IsUpper(r[0]) {
+		return true
+	}
+	// «т.е» → последняя часть «е»
+	if dot := strings.LastIndex(word, "."); dot >= 0 && !abbreviations[strings.ToLower(word)] {
+		word = word[dot+1:]
+	}
+	return abbreviations[strings.ToLower(word)]
+}
@@ -0,0 +1,127 @@
+package knowledge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ============================================
+// ЭМБЕДДИНГИ - локальный сервер (Ollama, llama.cpp, LM Studio)
+// ============================================
+
+// DefaultEmbedURL — эндпоинт эмбеддингов Ollama по умолчанию
+const DefaultEmbedURL = "http://localhost:11434/api/embeddings"
+
+// Embedder вычисляет вектор текста
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float64, error)
+}
+
+// HTTPEmbedder - клиент эндпоинта эмбеддингов.
+// URL, оканчивающийся на /api/embeddings, — старый API Ollama ({"prompt"} → {"embedding"});
+// остальные — OpenAI-совместимый формат ({"input"} → {"data":[{"embedding"}]}),
+// который понимают Ollama (/v1/embeddings, /api/embed), llama.cpp и LM Studio
+type HTTPEmbedder struct {
+	url        string
+	model      string
+	httpClient *http.Client
+}
+
+// NewHTTPEmbedder создаёт клиент эндпоинта url для модели model
+func NewHTTPEmbedder(url, model string) *HTTPEmbedder {
+	if url == "" {
+		url = DefaultEmbedURL
+	}
+	return &HTTPEmbedder{
+		url:   url,
+		model: model,
+		httpClient: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+	}
+}
+
+// URL возвращает адрес эндпоинта
+func (e *HTTPEmbedder) URL() string {
+	return e.url
+}
+
+// Model возвращает имя модели
+func (e *HTTPEmbedder) Model() string {
+	return e.model
+}
+
+// embedResponse объединяет форматы ответа Ollama и OpenAI
+type embedResponse struct {
+	Embedding  []float64   `json:"embedding"`
+	Embeddings [][]float64 `json:"embeddings"`
+	Data       []struct {
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed вычисляет вектор текста
+func (e *HTTPEmbedder) Embed(ctx context.Context, text string) ([]float64, error) {
+	body := map[string]interface{}{"model": e.model}
+	if strings.HasSuffix(strings.TrimRight(e.url, "/"), "/api/embeddings") {
+		body["prompt"] = text
+	} else {
+		body["input"] = text
+	}
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", e.url, bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка запроса эмбеддинга: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("сервер эмбеддингов вернул %d: %s", resp.StatusCode, truncate(string(data), 200))
+	}
+
+	var result embedResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("ошибка парсинга ответа: %w", err)
+	}
+	switch {
+	case len(result.Embedding) > 0:
+		return result.Embedding, nil
+	case len(result.Embeddings) > 0 && len(result.Embeddings[0]) > 0:
+		return result.Embeddings[0], nil
+	case len(result.Data) > 0 && len(result.Data[0].Embedding) > 0:
+		return result.Data[0].Embedding, nil
+	}
+	return nil, fmt.Errorf("в ответе нет эмбеддинга: %s", truncate(string(data), 200))
+}
+
+// truncate обрезает строку до n байт по границе руны
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "…"
+}
@@ -0,0 +1,185 @@
+package knowledge
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ============================================
+// СБОРКА ИНДЕКСА
+// Файлы → разделы → чанки → BM25 (+ эмбеддинги)
+// ============================================
+
+// BuildOptions - параметры сборки индекса
+type BuildOptions struct {
+	ChunkWords   int // размер чанка в словах; 0 — DefaultChunkWords
+	OverlapWords int // перекрытие соседних чанков в словах
+	// Embedder — если задан, для каждого чанка считается эмбеддинг
+	Embedder Embedder
+	// Log — вывод прогресса; nil — без вывода
+	Log func(format string, args ...interface{})
+}
+
+// Build собирает индекс из файлов и каталогов paths
+func Build(ctx context.Context, paths []string, opts BuildOptions) (*KnowledgeIndex, error) {
+	logf := opts.Log
+	if logf == nil {
+		logf = func(string, ...interface{}) {}
+	}
+
+	files, err := SourceFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("не найдено ни одного PDF, Markdown или текстового файла")
+	}
+
+	index := &KnowledgeIndex{
+		Version:   IndexVersion,
+		CreatedAt: time.Now().UTC(),
+		Analyzer:  AnalyzerVersion,
+	}
+	for _, file := range files {
+		sections, err := ReadSections(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		sum, err := fileSHA256(file)
+		if err != nil {
+			return nil, err
+		}
+
+		rel := relativePath(paths, file)
+		chunks := ChunkSections(sections, opts.ChunkWords, opts.OverlapWords)
+		for n, c := range chunks {
+			meta := map[string]string{
+				"file":  filepath.Base(file),
+				"path":  rel,
+				"chunk": strconv.Itoa(n + 1),
+			}
+			if c.Page > 0 {
+				meta["page"] = strconv.Itoa(c.Page)
+			}
+			if c.Section != "" {
+				meta["section"] = c.Section
+			}
+			index.Documents = append(index.Documents, Document{
+				ID:       fmt.Sprintf("%s#%d", rel, n+1),
+				Content:  c.Text,
+				Metadata: meta,
+			})
+		}
+
+		source := SourceInfo{File: rel, SHA256: sum, Chunks: len(chunks)}
+		if len(sections) > 0 && sections[len(sections)-1].Page > 0 {
+			source.Pages = sections[len(sections)-1].Page
+		}
+		index.Sources = append(index.Sources, source)
+		logf("%s: фрагментов — %d", rel, len(chunks))
+	}
+
+	if opts.Embedder != nil {
+		if err := embedDocuments(ctx, index, opts.Embedder, logf); err != nil {
+			return nil, err
+		}
+	}
+
+	index.BM25 = NewBM25Index(index.Documents)
+	return index, nil
+}
+
+// embedDocuments считает эмбеддинги всех документов индекса
+func embedDocuments(ctx context.Context, index *KnowledgeIndex, e Embedder, logf func(string, ...interface{})) error {
+	var dims int
+	for i := range index.Documents {
+		emb, err := e.Embed(ctx, index.Documents[i].Content)
+		if err != nil {
+			return fmt.Errorf("эмбеддинг %s: %w", index.Documents[i].ID, err)
+		}
+		if dims == 0 {
+			dims = len(emb)
+		} else if len(emb) != dims {
+			return fmt.Errorf("эмбеддинг %s: размерность %d вместо %d", index.Documents[i].ID, len(emb), dims)
+		}
+		index.Documents[i].Embedding = emb
+		if (i+1)%100 == 0 {
+			logf("эмбеддинги: %d/%d", i+1, len(index.Documents))
+		}
+	}
+
+	info := &EmbeddingInfo{Dimensions: dims}
+	if h, ok := e.(*HTTPEmbedder); ok {
+		info.URL = h.URL()
+		info.Model = h.Model()
+	}
+	index.Embedding = info
+	return nil
+}
+
+// Save записывает индекс в файл. Запись идёт во временный файл рядом,
+// который затем переименовывается: бот не прочитает недописанный индекс
+func (idx *KnowledgeIndex) Save(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	if err := json.NewEncoder(w).Encode(idx); err != nil {
+		tmp.Close()
+		return fmt.Errorf("ошибка записи индекса: %w", err)
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("ошибка записи индекса: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// CreateTemp создаёт файл с правами 0600
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// fileSHA256 — контрольная сумма файла: по ней видно, какие источники изменились
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// relativePath — путь файла относительно каталога, из которого он взят;
+// для файла, указанного явно, — его имя
+func relativePath(roots []string, file string) string {
+	for _, root := range roots {
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			continue
+		}
+		if rel, err := filepath.Rel(root, file); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.Base(file)
+}
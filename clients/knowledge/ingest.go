@@ -0,0 +1,230 @@
+package knowledge
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// ============================================
+// ЧТЕНИЕ ИСТОЧНИКОВ: PDF, Markdown, текст
+// ============================================
+
+// ErrUnsupported — формат файла не поддерживается
+var ErrUnsupported = errors.New("формат файла не поддерживается")
+
+// sourceExtensions — расширения файлов, которые попадают в базу знаний
+var sourceExtensions = map[string]bool{
+	".pdf": true, ".md": true, ".markdown": true, ".txt": true,
+}
+
+// SourceFiles раскрывает пути: каталоги обходятся рекурсивно, из них берутся
+// PDF, Markdown и текстовые файлы. Файлы, указанные явно, берутся как есть.
+// Результат отсортирован и без повторов
+func SourceFiles(paths []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var files []string
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+	for _, root := range paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			add(root)
+			continue
+		}
+		err = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() && strings.HasPrefix(d.Name(), ".") && path != root {
+				return filepath.SkipDir
+			}
+			if !d.IsDir() && sourceExtensions[strings.ToLower(filepath.Ext(path))] {
+				add(path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ReadSections читает файл и делит его на разделы: страницы PDF,
+// разделы Markdown по заголовкам, текстовый файл — один раздел
+func ReadSections(path string) ([]Section, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".pdf":
+		return readPDF(path)
+	case ".md", ".markdown":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return ParseMarkdown(string(data)), nil
+	case ".txt":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return []Section{{Text: strings.ReplaceAll(string(data), "\r\n", "\n")}}, nil
+	default:
+		return nil, fmt.Errorf("%s: %w", path, ErrUnsupported)
+	}
+}
+
+// readPDF извлекает текст PDF постранично
+func readPDF(path string) ([]Section, error) {
+	f, r, err := pdf.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось открыть PDF: %w", err)
+	}
+	defer f.Close()
+
+	var sections []Section
+	for i := 1; i <= r.NumPage(); i++ {
+		text, err := pdfPageText(r.Page(i))
+		if err != nil {
+			return nil, fmt.Errorf("страница %d: %w", i, err)
+		}
+		if strings.TrimSpace(text) != "" {
+			sections = append(sections, Section{Page: i, Text: text})
+		}
+	}
+	if len(sections) == 0 {
+		return nil, fmt.Errorf("в PDF нет текстового слоя (скан без распознавания?)")
+	}
+	return sections, nil
+}
+
+// hyphenBreak — перенос слова в конце строки: «трени-\nровка»
+var hyphenBreak = regexp.MustCompile(`(\p{L})[-\x{00AD}]\n(\p{Ll})`)
+
+// pdfPageText собирает текст страницы из глифов: пробел там, где между
+// глифами заметный промежуток, перевод строки — при смене строки. Многие
+// PDF не хранят пробелы явно, а задают их сдвигом глифов
+func pdfPageText(p pdf.Page) (text string, err error) {
+	if p.V.IsNull() {
+		return "", nil
+	}
+	defer func() {
+		// Разбор повреждённого потока в ledongthuc/pdf заканчивается паникой
+		if r := recover(); r != nil {
+			err = fmt.Errorf("ошибка разбора страницы: %v", r)
+		}
+	}()
+
+	var sb strings.Builder
+	texts := p.Content().Text
+	for i, t := range texts {
+		if i > 0 {
+			prev := texts[i-1]
+			size := math.Max(t.FontSize, 1)
+			switch {
+			case math.Abs(t.Y-prev.Y) > size*0.5:
+				sb.WriteString("\n")
+			case t.X-(prev.X+prev.W) > size*0.15 && !strings.HasSuffix(prev.S, " ") && !strings.HasPrefix(t.S, " "):
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString(t.S)
+	}
+	return hyphenBreak.ReplaceAllString(sb.String(), "$1$2"), nil
+}
+
+var (
+	mdImage      = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	mdLink       = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	mdHTML       = regexp.MustCompile(`<[^>]+>`)
+	mdEmphasis   = regexp.MustCompile("[*_~`]+")
+	mdListItem   = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+`)
+	mdHeading    = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdRule       = regexp.MustCompile(`^\s*(?:[-*_]\s*){3,}$`)
+	mdTableRule  = regexp.MustCompile(`^\s*\|?\s*:?-{3,}`)
+	mdBlockQuote = regexp.MustCompile(`^\s*>\s?`)
+)
+
+// ParseMarkdown делит Markdown на разделы по заголовкам и убирает разметку.
+// Пункты списков и строки таблиц становятся отдельными абзацами
+func ParseMarkdown(src string) []Section {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	// YAML front matter
+	if strings.HasPrefix(src, "---\n") {
+		if end := strings.Index(src[4:], "\n---"); end >= 0 {
+			src = src[4+end+4:]
+		}
+	}
+
+	var sections []Section
+	current := Section{}
+	var body bytes.Buffer
+	flush := func() {
+		current.Text = strings.TrimSpace(body.String())
+		if current.Text != "" || current.Title != "" {
+			sections = append(sections, current)
+		}
+		body.Reset()
+	}
+
+	inCode := false
+	scanner := bufio.NewScanner(strings.NewReader(src))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCode = !inCode
+			body.WriteString("\n\n")
+			continue
+		}
+		if inCode {
+			body.WriteString(line + "\n")
+			continue
+		}
+		if m := mdHeading.FindStringSubmatch(line); m != nil {
+			flush()
+			current = Section{Title: stripInline(m[2])}
+			continue
+		}
+		switch {
+		case trimmed == "":
+			body.WriteString("\n\n")
+		case mdRule.MatchString(line) || mdTableRule.MatchString(line):
+			body.WriteString("\n\n")
+		case mdListItem.MatchString(line), strings.HasPrefix(trimmed, "|"):
+			body.WriteString("\n\n" + stripInline(mdListItem.ReplaceAllString(line, "")) + "\n")
+		default:
+			body.WriteString(stripInline(mdBlockQuote.ReplaceAllString(line, "")) + "\n")
+		}
+	}
+	flush()
+	return sections
+}
+
+// stripInline убирает строчную разметку Markdown: ссылки, картинки, HTML, выделение
+func stripInline(s string) string {
+	s = mdImage.ReplaceAllString(s, "")
+	s = mdLink.ReplaceAllString(s, "$1")
+	s = mdHTML.ReplaceAllString(s, "")
+	s = mdEmphasis.ReplaceAllString(s, "")
+	s = strings.ReplaceAll(s, "|", " ")
+	return strings.Join(strings.Fields(s), " ")
+}
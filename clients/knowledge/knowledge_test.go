@@ -0,0 +1,285 @@
+package knowledge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"workbot/internal/pdfdoc"
+)
+
+func TestStemRussian(t *testing.T) {
+	tests := map[string]string{
+		"тренировка":     "тренировк",
+		"тренировки":     "тренировк",
+		"тренировками":   "тренировк",
+		"периодизация":   "периодизац",
+		"периодизации":   "периодизац",
+		"приседания":     "приседан",
+		"восстановлении": "восстановлен",
+	}
+	for word, want := range tests {
+		if got := stemRussian(word); got != want {
+			t.Errorf("stemRussian(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestStemEnglish(t *testing.T) {
+	tests := map[string]string{
+		"trainings":     "train",
+		"training":      "train",
+		"periodization": "period",
+		"squats":        "squat",
+		"loaded":        "load",
+	}
+	for word, want := range tests {
+		if got := stemEnglish(word); got != want {
+			t.Errorf("stemEnglish(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("Жим лёжа 5х5 на 80% — и Deadlifts!")
+	want := []string{"жим", "леж", "5х5", "80", "deadlift"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize = %q, want %q", got, want)
+	}
+}
+
+func TestChunkSectionsOverlap(t *testing.T) {
+	var sentences []string
+	for i := 0; i < 30; i++ {
+		sentences = append(sentences, "Это предложение номер "+strings.Repeat("раз ", i%3)+"ровно.")
+	}
+	chunks := ChunkSections([]Section{{Page: 3, Text: strings.Join(sentences, " ")}}, 20, 5)
+	if len(chunks) < 2 {
+		t.Fatalf("chunks = %d, want several", len(chunks))
+	}
+	for i, c := range chunks {
+		if n := len(strings.Fields(c.Text)); n > 20 {
+			t.Errorf("chunk %d has %d words, want <= 20", i, n)
+		}
+		if c.Page != 3 {
+			t.Errorf("chunk %d page = %d, want 3", i, c.Page)
+		}
+		if i == 0 {
+			continue
+		}
+		// Фрагмент начинается с последнего предложения предыдущего
+		prev := chunks[i-1].Text
+		first := c.Text[:strings.Index(c.Text, ".")+1]
+		if !strings.HasSuffix(prev, first) {
+			t.Errorf("chunk %d starts with %q, not an overlap of %q", i, first, prev)
+		}
+	}
+}
+
+func TestSplitSentencesAbbreviations(t *testing.T) {
+	got := splitSentences("Отдых 3 мин. между подходами, т.е. полное восстановление. См. рис. 2. Конец", 0, 100)
+	var texts []string
+	for _, s := range got {
+		texts = append(texts, s.text)
+	}
+	want := []string{"Отдых 3 мин. между подходами, т.е. полное восстановление.", "См. рис. 2.", "Конец"}
+	if !reflect.DeepEqual(texts, want) {
+		t.Errorf("splitSentences = %q, want %q", texts, want)
+	}
+}
+
+func TestParseMarkdown(t *testing.T) {
+	src := "---\ntitle: x\n---\nВступление с [ссылкой](http://x).\n\n# Жим лёжа\n\n**Техника** важна.\n\n- пункт один\n- пункт два\n\n```\ncode\n```\n## Отдых\nТекст"
+	got := ParseMarkdown(src)
+	if len(got) != 3 {
+		t.Fatalf("sections = %+v, want 3", got)
+	}
+	if got[0].Title != "" || got[0].Text != "Вступление с ссылкой." {
+		t.Errorf("section 0 = %+v", got[0])
+	}
+	if got[1].Title != "Жим лёжа" || !strings.Contains(got[1].Text, "Техника важна.") ||
+		!strings.Contains(got[1].Text, "пункт один\n\n\nпункт два") {
+		t.Errorf("section 1 = %+v", got[1])
+	}
+	if got[2].Title != "Отдых" || got[2].Text != "Текст" {
+		t.Errorf("section 2 = %+v", got[2])
+	}
+}
+
+func TestBM25Ranking(t *testing.T) {
+	docs := []Document{
+		{ID: "a", Content: "Питание и сон влияют на восстановление."},
+		{ID: "b", Content: "Периодизация тренировок: линейная периодизация и волновая периодизация жима."},
+		{ID: "c", Content: "Жим лёжа выполняется с паузой на груди."},
+	}
+	idx := NewBM25Index(docs)
+
+	got := idx.Search("периодизации жима", 3)
+	if len(got) != 2 || got[0].doc != 1 || got[1].doc != 2 {
+		t.Fatalf("Search = %+v, want b then c", got)
+	}
+	if got := idx.Search("питанием", 3); len(got) != 1 || got[0].doc != 0 {
+		t.Errorf("Search(питанием) = %+v, want a", got)
+	}
+	if got := idx.Search("становая", 3); len(got) != 0 {
+		t.Errorf("Search(становая) = %+v, want none", got)
+	}
+}
+
+func writeIndex(t *testing.T, index interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "knowledge.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadLegacyIndex(t *testing.T) {
+	// Индекс старых Python-скриптов: без версии анализатора и BM25
+	path := writeIndex(t, map[string]interface{}{
+		"version": "1.0",
+		"documents": []map[string]interface{}{
+			{"id": "1", "content": "Становая тяга в сумо", "embedding": []float64{}, "metadata": map[string]string{"file": "a.pdf"}},
+			{"id": "2", "content": "Жим стоя", "embedding": []float64{}, "metadata": map[string]string{}},
+		},
+	})
+	s := NewStore()
+	if err := s.Load(path); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	got := s.SearchByKeywords("становую тягу", 5)
+	if len(got) != 1 || got[0].Document.ID != "1" {
+		t.Fatalf("SearchByKeywords = %+v, want doc 1", got)
+	}
+	if ctx := s.GetContext("становую тягу", 5); !strings.Contains(ctx, "Файл: a.pdf") {
+		t.Errorf("GetContext = %q", ctx)
+	}
+}
+
+func TestLoadNewerVersion(t *testing.T) {
+	path := writeIndex(t, KnowledgeIndex{Version: "3"})
+	if err := NewStore().Load(path); err == nil || !strings.Contains(err.Error(), "новее") {
+		t.Errorf("Load(v3) = %v, want version error", err)
+	}
+}
+
+// fakeEmbedder векторизует текст по наличию слов словаря
+type fakeEmbedder struct{ vocab []string }
+
+func (f fakeEmbedder) vector(text string) []float64 {
+	v := make([]float64, len(f.vocab))
+	text = strings.ToLower(text)
+	for i, w := range f.vocab {
+		if strings.Contains(text, w) {
+			v[i] = 1
+		}
+	}
+	return v
+}
+
+func TestBuildAndHybridSearch(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"recovery.md": "# Восстановление\n\nСон и питание определяют восстановление после нагрузки.",
+		"bench.txt":   "Жим лёжа: лопатки сведены, ноги упираются в пол.",
+		"deload.txt":  "Разгрузочная неделя снижает объём на 40%. Отдых помогает суперкомпенсации.",
+	}
+	for name, text := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Сервер эмбеддингов в формате Ollama /api/embeddings: «отдых» и «разгрузка» — синонимы
+	emb := fakeEmbedder{vocab: []string{"сон", "жим", "разгруз"}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct{ Model, Prompt string }
+		json.NewDecoder(r.Body).Decode(&req)
+		text := strings.ReplaceAll(strings.ToLower(req.Prompt), "отдых", "разгруз")
+		json.NewEncoder(w).Encode(map[string]interface{}{"embedding": emb.vector(text)})
+	}))
+	defer srv.Close()
+	embedder := NewHTTPEmbedder(srv.URL+"/api/embeddings", "test-embed")
+
+	index, err := Build(context.Background(), []string{dir}, BuildOptions{Embedder: embedder})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if len(index.Documents) != 3 || len(index.Sources) != 3 {
+		t.Fatalf("documents = %d, sources = %d, want 3 and 3", len(index.Documents), len(index.Sources))
+	}
+	if index.Embedding == nil || index.Embedding.Model != "test-embed" || index.Embedding.Dimensions != 3 {
+		t.Errorf("Embedding = %+v", index.Embedding)
+	}
+	if doc := index.Documents[2]; doc.ID != "recovery.md#1" || doc.Metadata["section"] != "Восстановление" {
+		t.Errorf("document 2 = %+v", doc)
+	}
+
+	path := filepath.Join(dir, "knowledge.json")
+	if err := index.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	s := NewStore()
+	if err := s.Load(path); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	// Без эмбеддера — только BM25: слова «разгрузка» (основа «разгрузк») в текстах нет
+	if got := s.Search(context.Background(), "разгрузка", 3); len(got) != 0 {
+		t.Errorf("BM25 only: %+v, want none", got)
+	}
+	s.SetEmbedder(embedder)
+	got := s.Search(context.Background(), "разгрузка", 3)
+	if len(got) == 0 || got[0].Document.ID != "deload.txt#1" {
+		t.Fatalf("hybrid = %+v, want deload.txt first", got)
+	}
+
+	// Эндпоинт недоступен — поиск не падает, работает BM25
+	srv.Close()
+	if got := s.Search(context.Background(), "жим", 3); len(got) != 1 || got[0].Document.ID != "bench.txt#1" {
+		t.Errorf("fallback = %+v, want bench.txt", got)
+	}
+}
+
+func TestReadPDF(t *testing.T) {
+	var d pdfdoc.Document
+	d.Title("Методичка")
+	d.Text("Суперкомпенсация наступает через 48 часов после тренировки.")
+	d.PageBreak()
+	d.Text("Вторая страница про приседания.")
+
+	path := filepath.Join(t.TempDir(), "book.pdf")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pdfdoc.Render(f, &d); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	f.Close()
+
+	sections, err := ReadSections(path)
+	if err != nil {
+		t.Fatalf("ReadSections: %v", err)
+	}
+	if len(sections) != 2 || sections[0].Page != 1 || sections[1].Page != 2 {
+		t.Fatalf("sections = %+v, want pages 1 and 2", sections)
+	}
+	if !strings.Contains(sections[0].Text, "Суперкомпенсация наступает через 48 часов") {
+		t.Errorf("page 1 = %q", sections[0].Text)
+	}
+	if !strings.Contains(sections[1].Text, "про приседания") {
+		t.Errorf("page 2 = %q", sections[1].Text)
+	}
+}
@@ -0,0 +1,88 @@
+package knowledge
+
+import "strings"
+
+// ============================================
+// СТЕММЕР АНГЛИЙСКОГО ЯЗЫКА (облегчённый Портер)
+// Множественное число, -ed/-ing и частые словообразовательные суффиксы:
+// «trainings» → «train», «loaded» → «load», «periodization» → «period»
+// ============================================
+
+// enSuffixes — словообразовательные суффиксы и их замены, от длинных к коротким
+var enSuffixes = []struct{ suffix, replace string }{
+	{"izations", "ize"}, {"ization", "ize"}, {"ational", "ate"}, {"fulness", "ful"},
+	{"iveness", "ive"}, {"ousness", "ous"}, {"ations", "ate"}, {"ation", "ate"},
+	{"alism", "al"}, {"ality", "al"}, {"ement", ""}, {"ments", ""}, {"ment", ""},
+	{"ness", ""}, {"ably", ""}, {"ibly", ""}, {"ible", ""}, {"able", ""},
+	{"izer", "ize"}, {"ator", "ate"}, {"ical", "ic"}, {"ful", ""}, {"ous", ""},
+	{"ive", ""}, {"ize", ""}, {"ise", ""}, {"ate", ""}, {"ity", ""}, {"ism", ""},
+	{"ist", ""}, {"al", ""}, {"er", ""}, {"ly", ""},
+}
+
+const enMinStemLength = 3
+
+func isEnVowel(b byte) bool {
+	return strings.IndexByte("aeiou", b) >= 0
+}
+
+func hasEnVowel(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if isEnVowel(s[i]) || (s[i] == 'y' && i > 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// stemEnglish возвращает основу английского слова в нижнем регистре
+func stemEnglish(w string) string {
+	if len(w) <= enMinStemLength {
+		return w
+	}
+	w = strings.TrimSuffix(strings.TrimSuffix(w, "'s"), "'")
+
+	// Множественное число
+	switch {
+	case strings.HasSuffix(w, "sses"):
+		w = w[:len(w)-2]
+	case strings.HasSuffix(w, "ies") && len(w) > 4:
+		w = w[:len(w)-3] + "y"
+	case strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") && !strings.HasSuffix(w, "us") &&
+		!strings.HasSuffix(w, "is") && len(w) > 4:
+		w = w[:len(w)-1]
+	}
+
+	// -ed, -ing
+	for _, suf := range []string{"ing", "ed"} {
+		if stem := strings.TrimSuffix(w, suf); stem != w && len(stem) >= enMinStemLength && hasEnVowel(stem) {
+			w = stem
+			n := len(w)
+			if n >= 2 && w[n-1] == w[n-2] && !strings.ContainsRune("lsz", rune(w[n-1])) {
+				w = w[:n-1] // running → run
+			}
+			break
+		}
+	}
+
+	// Словообразовательные суффиксы: не больше двух подряд
+	for pass := 0; pass < 2; pass++ {
+		changed := false
+		for _, s := range enSuffixes {
+			stem := strings.TrimSuffix(w, s.suffix)
+			if stem == w || len(stem) < enMinStemLength || !hasEnVowel(stem) {
+				continue
+			}
+			w, changed = stem+s.replace, true
+			break
+		}
+		if !changed {
+			break
+		}
+	}
+
+	// Конечная «e» и «y» → «i» не влияют на совпадение при поиске, убираем обе
+	if n := len(w); n > enMinStemLength && (w[n-1] == 'e' || w[n-1] == 'y') {
+		w = w[:n-1]
+	}
+	return w
+}
@@ -0,0 +1,142 @@
+package knowledge
+
+import "strings"
+
+// ============================================
+// СТЕММЕР РУССКОГО ЯЗЫКА (Snowball, Портер)
+// «тренировками» → «тренировк», «жимов» → «жим»
+// ============================================
+
+var (
+	ruPerfectiveGerund1 = []string{"вшись", "вши", "в"}
+	ruPerfectiveGerund2 = []string{"ившись", "ывшись", "ивши", "ывши", "ив", "ыв"}
+	ruReflexive         = []string{"ся", "сь"}
+	ruAdjective         = []string{
+		"ими", "ыми", "его", "ого", "ему", "ому",
+		"ее", "ие", "ые", "ое", "ей", "ий", "ый", "ой", "ем", "им", "ым", "ом",
+		"их", "ых", "ую", "юю", "ая", "яя", "ою", "ею",
+	}
+	ruParticiple1 = []string{"ем", "нн", "вш", "ющ", "щ"}
+	ruParticiple2 = []string{"ивш", "ывш", "ующ"}
+	ruVerb1       = []string{
+		"ете", "йте", "ешь", "нно",
+		"ла", "на", "ли", "ем", "ло", "но", "ет", "ют", "ны", "ть",
+		"й", "л", "н",
+	}
+	ruVerb2 = []string{
+		"ейте", "уйте",
+		"ила", "ыла", "ена", "ите", "или", "ыли", "ило", "ыло", "ено", "ует", "уют", "ены", "ить", "ыть", "ишь",
+		"ей", "уй", "ил", "ыл", "им", "ым", "ен", "ят", "ит", "ыт", "ую",
+		"ю",
+	}
+	ruNoun = []string{
+		"иями", "ями", "ами", "ией", "иям", "ием", "иях",
+		"ев", "ов", "ие", "ье", "еи", "ии", "ей", "ой", "ий", "ям", "ем", "ам", "ом", "ах", "ях", "ию", "ью", "ия", "ья",
+		"а", "е", "и", "й", "о", "у", "ы", "ь", "ю", "я",
+	}
+	ruSuperlative   = []string{"ейше", "ейш"}
+	ruDerivational  = []string{"ость", "ост"}
+	ruVowels        = "аеиоуыэюя"
+	ruMinStemLength = 2
+)
+
+func isRuVowel(r rune) bool {
+	return strings.ContainsRune(ruVowels, r)
+}
+
+// ruRegions возвращает начала областей RV и R2 (в рунах)
+func ruRegions(w []rune) (rv, r2 int) {
+	rv = len(w)
+	for i, r := range w {
+		if isRuVowel(r) {
+			rv = i + 1
+			break
+		}
+	}
+	r1 := afterVowelConsonant(w, 0)
+	r2 = afterVowelConsonant(w, r1)
+	return rv, r2
+}
+
+// afterVowelConsonant — позиция после первой согласной, которой предшествует гласная, начиная с from
+func afterVowelConsonant(w []rune, from int) int {
+	for i := from + 1; i < len(w); i++ {
+		if !isRuVowel(w[i]) && isRuVowel(w[i-1]) {
+			return i + 1
+		}
+	}
+	return len(w)
+}
+
+// ruStripSuffix отрезает самое длинное окончание из списка, лежащее в области start.
+// Окончания списка упорядочены от длинных к коротким. afterAYa — окончанию
+// должна предшествовать «а» или «я», которая остаётся в основе
+func ruStripSuffix(w []rune, start int, suffixes []string, afterAYa bool) ([]rune, bool) {
+	s := string(w)
+	for _, suf := range suffixes {
+		if !strings.HasSuffix(s, suf) {
+			continue
+		}
+		cut := len(w) - len([]rune(suf))
+		if cut < start {
+			continue
+		}
+		if afterAYa && (cut-1 < start || (w[cut-1] != 'а' && w[cut-1] != 'я')) {
+			// Как в Snowball: самое длинное окончание найдено, но условие не выполнено
+			return w, false
+		}
+		return w[:cut], true
+	}
+	return w, false
+}
+
+// stemRussian возвращает основу русского слова в нижнем регистре
+func stemRussian(word string) string {
+	w := []rune(strings.ReplaceAll(word, "ё", "е"))
+	if len(w) <= ruMinStemLength {
+		return word
+	}
+	rv, r2 := ruRegions(w)
+
+	// Шаг 1
+	if out, ok := ruStripSuffix(w, rv, ruPerfectiveGerund2, false); ok {
+		w = out
+	} else if out, ok := ruStripSuffix(w, rv, ruPerfectiveGerund1, true); ok {
+		w = out
+	} else {
+		w, _ = ruStripSuffix(w, rv, ruReflexive, false)
+		if out, ok := ruStripSuffix(w, rv, ruAdjective, false); ok {
+			w = out
+			if out, ok := ruStripSuffix(w, rv, ruParticiple2, false); ok {
+				w = out
+			} else {
+				w, _ = ruStripSuffix(w, rv, ruParticiple1, true)
+			}
+		} else if out, ok := ruStripSuffix(w, rv, ruVerb2, false); ok {
+			w = out
+		} else if out, ok := ruStripSuffix(w, rv, ruVerb1, true); ok {
+			w = out
+		} else {
+			w, _ = ruStripSuffix(w, rv, ruNoun, false)
+		}
+	}
+
+	// Шаг 2
+	w, _ = ruStripSuffix(w, rv, []string{"и"}, false)
+
+	// Шаг 3
+	w, _ = ruStripSuffix(w, r2, ruDerivational, false)
+
+	// Шаг 4
+	if out, ok := ruStripSuffix(w, rv, []string{"нн"}, false); ok {
+		w = append(out, 'н')
+	} else if out, ok := ruStripSuffix(w, rv, ruSuperlative, false); ok {
+		w = out
+		if out, ok := ruStripSuffix(w, rv, []string{"нн"}, false); ok {
+			w = append(out, 'н')
+		}
+	} else {
+		w, _ = ruStripSuffix(w, rv, []string{"ь"}, false)
+	}
+	return string(w)
+}
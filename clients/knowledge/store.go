@@ -1,11 +1,14 @@
 package knowledge
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"math"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -16,11 +19,15 @@ import (
 // Работает на Pi без Ollama
 // ============================================
 
+// IndexVersion — версия формата индекса. Индекс старше поддерживается
+// (BM25 строится при загрузке), новее — нет: нужно обновить бота
+const IndexVersion = "2"
+
 // Document - документ с эмбеддингом
 type Document struct {
 	ID        string            `json:"id"`
 	Content   string            `json:"content"`
-	Embedding []float64         `json:"embedding"`
+	Embedding []float64         `json:"embedding,omitempty"`
 	Metadata  map[string]string `json:"metadata"`
 }
 
@@ -29,6 +36,28 @@ type KnowledgeIndex struct {
 	Version   string     `json:"version"`
 	CreatedAt time.Time  `json:"created_at"`
 	Documents []Document `json:"documents"`
+
+	// Analyzer — версия анализатора, которым построен BM25
+	Analyzer  string         `json:"analyzer,omitempty"`
+	Embedding *EmbeddingInfo `json:"embedding,omitempty"`
+	Sources   []SourceInfo   `json:"sources,omitempty"`
+	BM25      *BM25Index     `json:"bm25,omitempty"`
+}
+
+// EmbeddingInfo — чем посчитаны эмбеддинги документов. Запрос нужно
+// векторизовать той же моделью, иначе косинус не имеет смысла
+type EmbeddingInfo struct {
+	Model      string `json:"model"`
+	URL        string `json:"url"`
+	Dimensions int    `json:"dimensions"`
+}
+
+// SourceInfo — исходный файл индекса
+type SourceInfo struct {
+	File   string `json:"file"`
+	SHA256 string `json:"sha256"`
+	Pages  int    `json:"pages,omitempty"`
+	Chunks int    `json:"chunks"`
 }
 
 // SearchResult - результат поиска
@@ -41,6 +70,9 @@ type SearchResult struct {
 type Store struct {
 	mu        sync.RWMutex
 	documents []Document
+	bm25      *BM25Index
+	embedding *EmbeddingInfo
+	embedder  Embedder
 	loaded    bool
 }
 
@@ -53,9 +85,6 @@ func NewStore() *Store {
 
 // Load загружает индекс из файла
 func (s *Store) Load(path string) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return fmt.Errorf("не удалось прочитать файл: %w", err)
@@ -65,13 +94,56 @@ func (s *Store) Load(path string) error {
 	if err := json.Unmarshal(data, &index); err != nil {
 		return fmt.Errorf("ошибка парсинга индекса: %w", err)
 	}
+	if err := checkIndexVersion(index.Version); err != nil {
+		return err
+	}
 
+	// BM25 из файла годится, только если он построен тем же анализатором
+	bm25 := index.BM25
+	if bm25 == nil || index.Analyzer != AnalyzerVersion || len(bm25.DocLengths) != len(index.Documents) {
+		bm25 = NewBM25Index(index.Documents)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.documents = index.Documents
+	s.bm25 = bm25
+	s.embedding = index.Embedding
 	s.loaded = true
 
 	return nil
 }
 
+// checkIndexVersion проверяет, что формат индекса поддерживается.
+// Индексы без версии или с нечисловой версией собраны старыми
+// скриптами и читаются как версия 1
+func checkIndexVersion(version string) error {
+	major, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if err != nil {
+		return nil
+	}
+	current, _ := strconv.Atoi(IndexVersion)
+	if major > current {
+		return fmt.Errorf("индекс версии %s новее поддерживаемой (%s): обновите бота", version, IndexVersion)
+	}
+	return nil
+}
+
+// SetEmbedder включает гибридный поиск: запрос векторизуется и
+// сравнивается с эмбеддингами документов
+func (s *Store) SetEmbedder(e Embedder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.embedder = e
+}
+
+// EmbeddingInfo возвращает параметры эмбеддингов индекса; nil — индекс без эмбеддингов
+func (s *Store) EmbeddingInfo() *EmbeddingInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.embedding
+}
+
 // IsLoaded возвращает true если индекс загружен
 func (s *Store) IsLoaded() bool {
 	s.mu.RLock()
@@ -86,51 +158,23 @@ func (s *Store) Count() int {
 	return len(s.documents)
 }
 
-// SearchByKeywords ищет по ключевым словам (без эмбеддингов)
-// Используем TF-IDF подобный подход
+// SearchByKeywords ищет по ключевым словам (без эмбеддингов).
+// Ранжирование BM25 по основам слов; Similarity — оценка BM25
 func (s *Store) SearchByKeywords(query string, topK int) []SearchResult {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if len(s.documents) == 0 {
+	if s.bm25 == nil {
 		return nil
 	}
 
-	query = strings.ToLower(query)
-	words := strings.Fields(query)
-
 	var results []SearchResult
-
-	for _, doc := range s.documents {
-		content := strings.ToLower(doc.Content)
-		score := 0.0
-
-		for _, word := range words {
-			if strings.Contains(content, word) {
-				// Считаем количество вхождений
-				count := float64(strings.Count(content, word))
-				// Нормализуем по длине документа
-				score += count / float64(len(content)+1) * 1000
-			}
-		}
-
-		if score > 0 {
-			results = append(results, SearchResult{
-				Document:   doc,
-				Similarity: score,
-			})
-		}
-	}
-
-	// Сортируем по релевантности
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Similarity > results[j].Similarity
-	})
-
-	if len(results) > topK {
-		results = results[:topK]
+	for _, r := range s.bm25.Search(query, topK) {
+		results = append(results, SearchResult{
+			Document:   s.documents[r.doc],
+			Similarity: r.score,
+		})
 	}
-
 	return results
 }
 
@@ -171,9 +215,108 @@ func (s *Store) SearchByEmbedding(queryEmbedding []float64, topK int, threshold
 	return results
 }
 
+// Веса гибридного ранжирования
+const (
+	hybridBM25Weight   = 0.5
+	hybridVectorWeight = 0.5
+	// hybridCandidates — во сколько раз больше topK кандидатов берётся из каждого поиска
+	hybridCandidates = 4
+)
+
+// Search — гибридный поиск: кандидаты BM25 и ближайшие по косинусу
+// объединяются, оценка = 0.5·BM25/max(BM25) + 0.5·cos. Без эмбеддера
+// или при ошибке эндпоинта — только BM25. Similarity — итоговая оценка от 0 до 1
+func (s *Store) Search(ctx context.Context, query string, topK int) []SearchResult {
+	s.mu.RLock()
+	embedder := s.embedder
+	hasVectors := s.embedding != nil
+	s.mu.RUnlock()
+
+	var queryEmbedding []float64
+	if embedder != nil && hasVectors {
+		emb, err := embedder.Embed(ctx, query)
+		if err != nil {
+			log.Printf("knowledge: эмбеддинг запроса не получен, только BM25: %v", err)
+		} else {
+			queryEmbedding = emb
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.bm25 == nil || topK <= 0 {
+		return nil
+	}
+
+	n := topK * hybridCandidates
+	if len(queryEmbedding) == 0 {
+		n = topK
+	}
+	keyword := s.bm25.Search(query, n)
+	var maxBM25 float64
+	if len(keyword) > 0 {
+		maxBM25 = keyword[0].score
+	}
+	scores := make(map[int]float64)
+	for _, r := range keyword {
+		scores[r.doc] = r.score / maxBM25
+	}
+	if len(queryEmbedding) == 0 {
+		results := make([]SearchResult, 0, len(keyword))
+		for _, r := range keyword {
+			results = append(results, SearchResult{Document: s.documents[r.doc], Similarity: scores[r.doc]})
+		}
+		return results
+	}
+
+	// Косинус считается для всех документов: кандидаты по смыслу
+	// могут не содержать ни одного слова запроса
+	cosines := make([]scored, 0, len(s.documents))
+	for i, doc := range s.documents {
+		if len(doc.Embedding) > 0 {
+			cosines = append(cosines, scored{i, math.Max(0, cosineSimilarity(queryEmbedding, doc.Embedding))})
+		}
+	}
+	sortScored(cosines)
+	cosineOf := make(map[int]float64, len(cosines))
+	for _, c := range cosines {
+		cosineOf[c.doc] = c.score
+	}
+	if len(cosines) > n {
+		cosines = cosines[:n]
+	}
+	for _, c := range cosines {
+		if _, ok := scores[c.doc]; !ok {
+			scores[c.doc] = 0
+		}
+	}
+
+	combined := make([]scored, 0, len(scores))
+	for doc, bm := range scores {
+		if score := hybridBM25Weight*bm + hybridVectorWeight*cosineOf[doc]; score > 0 {
+			combined = append(combined, scored{doc, score})
+		}
+	}
+	sortScored(combined)
+	if len(combined) > topK {
+		combined = combined[:topK]
+	}
+
+	results := make([]SearchResult, 0, len(combined))
+	for _, r := range combined {
+		results = append(results, SearchResult{Document: s.documents[r.doc], Similarity: r.score})
+	}
+	return results
+}
+
+// contextSearchTimeout — сколько ждать эмбеддинг запроса при сборке контекста
+const contextSearchTimeout = 10 * time.Second
+
 // GetContext получает контекст для LLM
 func (s *Store) GetContext(query string, maxChunks int) string {
-	results := s.SearchByKeywords(query, maxChunks)
+	ctx, cancel := context.WithTimeout(context.Background(), contextSearchTimeout)
+	defer cancel()
+	results := s.Search(ctx, query, maxChunks)
 
 	if len(results) == 0 {
 		return ""
@@ -184,7 +327,14 @@ func (s *Store) GetContext(query string, maxChunks int) string {
 
 	for i, result := range results {
 		sb.WriteString(fmt.Sprintf("--- Источник %d (релевантность: %.2f) ---\n", i+1, result.Similarity))
-		if source, ok := result.Document.Metadata["file"]; ok {
+		meta := result.Document.Metadata
+		if source, ok := meta["file"]; ok {
+			if page := meta["page"]; page != "" {
+				source += ", стр. " + page
+			}
+			if section := meta["section"]; section != "" {
+				source += ", «" + section + "»"
+			}
 			sb.WriteString(fmt.Sprintf("Файл: %s\n", source))
 		}
 		sb.WriteString(result.Document.Content)
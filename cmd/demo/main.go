@@ -8,12 +8,14 @@ import (
 
 	"workbot/internal/generator"
 	"workbot/internal/generator/formatter"
+	"workbot/internal/i18n"
 	"workbot/internal/models"
 )
 
 func main() {
 	scenario := flag.Int("scenario", 0, "Номер сценария (1-4), 0 = все")
 	outputDir := flag.String("output", "./test_programs", "Директория для вывода")
+	lang := flag.String("lang", string(i18n.DefaultLang), "Язык программ (ru, en, ...)")
 	flag.Parse()
 
 	// Определяем путь к данным
@@ -33,7 +35,7 @@ func main() {
 	}
 
 	// Создаём форматтер
-	telegramFormatter := formatter.NewTelegramFormatter()
+	telegramFormatter := formatter.NewTelegramFormatter(i18n.ParseLanguage(*lang))
 
 	// Создаём директорию для вывода
 	if err := os.MkdirAll(*outputDir, 0755); err != nil {
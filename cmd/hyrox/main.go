@@ -0,0 +1,192 @@
+// hyrox раскладывает целевое время забега Hyrox по отрезкам и разбирает симуляции:
+//
+//	hyrox plan -target 1:25:00 -run 4:30 -ski 4:20 -row 4:10 -push 3:00 -pull 4:10
+//	hyrox analyze -target 1:25:00 -run 4:30 ... 5:02 4:35 5:05 3:10 ... [roxzone]
+//
+// Замеры: -run — свежий 1 км, -ski и -row — 1000 м, -push и -pull — 50 м саней
+// соревновательным весом; -burpee, -farmer, -lunges, -wb необязательны. Без -target
+// раскладка строится по прогнозу. analyze принимает 16 времён отрезков в порядке
+// забега (бег, станция, …) и, последним, общее время переходов.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"workbot/internal/hyrox"
+)
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	switch os.Args[1] {
+	case "plan":
+		runPlan(os.Args[2:])
+	case "analyze":
+		runAnalyze(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Использование:")
+	fmt.Fprintln(os.Stderr, "  hyrox plan [-target ч:мм:сс] -run м:сс [-ski м:сс -row м:сс -push м:сс -pull м:сс ...]")
+	fmt.Fprintln(os.Stderr, "  hyrox analyze [флаги plan] время1 время2 ... время16 [roxzone]")
+	os.Exit(2)
+}
+
+// planFlags — цель и замеры; строки разбираются после fs.Parse
+type planFlags struct {
+	target string
+	bench  map[string]*string
+}
+
+func addPlanFlags(fs *flag.FlagSet) *planFlags {
+	f := &planFlags{bench: make(map[string]*string)}
+	fs.StringVar(&f.target, "target", "", "Целевое время, ч:мм:сс; пусто — по прогнозу")
+	for _, b := range []struct{ name, id, usage string }{
+		{"run", hyrox.Run, "Свежий 1 км бега, м:сс"},
+		{"ski", hyrox.SkiErg, "SkiErg 1000 м"},
+		{"row", hyrox.Rowing, "Гребля 1000 м"},
+		{"push", hyrox.SledPush, "Sled Push 50 м"},
+		{"pull", hyrox.SledPull, "Sled Pull 50 м"},
+		{"burpee", hyrox.BurpeeBJ, "Burpee Broad Jump 80 м"},
+		{"farmer", hyrox.FarmerWalk, "Farmer's Carry 200 м"},
+		{"lunges", hyrox.Sandbag, "Sandbag Lunges 100 м"},
+		{"wb", hyrox.WallBall, "Wall Balls 100"},
+	} {
+		f.bench[b.id] = fs.String(b.name, "", b.usage)
+	}
+	return f
+}
+
+// plan строит раскладку по разобранным флагам
+func (f *planFlags) plan() hyrox.Plan {
+	var target time.Duration
+	if f.target != "" {
+		d, err := hyrox.ParseDuration(f.target)
+		if err != nil {
+			log.Fatalf("❌ -target: %v", err)
+		}
+		target = d
+	}
+	bench := make(hyrox.Benchmarks)
+	for id, v := range f.bench {
+		if *v == "" {
+			continue
+		}
+		d, err := hyrox.ParseDuration(*v)
+		if err != nil {
+			log.Fatalf("❌ %s: %v", id, err)
+		}
+		bench[id] = d
+	}
+
+	plan, err := hyrox.PlanRace(target, bench)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	return plan
+}
+
+func runPlan(args []string) {
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	f := addPlanFlags(fs)
+	fs.Parse(args)
+
+	plan := f.plan()
+	fmt.Printf("Цель %s · прогноз по замерам %s · средний темп бега %s /км\n",
+		hyrox.FormatTime(plan.Target), hyrox.FormatTime(plan.Predicted), hyrox.FormatTime(plan.RunPace()))
+	if !plan.Realistic() {
+		fmt.Printf("⚠️  Цель быстрее прогноза на %.0f%%\n", (1-plan.Scale())*100)
+	}
+	fmt.Println()
+
+	var elapsed time.Duration
+	for _, s := range plan.Segments {
+		elapsed += s.Target
+		fmt.Printf("%-22s %8s %9s\n", segmentName(s), hyrox.FormatTime(s.Target), hyrox.FormatTime(elapsed))
+	}
+	if len(plan.Estimated) > 0 {
+		names := make([]string, 0, len(plan.Estimated))
+		for _, id := range plan.Estimated {
+			names = append(names, hyrox.StationName(id))
+		}
+		fmt.Printf("\nБез замеров, оценено по уровню: %s\n", strings.Join(names, ", "))
+	}
+}
+
+func runAnalyze(args []string) {
+	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
+	f := addPlanFlags(fs)
+	fs.Parse(args)
+
+	plan := f.plan()
+	splits, err := hyrox.ParseSplits(strings.Join(fs.Args(), " "))
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	a, err := hyrox.Analyze(plan, splits)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	for _, l := range a.Splits {
+		s, _ := plan.Segment(l.ID)
+		fmt.Printf("%-22s %8s %8s %8s\n", segmentName(s), hyrox.FormatTime(l.Target), hyrox.FormatTime(l.Actual), signed(l.Delta))
+	}
+	fmt.Printf("\nИтого %s при плане %s (%s)\n", hyrox.FormatTime(a.Actual), hyrox.FormatTime(a.Target), signed(a.Delta()))
+
+	fmt.Println("\nГде теряется время:")
+	for _, g := range a.Groups {
+		if g.Delta <= 0 {
+			break
+		}
+		fmt.Printf("  %-20s %8s %+5.0f%%\n", groupName(g.ID), signed(g.Delta), g.Pct()*100)
+	}
+	if a.Fading() {
+		fmt.Printf("\n📉 Бег проседает к концу: последние километры отстают от плана на %.0f%% сильнее первых\n", a.RunFade*100)
+	}
+	if len(a.Focus) > 0 {
+		names := make([]string, 0, len(a.Focus))
+		for _, id := range a.Focus {
+			names = append(names, groupName(id))
+		}
+		fmt.Printf("🎯 Акцент следующих симуляций: %s\n", strings.Join(names, ", "))
+	}
+}
+
+func segmentName(s hyrox.Segment) string {
+	switch s.Kind {
+	case hyrox.KindRun:
+		return fmt.Sprintf("%d. Бег 1 км", s.Num)
+	case hyrox.KindRoxzone:
+		return "Переходы (roxzone)"
+	}
+	return fmt.Sprintf("%d. %s", s.Num, hyrox.StationName(s.ID))
+}
+
+func groupName(id string) string {
+	switch id {
+	case hyrox.Run:
+		return "Бег"
+	case hyrox.Roxzone:
+		return "Переходы"
+	}
+	return hyrox.StationName(id)
+}
+
+func signed(d time.Duration) string {
+	if d >= 0 {
+		return "+" + hyrox.FormatTime(d)
+	}
+	return hyrox.FormatTime(d)
+}
@@ -0,0 +1,343 @@
+// i18ncheck проверяет файлы локализации по исходному коду:
+//   - ключ, переданный в t/tf/tn/T/Tf/Tn/Match/Is, есть в каждом языке;
+//   - во всех языках одинаковый набор ключей и одинаковое число плейсхолдеров;
+//   - у плюральных ключей есть формы для всех категорий правила языка (internal/i18n/plural.go);
+//   - в файлах нет ключей, которые не встречаются в коде.
+//
+// Запускается через go generate ./locales; при ошибках завершается с кодом 1.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"workbot/internal/i18n"
+)
+
+const defaultLang = "ru"
+
+// translateFuncs — функции, первым аргументом которых передаётся ключ перевода
+var translateFuncs = map[string]bool{
+	"t": true, "tf": true, "tn": true,
+	"T": true, "Tf": true, "Tn": true,
+}
+
+// pluralForms — суффиксы форм множественного числа (см. i18n.Tn)
+var pluralForms = []string{".one", ".few", ".many", ".other"}
+
+// formatVerb — плейсхолдер fmt без %%
+var formatVerb = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z]`)
+
+// usage — ключи, найденные в исходном коде
+type usage struct {
+	called   map[string][]string // ключ → позиции вызовов
+	plural   map[string]bool     // ключи, переданные в tn/Tn
+	literals map[string]bool     // все строковые литералы
+	prefixes []string            // литералы слева от «+»: "status_" + status
+}
+
+func main() {
+	localesDir := flag.String("locales", "locales", "каталог с файлами локализации")
+	srcDir := flag.String("src", ".", "корень исходного кода")
+	flag.Parse()
+
+	locales, err := loadLocales(*localesDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	u, err := scanSource(*srcDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	problems := check(locales, u)
+	if len(problems) > 0 {
+		for _, p := range problems {
+			fmt.Fprintln(os.Stderr, p)
+		}
+		fmt.Fprintf(os.Stderr, "i18ncheck: найдено проблем: %d\n", len(problems))
+		os.Exit(1)
+	}
+
+	var langs []string
+	for lang := range locales {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	fmt.Printf("i18ncheck: %d ключей, языки: %s\n", len(locales[defaultLang]), strings.Join(langs, ", "))
+}
+
+// loadLocales читает все *.json каталога
+func loadLocales(dir string) (map[string]map[string]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	locales := make(map[string]map[string]string)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var keys map[string]string
+		if err := json.Unmarshal(data, &keys); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		locales[strings.TrimSuffix(filepath.Base(file), ".json")] = keys
+	}
+	if _, ok := locales[defaultLang]; !ok {
+		return nil, fmt.Errorf("в %s нет %s.json", dir, defaultLang)
+	}
+	return locales, nil
+}
+
+// scanSource собирает ключи и строковые литералы из .go файлов (без тестов)
+func scanSource(root string) (*usage, error) {
+	u := &usage{
+		called:   make(map[string][]string),
+		plural:   make(map[string]bool),
+		literals: make(map[string]bool),
+	}
+	fset := token.NewFileSet()
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			switch info.Name() {
+			case ".git", "vendor", "node_modules":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.BasicLit:
+				if s, ok := stringLit(node); ok {
+					u.literals[s] = true
+				}
+			case *ast.BinaryExpr:
+				if node.Op == token.ADD {
+					if s, ok := stringLit(node.X); ok && s != "" {
+						u.prefixes = append(u.prefixes, s)
+					}
+				}
+			case *ast.CallExpr:
+				u.addCall(fset, node)
+			}
+			return true
+		})
+		return nil
+	})
+	return u, err
+}
+
+// addCall запоминает ключи из вызова функции перевода
+func (u *usage) addCall(fset *token.FileSet, call *ast.CallExpr) {
+	var name string
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		name = fn.Name
+	case *ast.SelectorExpr:
+		name = fn.Sel.Name
+	default:
+		return
+	}
+
+	var keyArgs []ast.Expr
+	switch {
+	case translateFuncs[name] && len(call.Args) > 0:
+		keyArgs = call.Args[:1]
+	case name == "Match" && len(call.Args) > 1:
+		keyArgs = call.Args[1:]
+	case name == "Is" && len(call.Args) == 2:
+		keyArgs = call.Args[1:]
+	}
+
+	for _, arg := range keyArgs {
+		key, ok := stringLit(arg)
+		if !ok {
+			continue
+		}
+		u.called[key] = append(u.called[key], fset.Position(arg.Pos()).String())
+		if name == "tn" || name == "Tn" {
+			u.plural[key] = true
+		}
+	}
+}
+
+func stringLit(e ast.Expr) (string, bool) {
+	lit, ok := e.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
+
+// check сравнивает файлы локализации с кодом и между собой
+func check(locales map[string]map[string]string, u *usage) []string {
+	var problems []string
+	base := locales[defaultLang]
+
+	// Ключи из кода, которых нет в языке по умолчанию
+	for key, positions := range u.called {
+		if u.plural[key] {
+			if !hasPluralForms(base, key) {
+				problems = append(problems, fmt.Sprintf("%s: нет форм множественного числа %s.* в %s.json", positions[0], key, defaultLang))
+			}
+			continue
+		}
+		if _, ok := base[key]; !ok {
+			problems = append(problems, fmt.Sprintf("%s: нет ключа %q в %s.json", positions[0], key, defaultLang))
+		}
+	}
+
+	// Остальные языки должны совпадать с языком по умолчанию
+	for lang, keys := range locales {
+		if lang == defaultLang {
+			continue
+		}
+		for key, text := range base {
+			other, ok := keys[key]
+			if !ok {
+				if !isPluralForm(key) {
+					problems = append(problems, fmt.Sprintf("%s.json: нет ключа %q", lang, key))
+				}
+				continue
+			}
+			if countVerbs(text) != countVerbs(other) {
+				problems = append(problems, fmt.Sprintf("%s.json: ключ %q — %d плейсхолдеров вместо %d", lang, key, countVerbs(other), countVerbs(text)))
+			}
+		}
+		for key := range keys {
+			if _, ok := base[key]; !ok && !isPluralForm(key) {
+				problems = append(problems, fmt.Sprintf("%s.json: ключ %q отсутствует в %s.json", lang, key, defaultLang))
+			}
+		}
+	}
+
+	// Формы множественного числа зависят от языка: нужна каждая категория его правила CLDR
+	for lang, keys := range locales {
+		for key := range base {
+			if !isPluralForm(key) {
+				continue
+			}
+			if missing := missingPluralForms(keys, pluralBase(key), lang); len(missing) > 0 {
+				problems = append(problems, fmt.Sprintf("%s.json: нет форм множественного числа %s.{%s}", lang, pluralBase(key), strings.Join(missing, ",")))
+			}
+		}
+	}
+
+	// Неиспользуемые ключи
+	for key := range base {
+		if !u.isUsed(key) {
+			problems = append(problems, fmt.Sprintf("%s.json: ключ %q не используется в коде", defaultLang, key))
+		}
+	}
+
+	sort.Strings(problems)
+	return dedupe(problems)
+}
+
+func (u *usage) isUsed(key string) bool {
+	if u.literals[key] {
+		return true
+	}
+	if isPluralForm(key) && (u.plural[pluralBase(key)] || u.literals[pluralBase(key)]) {
+		return true
+	}
+	for _, prefix := range u.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPluralForm(key string) bool {
+	for _, form := range pluralForms {
+		if strings.HasSuffix(key, form) {
+			return true
+		}
+	}
+	return false
+}
+
+func pluralBase(key string) string {
+	return key[:strings.LastIndex(key, ".")]
+}
+
+func hasPluralForms(keys map[string]string, base string) bool {
+	for _, form := range pluralForms {
+		if _, ok := keys[base+form]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// missingPluralForms возвращает категории правила языка lang, для которых у base нет формы.
+// Форма .other подходит для любой категории: ею i18n.Tn заменяет недостающие
+func missingPluralForms(keys map[string]string, base, lang string) []string {
+	if _, ok := keys[base+".other"]; ok {
+		return nil
+	}
+	var missing []string
+	for _, category := range pluralCategories(i18n.Language(lang)) {
+		if _, ok := keys[base+"."+category]; !ok {
+			missing = append(missing, category)
+		}
+	}
+	return missing
+}
+
+// pluralCategories — категории, которые правило языка выдаёт для неотрицательных целых чисел
+func pluralCategories(lang i18n.Language) []string {
+	var categories []string
+	seen := make(map[string]bool)
+	for n := 0; n < 200; n++ {
+		if category := i18n.PluralCategory(lang, n); !seen[category] {
+			seen[category] = true
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+func countVerbs(s string) int {
+	return len(formatVerb.FindAllString(strings.ReplaceAll(s, "%%", ""), -1))
+}
+
+func dedupe(sorted []string) []string {
+	var out []string
+	for i, s := range sorted {
+		if i == 0 || s != sorted[i-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
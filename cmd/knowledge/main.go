@@ -0,0 +1,118 @@
+// knowledge собирает и проверяет индекс базы знаний (knowledge.json):
+//
+//	knowledge build -o knowledge.json [-chunk 220] [-overlap 40] [-embed-model nomic-embed-text] книги/ методички.pdf
+//	knowledge search -index knowledge.json "периодизация жима"
+//
+// build читает PDF, Markdown и текстовые файлы, режет их на фрагменты
+// с перекрытием и строит BM25-индекс; с -embed-model для каждого фрагмента
+// считается эмбеддинг на локальном сервере (-embed-url, по умолчанию Ollama).
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"workbot/clients/knowledge"
+)
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	switch os.Args[1] {
+	case "build":
+		runBuild(ctx, os.Args[2:])
+	case "search":
+		runSearch(ctx, os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Использование:")
+	fmt.Fprintln(os.Stderr, "  knowledge build -o knowledge.json [флаги] файлы и каталоги...")
+	fmt.Fprintln(os.Stderr, "  knowledge search -index knowledge.json [-k 5] \"запрос\"")
+	os.Exit(2)
+}
+
+func runBuild(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("build", flag.ExitOnError)
+	out := fs.String("o", "knowledge.json", "Файл индекса")
+	chunk := fs.Int("chunk", knowledge.DefaultChunkWords, "Размер фрагмента, слов")
+	overlap := fs.Int("overlap", knowledge.DefaultOverlapWords, "Перекрытие соседних фрагментов, слов")
+	embedURL := fs.String("embed-url", envOr("KNOWLEDGE_EMBED_URL", knowledge.DefaultEmbedURL), "Эндпоинт эмбеддингов")
+	embedModel := fs.String("embed-model", "", "Модель эмбеддингов; пусто — индекс только BM25")
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		log.Fatal("❌ Укажите файлы или каталоги с книгами")
+	}
+
+	opts := knowledge.BuildOptions{
+		ChunkWords:   *chunk,
+		OverlapWords: *overlap,
+		Log: func(format string, args ...interface{}) {
+			log.Printf("📄 "+format, args...)
+		},
+	}
+	if *embedModel != "" {
+		opts.Embedder = knowledge.NewHTTPEmbedder(*embedURL, *embedModel)
+	}
+
+	start := time.Now()
+	index, err := knowledge.Build(ctx, fs.Args(), opts)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	if err := index.Save(*out); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	log.Printf("✅ %s: источников — %d, фрагментов — %d, термов — %d, %.1f сек",
+		*out, len(index.Sources), len(index.Documents), len(index.BM25.Postings), time.Since(start).Seconds())
+}
+
+func runSearch(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	indexPath := fs.String("index", "knowledge.json", "Файл индекса")
+	topK := fs.Int("k", 5, "Сколько фрагментов показать")
+	fs.Parse(args)
+	query := strings.Join(fs.Args(), " ")
+	if query == "" {
+		log.Fatal("❌ Укажите запрос")
+	}
+
+	store := knowledge.NewStore()
+	if err := store.Load(*indexPath); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	if info := store.EmbeddingInfo(); info != nil && info.Model != "" {
+		store.SetEmbedder(knowledge.NewHTTPEmbedder(envOr("KNOWLEDGE_EMBED_URL", info.URL), info.Model))
+	}
+
+	for i, r := range store.Search(ctx, query, *topK) {
+		meta := r.Document.Metadata
+		fmt.Printf("%d. [%.3f] %s", i+1, r.Similarity, r.Document.ID)
+		if page := meta["page"]; page != "" {
+			fmt.Printf(" (стр. %s)", page)
+		}
+		fmt.Printf("\n%s\n\n", r.Document.Content)
+	}
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
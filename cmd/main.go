@@ -4,14 +4,17 @@ import (
 	"database/sql"
 	"log"
 	"path/filepath"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	_ "github.com/lib/pq"
 
 	"workbot/internal/bot"
+	"workbot/internal/calendar"
 	"workbot/internal/config"
 	"workbot/internal/excel"
 	"workbot/internal/i18n"
+	"workbot/internal/scheduler"
 )
 
 func main() {
@@ -54,12 +57,18 @@ func main() {
 	log.Printf("Журнал: %s", cfg.JournalPath)
 	log.Printf("Клиенты: %s", cfg.ClientsDir)
 
+	// Планировщик фоновых задач (cron-расписания — в поясе по умолчанию)
+	jobs := scheduler.New(db, calendar.LoadLocation(cfg.DefaultTimezone, time.Local))
+
 	// Запускаем наблюдение за Excel файлами
 	excelWatcher := excel.NewWatcher(botAPI, db, cfg.WorkDir)
 	excelWatcher.StartWatching()
+	if err := excelWatcher.RegisterJobs(jobs); err != nil {
+		log.Printf("Ошибка регистрации задач Excel: %v", err)
+	}
 
 	// Запускаем бота
-	telegramBot := bot.New(botAPI, db, cfg)
+	telegramBot := bot.New(botAPI, db, cfg, jobs)
 	log.Println("Бот запущен и готов к работе")
 	if err := telegramBot.Start(); err != nil {
 		log.Fatal(err)
@@ -10,6 +10,7 @@ import (
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/calendar/v3"
 	"google.golang.org/api/sheets/v4"
 )
 
@@ -28,6 +29,7 @@ func main() {
 	config, err := google.ConfigFromJSON(b,
 		sheets.SpreadsheetsScope,
 		"https://www.googleapis.com/auth/drive",
+		calendar.CalendarEventsScope,
 	)
 	if err != nil {
 		log.Fatalf("Ошибка парсинга credentials: %v", err)
@@ -97,4 +99,5 @@ func main() {
 	fmt.Println("\nДобавьте в .env:")
 	fmt.Printf("GOOGLE_OAUTH_CREDENTIALS_PATH=oauth-credentials.json\n")
 	fmt.Printf("GOOGLE_TOKEN_PATH=google-token.json\n")
+	fmt.Printf("GOOGLE_CALENDAR_ID=primary  # синхронизация записей с Google Calendar, можно не задавать\n")
 }
@@ -8,6 +8,7 @@ import (
 	"log"
 	"math"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -15,7 +16,9 @@ import (
 	"workbot/clients/ai"
 	"workbot/clients/knowledge"
 	"workbot/internal/gsheets"
+	"workbot/internal/interchange"
 	"workbot/internal/models"
+	"workbot/internal/programpdf"
 	"workbot/internal/training"
 )
 
@@ -75,6 +78,14 @@ func loadKnowledgeIfNeeded() {
 		knowledgeStore = nil
 	} else {
 		log.Printf("📚 Загружено %d документов за %.1f сек", knowledgeStore.Count(), time.Since(start).Seconds())
+		// Индекс с эмбеддингами: запрос векторизуется той же моделью (гибридный поиск)
+		if info := knowledgeStore.EmbeddingInfo(); info != nil && info.Model != "" {
+			url := os.Getenv("KNOWLEDGE_EMBED_URL")
+			if url == "" {
+				url = info.URL
+			}
+			knowledgeStore.SetEmbedder(knowledge.NewHTTPEmbedder(url, info.Model))
+		}
 	}
 }
 
@@ -99,7 +110,10 @@ func main() {
 	loadEnvFile()
 
 	// Флаги командной строки
-	jsonFile := flag.String("json", "", "Путь к JSON файлу с планом (ручной режим)")
+	jsonFile := flag.String("json", "", "Путь к файлу программы JSON/YAML (ручной режим, формат docs/program_format.md)")
+	checkFile := flag.String("check", "", "Проверить файл программы JSON/YAML")
+	convertFile := flag.String("convert", "", "Конвертировать файл программы (в т.ч. старого формата)")
+	convertOut := flag.String("o", "", "Файл результата для -convert (.json, .yaml, .pdf — для печати)")
 	credentials := flag.String("creds", "google-credentials.json", "Путь к Google credentials")
 	folderID := flag.String("folder", "", "ID папки Google Drive")
 
@@ -130,7 +144,7 @@ func main() {
 	listStates := flag.Bool("list-states", false, "Показать сохранённые состояния генерации")
 
 	// Примеры
-	example := flag.Bool("example", false, "Показать пример JSON")
+	example := flag.Bool("example", false, "Показать пример файла программы")
 	exampleComp := flag.Bool("example-comp", false, "Показать пример соревновательной подготовки")
 	calcWeeks := flag.String("calc-weeks", "", "Рассчитать недели до даты (DD.MM.YYYY)")
 
@@ -153,6 +167,16 @@ func main() {
 		return
 	}
 
+	// Проверка и конвертация файлов программ
+	if *checkFile != "" {
+		runCheckMode(*checkFile)
+		return
+	}
+	if *convertFile != "" {
+		runConvertMode(*convertFile, *convertOut)
+		return
+	}
+
 	// Список сохранённых состояний
 	if *listStates {
 		listSavedStates()
@@ -1495,7 +1519,7 @@ func getPhaseFocus(phase models.PlanPhase) string {
 }
 
 func savePlanToJSON(plan *models.TrainingPlan, filename string) {
-	data, err := json.MarshalIndent(plan, "", "  ")
+	data, err := interchange.Encode(interchange.FromTrainingPlan(plan), interchange.FormatJSON)
 	if err != nil {
 		log.Printf("Ошибка сериализации: %v", err)
 		return
@@ -1510,18 +1534,103 @@ func savePlanToJSON(plan *models.TrainingPlan, filename string) {
 }
 
 func runJSONMode(jsonFile, credentials, folderID string) {
-	data, err := os.ReadFile(jsonFile)
+	plan, err := loadPlanFile(jsonFile)
+	if err != nil {
+		log.Fatalf("Ошибка чтения %s:\n%v", jsonFile, err)
+	}
+
+	printPlanSummary(plan)
+	createGoogleSheet(plan, credentials, folderID)
+}
+
+// loadPlanFile читает программу в формате обмена (JSON/YAML).
+// Файлы старого формата models.TrainingPlan (без поля version) читаются с предупреждением
+func loadPlanFile(path string) (*models.TrainingPlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	format, ok := interchange.FormatFromFilename(path)
+	if !ok {
+		format = interchange.DetectFormat(data)
+	}
+
+	if format == interchange.FormatJSON && isLegacyPlanJSON(data) {
+		var plan models.TrainingPlan
+		if err := json.Unmarshal(data, &plan); err != nil {
+			return nil, err
+		}
+		log.Printf("⚠️ Файл в устаревшем формате, пересохраните его: plancli -convert %s", path)
+		return &plan, nil
+	}
+
+	program, err := interchange.Decode(data, format)
+	if err != nil {
+		return nil, err
+	}
+	return interchange.ToTrainingPlan(program), nil
+}
+
+// isLegacyPlanJSON проверяет, что JSON записан в старом формате (без поля version)
+func isLegacyPlanJSON(data []byte) bool {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return false
+	}
+	_, hasVersion := probe["version"]
+	_, hasWeeks := probe["weeks"]
+	return !hasVersion && hasWeeks
+}
+
+// runCheckMode проверяет файл программы и печатает ошибки с путями к полям
+func runCheckMode(path string) {
+	data, err := os.ReadFile(path)
 	if err != nil {
 		log.Fatalf("Ошибка чтения файла: %v", err)
 	}
+	format, ok := interchange.FormatFromFilename(path)
+	if !ok {
+		format = interchange.DetectFormat(data)
+	}
+
+	program, err := interchange.Decode(data, format)
+	if err != nil {
+		fmt.Printf("❌ %s:\n%v\n", path, err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ %s: «%s», %d нед.\n", path, program.Name, program.TotalWeeks())
+}
+
+// runConvertMode конвертирует файл программы (в том числе старого формата) в JSON или YAML
+// либо выводит её в PDF для печати
+func runConvertMode(path, output string) {
+	plan, err := loadPlanFile(path)
+	if err != nil {
+		log.Fatalf("Ошибка чтения %s:\n%v", path, err)
+	}
 
-	var plan models.TrainingPlan
-	if err := json.Unmarshal(data, &plan); err != nil {
-		log.Fatalf("Ошибка парсинга JSON: %v", err)
+	if output == "" {
+		output = strings.TrimSuffix(path, filepath.Ext(path)) + ".yaml"
 	}
 
-	printPlanSummary(&plan)
-	createGoogleSheet(&plan, credentials, folderID)
+	var data []byte
+	if strings.EqualFold(filepath.Ext(output), ".pdf") {
+		data, err = programpdf.Bytes(interchange.FromTrainingPlan(plan), programpdf.Options{})
+	} else {
+		format, ok := interchange.FormatFromFilename(output)
+		if !ok {
+			log.Fatalf("Неизвестное расширение %s (ожидается .json, .yaml, .yml или .pdf)", output)
+		}
+		data, err = interchange.Encode(interchange.FromTrainingPlan(plan), format)
+	}
+	if err != nil {
+		log.Fatalf("Ошибка сериализации: %v", err)
+	}
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		log.Fatalf("Ошибка записи файла: %v", err)
+	}
+	fmt.Printf("💾 Сохранено в %s\n", output)
 }
 
 func printUsage() {
@@ -1541,8 +1650,11 @@ func printUsage() {
 	fmt.Println("            -1pm \"Жим лёжа:100,Ягодичный мост:180\" \\")
 	fmt.Println("            -target \"Жим лёжа:110,Ягодичный мост:200\"")
 	fmt.Println()
-	fmt.Println("  Из JSON файла:")
-	fmt.Println("    plancli -json plan.json")
+	fmt.Println("  Из файла программы (JSON/YAML):")
+	fmt.Println("    plancli -json plan.yaml")
+	fmt.Println("    plancli -check plan.yaml          # только проверка")
+	fmt.Println("    plancli -convert old.json -o plan.yaml")
+	fmt.Println("    plancli -convert plan.yaml -o plan.pdf  # печатная версия")
 	fmt.Println()
 	fmt.Println("ФЛАГИ:")
 	flag.PrintDefaults()
@@ -1560,7 +1672,7 @@ func printUsage() {
 	fmt.Println("  strict_curl  - Строгий подъём на бицепс")
 	fmt.Println()
 	fmt.Println("ПРИМЕРЫ:")
-	fmt.Println("  plancli -example        # Пример JSON")
+	fmt.Println("  plancli -example        # Пример файла программы")
 	fmt.Println("  plancli -example-comp   # Пример соревновательной подготовки")
 	fmt.Println("  plancli -calc-weeks 15.03.2026  # Рассчитать недели")
 }
@@ -1604,7 +1716,36 @@ func calculateWeeksToDate(dateStr string) {
 }
 
 func printExample() {
-	fmt.Println("Пример JSON для стандартной программы — используйте -example-comp для соревнований")
+	example := &interchange.Program{
+		Version:     interchange.SchemaVersion,
+		Name:        "Сила 3x/нед",
+		Goal:        GoalStrength,
+		StartDate:   time.Now().Format("2006-01-02"),
+		DaysPerWeek: 3,
+		OneRM:       map[string]float64{"Жим лёжа": 100},
+		Weeks: []interchange.Week{{
+			Week: 1,
+			Days: []interchange.Day{{
+				Day:  1,
+				Name: "День A",
+				Exercises: []interchange.Exercise{
+					{Name: "Жим лёжа", RestSeconds: 180, Sets: []interchange.SetGroup{
+						{Sets: 5, Reps: "5", Percent: 75},
+					}},
+					{Name: "Подтягивания", Tempo: "2-0-1-0", Sets: []interchange.SetGroup{
+						{Sets: 3, Reps: "8-10", RPE: 8},
+					}},
+				},
+			}},
+		}},
+	}
+
+	data, err := interchange.Encode(example, interchange.FormatYAML)
+	if err != nil {
+		log.Fatalf("Ошибка сериализации: %v", err)
+	}
+	fmt.Println("# Пример программы (YAML, формат docs/program_format.md) — используйте -example-comp для соревнований")
+	fmt.Print(string(data))
 }
 
 func printCompetitionExample() {
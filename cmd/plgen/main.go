@@ -5,13 +5,21 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
 	"workbot/clients/ai"
+	"workbot/internal/interchange"
+	"workbot/internal/programpdf"
 )
 
 func main() {
+	// plgen lint [шаблон.json ...] — проверка шаблонов; без файлов — встроенные
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		os.Exit(runLint(os.Args[2:]))
+	}
+
 	// Флаги
 	listTemplates := flag.Bool("list", false, "Показать доступные шаблоны")
 	templateName := flag.String("template", "", "Имя шаблона для генерации")
@@ -22,7 +30,7 @@ func main() {
 	liftType := flag.String("lift", "full", "Тип дисциплины: full (троеборье), bench (жим), squat (присед), deadlift (тяга), hipthrust (ягодичный мост)")
 	daysPerWeek := flag.Int("days", 0, "Количество тренировок в неделю (2-4, 0 = как в шаблоне)")
 	autoSelect := flag.Bool("auto", false, "Автоматически выбрать шаблон по уровню атлета")
-	output := flag.String("output", "", "Файл для сохранения программы (markdown)")
+	output := flag.String("output", "", "Файл для сохранения программы (.md — текст, .json/.yaml — формат обмена, .pdf — для печати)")
 
 	flag.Parse()
 
@@ -104,18 +112,15 @@ func main() {
 		}
 	}
 
-	// Форматируем вывод
-	formatted := ai.FormatPLProgram(program)
-
 	if *output != "" {
-		err := os.WriteFile(*output, []byte(formatted), 0644)
+		err := writeProgramFile(*output, program)
 		if err != nil {
 			fmt.Printf("❌ Ошибка записи файла: %v\n", err)
 			os.Exit(1)
 		}
 		fmt.Printf("✅ Программа сохранена в %s\n", *output)
 	} else {
-		fmt.Println(formatted)
+		fmt.Println(ai.FormatPLProgram(program))
 	}
 
 	// Статистика
@@ -300,15 +305,14 @@ func runInteractive(gen *ai.ProgramGenerator) {
 	fmt.Print("\nСохранить программу? [y/N]: ")
 	input, _ = reader.ReadString('\n')
 	if strings.ToLower(strings.TrimSpace(input)) == "y" {
-		fmt.Print("Имя файла [program.md]: ")
+		fmt.Print("Имя файла (.md, .json, .yaml, .pdf) [program.md]: ")
 		input, _ = reader.ReadString('\n')
 		filename := strings.TrimSpace(input)
 		if filename == "" {
 			filename = "program.md"
 		}
 
-		formatted := ai.FormatPLProgram(program)
-		err := os.WriteFile(filename, []byte(formatted), 0644)
+		err := writeProgramFile(filename, program)
 		if err != nil {
 			fmt.Printf("❌ Ошибка: %v\n", err)
 			return
@@ -323,3 +327,79 @@ func runInteractive(gen *ai.ProgramGenerator) {
 		fmt.Println("\n" + ai.FormatWeekCompact(program.Weeks[0]))
 	}
 }
+
+// runLint проверяет шаблоны по схеме и резолверу упражнений; код выхода 1 — есть ошибки
+func runLint(files []string) int {
+	failed := 0
+	report := func(name string, issues ai.TemplateIssues) {
+		if len(issues) == 0 {
+			fmt.Printf("✅ %s\n", name)
+			return
+		}
+		failed++
+		fmt.Printf("❌ %s\n", name)
+		for _, issue := range issues {
+			fmt.Printf("  %s\n", issue)
+		}
+	}
+
+	if len(files) == 0 {
+		gen, err := ai.NewProgramGenerator()
+		if err != nil {
+			fmt.Printf("❌ Ошибка загрузки шаблонов: %v\n", err)
+			return 1
+		}
+		issues := gen.LintTemplates()
+		for _, name := range gen.ListTemplates() {
+			report(name, issues[name])
+		}
+	}
+
+	for _, file := range files {
+		t, err := readTemplate(file)
+		if err != nil {
+			failed++
+			fmt.Printf("❌ %s\n  %v\n", file, err)
+			continue
+		}
+		report(file, ai.LintTemplate(t))
+	}
+
+	if failed > 0 {
+		fmt.Printf("\nШаблонов с ошибками: %d\n", failed)
+		return 1
+	}
+	return 0
+}
+
+// readTemplate читает шаблон из файла
+func readTemplate(file string) (*ai.PowerliftingTemplate, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return ai.ParseTemplate(data)
+}
+
+// writeProgramFile сохраняет программу: .json/.yaml — в формате обмена, .pdf — для печати,
+// остальное — текстом
+func writeProgramFile(filename string, program *ai.PLGeneratedProgram) error {
+	if strings.EqualFold(filepath.Ext(filename), ".pdf") {
+		data, err := programpdf.Bytes(interchange.FromPL(program), programpdf.Options{OneRM: program.AthleteMaxes.ForExercise})
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filename, data, 0644)
+	}
+
+	format, ok := interchange.FormatFromFilename(filename)
+	if !ok {
+		return os.WriteFile(filename, []byte(ai.FormatPLProgram(program)), 0644)
+	}
+
+	data, err := interchange.Encode(interchange.FromPL(program), format)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
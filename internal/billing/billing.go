@@ -0,0 +1,272 @@
+// Package billing — пакеты тренировок, абонементы клиентов и оплата.
+//
+// Тренер продаёт пакеты двух видов: на N тренировок (с необязательным сроком
+// действия) и на срок — например, месяц ведения без ограничения числа
+// тренировок. Купленный пакет становится абонементом клиента. Когда тренер
+// отмечает запись проведённой, списание идёт с абонемента на срок, который
+// действует в день тренировки, иначе — с пакета тренировок, который раньше
+// всех истекает. Суммы хранятся в минимальных единицах валюты (копейках),
+// как их передаёт Telegram Payments. Пакет не знает о базе и боте.
+package billing
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind — вид пакета
+type Kind string
+
+const (
+	// Sessions — N тренировок; срок действия необязателен
+	Sessions Kind = "sessions"
+	// Period — безлимит на срок: ведение на месяц, квартал
+	Period Kind = "period"
+)
+
+// DefaultCurrency — валюта пакетов, если не задана в настройках
+const DefaultCurrency = "RUB"
+
+const (
+	// LowSessions — при стольких оставшихся тренировках клиенту напоминают о продлении
+	LowSessions = 1
+	// ExpiryNotice — за столько до окончания абонемента клиенту напоминают о продлении
+	ExpiryNotice = 3 * 24 * time.Hour
+	// maxPrice — верхняя граница цены пакета, минимальных единиц
+	maxPrice = 100_000_000_00
+)
+
+// Ошибки проверки пакета
+var (
+	ErrNoName     = errors.New("billing: не указано название пакета")
+	ErrBadKind    = errors.New("billing: неизвестный вид пакета")
+	ErrNoSessions = errors.New("billing: в пакете должна быть хотя бы одна тренировка")
+	ErrNoDuration = errors.New("billing: у пакета на срок должна быть длительность")
+	ErrBadPrice   = errors.New("billing: некорректная цена")
+)
+
+// Package — пакет из каталога тренера
+type Package struct {
+	ID        int
+	TrainerID int64
+	Name      string
+	Kind      Kind
+	// Sessions — число тренировок; для пакета на срок — 0
+	Sessions int
+	// Days — срок действия в днях; 0 у пакета тренировок — бессрочно
+	Days     int
+	Price    int64 // минимальные единицы валюты
+	Currency string
+	Active   bool
+}
+
+// Validate проверяет заполнение пакета
+func (p Package) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return ErrNoName
+	}
+	switch p.Kind {
+	case Sessions:
+		if p.Sessions <= 0 {
+			return ErrNoSessions
+		}
+	case Period:
+		if p.Days <= 0 {
+			return ErrNoDuration
+		}
+	default:
+		return ErrBadKind
+	}
+	if p.Days < 0 || p.Price <= 0 || p.Price > maxPrice {
+		return ErrBadPrice
+	}
+	return nil
+}
+
+// Balance — абонемент клиента: купленный пакет и его остаток
+type Balance struct {
+	ID        int
+	ClientID  int
+	PackageID int
+	Name      string
+	Kind      Kind
+	// Total и Left — куплено и осталось тренировок; для абонемента на срок — 0
+	Total int
+	Left  int
+	// Starts — первый день действия, Expires — последний; нулевой Expires — бессрочно
+	Starts  time.Time
+	Expires time.Time
+}
+
+// Activate создаёт абонемент из пакета, купленного в день start
+func Activate(p Package, clientID int, start time.Time) Balance {
+	start = day(start)
+	b := Balance{
+		ClientID:  clientID,
+		PackageID: p.ID,
+		Name:      p.Name,
+		Kind:      p.Kind,
+		Starts:    start,
+	}
+	if p.Kind == Sessions {
+		b.Total, b.Left = p.Sessions, p.Sessions
+	}
+	if p.Days > 0 {
+		b.Expires = start.AddDate(0, 0, p.Days-1)
+	}
+	return b
+}
+
+// Covers сообщает, что абонементом можно оплатить тренировку в день on
+func (b Balance) Covers(on time.Time) bool {
+	on = day(on)
+	if on.Before(day(b.Starts)) {
+		return false
+	}
+	if !b.Expires.IsZero() && on.After(day(b.Expires)) {
+		return false
+	}
+	return b.Kind == Period || b.Left > 0
+}
+
+// Expired сообщает, что срок абонемента закончился до дня on
+func (b Balance) Expired(on time.Time) bool {
+	return !b.Expires.IsZero() && day(on).After(day(b.Expires))
+}
+
+// Pick выбирает абонемент для оплаты тренировки в день on: сначала абонемент
+// на срок, затем пакет тренировок, который раньше истекает, при равенстве —
+// купленный раньше. ok == false — оплатить нечем
+func Pick(balances []Balance, on time.Time) (Balance, bool) {
+	var candidates []Balance
+	for _, b := range balances {
+		if b.Covers(on) {
+			candidates = append(candidates, b)
+		}
+	}
+	if len(candidates) == 0 {
+		return Balance{}, false
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if (a.Kind == Period) != (b.Kind == Period) {
+			return a.Kind == Period
+		}
+		if a.Expires.IsZero() != b.Expires.IsZero() {
+			return !a.Expires.IsZero()
+		}
+		if !a.Expires.Equal(b.Expires) {
+			return a.Expires.Before(b.Expires)
+		}
+		if !a.Starts.Equal(b.Starts) {
+			return a.Starts.Before(b.Starts)
+		}
+		return a.ID < b.ID
+	})
+	return candidates[0], true
+}
+
+// Reminder — повод напомнить о продлении
+type Reminder string
+
+const (
+	NoReminder      Reminder = ""
+	ReminderLow     Reminder = "low"     // осталось LowSessions тренировок или меньше
+	ReminderExpires Reminder = "expires" // до окончания меньше ExpiryNotice
+)
+
+// Remind возвращает повод напомнить клиенту о продлении на момент now.
+// Напоминание не нужно, если у клиента есть другой абонемент, которым
+// можно оплатить тренировки после окончания этого
+func Remind(b Balance, others []Balance, now time.Time) Reminder {
+	if b.Expired(now) {
+		return NoReminder
+	}
+	var reason Reminder
+	switch {
+	case b.Kind == Sessions && b.Left <= LowSessions:
+		reason = ReminderLow
+	case !b.Expires.IsZero() && day(b.Expires).Sub(day(now)) < ExpiryNotice:
+		reason = ReminderExpires
+	default:
+		return NoReminder
+	}
+
+	// Тренировки после окончания этого абонемента
+	after := day(now)
+	if reason == ReminderExpires {
+		after = day(b.Expires).AddDate(0, 0, 1)
+	}
+	for _, o := range others {
+		if o.ID == b.ID {
+			continue
+		}
+		if o.Kind == Sessions && o.Left > LowSessions && o.Covers(after) {
+			return NoReminder
+		}
+		if o.Kind == Period && o.Covers(after) {
+			return NoReminder
+		}
+	}
+	return reason
+}
+
+// FormatMoney форматирует сумму в минимальных единицах: «4 500 RUB», «990,50 RUB»
+func FormatMoney(amount int64, currency string) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	whole := strconv.FormatInt(amount/100, 10)
+	var grouped strings.Builder
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteString(" ")
+		}
+		grouped.WriteRune(r)
+	}
+	s := sign + grouped.String()
+	if cents := amount % 100; cents != 0 {
+		s += fmt.Sprintf(",%02d", cents)
+	}
+	return s + " " + currency
+}
+
+// ParseMoney разбирает сумму, введённую тренером: «4500», «4 500», «990,50»,
+// «990.5». Возвращает минимальные единицы валюты
+func ParseMoney(s string) (int64, error) {
+	s = strings.NewReplacer(" ", "", " ", "", ",", ".").Replace(strings.TrimSpace(s))
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	if whole == "" || (hasFrac && (frac == "" || len(frac) > 2)) {
+		return 0, ErrBadPrice
+	}
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units < 0 || units > maxPrice/100 {
+		return 0, ErrBadPrice
+	}
+	var cents int64
+	if hasFrac {
+		if len(frac) == 1 {
+			frac += "0"
+		}
+		if cents, err = strconv.ParseInt(frac, 10, 64); err != nil || cents < 0 {
+			return 0, ErrBadPrice
+		}
+	}
+	amount := units*100 + cents
+	if amount <= 0 {
+		return 0, ErrBadPrice
+	}
+	return amount, nil
+}
+
+// day отбрасывает время: календарная дата в исходном поясе как полночь UTC,
+// чтобы даты из базы и даты по поясу тренера сравнивались напрямую
+func day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,250 @@
+package billing
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPackageValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		pkg  Package
+		want error
+	}{
+		{"тренировки", Package{Name: "10 тренировок", Kind: Sessions, Sessions: 10, Price: 2500000}, nil},
+		{"на срок", Package{Name: "Месяц", Kind: Period, Days: 30, Price: 1500000}, nil},
+		{"без названия", Package{Name: " ", Kind: Sessions, Sessions: 10, Price: 100}, ErrNoName},
+		{"неизвестный вид", Package{Name: "X", Kind: "gift", Sessions: 1, Price: 100}, ErrBadKind},
+		{"без тренировок", Package{Name: "X", Kind: Sessions, Price: 100}, ErrNoSessions},
+		{"без срока", Package{Name: "X", Kind: Period, Price: 100}, ErrNoDuration},
+		{"бесплатно", Package{Name: "X", Kind: Sessions, Sessions: 1}, ErrBadPrice},
+		{"слишком дорого", Package{Name: "X", Kind: Sessions, Sessions: 1, Price: maxPrice + 1}, ErrBadPrice},
+	}
+	for _, tt := range tests {
+		if err := tt.pkg.Validate(); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Validate() = %v, хотим %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestActivate(t *testing.T) {
+	start := time.Date(2026, 3, 10, 22, 30, 0, 0, time.FixedZone("MSK", 3*3600))
+
+	b := Activate(Package{ID: 7, Name: "10 тренировок", Kind: Sessions, Sessions: 10, Days: 60}, 3, start)
+	if b.ClientID != 3 || b.PackageID != 7 || b.Total != 10 || b.Left != 10 {
+		t.Errorf("Activate = %+v", b)
+	}
+	if !b.Starts.Equal(date(2026, 3, 10)) || !b.Expires.Equal(date(2026, 5, 8)) {
+		t.Errorf("срок %s — %s, хотим 10.03 — 08.05", b.Starts, b.Expires)
+	}
+
+	b = Activate(Package{Name: "Бессрочный", Kind: Sessions, Sessions: 5}, 3, start)
+	if !b.Expires.IsZero() {
+		t.Errorf("бессрочный пакет истекает %s", b.Expires)
+	}
+
+	b = Activate(Package{Name: "Месяц", Kind: Period, Days: 30}, 3, start)
+	if b.Total != 0 || b.Left != 0 || !b.Expires.Equal(date(2026, 4, 8)) {
+		t.Errorf("абонемент на срок = %+v", b)
+	}
+}
+
+func TestCovers(t *testing.T) {
+	b := Balance{Kind: Sessions, Left: 1, Starts: date(2026, 3, 1), Expires: date(2026, 3, 31)}
+	for on, want := range map[time.Time]bool{
+		date(2026, 2, 28): false,
+		date(2026, 3, 1):  true,
+		date(2026, 3, 31): true,
+		date(2026, 4, 1):  false,
+	} {
+		if got := b.Covers(on); got != want {
+			t.Errorf("Covers(%s) = %v, хотим %v", on.Format("02.01"), got, want)
+		}
+	}
+	b.Left = 0
+	if b.Covers(date(2026, 3, 10)) {
+		t.Error("пустой пакет оплачивает тренировку")
+	}
+	if !b.Expired(date(2026, 4, 1)) || b.Expired(date(2026, 3, 31)) {
+		t.Error("Expired считает последний день действия неверно")
+	}
+}
+
+func TestPick(t *testing.T) {
+	on := date(2026, 3, 15)
+	forever := Balance{ID: 1, Kind: Sessions, Left: 5, Starts: date(2026, 1, 1)}
+	late := Balance{ID: 2, Kind: Sessions, Left: 5, Starts: date(2026, 2, 1), Expires: date(2026, 6, 1)}
+	soon := Balance{ID: 3, Kind: Sessions, Left: 5, Starts: date(2026, 3, 1), Expires: date(2026, 4, 1)}
+	empty := Balance{ID: 4, Kind: Sessions, Left: 0, Starts: date(2026, 1, 1), Expires: date(2026, 3, 20)}
+	month := Balance{ID: 5, Kind: Period, Starts: date(2026, 3, 1), Expires: date(2026, 3, 30)}
+	future := Balance{ID: 6, Kind: Period, Starts: date(2026, 3, 31), Expires: date(2026, 4, 29)}
+
+	tests := []struct {
+		name     string
+		balances []Balance
+		want     int
+	}{
+		{"сначала абонемент на срок", []Balance{forever, soon, month}, 5},
+		{"раньше истекающий пакет", []Balance{forever, late, soon}, 3},
+		{"пакет со сроком раньше бессрочного", []Balance{forever, late}, 2},
+		{"пустой и будущий не подходят", []Balance{empty, future, forever}, 1},
+		{"при равном сроке — купленный раньше", []Balance{{ID: 9, Kind: Sessions, Left: 1, Starts: date(2026, 3, 2)}, {ID: 8, Kind: Sessions, Left: 1, Starts: date(2026, 3, 1)}}, 8},
+	}
+	for _, tt := range tests {
+		got, ok := Pick(tt.balances, on)
+		if !ok || got.ID != tt.want {
+			t.Errorf("%s: Pick = %d, %v, хотим %d", tt.name, got.ID, ok, tt.want)
+		}
+	}
+	if _, ok := Pick([]Balance{empty, future}, on); ok {
+		t.Error("Pick нашёл абонемент, которым нечем оплатить")
+	}
+}
+
+func TestRemind(t *testing.T) {
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	low := Balance{ID: 1, Kind: Sessions, Total: 10, Left: 1, Starts: date(2026, 3, 1)}
+	plenty := Balance{ID: 2, Kind: Sessions, Total: 10, Left: 8, Starts: date(2026, 3, 1)}
+	expiring := Balance{ID: 3, Kind: Period, Starts: date(2026, 2, 16), Expires: date(2026, 3, 17)}
+	next := Balance{ID: 4, Kind: Period, Starts: date(2026, 3, 18), Expires: date(2026, 4, 16)}
+
+	tests := []struct {
+		name   string
+		b      Balance
+		others []Balance
+		want   Reminder
+	}{
+		{"последняя тренировка", low, []Balance{low}, ReminderLow},
+		{"есть другой пакет", low, []Balance{low, plenty}, NoReminder},
+		{"тренировок достаточно", plenty, []Balance{plenty}, NoReminder},
+		{"срок заканчивается", expiring, []Balance{expiring}, ReminderExpires},
+		{"следующий месяц уже куплен", expiring, []Balance{expiring, next}, NoReminder},
+		{"срок ещё не скоро", next, []Balance{next}, NoReminder},
+		{"уже истёк", Balance{ID: 5, Kind: Period, Expires: date(2026, 3, 14)}, nil, NoReminder},
+	}
+	for _, tt := range tests {
+		if got := Remind(tt.b, tt.others, now); got != tt.want {
+			t.Errorf("%s: Remind = %q, хотим %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := map[int64]string{
+		450000:   "4 500 RUB",
+		99050:    "990,50 RUB",
+		5:        "0,05 RUB",
+		12345600: "123 456 RUB",
+		-10000:   "-100 RUB",
+	}
+	for amount, want := range tests {
+		if got := FormatMoney(amount, "RUB"); got != want {
+			t.Errorf("FormatMoney(%d) = %q, хотим %q", amount, got, want)
+		}
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := map[string]int64{
+		"4500":     450000,
+		"4 500":    450000,
+		"990,50":   99050,
+		"990.5":    99050,
+		" 25000 ":  2500000,
+		"1 000,05": 100005,
+	}
+	for in, want := range tests {
+		got, err := ParseMoney(in)
+		if err != nil || got != want {
+			t.Errorf("ParseMoney(%q) = %d, %v, хотим %d", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "0", "-5", "12,345", "1.", "abc", "99999999999"} {
+		if _, err := ParseMoney(bad); err == nil {
+			t.Errorf("ParseMoney(%q) принял неверную сумму", bad)
+		}
+	}
+}
+
+func TestPayloadAndVerify(t *testing.T) {
+	var fake FakeProvider
+	inv := Invoice{PaymentID: 42, ChatID: 100, Title: "10 тренировок", Amount: 2500000, Currency: "RUB"}
+	if err := fake.SendInvoice(inv); err != nil {
+		t.Fatal(err)
+	}
+	if id, err := ParsePayload(inv.Payload()); err != nil || id != 42 {
+		t.Fatalf("ParsePayload(%q) = %d, %v", inv.Payload(), id, err)
+	}
+	for _, bad := range []string{"", "42", "pay:", "pay:x", "pay:-1"} {
+		if _, err := ParsePayload(bad); !errors.Is(err, ErrBadPayload) {
+			t.Errorf("ParsePayload(%q) = %v", bad, err)
+		}
+	}
+
+	c, err := fake.Pay(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.TelegramChargeID == "" || c.ProviderChargeID == "" {
+		t.Errorf("подтверждение без идентификаторов операции: %+v", c)
+	}
+	pending := Payment{ID: 42, Amount: 2500000, Currency: "RUB", Status: StatusPending}
+	if err := Verify(c, pending); err != nil {
+		t.Errorf("Verify = %v", err)
+	}
+
+	paid := pending
+	paid.Status = StatusPaid
+	if err := Verify(c, paid); !errors.Is(err, ErrNotPending) {
+		t.Errorf("повторная оплата: Verify = %v", err)
+	}
+	changed := pending
+	changed.Amount = 100
+	if err := Verify(c, changed); !errors.Is(err, ErrAmountChanged) {
+		t.Errorf("другая сумма: Verify = %v", err)
+	}
+	if err := Verify(c, Payment{ID: 43, Amount: 2500000, Currency: "RUB", Status: StatusPending}); !errors.Is(err, ErrBadPayload) {
+		t.Errorf("чужой платёж: Verify = %v", err)
+	}
+	if _, err := fake.Pay(7); !errors.Is(err, ErrBadPayload) {
+		t.Errorf("оплата невыставленного счёта: %v", err)
+	}
+	if n := len(fake.Invoices()); n != 1 {
+		t.Errorf("выставлено счетов: %d", n)
+	}
+}
+
+func TestReports(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*3600)
+	paid := []Paid{
+		{PaidAt: time.Date(2026, 2, 28, 22, 0, 0, 0, time.UTC), Amount: 100000, Currency: "RUB", Package: "Месяц"}, // 1 марта по Москве
+		{PaidAt: time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC), Amount: 250000, Currency: "RUB", Package: "10 тренировок"},
+		{PaidAt: time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC), Amount: 100000, Currency: "RUB", Package: "Месяц"},
+		{PaidAt: time.Date(2026, 2, 11, 9, 0, 0, 0, time.UTC), Amount: 5000, Currency: "USD", Package: "Месяц"},
+	}
+
+	months := Monthly(paid, loc)
+	if len(months) != 3 {
+		t.Fatalf("Monthly = %+v", months)
+	}
+	if m := months[0]; m.Month.Month() != time.March || m.Amount != 350000 || m.Count != 2 {
+		t.Errorf("март = %+v", m)
+	}
+	if m := months[1]; m.Month.Month() != time.February || m.Currency != "RUB" || m.Amount != 100000 {
+		t.Errorf("февраль RUB = %+v", m)
+	}
+	if m := months[2]; m.Currency != "USD" || m.Amount != 5000 {
+		t.Errorf("февраль USD = %+v", m)
+	}
+
+	packages := ByPackage(paid)
+	if len(packages) != 3 || packages[0].Package != "10 тренировок" || packages[1].Package != "Месяц" ||
+		packages[1].Amount != 200000 || packages[1].Count != 2 {
+		t.Errorf("ByPackage = %+v", packages)
+	}
+}
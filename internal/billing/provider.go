@@ -0,0 +1,180 @@
+package billing
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// payloadPrefix — начало полезной нагрузки счёта; после него номер платежа
+const payloadPrefix = "pay:"
+
+// Ошибки проверки оплаты
+var (
+	ErrBadPayload    = errors.New("billing: неизвестный счёт")
+	ErrAmountChanged = errors.New("billing: сумма или валюта оплаты не совпадает со счётом")
+	ErrNotPending    = errors.New("billing: счёт уже оплачен или отменён")
+)
+
+// Invoice — счёт на оплату пакета. Перед выставлением бот записывает платёж
+// со статусом pending, номер платежа передаётся провайдеру в полезной нагрузке
+type Invoice struct {
+	PaymentID   int
+	ChatID      int64
+	Title       string
+	Description string
+	Amount      int64
+	Currency    string
+}
+
+// Payload — полезная нагрузка счёта: по ней подтверждение оплаты находит платёж
+func (inv Invoice) Payload() string {
+	return payloadPrefix + strconv.Itoa(inv.PaymentID)
+}
+
+// ParsePayload возвращает номер платежа из полезной нагрузки счёта
+func ParsePayload(payload string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(payload, payloadPrefix))
+	if !strings.HasPrefix(payload, payloadPrefix) || err != nil || id <= 0 {
+		return 0, ErrBadPayload
+	}
+	return id, nil
+}
+
+// Payment — платёж, как он записан в базе
+type Payment struct {
+	ID       int
+	Amount   int64
+	Currency string
+	Status   string
+}
+
+// Статусы платежа
+const (
+	StatusPending  = "pending"
+	StatusPaid     = "paid"
+	StatusCanceled = "canceled"
+)
+
+// Confirmation — подтверждение оплаты от провайдера: pre-checkout запрос
+// или успешный платёж Telegram
+type Confirmation struct {
+	Payload  string
+	Amount   int64
+	Currency string
+	// TelegramChargeID и ProviderChargeID — идентификаторы операции в Telegram
+	// и у платёжного провайдера; пусто для pre-checkout
+	TelegramChargeID string
+	ProviderChargeID string
+}
+
+// Verify проверяет, что подтверждение относится к ожидающему оплаты платежу
+// и сумма с валютой не изменились
+func Verify(c Confirmation, p Payment) error {
+	id, err := ParsePayload(c.Payload)
+	if err != nil || id != p.ID {
+		return ErrBadPayload
+	}
+	if p.Status != StatusPending {
+		return ErrNotPending
+	}
+	if c.Amount != p.Amount || !strings.EqualFold(c.Currency, p.Currency) {
+		return ErrAmountChanged
+	}
+	return nil
+}
+
+// Provider выставляет счета клиентам. Оплата подтверждается отдельно:
+// для Telegram — обновлениями pre_checkout_query и successful_payment
+type Provider interface {
+	// Name — имя провайдера, записывается в платёж
+	Name() string
+	// SendInvoice выставляет счёт клиенту в чат
+	SendInvoice(inv Invoice) error
+}
+
+// API — часть Telegram Bot API, через которую выставляются счета
+type API interface {
+	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+}
+
+// TelegramProvider выставляет счета через Telegram Payments
+type TelegramProvider struct {
+	api   API
+	token string
+}
+
+// NewTelegramProvider создаёт провайдера с токеном платёжной системы из @BotFather
+func NewTelegramProvider(api API, token string) *TelegramProvider {
+	return &TelegramProvider{api: api, token: token}
+}
+
+// Name возвращает имя провайдера
+func (p *TelegramProvider) Name() string {
+	return "telegram"
+}
+
+// SendInvoice отправляет счёт сообщением в чат клиента
+func (p *TelegramProvider) SendInvoice(inv Invoice) error {
+	if inv.Amount > int64(^uint32(0)>>1) {
+		return ErrBadPrice
+	}
+	cfg := tgbotapi.NewInvoice(inv.ChatID, inv.Title, inv.Description, inv.Payload(), p.token, "",
+		strings.ToUpper(inv.Currency), []tgbotapi.LabeledPrice{{Label: inv.Title, Amount: int(inv.Amount)}})
+	if _, err := p.api.Send(cfg); err != nil {
+		return fmt.Errorf("ошибка выставления счёта: %w", err)
+	}
+	return nil
+}
+
+// FakeProvider запоминает выставленные счета и подтверждает их оплату без
+// обращения к платёжной системе. Используется в тестах и для проверки
+// сценария оплаты на тестовом боте
+type FakeProvider struct {
+	mu       sync.Mutex
+	invoices []Invoice
+	charges  int
+}
+
+// Name возвращает имя провайдера
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+// SendInvoice запоминает счёт
+func (p *FakeProvider) SendInvoice(inv Invoice) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.invoices = append(p.invoices, inv)
+	return nil
+}
+
+// Invoices возвращает выставленные счета
+func (p *FakeProvider) Invoices() []Invoice {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Invoice(nil), p.invoices...)
+}
+
+// Pay подтверждает оплату ранее выставленного счёта на полную сумму
+func (p *FakeProvider) Pay(paymentID int) (Confirmation, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, inv := range p.invoices {
+		if inv.PaymentID == paymentID {
+			p.charges++
+			return Confirmation{
+				Payload:          inv.Payload(),
+				Amount:           inv.Amount,
+				Currency:         inv.Currency,
+				TelegramChargeID: fmt.Sprintf("fake-tg-%d", p.charges),
+				ProviderChargeID: fmt.Sprintf("fake-%d", p.charges),
+			}, nil
+		}
+	}
+	return Confirmation{}, ErrBadPayload
+}
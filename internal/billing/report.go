@@ -0,0 +1,90 @@
+package billing
+
+import (
+	"sort"
+	"time"
+)
+
+// Paid — оплаченный платёж для отчёта о выручке
+type Paid struct {
+	PaidAt   time.Time
+	Amount   int64
+	Currency string
+	Package  string
+}
+
+// MonthTotal — выручка за месяц в одной валюте
+type MonthTotal struct {
+	Month    time.Time // первое число месяца
+	Currency string
+	Amount   int64
+	Count    int
+}
+
+// PackageTotal — выручка по пакету в одной валюте
+type PackageTotal struct {
+	Package  string
+	Currency string
+	Amount   int64
+	Count    int
+}
+
+// Monthly сводит платежи по месяцам в поясе loc, начиная с последнего месяца
+func Monthly(paid []Paid, loc *time.Location) []MonthTotal {
+	type key struct {
+		month    time.Time
+		currency string
+	}
+	totals := make(map[key]*MonthTotal)
+	for _, p := range paid {
+		t := p.PaidAt.In(loc)
+		k := key{time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc), p.Currency}
+		mt := totals[k]
+		if mt == nil {
+			mt = &MonthTotal{Month: k.month, Currency: k.currency}
+			totals[k] = mt
+		}
+		mt.Amount += p.Amount
+		mt.Count++
+	}
+
+	out := make([]MonthTotal, 0, len(totals))
+	for _, mt := range totals {
+		out = append(out, *mt)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].Month.Equal(out[j].Month) {
+			return out[i].Month.After(out[j].Month)
+		}
+		return out[i].Currency < out[j].Currency
+	})
+	return out
+}
+
+// ByPackage сводит платежи по пакетам, начиная с самого доходного
+func ByPackage(paid []Paid) []PackageTotal {
+	type key struct{ name, currency string }
+	totals := make(map[key]*PackageTotal)
+	for _, p := range paid {
+		k := key{p.Package, p.Currency}
+		pt := totals[k]
+		if pt == nil {
+			pt = &PackageTotal{Package: p.Package, Currency: p.Currency}
+			totals[k] = pt
+		}
+		pt.Amount += p.Amount
+		pt.Count++
+	}
+
+	out := make([]PackageTotal, 0, len(totals))
+	for _, pt := range totals {
+		out = append(out, *pt)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Amount != out[j].Amount {
+			return out[i].Amount > out[j].Amount
+		}
+		return out[i].Package < out[j].Package
+	})
+	return out
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 	"sync"
 
+	"workbot/internal/i18n"
+
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
@@ -59,44 +61,74 @@ func (b *Bot) isAdmin(telegramID int64) bool {
 
 // handleAdminCommand обрабатывает команды от админа
 func (b *Bot) handleAdminCommand(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
 	switch message.Command() {
 	case "start":
 		b.handleAdminStart(message)
 	case "info":
 		b.handleInfoCommand(message)
+	case "jobs":
+		b.handleJobsCommand(chatID, 0)
+	case "settings":
+		b.handleSettingsMenu(message)
+	case "timezone":
+		b.showTimezonePicker(chatID, 0, b.tf("admin_timezone_current", chatID, b.timezoneLabel(chatID)))
 	default:
-		b.sendMessage(message.Chat.ID, "Неизвестная команда")
+		b.sendMessage(chatID, b.t("unknown_command", chatID))
 	}
 }
 
 // handleAdminStart показывает админское меню
 func (b *Bot) handleAdminStart(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Клиенты"),
-			tgbotapi.NewKeyboardButton("Добавить клиента"),
+			tgbotapi.NewKeyboardButton(b.t("admin_clients", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("admin_add_client", chatID)),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(b.t("admin_pl_programs", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("admin_fit_programs", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("PL: Программы"),
-			tgbotapi.NewKeyboardButton("FIT: Программы"),
+			tgbotapi.NewKeyboardButton(b.t("admin_schedule", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("admin_1pm_testing", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Расписание"),
-			tgbotapi.NewKeyboardButton("1ПМ Тестирование"),
+			tgbotapi.NewKeyboardButton(b.t("admin_training_plans", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("admin_send_training", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Планы тренировок"),
-			tgbotapi.NewKeyboardButton("Отправить тренировку"),
+			tgbotapi.NewKeyboardButton(b.t("admin_statistics", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("admin_birthdays", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Статистика"),
-			tgbotapi.NewKeyboardButton("Дни рождения"),
+			tgbotapi.NewKeyboardButton(b.t("admin_templates", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("admin_groups", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("admin_packages", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Тренеры"),
+			tgbotapi.NewKeyboardButton(b.t("admin_trainers", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("btn_settings", chatID)),
 		),
 	)
-	b.sendMessageWithKeyboard(message.Chat.ID, "Панель тренера", keyboard)
+	b.sendMessageWithKeyboard(chatID, b.t("admin_panel", chatID), keyboard)
+}
+
+// adminMenuKeys — ключи кнопок меню тренера и его подменю.
+// Нажатие сопоставляется с ключом на любом языке (i18n.Match)
+var adminMenuKeys = []string{
+	"admin_clients", "admin_add_client", "admin_send_training",
+	"admin_schedule", "admin_schedule_add_slot", "admin_schedule_my", "admin_schedule_appointments",
+	"admin_schedule_delete_slot", "admin_schedule_manage",
+	"admin_trainers", "admin_add_trainer", "admin_remove_trainer",
+	"admin_groups", "admin_templates", "admin_birthdays", "admin_packages",
+	"admin_statistics", "stats_general", "stats_top_active", "stats_inactive", "stats_by_period", "stats_btn_revenue",
+	"admin_1pm_testing", "admin_training_plans",
+	"admin_pl_programs", "pl_btn_powerlifting", "pl_btn_bench", "pl_btn_squat", "pl_btn_deadlift",
+	"pl_btn_hip_thrust", "pl_btn_auto", "pl_btn_templates",
+	"admin_fit_programs", "fit_btn_hypertrophy", "fit_btn_strength", "fit_btn_fatloss", "fit_btn_hyrox",
+	"btn_settings", "cancel", "back",
 }
 
 // handleAdminMessage обрабатывает сообщения от админа
@@ -194,82 +226,116 @@ func (b *Bot) handleAdminMessage(message *tgbotapi.Message) {
 		return
 	}
 
+	// Обработка состояний групп клиентов
+	if strings.HasPrefix(state, "group_") {
+		b.handleGroupState(message, state)
+		return
+	}
+
+	// Обработка ручного ввода часового пояса
+	if state == stateTimezoneInput {
+		b.handleTimezoneInput(message)
+		return
+	}
+
+	// Обработка состояний шаблонов программ
+	if strings.HasPrefix(state, "template_") {
+		b.handleTemplateState(message, state)
+		return
+	}
+
+	// Обработка создания пакета тренировок
+	if strings.HasPrefix(state, "billing_") {
+		b.processBillingState(message, state)
+		return
+	}
+
 	// Обработка состояний AI плана
 	if strings.HasPrefix(state, "ai_") {
 		b.handleAIState(message, state)
 		return
 	}
 
-	switch text {
-	case "Клиенты":
+	switch i18n.Match(text, adminMenuKeys...) {
+	case "admin_clients":
 		b.showClientsList(message)
-	case "Добавить клиента":
+	case "admin_add_client":
 		b.startAddClient(message)
-	case "Отправить тренировку":
+	case "admin_send_training":
 		b.showClientsForSending(message)
-	case "Расписание":
+	case "admin_schedule":
 		b.handleScheduleMenu(message)
-	case "Добавить слот":
+	case "admin_schedule_add_slot":
 		b.handleAddScheduleSlot(message)
-	case "Мое расписание":
+	case "admin_schedule_my":
 		b.handleShowSchedule(message)
-	case "Записи клиентов":
+	case "admin_schedule_appointments":
 		b.handleTrainerAppointments(message)
-	case "Удалить слот":
+	case "admin_schedule_delete_slot":
 		b.handleDeleteScheduleSlot(message)
-	case "Управление записями":
+	case "admin_schedule_manage":
 		b.handleManageAppointments(message)
-	case "Тренеры":
+	case "admin_trainers":
 		b.handleTrainersMenu(message)
-	case "Дни рождения":
-		b.handleBirthdaysCommand(message.Chat.ID)
-	case "Статистика":
+	case "admin_groups":
+		b.handleGroupsMenu(chatID, 0)
+	case "admin_templates":
+		b.handleTemplatesMenu(chatID, 0)
+	case "admin_birthdays":
+		b.handleBirthdaysCommand(chatID)
+	case "admin_statistics":
 		b.handleStatisticsMenu(message)
-	case "📊 Общая статистика":
-		b.handleGeneralStatistics(message.Chat.ID)
-	case "👥 Топ активных":
-		b.handleTopActiveClients(message.Chat.ID)
-	case "📉 Неактивные клиенты":
-		b.handleInactiveClients(message.Chat.ID)
-	case "📅 За период":
-		b.handlePeriodStatistics(message.Chat.ID)
-	case "Добавить тренера":
+	case "stats_general":
+		b.handleGeneralStatistics(chatID)
+	case "stats_top_active":
+		b.handleTopActiveClients(chatID)
+	case "stats_inactive":
+		b.handleInactiveClients(chatID)
+	case "stats_by_period":
+		b.handlePeriodStatistics(chatID)
+	case "stats_btn_revenue":
+		b.handleRevenueStatistics(chatID)
+	case "admin_packages":
+		b.handlePackagesMenu(chatID, 0)
+	case "admin_add_trainer":
 		b.handleAddTrainer(message)
-	case "Удалить тренера":
+	case "admin_remove_trainer":
 		b.handleRemoveTrainer(message)
-	case "1ПМ Тестирование":
+	case "admin_1pm_testing":
 		b.handle1PMMenu(message)
-	case "Планы тренировок":
+	case "admin_training_plans":
 		b.handlePlansMenu(message)
-	case "PL: Программы":
+	case "admin_pl_programs":
 		b.handlePowerliftingMenu(message)
-	case "PL: Троеборье":
+	case "pl_btn_powerlifting":
 		b.handlePLLiftType(message, "powerlifting")
-	case "PL: Жим лёжа":
+	case "pl_btn_bench":
 		b.handlePLLiftType(message, "bench")
-	case "PL: Присед":
+	case "pl_btn_squat":
 		b.handlePLLiftType(message, "squat")
-	case "PL: Становая тяга":
+	case "pl_btn_deadlift":
 		b.handlePLLiftType(message, "deadlift")
-	case "PL: Ягодичный мост":
+	case "pl_btn_hip_thrust":
 		b.handlePLLiftType(message, "hip_thrust")
-	case "PL: Авто-подбор":
+	case "pl_btn_auto":
 		b.handlePLAutoSelect(message)
-	case "PL: Список шаблонов":
+	case "pl_btn_templates":
 		b.handlePLListTemplates(message)
-	case "FIT: Программы":
+	case "admin_fit_programs":
 		b.handleFitnessMenu(message)
-	case "FIT: Гипертрофия":
+	case "fit_btn_hypertrophy":
 		b.handleFitnessProgramType(message, "hypertrophy")
-	case "FIT: Сила":
+	case "fit_btn_strength":
 		b.handleFitnessProgramType(message, "strength")
-	case "FIT: Жиросжигание":
+	case "fit_btn_fatloss":
 		b.handleFitnessProgramType(message, "fatloss")
-	case "FIT: Hyrox":
+	case "fit_btn_hyrox":
 		b.handleFitnessProgramType(message, "hyrox")
-	case "Отмена":
+	case "btn_settings":
+		b.handleSettingsMenu(message)
+	case "cancel":
 		b.handleAdminCancel(message)
-	case "Назад":
+	case "back":
 		b.handleAdminStart(message)
 	default:
 		// Проверяем, не выбран ли клиент из списка для записи тренировки
@@ -278,11 +344,11 @@ func (b *Bot) handleAdminMessage(message *tgbotapi.Message) {
 			return
 		}
 		// Проверяем, не выбран ли клиент для отправки тренировки
-		if strings.HasPrefix(text, "Отправить: ") {
+		if strings.HasPrefix(text, sendTrainingPrefix) {
 			b.handleSendTrainingSelection(message, text)
 			return
 		}
-		b.sendMessage(chatID, "Неизвестная команда")
+		b.sendMessage(chatID, b.t("unknown_command", chatID))
 	}
 }
 
@@ -290,7 +356,7 @@ func (b *Bot) handleAdminMessage(message *tgbotapi.Message) {
 func (b *Bot) handlePLState(message *tgbotapi.Message, state string) {
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.handleAdminCancel(message)
 		return
 	}
@@ -337,6 +403,6 @@ func (b *Bot) handleAdminCancel(message *tgbotapi.Message) {
 
 	clearState(chatID)
 
-	b.sendMessage(chatID, "Отменено")
+	b.sendMessage(chatID, b.t("cancelled", chatID))
 	b.handleAdminStart(message)
 }
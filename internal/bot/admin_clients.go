@@ -5,6 +5,10 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
+
+	"workbot/internal/gsheets"
+	"workbot/internal/i18n"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -19,7 +23,7 @@ func (b *Bot) showClientsList(message *tgbotapi.Message) {
 		WHERE a.telegram_id IS NULL AND c.deleted_at IS NULL
 		ORDER BY c.name`)
 	if err != nil {
-		b.sendError(chatID, "Ошибка загрузки клиентов", err)
+		b.sendError(chatID, b.t("admin_clients_load_error", chatID), err)
 		return
 	}
 	defer rows.Close()
@@ -41,16 +45,16 @@ func (b *Bot) showClientsList(message *tgbotapi.Message) {
 	}
 
 	if len(buttons) == 0 {
-		b.sendMessage(chatID, "Список клиентов пуст")
+		b.sendMessage(chatID, b.t("info_clients_empty", chatID))
 		return
 	}
 
 	buttons = append(buttons, tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton("Назад"),
+		tgbotapi.NewKeyboardButton(b.t("back", chatID)),
 	))
 
 	keyboard := tgbotapi.NewReplyKeyboard(buttons...)
-	b.sendMessageWithKeyboard(chatID, "Выберите клиента:", keyboard)
+	b.sendMessageWithKeyboard(chatID, b.t("admin_select_client", chatID), keyboard)
 }
 
 // handleClientSelection обрабатывает выбор клиента — показывает профиль
@@ -59,7 +63,7 @@ func (b *Bot) handleClientSelection(message *tgbotapi.Message, text string) {
 
 	clientID := parseIDFromBrackets(text)
 	if clientID == 0 {
-		b.sendMessage(chatID, "Ошибка выбора клиента")
+		b.sendMessage(chatID, b.t("admin_client_select_error", chatID))
 		return
 	}
 
@@ -72,36 +76,36 @@ func (b *Bot) handleClientSelection(message *tgbotapi.Message, text string) {
 
 // showClientProfile показывает профиль клиента с меню действий
 func (b *Bot) showClientProfile(chatID int64, clientID int) {
-	var name, surname, phone, birthDate string
+	var name, surname, phone, birthDate, sheetID string
 	var goal, trainingPlan, notes sql.NullString
 	err := b.db.QueryRow(`
 		SELECT name, surname, COALESCE(phone, ''), COALESCE(birth_date, ''),
-		       goal, training_plan, notes
+		       goal, training_plan, notes, COALESCE(google_sheet_id, '')
 		FROM public.clients WHERE id = $1`, clientID).
-		Scan(&name, &surname, &phone, &birthDate, &goal, &trainingPlan, &notes)
+		Scan(&name, &surname, &phone, &birthDate, &goal, &trainingPlan, &notes, &sheetID)
 	if err != nil {
-		b.sendError(chatID, "Клиент не найден", err)
+		b.sendError(chatID, b.t("admin_client_not_found", chatID), err)
 		b.handleAdminStart(&tgbotapi.Message{Chat: &tgbotapi.Chat{ID: chatID}})
 		return
 	}
 
 	var profile strings.Builder
-	profile.WriteString(fmt.Sprintf("Клиент: %s %s\n", name, surname))
+	profile.WriteString(b.tf("client_card_name", chatID, name, surname) + "\n")
 	profile.WriteString("-------------------\n")
 
 	if phone != "" {
-		profile.WriteString(fmt.Sprintf("Телефон: %s\n", phone))
+		profile.WriteString(b.tf("client_card_phone", chatID, phone) + "\n")
 	}
 	if birthDate != "" {
-		profile.WriteString(fmt.Sprintf("Дата рождения: %s\n", birthDate))
+		profile.WriteString(b.tf("client_card_birthdate", chatID, birthDate) + "\n")
 	}
 
 	profile.WriteString("\n")
 
 	if goal.Valid && goal.String != "" {
-		profile.WriteString(fmt.Sprintf("Цель: %s\n", goal.String))
+		profile.WriteString(b.tf("client_card_goal", chatID, goal.String) + "\n")
 	} else {
-		profile.WriteString("Цель: не задана\n")
+		profile.WriteString(b.t("client_card_goal_none", chatID) + "\n")
 	}
 
 	if trainingPlan.Valid && trainingPlan.String != "" {
@@ -109,41 +113,54 @@ func (b *Bot) showClientProfile(chatID int64, clientID int) {
 		if len(planPreview) > 200 {
 			planPreview = planPreview[:200] + "..."
 		}
-		profile.WriteString(fmt.Sprintf("\nПлан:\n%s\n", planPreview))
+		profile.WriteString("\n" + b.tf("client_card_plan", chatID, planPreview) + "\n")
 	} else {
-		profile.WriteString("План: не составлен\n")
+		profile.WriteString(b.t("client_card_plan_none", chatID) + "\n")
 	}
 
 	if notes.Valid && notes.String != "" {
-		profile.WriteString(fmt.Sprintf("\nЗаметки: %s\n", notes.String))
+		profile.WriteString("\n" + b.tf("client_card_notes", chatID, notes.String) + "\n")
+	}
+
+	if sheetID != "" && b.sheetsQueue != nil {
+		if status, err := b.sheetsQueue.Status(sheetID); err != nil {
+			log.Printf("Ошибка получения состояния синхронизации %s: %v", sheetID, err)
+		} else {
+			profile.WriteString("\n" + formatSheetsSyncStatus(status, b.userLocation(chatID), b.getLanguage(chatID)) + "\n")
+		}
 	}
 
 	profile.WriteString("\n-------------------\n")
-	profile.WriteString("Выберите действие:")
+	profile.WriteString(b.t("select_action", chatID))
 
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("📊 Прогресс программы"),
+			tgbotapi.NewKeyboardButton(b.t("client_btn_progress", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("client_btn_charts", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("client_btn_gallery", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Записать тренировку"),
+			tgbotapi.NewKeyboardButton(b.t("client_btn_nutrition", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("client_btn_record_training", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("client_btn_billing", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("PL: Программа"),
-			tgbotapi.NewKeyboardButton("FIT: Программа"),
+			tgbotapi.NewKeyboardButton(b.t("client_btn_pl_program", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("client_btn_fit_program", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Задать цель"),
-			tgbotapi.NewKeyboardButton("Составить план"),
+			tgbotapi.NewKeyboardButton(b.t("client_btn_set_goal", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("client_btn_create_plan", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("История"),
+			tgbotapi.NewKeyboardButton(b.t("client_btn_history", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("client_btn_save_template", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Удалить клиента"),
+			tgbotapi.NewKeyboardButton(b.t("client_btn_delete", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Назад"),
+			tgbotapi.NewKeyboardButton(b.t("back", chatID)),
 		),
 	)
 	b.sendMessageWithKeyboard(chatID, profile.String(), keyboard)
@@ -151,6 +168,13 @@ func (b *Bot) showClientProfile(chatID int64, clientID int) {
 	setState(chatID, "viewing_client")
 }
 
+// clientActionKeys — кнопки карточки клиента
+var clientActionKeys = []string{
+	"client_btn_progress", "client_btn_charts", "client_btn_gallery", "client_btn_nutrition", "client_btn_record_training", "client_btn_billing", "client_btn_pl_program", "client_btn_fit_program",
+	"client_btn_set_goal", "client_btn_create_plan", "client_btn_history", "client_btn_save_template",
+	"client_btn_delete", "client_btn_delete_yes", "client_btn_delete_no", "back",
+}
+
 // handleClientAction обрабатывает действия с клиентом
 func (b *Bot) handleClientAction(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
@@ -165,37 +189,47 @@ func (b *Bot) handleClientAction(message *tgbotapi.Message) {
 		return
 	}
 
-	switch text {
-	case "📊 Прогресс программы":
+	switch i18n.Match(text, clientActionKeys...) {
+	case "client_btn_progress":
 		b.showProgramProgress(clientID, chatID)
-	case "Записать тренировку":
+	case "client_btn_charts":
+		b.sendProgressChart(chatID, clientID, chartWeight, defaultChartRange)
+	case "client_btn_gallery":
+		b.showPhotoGallery(chatID, clientID, 0, "")
+	case "client_btn_nutrition":
+		b.showNutrition(chatID, clientID, 0)
+	case "client_btn_record_training":
 		b.startTrainingInput(chatID, clientID)
-	case "PL: Программа":
+	case "client_btn_billing":
+		b.showBilling(chatID, clientID, 0)
+	case "client_btn_pl_program":
 		// Переход к пауэрлифтинг программе с предвыбранным клиентом
 		b.handlePLProgramForClient(message, clientID)
-	case "FIT: Программа":
+	case "client_btn_fit_program":
 		// Переход к фитнес программе с предвыбранным клиентом
 		b.handleFITProgramForClient(message, clientID)
-	case "Задать цель":
+	case "client_btn_set_goal":
 		b.startSetGoal(chatID, clientID)
-	case "Составить план":
+	case "client_btn_create_plan":
 		b.startCreatePlan(chatID, clientID)
-	case "История":
+	case "client_btn_history":
 		b.showClientHistory(chatID, clientID)
-	case "Удалить клиента":
+	case "client_btn_save_template":
+		b.saveClientProgramAsTemplate(chatID, clientID)
+	case "client_btn_delete":
 		b.confirmDeleteClient(chatID, clientID)
-	case "Да, удалить":
+	case "client_btn_delete_yes":
 		b.deleteClient(chatID, clientID)
-	case "Нет, отмена":
+	case "client_btn_delete_no":
 		b.showClientProfile(chatID, clientID)
-	case "Назад":
+	case "back":
 		adminStates.Lock()
 		delete(adminStates.selectedClient, chatID)
 		adminStates.Unlock()
 		clearState(chatID)
 		b.showClientsList(message)
 	default:
-		b.sendMessage(chatID, "Выберите действие из меню")
+		b.sendMessage(chatID, b.t("select_action_menu", chatID))
 	}
 }
 
@@ -207,16 +241,12 @@ func (b *Bot) confirmDeleteClient(chatID int64, clientID int) {
 		log.Printf("Ошибка получения имени клиента для удаления: %v", err)
 	}
 
-	text := fmt.Sprintf(
-		"Вы уверены, что хотите удалить клиента?\n\n"+
-			"Клиент: %s %s\n\n"+
-			"Данные клиента будут сохранены в истории, но он не будет отображаться в списках.",
-		name, surname)
+	text := b.tf("client_delete_confirm", chatID, name, surname)
 
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Да, удалить"),
-			tgbotapi.NewKeyboardButton("Нет, отмена"),
+			tgbotapi.NewKeyboardButton(b.t("client_btn_delete_yes", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("client_btn_delete_no", chatID)),
 		),
 	)
 	b.sendMessageWithKeyboard(chatID, text, keyboard)
@@ -232,12 +262,12 @@ func (b *Bot) deleteClient(chatID int64, clientID int) {
 
 	_, err := b.db.Exec("UPDATE public.clients SET deleted_at = NOW() WHERE id = $1", clientID)
 	if err != nil {
-		b.sendError(chatID, "Ошибка удаления клиента", err)
+		b.sendError(chatID, b.t("client_delete_error", chatID), err)
 		b.showClientProfile(chatID, clientID)
 		return
 	}
 
-	b.sendMessage(chatID, fmt.Sprintf("Клиент %s %s удалён", name, surname))
+	b.sendMessage(chatID, b.tf("client_deleted", chatID, name, surname))
 
 	adminStates.Lock()
 	delete(adminStates.selectedClient, chatID)
@@ -247,3 +277,33 @@ func (b *Bot) deleteClient(chatID int64, clientID int) {
 
 	b.handleAdminStart(&tgbotapi.Message{Chat: &tgbotapi.Chat{ID: chatID}})
 }
+
+// formatSheetsSyncStatus описывает состояние синхронизации Google таблицы клиента; время — в поясе loc
+func formatSheetsSyncStatus(st gsheets.SyncStatus, loc *time.Location, lang i18n.Language) string {
+	const layout = "02.01 15:04"
+	var lines []string
+
+	switch {
+	case st.Pending > 0:
+		lines = append(lines, i18n.Tf("client_card_sheets_pending", lang,
+			i18n.Tn("sheets_writes", lang, st.Pending),
+			st.PendingSince.Time.In(loc).Format(layout),
+			st.NextAttemptAt.Time.In(loc).Format(layout)))
+	case st.LastSyncedAt.Valid:
+		lines = append(lines, i18n.Tf("client_card_sheets_synced", lang, st.LastSyncedAt.Time.In(loc).Format(layout)))
+	default:
+		lines = append(lines, i18n.T("client_card_sheets_never", lang))
+	}
+
+	if st.Failed > 0 {
+		lines = append(lines, i18n.Tf("client_card_sheets_failed", lang, i18n.Tn("sheets_writes", lang, st.Failed)))
+	}
+	if st.LastError != "" && (st.Pending > 0 || st.Failed > 0) {
+		errText := st.LastError
+		if len([]rune(errText)) > 120 {
+			errText = string([]rune(errText)[:120]) + "…"
+		}
+		lines = append(lines, i18n.Tf("client_card_sheets_error", lang, errText))
+	}
+	return strings.Join(lines, "\n")
+}
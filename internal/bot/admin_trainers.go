@@ -1,12 +1,13 @@
 package bot
 
 import (
-	"fmt"
 	"log"
 	"strconv"
 	"strings"
 	"sync"
 
+	"workbot/internal/i18n"
+
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
@@ -37,7 +38,7 @@ func (b *Bot) handleTrainersMenu(message *tgbotapi.Message) {
 	rows, err := b.db.Query("SELECT telegram_id, name FROM public.admins ORDER BY name")
 	if err != nil {
 		log.Printf("Ошибка получения тренеров: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка загрузки списка тренеров")
+		msg := tgbotapi.NewMessage(chatID, b.t("trainers_load_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -50,24 +51,24 @@ func (b *Bot) handleTrainersMenu(message *tgbotapi.Message) {
 		if err := rows.Scan(&telegramID, &name); err != nil {
 			continue
 		}
-		trainers = append(trainers, fmt.Sprintf("• %s (ID: %d)", name, telegramID))
+		trainers = append(trainers, b.tf("trainers_item", chatID, name, telegramID))
 	}
 
-	text := "Управление тренерами\n\n"
+	text := b.t("trainers_title", chatID) + "\n\n"
 	if len(trainers) > 0 {
-		text += "Текущие тренеры:\n" + strings.Join(trainers, "\n")
+		text += b.t("trainers_current", chatID) + "\n" + strings.Join(trainers, "\n")
 	} else {
-		text += "Тренеров пока нет"
+		text += b.t("trainers_empty", chatID)
 	}
 
 	msg := tgbotapi.NewMessage(chatID, text)
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Добавить тренера"),
-			tgbotapi.NewKeyboardButton("Удалить тренера"),
+			tgbotapi.NewKeyboardButton(b.t("admin_add_trainer", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("admin_remove_trainer", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Назад"),
+			tgbotapi.NewKeyboardButton(b.t("back", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -86,10 +87,10 @@ func (b *Bot) handleAddTrainer(message *tgbotapi.Message) {
 	userStates.states[chatID] = stateAddTrainerID
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Добавление тренера\n\nВведите Telegram ID нового тренера:\n\n(Тренер может узнать свой ID написав боту @userinfobot)")
+	msg := tgbotapi.NewMessage(chatID, b.t("trainers_add_prompt", chatID))
 	msg.ReplyMarkup = tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	b.api.Send(msg)
@@ -100,7 +101,7 @@ func (b *Bot) processAddTrainer(message *tgbotapi.Message, state string) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.cancelAddTrainer(chatID, message)
 		return
 	}
@@ -119,7 +120,7 @@ func (b *Bot) processAddTrainer(message *tgbotapi.Message, state string) {
 		telegramID, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
 		if err != nil {
 			trainerStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, "Некорректный Telegram ID. Введите число:")
+			msg := tgbotapi.NewMessage(chatID, b.t("trainers_invalid_id", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -129,7 +130,7 @@ func (b *Bot) processAddTrainer(message *tgbotapi.Message, state string) {
 		b.db.QueryRow("SELECT EXISTS(SELECT 1 FROM public.admins WHERE telegram_id = $1)", telegramID).Scan(&exists)
 		if exists {
 			trainerStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, "Этот пользователь уже является тренером. Введите другой ID:")
+			msg := tgbotapi.NewMessage(chatID, b.t("trainers_already_exists", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -142,14 +143,14 @@ func (b *Bot) processAddTrainer(message *tgbotapi.Message, state string) {
 		userStates.states[chatID] = stateAddTrainerName
 		userStates.Unlock()
 
-		msg := tgbotapi.NewMessage(chatID, "Введите имя тренера (как будет отображаться в системе):")
+		msg := tgbotapi.NewMessage(chatID, b.t("trainers_enter_name", chatID))
 		b.api.Send(msg)
 
 	case stateAddTrainerName:
 		name := strings.TrimSpace(text)
 		if len(name) < 2 {
 			trainerStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, "Имя должно содержать минимум 2 символа. Введите имя:")
+			msg := tgbotapi.NewMessage(chatID, b.t("trainers_name_short", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -169,7 +170,7 @@ func (b *Bot) processAddTrainer(message *tgbotapi.Message, state string) {
 		)
 		if err != nil {
 			log.Printf("Ошибка добавления тренера: %v", err)
-			msg := tgbotapi.NewMessage(chatID, "Ошибка при добавлении тренера")
+			msg := tgbotapi.NewMessage(chatID, b.t("trainers_add_error", chatID))
 			b.api.Send(msg)
 			b.handleTrainersMenu(message)
 			return
@@ -180,10 +181,7 @@ func (b *Bot) processAddTrainer(message *tgbotapi.Message, state string) {
 		delete(adminCache.cache, telegramID)
 		adminCache.Unlock()
 
-		msg := tgbotapi.NewMessage(chatID, fmt.Sprintf(
-			"Тренер добавлен!\n\nИмя: %s\nTelegram ID: %d\n\nТеперь этот пользователь может использовать панель тренера.",
-			name, telegramID,
-		))
+		msg := tgbotapi.NewMessage(chatID, b.tf("trainers_added", chatID, name, telegramID))
 		b.api.Send(msg)
 		b.handleTrainersMenu(message)
 
@@ -199,7 +197,7 @@ func (b *Bot) handleRemoveTrainer(message *tgbotapi.Message) {
 	rows, err := b.db.Query("SELECT telegram_id, name FROM public.admins ORDER BY name")
 	if err != nil {
 		log.Printf("Ошибка получения тренеров: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка загрузки списка тренеров")
+		msg := tgbotapi.NewMessage(chatID, b.t("trainers_load_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -213,26 +211,26 @@ func (b *Bot) handleRemoveTrainer(message *tgbotapi.Message) {
 			continue
 		}
 		buttons = append(buttons, tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(fmt.Sprintf("Удалить: %s [%d]", name, telegramID)),
+			tgbotapi.NewKeyboardButton(b.tf("trainers_remove_item", chatID, name, telegramID)),
 		))
 	}
 
 	if len(buttons) == 0 {
-		msg := tgbotapi.NewMessage(chatID, "Нет тренеров для удаления")
+		msg := tgbotapi.NewMessage(chatID, b.t("trainers_none_to_remove", chatID))
 		b.api.Send(msg)
 		b.handleTrainersMenu(message)
 		return
 	}
 
 	buttons = append(buttons, tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton("Отмена"),
+		tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 	))
 
 	userStates.Lock()
 	userStates.states[chatID] = "remove_trainer_select"
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Выберите тренера для удаления:")
+	msg := tgbotapi.NewMessage(chatID, b.t("trainers_select_remove", chatID))
 	msg.ReplyMarkup = tgbotapi.ReplyKeyboardMarkup{
 		Keyboard:       buttons,
 		ResizeKeyboard: true,
@@ -245,7 +243,7 @@ func (b *Bot) handleRemoveTrainerSelection(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		userStates.Lock()
 		delete(userStates.states, chatID)
 		userStates.Unlock()
@@ -253,31 +251,25 @@ func (b *Bot) handleRemoveTrainerSelection(message *tgbotapi.Message) {
 		return
 	}
 
-	if !strings.HasPrefix(text, "Удалить: ") {
-		msg := tgbotapi.NewMessage(chatID, "Выберите тренера из списка")
-		b.api.Send(msg)
-		return
-	}
-
-	// Извлекаем Telegram ID из строки "Удалить: Имя [123456789]"
+	// Извлекаем Telegram ID из кнопки вида "Удалить: Имя [123456789]"
 	start := strings.LastIndex(text, "[")
 	end := strings.LastIndex(text, "]")
 	if start == -1 || end == -1 || start >= end {
-		msg := tgbotapi.NewMessage(chatID, "Ошибка: неверный формат")
+		msg := tgbotapi.NewMessage(chatID, b.t("trainers_select_from_list", chatID))
 		b.api.Send(msg)
 		return
 	}
 
 	telegramID, err := strconv.ParseInt(text[start+1:end], 10, 64)
 	if err != nil {
-		msg := tgbotapi.NewMessage(chatID, "Ошибка: неверный ID")
+		msg := tgbotapi.NewMessage(chatID, b.t("trainers_invalid_id_error", chatID))
 		b.api.Send(msg)
 		return
 	}
 
 	// Проверяем, не пытается ли админ удалить себя
 	if telegramID == chatID {
-		msg := tgbotapi.NewMessage(chatID, "Вы не можете удалить себя из списка тренеров")
+		msg := tgbotapi.NewMessage(chatID, b.t("trainers_remove_self", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -286,7 +278,7 @@ func (b *Bot) handleRemoveTrainerSelection(message *tgbotapi.Message) {
 	_, err = b.db.Exec("DELETE FROM public.admins WHERE telegram_id = $1", telegramID)
 	if err != nil {
 		log.Printf("Ошибка удаления тренера: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка при удалении тренера")
+		msg := tgbotapi.NewMessage(chatID, b.t("trainers_remove_error", chatID))
 		b.api.Send(msg)
 		b.handleTrainersMenu(message)
 		return
@@ -301,7 +293,7 @@ func (b *Bot) handleRemoveTrainerSelection(message *tgbotapi.Message) {
 	delete(userStates.states, chatID)
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Тренер удалён")
+	msg := tgbotapi.NewMessage(chatID, b.t("trainers_removed", chatID))
 	b.api.Send(msg)
 	b.handleTrainersMenu(message)
 }
@@ -316,7 +308,7 @@ func (b *Bot) cancelAddTrainer(chatID int64, message *tgbotapi.Message) {
 	delete(userStates.states, chatID)
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Отменено")
+	msg := tgbotapi.NewMessage(chatID, b.t("cancelled", chatID))
 	b.api.Send(msg)
 	b.handleTrainersMenu(message)
 }
@@ -9,6 +9,7 @@ import (
 
 	"workbot/internal/excel"
 	"workbot/internal/gsheets"
+	"workbot/internal/i18n"
 	"workbot/internal/models"
 	"workbot/internal/training"
 
@@ -25,8 +26,12 @@ func (b *Bot) getTrainingNumber(clientID int) int {
 	return count + 1
 }
 
+// sendTrainingPrefix начинает подпись кнопки клиента в списке отправки тренировок
+const sendTrainingPrefix = "📤 "
+
 // showClientsForSending показывает список клиентов для отправки тренировки
 func (b *Bot) showClientsForSending(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
 	rows, err := b.db.Query(`
 		SELECT c.id, c.name, c.surname, COALESCE(c.telegram_id, 0)
 		FROM public.clients c
@@ -34,7 +39,7 @@ func (b *Bot) showClientsForSending(message *tgbotapi.Message) {
 		WHERE a.telegram_id IS NULL AND c.deleted_at IS NULL
 		ORDER BY c.name`)
 	if err != nil {
-		b.sendError(message.Chat.ID, "Ошибка загрузки клиентов", err)
+		b.sendError(chatID, b.t("admin_clients_load_error", chatID), err)
 		return
 	}
 	defer rows.Close()
@@ -62,19 +67,19 @@ func (b *Bot) showClientsForSending(message *tgbotapi.Message) {
 			continue
 		}
 
-		status := "нет telegram"
+		status := b.t("send_status_no_telegram", chatID)
 		if telegramID > 0 {
 			if programWorkoutsCount > 0 && excelWorkoutsCount > 0 {
-				status = fmt.Sprintf("%d из прог. + %d из Excel", programWorkoutsCount, excelWorkoutsCount)
+				status = b.tf("send_status_both", chatID, programWorkoutsCount, excelWorkoutsCount)
 			} else if programWorkoutsCount > 0 {
-				status = fmt.Sprintf("%d из программы", programWorkoutsCount)
+				status = b.tf("send_status_program", chatID, programWorkoutsCount)
 			} else {
-				status = fmt.Sprintf("%d из Excel", excelWorkoutsCount)
+				status = b.tf("send_status_excel", chatID, excelWorkoutsCount)
 			}
 		}
 
 		buttons = append(buttons, tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(fmt.Sprintf("Отправить: %s %s [%d] (%s)", name, surname, id, status)),
+			tgbotapi.NewKeyboardButton(fmt.Sprintf("%s%s %s [%d] (%s)", sendTrainingPrefix, name, surname, id, status)),
 		))
 	}
 
@@ -83,17 +88,17 @@ func (b *Bot) showClientsForSending(message *tgbotapi.Message) {
 	}
 
 	if len(buttons) == 0 {
-		b.sendMessage(message.Chat.ID, "Нет неотправленных тренировок")
+		b.sendMessage(chatID, b.t("send_none", chatID))
 		b.handleAdminStart(message)
 		return
 	}
 
 	buttons = append(buttons, tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton("Назад"),
+		tgbotapi.NewKeyboardButton(b.t("back", chatID)),
 	))
 
 	keyboard := tgbotapi.NewReplyKeyboard(buttons...)
-	b.sendMessageWithKeyboard(message.Chat.ID, "Выберите клиента для отправки тренировки:", keyboard)
+	b.sendMessageWithKeyboard(chatID, b.t("send_select_client", chatID), keyboard)
 }
 
 // handleSendTrainingSelection обрабатывает отправку тренировки клиенту
@@ -102,7 +107,7 @@ func (b *Bot) handleSendTrainingSelection(message *tgbotapi.Message, text string
 
 	clientID := parseIDFromBrackets(text)
 	if clientID == 0 {
-		b.sendMessage(chatID, "Ошибка выбора клиента")
+		b.sendMessage(chatID, b.t("admin_client_select_error", chatID))
 		return
 	}
 
@@ -111,12 +116,12 @@ func (b *Bot) handleSendTrainingSelection(message *tgbotapi.Message, text string
 	err := b.db.QueryRow("SELECT telegram_id, name, surname FROM public.clients WHERE id = $1", clientID).
 		Scan(&telegramID, &name, &surname)
 	if err != nil {
-		b.sendError(chatID, "Клиент не найден", err)
+		b.sendError(chatID, b.t("admin_client_not_found", chatID), err)
 		return
 	}
 
 	if telegramID == 0 {
-		b.sendMessage(chatID, fmt.Sprintf("У клиента %s %s нет telegram. Тренировка не может быть отправлена.", name, surname))
+		b.sendMessage(chatID, b.tf("send_client_no_telegram", chatID, name, surname))
 		b.handleAdminStart(message)
 		return
 	}
@@ -140,7 +145,7 @@ func (b *Bot) handleSendTrainingSelection(message *tgbotapi.Message, text string
 	unsentGroups, err := excel.GetUnsentTrainings(excel.FilePath, b.db, clientID)
 	if err == nil && len(unsentGroups) > 0 {
 		for _, group := range unsentGroups {
-			msgText := excel.FormatTrainingMessage(&group)
+			msgText := excel.FormatTrainingMessage(&group, b.getLanguage(telegramID))
 			notification := tgbotapi.NewMessage(telegramID, msgText)
 			if _, err := b.api.Send(notification); err != nil {
 				log.Printf("Ошибка отправки клиенту %d: %v", clientID, err)
@@ -155,9 +160,9 @@ func (b *Bot) handleSendTrainingSelection(message *tgbotapi.Message, text string
 	}
 
 	if sentCount == 0 {
-		b.sendMessage(chatID, "Нет неотправленных тренировок для этого клиента")
+		b.sendMessage(chatID, b.t("send_none_for_client", chatID))
 	} else {
-		b.sendMessage(chatID, fmt.Sprintf("✅ Отправлено %d тренировок клиенту %s %s", sentCount, name, surname))
+		b.sendMessage(chatID, b.tn("send_done", chatID, sentCount, sentCount, name, surname))
 	}
 	b.handleAdminStart(message)
 }
@@ -168,25 +173,18 @@ func (b *Bot) startTrainingInput(chatID int64, clientID int) {
 	if err := b.db.QueryRow("SELECT name, surname FROM public.clients WHERE id = $1", clientID).
 		Scan(&name, &surname); err != nil {
 		log.Printf("Ошибка получения данных клиента %d: %v", clientID, err)
-		b.sendMessage(chatID, "Ошибка получения данных клиента")
+		b.sendMessage(chatID, b.t("admin_client_data_error", chatID))
 		return
 	}
 
 	setState(chatID, "admin_awaiting_training")
 
-	helpText := fmt.Sprintf("Клиент: %s %s\n\n"+
-		"Введите тренировку в формате:\n"+
-		"Упражнение подходы/повторы вес\n\n"+
-		"Пример:\n"+
-		"Жим лежа 4/8 60\n"+
-		"Присед 4/6 80\n\n"+
-		"Можно указать дату первой строкой (ДД.ММ.ГГГГ)",
-		name, surname)
+	helpText := b.tf("admin_training_enter", chatID, name, surname)
 
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Назад"),
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("back", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	b.sendMessageWithKeyboard(chatID, helpText, keyboard)
@@ -197,13 +195,13 @@ func (b *Bot) handleAdminTrainingInput(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.handleAdminCancel(message)
 		return
 	}
 
 	// Кнопка "Назад" - возврат к профилю клиента
-	if text == "Назад" {
+	if i18n.Is(text, "back") {
 		adminStates.RLock()
 		clientID := adminStates.selectedClient[chatID]
 		adminStates.RUnlock()
@@ -226,20 +224,20 @@ func (b *Bot) handleAdminTrainingInput(message *tgbotapi.Message) {
 	clearState(chatID)
 
 	if clientID == 0 {
-		b.sendMessage(chatID, "Ошибка: клиент не выбран")
+		b.sendMessage(chatID, b.t("admin_client_not_selected", chatID))
 		b.handleAdminStart(message)
 		return
 	}
 
 	exercises, trainingDate, err := training.Parse(text)
 	if err != nil {
-		b.sendMessage(chatID, fmt.Sprintf("Ошибка разбора: %v", err))
+		b.sendMessage(chatID, b.tf("trainings_parse_error", chatID, err))
 		b.handleAdminStart(message)
 		return
 	}
 
 	if len(exercises) == 0 {
-		b.sendMessage(chatID, "Не найдено упражнений. Проверьте формат.")
+		b.sendMessage(chatID, b.t("trainings_no_exercises", chatID))
 		b.handleAdminStart(message)
 		return
 	}
@@ -249,14 +247,14 @@ func (b *Bot) handleAdminTrainingInput(message *tgbotapi.Message) {
 	err = b.db.QueryRow("SELECT name, surname, COALESCE(telegram_id, 0) FROM public.clients WHERE id = $1", clientID).
 		Scan(&name, &surname, &telegramID)
 	if err != nil {
-		b.sendError(chatID, "Ошибка: клиент не найден", err)
+		b.sendError(chatID, b.t("admin_client_not_found", chatID), err)
 		b.handleAdminStart(message)
 		return
 	}
 
 	err = excel.SaveTrainingToExcel(excel.FilePath, b.db, clientID, name, surname, trainingDate, exercises)
 	if err != nil {
-		b.sendError(chatID, fmt.Sprintf("Ошибка сохранения: %v", err), err)
+		b.sendError(chatID, b.tf("trainings_save_error", chatID, err), err)
 		b.handleAdminStart(message)
 		return
 	}
@@ -288,18 +286,18 @@ func (b *Bot) handleAdminTrainingInput(message *tgbotapi.Message) {
 		}
 	}
 
-	confirmText := fmt.Sprintf("Тренировка для %s %s сохранена!\n\n", name, surname)
+	confirmText := b.tf("admin_training_saved", chatID, name, surname)
 	confirmText += training.FormatConfirmation(exercises, trainingDate)
 
 	b.sendMessage(chatID, confirmText)
 
 	if telegramID > 0 {
-		clientMsg := formatTrainingNotification(exercises, trainingDate)
+		clientMsg := formatTrainingNotification(exercises, trainingDate, b.getLanguage(telegramID))
 		notification := tgbotapi.NewMessage(telegramID, clientMsg)
 		if _, err := b.api.Send(notification); err != nil {
 			log.Printf("Ошибка отправки клиенту %d: %v", clientID, err)
 		} else {
-			b.sendMessage(chatID, "Уведомление отправлено клиенту")
+			b.sendMessage(chatID, b.t("admin_client_notified", chatID))
 		}
 	}
 
@@ -312,23 +310,18 @@ func (b *Bot) startSetGoal(chatID int64, clientID int) {
 
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Набор массы"),
-			tgbotapi.NewKeyboardButton("Похудение"),
+			tgbotapi.NewKeyboardButton(b.t("goal_mass", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("goal_fatloss", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Сила"),
-			tgbotapi.NewKeyboardButton("Выносливость"),
+			tgbotapi.NewKeyboardButton(b.t("goal_strength", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("goal_endurance", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
-	b.sendMessageWithKeyboard(chatID, "Введите цель клиента:\n\n"+
-		"Например:\n"+
-		"- Набор мышечной массы 5кг за 3 месяца\n"+
-		"- Похудение на 10кг\n"+
-		"- Подготовка к соревнованиям\n"+
-		"- Общая физическая подготовка", keyboard)
+	b.sendMessageWithKeyboard(chatID, b.t("goal_enter", chatID), keyboard)
 }
 
 // handleSetGoal сохраняет цель клиента
@@ -336,7 +329,7 @@ func (b *Bot) handleSetGoal(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		setState(chatID, "viewing_client")
 		adminStates.RLock()
 		clientID := adminStates.selectedClient[chatID]
@@ -351,12 +344,12 @@ func (b *Bot) handleSetGoal(message *tgbotapi.Message) {
 
 	_, err := b.db.Exec("UPDATE public.clients SET goal = $1 WHERE id = $2", text, clientID)
 	if err != nil {
-		b.sendError(chatID, "Ошибка сохранения цели", err)
+		b.sendError(chatID, b.t("goal_save_error", chatID), err)
 	} else {
 		if _, err := b.db.Exec(`INSERT INTO public.client_goals (client_id, goal) VALUES ($1, $2)`, clientID, text); err != nil {
 			log.Printf("Ошибка записи истории целей: %v", err)
 		}
-		b.sendMessage(chatID, fmt.Sprintf("Цель установлена: %s", text))
+		b.sendMessage(chatID, b.tf("goal_saved", chatID, text))
 	}
 
 	setState(chatID, "viewing_client")
@@ -369,19 +362,13 @@ func (b *Bot) startCreatePlan(chatID int64, clientID int) {
 
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Сгенерировать AI"),
+			tgbotapi.NewKeyboardButton(b.t("plan_btn_generate_ai", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
-	b.sendMessageWithKeyboard(chatID, "Введите план тренировок:\n\n"+
-		"Можете описать:\n"+
-		"- Периодизацию (подготовительный, соревновательный период)\n"+
-		"- Частоту тренировок в неделю\n"+
-		"- Основные упражнения и прогрессию\n"+
-		"- Любые заметки по плану\n\n"+
-		"Или используйте AI для автоматической генерации.", keyboard)
+	b.sendMessageWithKeyboard(chatID, b.t("plan_enter", chatID), keyboard)
 }
 
 // handleCreatePlan сохраняет план
@@ -389,7 +376,7 @@ func (b *Bot) handleCreatePlan(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		setState(chatID, "viewing_client")
 		adminStates.RLock()
 		clientID := adminStates.selectedClient[chatID]
@@ -398,7 +385,7 @@ func (b *Bot) handleCreatePlan(message *tgbotapi.Message) {
 		return
 	}
 
-	if text == "Сгенерировать AI" {
+	if i18n.Is(text, "plan_btn_generate_ai") {
 		adminStates.RLock()
 		clientID := adminStates.selectedClient[chatID]
 		adminStates.RUnlock()
@@ -412,9 +399,9 @@ func (b *Bot) handleCreatePlan(message *tgbotapi.Message) {
 
 	_, err := b.db.Exec("UPDATE public.clients SET training_plan = $1 WHERE id = $2", text, clientID)
 	if err != nil {
-		b.sendError(chatID, "Ошибка сохранения плана", err)
+		b.sendError(chatID, b.t("plan_save_error", chatID), err)
 	} else {
-		b.sendMessage(chatID, "План сохранён!")
+		b.sendMessage(chatID, b.t("plan_saved", chatID))
 	}
 
 	setState(chatID, "viewing_client")
@@ -427,12 +414,12 @@ func (b *Bot) handleAIClientPlan(chatID int64, clientID int) {
 	var goal sql.NullString
 	if err := b.db.QueryRow("SELECT name, surname, goal FROM public.clients WHERE id = $1", clientID).
 		Scan(&name, &surname, &goal); err != nil {
-		b.sendError(chatID, "Ошибка получения данных клиента", err)
+		b.sendError(chatID, b.t("admin_client_data_error", chatID), err)
 		return
 	}
 
 	if !goal.Valid || goal.String == "" {
-		b.sendMessage(chatID, "Сначала задайте цель клиента!")
+		b.sendMessage(chatID, b.t("goal_required", chatID))
 		b.startSetGoal(chatID, clientID)
 		return
 	}
@@ -445,39 +432,35 @@ func (b *Bot) handleAIClientPlan(chatID int64, clientID int) {
 
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Силовая"),
-			tgbotapi.NewKeyboardButton("Кардио"),
+			tgbotapi.NewKeyboardButton(b.t("ai_plan_type_strength", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("ai_plan_type_cardio", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Смешанная"),
-			tgbotapi.NewKeyboardButton("Функциональная"),
+			tgbotapi.NewKeyboardButton(b.t("ai_plan_type_mixed", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("ai_plan_type_functional", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
-	b.sendMessageWithKeyboard(chatID, fmt.Sprintf(
-		"Генерация плана для %s %s\n"+
-			"Цель: %s\n\n"+
-			"Выберите тип тренировки:",
-		name, surname, goal.String), keyboard)
+	b.sendMessageWithKeyboard(chatID, b.tf("ai_plan_title", chatID, name, surname, goal.String), keyboard)
 }
 
 // showClientHistory показывает историю тренировок клиента
 func (b *Bot) showClientHistory(chatID int64, clientID int) {
-	trainings, err := excel.GetClientTrainings(excel.FilePath, clientID, 10)
+	trainings, err := excel.GetClientTrainings(excel.FilePath, clientID, 10, b.getLanguage(chatID))
 	if err != nil {
-		b.sendError(chatID, "Ошибка загрузки истории", err)
+		b.sendError(chatID, b.t("admin_history_load_error", chatID), err)
 		return
 	}
 
 	if len(trainings) == 0 {
-		b.sendMessage(chatID, "У клиента пока нет записанных тренировок.")
+		b.sendMessage(chatID, b.t("admin_history_empty", chatID))
 		return
 	}
 
 	var result strings.Builder
-	result.WriteString("История тренировок:\n\n")
+	result.WriteString(b.t("admin_history_title", chatID) + "\n\n")
 	for _, t := range trainings {
 		result.WriteString(t)
 		result.WriteString("\n")
@@ -487,27 +470,27 @@ func (b *Bot) showClientHistory(chatID int64, clientID int) {
 }
 
 // formatTrainingNotification форматирует уведомление о тренировке для клиента
-func formatTrainingNotification(exercises []models.ExerciseInput, trainingDate time.Time) string {
+func formatTrainingNotification(exercises []models.ExerciseInput, trainingDate time.Time, lang i18n.Language) string {
 	var totalTonnage float64
 	var exerciseList strings.Builder
 
-	exerciseList.WriteString(fmt.Sprintf("Тренировка на %s\n\n", trainingDate.Format("02.01.2006")))
+	exerciseList.WriteString(i18n.Tf("training_notification_title", lang, trainingDate.Format("02.01.2006")) + "\n\n")
 
 	for i, ex := range exercises {
 		tonnage := float64(ex.Sets) * float64(ex.Reps) * ex.Weight
 		totalTonnage += tonnage
 		exerciseList.WriteString(fmt.Sprintf("%d. %s\n   %d/%d", i+1, ex.Name, ex.Sets, ex.Reps))
 		if ex.Weight > 0 {
-			exerciseList.WriteString(fmt.Sprintf(" %.0fкг", ex.Weight))
+			exerciseList.WriteString(" " + i18n.Tf("unit_kg", lang, ex.Weight))
 		}
 		exerciseList.WriteString("\n")
 	}
 
 	if totalTonnage > 0 {
-		exerciseList.WriteString(fmt.Sprintf("\nОбщий тоннаж: %.0f кг", totalTonnage))
+		exerciseList.WriteString("\n" + i18n.Tf("training_total_tonnage", lang, totalTonnage))
 	}
 
-	exerciseList.WriteString("\n\nУдачной тренировки!")
+	exerciseList.WriteString("\n\n" + i18n.T("training_good_luck", lang))
 
 	return exerciseList.String()
 }
@@ -517,7 +500,7 @@ func (b *Bot) handleAIState(message *tgbotapi.Message, state string) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		clearState(chatID)
 		aiClientStore.Lock()
 		delete(aiClientStore.data, chatID)
@@ -550,7 +533,7 @@ func (b *Bot) handleAIParamsSelect(message *tgbotapi.Message) {
 	aiClientStore.RUnlock()
 
 	if clientID == 0 {
-		b.sendMessage(chatID, "Ошибка: клиент не выбран")
+		b.sendMessage(chatID, b.t("admin_client_not_selected", chatID))
 		b.handleAdminStart(message)
 		return
 	}
@@ -560,36 +543,29 @@ func (b *Bot) handleAIParamsSelect(message *tgbotapi.Message) {
 	var goal sql.NullString
 	if err := b.db.QueryRow("SELECT name, surname, goal FROM public.clients WHERE id = $1", clientID).
 		Scan(&name, &surname, &goal); err != nil {
-		b.sendError(chatID, "Ошибка получения данных клиента", err)
+		b.sendError(chatID, b.t("admin_client_data_error", chatID), err)
 		return
 	}
 
-	var trainingType string
-	switch text {
-	case "Силовая":
-		trainingType = "силовая"
-	case "Кардио":
-		trainingType = "кардио"
-	case "Смешанная":
-		trainingType = "смешанная"
-	case "Функциональная":
-		trainingType = "функциональная"
-	default:
-		b.sendMessage(chatID, "Выберите тип тренировки из меню")
+	// Тип тренировки — суффикс ключа кнопки: ai_plan_type_strength → strength
+	key := i18n.Match(text, "ai_plan_type_strength", "ai_plan_type_cardio", "ai_plan_type_mixed", "ai_plan_type_functional")
+	if key == "" {
+		b.sendMessage(chatID, b.t("ai_plan_select_type", chatID))
 		return
 	}
+	trainingType := strings.TrimPrefix(key, "ai_plan_type_")
 
 	// Генерируем простой план на основе типа
-	plan := b.generateSimplePlan(name, surname, goal.String, trainingType)
+	plan := b.generateSimplePlan(chatID, name, surname, goal.String, trainingType)
 
 	// Сохраняем в БД
 	_, err := b.db.Exec("UPDATE public.clients SET training_plan = $1 WHERE id = $2", plan, clientID)
 	if err != nil {
-		b.sendError(chatID, "Ошибка сохранения плана", err)
+		b.sendError(chatID, b.t("plan_save_error", chatID), err)
 		return
 	}
 
-	b.sendMessage(chatID, fmt.Sprintf("✅ План сгенерирован!\n\n%s", plan))
+	b.sendMessage(chatID, b.tf("ai_plan_generated", chatID, plan))
 
 	// Очищаем состояние
 	clearState(chatID)
@@ -600,67 +576,9 @@ func (b *Bot) handleAIParamsSelect(message *tgbotapi.Message) {
 	b.showClientProfile(chatID, clientID)
 }
 
-// generateSimplePlan генерирует простой план тренировок
-func (b *Bot) generateSimplePlan(name, surname, goal, trainingType string) string {
-	var plan strings.Builder
-
-	plan.WriteString(fmt.Sprintf("План для %s %s\n", name, surname))
-	plan.WriteString(fmt.Sprintf("Цель: %s\n", goal))
-	plan.WriteString(fmt.Sprintf("Тип: %s тренировка\n\n", trainingType))
-
-	switch trainingType {
-	case "силовая":
-		plan.WriteString("День 1 (Верх):\n")
-		plan.WriteString("1. Жим штанги лёжа 4x8-10\n")
-		plan.WriteString("2. Тяга штанги в наклоне 4x8-10\n")
-		plan.WriteString("3. Жим гантелей сидя 3x10-12\n")
-		plan.WriteString("4. Подтягивания 3xmax\n")
-		plan.WriteString("5. Разгибания на трицепс 3x12-15\n\n")
-		plan.WriteString("День 2 (Низ):\n")
-		plan.WriteString("1. Приседания со штангой 4x8-10\n")
-		plan.WriteString("2. Румынская тяга 4x8-10\n")
-		plan.WriteString("3. Жим ногами 3x10-12\n")
-		plan.WriteString("4. Сгибания ног 3x12-15\n")
-		plan.WriteString("5. Подъём на носки 4x15-20\n")
-
-	case "кардио":
-		plan.WriteString("День 1:\n")
-		plan.WriteString("1. Разминка 5 мин\n")
-		plan.WriteString("2. Интервальный бег 20 мин (30с быстро/60с медленно)\n")
-		plan.WriteString("3. Велотренажёр 15 мин\n")
-		plan.WriteString("4. Заминка 5 мин\n\n")
-		plan.WriteString("День 2:\n")
-		plan.WriteString("1. Разминка 5 мин\n")
-		plan.WriteString("2. Эллипс 25 мин\n")
-		plan.WriteString("3. Скакалка 3x3 мин\n")
-		plan.WriteString("4. Заминка 5 мин\n")
-
-	case "смешанная":
-		plan.WriteString("День 1:\n")
-		plan.WriteString("1. Приседания 4x10\n")
-		plan.WriteString("2. Жим гантелей лёжа 3x12\n")
-		plan.WriteString("3. Тяга верхнего блока 3x12\n")
-		plan.WriteString("4. Интервальное кардио 15 мин\n\n")
-		plan.WriteString("День 2:\n")
-		plan.WriteString("1. Становая тяга 4x8\n")
-		plan.WriteString("2. Жим плечами 3x12\n")
-		plan.WriteString("3. Тяга нижнего блока 3x12\n")
-		plan.WriteString("4. HIIT 15 мин\n")
-
-	case "функциональная":
-		plan.WriteString("День 1:\n")
-		plan.WriteString("1. Берпи 3x10\n")
-		plan.WriteString("2. Махи гирей 4x15\n")
-		plan.WriteString("3. Выпады с гантелями 3x12 на ногу\n")
-		plan.WriteString("4. Планка 3x45 сек\n")
-		plan.WriteString("5. Box jump 3x10\n\n")
-		plan.WriteString("День 2:\n")
-		plan.WriteString("1. Турецкий подъём 3x5 на сторону\n")
-		plan.WriteString("2. Рывок гири 4x10\n")
-		plan.WriteString("3. Приседания с гирей 3x12\n")
-		plan.WriteString("4. Farmer's walk 3x30м\n")
-		plan.WriteString("5. Скручивания 3x20\n")
-	}
-
-	return plan.String()
+// generateSimplePlan генерирует простой план тренировок.
+// trainingType — strength, cardio, mixed или functional; текст плана берётся из ai_plan_body_<type>
+func (b *Bot) generateSimplePlan(chatID int64, name, surname, goal, trainingType string) string {
+	return b.tf("ai_plan_header", chatID, name, surname, goal, b.t("ai_plan_type_"+trainingType, chatID)) +
+		"\n\n" + b.t("ai_plan_body_"+trainingType, chatID)
 }
@@ -1,10 +1,14 @@
 package bot
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
+	"workbot/internal/calendar"
+	"workbot/internal/scheduler"
+
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
@@ -16,98 +20,56 @@ type AppointmentReminder struct {
 	ClientName      string
 	ClientSurname   string
 	TrainerID       int64
-	AppointmentDate time.Time
+	AppointmentDate time.Time // момент начала записи
 	StartTime       string
 	ReminderType    string // "1day", "1hour"
 }
 
-// StartAppointmentReminder запускает фоновую задачу напоминаний о тренировках
-func (b *Bot) StartAppointmentReminder() {
-	go func() {
-		// Ждём 15 секунд после старта
-		time.Sleep(15 * time.Second)
-		log.Println("Запущен сервис напоминаний о тренировках")
-
-		// Проверяем каждые 30 минут
-		ticker := time.NewTicker(30 * time.Minute)
-		defer ticker.Stop()
-
-		// Первая проверка сразу
-		b.checkAndSendAppointmentReminders()
-
-		for range ticker.C {
-			b.checkAndSendAppointmentReminders()
-		}
-	}()
-}
-
-// checkAndSendAppointmentReminders проверяет и отправляет напоминания
-func (b *Bot) checkAndSendAppointmentReminders() {
+// runAppointmentReminders — задача планировщика: напоминания клиентам за день и за час.
+// Флаги reminder_*_sent защищают от повторной отправки при повторе задачи
+func (b *Bot) runAppointmentReminders(ctx context.Context, run scheduler.Run) error {
 	now := time.Now()
-	log.Printf("Проверка напоминаний о тренировках: %s", now.Format("02.01.2006 15:04"))
 
-	// Напоминания за 1 день (проверяем тренировки на завтра)
-	tomorrow := now.AddDate(0, 0, 1)
-	oneDayReminders := b.getAppointmentsForReminder(tomorrow, "1day")
-	for _, reminder := range oneDayReminders {
-		b.sendAppointmentReminder(reminder)
-	}
-
-	// Напоминания за 1 час
-	oneHourLater := now.Add(time.Hour)
-	oneHourReminders := b.getAppointmentsForReminder(oneHourLater, "1hour")
-	for _, reminder := range oneHourReminders {
-		b.sendAppointmentReminder(reminder)
+	for _, reminderType := range []string{"1day", "1hour"} {
+		reminders, err := b.getAppointmentsForReminder(now, reminderType)
+		if err != nil {
+			return err
+		}
+		for _, reminder := range reminders {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			b.sendAppointmentReminder(reminder)
+		}
 	}
+	return nil
 }
 
-// getAppointmentsForReminder получает записи для напоминания
-func (b *Bot) getAppointmentsForReminder(targetTime time.Time, reminderType string) []AppointmentReminder {
+// getAppointmentsForReminder получает записи для напоминания.
+// Дата и время записи хранятся в поясе тренера, поэтому момент начала
+// и «завтра» клиента вычисляются в Go, а не в SQL
+func (b *Bot) getAppointmentsForReminder(now time.Time, reminderType string) ([]AppointmentReminder, error) {
 	var reminders []AppointmentReminder
 
-	var query string
-	var args []interface{}
-
+	sentColumn := "reminder_1hour_sent"
 	if reminderType == "1day" {
-		// За 1 день: проверяем записи на целевую дату
-		// Отправляем напоминания в 9:00-9:30 утра за день до тренировки
-		currentHour := time.Now().Hour()
-		if currentHour < 9 || currentHour >= 10 {
-			return reminders // Напоминания за день отправляем только утром
-		}
-
-		query = `
-			SELECT a.id, a.client_id, COALESCE(c.telegram_id, 0), c.name, c.surname,
-			       a.trainer_id, a.appointment_date, TO_CHAR(a.start_time, 'HH24:MI')
-			FROM public.appointments a
-			JOIN public.clients c ON a.client_id = c.id
-			WHERE a.appointment_date = $1
-			  AND a.status IN ('scheduled', 'confirmed')
-			  AND COALESCE(a.reminder_1day_sent, false) = false
-			  AND c.telegram_id IS NOT NULL
-			ORDER BY a.start_time`
-		args = []interface{}{targetTime.Format("2006-01-02")}
-	} else {
-		// За 1 час: проверяем записи в пределах часа
-		query = `
-			SELECT a.id, a.client_id, COALESCE(c.telegram_id, 0), c.name, c.surname,
-			       a.trainer_id, a.appointment_date, TO_CHAR(a.start_time, 'HH24:MI')
-			FROM public.appointments a
-			JOIN public.clients c ON a.client_id = c.id
-			WHERE a.appointment_date = $1
-			  AND a.start_time >= $2::time
-			  AND a.start_time < ($2::time + interval '30 minutes')
-			  AND a.status IN ('scheduled', 'confirmed')
-			  AND COALESCE(a.reminder_1hour_sent, false) = false
-			  AND c.telegram_id IS NOT NULL
-			ORDER BY a.start_time`
-		args = []interface{}{targetTime.Format("2006-01-02"), targetTime.Format("15:04:05")}
+		sentColumn = "reminder_1day_sent"
 	}
 
-	rows, err := b.db.Query(query, args...)
+	// Берём записи с запасом ±1 день: пояса тренера, клиента и сервера могут различаться
+	rows, err := b.db.Query(fmt.Sprintf(`
+		SELECT a.id, a.client_id, COALESCE(c.telegram_id, 0), c.name, c.surname,
+		       a.trainer_id, a.appointment_date, TO_CHAR(a.start_time, 'HH24:MI')
+		FROM public.appointments a
+		JOIN public.clients c ON a.client_id = c.id
+		WHERE a.appointment_date BETWEEN CURRENT_DATE - 1 AND CURRENT_DATE + 2
+		  AND a.status IN ('scheduled', 'confirmed')
+		  AND COALESCE(a.%s, false) = false
+		  AND c.telegram_id IS NOT NULL
+		  AND c.bot_blocked_at IS NULL
+		ORDER BY a.appointment_date, a.start_time`, sentColumn))
 	if err != nil {
-		log.Printf("Ошибка получения записей для напоминаний: %v", err)
-		return reminders
+		return nil, fmt.Errorf("ошибка получения записей для напоминаний: %w", err)
 	}
 	defer rows.Close()
 
@@ -119,55 +81,69 @@ func (b *Bot) getAppointmentsForReminder(targetTime time.Time, reminderType stri
 			continue
 		}
 
-		r.AppointmentDate, _ = time.Parse("2006-01-02T15:04:05Z", dateStr)
+		date, _ := time.Parse("2006-01-02T15:04:05Z", dateStr)
+		r.AppointmentDate = b.appointmentStart(r.TrainerID, date, r.StartTime)
 		r.ReminderType = reminderType
+
+		if reminderType == "1day" {
+			if !calendar.DayBeforeReminderDue(r.AppointmentDate, now, 9, b.userLocation(r.ClientTelegramID)) {
+				continue
+			}
+		} else {
+			// За 1 час: тренировка начнётся в ближайший час (с запасом на период задачи)
+			until := r.AppointmentDate.Sub(now)
+			if until <= 0 || until > time.Hour+appointmentReminderPeriod {
+				continue
+			}
+		}
 		reminders = append(reminders, r)
 	}
 
-	return reminders
+	return reminders, rows.Err()
 }
 
-// sendAppointmentReminder отправляет напоминание клиенту
+// sendAppointmentReminder ставит напоминание клиенту в очередь отправки.
+// Ключ очереди не даёт продублировать напоминание, если флаг не успел сохраниться
 func (b *Bot) sendAppointmentReminder(reminder AppointmentReminder) {
 	if reminder.ClientTelegramID == 0 {
 		return
 	}
 
 	chatID := reminder.ClientTelegramID
-	dateStr := reminder.AppointmentDate.Format("02.01.2006")
-	dayName := b.getWeekdayNameLocalized(reminder.AppointmentDate.Weekday(), chatID)
+	// Показываем время начала в поясе клиента
+	start := reminder.AppointmentDate.In(b.userLocation(chatID))
+	dateStr := start.Format("02.01.2006")
+	startTime := start.Format("15:04")
+	dayName := b.getWeekdayNameLocalized(start.Weekday(), chatID)
 
 	var message string
 	if reminder.ReminderType == "1day" {
 		message = b.t("reminder_1day_title", chatID) + "\n\n" +
-			b.tf("reminder_1day_text", chatID, dateStr, dayName, reminder.StartTime)
+			b.tf("reminder_1day_text", chatID, dateStr, dayName, startTime)
 	} else {
 		message = b.t("reminder_1hour_title", chatID) + "\n\n" +
-			b.tf("reminder_1hour_text", chatID, dateStr, reminder.StartTime)
+			b.tf("reminder_1hour_text", chatID, dateStr, startTime)
 	}
 
 	msg := tgbotapi.NewMessage(chatID, message)
 	msg.ParseMode = "Markdown"
 
-	if _, err := b.api.Send(msg); err != nil {
-		log.Printf("Ошибка отправки напоминания клиенту %d: %v", chatID, err)
+	key := fmt.Sprintf("appointment_reminder:%s:%d", reminder.ReminderType, reminder.AppointmentID)
+	if err := b.outbox.EnqueueOnce(key, msg); err != nil {
+		log.Printf("Ошибка постановки напоминания клиенту %d в очередь: %v", chatID, err)
 		return
 	}
 
 	// Отмечаем напоминание как отправленное
 	b.markReminderSent(reminder.AppointmentID, reminder.ReminderType)
-	log.Printf("Отправлено напоминание (%s) клиенту %s %s на %s %s",
+	log.Printf("Поставлено в очередь напоминание (%s) клиенту %s %s на %s %s",
 		reminder.ReminderType, reminder.ClientName, reminder.ClientSurname,
-		dateStr, reminder.StartTime)
+		dateStr, startTime)
 }
 
 // getWeekdayNameLocalized возвращает локализованное название дня недели
 func (b *Bot) getWeekdayNameLocalized(w time.Weekday, chatID int64) string {
-	keys := []string{
-		"weekday_sunday", "weekday_monday", "weekday_tuesday",
-		"weekday_wednesday", "weekday_thursday", "weekday_friday", "weekday_saturday",
-	}
-	return b.t(keys[w], chatID)
+	return weekdayFull(w, b.getLanguage(chatID))
 }
 
 // markReminderSent отмечает напоминание как отправленное
@@ -186,5 +162,3 @@ func (b *Bot) markReminderSent(appointmentID int, reminderType string) {
 		log.Printf("Ошибка обновления флага напоминания: %v", err)
 	}
 }
-
-// Примечание: russianWeekdayFull определена в calendar_widget.go
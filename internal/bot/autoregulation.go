@@ -0,0 +1,111 @@
+package bot
+
+import (
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"workbot/internal/models"
+	"workbot/internal/repository"
+	"workbot/internal/training"
+)
+
+// liftE1RM — топ-сеты одного упражнения
+type liftE1RM struct {
+	Name string
+	Tops []training.E1RMPoint
+}
+
+// liftKey — ключ упражнения для сопоставления записей: регистр, ё и лишние
+// пробелы в названии не важны
+func liftKey(name string) string {
+	name = strings.ReplaceAll(strings.ToLower(name), "ё", "е")
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// groupLiftE1RM группирует подходы по упражнениям и оставляет топ-сет каждого дня.
+// Упражнения без подходов в пределах таблицы RPE не попадают в результат.
+// Результат упорядочен по названию
+func groupLiftE1RM(sets []repository.RPESet) []liftE1RM {
+	points := make(map[string][]training.E1RMPoint)
+	names := make(map[string]string)
+	for _, s := range sets {
+		key := liftKey(s.ExerciseName)
+		if _, ok := names[key]; !ok {
+			names[key] = s.ExerciseName
+		}
+		points[key] = append(points[key], training.E1RMPoint{Date: s.Date, Weight: s.Weight, Reps: s.Reps, RPE: s.RPE})
+	}
+
+	var lifts []liftE1RM
+	for key, p := range points {
+		if tops := training.TopSets(p); len(tops) > 0 {
+			lifts = append(lifts, liftE1RM{Name: names[key], Tops: tops})
+		}
+	}
+	sort.Slice(lifts, func(i, j int) bool { return lifts[i].Name < lifts[j].Name })
+	return lifts
+}
+
+// rollingE1RMs возвращает скользящий e1RM клиента по упражнениям (ключ — liftKey)
+func (b *Bot) rollingE1RMs(clientID int, now time.Time) map[string]float64 {
+	sets, err := b.repo.Program.GetRPESets(clientID, now.AddDate(0, 0, -training.RollingE1RMDays))
+	if err != nil {
+		log.Printf("Ошибка получения подходов с RPE клиента %d: %v", clientID, err)
+		return nil
+	}
+	e1rms := make(map[string]float64)
+	for _, lift := range groupLiftE1RM(sets) {
+		if e1rm := training.RollingE1RM(lift.Tops, now); e1rm > 0 {
+			e1rms[liftKey(lift.Name)] = e1rm
+		}
+	}
+	return e1rms
+}
+
+// rpeTargetWeight переводит плановые повторы и целевой RPE упражнения в килограммы
+// от e1RM; 0 — у упражнения нет цели по RPE или она вне таблицы
+func rpeTargetWeight(e models.WorkoutExercise, e1rm float64) float64 {
+	if e.RPE <= 0 {
+		return 0
+	}
+	return training.WeightForRPE(e1rm, parsePlannedReps(e.Reps), e.RPE, weightStep)
+}
+
+// applyRPETargets назначает вес упражнениям с целевым RPE по скользящему e1RM
+// клиента. Плановый вес сохраняется в planned_weight, как при корректировке
+// по готовности; упражнения без истории подходов с RPE остаются по плану
+func (b *Bot) applyRPETargets(session *WorkoutSession, clientID int) {
+	var e1rms map[string]float64
+	for i := range session.Exercises {
+		e := &session.Exercises[i]
+		if e.Completed || e.RPE <= 0 {
+			continue
+		}
+		if e1rms == nil {
+			e1rms = b.rollingE1RMs(clientID, time.Now())
+			if len(e1rms) == 0 {
+				return
+			}
+		}
+		e1rm := e1rms[liftKey(e.ExerciseName)]
+		weight := rpeTargetWeight(*e, e1rm)
+		if weight <= 0 {
+			continue
+		}
+		if weight != e.Weight {
+			percent := math.Round(weight/e1rm*1000) / 10
+			if err := b.repo.Program.AdjustExerciseLoad(e.ID, e.Sets, weight, percent); err != nil {
+				log.Printf("Ошибка назначения веса по RPE упражнению %d: %v", e.ID, err)
+				continue
+			}
+			e.Weight, e.WeightPercent = weight, percent
+		}
+		if session.RPETargets == nil {
+			session.RPETargets = make(map[int]float64)
+		}
+		session.RPETargets[e.ID] = e1rm
+	}
+}
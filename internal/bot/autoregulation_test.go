@@ -0,0 +1,50 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"workbot/internal/models"
+	"workbot/internal/repository"
+)
+
+func TestLiftKey(t *testing.T) {
+	if a, b := liftKey("Жим  лёжа"), liftKey(" жим лежа "); a != b {
+		t.Errorf("liftKey: %q != %q", a, b)
+	}
+}
+
+func TestGroupLiftE1RM(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	sets := []repository.RPESet{
+		{ExerciseName: "Присед", Date: day, Reps: 5, Weight: 120, RPE: 8},
+		{ExerciseName: "Жим лёжа", Date: day, Reps: 5, Weight: 100, RPE: 8},
+		{ExerciseName: "жим лежа", Date: day.AddDate(0, 0, 3), Reps: 3, Weight: 105, RPE: 8},
+		{ExerciseName: "Тяга", Date: day, Reps: 15, Weight: 80, RPE: 8}, // вне таблицы RPE
+	}
+
+	lifts := groupLiftE1RM(sets)
+	if len(lifts) != 2 {
+		t.Fatalf("groupLiftE1RM = %+v, хотим 2 упражнения", lifts)
+	}
+	if lifts[0].Name != "Жим лёжа" || len(lifts[0].Tops) != 2 {
+		t.Errorf("жим = %+v, хотим 2 топ-сета", lifts[0])
+	}
+	if lifts[1].Name != "Присед" || lifts[1].Tops[0].E1RM != 148 {
+		t.Errorf("присед = %+v, хотим e1RM 148", lifts[1])
+	}
+}
+
+func TestRPETargetWeight(t *testing.T) {
+	e := models.WorkoutExercise{Reps: "5", RPE: 8}
+	if got := rpeTargetWeight(e, 200); got != 162.5 {
+		t.Errorf("rpeTargetWeight(5@8, 200) = %v, хотим 162.5", got)
+	}
+	if got := rpeTargetWeight(e, 0); got != 0 {
+		t.Errorf("без e1RM = %v, хотим 0", got)
+	}
+	e.RPE = 0
+	if got := rpeTargetWeight(e, 200); got != 0 {
+		t.Errorf("без RPE = %v, хотим 0", got)
+	}
+}
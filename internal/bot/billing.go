@@ -0,0 +1,747 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"workbot/internal/billing"
+	"workbot/internal/i18n"
+	"workbot/internal/scheduler"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Состояния создания пакета тренером
+const (
+	stateBillingPkgName  = "billing_pkg_name"
+	stateBillingPkgKind  = "billing_pkg_kind"
+	stateBillingPkgCount = "billing_pkg_count"
+	stateBillingPkgDays  = "billing_pkg_days"
+	stateBillingPkgPrice = "billing_pkg_price"
+)
+
+const (
+	// billingPaymentsShown — сколько последних платежей показывается на экране абонемента
+	billingPaymentsShown = 5
+	// billingMaxSessions и billingMaxDays — ограничения при создании пакета
+	billingMaxSessions = 200
+	billingMaxDays     = 730
+)
+
+// packageDrafts хранит пакет, который тренер сейчас заполняет
+var packageDrafts = struct {
+	sync.Mutex
+	data map[int64]*billing.Package
+}{data: make(map[int64]*billing.Package)}
+
+// billingCallback формирует данные кнопки: bill_<действие>[_<аргументы>]
+func billingCallback(action string, args ...int) string {
+	parts := []string{"bill", action}
+	for _, a := range args {
+		parts = append(parts, strconv.Itoa(a))
+	}
+	return strings.Join(parts, "_")
+}
+
+// parseBillingCallback разбирает данные кнопки абонементов
+func parseBillingCallback(data string) (action string, args []int, ok bool) {
+	parts := strings.Split(data, "_")
+	if len(parts) < 2 || parts[0] != "bill" {
+		return "", nil, false
+	}
+	for _, p := range parts[2:] {
+		n, err := strconv.Atoi(p)
+		if err != nil || n <= 0 {
+			return "", nil, false
+		}
+		args = append(args, n)
+	}
+	return parts[1], args, true
+}
+
+// balanceColumns — колонки client_subscriptions в порядке scanBalances
+const balanceColumns = `id, client_id, COALESCE(package_id, 0), name, kind,
+	sessions_total, sessions_left, starts_on, expires_on`
+
+// scanBalances читает абонементы из результата запроса с balanceColumns
+func scanBalances(rows *sql.Rows) ([]billing.Balance, error) {
+	defer rows.Close()
+	var balances []billing.Balance
+	for rows.Next() {
+		var bal billing.Balance
+		var expires sql.NullTime
+		if err := rows.Scan(&bal.ID, &bal.ClientID, &bal.PackageID, &bal.Name, &bal.Kind,
+			&bal.Total, &bal.Left, &bal.Starts, &expires); err != nil {
+			return nil, err
+		}
+		bal.Expires = expires.Time
+		balances = append(balances, bal)
+	}
+	return balances, rows.Err()
+}
+
+// loadBalances загружает абонементы клиента, которые ещё действуют на дату today
+func (b *Bot) loadBalances(clientID int, today time.Time) ([]billing.Balance, error) {
+	rows, err := b.db.Query(`
+		SELECT `+balanceColumns+`
+		FROM public.client_subscriptions
+		WHERE client_id = $1 AND (expires_on IS NULL OR expires_on >= $2)
+		ORDER BY starts_on, id`, clientID, today.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	return scanBalances(rows)
+}
+
+// packageColumns — колонки billing_packages в порядке scanPackage
+const packageColumns = `id, trainer_id, name, kind, sessions, days, price, currency, is_active`
+
+func scanPackage(scan func(dest ...interface{}) error) (billing.Package, error) {
+	var p billing.Package
+	err := scan(&p.ID, &p.TrainerID, &p.Name, &p.Kind, &p.Sessions, &p.Days, &p.Price, &p.Currency, &p.Active)
+	return p, err
+}
+
+// loadPackages загружает действующие пакеты тренера; trainerID == 0 — пакеты всех тренеров
+func (b *Bot) loadPackages(trainerID int64) ([]billing.Package, error) {
+	rows, err := b.db.Query(`
+		SELECT `+packageColumns+`
+		FROM public.billing_packages
+		WHERE is_active AND ($1 = 0 OR trainer_id = $1)
+		ORDER BY kind, sessions, days, price`, trainerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var packages []billing.Package
+	for rows.Next() {
+		p, err := scanPackage(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		packages = append(packages, p)
+	}
+	return packages, rows.Err()
+}
+
+// loadPackage загружает пакет по id, в том числе снятый с продажи
+func (b *Bot) loadPackage(id int) (billing.Package, error) {
+	return scanPackage(b.db.QueryRow(`SELECT `+packageColumns+` FROM public.billing_packages WHERE id = $1`, id).Scan)
+}
+
+// describePackage — состав пакета одной строкой: «10 тренировок · 60 дней · 25 000 RUB»
+func (b *Bot) describePackage(chatID int64, p billing.Package) string {
+	var parts []string
+	if p.Kind == billing.Sessions {
+		parts = append(parts, b.tn("billing_sessions", chatID, p.Sessions))
+		if p.Days > 0 {
+			parts = append(parts, b.tf("billing_valid_days", chatID, b.tn("days", chatID, p.Days)))
+		}
+	} else {
+		parts = append(parts, b.tf("billing_unlimited_days", chatID, b.tn("days", chatID, p.Days)))
+	}
+	parts = append(parts, billing.FormatMoney(p.Price, p.Currency))
+	return strings.Join(parts, " · ")
+}
+
+// formatBalance — абонемент одной строкой: остаток и срок действия
+func (b *Bot) formatBalance(chatID int64, bal billing.Balance, today time.Time) string {
+	var line string
+	if bal.Kind == billing.Sessions {
+		line = b.tf("billing_balance_sessions", chatID, bal.Name, bal.Left, bal.Total)
+	} else {
+		line = b.tf("billing_balance_period", chatID, bal.Name)
+	}
+	if bal.Starts.After(today) {
+		line += " " + b.tf("billing_balance_from", chatID, bal.Starts.Format("02.01.2006"))
+	}
+	if !bal.Expires.IsZero() {
+		line += " " + b.tf("billing_balance_until", chatID, bal.Expires.Format("02.01.2006"))
+	}
+	return line
+}
+
+// clientToday возвращает сегодняшнюю дату по поясу клиента
+func (b *Bot) clientToday(clientID int) time.Time {
+	var telegramID sql.NullInt64
+	b.db.QueryRow("SELECT telegram_id FROM public.clients WHERE id = $1", clientID).Scan(&telegramID)
+	return time.Now().In(b.userLocation(telegramID.Int64))
+}
+
+// handleMyBalance открывает клиенту экран абонемента
+func (b *Bot) handleMyBalance(chatID int64) {
+	clientID, err := b.repo.Program.GetClientByTelegramID(chatID)
+	if err != nil || clientID == 0 {
+		b.sendMessage(chatID, b.t("reg_not_registered", chatID))
+		return
+	}
+	b.showBilling(chatID, clientID, 0)
+}
+
+// showBilling показывает абонементы клиента и последние платежи. Клиенту —
+// с кнопкой покупки, тренеру — с кнопками внесения оплаты и выставления счёта
+func (b *Bot) showBilling(chatID int64, clientID int, messageID int) {
+	owner := b.isClientOwner(chatID, clientID)
+	if !owner && !b.isAdmin(chatID) {
+		return
+	}
+
+	var name, surname string
+	if err := b.db.QueryRow("SELECT name, surname FROM public.clients WHERE id = $1", clientID).
+		Scan(&name, &surname); err != nil {
+		b.sendError(chatID, b.t("billing_load_error", chatID), err)
+		return
+	}
+	today := b.clientToday(clientID)
+	balances, err := b.loadBalances(clientID, today)
+	if err != nil {
+		b.sendError(chatID, b.t("billing_load_error", chatID), err)
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(b.t("billing_title", chatID) + "\n")
+	if !owner {
+		text.WriteString(b.tf("chart_client", chatID, name, surname) + "\n")
+	}
+	text.WriteString("\n")
+
+	var usable int
+	for _, bal := range balances {
+		if bal.Kind == billing.Period || bal.Left > 0 {
+			text.WriteString(b.formatBalance(chatID, bal, today) + "\n")
+			usable++
+		}
+	}
+	if usable == 0 {
+		text.WriteString(b.t("billing_no_balance", chatID) + "\n")
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	payments, err := b.recentPayments(clientID, billingPaymentsShown)
+	if err != nil {
+		log.Printf("Ошибка загрузки платежей клиента %d: %v", clientID, err)
+	}
+	if len(payments) > 0 {
+		text.WriteString("\n" + b.t("billing_payments_title", chatID) + "\n")
+		var docRow []tgbotapi.InlineKeyboardButton
+		for _, p := range payments {
+			text.WriteString(b.formatPaymentLine(chatID, p) + "\n")
+			docRow = append(docRow, tgbotapi.NewInlineKeyboardButtonData(
+				b.tf("billing_btn_document", chatID, p.ID), billingCallback("pdf", p.ID)))
+		}
+		rows = append(rows, docRow)
+	}
+
+	switch {
+	case owner && b.payments != nil:
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("billing_btn_buy", chatID), billingCallback("buy", clientID))))
+	case !owner:
+		row := tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("billing_btn_sell", chatID), billingCallback("sell", clientID)))
+		if b.payments != nil {
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(b.t("billing_btn_invoice", chatID), billingCallback("inv", clientID)))
+		}
+		rows = append(rows, row)
+	}
+	b.sendOrEditInline(chatID, messageID, text.String(), rows)
+}
+
+// handleBillingCallback обрабатывает кнопки абонементов и каталога пакетов
+func (b *Bot) handleBillingCallback(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	b.api.Send(tgbotapi.NewCallback(callback.ID, ""))
+
+	action, args, ok := parseBillingCallback(callback.Data)
+	if !ok {
+		return
+	}
+	arg := func(i int) int {
+		if i < len(args) {
+			return args[i]
+		}
+		return 0
+	}
+
+	admin := b.isAdmin(chatID)
+	switch action {
+	case "view":
+		b.showBilling(chatID, arg(0), messageID)
+	case "pdf":
+		b.sendPaymentDocument(chatID, arg(0))
+	case "buy":
+		if b.isClientOwner(chatID, arg(0)) {
+			b.showPackagePicker(chatID, arg(0), "buyp", messageID)
+		}
+	case "buyp":
+		if b.isClientOwner(chatID, arg(0)) {
+			b.sendPackageInvoice(chatID, arg(0), arg(1))
+		}
+	case "sell", "inv":
+		if admin {
+			b.showPackagePicker(chatID, arg(0), action+"p", messageID)
+		}
+	case "sellp":
+		if admin {
+			b.confirmManualPayment(chatID, arg(0), arg(1), messageID)
+		}
+	case "sellok":
+		if admin {
+			b.recordManualPayment(chatID, arg(0), arg(1), messageID)
+		}
+	case "invp":
+		if admin {
+			b.sendPackageInvoice(chatID, arg(0), arg(1))
+		}
+	case "pkgs":
+		if admin {
+			b.handlePackagesMenu(chatID, messageID)
+		}
+	case "pkgadd":
+		if admin {
+			b.startPackageDraft(chatID)
+		}
+	case "pkgoff":
+		if admin {
+			b.archivePackage(chatID, arg(0), messageID)
+		}
+	}
+}
+
+// showPackagePicker показывает пакеты для покупки клиентом (buyp), внесения
+// оплаты (sellp) или выставления счёта (invp). Тренеру — его пакеты, клиенту — все
+func (b *Bot) showPackagePicker(chatID int64, clientID int, action string, messageID int) {
+	var trainerID int64
+	if action != "buyp" {
+		trainerID = chatID
+	}
+	packages, err := b.loadPackages(trainerID)
+	if err != nil {
+		b.sendError(chatID, b.t("billing_load_error", chatID), err)
+		return
+	}
+
+	backRow := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("billing_btn_back", chatID), billingCallback("view", clientID)))
+	if len(packages) == 0 {
+		key := "billing_no_packages_client"
+		if trainerID != 0 {
+			key = "billing_no_packages_trainer"
+		}
+		b.sendOrEditInline(chatID, messageID, b.t(key, chatID), [][]tgbotapi.InlineKeyboardButton{backRow})
+		return
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, p := range packages {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(
+			b.tf("billing_package_button", chatID, p.Name, billing.FormatMoney(p.Price, p.Currency)),
+			billingCallback(action, clientID, p.ID))))
+	}
+	rows = append(rows, backRow)
+	b.sendOrEditInline(chatID, messageID, b.t("billing_pick_package_"+action, chatID), rows)
+}
+
+// handlePackagesMenu показывает тренеру каталог его пакетов
+func (b *Bot) handlePackagesMenu(chatID int64, messageID int) {
+	packages, err := b.loadPackages(chatID)
+	if err != nil {
+		b.sendError(chatID, b.t("billing_load_error", chatID), err)
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(b.t("billing_packages_title", chatID) + "\n\n")
+	if len(packages) == 0 {
+		text.WriteString(b.t("billing_packages_empty", chatID))
+	}
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, p := range packages {
+		text.WriteString(fmt.Sprintf("• %s — %s\n", p.Name, b.describePackage(chatID, p)))
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(
+			b.tf("billing_btn_archive", chatID, p.Name), billingCallback("pkgoff", p.ID))))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("billing_btn_add_package", chatID), billingCallback("pkgadd"))))
+	b.sendOrEditInline(chatID, messageID, text.String(), rows)
+}
+
+// archivePackage снимает пакет тренера с продажи; купленные абонементы не меняются
+func (b *Bot) archivePackage(chatID int64, packageID int, messageID int) {
+	if _, err := b.db.Exec(`
+		UPDATE public.billing_packages SET is_active = false
+		WHERE id = $1 AND trainer_id = $2`, packageID, chatID); err != nil {
+		b.sendError(chatID, b.t("billing_save_error", chatID), err)
+		return
+	}
+	b.handlePackagesMenu(chatID, messageID)
+}
+
+// startPackageDraft начинает создание пакета: название, вид, количество, срок, цена
+func (b *Bot) startPackageDraft(chatID int64) {
+	packageDrafts.Lock()
+	packageDrafts.data[chatID] = &billing.Package{TrainerID: chatID, Currency: b.currency(), Active: true}
+	packageDrafts.Unlock()
+
+	setState(chatID, stateBillingPkgName)
+	b.sendMessageWithKeyboard(chatID, b.t("billing_ask_name", chatID), b.createCancelKeyboard(chatID))
+}
+
+// currency — валюта новых пакетов из настроек
+func (b *Bot) currency() string {
+	if b.config != nil && b.config.PaymentsCurrency != "" {
+		return b.config.PaymentsCurrency
+	}
+	return billing.DefaultCurrency
+}
+
+// processBillingState обрабатывает ввод при создании пакета
+func (b *Bot) processBillingState(message *tgbotapi.Message, state string) {
+	chatID := message.Chat.ID
+	text := strings.TrimSpace(message.Text)
+
+	if i18n.Is(text, "cancel") {
+		packageDrafts.Lock()
+		delete(packageDrafts.data, chatID)
+		packageDrafts.Unlock()
+		clearState(chatID)
+		b.sendMessage(chatID, b.t("billing_draft_cancelled", chatID))
+		b.handleAdminStart(message)
+		return
+	}
+
+	packageDrafts.Lock()
+	draft := packageDrafts.data[chatID]
+	packageDrafts.Unlock()
+	if draft == nil {
+		clearState(chatID)
+		b.handleAdminStart(message)
+		return
+	}
+
+	switch state {
+	case stateBillingPkgName:
+		if text == "" || len([]rune(text)) > 100 {
+			b.sendMessage(chatID, b.t("billing_ask_name", chatID))
+			return
+		}
+		draft.Name = text
+		setState(chatID, stateBillingPkgKind)
+		b.sendMessageWithKeyboard(chatID, b.t("billing_ask_kind", chatID), tgbotapi.NewReplyKeyboard(
+			tgbotapi.NewKeyboardButtonRow(
+				tgbotapi.NewKeyboardButton(b.t("billing_kind_sessions", chatID)),
+				tgbotapi.NewKeyboardButton(b.t("billing_kind_period", chatID)),
+			),
+			tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(b.t("cancel", chatID))),
+		))
+
+	case stateBillingPkgKind:
+		switch i18n.Match(text, "billing_kind_sessions", "billing_kind_period") {
+		case "billing_kind_sessions":
+			draft.Kind = billing.Sessions
+			setState(chatID, stateBillingPkgCount)
+			b.sendMessageWithKeyboard(chatID, b.t("billing_ask_count", chatID), tgbotapi.NewReplyKeyboard(
+				b.countButtons("billing_count_button", chatID, 5, 10, 20),
+				tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(b.t("cancel", chatID))),
+			))
+		case "billing_kind_period":
+			draft.Kind = billing.Period
+			b.askPackageDays(chatID, draft)
+		default:
+			b.sendMessage(chatID, b.t("billing_ask_kind", chatID))
+		}
+
+	case stateBillingPkgCount:
+		n, ok := leadingNumber(text)
+		if !ok || n < 1 || n > billingMaxSessions {
+			b.sendMessage(chatID, b.tf("billing_bad_count", chatID, billingMaxSessions))
+			return
+		}
+		draft.Sessions = n
+		b.askPackageDays(chatID, draft)
+
+	case stateBillingPkgDays:
+		if draft.Kind == billing.Sessions && i18n.Is(text, "skip") {
+			draft.Days = 0
+		} else {
+			n, ok := leadingNumber(text)
+			if !ok || n < 1 || n > billingMaxDays {
+				b.sendMessage(chatID, b.tf("billing_bad_days", chatID, billingMaxDays))
+				return
+			}
+			draft.Days = n
+		}
+		setState(chatID, stateBillingPkgPrice)
+		b.sendMessageWithKeyboard(chatID, b.tf("billing_ask_price", chatID, draft.Currency), b.createCancelKeyboard(chatID))
+
+	case stateBillingPkgPrice:
+		price, err := billing.ParseMoney(text)
+		if err != nil {
+			b.sendMessage(chatID, b.tf("billing_bad_price", chatID, draft.Currency))
+			return
+		}
+		draft.Price = price
+		b.savePackageDraft(message, draft)
+	}
+}
+
+// askPackageDays спрашивает срок действия; у пакета тренировок его можно пропустить
+func (b *Bot) askPackageDays(chatID int64, draft *billing.Package) {
+	setState(chatID, stateBillingPkgDays)
+	rows := [][]tgbotapi.KeyboardButton{b.countButtons("billing_days_button", chatID, 30, 60, 90)}
+	key := "billing_ask_days_period"
+	if draft.Kind == billing.Sessions {
+		key = "billing_ask_days_sessions"
+		rows = append(rows, tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(b.t("skip", chatID))))
+	}
+	rows = append(rows, tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(b.t("cancel", chatID))))
+	b.sendMessageWithKeyboard(chatID, b.t(key, chatID), tgbotapi.NewReplyKeyboard(rows...))
+}
+
+// leadingNumber извлекает число из ответа: кнопки «10 тренировок» или ввода «12»
+func leadingNumber(text string) (int, bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(fields[0])
+	return n, err == nil
+}
+
+// savePackageDraft сохраняет пакет в каталог тренера
+func (b *Bot) savePackageDraft(message *tgbotapi.Message, draft *billing.Package) {
+	chatID := message.Chat.ID
+	packageDrafts.Lock()
+	delete(packageDrafts.data, chatID)
+	packageDrafts.Unlock()
+	clearState(chatID)
+
+	if err := draft.Validate(); err != nil {
+		b.sendError(chatID, b.t("billing_save_error", chatID), err)
+		b.handleAdminStart(message)
+		return
+	}
+	if _, err := b.db.Exec(`
+		INSERT INTO public.billing_packages (trainer_id, name, kind, sessions, days, price, currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		draft.TrainerID, draft.Name, draft.Kind, draft.Sessions, draft.Days, draft.Price, draft.Currency); err != nil {
+		b.sendError(chatID, b.t("billing_save_error", chatID), err)
+		b.handleAdminStart(message)
+		return
+	}
+
+	b.sendMessage(chatID, b.tf("billing_package_saved", chatID, draft.Name, b.describePackage(chatID, *draft)))
+	b.handleAdminStart(message)
+	b.handlePackagesMenu(chatID, 0)
+}
+
+// chargeResult — итог списания за проведённую тренировку
+type chargeResult struct {
+	Charged  bool // тренировка списана с абонемента
+	Already  bool // тренировка была списана раньше
+	Balance  billing.Balance
+	Balances []billing.Balance // все действующие абонементы клиента после списания
+}
+
+// chargeAppointment списывает проведённую тренировку с абонемента клиента.
+// Повторная отметка той же записи ничего не списывает
+func (b *Bot) chargeAppointment(appointmentID int) (chargeResult, error) {
+	var res chargeResult
+	tx, err := b.db.Begin()
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback()
+
+	var clientID int
+	var date time.Time
+	if err := tx.QueryRow("SELECT client_id, appointment_date FROM public.appointments WHERE id = $1", appointmentID).
+		Scan(&clientID, &date); err != nil {
+		return res, err
+	}
+	if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM public.subscription_charges WHERE appointment_id = $1)",
+		appointmentID).Scan(&res.Already); err != nil || res.Already {
+		return res, err
+	}
+
+	rows, err := tx.Query(`
+		SELECT `+balanceColumns+`
+		FROM public.client_subscriptions
+		WHERE client_id = $1 AND (expires_on IS NULL OR expires_on >= $2)
+		ORDER BY starts_on, id
+		FOR UPDATE`, clientID, date.Format("2006-01-02"))
+	if err != nil {
+		return res, err
+	}
+	balances, err := scanBalances(rows)
+	if err != nil {
+		return res, err
+	}
+	pick, ok := billing.Pick(balances, date)
+	if !ok {
+		res.Balances = balances
+		return res, nil
+	}
+
+	if _, err := tx.Exec(`
+		INSERT INTO public.subscription_charges (appointment_id, subscription_id) VALUES ($1, $2)`,
+		appointmentID, pick.ID); err != nil {
+		return res, err
+	}
+	if pick.Kind == billing.Sessions {
+		if _, err := tx.Exec(`
+			UPDATE public.client_subscriptions SET sessions_left = sessions_left - 1
+			WHERE id = $1 AND sessions_left > 0`, pick.ID); err != nil {
+			return res, err
+		}
+		pick.Left--
+		for i := range balances {
+			if balances[i].ID == pick.ID {
+				balances[i] = pick
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return res, err
+	}
+	res.Charged, res.Balance, res.Balances = true, pick, balances
+	return res, nil
+}
+
+// refundAppointment возвращает тренировку на абонемент, если запись, отмеченная
+// проведённой, потом подтверждена заново или отменена. ok == false — списания не было
+func (b *Bot) refundAppointment(appointmentID int) (ok bool, err error) {
+	tx, err := b.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var subscriptionID int
+	err = tx.QueryRow(`
+		DELETE FROM public.subscription_charges WHERE appointment_id = $1
+		RETURNING subscription_id`, appointmentID).Scan(&subscriptionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(`
+		UPDATE public.client_subscriptions SET sessions_left = sessions_left + 1
+		WHERE id = $1 AND kind = 'sessions' AND sessions_left < sessions_total`, subscriptionID); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// updateAppointmentBalance списывает или возвращает тренировку после смены статуса
+// записи и сообщает тренеру, с какого абонемента она списана
+func (b *Bot) updateAppointmentBalance(chatID int64, appointmentID int, newStatus string) {
+	if newStatus != "completed" {
+		refunded, err := b.refundAppointment(appointmentID)
+		if err != nil {
+			log.Printf("Ошибка возврата тренировки по записи %d: %v", appointmentID, err)
+			return
+		}
+		if refunded {
+			b.sendMessage(chatID, b.t("billing_refunded", chatID))
+		}
+		return
+	}
+
+	res, err := b.chargeAppointment(appointmentID)
+	switch {
+	case err != nil:
+		log.Printf("Ошибка списания тренировки по записи %d: %v", appointmentID, err)
+		b.sendMessage(chatID, b.t("billing_charge_error", chatID))
+	case res.Already:
+	case !res.Charged:
+		b.sendMessage(chatID, b.t("billing_charge_none", chatID))
+	case res.Balance.Kind == billing.Period:
+		b.sendMessage(chatID, b.tf("billing_charged_period", chatID, res.Balance.Name))
+	default:
+		b.sendMessage(chatID, b.tf("billing_charged", chatID, res.Balance.Name, res.Balance.Left, res.Balance.Total))
+	}
+	if res.Charged {
+		b.remindRenewal(res.Balance, res.Balances, time.Now())
+	}
+}
+
+// remindRenewal напоминает клиенту о продлении, если тренировки заканчиваются
+// или абонемент скоро истекает и другого нет. По каждому поводу — один раз
+func (b *Bot) remindRenewal(bal billing.Balance, balances []billing.Balance, now time.Time) {
+	var telegramID sql.NullInt64
+	var blocked bool
+	if err := b.db.QueryRow(`
+		SELECT telegram_id, bot_blocked_at IS NOT NULL FROM public.clients WHERE id = $1`, bal.ClientID).
+		Scan(&telegramID, &blocked); err != nil || !telegramID.Valid || blocked {
+		return
+	}
+	chatID := telegramID.Int64
+
+	var text string
+	reason := billing.Remind(bal, balances, now.In(b.userLocation(chatID)))
+	switch reason {
+	case billing.ReminderLow:
+		text = b.tf("billing_remind_low", chatID, bal.Name, bal.Left)
+	case billing.ReminderExpires:
+		text = b.tf("billing_remind_expires", chatID, bal.Name, bal.Expires.Format("02.01.2006"))
+	default:
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	if b.payments != nil {
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("billing_btn_buy", chatID), billingCallback("buy", bal.ClientID))))
+	}
+	key := fmt.Sprintf("billing_remind:%d:%s", bal.ID, reason)
+	if err := b.outbox.EnqueueOnce(key, msg); err != nil {
+		log.Printf("Ошибка постановки напоминания о продлении клиенту %d в очередь: %v", bal.ClientID, err)
+	}
+}
+
+// runBillingReminders — задача планировщика: напоминает о продлении клиентам,
+// у которых заканчиваются тренировки или истекает абонемент
+func (b *Bot) runBillingReminders(ctx context.Context, run scheduler.Run) error {
+	rows, err := b.db.Query(`
+		SELECT ` + balanceColumns + `
+		FROM public.client_subscriptions s
+		WHERE (expires_on IS NULL OR expires_on >= CURRENT_DATE - 1)
+		  AND EXISTS (
+			SELECT 1 FROM public.clients c
+			WHERE c.id = s.client_id AND c.deleted_at IS NULL
+			  AND c.telegram_id IS NOT NULL AND c.bot_blocked_at IS NULL)
+		ORDER BY client_id, starts_on, id`)
+	if err != nil {
+		return fmt.Errorf("ошибка загрузки абонементов: %w", err)
+	}
+	balances, err := scanBalances(rows)
+	if err != nil {
+		return err
+	}
+
+	byClient := make(map[int][]billing.Balance)
+	for _, bal := range balances {
+		byClient[bal.ClientID] = append(byClient[bal.ClientID], bal)
+	}
+	now := time.Now()
+	for _, bal := range balances {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		b.remindRenewal(bal, byClient[bal.ClientID], now)
+	}
+	return nil
+}
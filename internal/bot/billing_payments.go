@@ -0,0 +1,398 @@
+package bot
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"workbot/internal/billing"
+	"workbot/internal/pdfdoc"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// providerManual — оплата, которую тренер внёс вручную (наличные, перевод)
+const providerManual = "manual"
+
+// paymentRecord — платёж из таблицы payments
+type paymentRecord struct {
+	ID             int
+	ClientID       int
+	PackageID      int
+	SubscriptionID int
+	TrainerID      int64
+	Description    string
+	Amount         int64
+	Currency       string
+	Provider       string
+	Status         string
+	CreatedAt      time.Time
+	PaidAt         time.Time
+}
+
+// paymentColumns — колонки payments в порядке scanPayment
+const paymentColumns = `id, client_id, COALESCE(package_id, 0), COALESCE(subscription_id, 0), trainer_id,
+	description, amount, currency, provider, status, created_at, paid_at`
+
+func scanPayment(scan func(dest ...interface{}) error) (paymentRecord, error) {
+	var p paymentRecord
+	var paidAt sql.NullTime
+	err := scan(&p.ID, &p.ClientID, &p.PackageID, &p.SubscriptionID, &p.TrainerID,
+		&p.Description, &p.Amount, &p.Currency, &p.Provider, &p.Status, &p.CreatedAt, &paidAt)
+	p.PaidAt = paidAt.Time
+	return p, err
+}
+
+// recentPayments возвращает последние оплаченные и ожидающие оплаты платежи клиента
+func (b *Bot) recentPayments(clientID, limit int) ([]paymentRecord, error) {
+	rows, err := b.db.Query(`
+		SELECT `+paymentColumns+`
+		FROM public.payments
+		WHERE client_id = $1 AND status <> 'canceled'
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2`, clientID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []paymentRecord
+	for rows.Next() {
+		p, err := scanPayment(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, p)
+	}
+	return payments, rows.Err()
+}
+
+// formatPaymentLine — платёж одной строкой для экрана абонемента
+func (b *Bot) formatPaymentLine(chatID int64, p paymentRecord) string {
+	key := "billing_payment_paid"
+	at := p.PaidAt
+	if p.Status == billing.StatusPending {
+		key, at = "billing_payment_pending", p.CreatedAt
+	}
+	return b.tf(key, chatID, p.ID, at.In(b.userLocation(chatID)).Format("02.01.2006"),
+		p.Description, billing.FormatMoney(p.Amount, p.Currency))
+}
+
+// createPayment записывает платёж за пакет со статусом pending
+func (b *Bot) createPayment(clientID int, pkg billing.Package, provider string) (int, error) {
+	var id int
+	err := b.db.QueryRow(`
+		INSERT INTO public.payments (client_id, package_id, trainer_id, description, amount, currency, provider)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`,
+		clientID, pkg.ID, pkg.TrainerID, pkg.Name, pkg.Price, pkg.Currency, provider).Scan(&id)
+	return id, err
+}
+
+// completePayment отмечает платёж оплаченным и активирует абонемент. confirm —
+// подтверждение провайдера; nil — оплату внёс тренер, сумма берётся из счёта.
+// Повторное подтверждение того же платежа возвращает billing.ErrNotPending
+func (b *Bot) completePayment(paymentID int, confirm *billing.Confirmation) (paymentRecord, billing.Balance, error) {
+	var bal billing.Balance
+	tx, err := b.db.Begin()
+	if err != nil {
+		return paymentRecord{}, bal, err
+	}
+	defer tx.Rollback()
+
+	p, err := scanPayment(tx.QueryRow(`
+		SELECT `+paymentColumns+` FROM public.payments WHERE id = $1 FOR UPDATE`, paymentID).Scan)
+	if errors.Is(err, sql.ErrNoRows) {
+		return p, bal, billing.ErrBadPayload
+	}
+	if err != nil {
+		return p, bal, err
+	}
+	c := billing.Confirmation{Payload: billing.Invoice{PaymentID: p.ID}.Payload(), Amount: p.Amount, Currency: p.Currency}
+	if confirm != nil {
+		c = *confirm
+	}
+	if err := billing.Verify(c, billing.Payment{ID: p.ID, Amount: p.Amount, Currency: p.Currency, Status: p.Status}); err != nil {
+		return p, bal, err
+	}
+
+	pkg, err := scanPackage(tx.QueryRow(`SELECT `+packageColumns+` FROM public.billing_packages WHERE id = $1`, p.PackageID).Scan)
+	if err != nil {
+		return p, bal, fmt.Errorf("пакет платежа %d не найден: %w", p.ID, err)
+	}
+
+	// Абонемент на срок, купленный заранее, начинается после окончания текущего
+	start := b.clientToday(p.ClientID)
+	if pkg.Kind == billing.Period {
+		var lastDay sql.NullTime
+		if err := tx.QueryRow(`
+			SELECT MAX(expires_on) FROM public.client_subscriptions
+			WHERE client_id = $1 AND kind = 'period'`, p.ClientID).Scan(&lastDay); err != nil {
+			return p, bal, err
+		}
+		if next := lastDay.Time.AddDate(0, 0, 1); lastDay.Valid && next.After(start) {
+			start = next
+		}
+	}
+	bal = billing.Activate(pkg, p.ClientID, start)
+
+	var expires interface{}
+	if !bal.Expires.IsZero() {
+		expires = bal.Expires.Format("2006-01-02")
+	}
+	if err := tx.QueryRow(`
+		INSERT INTO public.client_subscriptions
+			(client_id, package_id, name, kind, sessions_total, sessions_left, starts_on, expires_on)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`,
+		bal.ClientID, pkg.ID, bal.Name, bal.Kind, bal.Total, bal.Left, bal.Starts.Format("2006-01-02"), expires).
+		Scan(&bal.ID); err != nil {
+		return p, bal, err
+	}
+
+	p.Status, p.SubscriptionID, p.PaidAt = billing.StatusPaid, bal.ID, time.Now()
+	if _, err := tx.Exec(`
+		UPDATE public.payments
+		SET status = 'paid', subscription_id = $2, paid_at = $3,
+		    telegram_charge_id = NULLIF($4, ''), provider_charge_id = NULLIF($5, '')
+		WHERE id = $1`, p.ID, bal.ID, p.PaidAt, c.TelegramChargeID, c.ProviderChargeID); err != nil {
+		return p, bal, err
+	}
+	return p, bal, tx.Commit()
+}
+
+// sendPackageInvoice выставляет клиенту счёт на пакет через платёжного провайдера.
+// Нажимает клиент («Купить») или тренер («Выставить счёт»)
+func (b *Bot) sendPackageInvoice(chatID int64, clientID, packageID int) {
+	if b.payments == nil {
+		b.sendMessage(chatID, b.t("billing_payments_disabled", chatID))
+		return
+	}
+	pkg, err := b.loadPackage(packageID)
+	if err != nil || !pkg.Active {
+		b.sendError(chatID, b.t("billing_load_error", chatID), err)
+		return
+	}
+	var telegramID sql.NullInt64
+	if err := b.db.QueryRow("SELECT telegram_id FROM public.clients WHERE id = $1", clientID).Scan(&telegramID); err != nil || !telegramID.Valid {
+		b.sendMessage(chatID, b.t("billing_client_no_telegram", chatID))
+		return
+	}
+
+	paymentID, err := b.createPayment(clientID, pkg, b.payments.Name())
+	if err != nil {
+		b.sendError(chatID, b.t("billing_save_error", chatID), err)
+		return
+	}
+	clientChat := telegramID.Int64
+	inv := billing.Invoice{
+		PaymentID:   paymentID,
+		ChatID:      clientChat,
+		Title:       pkg.Name,
+		Description: b.describePackage(clientChat, pkg),
+		Amount:      pkg.Price,
+		Currency:    pkg.Currency,
+	}
+	if err := b.payments.SendInvoice(inv); err != nil {
+		b.db.Exec("UPDATE public.payments SET status = 'canceled' WHERE id = $1", paymentID)
+		b.sendError(chatID, b.t("billing_invoice_error", chatID), err)
+		return
+	}
+	if chatID != clientChat {
+		b.sendMessage(chatID, b.tf("billing_invoice_sent", chatID, paymentID, pkg.Name))
+	}
+}
+
+// confirmManualPayment переспрашивает тренера перед внесением оплаты
+func (b *Bot) confirmManualPayment(chatID int64, clientID, packageID int, messageID int) {
+	pkg, err := b.loadPackage(packageID)
+	if err != nil {
+		b.sendError(chatID, b.t("billing_load_error", chatID), err)
+		return
+	}
+	rows := [][]tgbotapi.InlineKeyboardButton{tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("billing_btn_confirm", chatID), billingCallback("sellok", clientID, packageID)),
+		tgbotapi.NewInlineKeyboardButtonData(b.t("billing_btn_back", chatID), billingCallback("view", clientID)),
+	)}
+	b.sendOrEditInline(chatID, messageID,
+		b.tf("billing_confirm_manual", chatID, pkg.Name, b.describePackage(chatID, pkg)), rows)
+}
+
+// recordManualPayment записывает оплату, принятую тренером, и активирует абонемент
+func (b *Bot) recordManualPayment(chatID int64, clientID, packageID int, messageID int) {
+	pkg, err := b.loadPackage(packageID)
+	if err != nil || !pkg.Active {
+		b.sendError(chatID, b.t("billing_load_error", chatID), err)
+		return
+	}
+	paymentID, err := b.createPayment(clientID, pkg, providerManual)
+	if err != nil {
+		b.sendError(chatID, b.t("billing_save_error", chatID), err)
+		return
+	}
+	p, bal, err := b.completePayment(paymentID, nil)
+	if err != nil {
+		b.sendError(chatID, b.t("billing_save_error", chatID), err)
+		return
+	}
+	b.showBilling(chatID, clientID, messageID)
+	b.notifyClientPaid(p, bal)
+}
+
+// handlePreCheckout отвечает Telegram, можно ли принять оплату: счёт ещё ждёт
+// оплаты, сумма и валюта не изменились
+func (b *Bot) handlePreCheckout(q *tgbotapi.PreCheckoutQuery) {
+	answer := tgbotapi.PreCheckoutConfig{PreCheckoutQueryID: q.ID, OK: true}
+	if err := b.verifyPreCheckout(q); err != nil {
+		log.Printf("Отклонена оплата %q от %d: %v", q.InvoicePayload, q.From.ID, err)
+		answer.OK = false
+		answer.ErrorMessage = b.t("billing_precheckout_rejected", q.From.ID)
+	}
+	if _, err := b.api.Request(answer); err != nil {
+		log.Printf("Ошибка ответа на pre-checkout %s: %v", q.ID, err)
+	}
+}
+
+func (b *Bot) verifyPreCheckout(q *tgbotapi.PreCheckoutQuery) error {
+	paymentID, err := billing.ParsePayload(q.InvoicePayload)
+	if err != nil {
+		return err
+	}
+	var p billing.Payment
+	if err := b.db.QueryRow(`
+		SELECT id, amount, currency, status FROM public.payments WHERE id = $1`, paymentID).
+		Scan(&p.ID, &p.Amount, &p.Currency, &p.Status); err != nil {
+		return err
+	}
+	return billing.Verify(billing.Confirmation{
+		Payload:  q.InvoicePayload,
+		Amount:   int64(q.TotalAmount),
+		Currency: q.Currency,
+	}, p)
+}
+
+// handleSuccessfulPayment активирует абонемент после оплаты через Telegram
+func (b *Bot) handleSuccessfulPayment(message *tgbotapi.Message) {
+	sp := message.SuccessfulPayment
+	chatID := message.Chat.ID
+	paymentID, err := billing.ParsePayload(sp.InvoicePayload)
+	if err != nil {
+		log.Printf("Оплата с неизвестным счётом %q от %d", sp.InvoicePayload, chatID)
+		return
+	}
+
+	p, bal, err := b.completePayment(paymentID, &billing.Confirmation{
+		Payload:          sp.InvoicePayload,
+		Amount:           int64(sp.TotalAmount),
+		Currency:         sp.Currency,
+		TelegramChargeID: sp.TelegramPaymentChargeID,
+		ProviderChargeID: sp.ProviderPaymentChargeID,
+	})
+	if errors.Is(err, billing.ErrNotPending) {
+		// Повторное уведомление об уже учтённой оплате
+		return
+	}
+	if err != nil {
+		log.Printf("Ошибка учёта оплаты %d (charge %s): %v", paymentID, sp.TelegramPaymentChargeID, err)
+		b.sendMessage(chatID, b.t("billing_payment_error", chatID))
+		adminIDs, _ := b.getAdminTelegramIDs()
+		for _, adminID := range adminIDs {
+			b.sendMessage(adminID, b.tf("billing_payment_error_admin", adminID, paymentID, sp.TelegramPaymentChargeID))
+		}
+		return
+	}
+	b.notifyClientPaid(p, bal)
+
+	var name, surname string
+	b.db.QueryRow("SELECT name, surname FROM public.clients WHERE id = $1", p.ClientID).Scan(&name, &surname)
+	b.sendMessage(p.TrainerID, b.tf("billing_trainer_paid", p.TrainerID,
+		name, surname, p.Description, billing.FormatMoney(p.Amount, p.Currency)))
+}
+
+// notifyClientPaid сообщает клиенту об активации абонемента и присылает квитанцию
+func (b *Bot) notifyClientPaid(p paymentRecord, bal billing.Balance) {
+	var telegramID sql.NullInt64
+	if err := b.db.QueryRow("SELECT telegram_id FROM public.clients WHERE id = $1", p.ClientID).
+		Scan(&telegramID); err != nil || !telegramID.Valid {
+		return
+	}
+	chatID := telegramID.Int64
+	b.sendMessage(chatID, b.tf("billing_activated", chatID,
+		b.formatBalance(chatID, bal, time.Now().In(b.userLocation(chatID)))))
+	b.sendPaymentDocument(chatID, p.ID)
+}
+
+// sendPaymentDocument присылает PDF: квитанцию за оплаченный платёж или счёт на ожидающий
+func (b *Bot) sendPaymentDocument(chatID int64, paymentID int) {
+	p, err := scanPayment(b.db.QueryRow(`SELECT `+paymentColumns+` FROM public.payments WHERE id = $1`, paymentID).Scan)
+	if err != nil {
+		b.sendError(chatID, b.t("billing_load_error", chatID), err)
+		return
+	}
+	if !b.isClientOwner(chatID, p.ClientID) && !b.isAdmin(chatID) {
+		return
+	}
+
+	data, err := b.renderPaymentDocument(chatID, p)
+	if err != nil {
+		b.sendError(chatID, b.t("billing_document_error", chatID), err)
+		return
+	}
+	name := fmt.Sprintf("invoice_%d.pdf", p.ID)
+	if p.Status == billing.StatusPaid {
+		name = fmt.Sprintf("receipt_%d.pdf", p.ID)
+	}
+	b.api.Send(tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{Name: name, Bytes: data}))
+}
+
+// renderPaymentDocument формирует квитанцию или счёт в PDF на языке chatID
+func (b *Bot) renderPaymentDocument(chatID int64, p paymentRecord) ([]byte, error) {
+	var clientName, clientSurname, trainerName string
+	if err := b.db.QueryRow("SELECT name, surname FROM public.clients WHERE id = $1", p.ClientID).
+		Scan(&clientName, &clientSurname); err != nil {
+		return nil, err
+	}
+	b.db.QueryRow("SELECT name FROM public.admins WHERE telegram_id = $1", p.TrainerID).Scan(&trainerName)
+
+	loc := b.userLocation(chatID)
+	title, at := b.tf("billing_doc_invoice", chatID, p.ID), p.CreatedAt
+	if p.Status == billing.StatusPaid {
+		title, at = b.tf("billing_doc_receipt", chatID, p.ID), p.PaidAt
+	}
+	method := b.t("billing_method_"+p.Provider, chatID)
+
+	amount := billing.FormatMoney(p.Amount, p.Currency)
+	var doc pdfdoc.Document
+	doc.Footer = title
+	doc.Title(title)
+	doc.Text(b.tf("billing_doc_date", chatID, at.In(loc).Format("02.01.2006 15:04")))
+	if trainerName != "" {
+		doc.Text(b.tf("billing_doc_trainer", chatID, trainerName))
+	}
+	doc.Text(b.tf("billing_doc_client", chatID, clientName+" "+clientSurname))
+	if p.Status == billing.StatusPaid {
+		doc.Text(b.tf("billing_doc_method", chatID, method))
+	}
+	doc.Table(pdfdoc.Table{
+		Header: []string{b.t("billing_doc_col_service", chatID), b.t("billing_doc_col_qty", chatID), b.t("billing_doc_col_amount", chatID)},
+		Rows: [][]string{
+			{p.Description, strconv.Itoa(1), amount},
+			{b.t("billing_doc_total", chatID), "", amount},
+		},
+		Widths: []int{60, 15, 25},
+		Align:  []pdfdoc.Align{pdfdoc.AlignLeft, pdfdoc.AlignCenter, pdfdoc.AlignRight},
+	})
+	if p.Status != billing.StatusPaid {
+		doc.Text(b.t("billing_doc_invoice_note", chatID))
+	}
+
+	var buf bytes.Buffer
+	if err := pdfdoc.Render(&buf, &doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
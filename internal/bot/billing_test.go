@@ -0,0 +1,36 @@
+package bot
+
+import "testing"
+
+func TestBillingCallbackRoundTrip(t *testing.T) {
+	data := billingCallback("sellp", 12, 3)
+	if data != "bill_sellp_12_3" {
+		t.Fatalf("billingCallback = %q", data)
+	}
+	action, args, ok := parseBillingCallback(data)
+	if !ok || action != "sellp" || len(args) != 2 || args[0] != 12 || args[1] != 3 {
+		t.Errorf("parseBillingCallback = %q, %v, %v", action, args, ok)
+	}
+	if action, args, ok := parseBillingCallback(billingCallback("pkgadd")); !ok || action != "pkgadd" || len(args) != 0 {
+		t.Errorf("кнопка без аргументов: %q, %v, %v", action, args, ok)
+	}
+	for _, bad := range []string{"bill", "bill_view_x", "bill_view_0", "gal_view_1", "bill_view_1_-2"} {
+		if _, _, ok := parseBillingCallback(bad); ok {
+			t.Errorf("parseBillingCallback(%q) принял неверные данные", bad)
+		}
+	}
+}
+
+func TestLeadingNumber(t *testing.T) {
+	tests := map[string]int{"10 тренировок": 10, "30 days": 30, "12": 12}
+	for in, want := range tests {
+		if got, ok := leadingNumber(in); !ok || got != want {
+			t.Errorf("leadingNumber(%q) = %d, %v", in, got, ok)
+		}
+	}
+	for _, bad := range []string{"", "десять", "x 10"} {
+		if _, ok := leadingNumber(bad); ok {
+			t.Errorf("leadingNumber(%q) принял неверный ввод", bad)
+		}
+	}
+}
@@ -1,10 +1,14 @@
 package bot
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
+	"workbot/internal/calendar"
+	"workbot/internal/scheduler"
+
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
@@ -18,61 +22,44 @@ type BirthdayInfo struct {
 	DaysUntil int // 0 = сегодня, 1 = завтра, и т.д.
 }
 
-// StartBirthdayReminder запускает фоновую задачу проверки дней рождения
-func (b *Bot) StartBirthdayReminder() {
-	go func() {
-		// Ждём 10 секунд после старта, чтобы бот полностью инициализировался
-		time.Sleep(10 * time.Second)
-
-		// Проверяем сразу при старте
-		b.checkAndSendBirthdayReminders()
-
-		// Запускаем ежедневную проверку в 9:00
-		for {
-			now := time.Now()
-			// Вычисляем время до следующих 9:00
-			next := time.Date(now.Year(), now.Month(), now.Day(), 9, 0, 0, 0, now.Location())
-			if now.After(next) {
-				next = next.Add(24 * time.Hour)
-			}
-			sleepDuration := next.Sub(now)
-
-			log.Printf("Следующая проверка дней рождения через %v", sleepDuration.Round(time.Minute))
-			time.Sleep(sleepDuration)
-
-			b.checkAndSendBirthdayReminders()
-		}
-	}()
-}
-
-// checkAndSendBirthdayReminders проверяет и отправляет напоминания
-func (b *Bot) checkAndSendBirthdayReminders() {
-	log.Println("Проверка дней рождения клиентов...")
-
-	// Получаем дни рождения на сегодня и завтра
-	todayBirthdays := b.getUpcomingBirthdays(0)
-	tomorrowBirthdays := b.getUpcomingBirthdays(1)
-	weekBirthdays := b.getUpcomingBirthdays(7)
-
-	// Получаем список админов (тренеров)
-	admins := b.getAdminTelegramIDs()
+// birthdayDigestHour — час (по поясу тренера), в который отправляется сводка дней рождения
+const birthdayDigestHour = 9
 
-	if len(admins) == 0 {
-		log.Println("Нет админов для отправки напоминаний")
-		return
+// runBirthdayDigest — задача планировщика: отправляет сводку дней рождения тренерам,
+// у которых 9:00 по местному времени наступило после прошлого успешного запуска.
+// Так сводка не теряется после простоя бота и не дублируется при частых запусках
+func (b *Bot) runBirthdayDigest(ctx context.Context, run scheduler.Run) error {
+	admins, err := b.getAdminTelegramIDs()
+	if err != nil {
+		return err
 	}
 
-	// Отправляем напоминания каждому админу
+	now := time.Now()
 	for _, adminID := range admins {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		local := now.In(b.userLocation(adminID))
+		digestAt := calendar.CombineDateTimeIn(local, birthdayDigestHour, 0, local.Location())
+		if digestAt.After(now) || !digestAt.After(run.LastSuccess) {
+			continue
+		}
+
+		log.Printf("Проверка дней рождения клиентов для тренера %d...", adminID)
+		todayBirthdays := b.getUpcomingBirthdays(local, 0)
+		tomorrowBirthdays := b.getUpcomingBirthdays(local, 1)
+		weekBirthdays := b.getUpcomingBirthdays(local, 7)
 		b.sendBirthdayNotifications(adminID, todayBirthdays, tomorrowBirthdays, weekBirthdays)
 	}
+	return nil
 }
 
 // getUpcomingBirthdays возвращает клиентов с днём рождения через daysAhead дней
-func (b *Bot) getUpcomingBirthdays(daysAhead int) []BirthdayInfo {
+// от даты from (дата берётся в поясе from)
+func (b *Bot) getUpcomingBirthdays(from time.Time, daysAhead int) []BirthdayInfo {
 	var birthdays []BirthdayInfo
 
-	targetDate := time.Now().AddDate(0, 0, daysAhead)
+	targetDate := from.AddDate(0, 0, daysAhead)
 	targetDay := targetDate.Day()
 	targetMonth := int(targetDate.Month())
 
@@ -130,13 +117,12 @@ func (b *Bot) getUpcomingBirthdays(daysAhead int) []BirthdayInfo {
 }
 
 // getAdminTelegramIDs возвращает Telegram ID всех админов
-func (b *Bot) getAdminTelegramIDs() []int64 {
+func (b *Bot) getAdminTelegramIDs() ([]int64, error) {
 	var admins []int64
 
 	rows, err := b.db.Query("SELECT telegram_id FROM public.admins")
 	if err != nil {
-		log.Printf("Ошибка получения админов: %v", err)
-		return admins
+		return nil, fmt.Errorf("ошибка получения админов: %w", err)
 	}
 	defer rows.Close()
 
@@ -148,7 +134,7 @@ func (b *Bot) getAdminTelegramIDs() []int64 {
 		admins = append(admins, telegramID)
 	}
 
-	return admins
+	return admins, rows.Err()
 }
 
 // sendBirthdayNotifications отправляет уведомления о днях рождения админу
@@ -157,18 +143,18 @@ func (b *Bot) sendBirthdayNotifications(adminID int64, today, tomorrow, week []B
 
 	// Сегодня
 	if len(today) > 0 {
-		message += "🎂 *СЕГОДНЯ ДЕНЬ РОЖДЕНИЯ!*\n\n"
+		message += b.t("birthday_today_title", adminID) + "\n\n"
 		for _, bd := range today {
-			message += fmt.Sprintf("🎉 *%s %s* — %d лет!\n", bd.Name, bd.Surname, bd.Age)
+			message += b.tf("birthday_today_item", adminID, bd.Name, bd.Surname, b.tn("age", adminID, bd.Age)) + "\n"
 		}
 		message += "\n"
 	}
 
 	// Завтра
 	if len(tomorrow) > 0 {
-		message += "🎈 *Завтра день рождения:*\n\n"
+		message += b.t("birthday_tomorrow_title", adminID) + "\n\n"
 		for _, bd := range tomorrow {
-			message += fmt.Sprintf("• %s %s — исполнится %d лет\n", bd.Name, bd.Surname, bd.Age)
+			message += b.tf("birthday_tomorrow_item", adminID, bd.Name, bd.Surname, b.tn("age", adminID, bd.Age)) + "\n"
 		}
 		message += "\n"
 	}
@@ -181,11 +167,10 @@ func (b *Bot) sendBirthdayNotifications(adminID int64, today, tomorrow, week []B
 		}
 	}
 	if len(weekFiltered) > 0 {
-		message += "📅 *На этой неделе:*\n\n"
+		message += b.t("birthday_week_title", adminID) + "\n\n"
 		for _, bd := range weekFiltered {
-			dayStr := getDayWord(bd.DaysUntil)
-			message += fmt.Sprintf("• %s %s — через %d %s (%d лет)\n",
-				bd.Name, bd.Surname, bd.DaysUntil, dayStr, bd.Age)
+			message += b.tf("birthday_upcoming_item", adminID,
+				bd.Name, bd.Surname, b.tn("days", adminID, bd.DaysUntil), b.tn("age", adminID, bd.Age)) + "\n"
 		}
 	}
 
@@ -193,70 +178,60 @@ func (b *Bot) sendBirthdayNotifications(adminID int64, today, tomorrow, week []B
 	if message != "" {
 		msg := tgbotapi.NewMessage(adminID, message)
 		msg.ParseMode = "Markdown"
-		if _, err := b.api.Send(msg); err != nil {
-			log.Printf("Ошибка отправки напоминания о ДР админу %d: %v", adminID, err)
+		key := fmt.Sprintf("birthday_digest:%d:%s", adminID, time.Now().In(b.userLocation(adminID)).Format("2006-01-02"))
+		if err := b.outbox.EnqueueOnce(key, msg); err != nil {
+			log.Printf("Ошибка постановки напоминания о ДР админу %d в очередь: %v", adminID, err)
 		} else {
-			log.Printf("Отправлено напоминание о днях рождения админу %d", adminID)
+			log.Printf("Напоминание о днях рождения админу %d поставлено в очередь", adminID)
 		}
 	}
 }
 
-// getDayWord возвращает правильное склонение слова "день"
-func getDayWord(days int) string {
-	if days%10 == 1 && days%100 != 11 {
-		return "день"
-	}
-	if days%10 >= 2 && days%10 <= 4 && (days%100 < 10 || days%100 >= 20) {
-		return "дня"
-	}
-	return "дней"
-}
-
 // GetTodayBirthdays возвращает клиентов с днём рождения сегодня (для ручного вызова)
 func (b *Bot) GetTodayBirthdays() []BirthdayInfo {
-	return b.getUpcomingBirthdays(0)
+	return b.getUpcomingBirthdays(time.Now().In(b.defaultLocation()), 0)
 }
 
 // handleBirthdaysCommand обрабатывает команду просмотра дней рождения
 func (b *Bot) handleBirthdaysCommand(chatID int64) {
-	today := b.getUpcomingBirthdays(0)
-	tomorrow := b.getUpcomingBirthdays(1)
+	local := time.Now().In(b.userLocation(chatID))
+	today := b.getUpcomingBirthdays(local, 0)
+	tomorrow := b.getUpcomingBirthdays(local, 1)
 
 	// Собираем ближайшие 7 дней
 	var upcoming []BirthdayInfo
 	for i := 2; i <= 7; i++ {
-		upcoming = append(upcoming, b.getUpcomingBirthdays(i)...)
+		upcoming = append(upcoming, b.getUpcomingBirthdays(local, i)...)
 	}
 
 	var message string
 
 	if len(today) == 0 && len(tomorrow) == 0 && len(upcoming) == 0 {
-		message = "📅 В ближайшую неделю дней рождения нет"
+		message = b.t("birthday_none", chatID)
 	} else {
-		message = "📅 *Ближайшие дни рождения*\n\n"
+		message = b.t("birthday_title", chatID) + "\n\n"
 
 		if len(today) > 0 {
-			message += "🎂 *Сегодня:*\n"
+			message += b.t("birthday_today", chatID) + "\n"
 			for _, bd := range today {
-				message += fmt.Sprintf("  🎉 %s %s — %d лет!\n", bd.Name, bd.Surname, bd.Age)
+				message += "  " + b.tf("birthday_today_item", chatID, bd.Name, bd.Surname, b.tn("age", chatID, bd.Age)) + "\n"
 			}
 			message += "\n"
 		}
 
 		if len(tomorrow) > 0 {
-			message += "🎈 *Завтра:*\n"
+			message += b.t("birthday_tomorrow", chatID) + "\n"
 			for _, bd := range tomorrow {
-				message += fmt.Sprintf("  • %s %s — %d лет\n", bd.Name, bd.Surname, bd.Age)
+				message += "  " + b.tf("birthday_item", chatID, bd.Name, bd.Surname, b.tn("age", chatID, bd.Age)) + "\n"
 			}
 			message += "\n"
 		}
 
 		if len(upcoming) > 0 {
-			message += "📆 *Ближайшие 7 дней:*\n"
+			message += b.t("birthday_upcoming", chatID) + "\n"
 			for _, bd := range upcoming {
-				dayStr := getDayWord(bd.DaysUntil)
-				message += fmt.Sprintf("  • %s %s — через %d %s (%d лет)\n",
-					bd.Name, bd.Surname, bd.DaysUntil, dayStr, bd.Age)
+				message += "  " + b.tf("birthday_upcoming_item", chatID,
+					bd.Name, bd.Surname, b.tn("days", chatID, bd.DaysUntil), b.tn("age", chatID, bd.Age)) + "\n"
 			}
 		}
 	}
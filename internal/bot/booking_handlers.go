@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"workbot/internal/calendar"
+	"workbot/internal/i18n"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -53,7 +54,7 @@ func (b *Bot) handleBookTraining(message *tgbotapi.Message) {
 // showAvailableDates показывает визуальный календарь для записи
 func (b *Bot) showAvailableDates(chatID int64) {
 	// Создаём виджет календаря
-	cal := NewCalendarWidget()
+	cal := NewCalendarWidget(b.getLanguage(chatID))
 
 	// Получаем полностью занятые даты (опционально)
 	bookedDates := b.getFullyBookedDates()
@@ -156,6 +157,14 @@ func (b *Bot) handleCallbackQuery(callback *tgbotapi.CallbackQuery) {
 		b.handleStatsCallback(chatID, callback.Message.MessageID, data)
 		return
 
+	case strings.HasPrefix(data, "job_"):
+		b.handleJobCallback(callback)
+		return
+
+	case strings.HasPrefix(data, "tz_"):
+		b.handleTimezoneCallback(callback)
+		return
+
 	case strings.HasPrefix(data, "settings_"), strings.HasPrefix(data, "lang_"):
 		b.handleSettingsCallback(callback)
 		return
@@ -167,6 +176,38 @@ func (b *Bot) handleCallbackQuery(callback *tgbotapi.CallbackQuery) {
 	case strings.HasPrefix(data, "prog_"):
 		b.handleProgramCallback(callback)
 		return
+
+	case strings.HasPrefix(data, "grp_"):
+		b.handleGroupCallback(callback)
+		return
+
+	case strings.HasPrefix(data, "tpl_"):
+		b.handleTemplateCallback(callback)
+		return
+
+	case strings.HasPrefix(data, "chart_"):
+		b.handleChartCallback(callback)
+		return
+
+	case strings.HasPrefix(data, "gal_"):
+		b.handleGalleryCallback(callback)
+		return
+
+	case strings.HasPrefix(data, "nut_"):
+		b.handleNutritionCallback(callback)
+		return
+
+	case strings.HasPrefix(data, "bill_"):
+		b.handleBillingCallback(callback)
+		return
+
+	case strings.HasPrefix(data, "load_"):
+		b.handleLoadCallback(callback)
+		return
+
+	case strings.HasPrefix(data, "hyrox_"):
+		b.handleHyroxCallback(callback)
+		return
 	}
 }
 
@@ -212,7 +253,7 @@ func (b *Bot) handleDateSelection(chatID int64, messageID int, dateStr string) {
 	availableSlots := b.getAvailableTimeSlotsForDate(date)
 	if len(availableSlots) == 0 {
 		// Нет свободных слотов
-		text := fmt.Sprintf("❌ На %s нет свободных слотов.\nВыберите другую дату:", dateStr)
+		text := b.tf("booking_no_slots", chatID, dateStr)
 		edit := tgbotapi.NewEditMessageText(chatID, messageID, text)
 		bookingStore.RLock()
 		if bookData.Calendar != nil {
@@ -223,9 +264,17 @@ func (b *Bot) handleDateSelection(chatID int64, messageID int, dateStr string) {
 		return
 	}
 
-	// Показываем слоты времени
-	text := fmt.Sprintf("🕐 Выберите время на %s:", dateStr)
-	keyboard := GenerateTimeSlots(date, availableSlots)
+	// Показываем слоты времени; подписи — по поясу клиента, данные кнопок — по поясу тренера
+	text := b.tf("booking_select_time", chatID, dateStr)
+	trainerLoc, clientLoc := b.bookingLocations(chatID)
+	var labels []string
+	if !sameOffset(date, trainerLoc, clientLoc) {
+		for _, slot := range availableSlots {
+			labels = append(labels, localSlotLabel(date, slot, trainerLoc, clientLoc))
+		}
+		text += "\n" + b.tf("booking_zone_note", chatID, b.timezoneLabel(chatID))
+	}
+	keyboard := GenerateTimeSlots(date, availableSlots, labels, b.getLanguage(chatID))
 	edit := tgbotapi.NewEditMessageText(chatID, messageID, text)
 	edit.ReplyMarkup = &keyboard
 	b.api.Send(edit)
@@ -256,16 +305,19 @@ func (b *Bot) handleTimeSlotSelection(chatID int64, messageID int, data string)
 	bookData.Step = 2
 	bookingStore.Unlock()
 
-	// Показываем подтверждение
-	dayName := russianWeekdayFull(date.Weekday())
-	text := fmt.Sprintf(
-		"📋 Подтвердите запись:\n\n"+
-			"📅 Дата: %s (%s)\n"+
-			"🕐 Время: %s\n\n"+
-			"Подтвердить запись?",
-		dateStr, dayName, timeSlot)
+	// Показываем подтверждение во времени клиента
+	hour, minute, err := calendar.ParseTime(timeSlot)
+	if err != nil {
+		return
+	}
+	trainerLoc, clientLoc := b.bookingLocations(chatID)
+	start := calendar.CombineDateTimeIn(date, hour, minute, trainerLoc).In(clientLoc)
+	text := b.t("booking_confirm_title", chatID) + "\n\n" +
+		b.tf("booking_confirm_date", chatID, start.Format("02.01.2006"), b.getWeekdayNameLocalized(start.Weekday(), chatID)) + "\n" +
+		b.tf("booking_confirm_time", chatID, start.Format("15:04")) + "\n\n" +
+		b.t("booking_confirm_question", chatID)
 
-	keyboard := GenerateConfirmation(date, timeSlot)
+	keyboard := GenerateConfirmation(date, timeSlot, b.getLanguage(chatID))
 	edit := tgbotapi.NewEditMessageText(chatID, messageID, text)
 	edit.ReplyMarkup = &keyboard
 	b.api.Send(edit)
@@ -291,7 +343,7 @@ func (b *Bot) handleBookingConfirmation(chatID int64, messageID int, data string
 	}
 
 	// Удаляем inline-клавиатуру
-	edit := tgbotapi.NewEditMessageText(chatID, messageID, "⏳ Создаю запись...")
+	edit := tgbotapi.NewEditMessageText(chatID, messageID, b.t("booking_creating", chatID))
 	b.api.Send(edit)
 
 	// Очищаем состояние
@@ -317,12 +369,12 @@ func (b *Bot) handleBackToCalendar(chatID int64, messageID int) {
 	}
 
 	if bookData.Calendar == nil {
-		bookData.Calendar = NewCalendarWidget()
+		bookData.Calendar = NewCalendarWidget(b.getLanguage(chatID))
 	}
 	bookData.Step = 0
 	bookingStore.Unlock()
 
-	text := "📅 Выберите дату тренировки:"
+	text := b.t("booking_select_date", chatID)
 	bookingStore.RLock()
 	keyboard := bookData.Calendar.GenerateCalendar()
 	bookingStore.RUnlock()
@@ -386,9 +438,26 @@ func (b *Bot) getAvailableTimeSlotsForDate(date time.Time) []string {
 		}
 	}
 
-	// Фильтруем занятые
+	// Фильтруем занятые, занятость тренера в Google Calendar и уже прошедшие (по времени тренера)
 	bookedSlots, _ := b.getBookedSlots(date)
-	return filterBookedSlots(timeSlots, bookedSlots)
+	trainerID := b.bookingTrainerID()
+	trainerLoc := b.userLocation(trainerID)
+	available := b.filterGoogleBusy(trainerID, date, filterBookedSlots(timeSlots, bookedSlots), trainerLoc)
+	return filterPastSlots(date, available, trainerLoc, time.Now())
+}
+
+// bookingTrainerID возвращает Telegram ID тренера, к которому идёт запись (первый админ)
+func (b *Bot) bookingTrainerID() int64 {
+	var trainerID int64
+	if err := b.db.QueryRow("SELECT telegram_id FROM public.admins LIMIT 1").Scan(&trainerID); err != nil {
+		log.Printf("Ошибка получения тренера: %v", err)
+	}
+	return trainerID
+}
+
+// bookingLocations возвращает пояса тренера и клиента для бронирования
+func (b *Bot) bookingLocations(clientChatID int64) (trainer, client *time.Location) {
+	return b.userLocation(b.bookingTrainerID()), b.userLocation(clientChatID)
 }
 
 // processBooking обрабатывает шаги бронирования
@@ -396,7 +465,7 @@ func (b *Bot) processBooking(message *tgbotapi.Message, state string) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.cancelBooking(chatID)
 		return
 	}
@@ -415,7 +484,7 @@ func (b *Bot) processBooking(message *tgbotapi.Message, state string) {
 		parts := strings.Split(text, " ")
 		if len(parts) < 1 {
 			bookingStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, "Пожалуйста, выберите дату из списка")
+			msg := tgbotapi.NewMessage(chatID, b.t("booking_pick_date", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -423,7 +492,7 @@ func (b *Bot) processBooking(message *tgbotapi.Message, state string) {
 		date, err := time.Parse("02.01.2006", parts[0])
 		if err != nil {
 			bookingStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, "Неверный формат даты. Выберите из списка.")
+			msg := tgbotapi.NewMessage(chatID, b.t("booking_pick_date", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -444,7 +513,7 @@ func (b *Bot) processBooking(message *tgbotapi.Message, state string) {
 		hour, minute, err := calendar.ParseTime(text)
 		if err != nil {
 			bookingStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, "Пожалуйста, выберите время из списка")
+			msg := tgbotapi.NewMessage(chatID, b.t("booking_pick_time", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -468,48 +537,10 @@ func (b *Bot) processBooking(message *tgbotapi.Message, state string) {
 
 // showAvailableTimeSlots показывает доступные слоты времени
 func (b *Bot) showAvailableTimeSlots(chatID int64, date time.Time) {
-	dayOfWeek := int(date.Weekday())
-
-	// Получаем расписание тренера на этот день
-	rows, err := b.db.Query(`
-		SELECT start_time, end_time, slot_duration
-		FROM public.trainer_schedule
-		WHERE day_of_week = $1 AND is_active = true
-		ORDER BY start_time`, dayOfWeek)
-
-	var timeSlots []string
-
-	if err == nil {
-		defer rows.Close()
-
-		for rows.Next() {
-			var startTime, endTime string
-			var slotDuration int
-			if err := rows.Scan(&startTime, &endTime, &slotDuration); err != nil {
-				continue
-			}
-
-			// Генерируем слоты
-			slots := generateTimeSlots(startTime, endTime, slotDuration)
-			timeSlots = append(timeSlots, slots...)
-		}
-	}
-
-	// Если расписания нет — показываем стандартные слоты
-	if len(timeSlots) == 0 {
-		timeSlots = []string{
-			"09:00", "10:00", "11:00", "12:00",
-			"14:00", "15:00", "16:00", "17:00",
-			"18:00", "19:00", "20:00",
-		}
-	}
-
-	// Фильтруем занятые слоты
-	bookedSlots, _ := b.getBookedSlots(date)
-	availableSlots := filterBookedSlots(timeSlots, bookedSlots)
+	availableSlots := b.getAvailableTimeSlotsForDate(date)
 
 	if len(availableSlots) == 0 {
-		msg := tgbotapi.NewMessage(chatID, "К сожалению, на эту дату нет свободных слотов. Выберите другую дату.")
+		msg := tgbotapi.NewMessage(chatID, b.tf("booking_no_slots", chatID, date.Format("02.01.2006")))
 		b.api.Send(msg)
 		b.showAvailableDates(chatID)
 		return
@@ -525,13 +556,11 @@ func (b *Bot) showAvailableTimeSlots(chatID int64, date time.Time) {
 		buttonRows = append(buttonRows, row)
 	}
 	buttonRows = append(buttonRows, tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton("Назад"),
-		tgbotapi.NewKeyboardButton("Отмена"),
+		tgbotapi.NewKeyboardButton(b.t("back", chatID)),
+		tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 	))
 
-	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf(
-		"Выберите время на %s:",
-		date.Format("02.01.2006")))
+	msg := tgbotapi.NewMessage(chatID, b.tf("booking_select_time", chatID, date.Format("02.01.2006")))
 	msg.ReplyMarkup = tgbotapi.NewReplyKeyboard(buttonRows...)
 	b.api.Send(msg)
 }
@@ -568,7 +597,7 @@ func (b *Bot) createAppointment(chatID int64, date time.Time, hour, minute int)
 		Scan(&clientID, &clientName, &clientSurname)
 	if err != nil {
 		log.Printf("Ошибка получения клиента: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка создания записи.")
+		msg := tgbotapi.NewMessage(chatID, b.t("booking_error", chatID))
 		b.api.Send(msg)
 		b.restoreMainMenu(chatID)
 		return
@@ -579,7 +608,7 @@ func (b *Bot) createAppointment(chatID int64, date time.Time, hour, minute int)
 	err = b.db.QueryRow("SELECT telegram_id FROM public.admins LIMIT 1").Scan(&trainerID)
 	if err != nil {
 		log.Printf("Ошибка получения тренера: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка: тренер не найден.")
+		msg := tgbotapi.NewMessage(chatID, b.t("booking_no_trainer", chatID))
 		b.api.Send(msg)
 		b.restoreMainMenu(chatID)
 		return
@@ -595,7 +624,7 @@ func (b *Bot) createAppointment(chatID int64, date time.Time, hour, minute int)
 		WHERE trainer_id = $1 AND appointment_date = $2 AND start_time = $3 AND status != 'cancelled'`,
 		trainerID, date.Format("2006-01-02"), startTimeStr).Scan(&existingCount)
 	if err == nil && existingCount > 0 {
-		msg := tgbotapi.NewMessage(chatID, "❌ К сожалению, это время уже занято другим клиентом.\nПожалуйста, выберите другое время.")
+		msg := tgbotapi.NewMessage(chatID, b.t("booking_already_booked", chatID))
 		b.api.Send(msg)
 		b.showAvailableDates(chatID)
 		return
@@ -613,36 +642,36 @@ func (b *Bot) createAppointment(chatID int64, date time.Time, hour, minute int)
 		log.Printf("Ошибка создания записи: %v", err)
 		// Проверяем, является ли ошибка нарушением уникальности (duplicate key)
 		if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "unique") {
-			msg := tgbotapi.NewMessage(chatID, "❌ Это время только что было занято другим клиентом.\nПожалуйста, выберите другое время.")
+			msg := tgbotapi.NewMessage(chatID, b.t("booking_already_booked", chatID))
 			b.api.Send(msg)
 			b.showAvailableDates(chatID)
 			return
 		}
-		msg := tgbotapi.NewMessage(chatID, "Ошибка создания записи. Попробуйте позже.")
+		msg := tgbotapi.NewMessage(chatID, b.t("booking_error", chatID))
 		b.api.Send(msg)
 		b.restoreMainMenu(chatID)
 		return
 	}
+	b.requestGoogleSync()
 
-	// Формируем событие для ICS файла
-	eventStart := calendar.CombineDateTime(date, hour, minute)
+	// Формируем событие для ICS файла: время записи задано в поясе тренера
+	trainerLoc := b.userLocation(trainerID)
+	eventStart := calendar.CombineDateTimeIn(date, hour, minute, trainerLoc)
 	eventEnd := eventStart.Add(time.Hour)
 	event := calendar.Event{
 		UID:         fmt.Sprintf("training-%d@workbot", appointmentID),
-		Summary:     "Тренировка",
-		Description: fmt.Sprintf("Персональная тренировка\\nКлиент: %s %s", clientName, clientSurname),
+		Summary:     b.t("ics_summary", chatID),
+		Description: b.tf("ics_description", chatID, clientName, clientSurname),
 		StartTime:   eventStart,
 		EndTime:     eventEnd,
 		Reminder:    60, // напоминание за 1 час
 	}
 	icsContent := calendar.GenerateICS(event)
 
-	// Формируем сообщение подтверждения
-	confirmMsg := fmt.Sprintf(
-		"✅ Вы записаны на тренировку!\n\n"+
-			"📅 Дата: %s\n"+
-			"🕐 Время: %02d:%02d",
-		date.Format("02.01.2006"), hour, minute)
+	// Формируем сообщение подтверждения во времени клиента
+	clientStart := eventStart.In(b.userLocation(chatID))
+	confirmMsg := b.tf("booking_success", chatID,
+		clientStart.Format("02.01.2006"), b.getWeekdayNameLocalized(clientStart.Weekday(), chatID), clientStart.Format("15:04"))
 
 	msg := tgbotapi.NewMessage(chatID, confirmMsg)
 	b.api.Send(msg)
@@ -653,16 +682,12 @@ func (b *Bot) createAppointment(chatID int64, date time.Time, hour, minute int)
 		Name:  fileName,
 		Bytes: []byte(icsContent),
 	})
-	doc.Caption = "Откройте файл для добавления в календарь"
+	doc.Caption = b.t("calendar_export_hint", chatID)
 	b.api.Send(doc)
 
 	// Уведомляем тренера
-	trainerMsg := tgbotapi.NewMessage(trainerID, fmt.Sprintf(
-		"📝 Новая запись на тренировку!\n\n"+
-			"👤 Клиент: %s %s\n"+
-			"📅 Дата: %s\n"+
-			"🕐 Время: %02d:%02d",
-		clientName, clientSurname, date.Format("02.01.2006"), hour, minute))
+	trainerMsg := tgbotapi.NewMessage(trainerID, b.tf("booking_trainer_notify", trainerID,
+		clientName, clientSurname, date.Format("02.01.2006"), fmt.Sprintf("%02d:%02d", hour, minute)))
 	b.api.Send(trainerMsg)
 
 	b.restoreMainMenu(chatID)
@@ -696,7 +721,7 @@ func (b *Bot) handleMyAppointments(message *tgbotapi.Message) {
 	}
 
 	rows, err := b.db.Query(`
-		SELECT id, appointment_date, start_time, status
+		SELECT id, trainer_id, appointment_date, start_time, status
 		FROM public.appointments
 		WHERE client_id = $1 AND appointment_date >= CURRENT_DATE AND status != 'cancelled'
 		ORDER BY appointment_date, start_time
@@ -709,19 +734,21 @@ func (b *Bot) handleMyAppointments(message *tgbotapi.Message) {
 	}
 	defer rows.Close()
 
+	clientLoc := b.userLocation(chatID)
 	var appointments []string
 	for rows.Next() {
 		var id int
+		var trainerID int64
 		var date, startTime, status string
-		if err := rows.Scan(&id, &date, &startTime, &status); err != nil {
+		if err := rows.Scan(&id, &trainerID, &date, &startTime, &status); err != nil {
 			continue
 		}
 
 		parsedDate, _ := time.Parse("2006-01-02T15:04:05Z", date)
+		start := b.appointmentStart(trainerID, parsedDate, startTime[:5]).In(clientLoc)
 		statusText := b.getStatusTextLocalized(status, chatID)
-		appointments = append(appointments, fmt.Sprintf(
-			"#%d: %s в %s (%s)",
-			id, parsedDate.Format("02.01.2006"), startTime[:5], statusText))
+		appointments = append(appointments, b.tf("appointments_item", chatID,
+			id, start.Format("02.01.2006"), start.Format("15:04"), statusText))
 	}
 
 	if len(appointments) == 0 {
@@ -749,13 +776,13 @@ func (b *Bot) handleExportCalendar(message *tgbotapi.Message) {
 	}
 
 	rows, err := b.db.Query(`
-		SELECT id, appointment_date, start_time, end_time
+		SELECT id, trainer_id, appointment_date, start_time, end_time
 		FROM public.appointments
 		WHERE client_id = $1 AND appointment_date >= CURRENT_DATE AND status != 'cancelled'
 		ORDER BY appointment_date, start_time`, clientID)
 	if err != nil {
 		log.Printf("Ошибка получения записей: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка загрузки записей.")
+		msg := tgbotapi.NewMessage(chatID, b.t("error_try_later", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -764,21 +791,23 @@ func (b *Bot) handleExportCalendar(message *tgbotapi.Message) {
 	var events []calendar.Event
 	for rows.Next() {
 		var id int
+		var trainerID int64
 		var dateStr, startTimeStr, endTimeStr string
-		if err := rows.Scan(&id, &dateStr, &startTimeStr, &endTimeStr); err != nil {
+		if err := rows.Scan(&id, &trainerID, &dateStr, &startTimeStr, &endTimeStr); err != nil {
 			continue
 		}
 
 		date, _ := time.Parse("2006-01-02T15:04:05Z", dateStr)
 		startHour, startMin, _ := calendar.ParseTime(startTimeStr[:5])
 		endHour, endMin, _ := calendar.ParseTime(endTimeStr[:5])
+		trainerLoc := b.userLocation(trainerID)
 
 		events = append(events, calendar.Event{
 			UID:         fmt.Sprintf("training-%d@workbot", id),
-			Summary:     "Тренировка",
-			Description: fmt.Sprintf("Персональная тренировка\\nКлиент: %s %s", clientName, clientSurname),
-			StartTime:   calendar.CombineDateTime(date, startHour, startMin),
-			EndTime:     calendar.CombineDateTime(date, endHour, endMin),
+			Summary:     b.t("ics_summary", chatID),
+			Description: b.tf("ics_description", chatID, clientName, clientSurname),
+			StartTime:   calendar.CombineDateTimeIn(date, startHour, startMin, trainerLoc),
+			EndTime:     calendar.CombineDateTimeIn(date, endHour, endMin, trainerLoc),
 			Reminder:    60,
 		})
 	}
@@ -795,15 +824,57 @@ func (b *Bot) handleExportCalendar(message *tgbotapi.Message) {
 		Name:  "trainings.ics",
 		Bytes: []byte(icsContent),
 	})
-	doc.Caption = fmt.Sprintf("Ваши тренировки (%d записей)\nОткройте файл для добавления в календарь", len(events))
+	doc.Caption = b.tn("calendar_export_count", chatID, len(events)) + "\n" + b.t("calendar_export_hint", chatID)
+	if b.calendarFeedEnabled() {
+		doc.Caption += "\n\n" + b.t("calendar_export_subscribe_hint", chatID)
+	}
 	b.api.Send(doc)
 }
 
 // Вспомогательные функции
 
-func russianWeekday(w time.Weekday) string {
-	days := []string{"Вс", "Пн", "Вт", "Ср", "Чт", "Пт", "Сб"}
-	return days[w]
+// filterPastSlots убирает слоты, которые на дату date в поясе тренера уже начались
+func filterPastSlots(date time.Time, slots []string, trainerLoc *time.Location, now time.Time) []string {
+	var result []string
+	for _, slot := range slots {
+		hour, minute, err := calendar.ParseTime(slot)
+		if err != nil {
+			continue
+		}
+		if calendar.CombineDateTimeIn(date, hour, minute, trainerLoc).After(now) {
+			result = append(result, slot)
+		}
+	}
+	return result
+}
+
+// sameOffset проверяет, совпадает ли смещение двух поясов на дату date
+func sameOffset(date time.Time, a, b *time.Location) bool {
+	noon := calendar.CombineDateTimeIn(date, 12, 0, a)
+	_, offA := noon.Zone()
+	_, offB := noon.In(b).Zone()
+	return offA == offB
+}
+
+// localSlotLabel переводит слот тренера во время клиента.
+// Если у клиента это другой календарный день, добавляется пометка (+1) или (-1)
+func localSlotLabel(date time.Time, slot string, trainerLoc, clientLoc *time.Location) string {
+	hour, minute, err := calendar.ParseTime(slot)
+	if err != nil {
+		return slot
+	}
+	local := calendar.CombineDateTimeIn(date, hour, minute, trainerLoc).In(clientLoc)
+	label := local.Format("15:04")
+
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	localDay := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	switch diff := int(localDay.Sub(day).Hours() / 24); {
+	case diff > 0:
+		label += fmt.Sprintf(" (+%d)", diff)
+	case diff < 0:
+		label += fmt.Sprintf(" (%d)", diff)
+	}
+	return label
 }
 
 func generateTimeSlots(startTime, endTime string, slotDuration int) []string {
@@ -832,37 +903,12 @@ func filterBookedSlots(all []string, booked []string) []string {
 	return available
 }
 
-func getStatusText(status string) string {
+// getStatusTextLocalized возвращает локализованный статус
+func (b *Bot) getStatusTextLocalized(status string, chatID int64) string {
 	switch status {
-	case "scheduled":
-		return "запланирована"
-	case "confirmed":
-		return "подтверждена"
-	case "completed":
-		return "завершена"
-	case "cancelled":
-		return "отменена"
+	case "scheduled", "confirmed", "completed", "cancelled":
+		return b.t("status_"+status, chatID)
 	default:
 		return status
 	}
 }
-
-// getStatusTextLocalized возвращает локализованный статус
-func (b *Bot) getStatusTextLocalized(status string, chatID int64) string {
-	lang := b.getLanguage(chatID)
-	if lang == "en" {
-		switch status {
-		case "scheduled":
-			return "scheduled"
-		case "confirmed":
-			return "confirmed"
-		case "completed":
-			return "completed"
-		case "cancelled":
-			return "cancelled"
-		default:
-			return status
-		}
-	}
-	return getStatusText(status)
-}
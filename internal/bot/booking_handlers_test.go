@@ -0,0 +1,41 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocalSlotLabel(t *testing.T) {
+	moscow, _ := time.LoadLocation("Europe/Moscow")
+	vladivostok, _ := time.LoadLocation("Asia/Vladivostok")
+	newYork, _ := time.LoadLocation("America/New_York")
+	date := time.Date(2030, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		slot   string
+		client *time.Location
+		want   string
+	}{
+		{"10:00", moscow, "10:00"},
+		{"10:00", vladivostok, "17:00"},
+		{"18:00", vladivostok, "01:00 (+1)"},
+		{"05:00", newYork, "22:00 (-1)"},
+	}
+	for _, tt := range tests {
+		if got := localSlotLabel(date, tt.slot, moscow, tt.client); got != tt.want {
+			t.Errorf("localSlotLabel(%s, %s) = %q, want %q", tt.slot, tt.client, got, tt.want)
+		}
+	}
+}
+
+func TestFilterPastSlots(t *testing.T) {
+	moscow, _ := time.LoadLocation("Europe/Moscow")
+	date := time.Date(2030, 3, 15, 0, 0, 0, 0, time.UTC)
+	// 12:30 по Москве
+	now := time.Date(2030, 3, 15, 9, 30, 0, 0, time.UTC)
+
+	got := filterPastSlots(date, []string{"09:00", "12:00", "13:00", "18:00"}, moscow, now)
+	if len(got) != 2 || got[0] != "13:00" || got[1] != "18:00" {
+		t.Errorf("filterPastSlots = %v, want [13:00 18:00]", got)
+	}
+}
@@ -1,27 +1,37 @@
 package bot
 
 import (
+	"context"
 	"database/sql"
 	"log"
 
+	"workbot/internal/billing"
 	"workbot/internal/config"
+	"workbot/internal/gcalendar"
 	"workbot/internal/gsheets"
+	"workbot/internal/outbox"
 	"workbot/internal/repository"
+	"workbot/internal/scheduler"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 // Bot представляет Telegram бота
 type Bot struct {
-	api          *tgbotapi.BotAPI
+	api          *telegramAPI
 	db           *sql.DB
 	config       *config.Config
 	sheetsClient *gsheets.Client
 	repo         *repository.Repository
+	sheetsQueue  *gsheets.Queue
+	jobs         *scheduler.Scheduler
+	outbox       *outbox.Outbox
+	payments     billing.Provider  // nil — оплата через Telegram не настроена
+	gcal         *gcalendar.Client // nil — синхронизация с Google Calendar не настроена
 }
 
 // New создаёт новый экземпляр бота
-func New(api *tgbotapi.BotAPI, db *sql.DB, cfg *config.Config) *Bot {
+func New(api *tgbotapi.BotAPI, db *sql.DB, cfg *config.Config, jobs *scheduler.Scheduler) *Bot {
 	// Инициализируем Google Sheets клиент
 	var sheetsClient *gsheets.Client
 
@@ -45,13 +55,41 @@ func New(api *tgbotapi.BotAPI, db *sql.DB, cfg *config.Config) *Bot {
 		}
 	}
 
-	return &Bot{
-		api:          api,
+	// Записи, которые не удалось отправить в Google, ждут в очереди
+	var sheetsQueue *gsheets.Queue
+	if sheetsClient != nil {
+		sheetsQueue = gsheets.NewQueue(db, sheetsClient)
+	}
+
+	// Все отправки идут через общий ограничитель частоты
+	sender := outbox.NewSender(api, outbox.NewLimiter(outbox.DefaultLimits()))
+
+	b := &Bot{
+		api:          &telegramAPI{BotAPI: api, sender: sender},
 		db:           db,
 		config:       cfg,
 		sheetsClient: sheetsClient,
+		sheetsQueue:  sheetsQueue,
 		repo:         repository.New(db),
+		jobs:         jobs,
+		outbox:       outbox.New(db, sender),
+	}
+	sender.OnBlocked = b.markClientBlocked
+
+	if cfg.PaymentsProviderToken != "" {
+		b.payments = billing.NewTelegramProvider(b.api, cfg.PaymentsProviderToken)
 	}
+
+	// Календарь тренера — через тот же OAuth2 токен, что и Google Sheets
+	if cfg.GoogleCalendarID != "" && cfg.GoogleOAuthCredPath != "" && cfg.GoogleTokenPath != "" {
+		gcal, err := gcalendar.NewOAuthClient(cfg.GoogleOAuthCredPath, cfg.GoogleTokenPath, cfg.GoogleCalendarID)
+		if err != nil {
+			log.Printf("Google Calendar не инициализирован: %v", err)
+		} else {
+			b.gcal = gcal
+		}
+	}
+	return b
 }
 
 // Start запускает бота
@@ -61,55 +99,98 @@ func (b *Bot) Start() error {
 		return err
 	}
 
-	// Запускаем фоновые задачи
-	b.StartBirthdayReminder()     // Напоминания о днях рождения
-	b.StartAppointmentReminder()  // Напоминания о тренировках
+	// Запускаем очередь уведомлений и фоновые задачи
+	b.outbox.Start(context.Background())
+	if err := b.registerJobs(); err != nil {
+		return err
+	}
+	if err := b.jobs.Start(context.Background()); err != nil {
+		return err
+	}
+	if b.calendarFeedEnabled() {
+		go b.serveCalendarFeed()
+	}
 
 	b.handleUpdates(updates)
 	return nil
 }
 
 func (b *Bot) handleUpdates(updates tgbotapi.UpdatesChannel) {
+	// Обработчики работают в горутинах чатов: ожидание лимитов при отправке
+	// не останавливает получение обновлений
+	dispatcher := newChatDispatcher(b.handleUpdate)
 	for update := range updates {
-		// Обработка callback-запросов (от inline-кнопок)
-		if update.CallbackQuery != nil {
-			b.handleCallbackQuery(update.CallbackQuery)
-			continue
-		}
+		dispatcher.dispatch(update)
+	}
+	dispatcher.wait()
+}
 
-		if update.Message == nil {
-			continue
-		}
+// handleUpdate обрабатывает одно обновление
+func (b *Bot) handleUpdate(update tgbotapi.Update) {
+	// Обработка callback-запросов (от inline-кнопок)
+	if update.CallbackQuery != nil {
+		b.handleCallbackQuery(update.CallbackQuery)
+		return
+	}
 
-		chatID := update.Message.Chat.ID
-		isAdmin := b.isAdmin(chatID)
+	// Оплата через Telegram Payments
+	if update.PreCheckoutQuery != nil {
+		b.handlePreCheckout(update.PreCheckoutQuery)
+		return
+	}
 
-		// Обработка фото (для трекера прогресса)
-		if update.Message.Photo != nil {
-			userStates.RLock()
-			state := userStates.states[chatID]
-			userStates.RUnlock()
+	if update.Message == nil {
+		return
+	}
 
-			if state == stateProgressPhoto {
-				b.handleProgressPhoto(update.Message)
-				continue
-			}
-		}
+	if update.Message.SuccessfulPayment != nil {
+		b.handleSuccessfulPayment(update.Message)
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	isAdmin := b.isAdmin(chatID)
+	if !isAdmin {
+		b.clearClientBlocked(chatID)
+	}
 
-		if update.Message.IsCommand() {
-			if isAdmin {
-				b.handleAdminCommand(update.Message)
-			} else {
-				b.handleCommand(update.Message)
-			}
-			continue
+	// Обработка фото (для трекера прогресса)
+	if update.Message.Photo != nil {
+		userStates.RLock()
+		state := userStates.states[chatID]
+		userStates.RUnlock()
+
+		if state == stateProgressPhoto {
+			b.handleProgressPhoto(update.Message)
+			return
 		}
+	}
+
+	// Импорт программы из файла (JSON/YAML)
+	if isAdmin && isProgramFile(update.Message.Document) {
+		b.handleProgramImport(update.Message)
+		return
+	}
 
+	// Кардиотренировка из файла часов (GPX/TCX/FIT)
+	if !isAdmin && isActivityFile(update.Message.Document) {
+		b.handleActivityImport(update.Message)
+		return
+	}
+
+	if update.Message.IsCommand() {
 		if isAdmin {
-			b.handleAdminMessage(update.Message)
+			b.handleAdminCommand(update.Message)
 		} else {
-			b.handleMessage(update.Message)
+			b.handleCommand(update.Message)
 		}
+		return
+	}
+
+	if isAdmin {
+		b.handleAdminMessage(update.Message)
+	} else {
+		b.handleMessage(update.Message)
 	}
 }
 
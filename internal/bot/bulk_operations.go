@@ -0,0 +1,492 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"workbot/internal/calendar"
+	"workbot/internal/i18n"
+	"workbot/internal/repository"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	// maxShiftOffset максимальный сдвиг записей при массовом переносе
+	maxShiftOffset = 30 * 24 * time.Hour
+)
+
+// errBulkSkip помечает получателя как пропущенного (не ошибка доставки); reason — ключ локализации
+type errBulkSkip struct {
+	reason string
+}
+
+func (e errBulkSkip) Error() string { return e.reason }
+
+// bulkFailure описывает неудачную или пропущенную доставку
+type bulkFailure struct {
+	Client string
+	Reason string
+}
+
+// bulkReport итог массового действия
+type bulkReport struct {
+	Title   string
+	Total   int
+	Sent    int
+	Skipped []bulkFailure
+	Failed  []bulkFailure
+}
+
+// runBulk выполняет действие для каждого клиента. Лимиты Telegram и повторы
+// после 429 обеспечивает отправитель b.api; клиенты, заблокировавшие бота, пропускаются
+func (b *Bot) runBulk(adminChatID int64, title string, members []repository.GroupMember, action func(m repository.GroupMember) error) bulkReport {
+	report := bulkReport{Title: title, Total: len(members)}
+
+	for _, m := range members {
+		if m.TelegramID == 0 {
+			report.Skipped = append(report.Skipped, bulkFailure{Client: m.FullName(), Reason: b.t("bulk_reason_no_telegram", adminChatID)})
+			continue
+		}
+		if m.BotBlocked {
+			report.Skipped = append(report.Skipped, bulkFailure{Client: m.FullName(), Reason: b.t("bulk_reason_blocked", adminChatID)})
+			continue
+		}
+
+		err := action(m)
+
+		var skip errBulkSkip
+		switch {
+		case err == nil:
+			report.Sent++
+		case errors.As(err, &skip):
+			report.Skipped = append(report.Skipped, bulkFailure{Client: m.FullName(), Reason: b.t(skip.reason, adminChatID)})
+		default:
+			log.Printf("Ошибка массовой отправки клиенту %d: %v", m.ClientID, err)
+			report.Failed = append(report.Failed, bulkFailure{Client: m.FullName(), Reason: describeSendError(err, b.getLanguage(adminChatID))})
+		}
+	}
+
+	return report
+}
+
+// describeSendError возвращает понятную тренеру причину ошибки отправки
+func describeSendError(err error, lang i18n.Language) string {
+	var tgErr *tgbotapi.Error
+	if errors.As(err, &tgErr) {
+		switch tgErr.Code {
+		case 403:
+			return i18n.T("bulk_reason_blocked", lang)
+		case 400:
+			if strings.Contains(tgErr.Message, "chat not found") {
+				return i18n.T("bulk_reason_chat_not_found", lang)
+			}
+		case 429:
+			return i18n.T("bulk_reason_rate_limited", lang)
+		}
+		return tgErr.Message
+	}
+	return err.Error()
+}
+
+// formatBulkReport формирует отчёт о доставке для тренера
+func formatBulkReport(r bulkReport, lang i18n.Language) string {
+	var text strings.Builder
+	text.WriteString(i18n.Tf("bulk_report_title", lang, r.Title) + "\n\n")
+	text.WriteString(i18n.Tf("bulk_report_total", lang, r.Total) + "\n")
+	text.WriteString(i18n.Tf("bulk_report_sent", lang, r.Sent) + "\n")
+	if len(r.Skipped) > 0 {
+		text.WriteString(i18n.Tf("bulk_report_skipped", lang, len(r.Skipped)) + "\n")
+	}
+	if len(r.Failed) > 0 {
+		text.WriteString(i18n.Tf("bulk_report_failed", lang, len(r.Failed)) + "\n")
+	}
+
+	if len(r.Failed) > 0 {
+		text.WriteString("\n" + i18n.T("bulk_report_failed_list", lang) + "\n")
+		for _, f := range r.Failed {
+			text.WriteString(fmt.Sprintf("• %s — %s\n", f.Client, f.Reason))
+		}
+	}
+	if len(r.Skipped) > 0 {
+		text.WriteString("\n" + i18n.T("bulk_report_skipped_list", lang) + "\n")
+		for _, f := range r.Skipped {
+			text.WriteString(fmt.Sprintf("• %s — %s\n", f.Client, f.Reason))
+		}
+	}
+
+	return text.String()
+}
+
+// bulkSendNextWorkout отправляет следующую тренировку всем клиентам группы
+func (b *Bot) bulkSendNextWorkout(adminChatID int64, title string, members []repository.GroupMember) {
+	report := b.runBulk(adminChatID, title, members, func(m repository.GroupMember) error {
+		workout, err := b.repo.Program.GetNextPendingWorkout(m.ClientID)
+		if err != nil {
+			return err
+		}
+		if workout == nil {
+			return errBulkSkip{reason: "bulk_reason_no_pending"}
+		}
+		if err := b.sendWorkoutToClient(m.TelegramID, workout); err != nil {
+			return err
+		}
+		if err := b.repo.Program.MarkWorkoutSent(workout.ID); err != nil {
+			log.Printf("Ошибка отметки тренировки как отправленной: %v", err)
+		}
+		return nil
+	})
+
+	b.sendMessage(adminChatID, formatBulkReport(report, b.getLanguage(adminChatID)))
+}
+
+// bulkSendReminder рассылает напоминание тренера всем клиентам группы
+func (b *Bot) bulkSendReminder(adminChatID int64, title string, members []repository.GroupMember, reminder string) {
+	report := b.runBulk(adminChatID, title, members, func(m repository.GroupMember) error {
+		msg := tgbotapi.NewMessage(m.TelegramID, b.tf("group_reminder", m.TelegramID, reminder))
+		_, err := b.api.Send(msg)
+		return err
+	})
+
+	b.sendMessage(adminChatID, formatBulkReport(report, b.getLanguage(adminChatID)))
+}
+
+// shiftUnitPattern число с единицей измерения сдвига: "2ч", "1 д", "30m"
+var shiftUnitPattern = regexp.MustCompile(`(\d+)\s*([a-zа-яёіїєґ]+)`)
+
+// parseShiftOffset разбирает сдвиг записей: "+2ч", "-1д", "+1д 3ч", "30 мин"
+func parseShiftOffset(text string) (time.Duration, error) {
+	s := strings.ToLower(strings.TrimSpace(text))
+	sign := time.Duration(1)
+	if strings.HasPrefix(s, "-") {
+		sign = -1
+		s = s[1:]
+	} else {
+		s = strings.TrimPrefix(s, "+")
+	}
+
+	matches := shiftUnitPattern.FindAllStringSubmatch(s, -1)
+	if len(matches) == 0 || strings.TrimSpace(shiftUnitPattern.ReplaceAllString(s, "")) != "" {
+		return 0, localizedError("bulk_shift_invalid")
+	}
+
+	var total time.Duration
+	for _, m := range matches {
+		n, _ := strconv.Atoi(m[1])
+		var unit time.Duration
+		switch m[2] {
+		case "д", "дн", "день", "дня", "дней", "дні", "днів", "d", "day", "days":
+			unit = 24 * time.Hour
+		case "ч", "час", "часа", "часов", "год", "година", "години", "годин", "h", "hour", "hours":
+			unit = time.Hour
+		case "м", "мин", "минут", "минуты", "хв", "хвилин", "хвилини", "m", "min":
+			unit = time.Minute
+		default:
+			return 0, localizedError("bulk_shift_unknown_unit")
+		}
+		total += time.Duration(n) * unit
+	}
+
+	if total == 0 {
+		return 0, localizedError("bulk_shift_zero")
+	}
+	if total > maxShiftOffset {
+		return 0, localizedError("bulk_shift_too_large")
+	}
+	return sign * total, nil
+}
+
+// formatShiftOffset форматирует сдвиг для тренера: "+1 д 2 ч"
+func formatShiftOffset(d time.Duration, lang i18n.Language) string {
+	sign := "+"
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+
+	var parts []string
+	if days > 0 {
+		parts = append(parts, i18n.Tf("bulk_shift_days", lang, days))
+	}
+	if hours > 0 {
+		parts = append(parts, i18n.Tf("bulk_shift_hours", lang, hours))
+	}
+	if minutes > 0 {
+		parts = append(parts, i18n.Tf("bulk_shift_minutes", lang, minutes))
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// plannedMove перенос записи, прошедший проверку конфликтов
+type plannedMove struct {
+	Appointment repository.AppointmentWithClient
+	NewStart    time.Time
+	NewEnd      time.Time
+}
+
+// rescheduleConflict запись, которую нельзя перенести; Reason — ключ локализации с аргументами Args
+type rescheduleConflict struct {
+	Appointment repository.AppointmentWithClient
+	Reason      string
+	Args        []interface{}
+}
+
+// appointmentBounds возвращает начало и конец записи; дата и время записи заданы в поясе тренера loc
+func appointmentBounds(a repository.AppointmentWithClient, loc *time.Location) (time.Time, time.Time, error) {
+	sh, sm, err := calendar.ParseTime(a.StartTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	eh, em, err := calendar.ParseTime(a.EndTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return calendar.CombineDateTimeIn(a.AppointmentDate, sh, sm, loc), calendar.CombineDateTimeIn(a.AppointmentDate, eh, em, loc), nil
+}
+
+// planReschedule проверяет сдвиг записей на offset и возвращает допустимые переносы и конфликты.
+// existing — неотменённые записи на целевые даты; записи, которые не удалось перенести,
+// остаются на месте и тоже считаются занятыми слотами. loc — часовой пояс тренера
+func planReschedule(moving, existing []repository.AppointmentWithClient, offset time.Duration, now time.Time, loc *time.Location) ([]plannedMove, []rescheduleConflict) {
+	type slot struct {
+		appt       repository.AppointmentWithClient
+		start, end time.Time
+	}
+
+	movingIDs := make(map[int]bool, len(moving))
+	for _, a := range moving {
+		movingIDs[a.ID] = true
+	}
+
+	var fixed []slot
+	for _, e := range existing {
+		if movingIDs[e.ID] {
+			continue
+		}
+		start, end, err := appointmentBounds(e, loc)
+		if err != nil {
+			continue
+		}
+		fixed = append(fixed, slot{e, start, end})
+	}
+
+	var conflicts []rescheduleConflict
+	candidates := make(map[int]plannedMove)
+	for _, a := range moving {
+		start, end, err := appointmentBounds(a, loc)
+		if err != nil {
+			conflicts = append(conflicts, rescheduleConflict{Appointment: a, Reason: "bulk_conflict_invalid_time"})
+			continue
+		}
+		newStart, newEnd := start.Add(offset), end.Add(offset)
+		switch {
+		case newStart.Format("2006-01-02") != newEnd.Add(-time.Minute).Format("2006-01-02"):
+			conflicts = append(conflicts, rescheduleConflict{Appointment: a, Reason: "bulk_conflict_midnight"})
+		case newStart.Before(now):
+			conflicts = append(conflicts, rescheduleConflict{Appointment: a, Reason: "bulk_conflict_past"})
+		default:
+			candidates[a.ID] = plannedMove{Appointment: a, NewStart: newStart, NewEnd: newEnd}
+			continue
+		}
+		fixed = append(fixed, slot{a, start, end})
+	}
+
+	// Повторяем, пока отказ одного переноса не перестанет порождать новые конфликты
+	for changed := true; changed; {
+		changed = false
+		for _, a := range moving {
+			move, ok := candidates[a.ID]
+			if !ok {
+				continue
+			}
+			for _, f := range fixed {
+				if f.appt.TrainerID != a.TrainerID {
+					continue
+				}
+				if move.NewStart.Before(f.end) && f.start.Before(move.NewEnd) {
+					conflicts = append(conflicts, rescheduleConflict{
+						Appointment: a,
+						Reason:      "bulk_conflict_overlap",
+						Args:        []interface{}{f.appt.ClientName, f.appt.ClientSurname, f.start.Format("02.01 15:04")},
+					})
+					delete(candidates, a.ID)
+					start, end, _ := appointmentBounds(a, loc)
+					fixed = append(fixed, slot{a, start, end})
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	var moves []plannedMove
+	for _, a := range moving {
+		if move, ok := candidates[a.ID]; ok {
+			moves = append(moves, move)
+		}
+	}
+
+	// Порядок применения: при сдвиге вперёд начинаем с последних записей,
+	// назад — с первых, чтобы не занимать ещё не освобождённые слоты
+	sort.Slice(moves, func(i, j int) bool {
+		if offset > 0 {
+			return moves[i].NewStart.After(moves[j].NewStart)
+		}
+		return moves[i].NewStart.Before(moves[j].NewStart)
+	})
+
+	return moves, conflicts
+}
+
+// loadReschedulePlan собирает записи клиентов группы на дату и строит план переноса
+func (b *Bot) loadReschedulePlan(members []repository.GroupMember, date time.Time, offset time.Duration) ([]plannedMove, []rescheduleConflict, error) {
+	onDate, err := b.repo.Appointment.GetActiveByDate(date)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	memberIDs := make(map[int]bool, len(members))
+	for _, m := range members {
+		memberIDs[m.ClientID] = true
+	}
+
+	var moving []repository.AppointmentWithClient
+	for _, a := range onDate {
+		if memberIDs[a.ClientID] {
+			moving = append(moving, a)
+		}
+	}
+	if len(moving) == 0 {
+		return nil, nil, nil
+	}
+
+	// Время записей задано в поясе тренера
+	loc := b.userLocation(moving[0].TrainerID)
+
+	// Записи на все затронутые даты (сдвиг по часам может перейти на соседний день)
+	targetDates := make(map[string]time.Time)
+	for _, a := range moving {
+		start, end, err := appointmentBounds(a, loc)
+		if err != nil {
+			continue
+		}
+		for _, t := range []time.Time{start.Add(offset), end.Add(offset)} {
+			targetDates[t.Format("2006-01-02")] = t
+		}
+	}
+
+	var existing []repository.AppointmentWithClient
+	for key, t := range targetDates {
+		if key == date.Format("2006-01-02") {
+			existing = append(existing, onDate...)
+			continue
+		}
+		appts, err := b.repo.Appointment.GetActiveByDate(t)
+		if err != nil {
+			return nil, nil, err
+		}
+		existing = append(existing, appts...)
+	}
+
+	moves, conflicts := planReschedule(moving, existing, offset, time.Now(), loc)
+	return moves, conflicts, nil
+}
+
+// formatReschedulePlan формирует превью или итог массового переноса
+func formatReschedulePlan(title string, moves []plannedMove, conflicts []rescheduleConflict, lang i18n.Language) string {
+	var text strings.Builder
+	text.WriteString(title)
+	text.WriteString("\n\n")
+
+	if len(moves) > 0 {
+		text.WriteString(i18n.Tf("bulk_plan_moves", lang, len(moves)) + "\n")
+		for _, m := range moves {
+			text.WriteString(fmt.Sprintf("• %s %s: %s %s → %s\n",
+				m.Appointment.ClientName, m.Appointment.ClientSurname,
+				m.Appointment.AppointmentDate.Format("02.01"), m.Appointment.StartTime,
+				m.NewStart.Format("02.01 15:04")))
+		}
+	}
+
+	if len(conflicts) > 0 {
+		text.WriteString("\n" + i18n.Tf("bulk_plan_conflicts", lang, len(conflicts)) + "\n")
+		for _, c := range conflicts {
+			text.WriteString(fmt.Sprintf("• %s %s %s — %s\n",
+				c.Appointment.ClientName, c.Appointment.ClientSurname, c.Appointment.StartTime, i18n.Tf(c.Reason, lang, c.Args...)))
+		}
+	}
+
+	return text.String()
+}
+
+// applyReschedule переносит записи и уведомляет клиентов
+func (b *Bot) applyReschedule(adminChatID int64, members []repository.GroupMember, date time.Time, offset time.Duration) {
+	moves, conflicts, err := b.loadReschedulePlan(members, date, offset)
+	if err != nil {
+		b.sendError(adminChatID, b.t("bulk_appointments_load_error", adminChatID), err)
+		return
+	}
+	if len(moves) == 0 {
+		b.sendMessage(adminChatID, formatReschedulePlan(b.t("bulk_nothing_to_move", adminChatID), nil, conflicts, b.getLanguage(adminChatID)))
+		return
+	}
+
+	batch := make([]repository.AppointmentMove, 0, len(moves))
+	for _, m := range moves {
+		batch = append(batch, repository.AppointmentMove{
+			ID:        m.Appointment.ID,
+			Date:      m.NewStart,
+			StartTime: m.NewStart.Format("15:04"),
+			EndTime:   m.NewEnd.Format("15:04"),
+		})
+	}
+	if err := b.repo.Appointment.RescheduleBatch(batch); err != nil {
+		b.sendError(adminChatID, b.t("bulk_reschedule_error", adminChatID), err)
+		return
+	}
+	b.requestGoogleSync()
+
+	lang := b.getLanguage(adminChatID)
+	b.sendMessage(adminChatID, formatReschedulePlan(
+		i18n.Tf("bulk_rescheduled_title", lang, date.Format("02.01.2006"), formatShiftOffset(offset, lang)),
+		moves, conflicts, lang))
+
+	// Уведомляем клиентов перенесённых записей
+	movedByClient := make(map[int]plannedMove, len(moves))
+	var recipients []repository.GroupMember
+	for _, m := range moves {
+		if _, ok := movedByClient[m.Appointment.ClientID]; ok {
+			continue
+		}
+		movedByClient[m.Appointment.ClientID] = m
+		for _, member := range members {
+			if member.ClientID == m.Appointment.ClientID {
+				recipients = append(recipients, member)
+				break
+			}
+		}
+	}
+
+	report := b.runBulk(adminChatID, b.t("bulk_title_reschedule_notify", adminChatID), recipients, func(member repository.GroupMember) error {
+		m := movedByClient[member.ClientID]
+		// Старое и новое время — в поясе клиента
+		clientLoc := b.userLocation(member.TelegramID)
+		oldStart := b.appointmentStart(m.Appointment.TrainerID, m.Appointment.AppointmentDate, m.Appointment.StartTime).In(clientLoc)
+		newStart := m.NewStart.In(clientLoc)
+		text := b.tf("appointment_rescheduled", member.TelegramID,
+			oldStart.Format("02.01.2006"), oldStart.Format("15:04"),
+			newStart.Format("02.01.2006"), newStart.Format("15:04"))
+		_, err := b.api.Send(tgbotapi.NewMessage(member.TelegramID, text))
+		return err
+	})
+	b.sendMessage(adminChatID, formatBulkReport(report, b.getLanguage(adminChatID)))
+}
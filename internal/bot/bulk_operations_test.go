@@ -0,0 +1,137 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"workbot/internal/i18n"
+	"workbot/internal/repository"
+)
+
+func TestParseShiftOffset(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"+2ч", 2 * time.Hour, false},
+		{"2ч", 2 * time.Hour, false},
+		{"-1д", -24 * time.Hour, false},
+		{"+1д 3ч", 27 * time.Hour, false},
+		{"+30 мин", 30 * time.Minute, false},
+		{"-1h", -time.Hour, false},
+		{"+2 days", 48 * time.Hour, false},
+		{"+1д 2год", 26 * time.Hour, false},
+		{"-15 хв", -15 * time.Minute, false},
+		{"", 0, true},
+		{"0ч", 0, true},
+		{"+2", 0, true},
+		{"+2 недели", 0, true},
+		{"+31д", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseShiftOffset(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseShiftOffset(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseShiftOffset(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFormatShiftOffset(t *testing.T) {
+	tests := []struct {
+		offset time.Duration
+		want   string
+	}{
+		{2 * time.Hour, "+2 ч"},
+		{-24 * time.Hour, "-1 д"},
+		{27*time.Hour + 30*time.Minute, "+1 д 3 ч 30 мин"},
+	}
+
+	for _, tt := range tests {
+		if got := formatShiftOffset(tt.offset, i18n.DefaultLang); got != tt.want {
+			t.Errorf("formatShiftOffset(%v) = %q, want %q", tt.offset, got, tt.want)
+		}
+	}
+}
+
+func testAppointment(id, clientID int, date time.Time, start, end string) repository.AppointmentWithClient {
+	return repository.AppointmentWithClient{
+		Appointment: repository.Appointment{
+			ID:              id,
+			ClientID:        clientID,
+			TrainerID:       1,
+			AppointmentDate: date,
+			StartTime:       start,
+			EndTime:         end,
+		},
+		ClientName: "Client",
+	}
+}
+
+func TestPlanReschedule(t *testing.T) {
+	day := time.Date(2030, 3, 10, 0, 0, 0, 0, time.Local)
+	now := time.Date(2030, 3, 1, 0, 0, 0, 0, time.Local)
+
+	t.Run("chain shift without conflicts", func(t *testing.T) {
+		moving := []repository.AppointmentWithClient{
+			testAppointment(1, 1, day, "10:00", "11:00"),
+			testAppointment(2, 2, day, "11:00", "12:00"),
+		}
+		moves, conflicts := planReschedule(moving, moving, time.Hour, now, time.Local)
+		if len(conflicts) != 0 || len(moves) != 2 {
+			t.Fatalf("got %d moves, %d conflicts; want 2, 0", len(moves), len(conflicts))
+		}
+		// При сдвиге вперёд первой переносится более поздняя запись
+		if moves[0].Appointment.ID != 2 {
+			t.Errorf("first move = %d, want 2", moves[0].Appointment.ID)
+		}
+	})
+
+	t.Run("conflict with staying appointment", func(t *testing.T) {
+		moving := []repository.AppointmentWithClient{
+			testAppointment(1, 1, day, "10:00", "11:00"),
+		}
+		existing := append(moving, testAppointment(3, 3, day, "11:00", "12:00"))
+		moves, conflicts := planReschedule(moving, existing, time.Hour, now, time.Local)
+		if len(moves) != 0 || len(conflicts) != 1 {
+			t.Fatalf("got %d moves, %d conflicts; want 0, 1", len(moves), len(conflicts))
+		}
+	})
+
+	t.Run("rejected move blocks its slot", func(t *testing.T) {
+		moving := []repository.AppointmentWithClient{
+			testAppointment(1, 1, day, "10:00", "11:00"),
+			testAppointment(2, 2, day, "11:00", "12:00"),
+		}
+		existing := append(moving, testAppointment(3, 3, day, "12:00", "13:00"))
+		moves, conflicts := planReschedule(moving, existing, time.Hour, now, time.Local)
+		if len(moves) != 0 || len(conflicts) != 2 {
+			t.Fatalf("got %d moves, %d conflicts; want 0, 2", len(moves), len(conflicts))
+		}
+	})
+
+	t.Run("crossing midnight", func(t *testing.T) {
+		moving := []repository.AppointmentWithClient{
+			testAppointment(1, 1, day, "21:00", "22:30"),
+		}
+		moves, conflicts := planReschedule(moving, moving, 2*time.Hour, now, time.Local)
+		if len(moves) != 0 || len(conflicts) != 1 {
+			t.Fatalf("got %d moves, %d conflicts; want 0, 1", len(moves), len(conflicts))
+		}
+	})
+
+	t.Run("shift into the past", func(t *testing.T) {
+		moving := []repository.AppointmentWithClient{
+			testAppointment(1, 1, day, "10:00", "11:00"),
+		}
+		moves, conflicts := planReschedule(moving, moving, -10*24*time.Hour, now, time.Local)
+		if len(moves) != 0 || len(conflicts) != 1 {
+			t.Fatalf("got %d moves, %d conflicts; want 0, 1", len(moves), len(conflicts))
+		}
+	})
+}
@@ -0,0 +1,248 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"workbot/internal/calendar"
+	"workbot/internal/i18n"
+	"workbot/internal/repository"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// feedPastDays — сколько дней прошлого остаётся в ленте: календарь не удаляет
+// события, пропавшие из подписки, не сразу, а отметки недавних нужны
+const feedPastDays = 30
+
+// feedAppointmentReminder — напоминание о записи в ленте, минут
+const feedAppointmentReminder = 60
+
+// calendarFeedEnabled проверяет, настроена ли лента календаря
+func (b *Bot) calendarFeedEnabled() bool {
+	return b.config != nil && b.config.CalendarFeedAddr != "" && b.config.CalendarFeedURL != ""
+}
+
+// calendarFeedURL возвращает ссылку на ленту для подписки
+func (b *Bot) calendarFeedURL(token string) string {
+	return b.config.CalendarFeedURL + "/calendar/" + token + ".ics"
+}
+
+// serveCalendarFeed запускает HTTP-сервер ленты календаря
+func (b *Bot) serveCalendarFeed() {
+	srv := &http.Server{
+		Addr:              b.config.CalendarFeedAddr,
+		Handler:           b.calendarFeedHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+	log.Printf("Лента календаря: %s (%s)", b.config.CalendarFeedAddr, b.config.CalendarFeedURL)
+	if err := srv.ListenAndServe(); err != nil {
+		log.Printf("Ошибка сервера ленты календаря: %v", err)
+	}
+}
+
+// calendarFeedHandler отдаёт ленту по ссылке /calendar/<токен>.ics
+func (b *Bot) calendarFeedHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /calendar/{file}", func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutSuffix(r.PathValue("file"), ".ics")
+		if !ok || token == "" {
+			http.NotFound(w, r)
+			return
+		}
+		feed, err := b.repo.Feed.GetByToken(token)
+		if err != nil {
+			log.Printf("Ошибка получения ленты календаря: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		if feed == nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		now := time.Now()
+		events, err := b.feedEvents(feed, now)
+		if err != nil {
+			log.Printf("Ошибка сборки ленты календаря %d: %v", feed.TelegramID, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		if err := b.repo.Feed.MarkFetched(feed.Token); err != nil {
+			log.Printf("Ошибка отметки ленты календаря: %v", err)
+		}
+
+		lang := b.getLanguage(feed.TelegramID)
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-cache")
+		fmt.Fprint(w, calendar.GenerateFeed(i18n.T("ics_feed_name", lang), events, now))
+	})
+	return mux
+}
+
+// feedEvents собирает события ленты: записи к тренеру или записи клиента
+// и тренировки его программ
+func (b *Bot) feedEvents(feed *repository.CalendarFeed, now time.Time) ([]calendar.Event, error) {
+	lang := b.getLanguage(feed.TelegramID)
+	since := now.AddDate(0, 0, -feedPastDays)
+
+	if feed.Role == repository.FeedRoleTrainer {
+		appointments, err := b.repo.Feed.TrainerAppointments(feed.TelegramID, since)
+		if err != nil {
+			return nil, err
+		}
+		loc := b.userLocation(feed.TelegramID)
+		events := make([]calendar.Event, 0, len(appointments))
+		for _, a := range appointments {
+			name := strings.TrimSpace(a.ClientName + " " + a.ClientSurname)
+			events = append(events, appointmentEvent(a, loc,
+				i18n.Tf("ics_feed_trainer_summary", lang, name), a.Notes))
+		}
+		return events, nil
+	}
+
+	appointments, err := b.repo.Feed.ClientAppointments(feed.TelegramID, since)
+	if err != nil {
+		return nil, err
+	}
+	workouts, err := b.repo.Feed.ClientWorkouts(feed.TelegramID, since)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]calendar.Event, 0, len(appointments)+len(workouts))
+	for _, a := range appointments {
+		events = append(events, appointmentEvent(a, b.userLocation(a.TrainerID),
+			i18n.T("ics_summary", lang), i18n.Tf("ics_description", lang, a.ClientName, a.ClientSurname)))
+	}
+	for _, w := range workouts {
+		events = append(events, workoutEvent(w,
+			i18n.Tf("ics_feed_workout_summary", lang, w.Name), i18n.Tf("ics_feed_workout_description", lang, w.ProgramName)))
+	}
+	return events, nil
+}
+
+// appointmentEvent переводит запись в событие ленты; дата и время записи — в поясе тренера
+func appointmentEvent(a repository.FeedAppointment, trainerLoc *time.Location, summary, description string) calendar.Event {
+	startHour, startMin, _ := calendar.ParseTime(a.StartTime)
+	endHour, endMin, _ := calendar.ParseTime(a.EndTime)
+	return calendar.Event{
+		UID:          fmt.Sprintf("training-%d@workbot", a.ID),
+		Summary:      summary,
+		Description:  description,
+		StartTime:    calendar.CombineDateTimeIn(a.AppointmentDate, startHour, startMin, trainerLoc),
+		EndTime:      calendar.CombineDateTimeIn(a.AppointmentDate, endHour, endMin, trainerLoc),
+		Reminder:     feedAppointmentReminder,
+		Sequence:     a.Sequence,
+		Cancelled:    a.Status == "cancelled",
+		LastModified: a.UpdatedAt,
+	}
+}
+
+// workoutEvent переводит тренировку программы в событие на весь день плановой даты
+func workoutEvent(w repository.FeedWorkout, summary, description string) calendar.Event {
+	return calendar.Event{
+		UID:         fmt.Sprintf("workout-%d@workbot", w.ID),
+		Summary:     summary,
+		Description: description,
+		StartTime:   w.PlannedDate,
+		AllDay:      true,
+		Sequence:    w.Sequence,
+		Cancelled:   workoutCancelled(w),
+	}
+}
+
+// workoutCancelled — тренировка пропущена или её программа на паузе, а сама она не выполнена
+func workoutCancelled(w repository.FeedWorkout) bool {
+	if w.Status == "skipped" {
+		return true
+	}
+	return w.ProgramStatus == "paused" && w.Status != "completed"
+}
+
+// feedRole возвращает роль ленты пользователя; false — пользователь не тренер и не клиент
+func (b *Bot) feedRole(telegramID int64) (string, bool) {
+	if b.isAdmin(telegramID) {
+		return repository.FeedRoleTrainer, true
+	}
+	exists, err := b.repo.Client.ExistsByTelegramID(telegramID)
+	if err != nil {
+		log.Printf("Ошибка проверки клиента %d: %v", telegramID, err)
+		return "", false
+	}
+	return repository.FeedRoleClient, exists
+}
+
+// handleCalendarFeedSettings показывает ссылку на ленту; ссылки нет — выдаёт новую.
+// regenerate — отозвать действующую и выдать новую
+func (b *Bot) handleCalendarFeedSettings(chatID int64, messageID int, regenerate bool) {
+	role, ok := b.feedRole(chatID)
+	if !ok {
+		b.sendMessage(chatID, b.t("reg_not_registered", chatID))
+		return
+	}
+
+	feed, err := b.repo.Feed.GetActive(chatID)
+	if err == nil && (feed == nil || regenerate) {
+		feed, err = b.repo.Feed.Issue(chatID, role)
+	}
+	if err != nil {
+		b.sendError(chatID, b.t("error_try_later", chatID), err)
+		return
+	}
+
+	var text strings.Builder
+	if regenerate {
+		text.WriteString(b.t("calfeed_regenerated", chatID) + "\n\n")
+	}
+	text.WriteString(b.tf("calfeed_link", chatID, b.calendarFeedURL(feed.Token)))
+	text.WriteString("\n\n" + b.t("calfeed_hint", chatID))
+	if feed.LastFetchedAt.Valid {
+		text.WriteString("\n\n" + b.tf("calfeed_last_fetched", chatID,
+			b.formatForUser(chatID, feed.LastFetchedAt.Time, "02.01.2006 15:04")))
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("calfeed_btn_regenerate", chatID), "settings_calfeed_new"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("calfeed_btn_revoke", chatID), "settings_calfeed_off"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("back", chatID), "settings_back"),
+		),
+	)
+	b.editCalendarFeedMessage(chatID, messageID, text.String(), keyboard)
+}
+
+// handleCalendarFeedRevoke отзывает ссылку: подписанные календари перестают обновляться
+func (b *Bot) handleCalendarFeedRevoke(chatID int64, messageID int) {
+	if _, err := b.repo.Feed.Revoke(chatID); err != nil {
+		b.sendError(chatID, b.t("error_try_later", chatID), err)
+		return
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("calfeed_btn_create", chatID), "settings_calfeed"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("back", chatID), "settings_back"),
+		),
+	)
+	b.editCalendarFeedMessage(chatID, messageID, b.t("calfeed_revoked", chatID), keyboard)
+}
+
+// editCalendarFeedMessage показывает экран ленты в сообщении настроек, без превью ссылки
+func (b *Bot) editCalendarFeedMessage(chatID int64, messageID int, text string, keyboard tgbotapi.InlineKeyboardMarkup) {
+	edit := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	edit.ReplyMarkup = &keyboard
+	edit.DisableWebPagePreview = true
+	if _, err := b.api.Send(edit); err != nil {
+		log.Printf("Ошибка редактирования сообщения: %v", err)
+	}
+}
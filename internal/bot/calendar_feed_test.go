@@ -0,0 +1,72 @@
+package bot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workbot/internal/repository"
+)
+
+func TestAppointmentEvent(t *testing.T) {
+	moscow, _ := time.LoadLocation("Europe/Moscow")
+	a := repository.FeedAppointment{Sequence: 3}
+	a.ID = 42
+	a.AppointmentDate = time.Date(2030, 3, 15, 0, 0, 0, 0, time.UTC)
+	a.StartTime, a.EndTime = "10:00", "11:30"
+	a.Status = "cancelled"
+
+	e := appointmentEvent(a, moscow, "Тренировка", "")
+	if e.UID != "training-42@workbot" {
+		t.Errorf("UID = %q", e.UID)
+	}
+	if want := time.Date(2030, 3, 15, 7, 0, 0, 0, time.UTC); !e.StartTime.Equal(want) {
+		t.Errorf("StartTime = %v, want %v", e.StartTime, want)
+	}
+	if e.EndTime.Sub(e.StartTime) != 90*time.Minute {
+		t.Errorf("длительность = %v", e.EndTime.Sub(e.StartTime))
+	}
+	if !e.Cancelled || e.Sequence != 3 {
+		t.Errorf("Cancelled = %v, Sequence = %d", e.Cancelled, e.Sequence)
+	}
+}
+
+func TestWorkoutCancelled(t *testing.T) {
+	tests := []struct {
+		status, program string
+		want            bool
+	}{
+		{"pending", "active", false},
+		{"skipped", "active", true},
+		{"pending", "paused", true},
+		{"sent", "paused", true},
+		{"completed", "paused", false},
+		{"completed", "completed", false},
+	}
+	for _, tt := range tests {
+		w := repository.FeedWorkout{ID: 1, Status: tt.status, ProgramStatus: tt.program}
+		if got := workoutCancelled(w); got != tt.want {
+			t.Errorf("workoutCancelled(%s, программа %s) = %v, want %v", tt.status, tt.program, got, tt.want)
+		}
+		if e := workoutEvent(w, "", ""); e.Cancelled != tt.want || !e.AllDay || e.UID != "workout-1@workbot" {
+			t.Errorf("workoutEvent(%s, %s) = %+v", tt.status, tt.program, e)
+		}
+	}
+}
+
+func TestCalendarFeedHandlerRejectsBadPaths(t *testing.T) {
+	h := (&Bot{}).calendarFeedHandler()
+	for _, path := range []string{"/calendar/token", "/calendar/.ics", "/calendar/", "/other/token.ics"} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("GET %s = %d, want 404", path, rec.Code)
+		}
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/calendar/token.ics", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST = %d, want 405", rec.Code)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"time"
 
+	"workbot/internal/i18n"
+
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
@@ -14,10 +16,11 @@ type CalendarWidget struct {
 	MinDate     time.Time // Минимальная дата для выбора
 	MaxDate     time.Time // Максимальная дата для выбора
 	BookedDates map[string]bool // Занятые даты
+	Lang        i18n.Language   // Язык подписей
 }
 
 // NewCalendarWidget создаёт новый виджет календаря
-func NewCalendarWidget() *CalendarWidget {
+func NewCalendarWidget(lang i18n.Language) *CalendarWidget {
 	now := time.Now()
 	return &CalendarWidget{
 		Year:        now.Year(),
@@ -25,6 +28,7 @@ func NewCalendarWidget() *CalendarWidget {
 		MinDate:     now.AddDate(0, 0, 1),   // Завтра
 		MaxDate:     now.AddDate(0, 0, 30),  // +30 дней
 		BookedDates: make(map[string]bool),
+		Lang:        lang,
 	}
 }
 
@@ -41,18 +45,18 @@ func (c *CalendarWidget) GenerateCalendar() tgbotapi.InlineKeyboardMarkup {
 	var rows [][]tgbotapi.InlineKeyboardButton
 
 	// Заголовок: < Январь 2026 >
-	monthName := russianMonth(c.Month)
+	title := fmt.Sprintf("%s %d", monthName(c.Month, c.Lang), c.Year)
 	headerRow := []tgbotapi.InlineKeyboardButton{
 		tgbotapi.NewInlineKeyboardButtonData("◀", fmt.Sprintf("cal_prev_%d_%d", c.Year, c.Month)),
-		tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%s %d", monthName, c.Year), "cal_ignore"),
+		tgbotapi.NewInlineKeyboardButtonData(title, "cal_ignore"),
 		tgbotapi.NewInlineKeyboardButtonData("▶", fmt.Sprintf("cal_next_%d_%d", c.Year, c.Month)),
 	}
 	rows = append(rows, headerRow)
 
 	// Дни недели
-	weekdays := []string{"Пн", "Вт", "Ср", "Чт", "Пт", "Сб", "Вс"}
 	var weekdayRow []tgbotapi.InlineKeyboardButton
-	for _, wd := range weekdays {
+	for i := 1; i <= 7; i++ {
+		wd := weekdayShort(time.Weekday(i%7), c.Lang)
 		weekdayRow = append(weekdayRow, tgbotapi.NewInlineKeyboardButtonData(wd, "cal_ignore"))
 	}
 	rows = append(rows, weekdayRow)
@@ -113,7 +117,7 @@ func (c *CalendarWidget) GenerateCalendar() tgbotapi.InlineKeyboardMarkup {
 
 	// Кнопка отмены
 	cancelRow := []tgbotapi.InlineKeyboardButton{
-		tgbotapi.NewInlineKeyboardButtonData("❌ Отмена", "cal_cancel"),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T("btn_cancel_inline", c.Lang), "cal_cancel"),
 	}
 	rows = append(rows, cancelRow)
 
@@ -121,12 +125,13 @@ func (c *CalendarWidget) GenerateCalendar() tgbotapi.InlineKeyboardMarkup {
 }
 
 // GenerateTimeSlots создаёт клавиатуру с временными слотами
-func GenerateTimeSlots(date time.Time, availableSlots []string) tgbotapi.InlineKeyboardMarkup {
+// labels — подписи кнопок (время в поясе клиента); если nil, подписью служит сам слот
+func GenerateTimeSlots(date time.Time, availableSlots []string, labels []string, lang i18n.Language) tgbotapi.InlineKeyboardMarkup {
 	var rows [][]tgbotapi.InlineKeyboardButton
 
 	// Заголовок
 	dateStr := date.Format("02.01.2006")
-	dayName := russianWeekdayFull(date.Weekday())
+	dayName := weekdayFull(date.Weekday(), lang)
 	headerRow := []tgbotapi.InlineKeyboardButton{
 		tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("📅 %s (%s)", dateStr, dayName), "time_ignore"),
 	}
@@ -137,8 +142,12 @@ func GenerateTimeSlots(date time.Time, availableSlots []string) tgbotapi.InlineK
 		var row []tgbotapi.InlineKeyboardButton
 		for j := i; j < i+3 && j < len(availableSlots); j++ {
 			slot := availableSlots[j]
+			label := slot
+			if j < len(labels) {
+				label = labels[j]
+			}
 			row = append(row, tgbotapi.NewInlineKeyboardButtonData(
-				fmt.Sprintf("🕐 %s", slot),
+				fmt.Sprintf("🕐 %s", label),
 				fmt.Sprintf("time_slot_%s_%s", dateStr, slot),
 			))
 		}
@@ -147,8 +156,8 @@ func GenerateTimeSlots(date time.Time, availableSlots []string) tgbotapi.InlineK
 
 	// Кнопки навигации
 	navRow := []tgbotapi.InlineKeyboardButton{
-		tgbotapi.NewInlineKeyboardButtonData("◀ Назад", "time_back"),
-		tgbotapi.NewInlineKeyboardButtonData("❌ Отмена", "cal_cancel"),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T("btn_back_inline", lang), "time_back"),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T("btn_cancel_inline", lang), "cal_cancel"),
 	}
 	rows = append(rows, navRow)
 
@@ -156,33 +165,47 @@ func GenerateTimeSlots(date time.Time, availableSlots []string) tgbotapi.InlineK
 }
 
 // GenerateConfirmation создаёт клавиатуру подтверждения
-func GenerateConfirmation(date time.Time, timeSlot string) tgbotapi.InlineKeyboardMarkup {
+func GenerateConfirmation(date time.Time, timeSlot string, lang i18n.Language) tgbotapi.InlineKeyboardMarkup {
 	dateStr := date.Format("02.01.2006")
 	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("✅ Подтвердить", fmt.Sprintf("confirm_%s_%s", dateStr, timeSlot)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T("booking_btn_confirm", lang), fmt.Sprintf("confirm_%s_%s", dateStr, timeSlot)),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("◀ Изменить время", fmt.Sprintf("change_time_%s", dateStr)),
-			tgbotapi.NewInlineKeyboardButtonData("◀ Изменить дату", "change_date"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T("booking_btn_change_time", lang), fmt.Sprintf("change_time_%s", dateStr)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T("booking_btn_change_date", lang), "change_date"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("❌ Отмена", "cal_cancel"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T("btn_cancel_inline", lang), "cal_cancel"),
 		),
 	)
 }
 
 // Вспомогательные функции
 
-func russianMonth(m time.Month) string {
-	months := []string{
-		"", "Январь", "Февраль", "Март", "Апрель", "Май", "Июнь",
-		"Июль", "Август", "Сентябрь", "Октябрь", "Ноябрь", "Декабрь",
-	}
-	return months[m]
+var monthKeys = []string{
+	"", "month_january", "month_february", "month_march", "month_april", "month_may", "month_june",
+	"month_july", "month_august", "month_september", "month_october", "month_november", "month_december",
+}
+
+var weekdayKeys = []string{
+	"weekday_sunday", "weekday_monday", "weekday_tuesday",
+	"weekday_wednesday", "weekday_thursday", "weekday_friday", "weekday_saturday",
+}
+
+var weekdayShortKeys = []string{
+	"weekday_short_sun", "weekday_short_mon", "weekday_short_tue",
+	"weekday_short_wed", "weekday_short_thu", "weekday_short_fri", "weekday_short_sat",
+}
+
+func monthName(m time.Month, lang i18n.Language) string {
+	return i18n.T(monthKeys[m], lang)
+}
+
+func weekdayFull(w time.Weekday, lang i18n.Language) string {
+	return i18n.T(weekdayKeys[w], lang)
 }
 
-func russianWeekdayFull(w time.Weekday) string {
-	days := []string{"Воскресенье", "Понедельник", "Вторник", "Среда", "Четверг", "Пятница", "Суббота"}
-	return days[w]
+func weekdayShort(w time.Weekday, lang i18n.Language) string {
+	return i18n.T(weekdayShortKeys[w], lang)
 }
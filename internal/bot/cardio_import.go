@@ -0,0 +1,448 @@
+package bot
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/lib/pq"
+
+	"workbot/internal/cardio"
+	"workbot/internal/generator/progression"
+	"workbot/internal/i18n"
+	"workbot/internal/models"
+)
+
+const (
+	// maxActivityFileSize — Telegram отдаёт ботам файлы до 20 МБ
+	maxActivityFileSize = 20 << 20
+	// cardioMatchDays — запись сопоставляется с тренировкой, запланированной не дальше 3 дней
+	cardioMatchDays = 3
+	// cardioDefaultAge — возраст для максимального пульса, если дата рождения неизвестна
+	cardioDefaultAge = 30
+	// cardioSessionsShown — сколько последних кардиотренировок показывать тренеру
+	cardioSessionsShown = 5
+	// maxSplitsShown — сколько сплитов выводить в сообщении
+	maxSplitsShown = 10
+)
+
+// isActivityFile проверяет, похож ли документ на запись тренировки (GPX/TCX/FIT)
+func isActivityFile(doc *tgbotapi.Document) bool {
+	if doc == nil {
+		return false
+	}
+	_, ok := cardio.FormatFromFilename(doc.FileName)
+	return ok
+}
+
+// cardioZones — пульсовые зоны CardioProgression в ударах в минуту
+func cardioZones(cp *progression.CardioProgression) []cardio.Zone {
+	zones := make([]cardio.Zone, 0, len(progression.HRZoneNames))
+	for _, name := range progression.HRZoneNames {
+		low, high := cp.GetHRZone(name)
+		zones = append(zones, cardio.Zone{Name: name, Low: low, High: high})
+	}
+	return zones
+}
+
+// plannedExercises переводит упражнения тренировки в вид для разбора назначения
+func plannedExercises(exercises []models.WorkoutExercise) []cardio.PlannedExercise {
+	out := make([]cardio.PlannedExercise, 0, len(exercises))
+	for _, e := range exercises {
+		out = append(out, cardio.PlannedExercise{Name: e.ExerciseName, Sets: e.Sets, Reps: e.Reps, Notes: e.Notes})
+	}
+	return out
+}
+
+// matchCardioWorkout выбирает кардиотренировку программы для записи, начатой в start:
+// ближайшую по плановой дате в пределах 3 дней, иначе первую отправленную клиенту,
+// иначе первую ожидающую в текущей неделе. Выполненные тренировки не подходят
+func matchCardioWorkout(workouts []models.Workout, start time.Time) (*models.Workout, cardio.Prescription, bool) {
+	type candidate struct {
+		w *models.Workout
+		p cardio.Prescription
+	}
+	var candidates []candidate
+	currentWeek := 0
+	for i := range workouts {
+		w := &workouts[i]
+		if w.Status != models.WorkoutStatusPending && w.Status != models.WorkoutStatusSent {
+			continue
+		}
+		if currentWeek == 0 || w.WeekNum < currentWeek {
+			currentWeek = w.WeekNum
+		}
+		if p, ok := cardio.ParsePrescription(plannedExercises(w.Exercises)); ok {
+			candidates = append(candidates, candidate{w, p})
+		}
+	}
+
+	var best *candidate
+	bestGap := time.Duration(cardioMatchDays*24) * time.Hour
+	for i := range candidates {
+		c := &candidates[i]
+		if c.w.Date == nil {
+			continue
+		}
+		gap := c.w.Date.Sub(start)
+		if gap < 0 {
+			gap = -gap
+		}
+		if gap <= bestGap {
+			best, bestGap = c, gap
+		}
+	}
+	if best == nil {
+		for i := range candidates {
+			if candidates[i].w.Status == models.WorkoutStatusSent {
+				best = &candidates[i]
+				break
+			}
+		}
+	}
+	if best == nil {
+		for i := range candidates {
+			if candidates[i].w.WeekNum == currentWeek {
+				best = &candidates[i]
+				break
+			}
+		}
+	}
+	if best == nil {
+		return nil, cardio.Prescription{}, false
+	}
+	return best.w, best.p, true
+}
+
+// cardioImport — результат разбора и оценки загруженной тренировки
+type cardioImport struct {
+	Format     cardio.Format
+	Sport      string
+	Summary    cardio.Summary
+	Zones      []cardio.Zone
+	Workout    *models.Workout // nil — не сопоставлена
+	Compliance cardio.Compliance
+	Before     float64
+	After      float64
+	Reason     cardio.AdjustReason // пусто — поправка не менялась
+}
+
+// handleActivityImport разбирает присланный клиентом файл тренировки, сопоставляет
+// его с запланированной кардиотренировкой и сообщает итоги клиенту и тренеру
+func (b *Bot) handleActivityImport(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	doc := message.Document
+
+	client, err := b.repo.Client.GetByTelegramID(chatID)
+	if err != nil || client == nil {
+		b.sendMessage(chatID, b.t("cardio_not_client", chatID))
+		return
+	}
+	if doc.FileSize > maxActivityFileSize {
+		b.sendMessage(chatID, b.t("cardio_file_too_large", chatID))
+		return
+	}
+	data, err := b.downloadFile(doc.FileID, maxActivityFileSize)
+	if err != nil {
+		b.sendError(chatID, b.t("import_download_error", chatID), err)
+		return
+	}
+
+	format, _ := cardio.FormatFromFilename(doc.FileName)
+	activity, err := cardio.Parse(data, format)
+	if err != nil {
+		b.sendMessage(chatID, b.tf("cardio_parse_error", chatID, doc.FileName, err))
+		return
+	}
+
+	age := b.clientAge(client.ID)
+	if age == 0 {
+		age = cardioDefaultAge
+	}
+	cp := progression.NewCardioProgression("", "", age)
+	imp := cardioImport{Format: format, Sport: activity.Sport, Zones: cardioZones(cp)}
+	imp.Summary = cardio.Analyze(activity, imp.Zones)
+	imp.Before = b.loadCardioFactor(client.ID)
+	imp.After = imp.Before
+
+	var prescription cardio.Prescription
+	if program, err := b.repo.Program.GetActiveProgram(client.ID); err == nil && program != nil {
+		if workouts, err := b.repo.Program.GetWorkoutsByProgram(program.ID); err == nil {
+			var ok bool
+			if imp.Workout, prescription, ok = matchCardioWorkout(workouts, imp.Summary.Start); !ok {
+				imp.Workout = nil
+			}
+		}
+	}
+	if imp.Workout != nil {
+		imp.Compliance = cardio.Evaluate(imp.Summary, prescription, cp.MaxHR)
+		if imp.Compliance.Rated {
+			imp.After, imp.Reason = cardio.NextVolumeFactor(imp.Before, imp.Compliance)
+		}
+	}
+
+	inserted, err := b.saveCardioSession(client.ID, imp)
+	if err != nil {
+		b.sendError(chatID, b.t("error", chatID), err)
+		return
+	}
+	if !inserted {
+		b.sendMessage(chatID, b.t("cardio_duplicate", chatID))
+		return
+	}
+
+	if imp.Workout != nil {
+		feedback := i18n.Tf("cardio_feedback", i18n.DefaultLang, strings.ToUpper(string(format)), formatClock(imp.Summary.Duration))
+		if err := b.repo.Program.MarkWorkoutCompleted(imp.Workout.ID, feedback, 0); err != nil {
+			log.Printf("Ошибка завершения кардиотренировки: %v", err)
+		}
+	}
+
+	msg := tgbotapi.NewMessage(chatID, b.formatCardioImport(chatID, imp, false))
+	msg.ParseMode = "Markdown"
+	if _, err := b.api.Send(msg); err != nil {
+		log.Printf("Ошибка отправки итогов кардио: %v", err)
+	}
+	b.notifyTrainerCardio(client.ID, imp)
+}
+
+// loadCardioFactor — текущая поправка объёма кардио клиента; 1.0 — поправок не было
+func (b *Bot) loadCardioFactor(clientID int) float64 {
+	var factor float64
+	err := b.db.QueryRow(`
+		SELECT factor_after FROM public.cardio_sessions
+		WHERE client_id = $1
+		ORDER BY created_at DESC, id DESC LIMIT 1`, clientID).Scan(&factor)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("Ошибка чтения поправки кардио: %v", err)
+		}
+		return 1.0
+	}
+	return factor
+}
+
+// cardioSplit — сплит в JSON журнала
+type cardioSplit struct {
+	Km        int     `json:"km"`
+	Distance  float64 `json:"distance_m"`
+	MovingSec int     `json:"moving_sec"`
+	AvgHR     int     `json:"avg_hr,omitempty"`
+}
+
+// saveCardioSession записывает тренировку; inserted = false — эта запись уже загружена
+func (b *Bot) saveCardioSession(clientID int, imp cardioImport) (bool, error) {
+	s := imp.Summary
+	splits := make([]cardioSplit, 0, len(s.Splits))
+	for _, sp := range s.Splits {
+		splits = append(splits, cardioSplit{Km: sp.Num, Distance: math.Round(sp.Distance), MovingSec: int(sp.Moving.Seconds()), AvgHR: sp.AvgHR})
+	}
+	splitsJSON, err := json.Marshal(splits)
+	if err != nil {
+		return false, err
+	}
+
+	var workoutID, compliance, volume, inZone, paceInRange, reason interface{}
+	if imp.Workout != nil {
+		workoutID = imp.Workout.ID
+	}
+	if c := imp.Compliance; c.Rated {
+		compliance = c.Score
+		if c.Volume > 0 {
+			volume = math.Round(math.Min(c.Volume, 9.99)*100) / 100
+		}
+		if c.HasHR {
+			inZone = int(math.Round(c.InZone * 100))
+		}
+		if c.HasPace {
+			paceInRange = int(math.Round(c.PaceInRange * 100))
+		}
+	}
+	if imp.Reason != "" {
+		reason = string(imp.Reason)
+	}
+
+	var id int
+	err = b.db.QueryRow(`
+		INSERT INTO public.cardio_sessions
+			(client_id, workout_id, source_format, sport, started_at, duration_sec, moving_sec, distance_m,
+			 avg_hr, max_hr, splits, zone_seconds, compliance, volume_ratio, in_zone_pct, pace_in_range_pct,
+			 factor_before, factor_after, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, 0), NULLIF($10, 0), $11, $12, $13, $14, $15, $16, $17, $18, $19)
+		ON CONFLICT (client_id, started_at) DO NOTHING
+		RETURNING id`,
+		clientID, workoutID, string(imp.Format), truncateString(imp.Sport, 30), s.Start, int(s.Duration.Seconds()),
+		int(s.Moving.Seconds()), math.Round(s.Distance*10)/10, s.AvgHR, s.MaxHR, splitsJSON,
+		pq.Array(s.ZoneSeconds), compliance, volume, inZone, paceInRange, imp.Before, imp.After, reason).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// formatClock форматирует длительность как ч:мм:сс или м:сс
+func formatClock(d time.Duration) string {
+	sec := int(d.Round(time.Second).Seconds())
+	if sec >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", sec/3600, sec%3600/60, sec%60)
+	}
+	return formatRestDuration(sec)
+}
+
+// formatCardioImport — итоги загруженной тренировки: объём, пульс, зоны, сплиты
+// и соответствие назначению; forTrainer добавляет поправку объёма
+func (b *Bot) formatCardioImport(chatID int64, imp cardioImport, forTrainer bool) string {
+	s := imp.Summary
+	var text strings.Builder
+
+	text.WriteString(b.tf("cardio_summary_main", chatID,
+		formatClock(s.Duration), formatClock(s.Moving), s.Distance/1000) + "\n")
+	if pace := s.Pace(); pace > 0 {
+		text.WriteString(b.tf("cardio_summary_pace", chatID, formatRestDuration(int(pace.Seconds()))) + "\n")
+	}
+	if s.HasHR() {
+		text.WriteString(b.tf("cardio_summary_hr", chatID, s.AvgHR, s.MaxHR) + "\n")
+		var zones []string
+		for i, z := range imp.Zones {
+			if sec := s.ZoneSeconds[i]; sec >= 60 {
+				zones = append(zones, b.tf("cardio_zone_time", chatID, b.t("cardio_zone_"+z.Name, chatID), sec/60))
+			}
+		}
+		if len(zones) > 0 {
+			text.WriteString(b.tf("cardio_summary_zones", chatID, strings.Join(zones, " · ")) + "\n")
+		}
+	}
+	if len(s.Splits) > 0 {
+		var splits []string
+		for i, sp := range s.Splits {
+			if i == maxSplitsShown {
+				splits = append(splits, "…")
+				break
+			}
+			splits = append(splits, fmt.Sprintf("%d: %s", sp.Num, formatRestDuration(int(sp.Pace().Seconds()))))
+		}
+		text.WriteString(b.tf("cardio_summary_splits", chatID, strings.Join(splits, " · ")) + "\n")
+	}
+
+	text.WriteString("\n")
+	if imp.Workout == nil {
+		text.WriteString(b.t("cardio_not_matched", chatID))
+		return text.String()
+	}
+	text.WriteString(b.tf("cardio_matched", chatID, imp.Workout.Name, imp.Workout.WeekNum, imp.Workout.DayNum) + "\n")
+
+	c := imp.Compliance
+	if !c.Rated {
+		text.WriteString(b.t("cardio_compliance_none", chatID))
+		return text.String()
+	}
+	var parts []string
+	if c.Volume > 0 {
+		parts = append(parts, b.tf("cardio_compliance_volume", chatID, int(math.Round(c.Volume*100))))
+	}
+	if c.HasHR {
+		parts = append(parts, b.tf("cardio_compliance_hr", chatID,
+			int(math.Round(c.InZone*100)), int(math.Round(c.BelowZone*100)), int(math.Round(c.AboveZone*100))))
+	}
+	if c.HasPace {
+		parts = append(parts, b.tf("cardio_compliance_pace", chatID, int(math.Round(c.PaceInRange*100))))
+	}
+	text.WriteString(b.tf("cardio_compliance", chatID, c.Score, strings.Join(parts, "; ")))
+
+	if forTrainer && imp.Reason != "" {
+		text.WriteString("\n" + b.tf("cardio_factor_change", chatID, imp.Before, imp.After,
+			b.t("cardio_reason_"+string(imp.Reason), chatID)))
+	}
+	return text.String()
+}
+
+// notifyTrainerCardio отправляет тренеру итоги загруженной кардиотренировки
+func (b *Bot) notifyTrainerCardio(clientID int, imp cardioImport) {
+	trainerID, err := b.repo.Admin.GetFirst()
+	if err != nil {
+		log.Printf("Ошибка получения тренера: %v", err)
+		return
+	}
+	client, _ := b.repo.Client.GetByID(clientID)
+	if client == nil {
+		return
+	}
+
+	text := b.tf("cardio_trainer_title", trainerID, client.Name, client.Surname, strings.ToUpper(string(imp.Format))) +
+		"\n\n" + b.formatCardioImport(trainerID, imp, true)
+	msg := tgbotapi.NewMessage(trainerID, text)
+	msg.ParseMode = "Markdown"
+	if err := b.outbox.Enqueue(msg); err != nil {
+		log.Printf("Ошибка постановки уведомления о кардио в очередь: %v", err)
+	}
+}
+
+// showCardioSessions показывает тренеру последние загруженные кардиотренировки клиента
+func (b *Bot) showCardioSessions(chatID int64, clientID, messageID int) {
+	rows, err := b.db.Query(`
+		SELECT cs.started_at, cs.duration_sec, cs.distance_m, COALESCE(cs.avg_hr, 0),
+		       cs.compliance, COALESCE(w.name, ''), cs.factor_after
+		FROM public.cardio_sessions cs
+		LEFT JOIN public.program_workouts w ON w.id = cs.workout_id
+		WHERE cs.client_id = $1
+		ORDER BY cs.started_at DESC
+		LIMIT $2`, clientID, cardioSessionsShown)
+	if err != nil {
+		b.sendError(chatID, b.t("error", chatID), err)
+		return
+	}
+	defer rows.Close()
+
+	loc := b.userLocation(chatID)
+	var text strings.Builder
+	text.WriteString(b.t("cardio_view_title", chatID) + "\n")
+	factor, count := 1.0, 0
+	for rows.Next() {
+		var started time.Time
+		var duration, avgHR int
+		var distance float64
+		var compliance sql.NullInt64
+		var workout string
+		var after float64
+		if err := rows.Scan(&started, &duration, &distance, &avgHR, &compliance, &workout, &after); err != nil {
+			b.sendError(chatID, b.t("error", chatID), err)
+			return
+		}
+		if count == 0 {
+			factor = after
+		}
+		count++
+
+		text.WriteString("\n" + b.tf("cardio_view_row", chatID, started.In(loc).Format("02.01 15:04"),
+			formatClock(time.Duration(duration)*time.Second), distance/1000))
+		if avgHR > 0 {
+			text.WriteString(" · " + b.tf("cardio_view_hr", chatID, avgHR))
+		}
+		if compliance.Valid {
+			text.WriteString("\n   " + b.tf("cardio_view_compliance", chatID, compliance.Int64, workout))
+		} else if workout == "" {
+			text.WriteString("\n   " + b.t("cardio_view_unmatched", chatID))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		b.sendError(chatID, b.t("error", chatID), err)
+		return
+	}
+
+	if count == 0 {
+		text.WriteString("\n" + b.t("cardio_view_empty", chatID))
+	} else {
+		text.WriteString("\n\n" + b.tf("cardio_view_factor", chatID, factor))
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("workout_btn_back_progress", chatID), fmt.Sprintf("prog_back_%d", clientID)),
+	))
+	b.editMessage(chatID, messageID, text.String(), &keyboard)
+}
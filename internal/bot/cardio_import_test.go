@@ -0,0 +1,63 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"workbot/internal/models"
+)
+
+func TestMatchCardioWorkout(t *testing.T) {
+	day := func(d int) *time.Time {
+		t := time.Date(2026, 5, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	liss := []models.WorkoutExercise{{ExerciseName: "LISS Кардио", Sets: 1, Reps: "30 мин", Notes: "Пульс: 65% от максимума"}}
+	strength := []models.WorkoutExercise{
+		{ExerciseName: "Присед", Sets: 4, Reps: "8"},
+		{ExerciseName: "Жим лёжа", Sets: 4, Reps: "8"},
+	}
+
+	workouts := []models.Workout{
+		{ID: 1, WeekNum: 1, Status: models.WorkoutStatusCompleted, Date: day(4), Exercises: liss},
+		{ID: 2, WeekNum: 2, Status: models.WorkoutStatusSent, Date: day(11), Exercises: strength},
+		{ID: 3, WeekNum: 2, Status: models.WorkoutStatusPending, Date: day(13), Exercises: liss},
+		{ID: 4, WeekNum: 2, Status: models.WorkoutStatusSent, Date: day(20), Exercises: liss},
+		{ID: 5, WeekNum: 3, Status: models.WorkoutStatusPending, Date: day(22), Exercises: liss},
+	}
+
+	tests := []struct {
+		name  string
+		start time.Time
+		want  int
+	}{
+		{"ближайшая по дате", time.Date(2026, 5, 12, 18, 0, 0, 0, time.UTC), 3},
+		{"выполненная не подходит", time.Date(2026, 5, 4, 8, 0, 0, 0, time.UTC), 4},
+		{"вне окна — отправленная", time.Date(2026, 5, 30, 8, 0, 0, 0, time.UTC), 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, p, ok := matchCardioWorkout(workouts, tt.start)
+			if !ok || w.ID != tt.want {
+				t.Fatalf("matchCardioWorkout = %+v, %v; want тренировка %d", w, ok, tt.want)
+			}
+			if p.Duration != 30*time.Minute {
+				t.Errorf("Duration = %v, want 30m", p.Duration)
+			}
+		})
+	}
+
+	// Без отправленных и без дат — первая кардиотренировка текущей недели
+	undated := []models.Workout{
+		{ID: 6, WeekNum: 2, Status: models.WorkoutStatusPending, Exercises: strength},
+		{ID: 7, WeekNum: 2, Status: models.WorkoutStatusPending, Exercises: liss},
+		{ID: 8, WeekNum: 3, Status: models.WorkoutStatusPending, Exercises: liss},
+	}
+	if w, _, ok := matchCardioWorkout(undated, time.Now()); !ok || w.ID != 7 {
+		t.Errorf("matchCardioWorkout без дат = %+v, %v; want 7", w, ok)
+	}
+
+	if _, _, ok := matchCardioWorkout(workouts[:2], time.Now()); ok {
+		t.Error("matchCardioWorkout без кардиотренировок: ok = true")
+	}
+}
@@ -0,0 +1,440 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"workbot/internal/calendar"
+	"workbot/internal/i18n"
+	"workbot/internal/repository"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	// allClientsGroupID виртуальная группа со всеми активными клиентами
+	allClientsGroupID = 0
+	// groupMembersPageSize клиентов на странице выбора состава группы
+	groupMembersPageSize = 15
+)
+
+const (
+	stateGroupNewName     = "group_new_name"
+	stateGroupRemind      = "group_remind_"       // + groupID
+	stateGroupShiftDate   = "group_shift_date_"   // + groupID
+	stateGroupShiftOffset = "group_shift_offset_" // + groupID_ГГГГ-ММ-ДД
+)
+
+// handleGroupsMenu показывает список групп клиентов
+func (b *Bot) handleGroupsMenu(chatID int64, messageID int) {
+	groups, err := b.repo.Group.GetByTrainer(chatID)
+	if err != nil {
+		b.sendError(chatID, b.t("groups_load_error", chatID), err)
+		return
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("groups_btn_all_clients", chatID), fmt.Sprintf("grp_view_%d", allClientsGroupID)),
+	))
+	for _, g := range groups {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("🏷 %s (%d)", g.Name, g.MemberCount),
+				fmt.Sprintf("grp_view_%d", g.ID),
+			),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("groups_btn_new", chatID), "grp_new"),
+	))
+
+	text := b.t("groups_menu_text", chatID)
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	if messageID > 0 {
+		b.editMessage(chatID, messageID, text, &keyboard)
+		return
+	}
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = "Markdown"
+	msg.ReplyMarkup = keyboard
+	b.api.Send(msg)
+}
+
+// loadGroupMembers возвращает название и клиентов группы (0 — все клиенты, название на языке chatID)
+func (b *Bot) loadGroupMembers(groupID int, chatID int64) (string, []repository.GroupMember, error) {
+	if groupID == allClientsGroupID {
+		members, err := b.repo.Group.GetAllClientsAsMembers()
+		return b.t("groups_all_clients", chatID), members, err
+	}
+
+	group, err := b.repo.Group.GetByID(groupID)
+	if err != nil {
+		return "", nil, err
+	}
+	if group == nil {
+		return "", nil, fmt.Errorf("группа %d не найдена", groupID)
+	}
+	members, err := b.repo.Group.GetMembers(groupID)
+	return group.Name, members, err
+}
+
+// showGroup показывает состав группы и массовые действия
+func (b *Bot) showGroup(chatID int64, groupID int, messageID int) {
+	name, members, err := b.loadGroupMembers(groupID, chatID)
+	if err != nil {
+		b.sendError(chatID, b.t("groups_not_found", chatID), err)
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("🏷 *%s*\n", name))
+	text.WriteString(b.tf("groups_members_count", chatID, len(members)) + "\n")
+	if groupID != allClientsGroupID {
+		text.WriteString("\n")
+		for _, m := range members {
+			mark := ""
+			if m.TelegramID == 0 {
+				mark = " " + b.t("groups_no_telegram", chatID)
+			}
+			text.WriteString(fmt.Sprintf("• %s%s\n", m.FullName(), mark))
+		}
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if len(members) > 0 {
+		rows = append(rows,
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(b.t("groups_btn_send", chatID), fmt.Sprintf("grp_send_%d", groupID)),
+			),
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(b.t("groups_btn_shift", chatID), fmt.Sprintf("grp_shift_%d", groupID)),
+				tgbotapi.NewInlineKeyboardButtonData(b.t("groups_btn_remind", chatID), fmt.Sprintf("grp_remind_%d", groupID)),
+			),
+		)
+	}
+	if groupID != allClientsGroupID {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("groups_btn_members", chatID), fmt.Sprintf("grp_members_%d_0", groupID)),
+			tgbotapi.NewInlineKeyboardButtonData(b.t("groups_btn_delete", chatID), fmt.Sprintf("grp_delete_%d", groupID)),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("groups_btn_back", chatID), "grp_list"),
+	))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	b.editMessage(chatID, messageID, text.String(), &keyboard)
+}
+
+// showGroupMembersEditor показывает клиентов с отметкой участия в группе
+func (b *Bot) showGroupMembersEditor(chatID int64, groupID, page, messageID int) {
+	group, err := b.repo.Group.GetByID(groupID)
+	if err != nil || group == nil {
+		b.sendError(chatID, b.t("groups_not_found", chatID), err)
+		return
+	}
+	clients, err := b.repo.Client.GetAllActive()
+	if err != nil {
+		b.sendError(chatID, b.t("admin_clients_load_error", chatID), err)
+		return
+	}
+	members, err := b.repo.Group.GetMembers(groupID)
+	if err != nil {
+		b.sendError(chatID, b.t("groups_load_group_error", chatID), err)
+		return
+	}
+	inGroup := make(map[int]bool, len(members))
+	for _, m := range members {
+		inGroup[m.ClientID] = true
+	}
+
+	pages := (len(clients) + groupMembersPageSize - 1) / groupMembersPageSize
+	if page >= pages {
+		page = pages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	from := page * groupMembersPageSize
+	to := from + groupMembersPageSize
+	if to > len(clients) {
+		to = len(clients)
+	}
+	for _, c := range clients[from:to] {
+		mark := "⬜"
+		if inGroup[c.ID] {
+			mark = "✅"
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("%s %s %s", mark, c.Name, c.Surname),
+				fmt.Sprintf("grp_toggle_%d_%d_%d", groupID, c.ID, page),
+			),
+		))
+	}
+
+	var navRow []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		navRow = append(navRow, tgbotapi.NewInlineKeyboardButtonData("⬅️", fmt.Sprintf("grp_members_%d_%d", groupID, page-1)))
+	}
+	if page < pages-1 {
+		navRow = append(navRow, tgbotapi.NewInlineKeyboardButtonData("➡️", fmt.Sprintf("grp_members_%d_%d", groupID, page+1)))
+	}
+	if len(navRow) > 0 {
+		rows = append(rows, navRow)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("groups_btn_done", chatID), fmt.Sprintf("grp_view_%d", groupID)),
+	))
+
+	text := b.tf("groups_members_editor", chatID, group.Name)
+	if pages > 1 {
+		text += " " + b.tf("groups_page", chatID, page+1, pages)
+	}
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	b.editMessage(chatID, messageID, text, &keyboard)
+}
+
+// toggleGroupMember добавляет клиента в группу или убирает из неё
+func (b *Bot) toggleGroupMember(groupID, clientID int) error {
+	isMember, err := b.repo.Group.IsMember(groupID, clientID)
+	if err != nil {
+		return err
+	}
+	if isMember {
+		return b.repo.Group.RemoveMember(groupID, clientID)
+	}
+	return b.repo.Group.AddMember(groupID, clientID)
+}
+
+// handleGroupCallback обрабатывает inline-кнопки групп клиентов
+func (b *Bot) handleGroupCallback(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	data := callback.Data
+
+	if !b.isAdmin(chatID) {
+		return
+	}
+
+	switch {
+	case data == "grp_list":
+		b.handleGroupsMenu(chatID, messageID)
+
+	case data == "grp_new":
+		setState(chatID, stateGroupNewName)
+		b.sendMessageWithKeyboard(chatID, b.t("groups_enter_name", chatID), b.createCancelKeyboard(chatID))
+
+	case strings.HasPrefix(data, "grp_view_"):
+		groupID, _ := strconv.Atoi(strings.TrimPrefix(data, "grp_view_"))
+		b.showGroup(chatID, groupID, messageID)
+
+	case strings.HasPrefix(data, "grp_members_"):
+		parts := strings.Split(strings.TrimPrefix(data, "grp_members_"), "_")
+		if len(parts) != 2 {
+			return
+		}
+		groupID, _ := strconv.Atoi(parts[0])
+		page, _ := strconv.Atoi(parts[1])
+		b.showGroupMembersEditor(chatID, groupID, page, messageID)
+
+	case strings.HasPrefix(data, "grp_toggle_"):
+		parts := strings.Split(strings.TrimPrefix(data, "grp_toggle_"), "_")
+		if len(parts) != 3 {
+			return
+		}
+		groupID, _ := strconv.Atoi(parts[0])
+		clientID, _ := strconv.Atoi(parts[1])
+		page, _ := strconv.Atoi(parts[2])
+		if err := b.toggleGroupMember(groupID, clientID); err != nil {
+			log.Printf("Ошибка изменения состава группы: %v", err)
+		}
+		b.showGroupMembersEditor(chatID, groupID, page, messageID)
+
+	case strings.HasPrefix(data, "grp_send_"):
+		groupID, _ := strconv.Atoi(strings.TrimPrefix(data, "grp_send_"))
+		name, members, err := b.loadGroupMembers(groupID, chatID)
+		if err != nil {
+			b.sendError(chatID, b.t("groups_not_found", chatID), err)
+			return
+		}
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(b.t("groups_btn_send_confirm", chatID), fmt.Sprintf("grp_sendok_%d", groupID)),
+				tgbotapi.NewInlineKeyboardButtonData(b.t("btn_cancel_inline", chatID), fmt.Sprintf("grp_view_%d", groupID)),
+			),
+		)
+		b.editMessage(chatID, messageID,
+			b.tf("groups_send_confirm", chatID, name, len(members)),
+			&keyboard)
+
+	case strings.HasPrefix(data, "grp_sendok_"):
+		groupID, _ := strconv.Atoi(strings.TrimPrefix(data, "grp_sendok_"))
+		name, members, err := b.loadGroupMembers(groupID, chatID)
+		if err != nil {
+			b.sendError(chatID, b.t("groups_not_found", chatID), err)
+			return
+		}
+		b.editMessage(chatID, messageID, b.tf("groups_sending", chatID, name, len(members)), nil)
+		go b.bulkSendNextWorkout(chatID, b.tf("groups_title_workouts", chatID, name), members)
+
+	case strings.HasPrefix(data, "grp_remind_"):
+		groupID, _ := strconv.Atoi(strings.TrimPrefix(data, "grp_remind_"))
+		setState(chatID, stateGroupRemind+strconv.Itoa(groupID))
+		b.sendMessageWithKeyboard(chatID, b.t("groups_enter_reminder", chatID), b.createCancelKeyboard(chatID))
+
+	case strings.HasPrefix(data, "grp_shift_"):
+		groupID, _ := strconv.Atoi(strings.TrimPrefix(data, "grp_shift_"))
+		setState(chatID, stateGroupShiftDate+strconv.Itoa(groupID))
+		b.sendMessageWithKeyboard(chatID, b.t("groups_enter_shift_date", chatID), b.createCancelKeyboard(chatID))
+
+	case strings.HasPrefix(data, "grp_shiftok_"):
+		// grp_shiftok_<groupID>_<ГГГГММДД>_<сдвиг в минутах>
+		parts := strings.Split(strings.TrimPrefix(data, "grp_shiftok_"), "_")
+		if len(parts) != 3 {
+			return
+		}
+		groupID, _ := strconv.Atoi(parts[0])
+		date, err := time.Parse("20060102", parts[1])
+		if err != nil {
+			return
+		}
+		minutes, _ := strconv.Atoi(parts[2])
+		_, members, err := b.loadGroupMembers(groupID, chatID)
+		if err != nil {
+			b.sendError(chatID, b.t("groups_not_found", chatID), err)
+			return
+		}
+		b.editMessage(chatID, messageID, b.t("groups_rescheduling", chatID), nil)
+		go b.applyReschedule(chatID, members, date, time.Duration(minutes)*time.Minute)
+
+	case strings.HasPrefix(data, "grp_delete_"):
+		groupID, _ := strconv.Atoi(strings.TrimPrefix(data, "grp_delete_"))
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(b.t("groups_btn_delete_confirm", chatID), fmt.Sprintf("grp_delok_%d", groupID)),
+				tgbotapi.NewInlineKeyboardButtonData(b.t("btn_cancel_inline", chatID), fmt.Sprintf("grp_view_%d", groupID)),
+			),
+		)
+		b.editMessage(chatID, messageID, b.t("groups_delete_confirm", chatID), &keyboard)
+
+	case strings.HasPrefix(data, "grp_delok_"):
+		groupID, _ := strconv.Atoi(strings.TrimPrefix(data, "grp_delok_"))
+		if err := b.repo.Group.Delete(groupID); err != nil {
+			b.sendError(chatID, b.t("groups_delete_error", chatID), err)
+			return
+		}
+		b.handleGroupsMenu(chatID, messageID)
+	}
+}
+
+// handleGroupState обрабатывает текстовый ввод в сценариях групп
+func (b *Bot) handleGroupState(message *tgbotapi.Message, state string) {
+	chatID := message.Chat.ID
+	text := strings.TrimSpace(message.Text)
+
+	if i18n.Is(text, "cancel") {
+		b.handleAdminCancel(message)
+		return
+	}
+
+	switch {
+	case state == stateGroupNewName:
+		if text == "" || len([]rune(text)) > 100 {
+			b.sendMessage(chatID, b.t("groups_name_length", chatID))
+			return
+		}
+		groupID, err := b.repo.Group.Create(chatID, text)
+		if err != nil {
+			b.sendError(chatID, b.t("groups_create_error", chatID), err)
+			return
+		}
+		clearState(chatID)
+		b.handleAdminStart(message)
+		msg := tgbotapi.NewMessage(chatID, b.tf("groups_created", chatID, text))
+		sent, err := b.api.Send(msg)
+		if err == nil {
+			b.showGroupMembersEditor(chatID, groupID, 0, sent.MessageID)
+		}
+
+	case strings.HasPrefix(state, stateGroupRemind):
+		groupID, _ := strconv.Atoi(strings.TrimPrefix(state, stateGroupRemind))
+		name, members, err := b.loadGroupMembers(groupID, chatID)
+		if err != nil {
+			b.sendError(chatID, b.t("groups_not_found", chatID), err)
+			return
+		}
+		clearState(chatID)
+		b.handleAdminStart(message)
+		b.sendMessage(chatID, b.tf("groups_reminding", chatID, name, len(members)))
+		go b.bulkSendReminder(chatID, b.tf("groups_title_reminder", chatID, name), members, text)
+
+	case strings.HasPrefix(state, stateGroupShiftDate):
+		groupID, _ := strconv.Atoi(strings.TrimPrefix(state, stateGroupShiftDate))
+		date, err := calendar.ParseDate(text)
+		if err != nil {
+			b.sendMessage(chatID, b.t("validation_date_invalid", chatID))
+			return
+		}
+		setState(chatID, fmt.Sprintf("%s%d_%s", stateGroupShiftOffset, groupID, date.Format("2006-01-02")))
+		b.sendMessage(chatID, b.t("groups_enter_shift_offset", chatID))
+
+	case strings.HasPrefix(state, stateGroupShiftOffset):
+		parts := strings.SplitN(strings.TrimPrefix(state, stateGroupShiftOffset), "_", 2)
+		if len(parts) != 2 {
+			clearState(chatID)
+			return
+		}
+		groupID, _ := strconv.Atoi(parts[0])
+		date, _ := time.Parse("2006-01-02", parts[1])
+
+		offset, err := parseShiftOffset(text)
+		if err != nil {
+			b.sendMessage(chatID, b.errorText(err, chatID))
+			return
+		}
+
+		_, members, err := b.loadGroupMembers(groupID, chatID)
+		if err != nil {
+			b.sendError(chatID, b.t("groups_not_found", chatID), err)
+			return
+		}
+		moves, conflicts, err := b.loadReschedulePlan(members, date, offset)
+		if err != nil {
+			b.sendError(chatID, b.t("bulk_appointments_load_error", chatID), err)
+			return
+		}
+
+		clearState(chatID)
+		b.handleAdminStart(message)
+
+		if len(moves) == 0 && len(conflicts) == 0 {
+			b.sendMessage(chatID, b.tf("groups_no_appointments", chatID, date.Format("02.01.2006")))
+			return
+		}
+
+		lang := b.getLanguage(chatID)
+		preview := formatReschedulePlan(
+			i18n.Tf("groups_shift_preview", lang, date.Format("02.01.2006"), formatShiftOffset(offset, lang)),
+			moves, conflicts, lang)
+		msg := tgbotapi.NewMessage(chatID, preview)
+		if len(moves) > 0 {
+			msg.Text += "\n" + b.t("groups_shift_confirm", chatID)
+			msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+				tgbotapi.NewInlineKeyboardRow(
+					tgbotapi.NewInlineKeyboardButtonData(b.t("groups_btn_shift_confirm", chatID),
+						fmt.Sprintf("grp_shiftok_%d_%s_%d", groupID, date.Format("20060102"), int(offset/time.Minute))),
+					tgbotapi.NewInlineKeyboardButtonData(b.t("btn_cancel_inline", chatID), fmt.Sprintf("grp_view_%d", groupID)),
+				),
+			)
+		}
+		b.api.Send(msg)
+	}
+}
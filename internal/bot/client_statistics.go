@@ -6,6 +6,9 @@ import (
 	"strings"
 	"time"
 
+	"workbot/internal/billing"
+	"workbot/internal/i18n"
+
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
@@ -29,19 +32,22 @@ func (b *Bot) handleStatisticsMenu(message *tgbotapi.Message) {
 
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("📊 Общая статистика"),
-			tgbotapi.NewKeyboardButton("👥 Топ активных"),
+			tgbotapi.NewKeyboardButton(b.t("stats_general", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("stats_top_active", chatID)),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(b.t("stats_inactive", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("stats_by_period", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("📉 Неактивные клиенты"),
-			tgbotapi.NewKeyboardButton("📅 За период"),
+			tgbotapi.NewKeyboardButton(b.t("stats_btn_revenue", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Назад"),
+			tgbotapi.NewKeyboardButton(b.t("back", chatID)),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, "📊 *Статистика клиентов*\n\nВыберите тип отчёта:")
+	msg := tgbotapi.NewMessage(chatID, b.t("stats_menu_title", chatID))
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 	b.api.Send(msg)
@@ -93,21 +99,21 @@ func (b *Bot) handleGeneralStatistics(chatID int64) {
 	}
 
 	var message strings.Builder
-	message.WriteString("📊 *Общая статистика*\n\n")
+	message.WriteString(b.t("stats_general_title", chatID) + "\n\n")
 
-	message.WriteString("👥 *Клиенты:*\n")
-	message.WriteString(fmt.Sprintf("  • Всего: %d\n", totalClients))
-	message.WriteString(fmt.Sprintf("  • Активных (30 дней): %d\n\n", activeClients))
+	message.WriteString(b.t("stats_clients_header", chatID) + "\n")
+	message.WriteString(b.tf("stats_total", chatID, totalClients) + "\n")
+	message.WriteString(b.tf("stats_active_30", chatID, activeClients) + "\n\n")
 
-	message.WriteString("🏋️ *Тренировки:*\n")
-	message.WriteString(fmt.Sprintf("  • Всего записей: %d\n", totalTrainings))
-	message.WriteString(fmt.Sprintf("  • Завершено: %d\n", completedTrainings))
-	message.WriteString(fmt.Sprintf("  • Отменено: %d\n", cancelledTrainings))
-	message.WriteString(fmt.Sprintf("  • Посещаемость: %.1f%%\n\n", attendanceRate))
+	message.WriteString(b.t("stats_trainings_header", chatID) + "\n")
+	message.WriteString(b.tf("stats_total_appointments", chatID, totalTrainings) + "\n")
+	message.WriteString(b.tf("stats_completed", chatID, completedTrainings) + "\n")
+	message.WriteString(b.tf("stats_cancelled", chatID, cancelledTrainings) + "\n")
+	message.WriteString(b.tf("stats_attendance", chatID, attendanceRate) + "\n\n")
 
-	message.WriteString("📅 *Период:*\n")
-	message.WriteString(fmt.Sprintf("  • Эта неделя: %d тренировок\n", weekTrainings))
-	message.WriteString(fmt.Sprintf("  • Этот месяц: %d тренировок\n", monthTrainings))
+	message.WriteString(b.t("stats_period_header", chatID) + "\n")
+	message.WriteString(b.tf("stats_this_week", chatID, b.tn("trainings", chatID, weekTrainings)) + "\n")
+	message.WriteString(b.tf("stats_this_month", chatID, b.tn("trainings", chatID, monthTrainings)) + "\n")
 
 	msg := tgbotapi.NewMessage(chatID, message.String())
 	msg.ParseMode = "Markdown"
@@ -131,13 +137,13 @@ func (b *Bot) handleTopActiveClients(chatID int64) {
 	`)
 	if err != nil {
 		log.Printf("Ошибка получения топа клиентов: %v", err)
-		b.sendMessage(chatID, "Ошибка загрузки статистики")
+		b.sendMessage(chatID, b.t("stats_load_error", chatID))
 		return
 	}
 	defer rows.Close()
 
 	var message strings.Builder
-	message.WriteString("🏆 *Топ-10 активных клиентов*\n\n")
+	message.WriteString(b.t("stats_top_title", chatID) + "\n\n")
 
 	rank := 1
 	for rows.Next() {
@@ -169,13 +175,13 @@ func (b *Bot) handleTopActiveClients(chatID int64) {
 		}
 
 		message.WriteString(fmt.Sprintf("%s *%s %s*\n", medal, name, surname))
-		message.WriteString(fmt.Sprintf("   📈 %d тренировок | Последняя: %s\n\n", completed, lastDateStr))
+		message.WriteString(b.tf("stats_top_item", chatID, b.tn("trainings", chatID, completed), lastDateStr) + "\n\n")
 
 		rank++
 	}
 
 	if rank == 1 {
-		message.WriteString("Пока нет данных о тренировках клиентов")
+		message.WriteString(b.t("stats_no_data", chatID))
 	}
 
 	msg := tgbotapi.NewMessage(chatID, message.String())
@@ -200,14 +206,13 @@ func (b *Bot) handleInactiveClients(chatID int64) {
 	`)
 	if err != nil {
 		log.Printf("Ошибка получения неактивных клиентов: %v", err)
-		b.sendMessage(chatID, "Ошибка загрузки статистики")
+		b.sendMessage(chatID, b.t("stats_load_error", chatID))
 		return
 	}
 	defer rows.Close()
 
 	var message strings.Builder
-	message.WriteString("📉 *Неактивные клиенты*\n")
-	message.WriteString("_(более 14 дней без тренировок)_\n\n")
+	message.WriteString(b.t("stats_inactive_title", chatID) + "\n\n")
 
 	count := 0
 	for rows.Next() {
@@ -222,21 +227,21 @@ func (b *Bot) handleInactiveClients(chatID int64) {
 
 		count++
 
-		inactiveStr := "никогда"
+		inactiveStr := b.t("stats_never", chatID)
 		if lastTraining != nil && daysInactive != nil {
 			lastDate, _ := time.Parse("2006-01-02T15:04:05Z", *lastTraining)
-			inactiveStr = fmt.Sprintf("%d дн. (с %s)", *daysInactive, lastDate.Format("02.01"))
+			inactiveStr = b.tf("stats_inactive_since", chatID, b.tn("days", chatID, *daysInactive), lastDate.Format("02.01"))
 		}
 
 		message.WriteString(fmt.Sprintf("⚠️ *%s %s*\n", name, surname))
 		message.WriteString(fmt.Sprintf("   📱 %s\n", phone))
-		message.WriteString(fmt.Sprintf("   ⏰ Не был: %s\n\n", inactiveStr))
+		message.WriteString(b.tf("stats_inactive_last", chatID, inactiveStr) + "\n\n")
 	}
 
 	if count == 0 {
-		message.WriteString("✅ Все клиенты активны!")
+		message.WriteString(b.t("stats_all_active", chatID))
 	} else {
-		message.WriteString(fmt.Sprintf("\n_Всего неактивных: %d_", count))
+		message.WriteString("\n" + b.tf("stats_inactive_total", chatID, count))
 	}
 
 	msg := tgbotapi.NewMessage(chatID, message.String())
@@ -248,16 +253,16 @@ func (b *Bot) handleInactiveClients(chatID int64) {
 func (b *Bot) handlePeriodStatistics(chatID int64) {
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("📅 За неделю", "stats_week"),
-			tgbotapi.NewInlineKeyboardButtonData("📅 За месяц", "stats_month"),
+			tgbotapi.NewInlineKeyboardButtonData(b.t("stats_btn_week", chatID), "stats_week"),
+			tgbotapi.NewInlineKeyboardButtonData(b.t("stats_btn_month", chatID), "stats_month"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("📅 За 3 месяца", "stats_quarter"),
-			tgbotapi.NewInlineKeyboardButtonData("📅 За год", "stats_year"),
+			tgbotapi.NewInlineKeyboardButtonData(b.t("stats_btn_quarter", chatID), "stats_quarter"),
+			tgbotapi.NewInlineKeyboardButtonData(b.t("stats_btn_year", chatID), "stats_year"),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, "📅 Выберите период для статистики:")
+	msg := tgbotapi.NewMessage(chatID, b.t("stats_select_period", chatID))
 	msg.ReplyMarkup = keyboard
 	b.api.Send(msg)
 }
@@ -265,27 +270,21 @@ func (b *Bot) handlePeriodStatistics(chatID int64) {
 // handleStatsCallback обрабатывает callback для статистики
 func (b *Bot) handleStatsCallback(chatID int64, messageID int, period string) {
 	var interval string
-	var periodName string
 
 	switch period {
 	case "stats_week":
 		interval = "7 days"
-		periodName = "за неделю"
 	case "stats_month":
 		interval = "30 days"
-		periodName = "за месяц"
 	case "stats_quarter":
 		interval = "90 days"
-		periodName = "за 3 месяца"
 	case "stats_year":
 		interval = "365 days"
-		periodName = "за год"
 	default:
 		return
 	}
 
 	var totalTrainings, completedTrainings, cancelledTrainings, uniqueClients int
-	var revenue float64 // если есть поле стоимости тренировки
 
 	// Всего тренировок за период
 	b.db.QueryRow(fmt.Sprintf(`
@@ -315,30 +314,32 @@ func (b *Bot) handleStatsCallback(chatID int64, messageID int, period string) {
 	`, interval)).Scan(&uniqueClients)
 
 	// Статистика по дням недели
-	dayStats := b.getTrainingsByDayOfWeek(interval)
+	dayStats := b.getTrainingsByDayOfWeek(interval, b.getLanguage(chatID))
 
 	var message strings.Builder
-	message.WriteString(fmt.Sprintf("📊 *Статистика %s*\n\n", periodName))
+	// period совпадает с суффиксом ключа заголовка: stats_title_week, stats_title_month...
+	message.WriteString(b.t("stats_title_"+strings.TrimPrefix(period, "stats_"), chatID) + "\n\n")
 
-	message.WriteString("🏋️ *Тренировки:*\n")
-	message.WriteString(fmt.Sprintf("  • Всего: %d\n", totalTrainings))
-	message.WriteString(fmt.Sprintf("  • Завершено: %d\n", completedTrainings))
-	message.WriteString(fmt.Sprintf("  • Отменено: %d\n", cancelledTrainings))
+	message.WriteString(b.t("stats_trainings_header", chatID) + "\n")
+	message.WriteString(b.tf("stats_total", chatID, totalTrainings) + "\n")
+	message.WriteString(b.tf("stats_completed", chatID, completedTrainings) + "\n")
+	message.WriteString(b.tf("stats_cancelled", chatID, cancelledTrainings) + "\n")
 
 	if totalTrainings > 0 {
 		rate := float64(completedTrainings) / float64(totalTrainings) * 100
-		message.WriteString(fmt.Sprintf("  • Посещаемость: %.1f%%\n", rate))
+		message.WriteString(b.tf("stats_attendance", chatID, rate) + "\n")
 	}
 
-	message.WriteString(fmt.Sprintf("\n👥 Уникальных клиентов: %d\n", uniqueClients))
+	message.WriteString("\n" + b.tf("stats_unique_clients", chatID, uniqueClients) + "\n")
 
-	if revenue > 0 {
-		message.WriteString(fmt.Sprintf("💰 Доход: %.0f ₽\n", revenue))
+	// Выручка — оплаченные пакеты за период
+	if revenue := b.paidSince(interval); len(revenue) > 0 {
+		message.WriteString(b.tf("stats_revenue", chatID, strings.Join(revenue, ", ")) + "\n")
 	}
 
 	// Популярные дни
 	if len(dayStats) > 0 {
-		message.WriteString("\n📅 *По дням недели:*\n")
+		message.WriteString("\n" + b.t("stats_by_weekday", chatID) + "\n")
 		for _, ds := range dayStats {
 			bar := strings.Repeat("█", ds.count/2)
 			if len(bar) == 0 && ds.count > 0 {
@@ -353,13 +354,100 @@ func (b *Bot) handleStatsCallback(chatID int64, messageID int, period string) {
 	b.api.Send(edit)
 }
 
+// paidSince возвращает выручку за интервал по валютам: «45 000 RUB»
+func (b *Bot) paidSince(interval string) []string {
+	rows, err := b.db.Query(fmt.Sprintf(`
+		SELECT currency, SUM(amount)
+		FROM public.payments
+		WHERE status = 'paid' AND paid_at >= CURRENT_DATE - INTERVAL '%s'
+		GROUP BY currency
+		ORDER BY currency
+	`, interval))
+	if err != nil {
+		log.Printf("Ошибка подсчёта выручки: %v", err)
+		return nil
+	}
+	defer rows.Close()
+
+	var totals []string
+	for rows.Next() {
+		var currency string
+		var amount int64
+		if err := rows.Scan(&currency, &amount); err != nil {
+			continue
+		}
+		totals = append(totals, billing.FormatMoney(amount, currency))
+	}
+	return totals
+}
+
+// handleRevenueStatistics показывает выручку по месяцам и пакетам за год
+// и сколько оплаченных тренировок клиенты ещё не использовали
+func (b *Bot) handleRevenueStatistics(chatID int64) {
+	rows, err := b.db.Query(`
+		SELECT paid_at, amount, currency, description
+		FROM public.payments
+		WHERE status = 'paid' AND paid_at >= date_trunc('month', NOW()) - INTERVAL '11 months'
+		ORDER BY paid_at`)
+	if err != nil {
+		b.sendError(chatID, b.t("stats_revenue_error", chatID), err)
+		return
+	}
+	var paid []billing.Paid
+	for rows.Next() {
+		var p billing.Paid
+		if err := rows.Scan(&p.PaidAt, &p.Amount, &p.Currency, &p.Package); err != nil {
+			rows.Close()
+			b.sendError(chatID, b.t("stats_revenue_error", chatID), err)
+			return
+		}
+		paid = append(paid, p)
+	}
+	rows.Close()
+
+	var activeSubscriptions, sessionsLeft int
+	b.db.QueryRow(`
+		SELECT COUNT(*), COALESCE(SUM(sessions_left), 0)
+		FROM public.client_subscriptions
+		WHERE (expires_on IS NULL OR expires_on >= CURRENT_DATE)
+		  AND (kind = 'period' OR sessions_left > 0)`).Scan(&activeSubscriptions, &sessionsLeft)
+
+	var message strings.Builder
+	message.WriteString(b.t("stats_revenue_title", chatID) + "\n\n")
+	if len(paid) == 0 {
+		message.WriteString(b.t("stats_revenue_empty", chatID) + "\n")
+	}
+
+	lang := b.getLanguage(chatID)
+	if months := billing.Monthly(paid, b.userLocation(chatID)); len(months) > 0 {
+		message.WriteString(b.t("stats_revenue_by_month", chatID) + "\n")
+		for _, m := range months {
+			message.WriteString(fmt.Sprintf("  %s %d — %s (%s)\n",
+				monthName(m.Month.Month(), lang), m.Month.Year(),
+				billing.FormatMoney(m.Amount, m.Currency), b.tn("stats_revenue_payments", chatID, m.Count)))
+		}
+	}
+	if packages := billing.ByPackage(paid); len(packages) > 0 {
+		message.WriteString("\n" + b.t("stats_revenue_by_package", chatID) + "\n")
+		for _, p := range packages {
+			message.WriteString(fmt.Sprintf("  %s — %s (%s)\n",
+				p.Package, billing.FormatMoney(p.Amount, p.Currency), b.tn("stats_revenue_payments", chatID, p.Count)))
+		}
+	}
+	message.WriteString("\n" + b.tf("stats_revenue_active", chatID, activeSubscriptions, sessionsLeft) + "\n")
+
+	msg := tgbotapi.NewMessage(chatID, message.String())
+	msg.ParseMode = "Markdown"
+	b.api.Send(msg)
+}
+
 type dayStat struct {
 	day   string
 	count int
 }
 
 // getTrainingsByDayOfWeek возвращает статистику по дням недели
-func (b *Bot) getTrainingsByDayOfWeek(interval string) []dayStat {
+func (b *Bot) getTrainingsByDayOfWeek(interval string, lang i18n.Language) []dayStat {
 	rows, err := b.db.Query(fmt.Sprintf(`
 		SELECT EXTRACT(DOW FROM appointment_date) as dow, COUNT(*) as cnt
 		FROM public.appointments
@@ -373,7 +461,6 @@ func (b *Bot) getTrainingsByDayOfWeek(interval string) []dayStat {
 	}
 	defer rows.Close()
 
-	days := []string{"Вс", "Пн", "Вт", "Ср", "Чт", "Пт", "Сб"}
 	stats := make(map[int]int)
 
 	for rows.Next() {
@@ -389,7 +476,7 @@ func (b *Bot) getTrainingsByDayOfWeek(interval string) []dayStat {
 	for i := 1; i <= 7; i++ {
 		dow := i % 7 // 1,2,3,4,5,6,0 -> Пн,Вт,Ср,Чт,Пт,Сб,Вс
 		result = append(result, dayStat{
-			day:   days[dow],
+			day:   weekdayShort(time.Weekday(dow), lang),
 			count: stats[dow],
 		})
 	}
@@ -458,31 +545,31 @@ func (b *Bot) getClientStatistics(clientID int) *ClientStats {
 func (b *Bot) handleClientStatistics(chatID int64, clientID int) {
 	stats := b.getClientStatistics(clientID)
 	if stats == nil {
-		b.sendMessage(chatID, "Клиент не найден")
+		b.sendMessage(chatID, b.t("admin_client_not_found", chatID))
 		return
 	}
 
 	var message strings.Builder
-	message.WriteString(fmt.Sprintf("📊 *Статистика: %s %s*\n\n", stats.Name, stats.Surname))
+	message.WriteString(b.tf("stats_client_title", chatID, stats.Name, stats.Surname) + "\n\n")
 
-	message.WriteString("🏋️ *Тренировки:*\n")
-	message.WriteString(fmt.Sprintf("  • Всего записей: %d\n", stats.TotalAppointments))
-	message.WriteString(fmt.Sprintf("  • Завершено: %d\n", stats.CompletedTrainings))
-	message.WriteString(fmt.Sprintf("  • Отменено: %d\n", stats.CancelledTrainings))
-	message.WriteString(fmt.Sprintf("  • Посещаемость: %.1f%%\n", stats.AttendanceRate))
+	message.WriteString(b.t("stats_trainings_header", chatID) + "\n")
+	message.WriteString(b.tf("stats_total_appointments", chatID, stats.TotalAppointments) + "\n")
+	message.WriteString(b.tf("stats_completed", chatID, stats.CompletedTrainings) + "\n")
+	message.WriteString(b.tf("stats_cancelled", chatID, stats.CancelledTrainings) + "\n")
+	message.WriteString(b.tf("stats_attendance", chatID, stats.AttendanceRate) + "\n")
 
 	if stats.AvgTrainingsPerMonth > 0 {
-		message.WriteString(fmt.Sprintf("  • В среднем: %.1f/мес\n", stats.AvgTrainingsPerMonth))
+		message.WriteString(b.tf("stats_avg_month", chatID, stats.AvgTrainingsPerMonth) + "\n")
 	}
 
-	message.WriteString("\n📅 *Даты:*\n")
-	message.WriteString(fmt.Sprintf("  • Регистрация: %s\n", stats.RegistrationDate.Format("02.01.2006")))
+	message.WriteString("\n" + b.t("stats_dates_header", chatID) + "\n")
+	message.WriteString(b.tf("stats_registered", chatID, stats.RegistrationDate.Format("02.01.2006")) + "\n")
 	if !stats.LastTrainingDate.IsZero() {
 		daysAgo := int(time.Since(stats.LastTrainingDate).Hours() / 24)
-		message.WriteString(fmt.Sprintf("  • Последняя тренировка: %s (%d дн. назад)\n",
-			stats.LastTrainingDate.Format("02.01.2006"), daysAgo))
+		message.WriteString(b.tf("stats_last_training", chatID,
+			stats.LastTrainingDate.Format("02.01.2006"), b.tn("days", chatID, daysAgo)) + "\n")
 	} else {
-		message.WriteString("  • Последняя тренировка: нет данных\n")
+		message.WriteString(b.t("stats_last_training_none", chatID) + "\n")
 	}
 
 	// Оценка активности
@@ -494,13 +581,13 @@ func (b *Bot) handleClientStatistics(chatID int64, clientID int) {
 	} else {
 		activityEmoji = "⚠️"
 	}
-	message.WriteString(fmt.Sprintf("\n%s Оценка активности: ", activityEmoji))
+	message.WriteString("\n" + b.tf("stats_activity", chatID, activityEmoji))
 	if stats.AttendanceRate >= 80 {
-		message.WriteString("*Отличная*")
+		message.WriteString(b.t("stats_activity_excellent", chatID))
 	} else if stats.AttendanceRate >= 50 {
-		message.WriteString("*Хорошая*")
+		message.WriteString(b.t("stats_activity_good", chatID))
 	} else {
-		message.WriteString("*Требует внимания*")
+		message.WriteString(b.t("stats_activity_attention", chatID))
 	}
 
 	msg := tgbotapi.NewMessage(chatID, message.String())
@@ -0,0 +1,78 @@
+package bot
+
+import (
+	"sync"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// chatDispatcher раздаёт обновления по чатам: обновления одного чата обрабатываются
+// по очереди в порядке получения, разных чатов — параллельно. Цикл получения
+// обновлений не ждёт обработчиков, поэтому ожидание лимитов Telegram и пауза
+// после 429 задерживают только чат, который отправляет
+type chatDispatcher struct {
+	mu      sync.Mutex
+	pending map[int64][]tgbotapi.Update // очередь чата; ключ есть, пока работает его обработчик
+	handle  func(tgbotapi.Update)
+	wg      sync.WaitGroup
+}
+
+func newChatDispatcher(handle func(tgbotapi.Update)) *chatDispatcher {
+	return &chatDispatcher{
+		pending: make(map[int64][]tgbotapi.Update),
+		handle:  handle,
+	}
+}
+
+// dispatch ставит обновление в очередь его чата и не блокируется
+func (d *chatDispatcher) dispatch(update tgbotapi.Update) {
+	chatID := updateChatID(update)
+
+	d.mu.Lock()
+	queue, running := d.pending[chatID]
+	d.pending[chatID] = append(queue, update)
+	if !running {
+		d.wg.Add(1)
+		go d.drain(chatID)
+	}
+	d.mu.Unlock()
+}
+
+// drain обрабатывает очередь чата, пока она не опустеет
+func (d *chatDispatcher) drain(chatID int64) {
+	defer d.wg.Done()
+	for {
+		d.mu.Lock()
+		queue := d.pending[chatID]
+		if len(queue) == 0 {
+			delete(d.pending, chatID)
+			d.mu.Unlock()
+			return
+		}
+		update := queue[0]
+		d.pending[chatID] = queue[1:]
+		d.mu.Unlock()
+
+		d.handle(update)
+	}
+}
+
+// wait ждёт, пока обработаются все полученные обновления
+func (d *chatDispatcher) wait() {
+	d.wg.Wait()
+}
+
+// updateChatID возвращает чат, к которому относится обновление
+func updateChatID(update tgbotapi.Update) int64 {
+	switch {
+	case update.Message != nil && update.Message.Chat != nil:
+		return update.Message.Chat.ID
+	case update.CallbackQuery != nil && update.CallbackQuery.Message != nil && update.CallbackQuery.Message.Chat != nil:
+		return update.CallbackQuery.Message.Chat.ID
+	case update.CallbackQuery != nil && update.CallbackQuery.From != nil:
+		return update.CallbackQuery.From.ID
+	case update.PreCheckoutQuery != nil && update.PreCheckoutQuery.From != nil:
+		return update.PreCheckoutQuery.From.ID
+	}
+	return 0
+}
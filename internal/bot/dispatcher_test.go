@@ -0,0 +1,94 @@
+package bot
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func chatUpdate(chatID int64, messageID int) tgbotapi.Update {
+	return tgbotapi.Update{Message: &tgbotapi.Message{MessageID: messageID, Chat: &tgbotapi.Chat{ID: chatID}}}
+}
+
+func TestChatDispatcherKeepsOrderWithinChat(t *testing.T) {
+	var mu sync.Mutex
+	got := make(map[int64][]int)
+	d := newChatDispatcher(func(u tgbotapi.Update) {
+		mu.Lock()
+		got[u.Message.Chat.ID] = append(got[u.Message.Chat.ID], u.Message.MessageID)
+		mu.Unlock()
+	})
+
+	for i := 1; i <= 50; i++ {
+		d.dispatch(chatUpdate(1, i))
+		d.dispatch(chatUpdate(2, i))
+	}
+	d.wait()
+
+	for _, chatID := range []int64{1, 2} {
+		if len(got[chatID]) != 50 {
+			t.Fatalf("чат %d: обработано %d обновлений, want 50", chatID, len(got[chatID]))
+		}
+		for i, id := range got[chatID] {
+			if id != i+1 {
+				t.Fatalf("чат %d: порядок нарушен: %v", chatID, got[chatID])
+			}
+		}
+	}
+	if len(d.pending) != 0 {
+		t.Errorf("pending = %v, want пусто", d.pending)
+	}
+}
+
+func TestChatDispatcherDoesNotBlockOtherChats(t *testing.T) {
+	release := make(chan struct{})
+	handled := make(chan int64, 2)
+	d := newChatDispatcher(func(u tgbotapi.Update) {
+		// Чат 1 «ждёт лимита» при отправке
+		if u.Message.Chat.ID == 1 {
+			<-release
+		}
+		handled <- u.Message.Chat.ID
+	})
+
+	d.dispatch(chatUpdate(1, 1))
+	d.dispatch(chatUpdate(2, 1))
+
+	select {
+	case chatID := <-handled:
+		if chatID != 2 {
+			t.Fatalf("обработан чат %d, want 2", chatID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("обновление чата 2 ждёт обработчика чата 1")
+	}
+
+	close(release)
+	d.wait()
+	if chatID := <-handled; chatID != 1 {
+		t.Errorf("обработан чат %d, want 1", chatID)
+	}
+}
+
+func TestUpdateChatID(t *testing.T) {
+	tests := []struct {
+		name   string
+		update tgbotapi.Update
+		want   int64
+	}{
+		{"сообщение", chatUpdate(10, 1), 10},
+		{"callback", tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{
+			From: &tgbotapi.User{ID: 20}, Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 30}},
+		}}, 30},
+		{"inline callback", tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{From: &tgbotapi.User{ID: 20}}}, 20},
+		{"pre-checkout", tgbotapi.Update{PreCheckoutQuery: &tgbotapi.PreCheckoutQuery{From: &tgbotapi.User{ID: 40}}}, 40},
+		{"пустое", tgbotapi.Update{}, 0},
+	}
+	for _, tt := range tests {
+		if got := updateChatID(tt.update); got != tt.want {
+			t.Errorf("%s: updateChatID = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"workbot/internal/excel"
+	"workbot/internal/i18n"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -18,7 +20,18 @@ type feedbackState struct {
 	TrainingDate  string // дата тренировки
 }
 
-var clientFeedbackStates = make(map[int64]*feedbackState)
+// feedbackStore хранит выбранную тренировку, пока клиент пишет отзыв
+var feedbackStore = struct {
+	sync.RWMutex
+	data map[int64]*feedbackState
+}{data: make(map[int64]*feedbackState)}
+
+// clearFeedbackState забывает выбранную для отзыва тренировку
+func clearFeedbackState(chatID int64) {
+	feedbackStore.Lock()
+	delete(feedbackStore.data, chatID)
+	feedbackStore.Unlock()
+}
 
 // handleFeedbackStart начинает процесс обратной связи - показывает список тренировок
 func (b *Bot) handleFeedbackStart(message *tgbotapi.Message) {
@@ -35,7 +48,7 @@ func (b *Bot) handleFeedbackStart(message *tgbotapi.Message) {
 	}
 
 	// Получаем последние тренировки из Excel
-	trainings, err := excel.GetClientTrainings(excel.FilePath, clientID, 10)
+	trainings, err := excel.GetClientTrainings(excel.FilePath, clientID, 10, b.getLanguage(chatID))
 	if err != nil {
 		b.sendError(chatID, b.t("error", chatID), err)
 		return
@@ -76,9 +89,9 @@ func (b *Bot) handleFeedbackSelectTraining(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" || text == "Cancel" {
+	if i18n.Is(text, "cancel") {
 		clearState(chatID)
-		delete(clientFeedbackStates, chatID)
+		clearFeedbackState(chatID)
 		b.restoreMainMenu(chatID)
 		return
 	}
@@ -86,22 +99,24 @@ func (b *Bot) handleFeedbackSelectTraining(message *tgbotapi.Message) {
 	// Парсим номер тренировки
 	parts := strings.SplitN(text, ".", 2)
 	if len(parts) < 2 {
-		b.sendMessage(chatID, "Выберите тренировку из списка.")
+		b.sendMessage(chatID, b.t("feedback_select_from_list", chatID))
 		return
 	}
 
 	index, err := strconv.Atoi(parts[0])
 	if err != nil || index < 1 {
-		b.sendMessage(chatID, "Выберите тренировку из списка.")
+		b.sendMessage(chatID, b.t("feedback_select_from_list", chatID))
 		return
 	}
 
 	// Сохраняем выбор
 	dateStr := strings.TrimSpace(parts[1])
-	clientFeedbackStates[chatID] = &feedbackState{
+	feedbackStore.Lock()
+	feedbackStore.data[chatID] = &feedbackState{
 		TrainingIndex: index - 1,
 		TrainingDate:  dateStr,
 	}
+	feedbackStore.Unlock()
 
 	setState(chatID, "feedback_awaiting_input")
 
@@ -119,9 +134,9 @@ func (b *Bot) handleFeedbackInput(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" || text == "Cancel" {
+	if i18n.Is(text, "cancel") {
 		clearState(chatID)
-		delete(clientFeedbackStates, chatID)
+		clearFeedbackState(chatID)
 		b.restoreMainMenu(chatID)
 		return
 	}
@@ -132,9 +147,11 @@ func (b *Bot) handleFeedbackInput(message *tgbotapi.Message) {
 
 // saveFeedback сохраняет обратную связь и отправляет тренеру
 func (b *Bot) saveFeedback(chatID int64, feedbackText string) {
-	state := clientFeedbackStates[chatID]
+	feedbackStore.RLock()
+	state := feedbackStore.data[chatID]
+	feedbackStore.RUnlock()
 	if state == nil {
-		b.sendMessage(chatID, "Ошибка: не выбрана тренировка.")
+		b.sendMessage(chatID, b.t("feedback_no_training_selected", chatID))
 		b.restoreMainMenu(chatID)
 		return
 	}
@@ -145,9 +162,9 @@ func (b *Bot) saveFeedback(chatID int64, feedbackText string) {
 	err := b.db.QueryRow("SELECT id, name, surname FROM public.clients WHERE telegram_id = $1", chatID).
 		Scan(&clientID, &name, &surname)
 	if err != nil {
-		b.sendMessage(chatID, "Ошибка: клиент не найден.")
+		b.sendMessage(chatID, b.t("feedback_error", chatID))
 		clearState(chatID)
-		delete(clientFeedbackStates, chatID)
+		clearFeedbackState(chatID)
 		b.restoreMainMenu(chatID)
 		return
 	}
@@ -164,7 +181,7 @@ func (b *Bot) saveFeedback(chatID int64, feedbackText string) {
 
 	// Очищаем состояние
 	clearState(chatID)
-	delete(clientFeedbackStates, chatID)
+	clearFeedbackState(chatID)
 
 	b.sendMessage(chatID, b.t("feedback_saved", chatID))
 	b.restoreMainMenu(chatID)
@@ -180,17 +197,7 @@ func (b *Bot) notifyTrainersAboutFeedback(clientID int, name, surname, trainingD
 	}
 	defer rows.Close()
 
-	notification := fmt.Sprintf(
-		"Обратная связь от клиента\n\n"+
-			"Клиент: %s %s\n"+
-			"Тренировка: %s\n"+
-			"Время: %s\n\n"+
-			"Сообщение:\n%s",
-		name, surname,
-		trainingDate,
-		time.Now().Format("02.01.2006 15:04"),
-		feedback,
-	)
+	sentAt := time.Now().Format("02.01.2006 15:04")
 
 	for rows.Next() {
 		var adminTelegramID int64
@@ -198,9 +205,11 @@ func (b *Bot) notifyTrainersAboutFeedback(clientID int, name, surname, trainingD
 			continue
 		}
 
+		notification := b.tf("feedback_trainer_notify", adminTelegramID,
+			name, surname, trainingDate, sentAt, feedback)
 		msg := tgbotapi.NewMessage(adminTelegramID, notification)
-		if _, err := b.api.Send(msg); err != nil {
-			log.Printf("Ошибка отправки уведомления админу %d: %v", adminTelegramID, err)
+		if err := b.outbox.Enqueue(msg); err != nil {
+			log.Printf("Ошибка постановки уведомления админу %d в очередь: %v", adminTelegramID, err)
 		}
 	}
 }
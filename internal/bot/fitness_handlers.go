@@ -11,6 +11,7 @@ import (
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"workbot/internal/generator"
 	"workbot/internal/gsheets"
+	"workbot/internal/i18n"
 	"workbot/internal/models"
 )
 
@@ -69,25 +70,19 @@ func getFitnessSelector() *generator.ExerciseSelector {
 func (b *Bot) handleFitnessMenu(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 
-	msg := tgbotapi.NewMessage(chatID, "🏃 Генератор фитнес программ\n\n"+
-		"Доступные программы:\n"+
-		"• 💪 Гипертрофия (набор мышечной массы)\n"+
-		"• 🏋️ Сила (максимальная сила)\n"+
-		"• 🔥 Жиросжигание (похудение + тонус)\n"+
-		"• 🏃 Hyrox (функциональный фитнес)\n\n"+
-		"Выберите тип программы:")
+	msg := tgbotapi.NewMessage(chatID, b.t("fit_menu_text", chatID))
 
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("FIT: Гипертрофия"),
-			tgbotapi.NewKeyboardButton("FIT: Сила"),
+			tgbotapi.NewKeyboardButton(b.t("fit_btn_hypertrophy", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("fit_btn_strength", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("FIT: Жиросжигание"),
-			tgbotapi.NewKeyboardButton("FIT: Hyrox"),
+			tgbotapi.NewKeyboardButton(b.t("fit_btn_fatloss", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("fit_btn_hyrox", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Назад"),
+			tgbotapi.NewKeyboardButton(b.t("back", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -115,7 +110,7 @@ func (b *Bot) showClientsForFitness(chatID int64, programType string) {
 		ORDER BY c.name, c.surname`)
 	if err != nil {
 		log.Printf("Ошибка получения клиентов: %v", err)
-		b.sendMessage(chatID, "Ошибка загрузки клиентов")
+		b.sendMessage(chatID, b.t("admin_clients_load_error", chatID))
 		return
 	}
 	defer rows.Close()
@@ -134,16 +129,16 @@ func (b *Bot) showClientsForFitness(chatID int64, programType string) {
 	}
 
 	if len(buttons) == 0 {
-		b.sendMessage(chatID, "Нет клиентов. Сначала добавьте клиента.")
+		b.sendMessage(chatID, b.t("fit_no_clients", chatID))
 		return
 	}
 
 	buttons = append(buttons, tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton("Отмена"),
+		tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 	))
 
-	typeName := getFitnessProgramTypeName(programType)
-	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("📋 Программа: %s\n\nВыберите клиента:", typeName))
+	typeName := fitnessProgramTypeName(programType, b.getLanguage(chatID))
+	msg := tgbotapi.NewMessage(chatID, b.tf("fit_select_client", chatID, typeName))
 	msg.ReplyMarkup = tgbotapi.NewReplyKeyboard(buttons...)
 	b.api.Send(msg)
 
@@ -157,7 +152,7 @@ func (b *Bot) handleFitnessClientSelect(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.clearFitnessState(chatID)
 		b.handleFitnessMenu(message)
 		return
@@ -165,7 +160,7 @@ func (b *Bot) handleFitnessClientSelect(message *tgbotapi.Message) {
 
 	clientID := parseIDFromBrackets(text)
 	if clientID == 0 {
-		b.sendMessage(chatID, "Ошибка выбора клиента")
+		b.sendMessage(chatID, b.t("admin_client_select_error", chatID))
 		return
 	}
 
@@ -183,7 +178,7 @@ func (b *Bot) showFitnessWeightInput(chatID int64) {
 	userStates.states[chatID] = "fit_enter_weight"
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Введите вес клиента (кг):\n\nНапример: 70 или 65.5")
+	msg := tgbotapi.NewMessage(chatID, b.t("fit_enter_weight", chatID))
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
 			tgbotapi.NewKeyboardButton("60"),
@@ -195,7 +190,7 @@ func (b *Bot) showFitnessWeightInput(chatID int64) {
 			tgbotapi.NewKeyboardButton("100"),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -207,7 +202,7 @@ func (b *Bot) handleFitnessWeightInput(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.clearFitnessState(chatID)
 		b.handleFitnessMenu(message)
 		return
@@ -217,7 +212,7 @@ func (b *Bot) handleFitnessWeightInput(message *tgbotapi.Message) {
 	var weight float64
 	_, err := fmt.Sscanf(strings.Replace(text, ",", ".", 1), "%f", &weight)
 	if err != nil || weight < 30 || weight > 300 {
-		b.sendMessage(chatID, "❌ Введите корректный вес (от 30 до 300 кг)")
+		b.sendMessage(chatID, b.t("fit_invalid_weight", chatID))
 		return
 	}
 
@@ -234,17 +229,12 @@ func (b *Bot) showFitnessWeeksSelection(chatID int64) {
 	userStates.states[chatID] = "fit_select_weeks"
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "На сколько недель составить программу?")
+	msg := tgbotapi.NewMessage(chatID, b.t("fit_select_weeks", chatID))
 	keyboard := tgbotapi.NewReplyKeyboard(
+		b.countButtons("weeks", chatID, 4, 8),
+		b.countButtons("weeks", chatID, 12),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("4 недели"),
-			tgbotapi.NewKeyboardButton("8 недель"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("12 недель"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -256,22 +246,15 @@ func (b *Bot) handleFitnessWeeksSelect(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.clearFitnessState(chatID)
 		b.handleFitnessMenu(message)
 		return
 	}
 
-	var weeks int
-	switch text {
-	case "4 недели":
-		weeks = 4
-	case "8 недель":
-		weeks = 8
-	case "12 недель":
-		weeks = 12
-	default:
-		b.sendMessage(chatID, "Выберите из предложенных вариантов")
+	weeks, ok := parseCountButton(text, 4, 8, 12)
+	if !ok {
+		b.sendMessage(chatID, b.t("select_option_menu", chatID))
 		return
 	}
 
@@ -288,18 +271,12 @@ func (b *Bot) showFitnessDaysSelection(chatID int64) {
 	userStates.states[chatID] = "fit_select_days"
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Сколько тренировок в неделю?")
+	msg := tgbotapi.NewMessage(chatID, b.t("fit_select_days", chatID))
 	keyboard := tgbotapi.NewReplyKeyboard(
+		b.countButtons("days", chatID, 2, 3),
+		b.countButtons("days", chatID, 4, 5),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("2 дня"),
-			tgbotapi.NewKeyboardButton("3 дня"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("4 дня"),
-			tgbotapi.NewKeyboardButton("5 дней"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -311,24 +288,15 @@ func (b *Bot) handleFitnessDaysSelect(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.clearFitnessState(chatID)
 		b.handleFitnessMenu(message)
 		return
 	}
 
-	var days int
-	switch text {
-	case "2 дня":
-		days = 2
-	case "3 дня":
-		days = 3
-	case "4 дня":
-		days = 4
-	case "5 дней":
-		days = 5
-	default:
-		b.sendMessage(chatID, "Выберите из предложенных вариантов")
+	days, ok := parseCountButton(text, 2, 3, 4, 5)
+	if !ok {
+		b.sendMessage(chatID, b.t("select_option_menu", chatID))
 		return
 	}
 
@@ -359,19 +327,19 @@ func (b *Bot) showFitnessSplitSelection(chatID int64) {
 	userStates.states[chatID] = "fit_select_split"
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Выберите тип сплита:")
+	msg := tgbotapi.NewMessage(chatID, b.t("fit_select_split", chatID))
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Full Body"),
+			tgbotapi.NewKeyboardButton(b.t("fit_split_fullbody", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Upper/Lower"),
+			tgbotapi.NewKeyboardButton(b.t("fit_split_upper_lower", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Push/Pull/Legs"),
+			tgbotapi.NewKeyboardButton(b.t("fit_split_push_pull_legs", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -383,22 +351,15 @@ func (b *Bot) handleFitnessSplitSelect(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.clearFitnessState(chatID)
 		b.handleFitnessMenu(message)
 		return
 	}
 
-	var split string
-	switch text {
-	case "Full Body":
-		split = "fullbody"
-	case "Upper/Lower":
-		split = "upper_lower"
-	case "Push/Pull/Legs":
-		split = "push_pull_legs"
-	default:
-		b.sendMessage(chatID, "Выберите из предложенных вариантов")
+	split := strings.TrimPrefix(i18n.Match(text, fitSplitKeys...), "fit_split_")
+	if split == "" {
+		b.sendMessage(chatID, b.t("select_option_menu", chatID))
 		return
 	}
 
@@ -415,16 +376,16 @@ func (b *Bot) showFitnessHIITSelection(chatID int64) {
 	userStates.states[chatID] = "fit_select_hiit"
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Включить HIIT кардио в программу?")
+	msg := tgbotapi.NewMessage(chatID, b.t("fit_select_hiit", chatID))
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Да, включить HIIT"),
+			tgbotapi.NewKeyboardButton(b.t("fit_btn_hiit_yes", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Нет, только силовые"),
+			tgbotapi.NewKeyboardButton(b.t("fit_btn_hiit_no", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -436,13 +397,13 @@ func (b *Bot) handleFitnessHIITSelect(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.clearFitnessState(chatID)
 		b.handleFitnessMenu(message)
 		return
 	}
 
-	includeHIIT := text == "Да, включить HIIT"
+	includeHIIT := i18n.Is(text, "fit_btn_hiit_yes")
 
 	fitnessStates.Lock()
 	fitnessStates.includeHIIT[chatID] = includeHIIT
@@ -465,14 +426,14 @@ func (b *Bot) generateFitnessProgram(message *tgbotapi.Message) {
 	includeHIIT := fitnessStates.includeHIIT[chatID]
 	fitnessStates.RUnlock()
 
-	waitMsg := tgbotapi.NewMessage(chatID, "⏳ Генерирую программу...")
+	waitMsg := tgbotapi.NewMessage(chatID, b.t("program_generating", chatID))
 	b.api.Send(waitMsg)
 
 	// Загружаем профиль клиента
 	client, err := b.loadClientProfile(clientID)
 	if err != nil {
 		log.Printf("Ошибка загрузки клиента: %v", err)
-		b.sendMessage(chatID, "Ошибка загрузки данных клиента")
+		b.sendMessage(chatID, b.t("plans_client_load_error", chatID))
 		return
 	}
 
@@ -483,7 +444,7 @@ func (b *Bot) generateFitnessProgram(message *tgbotapi.Message) {
 
 	selector := getFitnessSelector()
 	if selector == nil {
-		b.sendMessage(chatID, "Ошибка инициализации генератора")
+		b.sendMessage(chatID, b.t("program_generator_init_error", chatID))
 		return
 	}
 
@@ -526,13 +487,13 @@ func (b *Bot) generateFitnessProgram(message *tgbotapi.Message) {
 		program, err = gen.Generate(config)
 
 	default:
-		b.sendMessage(chatID, "Неизвестный тип программы")
+		b.sendMessage(chatID, b.t("fit_unknown_type", chatID))
 		return
 	}
 
 	if err != nil {
 		log.Printf("Ошибка генерации: %v", err)
-		b.sendMessage(chatID, fmt.Sprintf("Ошибка генерации: %v", err))
+		b.sendMessage(chatID, b.tf("program_generation_error", chatID, err))
 		return
 	}
 
@@ -542,25 +503,18 @@ func (b *Bot) generateFitnessProgram(message *tgbotapi.Message) {
 	fitnessStates.Unlock()
 
 	// Показываем статистику
-	statsMsg := fmt.Sprintf("✅ Программа сгенерирована!\n\n"+
-		"📋 %s\n"+
-		"👤 %s\n"+
-		"📅 %d недель, %d тренировок/неделю\n\n"+
-		"📊 Статистика:\n"+
-		"• Всего тренировок: %d\n"+
-		"• Всего подходов: %d\n"+
-		"• Общий объём: %.0f кг\n\n"+
-		"Фазы программы:\n",
-		getFitnessProgramTypeName(programType),
+	lang := b.getLanguage(chatID)
+	statsMsg := b.tf("fit_generated_stats", chatID,
+		fitnessProgramTypeName(programType, lang),
 		client.Name,
-		program.TotalWeeks,
+		b.tn("weeks", chatID, program.TotalWeeks),
 		program.DaysPerWeek,
 		program.Statistics.TotalWorkouts,
 		program.Statistics.TotalSets,
 		program.Statistics.TotalVolume)
 
 	for _, phase := range program.Phases {
-		statsMsg += fmt.Sprintf("• Нед. %d-%d: %s\n", phase.WeekStart, phase.WeekEnd, phase.Name)
+		statsMsg += b.tf("fit_phase_item", chatID, phase.WeekStart, phase.WeekEnd, phase.Name) + "\n"
 	}
 
 	b.sendMessage(chatID, statsMsg)
@@ -569,23 +523,29 @@ func (b *Bot) generateFitnessProgram(message *tgbotapi.Message) {
 
 // showFitnessProgramOptions показывает опции программы
 func (b *Bot) showFitnessProgramOptions(chatID int64) {
-	msg := tgbotapi.NewMessage(chatID, "Что сделать с программой?")
+	msg := tgbotapi.NewMessage(chatID, b.t("program_options_title", chatID))
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Показать 1-ю неделю"),
+			tgbotapi.NewKeyboardButton(b.t("program_btn_show_week1", chatID)),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(b.t("program_btn_show_all", chatID)),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(b.t("program_btn_export_pdf", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Показать всю программу"),
+			tgbotapi.NewKeyboardButton(b.t("fit_btn_send_client", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("FIT: Отправить клиенту"),
+			tgbotapi.NewKeyboardButton(b.t("fit_btn_export_google", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("FIT: Экспорт в Google"),
+			tgbotapi.NewKeyboardButton(b.t("program_btn_save_template", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Новая программа"),
-			tgbotapi.NewKeyboardButton("В меню"),
+			tgbotapi.NewKeyboardButton(b.t("program_btn_new_program", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("fit_btn_menu", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -597,6 +557,12 @@ func (b *Bot) showFitnessProgramOptions(chatID int64) {
 	b.api.Send(msg)
 }
 
+// fitReviewKeys — кнопки меню действий с FIT-программой
+var fitReviewKeys = []string{
+	"program_btn_show_week1", "program_btn_show_all", "program_btn_export_pdf", "fit_btn_send_client", "fit_btn_export_google",
+	"program_btn_save_template", "program_btn_new_program", "fit_btn_menu",
+}
+
 // handleFitnessReview обрабатывает действия с программой
 func (b *Bot) handleFitnessReview(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
@@ -607,36 +573,43 @@ func (b *Bot) handleFitnessReview(message *tgbotapi.Message) {
 	fitnessStates.RUnlock()
 
 	if program == nil {
-		b.sendMessage(chatID, "Программа не найдена")
+		b.sendMessage(chatID, b.t("program_not_found", chatID))
 		b.handleFitnessMenu(message)
 		return
 	}
 
-	switch text {
-	case "Показать 1-ю неделю":
+	switch i18n.Match(text, fitReviewKeys...) {
+	case "program_btn_show_week1":
 		if len(program.Weeks) > 0 {
-			formatted := formatFitnessWeek(program.Weeks[0])
-			sendLongMessage(b, chatID, "📅 Неделя 1:\n\n"+formatted)
+			formatted := formatFitnessWeek(program.Weeks[0], b.getLanguage(chatID))
+			sendLongMessage(b, chatID, b.t("program_week1_title", chatID)+"\n\n"+formatted)
 		}
 
-	case "Показать всю программу":
-		formatted := formatFitnessProgram(program)
+	case "program_btn_show_all":
+		formatted := formatFitnessProgram(program, b.getLanguage(chatID))
 		sendLongMessage(b, chatID, formatted)
 
-	case "FIT: Отправить клиенту":
+	case "program_btn_export_pdf":
+		b.sendFitnessProgramPDF(chatID, program)
+
+	case "fit_btn_send_client":
 		b.handleFitnessSendToClient(message)
 		return
 
-	case "FIT: Экспорт в Google":
+	case "fit_btn_export_google":
 		b.handleFITExportToGoogle(message)
 		return
 
-	case "Новая программа":
+	case "program_btn_save_template":
+		b.startSaveTemplate(chatID, templateFromGenerated(program))
+		return
+
+	case "program_btn_new_program":
 		b.clearFitnessState(chatID)
 		b.handleFitnessMenu(message)
 		return
 
-	case "В меню":
+	case "fit_btn_menu":
 		b.clearFitnessState(chatID)
 		userStates.Lock()
 		delete(userStates.states, chatID)
@@ -656,7 +629,7 @@ func (b *Bot) handleFitnessSendToClient(message *tgbotapi.Message) {
 	fitnessStates.RUnlock()
 
 	if program == nil {
-		b.sendMessage(chatID, "Программа не найдена")
+		b.sendMessage(chatID, b.t("program_not_found", chatID))
 		return
 	}
 
@@ -666,26 +639,24 @@ func (b *Bot) handleFitnessSendToClient(message *tgbotapi.Message) {
 	err := b.db.QueryRow("SELECT telegram_id, name, surname FROM public.clients WHERE id = $1", clientID).
 		Scan(&telegramID, &name, &surname)
 	if err != nil {
-		b.sendMessage(chatID, "Клиент не найден")
+		b.sendMessage(chatID, b.t("admin_client_not_found", chatID))
 		return
 	}
 
 	if telegramID == 0 {
-		b.sendMessage(chatID, fmt.Sprintf("❌ У клиента %s %s нет Telegram ID", name, surname))
+		b.sendMessage(chatID, b.tf("fit_client_no_telegram", chatID, name, surname))
 		b.showFitnessProgramOptions(chatID)
 		return
 	}
 
-	// Форматируем программу
-	formatted := formatFitnessProgram(program)
+	// Форматируем программу на языке клиента
+	clientLang := b.getLanguage(telegramID)
+	formatted := formatFitnessProgram(program, clientLang)
 
 	// Отправляем клиенту
-	introMsg := fmt.Sprintf("🏋️ Твоя программа тренировок!\n\n"+
-		"📋 Цель: %s\n"+
-		"📅 %d недель, %d тренировок/неделю\n\n"+
-		"Полная программа в файле ниже.",
-		getFitnessProgramTypeName(string(program.Goal)),
-		program.TotalWeeks,
+	introMsg := b.tf("fit_client_intro", telegramID,
+		fitnessProgramTypeName(string(program.Goal), clientLang),
+		b.tn("weeks", telegramID, program.TotalWeeks),
 		program.DaysPerWeek)
 
 	clientMsg := tgbotapi.NewMessage(telegramID, introMsg)
@@ -693,12 +664,12 @@ func (b *Bot) handleFitnessSendToClient(message *tgbotapi.Message) {
 
 	// Отправляем файл
 	doc := tgbotapi.NewDocument(telegramID, tgbotapi.FileBytes{
-		Name:  fmt.Sprintf("Программа_%s.txt", sanitizeFilename(name)),
+		Name:  b.tf("fit_client_file_name", telegramID, sanitizeFilename(name)),
 		Bytes: []byte(formatted),
 	})
 	b.api.Send(doc)
 
-	b.sendMessage(chatID, fmt.Sprintf("✅ Программа отправлена клиенту %s %s", name, surname))
+	b.sendMessage(chatID, b.tf("program_sent_to_client", chatID, name, surname))
 	b.showFitnessProgramOptions(chatID)
 }
 
@@ -724,16 +695,19 @@ func (b *Bot) loadClientProfile(clientID int) (*models.ClientProfile, error) {
 	}
 
 	// Загружаем данные из анкеты клиента (если есть)
-	var gender, experience sql.NullString
+	var gender, experience, injuries sql.NullString
 	var weight sql.NullFloat64
 	var height sql.NullInt64
 	err = b.db.QueryRow(`
-		SELECT gender, weight, height, experience
+		SELECT gender, weight, height, experience, injuries
 		FROM public.client_forms
 		WHERE client_id = $1
 		ORDER BY created_at DESC LIMIT 1`, clientID).
-		Scan(&gender, &weight, &height, &experience)
+		Scan(&gender, &weight, &height, &experience, &injuries)
 	if err == nil {
+		if injuries.Valid {
+			profile.Constraints = parseInjuryConstraints(injuries.String)
+		}
 		if gender.Valid && gender.String != "" {
 			profile.Gender = gender.String
 		}
@@ -789,6 +763,15 @@ func (b *Bot) loadClientProfile(clientID int) (*models.ClientProfile, error) {
 		models.EquipmentTRX,
 	}
 
+	// Ориентиры объёма, адаптированные по итогам прошлых блоков
+	profile.VolumeFactors = b.loadVolumeFactors(clientID)
+
+	// Поправка объёма кардио по загруженным тренировкам с часов
+	profile.CardioFactor = b.loadCardioFactor(clientID)
+
+	// Раскладка забега Hyrox и акцент последней симуляции
+	profile.HyroxSplits, profile.HyroxFocus, profile.HyroxFading = b.loadHyroxProfile(clientID)
+
 	return profile, nil
 }
 
@@ -819,7 +802,7 @@ func (b *Bot) handleFitSelectTypeForClient(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Назад" {
+	if i18n.Is(text, "back") {
 		b.clearFitnessState(chatID)
 		// Возвращаемся к профилю клиента
 		adminStates.RLock()
@@ -833,18 +816,9 @@ func (b *Bot) handleFitSelectTypeForClient(message *tgbotapi.Message) {
 		return
 	}
 
-	var programType string
-	switch text {
-	case "FIT: Гипертрофия":
-		programType = "hypertrophy"
-	case "FIT: Сила":
-		programType = "strength"
-	case "FIT: Жиросжигание":
-		programType = "fatloss"
-	case "FIT: Hyrox":
-		programType = "hyrox"
-	default:
-		b.sendMessage(chatID, "Выберите тип программы из меню")
+	programType := strings.TrimPrefix(i18n.Match(text, fitTypeKeys...), "fit_btn_")
+	if programType == "" {
+		b.sendMessage(chatID, b.t("fit_select_type_menu", chatID))
 		return
 	}
 
@@ -871,19 +845,53 @@ func (b *Bot) clearFitnessState(chatID int64) {
 
 // === Вспомогательные функции ===
 
-func getFitnessProgramTypeName(programType string) string {
-	names := map[string]string{
-		"hypertrophy": "💪 Гипертрофия",
-		"strength":    "🏋️ Сила",
-		"fatloss":     "🔥 Жиросжигание",
-		"hyrox":       "🏃 Hyrox",
-	}
-	if name, ok := names[programType]; ok {
-		return name
+// fitTypeKeys — кнопки выбора типа FIT-программы; суффикс ключа совпадает с кодом типа
+var fitTypeKeys = []string{"fit_btn_hypertrophy", "fit_btn_strength", "fit_btn_fatloss", "fit_btn_hyrox"}
+
+var fitSplitKeys = []string{"fit_split_fullbody", "fit_split_upper_lower", "fit_split_push_pull_legs"}
+
+// fitnessProgramTypeName возвращает название типа программы на языке lang
+func fitnessProgramTypeName(programType string, lang i18n.Language) string {
+	switch programType {
+	case "hypertrophy", "strength", "fatloss", "hyrox":
+		return i18n.T("fit_type_"+programType, lang)
 	}
 	return programType
 }
 
+// parseInjuryConstraints извлекает ограничения из текста анкеты ("болит колено", "грыжа поясницы")
+func parseInjuryConstraints(injuries string) []models.ClientConstraint {
+	text := strings.ToLower(injuries)
+	zones := []struct {
+		zone     models.BodyZone
+		keywords []string
+	}{
+		{models.ZoneKnee, []string{"колен", "мениск", "knee"}},
+		{models.ZoneLowerBack, []string{"поясниц", "грыж", "протрузи", "lower back"}},
+		{models.ZoneShoulder, []string{"плеч", "shoulder"}},
+		{models.ZoneWrist, []string{"запяст", "wrist"}},
+		{models.ZoneCervical, []string{"шея", "шеи", "шейн", "neck"}},
+		{models.ZoneHip, []string{"тазобедр", "hip"}},
+		{models.ZoneAnkle, []string{"голеностоп", "ankle"}},
+		{models.ZoneElbow, []string{"локт", "локот", "elbow"}},
+	}
+
+	var constraints []models.ClientConstraint
+	for _, z := range zones {
+		for _, kw := range z.keywords {
+			if strings.Contains(text, kw) {
+				constraints = append(constraints, models.ClientConstraint{
+					BodyZone: z.zone,
+					Severity: models.SeverityRelative,
+					Notes:    injuries,
+				})
+				break
+			}
+		}
+	}
+	return constraints
+}
+
 func mapExerciseToMovement(exName string) string {
 	exLower := strings.ToLower(exName)
 	if strings.Contains(exLower, "присед") {
@@ -901,19 +909,19 @@ func mapExerciseToMovement(exName string) string {
 	return ""
 }
 
-func formatFitnessWeek(week models.GeneratedWeek) string {
+func formatFitnessWeek(week models.GeneratedWeek, lang i18n.Language) string {
 	var sb strings.Builder
 
-	sb.WriteString(fmt.Sprintf("📆 Неделя %d — %s\n", week.WeekNum, week.PhaseName))
+	sb.WriteString(i18n.Tf("fit_week_title", lang, week.WeekNum, week.PhaseName) + "\n")
 	if week.IsDeload {
-		sb.WriteString("⚡ Разгрузочная неделя\n")
+		sb.WriteString(i18n.T("fit_week_deload", lang) + "\n")
 	}
-	sb.WriteString(fmt.Sprintf("Интенсивность: %.0f%% | RPE: %.1f\n\n", week.IntensityPercent, week.RPETarget))
+	sb.WriteString(i18n.Tf("fit_week_intensity", lang, week.IntensityPercent, week.RPETarget) + "\n\n")
 
 	for _, day := range week.Days {
 		sb.WriteString(fmt.Sprintf("━━━ %s ━━━\n", day.Name))
 		if day.EstimatedDuration > 0 {
-			sb.WriteString(fmt.Sprintf("⏱ ~%d мин\n", day.EstimatedDuration))
+			sb.WriteString(i18n.Tf("fit_day_duration", lang, day.EstimatedDuration) + "\n")
 		}
 		sb.WriteString("\n")
 
@@ -923,12 +931,12 @@ func formatFitnessWeek(week models.GeneratedWeek) string {
 				line += fmt.Sprintf(" — %dx%s", ex.Sets, ex.Reps)
 			}
 			if ex.Weight > 0 {
-				line += fmt.Sprintf(" @ %.0f кг", ex.Weight)
+				line += i18n.Tf("fit_weight_kg", lang, ex.Weight)
 			} else if ex.WeightPercent > 0 {
 				line += fmt.Sprintf(" @ %.0f%%", ex.WeightPercent)
 			}
 			if ex.RestSeconds > 0 {
-				line += fmt.Sprintf(" (отдых %d сек)", ex.RestSeconds)
+				line += i18n.Tf("fit_rest", lang, ex.RestSeconds)
 			}
 			sb.WriteString(line + "\n")
 
@@ -942,25 +950,25 @@ func formatFitnessWeek(week models.GeneratedWeek) string {
 	return sb.String()
 }
 
-func formatFitnessProgram(program *models.GeneratedProgram) string {
+func formatFitnessProgram(program *models.GeneratedProgram, lang i18n.Language) string {
 	var sb strings.Builder
 
-	sb.WriteString(fmt.Sprintf("ПРОГРАММА: %s\n\n", getFitnessProgramTypeName(string(program.Goal))))
-	sb.WriteString(fmt.Sprintf("Клиент: %s\n", program.ClientName))
-	sb.WriteString(fmt.Sprintf("Длительность: %d недель\n", program.TotalWeeks))
-	sb.WriteString(fmt.Sprintf("Тренировок в неделю: %d\n\n", program.DaysPerWeek))
+	sb.WriteString(i18n.Tf("fit_program_title", lang, fitnessProgramTypeName(string(program.Goal), lang)) + "\n\n")
+	sb.WriteString(i18n.Tf("fit_program_client", lang, program.ClientName) + "\n")
+	sb.WriteString(i18n.Tf("fit_program_duration", lang, i18n.Tn("weeks", lang, program.TotalWeeks)) + "\n")
+	sb.WriteString(i18n.Tf("fit_program_days", lang, program.DaysPerWeek) + "\n\n")
 
 	sb.WriteString("══════════════════════════════════\n")
-	sb.WriteString("ФАЗЫ ПРОГРАММЫ\n")
+	sb.WriteString(i18n.T("fit_program_phases", lang) + "\n")
 	sb.WriteString("══════════════════════════════════\n\n")
 	for _, phase := range program.Phases {
-		sb.WriteString(fmt.Sprintf("▸ %s (нед. %d-%d): %s\n",
-			phase.Name, phase.WeekStart, phase.WeekEnd, phase.Focus))
+		sb.WriteString(i18n.Tf("fit_program_phase_item", lang,
+			phase.Name, phase.WeekStart, phase.WeekEnd, phase.Focus) + "\n")
 	}
 	sb.WriteString("\n")
 
 	for _, week := range program.Weeks {
-		sb.WriteString(formatFitnessWeek(week))
+		sb.WriteString(formatFitnessWeek(week, lang))
 		sb.WriteString("\n")
 	}
 
@@ -979,19 +987,19 @@ func (b *Bot) handleFITProgramForClient(message *tgbotapi.Message, clientID int)
 	fitnessStates.Unlock()
 
 	// Показываем выбор типа программы
-	msg := tgbotapi.NewMessage(chatID, "🏃 Выберите тип программы:")
+	msg := tgbotapi.NewMessage(chatID, b.t("fit_select_type", chatID))
 
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("FIT: Гипертрофия"),
-			tgbotapi.NewKeyboardButton("FIT: Сила"),
+			tgbotapi.NewKeyboardButton(b.t("fit_btn_hypertrophy", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("fit_btn_strength", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("FIT: Жиросжигание"),
-			tgbotapi.NewKeyboardButton("FIT: Hyrox"),
+			tgbotapi.NewKeyboardButton(b.t("fit_btn_fatloss", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("fit_btn_hyrox", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Назад"),
+			tgbotapi.NewKeyboardButton(b.t("back", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -1011,43 +1019,43 @@ func (b *Bot) handleFITExportToGoogle(message *tgbotapi.Message) {
 	fitnessStates.RUnlock()
 
 	if program == nil {
-		b.sendMessage(chatID, "Программа не найдена")
+		b.sendMessage(chatID, b.t("program_not_found", chatID))
 		b.handleFitnessMenu(message)
 		return
 	}
 
 	if b.sheetsClient == nil {
-		b.sendMessage(chatID, "❌ Google Sheets не настроен")
+		b.sendMessage(chatID, b.t("sheets_not_configured", chatID))
 		b.showFitnessProgramOptions(chatID)
 		return
 	}
 
-	waitMsg := tgbotapi.NewMessage(chatID, "⏳ Создаю таблицу в Google Sheets...")
+	waitMsg := tgbotapi.NewMessage(chatID, b.t("sheets_creating", chatID))
 	b.api.Send(waitMsg)
 
 	// Конвертируем в формат gsheets
-	programData := convertToFITProgramData(program)
+	programData := convertToFITProgramData(program, b.getLanguage(chatID))
 
 	// Создаём таблицу
 	spreadsheetID, err := b.sheetsClient.CreateProgramSpreadsheet(programData)
 	if err != nil {
-		b.sendError(chatID, "Ошибка создания таблицы", err)
+		b.sendError(chatID, b.t("sheets_create_error", chatID), err)
 		b.showFitnessProgramOptions(chatID)
 		return
 	}
 
 	url := gsheets.GetSpreadsheetURL(spreadsheetID)
 
-	b.sendMessage(chatID, fmt.Sprintf("✅ Таблица создана!\n\n📋 %s — %s\n\n🔗 %s",
-		program.ClientName, getFitnessProgramTypeName(string(program.Goal)), url))
+	b.sendMessage(chatID, b.tf("fit_sheets_created", chatID,
+		program.ClientName, fitnessProgramTypeName(string(program.Goal), b.getLanguage(chatID)), url))
 	b.showFitnessProgramOptions(chatID)
 }
 
 // convertToFITProgramData конвертирует models.GeneratedProgram в gsheets.ProgramData
-func convertToFITProgramData(program *models.GeneratedProgram) gsheets.ProgramData {
+func convertToFITProgramData(program *models.GeneratedProgram, lang i18n.Language) gsheets.ProgramData {
 	data := gsheets.ProgramData{
 		ClientName:  program.ClientName,
-		ProgramName: getFitnessProgramTypeName(string(program.Goal)),
+		ProgramName: fitnessProgramTypeName(string(program.Goal), lang),
 		Goal:        string(program.Goal),
 		TotalWeeks:  program.TotalWeeks,
 		DaysPerWeek: program.DaysPerWeek,
@@ -0,0 +1,191 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"workbot/internal/calendar"
+	"workbot/internal/gcalendar"
+	"workbot/internal/i18n"
+	"workbot/internal/repository"
+	"workbot/internal/scheduler"
+)
+
+const (
+	// gcalPushJob — задача отправки изменённых записей в Google Calendar
+	gcalPushJob = "gcal_push"
+	// gcalPushBatch — записей за один запуск задачи
+	gcalPushBatch = 100
+	// gcalBusyDays — на сколько дней вперёд читается занятость (запись — на 30 дней)
+	gcalBusyDays = 31
+	// bookingDuration — длительность записи, см. createAppointment
+	bookingDuration = time.Hour
+)
+
+// googleAction — что сделать с событием записи в Google Calendar
+type googleAction int
+
+const (
+	googleSkip googleAction = iota // события нет и не нужно
+	googleCreate
+	googleUpdate
+	googleDelete
+)
+
+// googleActionFor выбирает действие по статусу записи и ID её события
+func googleActionFor(status, eventID string) googleAction {
+	if status == "cancelled" {
+		if eventID == "" {
+			return googleSkip
+		}
+		return googleDelete
+	}
+	if eventID == "" {
+		return googleCreate
+	}
+	return googleUpdate
+}
+
+// requestGoogleSync запускает отправку записей в Google Calendar сразу после изменения.
+// Если Google недоступен, планировщик повторит задачу
+func (b *Bot) requestGoogleSync() {
+	if b.gcal == nil {
+		return
+	}
+	if err := b.jobs.TriggerNow(gcalPushJob); err != nil {
+		log.Printf("Ошибка запуска синхронизации Google Calendar: %v", err)
+	}
+}
+
+// runGoogleCalendarPush — задача планировщика: создаёт, меняет и удаляет события
+// записей, изменённых после прошлой отправки
+func (b *Bot) runGoogleCalendarPush(ctx context.Context, run scheduler.Run) error {
+	pending, err := b.repo.Appointment.GoogleSyncPending(gcalPushBatch)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	failed := 0
+	for _, a := range pending {
+		eventID, err := b.pushAppointment(ctx, a)
+		if err == nil {
+			err = b.repo.Appointment.MarkGoogleSynced(a.ID, eventID, a.UpdatedAt)
+		}
+		if err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = fmt.Errorf("запись %d: %w", a.ID, err)
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("в Google Calendar не отправлено %d из %d записей: %w", failed, len(pending), firstErr)
+	}
+	return nil
+}
+
+// pushAppointment приводит событие записи в соответствие с ней и возвращает ID события
+func (b *Bot) pushAppointment(ctx context.Context, a repository.GoogleSyncAppointment) (string, error) {
+	switch googleActionFor(a.Status, a.GoogleEventID) {
+	case googleSkip:
+		return "", nil
+	case googleDelete:
+		return "", b.gcal.DeleteEvent(ctx, a.GoogleEventID)
+	case googleUpdate:
+		err := b.gcal.UpdateEvent(ctx, a.GoogleEventID, b.googleEvent(a))
+		if !errors.Is(err, gcalendar.ErrNotFound) {
+			return a.GoogleEventID, err
+		}
+		// Событие удалили в календаре, а запись осталась — создаём заново
+	}
+	return b.gcal.CreateEvent(ctx, b.googleEvent(a))
+}
+
+// googleEvent формирует событие записи на языке тренера; время записи — в его поясе
+func (b *Bot) googleEvent(a repository.GoogleSyncAppointment) gcalendar.Event {
+	lang := b.getLanguage(a.TrainerID)
+	loc := b.userLocation(a.TrainerID)
+	startHour, startMin, _ := calendar.ParseTime(a.StartTime)
+	endHour, endMin, _ := calendar.ParseTime(a.EndTime)
+
+	description := i18n.Tf("gcal_event_description", lang, i18n.T("status_"+a.Status, lang))
+	if a.Notes != "" {
+		description += "\n\n" + a.Notes
+	}
+	return gcalendar.Event{
+		AppointmentID: a.ID,
+		Summary:       i18n.Tf("gcal_event_summary", lang, strings.TrimSpace(a.ClientName+" "+a.ClientSurname)),
+		Description:   description,
+		StartTime:     calendar.CombineDateTimeIn(a.AppointmentDate, startHour, startMin, loc),
+		EndTime:       calendar.CombineDateTimeIn(a.AppointmentDate, endHour, endMin, loc),
+	}
+}
+
+// runGoogleCalendarBusy — задача планировщика: переносит занятость тренера
+// из Google Calendar в trainer_busy_blocks
+func (b *Bot) runGoogleCalendarBusy(ctx context.Context, run scheduler.Run) error {
+	trainerID := b.bookingTrainerID()
+	if trainerID == 0 {
+		return nil
+	}
+	loc := b.userLocation(trainerID)
+	now := time.Now()
+
+	busy, err := b.gcal.BusySlots(ctx, now, now.AddDate(0, 0, gcalBusyDays), loc)
+	if err != nil {
+		return err
+	}
+	blocks := make([]repository.BusyBlock, 0, len(busy))
+	for _, bs := range busy {
+		blocks = append(blocks, repository.BusyBlock{
+			GoogleEventID: bs.EventID,
+			Summary:       bs.Summary,
+			Start:         bs.Start,
+			End:           bs.End,
+		})
+	}
+	return b.repo.Schedule.ReplaceBusyBlocks(trainerID, now, blocks)
+}
+
+// filterGoogleBusy убирает слоты, пересекающиеся с занятостью тренера из Google Calendar
+func (b *Bot) filterGoogleBusy(trainerID int64, date time.Time, slots []string, trainerLoc *time.Location) []string {
+	if b.gcal == nil || len(slots) == 0 {
+		return slots
+	}
+	dayStart := calendar.CombineDateTimeIn(date, 0, 0, trainerLoc)
+	blocks, err := b.repo.Schedule.BusyBlocks(trainerID, dayStart, dayStart.AddDate(0, 0, 1))
+	if err != nil {
+		log.Printf("Ошибка получения занятости тренера: %v", err)
+		return slots
+	}
+	return filterBusySlots(date, slots, blocks, trainerLoc)
+}
+
+// filterBusySlots убирает слоты, запись на которые (bookingDuration) пересекается с занятостью
+func filterBusySlots(date time.Time, slots []string, blocks []repository.BusyBlock, trainerLoc *time.Location) []string {
+	var result []string
+	for _, slot := range slots {
+		hour, minute, err := calendar.ParseTime(slot)
+		if err != nil {
+			continue
+		}
+		start := calendar.CombineDateTimeIn(date, hour, minute, trainerLoc)
+		end := start.Add(bookingDuration)
+		free := true
+		for _, bb := range blocks {
+			if start.Before(bb.End) && bb.Start.Before(end) {
+				free = false
+				break
+			}
+		}
+		if free {
+			result = append(result, slot)
+		}
+	}
+	return result
+}
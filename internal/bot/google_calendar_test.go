@@ -0,0 +1,44 @@
+package bot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"workbot/internal/repository"
+)
+
+func TestGoogleActionFor(t *testing.T) {
+	tests := []struct {
+		status, eventID string
+		want            googleAction
+	}{
+		{"scheduled", "", googleCreate},
+		{"confirmed", "ev1", googleUpdate},
+		{"completed", "ev1", googleUpdate},
+		{"cancelled", "ev1", googleDelete},
+		{"cancelled", "", googleSkip},
+	}
+	for _, tt := range tests {
+		if got := googleActionFor(tt.status, tt.eventID); got != tt.want {
+			t.Errorf("googleActionFor(%s, %q) = %d, want %d", tt.status, tt.eventID, got, tt.want)
+		}
+	}
+}
+
+func TestFilterBusySlots(t *testing.T) {
+	moscow, _ := time.LoadLocation("Europe/Moscow")
+	date := time.Date(2030, 3, 15, 0, 0, 0, 0, time.UTC)
+	blocks := []repository.BusyBlock{
+		// 12:30–13:15 по Москве: заняты записи на 12:00 и 13:00
+		{Start: time.Date(2030, 3, 15, 9, 30, 0, 0, time.UTC), End: time.Date(2030, 3, 15, 10, 15, 0, 0, time.UTC)},
+		// 16:00–17:00: запись на 15:00 заканчивается к началу и свободна
+		{Start: time.Date(2030, 3, 15, 16, 0, 0, 0, moscow), End: time.Date(2030, 3, 15, 17, 0, 0, 0, moscow)},
+	}
+
+	got := filterBusySlots(date, []string{"10:00", "12:00", "13:00", "14:00", "15:00", "16:00", "17:00"}, blocks, moscow)
+	want := []string{"10:00", "14:00", "15:00", "17:00"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("filterBusySlots = %v, want %v", got, want)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"workbot/internal/excel"
+	"workbot/internal/i18n"
 	"workbot/internal/models"
 	"workbot/internal/training"
 
@@ -51,6 +52,7 @@ func (b *Bot) handleCommand(message *tgbotapi.Message) {
 					tgbotapi.NewKeyboardButton(b.t("btn_export_calendar", chatID)),
 				),
 				tgbotapi.NewKeyboardButtonRow(
+					tgbotapi.NewKeyboardButton(b.t("btn_my_balance", chatID)),
 					tgbotapi.NewKeyboardButton(b.t("btn_settings", chatID)),
 				),
 			)
@@ -76,7 +78,7 @@ func (b *Bot) handleCommand(message *tgbotapi.Message) {
 		b.handleInfoCommand(message)
 
 	default:
-		msg := tgbotapi.NewMessage(chatID, "Пока я такого не умею =(")
+		msg := tgbotapi.NewMessage(chatID, b.t("unknown_command_start", chatID))
 		if _, err := b.api.Send(msg); err != nil {
 			log.Printf("Ошибка отправки сообщения: %v", err)
 		}
@@ -87,7 +89,7 @@ func (b *Bot) handleInfoCommand(message *tgbotapi.Message) {
 	rows, err := b.db.Query("SELECT id, name, surname, phone, COALESCE(birth_date, '') FROM public.clients")
 	if err != nil {
 		log.Println("Ошибка запроса клиентов:", err)
-		b.sendError(message.Chat.ID, "Ошибка получения списка клиентов", err)
+		b.sendError(message.Chat.ID, b.t("admin_clients_load_error", message.Chat.ID), err)
 		return
 	}
 	defer rows.Close()
@@ -109,11 +111,11 @@ func (b *Bot) handleInfoCommand(message *tgbotapi.Message) {
 	}
 
 	if len(clients) == 0 {
-		b.sendMessage(message.Chat.ID, "Список клиентов пуст")
+		b.sendMessage(message.Chat.ID, b.t("info_clients_empty", message.Chat.ID))
 		return
 	}
 
-	b.sendMessage(message.Chat.ID, "Список клиентов:\n"+strings.Join(clients, ""))
+	b.sendMessage(message.Chat.ID, b.t("info_clients_title", message.Chat.ID)+"\n"+strings.Join(clients, ""))
 }
 
 func (b *Bot) handleMessage(message *tgbotapi.Message) {
@@ -129,6 +131,12 @@ func (b *Bot) handleMessage(message *tgbotapi.Message) {
 		return
 	}
 
+	// Обработка ручного ввода часового пояса
+	if state == stateTimezoneInput {
+		b.handleTimezoneInput(message)
+		return
+	}
+
 	// Обработка состояний бронирования
 	if strings.HasPrefix(state, "booking_") {
 		b.processBooking(message, state)
@@ -157,6 +165,24 @@ func (b *Bot) handleMessage(message *tgbotapi.Message) {
 		return
 	}
 
+	// Обработка состояний настройки питания и дневной отметки
+	if strings.HasPrefix(state, "nutrition_") {
+		b.processNutritionState(message, state)
+		return
+	}
+
+	// Ввод цели Hyrox и результатов симуляции
+	if strings.HasPrefix(state, "hyrox_") {
+		b.processHyroxState(message, state)
+		return
+	}
+
+	// Пульс покоя в анкете готовности
+	if state == stateReadinessHR {
+		b.handleReadinessHRInput(message)
+		return
+	}
+
 	// Обработка состояний тренировки (ввод веса)
 	if strings.HasPrefix(state, "workout_weight_") {
 		exerciseIDStr := strings.TrimPrefix(state, "workout_weight_")
@@ -164,36 +190,51 @@ func (b *Bot) handleMessage(message *tgbotapi.Message) {
 		return
 	}
 
-	switch message.Text {
-	case "Регистрация", "Registration":
+	// Ввод подхода текстом
+	if strings.HasPrefix(state, "workout_set_") {
+		exerciseIDStr := strings.TrimPrefix(state, "workout_set_")
+		b.handleWorkoutSetInput(message, exerciseIDStr)
+		return
+	}
+
+	switch i18n.Match(message.Text, clientMenuKeys...) {
+	case "btn_registration":
 		b.startRegistration(message)
-	case "Записаться на тренировку", "Book a training":
+	case "btn_book_training":
 		b.handleBookTraining(message)
-	case "Обратная связь", "Feedback":
+	case "btn_feedback":
 		b.handleFeedbackStart(message)
-	case "Мои записи", "My appointments":
+	case "btn_my_appointments":
 		b.handleMyAppointments(message)
-	case "Мои тренировки", "My trainings":
+	case "btn_my_trainings":
 		b.handleMyTrainings(message)
-	case "Экспорт в календарь", "Export to calendar":
+	case "btn_export_calendar":
 		b.handleExportCalendar(message)
-	case "Мой прогресс", "My progress":
+	case "btn_my_progress":
 		b.handleProgressMenu(message)
-	case "📝 Записать прогресс", "📝 Record progress":
+	case "progress_btn_record":
 		b.handleStartProgress(chatID)
-	case "📊 Мой прогресс", "📊 My progress":
+	case "progress_btn_view":
 		b.handleViewProgress(chatID)
-	case "🏋️ Прогресс программы", "🏋️ Program progress":
+	case "progress_btn_program":
 		b.handleClientProgramProgress(chatID)
-	case "📈 Динамика веса", "📈 Weight dynamics":
+	case "progress_btn_weight":
 		b.handleWeightDynamics(chatID)
-	case "📏 Динамика замеров", "📏 Measurements dynamics":
+	case "progress_btn_measurements":
 		b.handleMeasurementsDynamics(chatID)
-	case "⚙️ Настройки", "⚙️ Settings":
+	case "progress_btn_gallery":
+		b.handleProgressGallery(chatID)
+	case "progress_btn_nutrition":
+		b.handleNutrition(chatID)
+	case "progress_btn_hyrox":
+		b.handleHyrox(chatID)
+	case "btn_my_balance":
+		b.handleMyBalance(chatID)
+	case "btn_settings":
 		b.handleSettingsMenu(message)
-	case "Отмена", "Cancel":
+	case "cancel":
 		b.handleCancel(message)
-	case "Назад", "Back":
+	case "back":
 		b.restoreMainMenu(chatID)
 	default:
 		msg := tgbotapi.NewMessage(chatID, b.t("unknown_command_start", chatID))
@@ -203,6 +244,15 @@ func (b *Bot) handleMessage(message *tgbotapi.Message) {
 	}
 }
 
+// clientMenuKeys — ключи кнопок меню клиента; нажатие сопоставляется с ключом на любом языке
+var clientMenuKeys = []string{
+	"btn_registration", "btn_book_training", "btn_feedback", "btn_my_appointments",
+	"btn_my_trainings", "btn_export_calendar", "btn_my_progress", "btn_my_balance", "btn_settings",
+	"progress_btn_record", "progress_btn_view", "progress_btn_program",
+	"progress_btn_weight", "progress_btn_measurements", "progress_btn_gallery",
+	"progress_btn_nutrition", "progress_btn_hyrox", "cancel", "back",
+}
+
 // handleMyTrainings показывает тренировки пользователя
 func (b *Bot) handleMyTrainings(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
@@ -223,7 +273,7 @@ func (b *Bot) handleMyTrainings(message *tgbotapi.Message) {
 	}
 
 	// Fallback: показываем из Excel
-	trainings, err := excel.GetClientTrainings(excel.FilePath, clientID, 5)
+	trainings, err := excel.GetClientTrainings(excel.FilePath, clientID, 5, b.getLanguage(chatID))
 	if err != nil {
 		b.sendError(chatID, b.t("error", chatID), err)
 		return
@@ -250,29 +300,15 @@ func (b *Bot) handleTrainingStart(message *tgbotapi.Message) {
 	var clientID int
 	err := b.db.QueryRow("SELECT id FROM public.clients WHERE telegram_id = $1", chatID).Scan(&clientID)
 	if err != nil {
-		b.sendMessage(chatID, "Вы не зарегистрированы. Нажмите 'Регистрация' для начала.")
+		b.sendMessage(chatID, b.t("reg_not_registered", chatID))
 		return
 	}
 
 	setState(chatID, "awaiting_training")
 
-	helpText := `Введите тренировку в формате:
-
-Жим лежа 4x10x60
-Присед 5x5x100
-Подтягивания 3x12
-Планка 3x60сек
-
-Формат: Упражнение ПодходыxПовторыxВес
+	helpText := b.t("trainings_enter_format", chatID)
 
-Можно указать дату в первой строке:
-13.01.2026
-Жим лежа 4x10x60
-...
-
-Если дата не указана — используется сегодня.`
-
-	b.sendMessageWithKeyboard(chatID, helpText, createCancelKeyboard())
+	b.sendMessageWithKeyboard(chatID, helpText, b.createCancelKeyboard(chatID))
 }
 
 func (b *Bot) handleCancel(message *tgbotapi.Message) {
@@ -288,7 +324,7 @@ func (b *Bot) handleTrainingInput(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.handleCancel(message)
 		return
 	}
@@ -297,13 +333,13 @@ func (b *Bot) handleTrainingInput(message *tgbotapi.Message) {
 
 	exercises, trainingDate, err := training.Parse(text)
 	if err != nil {
-		b.sendMessage(chatID, fmt.Sprintf("Ошибка разбора тренировки: %v", err))
+		b.sendMessage(chatID, b.tf("trainings_parse_error", chatID, err))
 		b.restoreMainMenu(chatID)
 		return
 	}
 
 	if len(exercises) == 0 {
-		b.sendMessage(chatID, "Не найдено ни одного упражнения. Проверьте формат.")
+		b.sendMessage(chatID, b.t("trainings_no_exercises", chatID))
 		b.restoreMainMenu(chatID)
 		return
 	}
@@ -313,14 +349,14 @@ func (b *Bot) handleTrainingInput(message *tgbotapi.Message) {
 	err = b.db.QueryRow("SELECT id, name, surname FROM public.clients WHERE telegram_id = $1", chatID).
 		Scan(&clientID, &name, &surname)
 	if err != nil {
-		b.sendMessage(chatID, "Ошибка: клиент не найден.")
+		b.sendMessage(chatID, b.t("reg_not_registered", chatID))
 		b.restoreMainMenu(chatID)
 		return
 	}
 
 	err = excel.SaveTrainingToExcel(excel.FilePath, b.db, clientID, name, surname, trainingDate, exercises)
 	if err != nil {
-		b.sendError(chatID, fmt.Sprintf("Ошибка сохранения: %v", err), err)
+		b.sendError(chatID, b.tf("trainings_save_error", chatID, err), err)
 		b.restoreMainMenu(chatID)
 		return
 	}
@@ -359,6 +395,7 @@ func (b *Bot) restoreMainMenu(chatID int64) {
 				tgbotapi.NewKeyboardButton(b.t("btn_export_calendar", chatID)),
 			),
 			tgbotapi.NewKeyboardButtonRow(
+				tgbotapi.NewKeyboardButton(b.t("btn_my_balance", chatID)),
 				tgbotapi.NewKeyboardButton(b.t("btn_settings", chatID)),
 			),
 		)
@@ -54,11 +54,39 @@ func parseIDFromBrackets(text string) int {
 	return id
 }
 
-// createCancelKeyboard creates a simple keyboard with just Cancel button
-func createCancelKeyboard() tgbotapi.ReplyKeyboardMarkup {
+// countButtons builds plural-aware labels like "4 недели" / "4 weeks" for the given counts
+func (b *Bot) countButtons(key string, chatID int64, counts ...int) []tgbotapi.KeyboardButton {
+	buttons := make([]tgbotapi.KeyboardButton, 0, len(counts))
+	for _, n := range counts {
+		buttons = append(buttons, tgbotapi.NewKeyboardButton(b.tn(key, chatID, n)))
+	}
+	return buttons
+}
+
+// parseCountButton extracts the leading number from a countButtons label
+// and reports whether it is one of the offered options
+func parseCountButton(text string, options ...int) (int, bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, false
+	}
+	for _, option := range options {
+		if n == option {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// createCancelKeyboard creates a simple keyboard with just Cancel button in the user's language
+func (b *Bot) createCancelKeyboard(chatID int64) tgbotapi.ReplyKeyboardMarkup {
 	return tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 }
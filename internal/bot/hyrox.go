@@ -0,0 +1,451 @@
+package bot
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/lib/pq"
+
+	"workbot/internal/hyrox"
+	"workbot/internal/i18n"
+)
+
+// Состояния ввода Hyrox: цель с замерами и результаты симуляции
+const (
+	stateHyroxPlan = "hyrox_plan"
+	stateHyroxSim  = "hyrox_sim"
+)
+
+const (
+	// hyroxSimulationsShown — сколько симуляций показывать под раскладкой
+	hyroxSimulationsShown = 4
+	// hyroxLossesShown — сколько групп с наибольшей потерей времени выводить
+	hyroxLossesShown = 3
+)
+
+// hyroxPlan — последняя раскладка клиента; сама раскладка пересчитывается из цели и замеров
+type hyroxPlan struct {
+	ID         int
+	Benchmarks hyrox.Benchmarks
+	Plan       hyrox.Plan
+	CreatedAt  time.Time
+}
+
+// hyroxSimulation — записанная симуляция
+type hyroxSimulation struct {
+	CreatedAt time.Time
+	Splits    []time.Duration // по отрезкам hyrox.Layout()
+}
+
+// loadHyroxPlan загружает последнюю раскладку; nil — клиент её ещё не задавал
+func (b *Bot) loadHyroxPlan(clientID int) (*hyroxPlan, error) {
+	var p hyroxPlan
+	var targetSec int
+	var raw []byte
+	err := b.db.QueryRow(`
+		SELECT id, target_sec, benchmarks, created_at FROM public.hyrox_plans
+		WHERE client_id = $1
+		ORDER BY created_at DESC, id DESC LIMIT 1`, clientID).Scan(&p.ID, &targetSec, &raw, &p.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var benchSec map[string]int
+	if err := json.Unmarshal(raw, &benchSec); err != nil {
+		return nil, err
+	}
+	p.Benchmarks = make(hyrox.Benchmarks, len(benchSec))
+	for id, sec := range benchSec {
+		p.Benchmarks[id] = time.Duration(sec) * time.Second
+	}
+	p.Plan, err = hyrox.PlanRace(time.Duration(targetSec)*time.Second, p.Benchmarks)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// saveHyroxPlan записывает цель и замеры
+func (b *Bot) saveHyroxPlan(clientID int, plan hyrox.Plan, bench hyrox.Benchmarks) error {
+	benchSec := make(map[string]int, len(bench))
+	for id, d := range bench {
+		benchSec[id] = int(d.Seconds())
+	}
+	raw, err := json.Marshal(benchSec)
+	if err != nil {
+		return err
+	}
+	_, err = b.db.Exec(`
+		INSERT INTO public.hyrox_plans (client_id, target_sec, predicted_sec, benchmarks)
+		VALUES ($1, $2, $3, $4)`,
+		clientID, int(plan.Target.Seconds()), int(plan.Predicted.Seconds()), raw)
+	return err
+}
+
+// loadHyroxSimulations загружает последние симуляции по раскладке, новые первыми
+func (b *Bot) loadHyroxSimulations(planID, limit int) ([]hyroxSimulation, error) {
+	rows, err := b.db.Query(`
+		SELECT created_at, splits FROM public.hyrox_simulations
+		WHERE plan_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2`, planID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sims []hyroxSimulation
+	for rows.Next() {
+		var s hyroxSimulation
+		var splits []int64
+		if err := rows.Scan(&s.CreatedAt, pq.Array(&splits)); err != nil {
+			return nil, err
+		}
+		for _, sec := range splits {
+			s.Splits = append(s.Splits, time.Duration(sec)*time.Second)
+		}
+		sims = append(sims, s)
+	}
+	return sims, rows.Err()
+}
+
+// saveHyroxSimulation записывает симуляцию и её разбор
+func (b *Bot) saveHyroxSimulation(clientID, planID int, splits []time.Duration, a hyrox.Analysis) error {
+	splitSec := make([]int64, len(splits))
+	for i, d := range splits {
+		splitSec[i] = int64(d.Seconds())
+	}
+	focus := a.Focus
+	if focus == nil {
+		focus = []string{}
+	}
+	_, err := b.db.Exec(`
+		INSERT INTO public.hyrox_simulations (client_id, plan_id, splits, total_sec, delta_sec, run_fade, focus)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		clientID, planID, pq.Array(splitSec), int(a.Actual.Seconds()), int(a.Delta().Seconds()),
+		math.Round(math.Max(-9.999, math.Min(a.RunFade, 9.999))*1000)/1000, pq.Array(focus))
+	return err
+}
+
+// loadHyroxProfile — раскладка и акцент последней симуляции для генератора программ;
+// пустые значения — клиент раскладку не задавал
+func (b *Bot) loadHyroxProfile(clientID int) (splits map[string]int, focus []string, fading bool) {
+	p, err := b.loadHyroxPlan(clientID)
+	if err != nil {
+		log.Printf("Ошибка чтения раскладки Hyrox: %v", err)
+		return nil, nil, false
+	}
+	if p == nil {
+		return nil, nil, false
+	}
+	sims, err := b.loadHyroxSimulations(p.ID, 1)
+	if err != nil {
+		log.Printf("Ошибка чтения симуляций Hyrox: %v", err)
+	}
+	if len(sims) > 0 {
+		if a, err := hyrox.Analyze(p.Plan, sims[0].Splits); err == nil {
+			focus, fading = a.Focus, a.Fading()
+		}
+	}
+	return p.Plan.Splits(), focus, fading
+}
+
+// handleHyrox открывает клиенту раскладку забега
+func (b *Bot) handleHyrox(chatID int64) {
+	clientID, err := b.repo.Program.GetClientByTelegramID(chatID)
+	if err != nil || clientID == 0 {
+		b.sendMessage(chatID, b.t("reg_not_registered", chatID))
+		return
+	}
+	b.showHyrox(chatID, clientID, 0)
+}
+
+// showHyrox показывает раскладку забега и последние симуляции. Клиенту — с кнопками
+// ввода, тренеру — только просмотр
+func (b *Bot) showHyrox(chatID int64, clientID int, messageID int) {
+	p, err := b.loadHyroxPlan(clientID)
+	if err != nil {
+		b.sendError(chatID, b.t("hyrox_load_error", chatID), err)
+		return
+	}
+	owner := b.isClientOwner(chatID, clientID)
+
+	var text strings.Builder
+	text.WriteString(b.t("hyrox_title", chatID) + "\n")
+	if !owner {
+		if client, _ := b.repo.Client.GetByID(clientID); client != nil {
+			text.WriteString(b.tf("chart_client", chatID, client.Name, client.Surname) + "\n")
+		}
+	}
+	text.WriteString("\n")
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if p == nil {
+		text.WriteString(b.t("hyrox_no_plan", chatID))
+	} else {
+		text.WriteString(b.formatHyroxPlan(chatID, p))
+
+		sims, err := b.loadHyroxSimulations(p.ID, hyroxSimulationsShown)
+		if err != nil {
+			log.Printf("Ошибка чтения симуляций Hyrox клиента %d: %v", clientID, err)
+		}
+		for i, s := range sims {
+			a, err := hyrox.Analyze(p.Plan, s.Splits)
+			if err != nil {
+				continue
+			}
+			if i == 0 {
+				text.WriteString("\n\n" + b.formatHyroxAnalysis(chatID, s.CreatedAt, a))
+				if len(sims) > 1 {
+					text.WriteString("\n\n" + b.t("hyrox_history_title", chatID))
+				}
+				continue
+			}
+			text.WriteString("\n" + b.tf("hyrox_history_row", chatID,
+				s.CreatedAt.In(b.userLocation(chatID)).Format("02.01"), hyrox.FormatTime(a.Actual), signedTime(a.Delta())))
+		}
+	}
+
+	if owner {
+		row := tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("hyrox_btn_plan", chatID), hyroxCallback("plan", clientID)))
+		if p != nil {
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(b.t("hyrox_btn_sim", chatID), hyroxCallback("sim", clientID)))
+		}
+		rows = append(rows, row)
+	} else if messageID > 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("workout_btn_back_progress", chatID), fmt.Sprintf("prog_back_%d", clientID))))
+	}
+	b.sendOrEditInline(chatID, messageID, text.String(), rows)
+}
+
+// formatHyroxPlan — цель, прогноз и целевое время каждого круга
+func (b *Bot) formatHyroxPlan(chatID int64, p *hyroxPlan) string {
+	plan := p.Plan
+	var text strings.Builder
+	text.WriteString(b.tf("hyrox_target", chatID, hyrox.FormatTime(plan.Target), hyrox.FormatTime(plan.Predicted)) + "\n")
+	if !plan.Realistic() {
+		text.WriteString(b.tf("hyrox_unrealistic", chatID, int(math.Round((1-plan.Scale())*100))) + "\n")
+	}
+	text.WriteString(b.tf("hyrox_run_pace", chatID, hyrox.FormatTime(plan.RunPace())) + "\n\n")
+
+	for i, id := range hyrox.Stations {
+		run, _ := plan.Segment(hyrox.RunID(i + 1))
+		station, _ := plan.Segment(id)
+		text.WriteString(b.tf("hyrox_split_row", chatID, i+1, hyrox.FormatTime(run.Target),
+			hyrox.StationName(id), hyrox.FormatTime(station.Target)) + "\n")
+	}
+	roxzone, _ := plan.Segment(hyrox.Roxzone)
+	text.WriteString(b.tf("hyrox_roxzone", chatID, hyrox.FormatTime(roxzone.Target)))
+
+	if len(plan.Estimated) > 0 {
+		names := make([]string, 0, len(plan.Estimated))
+		for _, id := range plan.Estimated {
+			names = append(names, hyrox.StationName(id))
+		}
+		text.WriteString("\n\n" + b.tf("hyrox_estimated", chatID, strings.Join(names, ", ")))
+	}
+	return text.String()
+}
+
+// formatHyroxAnalysis — итог симуляции: где потеряно время, просадка бега и акцент
+func (b *Bot) formatHyroxAnalysis(chatID int64, at time.Time, a hyrox.Analysis) string {
+	var text strings.Builder
+	text.WriteString(b.tf("hyrox_sim_title", chatID, at.In(b.userLocation(chatID)).Format("02.01"),
+		hyrox.FormatTime(a.Actual), signedTime(a.Delta())) + "\n")
+
+	var losses []string
+	for _, g := range a.Groups {
+		if len(losses) == hyroxLossesShown || g.Delta <= 0 {
+			break
+		}
+		losses = append(losses, b.tf("hyrox_loss_row", chatID, b.hyroxGroupName(chatID, g.ID),
+			signedTime(g.Delta), int(math.Round(g.Pct()*100))))
+	}
+	if len(losses) == 0 {
+		text.WriteString(b.t("hyrox_no_losses", chatID))
+	} else {
+		text.WriteString(b.t("hyrox_losses_title", chatID) + "\n" + strings.Join(losses, "\n"))
+	}
+
+	if a.Fading() {
+		text.WriteString("\n" + b.tf("hyrox_fading", chatID, int(math.Round(a.RunFade*100))))
+	}
+	if len(a.Focus) > 0 {
+		names := make([]string, 0, len(a.Focus))
+		for _, id := range a.Focus {
+			names = append(names, b.hyroxGroupName(chatID, id))
+		}
+		text.WriteString("\n" + b.tf("hyrox_focus", chatID, strings.Join(names, ", ")))
+	}
+	return text.String()
+}
+
+// hyroxGroupName — название группы отрезков для сообщения
+func (b *Bot) hyroxGroupName(chatID int64, id string) string {
+	switch id {
+	case hyrox.Run:
+		return b.t("hyrox_group_run", chatID)
+	case hyrox.Roxzone:
+		return b.t("hyrox_group_roxzone", chatID)
+	}
+	return hyrox.StationName(id)
+}
+
+// signedTime — отклонение со знаком: «+0:45», «-0:20»
+func signedTime(d time.Duration) string {
+	if d >= 0 {
+		return "+" + hyrox.FormatTime(d)
+	}
+	return hyrox.FormatTime(d)
+}
+
+// hyroxCallback формирует данные кнопки: hyrox_<действие>_<клиент>
+func hyroxCallback(action string, clientID int) string {
+	return fmt.Sprintf("hyrox_%s_%d", action, clientID)
+}
+
+// parseHyroxCallback разбирает данные кнопки Hyrox
+func parseHyroxCallback(data string) (action string, clientID int, ok bool) {
+	parts := strings.Split(data, "_")
+	if len(parts) != 3 || parts[0] != "hyrox" {
+		return "", 0, false
+	}
+	clientID, err := strconv.Atoi(parts[2])
+	if err != nil || clientID <= 0 {
+		return "", 0, false
+	}
+	return parts[1], clientID, true
+}
+
+// handleHyroxCallback обрабатывает кнопки экрана Hyrox; вводить цель и симуляции
+// может только сам клиент
+func (b *Bot) handleHyroxCallback(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	b.api.Send(tgbotapi.NewCallback(callback.ID, ""))
+
+	action, clientID, ok := parseHyroxCallback(callback.Data)
+	if !ok || !b.isClientOwner(chatID, clientID) {
+		return
+	}
+
+	switch action {
+	case "plan":
+		setState(chatID, stateHyroxPlan)
+		b.sendMessageWithKeyboard(chatID, b.t("hyrox_ask_plan", chatID), b.createCancelKeyboard(chatID))
+	case "sim":
+		var order strings.Builder
+		for i, id := range hyrox.Stations {
+			order.WriteString(b.tf("hyrox_order_row", chatID, i+1, hyrox.StationName(id)) + "\n")
+		}
+		setState(chatID, stateHyroxSim)
+		b.sendMessageWithKeyboard(chatID, b.tf("hyrox_ask_sim", chatID, order.String()), b.createCancelKeyboard(chatID))
+	}
+}
+
+// processHyroxState обрабатывает ввод цели с замерами и результатов симуляции
+func (b *Bot) processHyroxState(message *tgbotapi.Message, state string) {
+	chatID := message.Chat.ID
+	text := strings.TrimSpace(message.Text)
+
+	if i18n.Is(text, "cancel") {
+		clearState(chatID)
+		b.sendMessage(chatID, "❌ "+b.t("cancelled", chatID))
+		b.restoreMainMenu(chatID)
+		return
+	}
+
+	clientID, err := b.repo.Program.GetClientByTelegramID(chatID)
+	if err != nil || clientID == 0 {
+		clearState(chatID)
+		b.sendMessage(chatID, b.t("reg_not_registered", chatID))
+		return
+	}
+
+	switch state {
+	case stateHyroxPlan:
+		target, bench, err := hyrox.ParseBenchmarks(text)
+		if err != nil {
+			b.sendMessage(chatID, b.tf("hyrox_parse_error", chatID, err))
+			return
+		}
+		plan, err := hyrox.PlanRace(target, bench)
+		if err != nil {
+			b.sendMessage(chatID, b.tf("hyrox_parse_error", chatID, err))
+			return
+		}
+		clearState(chatID)
+		if err := b.saveHyroxPlan(clientID, plan, bench); err != nil {
+			b.sendError(chatID, b.t("hyrox_save_error", chatID), err)
+			b.restoreMainMenu(chatID)
+			return
+		}
+		b.sendMessage(chatID, b.t("hyrox_plan_saved", chatID))
+
+	case stateHyroxSim:
+		p, err := b.loadHyroxPlan(clientID)
+		if err != nil || p == nil {
+			clearState(chatID)
+			b.sendError(chatID, b.t("hyrox_load_error", chatID), err)
+			b.restoreMainMenu(chatID)
+			return
+		}
+		splits, err := hyrox.ParseSplits(text)
+		if err != nil {
+			b.sendMessage(chatID, b.tf("hyrox_parse_error", chatID, err))
+			return
+		}
+		a, err := hyrox.Analyze(p.Plan, splits)
+		if err != nil {
+			b.sendMessage(chatID, b.tf("hyrox_parse_error", chatID, err))
+			return
+		}
+		clearState(chatID)
+		if err := b.saveHyroxSimulation(clientID, p.ID, splits, a); err != nil {
+			b.sendError(chatID, b.t("hyrox_save_error", chatID), err)
+			b.restoreMainMenu(chatID)
+			return
+		}
+		b.sendMessage(chatID, b.t("hyrox_sim_saved", chatID))
+		b.notifyTrainerHyrox(clientID, a)
+
+	default:
+		clearState(chatID)
+	}
+
+	b.restoreMainMenu(chatID)
+	b.showHyrox(chatID, clientID, 0)
+}
+
+// notifyTrainerHyrox отправляет тренеру разбор симуляции клиента
+func (b *Bot) notifyTrainerHyrox(clientID int, a hyrox.Analysis) {
+	trainerID, err := b.repo.Admin.GetFirst()
+	if err != nil {
+		log.Printf("Ошибка получения тренера: %v", err)
+		return
+	}
+	client, _ := b.repo.Client.GetByID(clientID)
+	if client == nil {
+		return
+	}
+
+	text := b.tf("hyrox_trainer_title", trainerID, client.Name, client.Surname) +
+		"\n\n" + b.formatHyroxAnalysis(trainerID, time.Now(), a)
+	msg := tgbotapi.NewMessage(trainerID, text)
+	msg.ParseMode = "Markdown"
+	if err := b.outbox.Enqueue(msg); err != nil {
+		log.Printf("Ошибка постановки уведомления о симуляции Hyrox в очередь: %v", err)
+	}
+}
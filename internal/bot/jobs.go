@@ -0,0 +1,228 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"workbot/internal/i18n"
+	"workbot/internal/scheduler"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	// appointmentReminderPeriod — период задачи напоминаний о тренировках
+	appointmentReminderPeriod = 10 * time.Minute
+	// outboxSentRetention и outboxFailedRetention — сколько хранить отправленные и неотправленные уведомления
+	outboxSentRetention   = 7 * 24 * time.Hour
+	outboxFailedRetention = 30 * 24 * time.Hour
+)
+
+// registerJobs регистрирует фоновые задачи бота в планировщике
+func (b *Bot) registerJobs() error {
+	jobs := []scheduler.Job{
+		{
+			Name:        "birthday_digest",
+			Description: i18n.T("job_desc_birthday_digest", i18n.DefaultLang),
+			Schedule:    "*/15 * * * *",
+			Handler:     b.runBirthdayDigest,
+		},
+		{
+			Name:        "appointment_reminders",
+			Description: i18n.T("job_desc_appointment_reminders", i18n.DefaultLang),
+			Schedule:    fmt.Sprintf("*/%d * * * *", int(appointmentReminderPeriod.Minutes())),
+			Handler:     b.runAppointmentReminders,
+		},
+		{
+			Name:        "nutrition_weekly",
+			Description: i18n.T("job_desc_nutrition_weekly", i18n.DefaultLang),
+			Schedule:    "0 7 * * 1",
+			Handler:     b.runNutritionWeekly,
+		},
+		{
+			Name:        "nutrition_reminders",
+			Description: i18n.T("job_desc_nutrition_reminders", i18n.DefaultLang),
+			Schedule:    "*/15 * * * *",
+			Handler:     b.runNutritionReminders,
+		},
+		{
+			Name:        "billing_reminders",
+			Description: i18n.T("job_desc_billing_reminders", i18n.DefaultLang),
+			Schedule:    "0 10 * * *",
+			Handler:     b.runBillingReminders,
+		},
+		{
+			Name:        "outbox_cleanup",
+			Description: i18n.T("job_desc_outbox_cleanup", i18n.DefaultLang),
+			Schedule:    "0 4 * * *",
+			Handler:     b.runOutboxCleanup,
+		},
+	}
+	if b.sheetsQueue != nil {
+		jobs = append(jobs, scheduler.Job{
+			Name:        "sheets_sync",
+			Description: i18n.T("job_desc_sheets_sync", i18n.DefaultLang),
+			Schedule:    "@every 2m",
+			Handler: func(ctx context.Context, run scheduler.Run) error {
+				return b.sheetsQueue.Drain(ctx)
+			},
+		})
+	}
+	if b.gcal != nil {
+		jobs = append(jobs,
+			scheduler.Job{
+				Name:        gcalPushJob,
+				Description: i18n.T("job_desc_gcal_push", i18n.DefaultLang),
+				Schedule:    "@every 5m",
+				Handler:     b.runGoogleCalendarPush,
+			},
+			scheduler.Job{
+				Name:        "gcal_busy",
+				Description: i18n.T("job_desc_gcal_busy", i18n.DefaultLang),
+				Schedule:    "@every 15m",
+				Handler:     b.runGoogleCalendarBusy,
+			},
+		)
+	}
+	for _, job := range jobs {
+		if err := b.jobs.Register(job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runOutboxCleanup — задача планировщика: удаляет из очереди отправленные
+// уведомления старше недели и неотправленные старше месяца
+func (b *Bot) runOutboxCleanup(ctx context.Context, run scheduler.Run) error {
+	deleted, err := b.outbox.Cleanup(outboxSentRetention, outboxFailedRetention)
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Printf("Очередь сообщений: удалено %d старых записей", deleted)
+	}
+	return nil
+}
+
+// handleJobsCommand показывает тренеру состояние фоновых задач (/jobs)
+func (b *Bot) handleJobsCommand(chatID int64, messageID int) {
+	statuses, err := b.jobs.Status()
+	if err != nil {
+		b.sendError(chatID, b.t("jobs_load_error", chatID), err)
+		return
+	}
+
+	text := formatJobStatuses(statuses, time.Now(), b.userLocation(chatID), b.getLanguage(chatID))
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, st := range statuses {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.tf("jobs_btn_run", chatID, st.Name), "job_run_"+st.Name),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("jobs_btn_refresh", chatID), "job_refresh"),
+	))
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	if messageID > 0 {
+		edit := tgbotapi.NewEditMessageText(chatID, messageID, text)
+		edit.ReplyMarkup = &keyboard
+		b.api.Send(edit)
+		return
+	}
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = keyboard
+	b.api.Send(msg)
+}
+
+// handleJobCallback обрабатывает кнопки экрана фоновых задач
+func (b *Bot) handleJobCallback(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	data := callback.Data
+
+	if !b.isAdmin(chatID) {
+		b.api.Send(tgbotapi.NewCallback(callback.ID, ""))
+		return
+	}
+
+	switch {
+	case data == "job_refresh":
+		b.api.Send(tgbotapi.NewCallback(callback.ID, ""))
+
+	case strings.HasPrefix(data, "job_run_"):
+		name := strings.TrimPrefix(data, "job_run_")
+		if err := b.jobs.TriggerNow(name); err != nil {
+			b.api.Send(tgbotapi.NewCallback(callback.ID, b.tf("jobs_run_error", chatID, err)))
+			return
+		}
+		b.api.Send(tgbotapi.NewCallback(callback.ID, b.t("jobs_run_scheduled", chatID)))
+	}
+
+	b.handleJobsCommand(chatID, messageID)
+}
+
+// formatJobStatuses формирует текст экрана фоновых задач; время показывается в поясе loc.
+// Описание задачи берётся из ключа job_desc_<имя>, если он есть в локализации
+func formatJobStatuses(statuses []scheduler.JobStatus, now time.Time, loc *time.Location, lang i18n.Language) string {
+	if len(statuses) == 0 {
+		return i18n.T("jobs_empty", lang)
+	}
+
+	const layout = "02.01 15:04"
+	var text strings.Builder
+	text.WriteString(i18n.T("jobs_title", lang) + "\n")
+
+	for _, st := range statuses {
+		icon := "✅"
+		switch {
+		case st.Running:
+			icon = "⏳"
+		case st.LastError != "":
+			icon = "❌"
+		case !st.LastRunAt.Valid:
+			icon = "🆕"
+		}
+
+		text.WriteString(fmt.Sprintf("\n%s %s\n", icon, st.Name))
+		description := st.Description
+		if descKey := "job_desc_" + st.Name; i18n.Match(description, descKey) == descKey {
+			description = i18n.T(descKey, lang)
+		}
+		if description != "" {
+			text.WriteString(fmt.Sprintf("   %s\n", description))
+		}
+		text.WriteString("   " + i18n.Tf("jobs_schedule", lang, st.Schedule) + "\n")
+
+		if st.LastRunAt.Valid {
+			text.WriteString("   " + i18n.Tf("jobs_last_run", lang,
+				st.LastRunAt.Time.In(loc).Format(layout), float64(st.LastDurationMs)/1000) + "\n")
+		}
+		if st.LastSuccessAt.Valid && st.LastError != "" {
+			text.WriteString("   " + i18n.Tf("jobs_last_success", lang, st.LastSuccessAt.Time.In(loc).Format(layout)) + "\n")
+		}
+		if st.LastError != "" {
+			errText := st.LastError
+			if r := []rune(errText); len(r) > 200 {
+				errText = string(r[:200]) + "…"
+			}
+			text.WriteString("   " + i18n.Tf("jobs_last_error", lang, st.Attempts, errText) + "\n")
+		}
+
+		if st.Running {
+			text.WriteString("   " + i18n.T("jobs_running", lang) + "\n")
+		} else if st.NextRunAt.After(now) {
+			text.WriteString("   " + i18n.Tf("jobs_next_run", lang, st.NextRunAt.In(loc).Format(layout)) + "\n")
+		} else {
+			text.WriteString("   " + i18n.T("jobs_overdue", lang) + "\n")
+		}
+		text.WriteString("   " + i18n.Tf("jobs_counts", lang, st.RunCount, st.FailCount) + "\n")
+	}
+
+	return text.String()
+}
@@ -1,7 +1,9 @@
 package bot
 
 import (
+	"fmt"
 	"log"
+	"strings"
 	"sync"
 
 	"workbot/internal/i18n"
@@ -25,11 +27,15 @@ func (b *Bot) getLanguage(telegramID int64) i18n.Language {
 	}
 	langCache.RUnlock()
 
-	// Запрос к БД
+	// Запрос к БД: сначала язык тренера, затем клиента
 	var langStr string
-	err := b.db.QueryRow("SELECT COALESCE(language, 'ru') FROM public.clients WHERE telegram_id = $1", telegramID).Scan(&langStr)
+	err := b.db.QueryRow(`
+		SELECT COALESCE(
+			(SELECT language FROM public.admins WHERE telegram_id = $1),
+			(SELECT language FROM public.clients WHERE telegram_id = $1 AND deleted_at IS NULL LIMIT 1),
+			'')`, telegramID).Scan(&langStr)
 	if err != nil {
-		// Если клиент не найден — используем русский
+		// Если пользователь не найден — используем язык по умолчанию
 		return i18n.DefaultLang
 	}
 
@@ -43,9 +49,15 @@ func (b *Bot) getLanguage(telegramID int64) i18n.Language {
 	return lang
 }
 
-// setLanguage устанавливает язык пользователя
+// setLanguage устанавливает язык тренера или клиента
 func (b *Bot) setLanguage(telegramID int64, lang i18n.Language) error {
-	_, err := b.db.Exec("UPDATE public.clients SET language = $1 WHERE telegram_id = $2", string(lang), telegramID)
+	table := "clients"
+	if b.isAdmin(telegramID) {
+		table = "admins"
+	}
+	_, err := b.db.Exec(
+		fmt.Sprintf("UPDATE public.%s SET language = $1 WHERE telegram_id = $2", table),
+		string(lang), telegramID)
 	if err != nil {
 		return err
 	}
@@ -77,6 +89,12 @@ func (b *Bot) tf(key string, telegramID int64, args ...interface{}) string {
 	return i18n.Tf(key, lang, args...)
 }
 
+// tn возвращает перевод с учётом числа n (формы key.one, key.few, key.many, key.other)
+func (b *Bot) tn(key string, telegramID int64, n int, args ...interface{}) string {
+	lang := b.getLanguage(telegramID)
+	return i18n.Tn(key, lang, n, args...)
+}
+
 // handleSettingsMenu показывает меню настроек
 func (b *Bot) handleSettingsMenu(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
@@ -84,7 +102,7 @@ func (b *Bot) handleSettingsMenu(message *tgbotapi.Message) {
 	langName := i18n.GetLanguageName(lang)
 	langFlag := i18n.GetLanguageFlag(lang)
 
-	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+	rows := [][]tgbotapi.InlineKeyboardButton{
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
 				b.tf("settings_language", chatID, langFlag+" "+langName),
@@ -92,9 +110,21 @@ func (b *Bot) handleSettingsMenu(message *tgbotapi.Message) {
 			),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(b.t("back", chatID), "settings_back"),
+			tgbotapi.NewInlineKeyboardButtonData(
+				b.tf("settings_timezone", chatID, b.timezoneLabel(chatID)),
+				"settings_timezone",
+			),
 		),
-	)
+	}
+	if b.calendarFeedEnabled() {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("settings_calendar_feed", chatID), "settings_calfeed"),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("back", chatID), "settings_back"),
+	))
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
 
 	msg := tgbotapi.NewMessage(chatID, b.t("settings_title", chatID))
 	msg.ReplyMarkup = keyboard
@@ -105,15 +135,26 @@ func (b *Bot) handleSettingsMenu(message *tgbotapi.Message) {
 
 // handleLanguageSelection показывает выбор языка
 func (b *Bot) handleLanguageSelection(chatID int64, messageID int) {
-	keyboard := tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("🇷🇺 Русский", "lang_ru"),
-			tgbotapi.NewInlineKeyboardButtonData("🇬🇧 English", "lang_en"),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(b.t("back", chatID), "settings_back"),
-		),
-	)
+	// Кнопки строятся по загруженным файлам локализации, по два языка в ряд
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+	for _, lang := range i18n.Languages() {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			i18n.GetLanguageFlag(lang)+" "+i18n.GetLanguageName(lang),
+			"lang_"+string(lang),
+		))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("back", chatID), "settings_back"),
+	))
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
 
 	edit := tgbotapi.NewEditMessageText(chatID, messageID, b.t("settings_select_language", chatID))
 	edit.ReplyMarkup = &keyboard
@@ -140,7 +181,7 @@ func (b *Bot) handleLanguageChange(chatID int64, messageID int, langCode string)
 	}
 
 	// Обновляем главное меню
-	b.restoreMainMenu(chatID)
+	b.restoreMenu(chatID, &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: chatID}})
 }
 
 // handleSettingsCallback обрабатывает callback-запросы настроек
@@ -152,15 +193,21 @@ func (b *Bot) handleSettingsCallback(callback *tgbotapi.CallbackQuery) {
 	// Подтверждаем получение callback
 	b.api.Send(tgbotapi.NewCallback(callback.ID, ""))
 
-	switch data {
-	case "settings_language":
+	switch {
+	case data == "settings_language":
 		b.handleLanguageSelection(chatID, messageID)
-	case "lang_ru":
-		b.handleLanguageChange(chatID, messageID, "ru")
-	case "lang_en":
-		b.handleLanguageChange(chatID, messageID, "en")
-	case "settings_back":
-		b.restoreMainMenu(chatID)
+	case data == "settings_timezone":
+		b.showTimezonePicker(chatID, messageID, b.t("tz_select", chatID))
+	case data == "settings_calfeed":
+		b.handleCalendarFeedSettings(chatID, messageID, false)
+	case data == "settings_calfeed_new":
+		b.handleCalendarFeedSettings(chatID, messageID, true)
+	case data == "settings_calfeed_off":
+		b.handleCalendarFeedRevoke(chatID, messageID)
+	case strings.HasPrefix(data, "lang_"):
+		b.handleLanguageChange(chatID, messageID, strings.TrimPrefix(data, "lang_"))
+	case data == "settings_back":
+		b.restoreMenu(chatID, &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: chatID}})
 		// Удаляем inline-сообщение
 		b.api.Send(tgbotapi.NewDeleteMessage(chatID, messageID))
 	}
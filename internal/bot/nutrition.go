@@ -0,0 +1,1089 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"workbot/internal/calendar"
+	"workbot/internal/i18n"
+	"workbot/internal/nutrition"
+	"workbot/internal/scheduler"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Состояния настройки питания и дневной отметки
+const (
+	stateNutritionSex      = "nutrition_sex"
+	stateNutritionHeight   = "nutrition_height"
+	stateNutritionAge      = "nutrition_age"
+	stateNutritionActivity = "nutrition_activity"
+	stateNutritionGoal     = "nutrition_goal"
+	stateNutritionCalories = "nutrition_calories"
+	stateNutritionProtein  = "nutrition_protein"
+	stateNutritionSteps    = "nutrition_steps"
+	stateNutritionSleep    = "nutrition_sleep"
+)
+
+const (
+	// nutritionSummaryDays — за сколько дней показывается сводка отметок
+	nutritionSummaryDays = 7
+	// bodyFatMaxAge — процент жира старше этого срока в расчёте не используется
+	bodyFatMaxAge = 90 * 24 * time.Hour
+)
+
+// nutritionSetupSteps — шаги настройки по порядку; известные значения пропускаются
+var nutritionSetupSteps = []string{
+	stateNutritionSex, stateNutritionHeight, stateNutritionAge, stateNutritionActivity, stateNutritionGoal,
+}
+
+// nutritionCheckinSteps — шаги дневной отметки
+var nutritionCheckinSteps = []string{
+	stateNutritionCalories, stateNutritionProtein, stateNutritionSteps, stateNutritionSleep,
+}
+
+// NutritionState хранит состояние настройки питания или дневной отметки
+type NutritionState struct {
+	ClientID int
+	Step     int // индекс в nutritionSetupSteps или nutritionCheckinSteps
+	Sex      nutrition.Sex
+	HeightCm float64
+	Age      int
+	Activity nutrition.Activity
+	Goal     nutrition.Goal
+	Checkin  nutrition.Checkin
+}
+
+var nutritionStore = struct {
+	sync.Mutex
+	data map[int64]*NutritionState
+}{data: make(map[int64]*NutritionState)}
+
+// needs сообщает, что на шаге настройки нужно спросить клиента
+func (s *NutritionState) needs(step string) bool {
+	switch step {
+	case stateNutritionSex:
+		return s.Sex == ""
+	case stateNutritionHeight:
+		return s.HeightCm == 0
+	case stateNutritionAge:
+		return s.Age == 0
+	}
+	return true
+}
+
+// nutritionPlan — профиль питания клиента из nutrition_profiles
+type nutritionPlan struct {
+	ClientID   int
+	Sex        nutrition.Sex
+	HeightCm   float64
+	Age        int // из профиля; 0 — возраст берётся из даты рождения
+	Activity   nutrition.Activity
+	Goal       nutrition.Goal
+	Energy     nutrition.Energy
+	Targets    nutrition.Targets
+	WeeklyRate sql.NullFloat64
+	AdjustedAt sql.NullTime
+}
+
+// loadNutritionPlan загружает профиль питания; nil без ошибки — профиль не настроен
+func (b *Bot) loadNutritionPlan(clientID int) (*nutritionPlan, error) {
+	p := &nutritionPlan{ClientID: clientID}
+	var height sql.NullFloat64
+	var age sql.NullInt64
+	var bmr, tdee int
+	err := b.db.QueryRow(`
+		SELECT sex, height_cm, age, activity, goal, formula, bmr, tdee,
+		       calories, protein, fat, carbs, weekly_rate, adjusted_at
+		FROM public.nutrition_profiles WHERE client_id = $1`, clientID).
+		Scan(&p.Sex, &height, &age, &p.Activity, &p.Goal, &p.Energy.Formula, &bmr, &tdee,
+			&p.Targets.Calories, &p.Targets.Protein, &p.Targets.Fat, &p.Targets.Carbs,
+			&p.WeeklyRate, &p.AdjustedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.HeightCm = height.Float64
+	p.Age = int(age.Int64)
+	p.Energy.BMR, p.Energy.TDEE = float64(bmr), float64(tdee)
+	return p, nil
+}
+
+// saveNutritionPlan сохраняет профиль и цели
+func (b *Bot) saveNutritionPlan(p *nutritionPlan) error {
+	var height interface{}
+	if p.HeightCm > 0 {
+		height = p.HeightCm
+	}
+	var age interface{}
+	if p.Age > 0 {
+		age = p.Age
+	}
+	_, err := b.db.Exec(`
+		INSERT INTO public.nutrition_profiles
+		(client_id, sex, height_cm, age, activity, goal, formula, bmr, tdee, calories, protein, fat, carbs, weekly_rate, adjusted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (client_id) DO UPDATE SET
+			sex = EXCLUDED.sex, height_cm = EXCLUDED.height_cm, age = EXCLUDED.age,
+			activity = EXCLUDED.activity, goal = EXCLUDED.goal, formula = EXCLUDED.formula,
+			bmr = EXCLUDED.bmr, tdee = EXCLUDED.tdee,
+			calories = EXCLUDED.calories, protein = EXCLUDED.protein, fat = EXCLUDED.fat, carbs = EXCLUDED.carbs,
+			weekly_rate = EXCLUDED.weekly_rate, adjusted_at = EXCLUDED.adjusted_at,
+			updated_at = NOW()`,
+		p.ClientID, p.Sex, height, age, p.Activity, p.Goal, p.Energy.Formula,
+		int(p.Energy.BMR), int(p.Energy.TDEE),
+		p.Targets.Calories, p.Targets.Protein, p.Targets.Fat, p.Targets.Carbs,
+		p.WeeklyRate, p.AdjustedAt)
+	return err
+}
+
+// nutritionProfile собирает данные для расчёта: вес и процент жира — из последних
+// записей прогресса (вес — или из анкеты), возраст — из профиля или даты рождения
+func (b *Bot) nutritionProfile(clientID int, sex nutrition.Sex, heightCm float64, age int, activity nutrition.Activity) (nutrition.Profile, error) {
+	p := nutrition.Profile{Sex: sex, HeightCm: heightCm, Age: age, Activity: activity}
+
+	err := b.db.QueryRow(`
+		SELECT weight FROM public.client_progress
+		WHERE client_id = $1 AND weight > 0
+		ORDER BY record_date DESC, id DESC LIMIT 1`, clientID).Scan(&p.WeightKg)
+	if errors.Is(err, sql.ErrNoRows) {
+		var formWeight sql.NullFloat64
+		err = b.db.QueryRow(`
+			SELECT weight FROM public.client_forms
+			WHERE client_id = $1 AND weight > 0
+			ORDER BY created_at DESC LIMIT 1`, clientID).Scan(&formWeight)
+		p.WeightKg = formWeight.Float64
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return p, err
+	}
+
+	err = b.db.QueryRow(`
+		SELECT body_fat FROM public.client_progress
+		WHERE client_id = $1 AND body_fat > 0 AND record_date >= $2
+		ORDER BY record_date DESC, id DESC LIMIT 1`, clientID, time.Now().Add(-bodyFatMaxAge)).Scan(&p.BodyFat)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return p, err
+	}
+
+	if p.Age == 0 {
+		p.Age = b.clientAge(clientID)
+	}
+	return p, nil
+}
+
+// clientAge возвращает возраст по дате рождения из карточки или 0
+func (b *Bot) clientAge(clientID int) int {
+	var birthDate sql.NullString
+	if err := b.db.QueryRow("SELECT birth_date FROM public.clients WHERE id = $1", clientID).Scan(&birthDate); err != nil {
+		return 0
+	}
+	return ageOn(birthDate.String, time.Now())
+}
+
+// ageOn считает полных лет на дату now; дата рождения — ДД.ММ.ГГГГ или ГГГГ-ММ-ДД
+func ageOn(birthDate string, now time.Time) int {
+	born, err := time.Parse("02.01.2006", birthDate)
+	if err != nil {
+		if born, err = time.Parse("2006-01-02", birthDate); err != nil {
+			return 0
+		}
+	}
+	age := now.Year() - born.Year()
+	if now.Month() < born.Month() || (now.Month() == born.Month() && now.Day() < born.Day()) {
+		age--
+	}
+	if age < 0 {
+		return 0
+	}
+	return age
+}
+
+// parseSex разбирает пол из анкеты: male/female, м/ж, мужской/женский
+func parseSex(s string) nutrition.Sex {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch {
+	case strings.HasPrefix(s, "m"), strings.HasPrefix(s, "м"):
+		return nutrition.Male
+	case strings.HasPrefix(s, "f"), strings.HasPrefix(s, "ж"):
+		return nutrition.Female
+	}
+	return ""
+}
+
+// handleNutrition открывает клиенту экран питания; без профиля — начинает настройку
+func (b *Bot) handleNutrition(chatID int64) {
+	clientID, err := b.repo.Program.GetClientByTelegramID(chatID)
+	if err != nil || clientID == 0 {
+		b.sendMessage(chatID, b.t("reg_not_registered", chatID))
+		return
+	}
+	plan, err := b.loadNutritionPlan(clientID)
+	if err != nil {
+		b.sendError(chatID, b.t("nutrition_load_error", chatID), err)
+		return
+	}
+	if plan == nil {
+		b.startNutritionSetup(chatID, clientID)
+		return
+	}
+	b.showNutrition(chatID, clientID, 0)
+}
+
+// showNutrition показывает цели и сводку отметок за неделю. Клиенту — с кнопками
+// отметки и настройки, тренеру — только просмотр
+func (b *Bot) showNutrition(chatID int64, clientID int, messageID int) {
+	plan, err := b.loadNutritionPlan(clientID)
+	if err != nil {
+		b.sendError(chatID, b.t("nutrition_load_error", chatID), err)
+		return
+	}
+	owner := b.isClientOwner(chatID, clientID)
+
+	var name, surname string
+	var telegramID sql.NullInt64
+	if err := b.db.QueryRow("SELECT name, surname, telegram_id FROM public.clients WHERE id = $1", clientID).
+		Scan(&name, &surname, &telegramID); err != nil {
+		b.sendError(chatID, b.t("nutrition_load_error", chatID), err)
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(b.t("nutrition_title", chatID) + "\n")
+	if !owner {
+		text.WriteString(b.tf("chart_client", chatID, name, surname) + "\n")
+	}
+	text.WriteString("\n")
+
+	if plan == nil {
+		text.WriteString(b.t("nutrition_not_set", chatID))
+		b.sendOrEditInline(chatID, messageID, text.String(), nil)
+		return
+	}
+
+	text.WriteString(b.formatNutritionTargets(chatID, plan) + "\n\n")
+
+	// Дни отметок — по поясу клиента, даже когда смотрит тренер
+	today := time.Now().In(b.userLocation(telegramID.Int64))
+	checkins, err := b.nutritionCheckins(clientID, today.AddDate(0, 0, -(nutritionSummaryDays-1)))
+	if err != nil {
+		log.Printf("Ошибка загрузки отметок питания клиента %d: %v", clientID, err)
+	}
+	text.WriteString(b.formatNutritionWeek(chatID, plan.Targets, checkins, today))
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if owner {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("nutrition_btn_checkin", chatID), nutritionCallback("checkin", clientID)),
+			tgbotapi.NewInlineKeyboardButtonData(b.t("nutrition_btn_setup", chatID), nutritionCallback("setup", clientID)),
+		))
+	}
+	b.sendOrEditInline(chatID, messageID, text.String(), rows)
+}
+
+// formatNutritionTargets — цели по КБЖУ, расход и параметры расчёта
+func (b *Bot) formatNutritionTargets(chatID int64, plan *nutritionPlan) string {
+	var text strings.Builder
+	t := plan.Targets
+	text.WriteString(b.tf("nutrition_targets", chatID, t.Calories, t.Protein, t.Fat, t.Carbs) + "\n")
+	text.WriteString(b.tf("nutrition_goal_line", chatID,
+		b.t("nutrition_goal_"+string(plan.Goal), chatID),
+		b.t("nutrition_activity_"+string(plan.Activity), chatID)) + "\n")
+	text.WriteString(b.tf("nutrition_energy", chatID,
+		int(plan.Energy.BMR), int(plan.Energy.TDEE), b.t("nutrition_formula_"+string(plan.Energy.Formula), chatID)))
+	if plan.WeeklyRate.Valid {
+		text.WriteString("\n" + b.tf("nutrition_rate", chatID, plan.WeeklyRate.Float64))
+	}
+	return text.String()
+}
+
+// formatNutritionWeek — сводка и отметки по дням за последние nutritionSummaryDays дней
+func (b *Bot) formatNutritionWeek(chatID int64, targets nutrition.Targets, checkins []nutrition.Checkin, today time.Time) string {
+	var text strings.Builder
+	s := nutrition.Summarize(checkins, targets, nutritionSummaryDays)
+	text.WriteString(b.tf("nutrition_week_title", chatID, s.Logged, s.Days) + "\n")
+	if s.Logged == 0 {
+		text.WriteString(b.t("nutrition_week_empty", chatID))
+		return text.String()
+	}
+	text.WriteString(b.formatNutritionSummary(chatID, s, targets) + "\n\n")
+
+	byDate := make(map[string]nutrition.Checkin, len(checkins))
+	for _, c := range checkins {
+		byDate[c.Date.Format("2006-01-02")] = c
+	}
+	for i := 0; i < nutritionSummaryDays; i++ {
+		day := today.AddDate(0, 0, -i)
+		c, ok := byDate[day.Format("2006-01-02")]
+		if !ok {
+			text.WriteString(b.tf("nutrition_day_missing", chatID, day.Format("02.01")) + "\n")
+			continue
+		}
+		text.WriteString(b.tf("nutrition_day", chatID, day.Format("02.01"), b.formatCheckin(chatID, c)) + "\n")
+	}
+	return strings.TrimRight(text.String(), "\n")
+}
+
+// formatNutritionSummary — средние значения и соблюдение плана
+func (b *Bot) formatNutritionSummary(chatID int64, s nutrition.Summary, targets nutrition.Targets) string {
+	lines := []string{
+		b.tf("nutrition_avg_calories", chatID, s.AvgCalories, targets.Calories, s.CaloriesHit),
+		b.tf("nutrition_avg_protein", chatID, s.AvgProtein, targets.Protein, s.ProteinHit),
+	}
+	if s.AvgSteps > 0 {
+		lines = append(lines, b.tf("nutrition_avg_steps", chatID, s.AvgSteps))
+	}
+	if s.AvgSleep > 0 {
+		lines = append(lines, b.tf("nutrition_avg_sleep", chatID, s.AvgSleep))
+	}
+	lines = append(lines, b.tf("nutrition_adherence", chatID, s.Adherence))
+	return strings.Join(lines, "\n")
+}
+
+// formatCheckin — отметка одной строкой: заполненные поля через точку
+func (b *Bot) formatCheckin(chatID int64, c nutrition.Checkin) string {
+	var parts []string
+	if c.Calories > 0 {
+		parts = append(parts, b.tf("nutrition_short_calories", chatID, c.Calories))
+	}
+	if c.Protein > 0 {
+		parts = append(parts, b.tf("nutrition_short_protein", chatID, c.Protein))
+	}
+	if c.Steps > 0 {
+		parts = append(parts, b.tf("nutrition_short_steps", chatID, c.Steps))
+	}
+	if c.Sleep > 0 {
+		parts = append(parts, b.tf("nutrition_short_sleep", chatID, c.Sleep))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// nutritionCheckins загружает отметки начиная с даты from
+func (b *Bot) nutritionCheckins(clientID int, from time.Time) ([]nutrition.Checkin, error) {
+	rows, err := b.db.Query(`
+		SELECT checkin_date, COALESCE(calories, 0), COALESCE(protein, 0), COALESCE(steps, 0), COALESCE(sleep_hours, 0)
+		FROM public.nutrition_checkins
+		WHERE client_id = $1 AND checkin_date >= $2
+		ORDER BY checkin_date`, clientID, from.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var checkins []nutrition.Checkin
+	for rows.Next() {
+		var c nutrition.Checkin
+		if err := rows.Scan(&c.Date, &c.Calories, &c.Protein, &c.Steps, &c.Sleep); err != nil {
+			return nil, err
+		}
+		checkins = append(checkins, c)
+	}
+	return checkins, rows.Err()
+}
+
+// saveNutritionCheckin сохраняет отметку за дату; пропущенные поля не затирают
+// заполненные ранее в тот же день
+func (b *Bot) saveNutritionCheckin(clientID int, date time.Time, c nutrition.Checkin) error {
+	nullInt := func(v int) interface{} {
+		if v <= 0 {
+			return nil
+		}
+		return v
+	}
+	var sleep interface{}
+	if c.Sleep > 0 {
+		sleep = c.Sleep
+	}
+	_, err := b.db.Exec(`
+		INSERT INTO public.nutrition_checkins (client_id, checkin_date, calories, protein, steps, sleep_hours)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (client_id, checkin_date) DO UPDATE SET
+			calories = COALESCE(EXCLUDED.calories, nutrition_checkins.calories),
+			protein = COALESCE(EXCLUDED.protein, nutrition_checkins.protein),
+			steps = COALESCE(EXCLUDED.steps, nutrition_checkins.steps),
+			sleep_hours = COALESCE(EXCLUDED.sleep_hours, nutrition_checkins.sleep_hours),
+			updated_at = NOW()`,
+		clientID, date.Format("2006-01-02"), nullInt(c.Calories), nullInt(c.Protein), nullInt(c.Steps), sleep)
+	return err
+}
+
+// nutritionCallback формирует данные кнопки: nut_<действие>_<клиент>
+func nutritionCallback(action string, clientID int) string {
+	return fmt.Sprintf("nut_%s_%d", action, clientID)
+}
+
+// parseNutritionCallback разбирает данные кнопки питания
+func parseNutritionCallback(data string) (action string, clientID int, ok bool) {
+	parts := strings.Split(data, "_")
+	if len(parts) != 3 || parts[0] != "nut" {
+		return "", 0, false
+	}
+	clientID, err := strconv.Atoi(parts[2])
+	if err != nil || clientID <= 0 {
+		return "", 0, false
+	}
+	return parts[1], clientID, true
+}
+
+// handleNutritionCallback обрабатывает кнопки экрана питания; отмечать день
+// и менять параметры может только сам клиент
+func (b *Bot) handleNutritionCallback(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	b.api.Send(tgbotapi.NewCallback(callback.ID, ""))
+
+	action, clientID, ok := parseNutritionCallback(callback.Data)
+	if !ok || !b.isClientOwner(chatID, clientID) {
+		return
+	}
+
+	switch action {
+	case "checkin":
+		b.startNutritionCheckin(chatID, clientID)
+	case "setup":
+		b.startNutritionSetup(chatID, clientID)
+	}
+}
+
+// startNutritionSetup начинает настройку: пол и рост берутся из профиля или анкеты,
+// возраст — из даты рождения; спрашивается только то, чего нет
+func (b *Bot) startNutritionSetup(chatID int64, clientID int) {
+	state := &NutritionState{ClientID: clientID}
+
+	plan, err := b.loadNutritionPlan(clientID)
+	if err != nil {
+		b.sendError(chatID, b.t("nutrition_load_error", chatID), err)
+		return
+	}
+	if plan != nil {
+		state.Sex, state.HeightCm, state.Age = plan.Sex, plan.HeightCm, plan.Age
+	} else {
+		var gender sql.NullString
+		var height sql.NullInt64
+		err := b.db.QueryRow(`
+			SELECT gender, height FROM public.client_forms
+			WHERE client_id = $1
+			ORDER BY created_at DESC LIMIT 1`, clientID).Scan(&gender, &height)
+		if err == nil {
+			state.Sex = parseSex(gender.String)
+			state.HeightCm = float64(height.Int64)
+		}
+	}
+	if state.Age == 0 {
+		state.Age = b.clientAge(clientID)
+	}
+
+	profile, err := b.nutritionProfile(clientID, state.Sex, state.HeightCm, state.Age, "")
+	if err != nil {
+		b.sendError(chatID, b.t("nutrition_load_error", chatID), err)
+		return
+	}
+	if profile.WeightKg <= 0 {
+		b.sendMessage(chatID, b.t("nutrition_need_weight", chatID))
+		return
+	}
+
+	nutritionStore.Lock()
+	nutritionStore.data[chatID] = state
+	nutritionStore.Unlock()
+
+	b.sendMessage(chatID, b.t("nutrition_setup_intro", chatID))
+	b.nextNutritionSetupStep(chatID)
+}
+
+// nextNutritionSetupStep спрашивает следующий неизвестный параметр; после последнего — считает цели
+func (b *Bot) nextNutritionSetupStep(chatID int64) {
+	nutritionStore.Lock()
+	state := nutritionStore.data[chatID]
+	if state == nil {
+		nutritionStore.Unlock()
+		return
+	}
+	for state.Step < len(nutritionSetupSteps) && !state.needs(nutritionSetupSteps[state.Step]) {
+		state.Step++
+	}
+	step := len(nutritionSetupSteps)
+	if state.Step < len(nutritionSetupSteps) {
+		step = state.Step
+	}
+	nutritionStore.Unlock()
+
+	if step == len(nutritionSetupSteps) {
+		b.finishNutritionSetup(chatID)
+		return
+	}
+
+	name := nutritionSetupSteps[step]
+	setState(chatID, name)
+
+	var rows [][]tgbotapi.KeyboardButton
+	switch name {
+	case stateNutritionSex:
+		rows = append(rows, tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(b.t("nutrition_sex_male", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("nutrition_sex_female", chatID)),
+		))
+	case stateNutritionActivity:
+		for _, a := range nutrition.Activities {
+			rows = append(rows, tgbotapi.NewKeyboardButtonRow(
+				tgbotapi.NewKeyboardButton(b.t("nutrition_activity_"+string(a), chatID))))
+		}
+	case stateNutritionGoal:
+		for _, g := range nutrition.Goals {
+			rows = append(rows, tgbotapi.NewKeyboardButtonRow(
+				tgbotapi.NewKeyboardButton(b.t("nutrition_goal_"+string(g), chatID))))
+		}
+	}
+	last := tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(b.t("cancel", chatID)))
+	if name == stateNutritionHeight || name == stateNutritionAge {
+		last = tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(b.t("skip", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
+		)
+	}
+	rows = append(rows, last)
+
+	b.sendMessageWithKeyboard(chatID, b.t("nutrition_ask_"+strings.TrimPrefix(name, "nutrition_"), chatID),
+		tgbotapi.NewReplyKeyboard(rows...))
+}
+
+// finishNutritionSetup считает и сохраняет цели
+func (b *Bot) finishNutritionSetup(chatID int64) {
+	nutritionStore.Lock()
+	state := nutritionStore.data[chatID]
+	delete(nutritionStore.data, chatID)
+	nutritionStore.Unlock()
+	clearState(chatID)
+	if state == nil {
+		return
+	}
+
+	profile, err := b.nutritionProfile(state.ClientID, state.Sex, state.HeightCm, state.Age, state.Activity)
+	if err != nil {
+		b.sendError(chatID, b.t("nutrition_load_error", chatID), err)
+		b.restoreMainMenu(chatID)
+		return
+	}
+	targets, energy, err := nutrition.Plan(profile, state.Goal)
+	if err != nil {
+		b.sendMessage(chatID, b.t("nutrition_incomplete", chatID))
+		b.restoreMainMenu(chatID)
+		return
+	}
+
+	plan := &nutritionPlan{
+		ClientID: state.ClientID,
+		Sex:      state.Sex,
+		HeightCm: state.HeightCm,
+		Activity: state.Activity,
+		Goal:     state.Goal,
+		Energy:   energy,
+		Targets:  targets,
+	}
+	// Возраст из даты рождения не сохраняется — он меняется сам
+	if b.clientAge(state.ClientID) == 0 {
+		plan.Age = state.Age
+	}
+	if err := b.saveNutritionPlan(plan); err != nil {
+		b.sendError(chatID, b.t("nutrition_save_error", chatID), err)
+		b.restoreMainMenu(chatID)
+		return
+	}
+
+	b.sendMessage(chatID, b.t("nutrition_setup_done", chatID))
+	b.restoreMainMenu(chatID)
+	b.showNutrition(chatID, state.ClientID, 0)
+}
+
+// startNutritionCheckin начинает дневную отметку
+func (b *Bot) startNutritionCheckin(chatID int64, clientID int) {
+	nutritionStore.Lock()
+	nutritionStore.data[chatID] = &NutritionState{ClientID: clientID}
+	nutritionStore.Unlock()
+	b.askNutritionCheckin(chatID, 0)
+}
+
+// askNutritionCheckin спрашивает поле отметки nutritionCheckinSteps[step]
+func (b *Bot) askNutritionCheckin(chatID int64, step int) {
+	name := nutritionCheckinSteps[step]
+	setState(chatID, name)
+
+	keyboard := tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(b.t("skip", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
+		),
+	)
+	text := b.tf("nutrition_ask_"+strings.TrimPrefix(name, "nutrition_"), chatID, step+1, len(nutritionCheckinSteps))
+	b.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// processNutritionState обрабатывает ввод при настройке питания и дневной отметке
+func (b *Bot) processNutritionState(message *tgbotapi.Message, state string) {
+	chatID := message.Chat.ID
+	text := strings.TrimSpace(message.Text)
+
+	if i18n.Is(text, "cancel") {
+		b.cancelNutrition(chatID)
+		return
+	}
+
+	nutritionStore.Lock()
+	ns := nutritionStore.data[chatID]
+	nutritionStore.Unlock()
+	if ns == nil {
+		b.cancelNutrition(chatID)
+		return
+	}
+	skip := i18n.Is(text, "skip")
+
+	switch state {
+	case stateNutritionSex:
+		switch i18n.Match(text, "nutrition_sex_male", "nutrition_sex_female") {
+		case "nutrition_sex_male":
+			b.setNutritionSetup(chatID, func(s *NutritionState) { s.Sex = nutrition.Male })
+		case "nutrition_sex_female":
+			b.setNutritionSetup(chatID, func(s *NutritionState) { s.Sex = nutrition.Female })
+		default:
+			b.sendMessage(chatID, b.t("nutrition_choose_option", chatID))
+		}
+
+	case stateNutritionHeight:
+		height, ok := parseNutritionNumber(text, 120, 230)
+		switch {
+		case skip:
+			b.setNutritionSetup(chatID, func(s *NutritionState) {})
+		case !ok:
+			b.sendMessage(chatID, b.tf("nutrition_invalid_range", chatID, 120, 230))
+		default:
+			b.setNutritionSetup(chatID, func(s *NutritionState) { s.HeightCm = height })
+		}
+
+	case stateNutritionAge:
+		age, ok := parseNutritionNumber(text, 14, 100)
+		switch {
+		case skip:
+			b.setNutritionSetup(chatID, func(s *NutritionState) {})
+		case !ok:
+			b.sendMessage(chatID, b.tf("nutrition_invalid_range", chatID, 14, 100))
+		default:
+			b.setNutritionSetup(chatID, func(s *NutritionState) { s.Age = int(age) })
+		}
+
+	case stateNutritionActivity:
+		keys := make([]string, len(nutrition.Activities))
+		for i, a := range nutrition.Activities {
+			keys[i] = "nutrition_activity_" + string(a)
+		}
+		if key := i18n.Match(text, keys...); key != "" {
+			b.setNutritionSetup(chatID, func(s *NutritionState) {
+				s.Activity = nutrition.Activity(strings.TrimPrefix(key, "nutrition_activity_"))
+			})
+		} else {
+			b.sendMessage(chatID, b.t("nutrition_choose_option", chatID))
+		}
+
+	case stateNutritionGoal:
+		keys := make([]string, len(nutrition.Goals))
+		for i, g := range nutrition.Goals {
+			keys[i] = "nutrition_goal_" + string(g)
+		}
+		if key := i18n.Match(text, keys...); key != "" {
+			b.setNutritionSetup(chatID, func(s *NutritionState) {
+				s.Goal = nutrition.Goal(strings.TrimPrefix(key, "nutrition_goal_"))
+			})
+		} else {
+			b.sendMessage(chatID, b.t("nutrition_choose_option", chatID))
+		}
+
+	case stateNutritionCalories, stateNutritionProtein, stateNutritionSteps, stateNutritionSleep:
+		b.processNutritionCheckin(chatID, state, text, skip)
+	}
+}
+
+// setNutritionSetup применяет ответ к состоянию и переходит к следующему шагу
+func (b *Bot) setNutritionSetup(chatID int64, apply func(s *NutritionState)) {
+	nutritionStore.Lock()
+	if s := nutritionStore.data[chatID]; s != nil {
+		apply(s)
+		s.Step++
+	}
+	nutritionStore.Unlock()
+	b.nextNutritionSetupStep(chatID)
+}
+
+// nutritionCheckinLimits — допустимые значения полей отметки
+var nutritionCheckinLimits = map[string][2]float64{
+	stateNutritionCalories: {300, 10000},
+	stateNutritionProtein:  {10, 500},
+	stateNutritionSteps:    {100, 100000},
+	stateNutritionSleep:    {1, 24},
+}
+
+// processNutritionCheckin обрабатывает поле дневной отметки
+func (b *Bot) processNutritionCheckin(chatID int64, state, text string, skip bool) {
+	var value float64
+	if !skip {
+		limits := nutritionCheckinLimits[state]
+		v, ok := parseNutritionNumber(text, limits[0], limits[1])
+		if !ok {
+			b.sendMessage(chatID, b.tf("nutrition_invalid_range", chatID, limits[0], limits[1]))
+			return
+		}
+		value = v
+	}
+
+	nutritionStore.Lock()
+	ns := nutritionStore.data[chatID]
+	if ns == nil {
+		nutritionStore.Unlock()
+		return
+	}
+	switch state {
+	case stateNutritionCalories:
+		ns.Checkin.Calories = int(value)
+	case stateNutritionProtein:
+		ns.Checkin.Protein = int(value)
+	case stateNutritionSteps:
+		ns.Checkin.Steps = int(value)
+	case stateNutritionSleep:
+		ns.Checkin.Sleep = value
+	}
+	ns.Step++
+	step := ns.Step
+	nutritionStore.Unlock()
+
+	if step < len(nutritionCheckinSteps) {
+		b.askNutritionCheckin(chatID, step)
+		return
+	}
+	b.saveCheckinFromState(chatID)
+}
+
+// saveCheckinFromState сохраняет отметку за сегодняшний день по поясу клиента
+func (b *Bot) saveCheckinFromState(chatID int64) {
+	nutritionStore.Lock()
+	ns := nutritionStore.data[chatID]
+	delete(nutritionStore.data, chatID)
+	nutritionStore.Unlock()
+	clearState(chatID)
+	if ns == nil {
+		return
+	}
+
+	c := ns.Checkin
+	if c.Calories == 0 && c.Protein == 0 && c.Steps == 0 && c.Sleep == 0 {
+		b.sendMessage(chatID, b.t("nutrition_checkin_empty", chatID))
+		b.restoreMainMenu(chatID)
+		return
+	}
+
+	today := time.Now().In(b.userLocation(chatID))
+	if err := b.saveNutritionCheckin(ns.ClientID, today, c); err != nil {
+		b.sendError(chatID, b.t("nutrition_save_error", chatID), err)
+		b.restoreMainMenu(chatID)
+		return
+	}
+
+	text := b.t("nutrition_checkin_saved", chatID)
+	if plan, err := b.loadNutritionPlan(ns.ClientID); err == nil && plan != nil {
+		var lines []string
+		if c.Calories > 0 {
+			lines = append(lines, b.tf("nutrition_checkin_calories", chatID, c.Calories, plan.Targets.Calories))
+		}
+		if c.Protein > 0 {
+			lines = append(lines, b.tf("nutrition_checkin_protein", chatID, c.Protein, plan.Targets.Protein))
+		}
+		if len(lines) > 0 {
+			text += "\n\n" + strings.Join(lines, "\n")
+		}
+	}
+	b.sendMessage(chatID, text)
+	b.restoreMainMenu(chatID)
+}
+
+// cancelNutrition отменяет настройку или отметку
+func (b *Bot) cancelNutrition(chatID int64) {
+	nutritionStore.Lock()
+	delete(nutritionStore.data, chatID)
+	nutritionStore.Unlock()
+	clearState(chatID)
+
+	b.sendMessage(chatID, "❌ "+b.t("cancelled", chatID))
+	b.restoreMainMenu(chatID)
+}
+
+// parseNutritionNumber разбирает число в диапазоне [min, max]; пробелы и запятая допускаются: «8 500», «7,5»
+func parseNutritionNumber(text string, min, max float64) (float64, bool) {
+	s := strings.NewReplacer(" ", "", " ", "", ",", ".").Replace(text)
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < min || v > max {
+		return 0, false
+	}
+	return v, true
+}
+
+const (
+	// nutritionReminderHour — час (по поясу клиента), после которого напоминается об отметке
+	nutritionReminderHour = 21
+	// nutritionAdjustInterval — цели пересчитываются не чаще раза в неделю, даже при ручном запуске задачи
+	nutritionAdjustInterval = 6 * 24 * time.Hour
+)
+
+// nutritionReview — итог недели по клиенту для сводки тренеру
+type nutritionReview struct {
+	Name    string
+	Summary nutrition.Summary
+	Targets nutrition.Targets
+	Rate    sql.NullFloat64
+	Target  float64 // желаемый темп, кг в неделю
+	Delta   int
+}
+
+// runNutritionWeekly — задача планировщика: пересчитывает цели по динамике веса,
+// сообщает клиентам об изменении и отправляет тренерам сводку соблюдения плана
+func (b *Bot) runNutritionWeekly(ctx context.Context, run scheduler.Run) error {
+	type client struct {
+		id         int
+		name       string
+		telegramID int64
+		blocked    bool
+	}
+	rows, err := b.db.Query(`
+		SELECT np.client_id, c.name || ' ' || c.surname, COALESCE(c.telegram_id, 0), c.bot_blocked_at IS NOT NULL
+		FROM public.nutrition_profiles np
+		JOIN public.clients c ON c.id = np.client_id
+		WHERE c.deleted_at IS NULL
+		ORDER BY c.name, c.surname`)
+	if err != nil {
+		return fmt.Errorf("ошибка загрузки профилей питания: %w", err)
+	}
+	var clients []client
+	for rows.Next() {
+		var c client
+		if err := rows.Scan(&c.id, &c.name, &c.telegramID, &c.blocked); err != nil {
+			rows.Close()
+			return err
+		}
+		clients = append(clients, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var reviews []nutritionReview
+	for _, c := range clients {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		review, ok, err := b.reviewNutrition(c.id, now)
+		if err != nil {
+			log.Printf("Ошибка пересчёта питания клиента %d: %v", c.id, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		review.Name = c.name
+		reviews = append(reviews, review)
+
+		if review.Delta != 0 && c.telegramID != 0 && !c.blocked {
+			msg := tgbotapi.NewMessage(c.telegramID, b.tf("nutrition_adjusted", c.telegramID,
+				review.Rate.Float64, review.Target,
+				review.Targets.Calories, review.Delta,
+				review.Targets.Protein, review.Targets.Fat, review.Targets.Carbs))
+			key := fmt.Sprintf("nutrition_adjust:%d:%s", c.id, now.Format("2006-01-02"))
+			if err := b.outbox.EnqueueOnce(key, msg); err != nil {
+				log.Printf("Ошибка постановки пересчёта питания клиенту %d в очередь: %v", c.id, err)
+			}
+		}
+	}
+	if len(reviews) == 0 {
+		return nil
+	}
+
+	admins, err := b.getAdminTelegramIDs()
+	if err != nil {
+		return err
+	}
+	for _, adminID := range admins {
+		msg := tgbotapi.NewMessage(adminID, b.formatNutritionDigest(adminID, reviews))
+		key := fmt.Sprintf("nutrition_weekly:%d:%s", adminID, now.Format("2006-01-02"))
+		if err := b.outbox.EnqueueOnce(key, msg); err != nil {
+			log.Printf("Ошибка постановки сводки питания тренеру %d в очередь: %v", adminID, err)
+		}
+	}
+	return nil
+}
+
+// reviewNutrition пересчитывает цели клиента по тренду веса за три недели
+// и сводит отметки за неделю. ok == false — профиля уже нет
+func (b *Bot) reviewNutrition(clientID int, now time.Time) (review nutritionReview, ok bool, err error) {
+	plan, err := b.loadNutritionPlan(clientID)
+	if err != nil || plan == nil {
+		return review, false, err
+	}
+
+	points, err := b.weightPoints(clientID, now.Add(-nutrition.TrendWindow))
+	if err != nil {
+		return review, false, err
+	}
+	rate, hasTrend := nutrition.WeeklyRate(points)
+	due := !plan.AdjustedAt.Valid || now.Sub(plan.AdjustedAt.Time) >= nutritionAdjustInterval
+	if hasTrend && due {
+		profile, err := b.nutritionProfile(clientID, plan.Sex, plan.HeightCm, plan.Age, plan.Activity)
+		if err != nil {
+			return review, false, err
+		}
+		adj := nutrition.Adjust(profile, plan.Goal, plan.Targets, rate)
+		if energy, err := nutrition.Estimate(profile); err == nil {
+			plan.Energy = energy
+		}
+		plan.Targets = adj.Targets
+		plan.WeeklyRate = sql.NullFloat64{Float64: math.Round(rate*100) / 100, Valid: true}
+		plan.AdjustedAt = sql.NullTime{Time: now, Valid: true}
+		if err := b.saveNutritionPlan(plan); err != nil {
+			return review, false, err
+		}
+		review.Delta = adj.Delta
+		review.Target = adj.TargetRate
+	}
+
+	checkins, err := b.nutritionCheckins(clientID, now.AddDate(0, 0, -nutritionSummaryDays))
+	if err != nil {
+		return review, false, err
+	}
+	// Сегодняшний день ещё не закончился — сводка за семь полных дней
+	today := now.Format("2006-01-02")
+	var week []nutrition.Checkin
+	for _, c := range checkins {
+		if c.Date.Format("2006-01-02") != today {
+			week = append(week, c)
+		}
+	}
+
+	review.Summary = nutrition.Summarize(week, plan.Targets, nutritionSummaryDays)
+	review.Targets = plan.Targets
+	review.Rate = plan.WeeklyRate
+	return review, true, nil
+}
+
+// weightPoints загружает взвешивания начиная с даты since
+func (b *Bot) weightPoints(clientID int, since time.Time) ([]nutrition.WeightPoint, error) {
+	rows, err := b.db.Query(`
+		SELECT record_date, weight FROM public.client_progress
+		WHERE client_id = $1 AND weight > 0 AND record_date >= $2
+		ORDER BY record_date`, clientID, since.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []nutrition.WeightPoint
+	for rows.Next() {
+		var p nutrition.WeightPoint
+		if err := rows.Scan(&p.Date, &p.Weight); err != nil {
+			return nil, err
+		}
+		points = append(points, p)
+	}
+	return points, rows.Err()
+}
+
+// formatNutritionDigest — недельная сводка питания для тренера
+func (b *Bot) formatNutritionDigest(chatID int64, reviews []nutritionReview) string {
+	var text strings.Builder
+	text.WriteString(b.t("nutrition_digest_title", chatID) + "\n")
+	for _, r := range reviews {
+		s := r.Summary
+		text.WriteString("\n" + b.tf("nutrition_digest_client", chatID, r.Name, s.Adherence, s.Logged, s.Days) + "\n")
+		if s.Logged > 0 {
+			text.WriteString(b.tf("nutrition_digest_details", chatID,
+				s.AvgCalories, r.Targets.Calories, s.AvgProtein, r.Targets.Protein) + "\n")
+		}
+		switch {
+		case !r.Rate.Valid:
+			text.WriteString(b.t("nutrition_digest_no_rate", chatID) + "\n")
+		case r.Delta != 0:
+			text.WriteString(b.tf("nutrition_digest_adjusted", chatID, r.Rate.Float64, r.Targets.Calories, r.Delta) + "\n")
+		default:
+			text.WriteString(b.tf("nutrition_digest_rate", chatID, r.Rate.Float64) + "\n")
+		}
+	}
+	return strings.TrimRight(text.String(), "\n")
+}
+
+// runNutritionReminders — задача планировщика: в 21:00 по поясу клиента напоминает
+// об отметке тем, у кого настроено питание и сегодня отметки ещё нет
+func (b *Bot) runNutritionReminders(ctx context.Context, run scheduler.Run) error {
+	type client struct {
+		id         int
+		telegramID int64
+	}
+	rows, err := b.db.Query(`
+		SELECT np.client_id, c.telegram_id
+		FROM public.nutrition_profiles np
+		JOIN public.clients c ON c.id = np.client_id
+		WHERE c.deleted_at IS NULL
+		  AND c.telegram_id IS NOT NULL
+		  AND c.bot_blocked_at IS NULL`)
+	if err != nil {
+		return fmt.Errorf("ошибка загрузки профилей питания: %w", err)
+	}
+	var clients []client
+	for rows.Next() {
+		var c client
+		if err := rows.Scan(&c.id, &c.telegramID); err != nil {
+			rows.Close()
+			return err
+		}
+		clients = append(clients, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, c := range clients {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		local := now.In(b.userLocation(c.telegramID))
+		remindAt := calendar.CombineDateTimeIn(local, nutritionReminderHour, 0, local.Location())
+		if remindAt.After(now) || !remindAt.After(run.LastSuccess) {
+			continue
+		}
+
+		date := local.Format("2006-01-02")
+		var done bool
+		if err := b.db.QueryRow(`
+			SELECT EXISTS(SELECT 1 FROM public.nutrition_checkins WHERE client_id = $1 AND checkin_date = $2)`,
+			c.id, date).Scan(&done); err != nil {
+			return err
+		}
+		if done {
+			continue
+		}
+
+		msg := tgbotapi.NewMessage(c.telegramID, b.t("nutrition_reminder", c.telegramID))
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("nutrition_btn_checkin", c.telegramID), nutritionCallback("checkin", c.id))))
+		key := fmt.Sprintf("nutrition_reminder:%d:%s", c.id, date)
+		if err := b.outbox.EnqueueOnce(key, msg); err != nil {
+			log.Printf("Ошибка постановки напоминания об отметке клиенту %d в очередь: %v", c.id, err)
+		}
+	}
+	return nil
+}
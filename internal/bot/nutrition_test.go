@@ -0,0 +1,68 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"workbot/internal/nutrition"
+)
+
+func TestNutritionCallbackRoundTrip(t *testing.T) {
+	data := nutritionCallback("checkin", 42)
+	if data != "nut_checkin_42" {
+		t.Fatalf("nutritionCallback = %q", data)
+	}
+	action, clientID, ok := parseNutritionCallback(data)
+	if !ok || action != "checkin" || clientID != 42 {
+		t.Errorf("parseNutritionCallback = %q, %d, %v", action, clientID, ok)
+	}
+	for _, bad := range []string{"nut_checkin", "nut_checkin_x", "nut_checkin_0", "gal_checkin_1", "nut_a_b_1"} {
+		if _, _, ok := parseNutritionCallback(bad); ok {
+			t.Errorf("parseNutritionCallback(%q) принял неверные данные", bad)
+		}
+	}
+}
+
+func TestAgeOn(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	tests := map[string]int{
+		"15.03.1990": 36, // день рождения сегодня
+		"16.03.1990": 35, // завтра
+		"1990-01-31": 36,
+		"29.02.2000": 26,
+		"":           0,
+		"весной":     0,
+	}
+	for birth, want := range tests {
+		if got := ageOn(birth, now); got != want {
+			t.Errorf("ageOn(%q) = %d, хотим %d", birth, got, want)
+		}
+	}
+}
+
+func TestParseSex(t *testing.T) {
+	tests := map[string]nutrition.Sex{
+		"male": nutrition.Male, "Мужской": nutrition.Male, "м": nutrition.Male,
+		"female": nutrition.Female, "ж": nutrition.Female, "Женский": nutrition.Female,
+		"": "", "—": "",
+	}
+	for in, want := range tests {
+		if got := parseSex(in); got != want {
+			t.Errorf("parseSex(%q) = %q, хотим %q", in, got, want)
+		}
+	}
+}
+
+func TestParseNutritionNumber(t *testing.T) {
+	if v, ok := parseNutritionNumber("8 500", 100, 100000); !ok || v != 8500 {
+		t.Errorf("«8 500» = %v, %v", v, ok)
+	}
+	if v, ok := parseNutritionNumber("7,5", 1, 24); !ok || v != 7.5 {
+		t.Errorf("«7,5» = %v, %v", v, ok)
+	}
+	for _, bad := range []string{"", "много", "25", "0"} {
+		if _, ok := parseNutritionNumber(bad, 1, 24); ok {
+			t.Errorf("parseNutritionNumber(%q) принял неверное значение", bad)
+		}
+	}
+}
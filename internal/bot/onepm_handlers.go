@@ -9,6 +9,7 @@ import (
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"workbot/internal/i18n"
 	"workbot/internal/models"
 	"workbot/internal/training"
 )
@@ -53,7 +54,7 @@ func (b *Bot) handle1PMMenu(message *tgbotapi.Message) {
 	userStates.states[chatID] = state1PMSelectClient
 	userStates.Unlock()
 
-	b.showClientsFor1PM(chatID, "Выберите клиента для записи 1ПМ:")
+	b.showClientsFor1PM(chatID, b.t("onepm_select_client", chatID))
 }
 
 // handle1PMForClient записывает 1ПМ для конкретного клиента (минуя выбор клиента)
@@ -80,7 +81,7 @@ func (b *Bot) showClientsFor1PM(chatID int64, text string) {
 		ORDER BY name, surname`)
 	if err != nil {
 		log.Printf("Ошибка получения клиентов: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка загрузки списка клиентов")
+		msg := tgbotapi.NewMessage(chatID, b.t("admin_clients_load_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -100,13 +101,13 @@ func (b *Bot) showClientsFor1PM(chatID int64, text string) {
 	}
 
 	if len(buttons) == 0 {
-		msg := tgbotapi.NewMessage(chatID, "Нет клиентов. Сначала добавьте клиента через меню клиентов.")
+		msg := tgbotapi.NewMessage(chatID, b.t("plans_no_clients", chatID))
 		b.api.Send(msg)
 		return
 	}
 
 	buttons = append(buttons, tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton("Отмена"),
+		tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 	))
 
 	msg := tgbotapi.NewMessage(chatID, text)
@@ -119,7 +120,7 @@ func (b *Bot) handle1PMClientSelect(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.handleAdminCancel(message)
 		return
 	}
@@ -127,7 +128,7 @@ func (b *Bot) handle1PMClientSelect(message *tgbotapi.Message) {
 	// Parse client ID from "1PM>> Name Surname [ID]"
 	clientID := parse1PMClientID(text)
 	if clientID == 0 {
-		msg := tgbotapi.NewMessage(chatID, "Ошибка выбора клиента")
+		msg := tgbotapi.NewMessage(chatID, b.t("admin_client_select_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -158,7 +159,7 @@ func (b *Bot) show1PMExerciseList(chatID int64, clientID int) {
 	`, clientID)
 	if err != nil {
 		log.Printf("Ошибка получения упражнений: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка загрузки упражнений")
+		msg := tgbotapi.NewMessage(chatID, b.t("onepm_exercises_load_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -175,7 +176,7 @@ func (b *Bot) show1PMExerciseList(chatID int64, clientID int) {
 
 		buttonText := fmt.Sprintf("EX>> %s", name)
 		if current1PM > 0 {
-			buttonText += fmt.Sprintf(" (%.1f кг)", current1PM)
+			buttonText += " " + b.tf("onepm_exercise_current", chatID, current1PM)
 		}
 		buttonText += fmt.Sprintf(" [%d]", id)
 
@@ -186,13 +187,13 @@ func (b *Bot) show1PMExerciseList(chatID int64, clientID int) {
 
 	// Add option to create new exercise
 	buttons = append(buttons, tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton("➕ Добавить упражнение"),
+		tgbotapi.NewKeyboardButton(b.t("onepm_btn_add_exercise", chatID)),
 	))
 	buttons = append(buttons, tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton("Отмена"),
+		tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 	))
 
-	msg := tgbotapi.NewMessage(chatID, "Выберите упражнение для записи 1ПМ:")
+	msg := tgbotapi.NewMessage(chatID, b.t("onepm_select_exercise", chatID))
 	msg.ReplyMarkup = tgbotapi.NewReplyKeyboard(buttons...)
 	b.api.Send(msg)
 }
@@ -202,21 +203,21 @@ func (b *Bot) handle1PMExerciseSelect(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.clear1PMState(chatID)
 		b.handleAdminCancel(message)
 		return
 	}
 
-	if text == "➕ Добавить упражнение" {
+	if i18n.Is(text, "onepm_btn_add_exercise") {
 		userStates.Lock()
 		userStates.states[chatID] = state1PMAddExercise
 		userStates.Unlock()
 
-		msg := tgbotapi.NewMessage(chatID, "Введите название нового упражнения:")
+		msg := tgbotapi.NewMessage(chatID, b.t("onepm_enter_exercise", chatID))
 		keyboard := tgbotapi.NewReplyKeyboard(
 			tgbotapi.NewKeyboardButtonRow(
-				tgbotapi.NewKeyboardButton("Отмена"),
+				tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 			),
 		)
 		msg.ReplyMarkup = keyboard
@@ -227,7 +228,7 @@ func (b *Bot) handle1PMExerciseSelect(message *tgbotapi.Message) {
 	// Parse exercise ID from "EX>> Name (1PM) [ID]"
 	exerciseID := parse1PMExerciseID(text)
 	if exerciseID == 0 {
-		msg := tgbotapi.NewMessage(chatID, "Ошибка выбора упражнения")
+		msg := tgbotapi.NewMessage(chatID, b.t("onepm_exercise_select_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -245,22 +246,22 @@ func (b *Bot) show1PMInputMethod(chatID int64) {
 	userStates.states[chatID] = state1PMInputMethod
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Как записать 1ПМ?")
+	msg := tgbotapi.NewMessage(chatID, b.t("onepm_select_method", chatID))
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Ввести 1ПМ вручную"),
+			tgbotapi.NewKeyboardButton(b.t("onepm_btn_manual", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Рассчитать по подходу (Бжицки)"),
+			tgbotapi.NewKeyboardButton(b.t("onepm_btn_brzycki", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Рассчитать по подходу (Эпли)"),
+			tgbotapi.NewKeyboardButton(b.t("onepm_btn_epley", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Рассчитать (среднее)"),
+			tgbotapi.NewKeyboardButton(b.t("onepm_btn_average", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -272,14 +273,14 @@ func (b *Bot) handle1PMInputMethod(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.clear1PMState(chatID)
 		b.handleAdminCancel(message)
 		return
 	}
 
-	switch text {
-	case "Ввести 1ПМ вручную":
+	switch i18n.Match(text, "onepm_btn_manual", "onepm_btn_brzycki", "onepm_btn_epley", "onepm_btn_average") {
+	case "onepm_btn_manual":
 		userStates.Lock()
 		userStates.states[chatID] = state1PMManualInput
 		userStates.Unlock()
@@ -288,35 +289,35 @@ func (b *Bot) handle1PMInputMethod(message *tgbotapi.Message) {
 		onePMStore.calcMethod[chatID] = "manual"
 		onePMStore.Unlock()
 
-		msg := tgbotapi.NewMessage(chatID, "Введите 1ПМ в килограммах (например: 100 или 102.5):")
+		msg := tgbotapi.NewMessage(chatID, b.t("onepm_enter_manual", chatID))
 		keyboard := tgbotapi.NewReplyKeyboard(
 			tgbotapi.NewKeyboardButtonRow(
-				tgbotapi.NewKeyboardButton("Отмена"),
+				tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 			),
 		)
 		msg.ReplyMarkup = keyboard
 		b.api.Send(msg)
 
-	case "Рассчитать по подходу (Бжицки)":
+	case "onepm_btn_brzycki":
 		onePMStore.Lock()
 		onePMStore.calcMethod[chatID] = "brzycki"
 		onePMStore.Unlock()
 		b.ask1PMCalcWeight(chatID)
 
-	case "Рассчитать по подходу (Эпли)":
+	case "onepm_btn_epley":
 		onePMStore.Lock()
 		onePMStore.calcMethod[chatID] = "epley"
 		onePMStore.Unlock()
 		b.ask1PMCalcWeight(chatID)
 
-	case "Рассчитать (среднее)":
+	case "onepm_btn_average":
 		onePMStore.Lock()
 		onePMStore.calcMethod[chatID] = "average"
 		onePMStore.Unlock()
 		b.ask1PMCalcWeight(chatID)
 
 	default:
-		msg := tgbotapi.NewMessage(chatID, "Выберите вариант из меню")
+		msg := tgbotapi.NewMessage(chatID, b.t("select_option_menu", chatID))
 		b.api.Send(msg)
 	}
 }
@@ -327,10 +328,10 @@ func (b *Bot) ask1PMCalcWeight(chatID int64) {
 	userStates.states[chatID] = state1PMCalcWeight
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Введите вес, с которым был выполнен подход (кг):\n\nНапример: 80 или 82.5")
+	msg := tgbotapi.NewMessage(chatID, b.t("onepm_enter_set_weight", chatID))
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -342,7 +343,7 @@ func (b *Bot) handle1PMCalcWeight(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.clear1PMState(chatID)
 		b.handleAdminCancel(message)
 		return
@@ -350,7 +351,7 @@ func (b *Bot) handle1PMCalcWeight(message *tgbotapi.Message) {
 
 	weight, err := strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
 	if err != nil || weight <= 0 {
-		msg := tgbotapi.NewMessage(chatID, "Введите корректный вес (положительное число)")
+		msg := tgbotapi.NewMessage(chatID, b.t("onepm_invalid_weight", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -363,7 +364,7 @@ func (b *Bot) handle1PMCalcWeight(message *tgbotapi.Message) {
 	userStates.states[chatID] = state1PMCalcReps
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Сколько повторений было выполнено?")
+	msg := tgbotapi.NewMessage(chatID, b.t("onepm_enter_set_reps", chatID))
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
 			tgbotapi.NewKeyboardButton("1"),
@@ -381,7 +382,7 @@ func (b *Bot) handle1PMCalcWeight(message *tgbotapi.Message) {
 			tgbotapi.NewKeyboardButton("10"),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -393,7 +394,7 @@ func (b *Bot) handle1PMCalcReps(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.clear1PMState(chatID)
 		b.handleAdminCancel(message)
 		return
@@ -401,7 +402,7 @@ func (b *Bot) handle1PMCalcReps(message *tgbotapi.Message) {
 
 	reps, err := strconv.Atoi(text)
 	if err != nil || reps <= 0 || reps > 30 {
-		msg := tgbotapi.NewMessage(chatID, "Введите корректное количество повторений (1-30)")
+		msg := tgbotapi.NewMessage(chatID, b.t("onepm_invalid_reps", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -419,9 +420,7 @@ func (b *Bot) handle1PMCalcReps(message *tgbotapi.Message) {
 	onePMStore.calculated1PM[chatID] = calculated1PM
 	onePMStore.Unlock()
 
-	methodName := training.CalcMethodName(method)
-
-	b.show1PMConfirmation(chatID, calculated1PM, weight, reps, methodName)
+	b.show1PMConfirmation(chatID, calculated1PM, weight, reps, method)
 }
 
 // handle1PMManualInput handles manual 1PM input
@@ -429,7 +428,7 @@ func (b *Bot) handle1PMManualInput(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.clear1PMState(chatID)
 		b.handleAdminCancel(message)
 		return
@@ -437,7 +436,7 @@ func (b *Bot) handle1PMManualInput(message *tgbotapi.Message) {
 
 	onePM, err := strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
 	if err != nil || onePM <= 0 {
-		msg := tgbotapi.NewMessage(chatID, "Введите корректный вес (положительное число)")
+		msg := tgbotapi.NewMessage(chatID, b.t("onepm_invalid_weight", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -446,7 +445,7 @@ func (b *Bot) handle1PMManualInput(message *tgbotapi.Message) {
 	onePMStore.calculated1PM[chatID] = onePM
 	onePMStore.Unlock()
 
-	b.show1PMConfirmation(chatID, onePM, 0, 0, "Ручной ввод")
+	b.show1PMConfirmation(chatID, onePM, 0, 0, "manual")
 }
 
 // show1PMConfirmation shows confirmation before saving
@@ -465,27 +464,22 @@ func (b *Bot) show1PMConfirmation(chatID int64, onePM, weight float64, reps int,
 	b.db.QueryRow("SELECT name FROM public.exercises WHERE id = $1", exerciseID).Scan(&exerciseName)
 	b.db.QueryRow("SELECT name || ' ' || surname FROM public.clients WHERE id = $1", clientID).Scan(&clientName)
 
-	text := fmt.Sprintf("📊 Подтверждение записи 1ПМ\n\n"+
-		"👤 Клиент: %s\n"+
-		"🏋️ Упражнение: %s\n"+
-		"💪 1ПМ: %.1f кг\n"+
-		"📐 Метод: %s\n",
-		clientName, exerciseName, onePM, method)
+	text := b.tf("onepm_confirm", chatID, clientName, exerciseName, onePM, b.t("onepm_method_"+method, chatID)) + "\n"
 
 	if weight > 0 && reps > 0 {
-		text += fmt.Sprintf("📝 Исходные данные: %.1f кг × %d повт.\n", weight, reps)
+		text += b.tf("onepm_confirm_source", chatID, weight, reps) + "\n"
 	}
 
-	text += "\nСохранить?"
+	text += "\n" + b.t("onepm_confirm_question", chatID)
 
 	msg := tgbotapi.NewMessage(chatID, text)
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("✅ Сохранить"),
+			tgbotapi.NewKeyboardButton(b.t("save", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("🔄 Пересчитать"),
-			tgbotapi.NewKeyboardButton("❌ Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("onepm_btn_recalculate", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -497,16 +491,16 @@ func (b *Bot) handle1PMConfirm(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	switch text {
-	case "✅ Сохранить":
+	switch i18n.Match(text, "save", "onepm_btn_recalculate", "cancel") {
+	case "save":
 		b.save1PM(chatID, message)
-	case "🔄 Пересчитать":
+	case "onepm_btn_recalculate":
 		b.show1PMInputMethod(chatID)
-	case "❌ Отмена":
+	case "cancel":
 		b.clear1PMState(chatID)
 		b.handleAdminCancel(message)
 	default:
-		msg := tgbotapi.NewMessage(chatID, "Выберите действие из меню")
+		msg := tgbotapi.NewMessage(chatID, b.t("select_action_menu", chatID))
 		b.api.Send(msg)
 	}
 }
@@ -531,7 +525,7 @@ func (b *Bot) save1PM(chatID int64, message *tgbotapi.Message) {
 
 	if err != nil {
 		log.Printf("Ошибка сохранения 1ПМ: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка сохранения. Попробуйте снова.")
+		msg := tgbotapi.NewMessage(chatID, b.t("error_try_later", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -539,21 +533,21 @@ func (b *Bot) save1PM(chatID int64, message *tgbotapi.Message) {
 	// Get history
 	history := b.get1PMHistory(clientID, exerciseID)
 
-	responseText := fmt.Sprintf("✅ 1ПМ сохранён: %.1f кг\n\n", onePM)
+	responseText := b.tf("onepm_saved", chatID, onePM) + "\n\n"
 	if len(history) > 1 {
-		responseText += "📈 История:\n"
+		responseText += b.t("onepm_history", chatID) + "\n"
 		for i, h := range history {
 			if i >= 5 {
 				break
 			}
-			responseText += fmt.Sprintf("• %s: %.1f кг\n", h.TestDate.Format("02.01.2006"), h.OnePMKg)
+			responseText += b.tf("onepm_history_item", chatID, h.TestDate.Format("02.01.2006"), h.OnePMKg) + "\n"
 		}
 
 		// Show progress
 		if len(history) >= 2 {
 			gain := history[0].OnePMKg - history[len(history)-1].OnePMKg
 			gainPercent := (gain / history[len(history)-1].OnePMKg) * 100
-			responseText += fmt.Sprintf("\n📊 Прогресс: %+.1f кг (%+.1f%%)\n", gain, gainPercent)
+			responseText += "\n" + b.tf("onepm_progress", chatID, gain, gainPercent) + "\n"
 		}
 	}
 
@@ -574,7 +568,7 @@ func (b *Bot) save1PM(chatID int64, message *tgbotapi.Message) {
 		planStore.clientID[chatID] = savedClientID
 		planStore.Unlock()
 
-		b.sendMessage(chatID, "✅ 1ПМ записан. Продолжаем создание плана...")
+		b.sendMessage(chatID, b.t("onepm_back_to_plan", chatID))
 		b.showPlanGoalSelection(chatID)
 	} else {
 		b.handle1PMMenu(message)
@@ -586,7 +580,7 @@ func (b *Bot) handle1PMAddExercise(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		onePMStore.RLock()
 		clientID := onePMStore.clientID[chatID]
 		onePMStore.RUnlock()
@@ -612,7 +606,7 @@ func (b *Bot) handle1PMAddExercise(message *tgbotapi.Message) {
 		onePMStore.exerciseID[chatID] = existingID
 		onePMStore.Unlock()
 
-		msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("Упражнение \"%s\" уже существует. Используем его.", name))
+		msg := tgbotapi.NewMessage(chatID, b.tf("onepm_exercise_exists", chatID, name))
 		b.api.Send(msg)
 
 		b.show1PMInputMethod(chatID)
@@ -628,7 +622,7 @@ func (b *Bot) handle1PMAddExercise(message *tgbotapi.Message) {
 
 	if err != nil {
 		log.Printf("Ошибка добавления упражнения: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка добавления упражнения")
+		msg := tgbotapi.NewMessage(chatID, b.t("onepm_exercise_add_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -637,7 +631,7 @@ func (b *Bot) handle1PMAddExercise(message *tgbotapi.Message) {
 	onePMStore.exerciseID[chatID] = newID
 	onePMStore.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("✅ Упражнение \"%s\" добавлено", name))
+	msg := tgbotapi.NewMessage(chatID, b.tf("onepm_exercise_added", chatID, name))
 	b.api.Send(msg)
 
 	b.show1PMInputMethod(chatID)
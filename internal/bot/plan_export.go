@@ -9,6 +9,7 @@ import (
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"workbot/internal/excel"
+	"workbot/internal/i18n"
 	"workbot/internal/models"
 )
 
@@ -23,7 +24,7 @@ func (b *Bot) showPlansForExport(chatID int64) {
 		LIMIT 15`)
 	if err != nil {
 		log.Printf("Ошибка получения планов: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка загрузки планов")
+		msg := tgbotapi.NewMessage(chatID, b.t("plan_export_load_plans_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -43,20 +44,20 @@ func (b *Bot) showPlansForExport(chatID int64) {
 	}
 
 	if len(buttons) == 0 {
-		msg := tgbotapi.NewMessage(chatID, "Нет активных планов для экспорта. Сначала создайте план.")
+		msg := tgbotapi.NewMessage(chatID, b.t("plan_export_no_plans", chatID))
 		b.api.Send(msg)
 		return
 	}
 
 	buttons = append(buttons, tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton("Отмена"),
+		tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 	))
 
 	userStates.Lock()
 	userStates.states[chatID] = statePlanExportSelect
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Выберите план для экспорта в Excel:")
+	msg := tgbotapi.NewMessage(chatID, b.t("plan_export_select", chatID))
 	msg.ReplyMarkup = tgbotapi.NewReplyKeyboard(buttons...)
 	b.api.Send(msg)
 }
@@ -66,7 +67,7 @@ func (b *Bot) handlePlanExportSelect(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.clearPlanState(chatID)
 		b.handlePlansMenu(message)
 		return
@@ -75,7 +76,7 @@ func (b *Bot) handlePlanExportSelect(message *tgbotapi.Message) {
 	// Parse plan ID from "EXP>> Name [ID]"
 	planID := parsePlanExportID(text)
 	if planID == 0 {
-		msg := tgbotapi.NewMessage(chatID, "Ошибка выбора плана")
+		msg := tgbotapi.NewMessage(chatID, b.t("plan_export_select_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -85,14 +86,14 @@ func (b *Bot) handlePlanExportSelect(message *tgbotapi.Message) {
 
 // exportPlanToExcel exports plan to Excel and sends file
 func (b *Bot) exportPlanToExcel(chatID int64, planID int, originalMessage *tgbotapi.Message) {
-	waitMsg := tgbotapi.NewMessage(chatID, "⏳ Генерирую Excel файл...")
+	waitMsg := tgbotapi.NewMessage(chatID, b.t("plan_export_generating", chatID))
 	b.api.Send(waitMsg)
 
 	// Load plan from database
 	plan, err := b.loadPlanForExport(planID)
 	if err != nil {
 		log.Printf("Ошибка загрузки плана: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка загрузки плана")
+		msg := tgbotapi.NewMessage(chatID, b.t("plan_export_load_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -120,7 +121,7 @@ func (b *Bot) exportPlanToExcel(chatID int64, planID int, originalMessage *tgbot
 	f, err := excel.ExportTrainingPlanWithWorkouts(plan, progression, pm1History, workouts)
 	if err != nil {
 		log.Printf("Ошибка генерации Excel: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка создания Excel файла")
+		msg := tgbotapi.NewMessage(chatID, b.t("plan_export_create_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -131,7 +132,7 @@ func (b *Bot) exportPlanToExcel(chatID int64, planID int, originalMessage *tgbot
 
 	if err := f.SaveAs(tempPath); err != nil {
 		log.Printf("Ошибка сохранения файла: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка сохранения файла")
+		msg := tgbotapi.NewMessage(chatID, b.t("plan_export_save_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -139,7 +140,7 @@ func (b *Bot) exportPlanToExcel(chatID int64, planID int, originalMessage *tgbot
 
 	// Send file to Telegram
 	doc := tgbotapi.NewDocument(chatID, tgbotapi.FilePath(tempPath))
-	caption := fmt.Sprintf("📊 План: %s\n📅 %d недель | %d тренировок/нед", plan.Name, plan.TotalWeeks, plan.DaysPerWeek)
+	caption := b.tf("plan_export_caption", chatID, plan.Name, plan.TotalWeeks, plan.DaysPerWeek)
 	if workouts != nil && len(workouts.Weeks) > 0 {
 		totalEx := 0
 		for _, w := range workouts.Weeks {
@@ -147,17 +148,17 @@ func (b *Bot) exportPlanToExcel(chatID int64, planID int, originalMessage *tgbot
 				totalEx += len(d.Exercises)
 			}
 		}
-		caption += fmt.Sprintf("\n🏋️ %d упражнений", totalEx)
+		caption += "\n" + b.tf("plan_export_caption_exercises", chatID, totalEx)
 	}
 	doc.Caption = caption
 	if _, err := b.api.Send(doc); err != nil {
 		log.Printf("Ошибка отправки файла: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка отправки файла")
+		msg := tgbotapi.NewMessage(chatID, b.t("plan_export_send_error", chatID))
 		b.api.Send(msg)
 		return
 	}
 
-	msg := tgbotapi.NewMessage(chatID, "✅ Excel файл отправлен!")
+	msg := tgbotapi.NewMessage(chatID, b.t("plan_export_sent", chatID))
 	b.api.Send(msg)
 
 	b.clearPlanState(chatID)
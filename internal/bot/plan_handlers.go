@@ -11,6 +11,7 @@ import (
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"workbot/internal/generator"
+	"workbot/internal/i18n"
 	"workbot/internal/models"
 	"workbot/internal/training"
 )
@@ -51,19 +52,19 @@ func (b *Bot) handlePlansMenu(message *tgbotapi.Message) {
 	userStates.states[chatID] = statePlanMenu
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "📋 Тренировочные планы\n\nВыберите действие:")
+	msg := tgbotapi.NewMessage(chatID, b.t("plans_menu_title", chatID)+"\n\n"+b.t("select_action", chatID))
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Создать план"),
+			tgbotapi.NewKeyboardButton(b.t("plans_btn_create", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Просмотр планов"),
+			tgbotapi.NewKeyboardButton(b.t("plans_btn_list", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Экспорт в Excel"),
+			tgbotapi.NewKeyboardButton(b.t("plans_btn_export", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Назад"),
+			tgbotapi.NewKeyboardButton(b.t("back", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -75,25 +76,25 @@ func (b *Bot) handlePlanMenuChoice(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	switch text {
-	case "Создать план":
+	switch i18n.Match(text, "plans_btn_create", "plans_btn_list", "plans_btn_export", "back") {
+	case "plans_btn_create":
 		userStates.Lock()
 		userStates.states[chatID] = statePlanSelectClient
 		userStates.Unlock()
-		b.showClientsForPlan(chatID, "Выберите клиента для создания плана:")
+		b.showClientsForPlan(chatID, b.t("plans_select_client", chatID))
 
-	case "Просмотр планов":
+	case "plans_btn_list":
 		b.showPlansList(chatID)
 
-	case "Экспорт в Excel":
+	case "plans_btn_export":
 		b.showPlansForExport(chatID)
 
-	case "Назад":
+	case "back":
 		b.clearPlanState(chatID)
 		b.handleAdminStart(message)
 
 	default:
-		msg := tgbotapi.NewMessage(chatID, "Выберите действие из меню")
+		msg := tgbotapi.NewMessage(chatID, b.t("select_action_menu", chatID))
 		b.api.Send(msg)
 	}
 }
@@ -108,7 +109,7 @@ func (b *Bot) showClientsForPlan(chatID int64, text string) {
 		ORDER BY c.name, c.surname`)
 	if err != nil {
 		log.Printf("Ошибка получения клиентов: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка загрузки списка клиентов")
+		msg := tgbotapi.NewMessage(chatID, b.t("admin_clients_load_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -123,7 +124,7 @@ func (b *Bot) showClientsForPlan(chatID int64, text string) {
 		}
 		buttonText := fmt.Sprintf("PLAN>> %s %s", name, surname)
 		if pmCount > 0 {
-			buttonText += fmt.Sprintf(" (%d 1ПМ)", pmCount)
+			buttonText += " " + b.tf("plans_client_1pm_count", chatID, pmCount)
 		}
 		buttonText += fmt.Sprintf(" [%d]", id)
 		buttons = append(buttons, tgbotapi.NewKeyboardButtonRow(
@@ -132,13 +133,13 @@ func (b *Bot) showClientsForPlan(chatID int64, text string) {
 	}
 
 	if len(buttons) == 0 {
-		msg := tgbotapi.NewMessage(chatID, "Нет клиентов. Сначала добавьте клиента через меню клиентов.")
+		msg := tgbotapi.NewMessage(chatID, b.t("plans_no_clients", chatID))
 		b.api.Send(msg)
 		return
 	}
 
 	buttons = append(buttons, tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton("Отмена"),
+		tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 	))
 
 	msg := tgbotapi.NewMessage(chatID, text)
@@ -151,7 +152,7 @@ func (b *Bot) handlePlanClientSelect(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.clearPlanState(chatID)
 		b.handlePlansMenu(message)
 		return
@@ -160,7 +161,7 @@ func (b *Bot) handlePlanClientSelect(message *tgbotapi.Message) {
 	// Parse client ID
 	clientID := parsePlanClientID(text)
 	if clientID == 0 {
-		msg := tgbotapi.NewMessage(chatID, "Ошибка выбора клиента")
+		msg := tgbotapi.NewMessage(chatID, b.t("admin_client_select_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -170,17 +171,14 @@ func (b *Bot) handlePlanClientSelect(message *tgbotapi.Message) {
 	b.db.QueryRow("SELECT COUNT(*) FROM public.exercise_1pm WHERE client_id = $1", clientID).Scan(&pmCount)
 
 	if pmCount == 0 {
-		msg := tgbotapi.NewMessage(chatID,
-			"⚠️ У клиента нет записей 1ПМ.\n\n"+
-				"Для создания плана с прогрессией рекомендуется сначала записать 1ПМ.\n\n"+
-				"Продолжить без 1ПМ? (веса будут указаны в %)")
+		msg := tgbotapi.NewMessage(chatID, b.t("plans_no_1pm", chatID))
 		keyboard := tgbotapi.NewReplyKeyboard(
 			tgbotapi.NewKeyboardButtonRow(
-				tgbotapi.NewKeyboardButton("Да, продолжить"),
-				tgbotapi.NewKeyboardButton("Записать 1ПМ"),
+				tgbotapi.NewKeyboardButton(b.t("plans_btn_continue", chatID)),
+				tgbotapi.NewKeyboardButton(b.t("plans_btn_record_1pm", chatID)),
 			),
 			tgbotapi.NewKeyboardButtonRow(
-				tgbotapi.NewKeyboardButton("Отмена"),
+				tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 			),
 		)
 		msg.ReplyMarkup = keyboard
@@ -214,18 +212,18 @@ func (b *Bot) handlePlanNo1PMConfirm(message *tgbotapi.Message) {
 	clientID := planStore.clientID[chatID]
 	planStore.RUnlock()
 
-	switch text {
-	case "Да, продолжить":
+	switch i18n.Match(text, "plans_btn_continue", "plans_btn_record_1pm", "cancel") {
+	case "plans_btn_continue":
 		b.showPlanGoalSelection(chatID)
-	case "Записать 1ПМ":
+	case "plans_btn_record_1pm":
 		// Не очищаем planState — переходим к записи 1ПМ с флагом возврата
 		b.handle1PMForClient(chatID, clientID, true)
 		return
-	case "Отмена":
+	case "cancel":
 		b.clearPlanState(chatID)
 		b.handlePlansMenu(message)
 	default:
-		msg := tgbotapi.NewMessage(chatID, "Выберите вариант из меню")
+		msg := tgbotapi.NewMessage(chatID, b.t("select_option_menu", chatID))
 		b.api.Send(msg)
 	}
 }
@@ -236,18 +234,18 @@ func (b *Bot) showPlanGoalSelection(chatID int64) {
 	userStates.states[chatID] = statePlanSelectGoal
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Выберите цель программы:")
+	msg := tgbotapi.NewMessage(chatID, b.t("plans_select_goal", chatID))
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("💪 Сила"),
-			tgbotapi.NewKeyboardButton("🏋️ Масса"),
+			tgbotapi.NewKeyboardButton(b.t("plans_goal_strength", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("plans_goal_hypertrophy", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("🔥 Похудение"),
-			tgbotapi.NewKeyboardButton("🏆 Соревнования"),
+			tgbotapi.NewKeyboardButton(b.t("plans_goal_weight_loss", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("plans_goal_competition", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -259,27 +257,19 @@ func (b *Bot) handlePlanGoalSelect(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.clearPlanState(chatID)
 		b.handlePlansMenu(message)
 		return
 	}
 
-	var goal string
-	switch text {
-	case "💪 Сила":
-		goal = "strength"
-	case "🏋️ Масса":
-		goal = "hypertrophy"
-	case "🔥 Похудение":
-		goal = "weight_loss"
-	case "🏆 Соревнования":
-		goal = "competition"
-	default:
-		msg := tgbotapi.NewMessage(chatID, "Выберите цель из меню")
+	key := i18n.Match(text, "plans_goal_strength", "plans_goal_hypertrophy", "plans_goal_weight_loss", "plans_goal_competition")
+	if key == "" {
+		msg := tgbotapi.NewMessage(chatID, b.t("plans_select_goal", chatID))
 		b.api.Send(msg)
 		return
 	}
+	goal := strings.TrimPrefix(key, "plans_goal_")
 
 	planStore.Lock()
 	planStore.goal[chatID] = goal
@@ -294,18 +284,12 @@ func (b *Bot) showPlanDurationSelection(chatID int64) {
 	userStates.states[chatID] = statePlanSelectDuration
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "На сколько недель составить план?")
+	msg := tgbotapi.NewMessage(chatID, b.t("plans_select_weeks", chatID))
 	keyboard := tgbotapi.NewReplyKeyboard(
+		b.countButtons("weeks", chatID, 4, 8),
+		b.countButtons("weeks", chatID, 12, 16),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("4 недели"),
-			tgbotapi.NewKeyboardButton("8 недель"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("12 недель"),
-			tgbotapi.NewKeyboardButton("16 недель"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -317,24 +301,15 @@ func (b *Bot) handlePlanDurationSelect(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.clearPlanState(chatID)
 		b.handlePlansMenu(message)
 		return
 	}
 
-	var weeks int
-	switch text {
-	case "4 недели":
-		weeks = 4
-	case "8 недель":
-		weeks = 8
-	case "12 недель":
-		weeks = 12
-	case "16 недель":
-		weeks = 16
-	default:
-		msg := tgbotapi.NewMessage(chatID, "Выберите из предложенных вариантов")
+	weeks, ok := parseCountButton(text, 4, 8, 12, 16)
+	if !ok {
+		msg := tgbotapi.NewMessage(chatID, b.t("select_option_menu", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -352,21 +327,13 @@ func (b *Bot) showPlanDaysSelection(chatID int64) {
 	userStates.states[chatID] = statePlanSelectDays
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Сколько тренировок в неделю?")
+	msg := tgbotapi.NewMessage(chatID, b.t("plans_select_days", chatID))
 	keyboard := tgbotapi.NewReplyKeyboard(
+		b.countButtons("days", chatID, 2, 3),
+		b.countButtons("days", chatID, 4, 5),
+		b.countButtons("days", chatID, 6),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("2 дня"),
-			tgbotapi.NewKeyboardButton("3 дня"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("4 дня"),
-			tgbotapi.NewKeyboardButton("5 дней"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("6 дней"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -378,26 +345,15 @@ func (b *Bot) handlePlanDaysSelect(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.clearPlanState(chatID)
 		b.handlePlansMenu(message)
 		return
 	}
 
-	var days int
-	switch text {
-	case "2 дня":
-		days = 2
-	case "3 дня":
-		days = 3
-	case "4 дня":
-		days = 4
-	case "5 дней":
-		days = 5
-	case "6 дней":
-		days = 6
-	default:
-		msg := tgbotapi.NewMessage(chatID, "Выберите из предложенных вариантов")
+	days, ok := parseCountButton(text, 2, 3, 4, 5, 6)
+	if !ok {
+		msg := tgbotapi.NewMessage(chatID, b.t("select_option_menu", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -429,21 +385,15 @@ func (b *Bot) showPlanConfirmation(chatID int64) {
 	// Get goal name
 	goalName := training.PeriodizationTemplates[goal].Name
 
-	text := fmt.Sprintf("📋 Подтверждение создания плана\n\n"+
-		"👤 Клиент: %s\n"+
-		"🎯 Цель: %s\n"+
-		"📅 Длительность: %d недель\n"+
-		"🏋️ Тренировок в неделю: %d\n\n"+
-		"Создать план?",
-		clientName, goalName, weeks, days)
+	text := b.tf("plans_confirm", chatID, clientName, goalName, b.tn("weeks", chatID, weeks), days)
 
 	msg := tgbotapi.NewMessage(chatID, text)
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("✅ Создать план"),
+			tgbotapi.NewKeyboardButton(b.t("plans_btn_confirm", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("❌ Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -455,14 +405,14 @@ func (b *Bot) handlePlanConfirm(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	switch text {
-	case "✅ Создать план":
+	switch i18n.Match(text, "plans_btn_confirm", "cancel") {
+	case "plans_btn_confirm":
 		b.createTrainingPlan(chatID, message)
-	case "❌ Отмена":
+	case "cancel":
 		b.clearPlanState(chatID)
 		b.handlePlansMenu(message)
 	default:
-		msg := tgbotapi.NewMessage(chatID, "Выберите действие из меню")
+		msg := tgbotapi.NewMessage(chatID, b.t("select_action_menu", chatID))
 		b.api.Send(msg)
 	}
 }
@@ -483,14 +433,14 @@ func (b *Bot) createTrainingPlan(chatID int64, message *tgbotapi.Message) {
 	goalName := training.PeriodizationTemplates[goal].Name
 	planName := fmt.Sprintf("%s - %s", clientName, goalName)
 
-	waitMsg := tgbotapi.NewMessage(chatID, "⏳ Создаю план тренировок с полной программой...")
+	waitMsg := tgbotapi.NewMessage(chatID, b.t("plans_creating", chatID))
 	b.api.Send(waitMsg)
 
 	// Load client profile for generator
 	client, err := b.loadClientProfile(clientID)
 	if err != nil {
 		log.Printf("Ошибка загрузки профиля клиента: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка загрузки данных клиента")
+		msg := tgbotapi.NewMessage(chatID, b.t("plans_client_load_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -533,7 +483,7 @@ func (b *Bot) createTrainingPlan(chatID int64, message *tgbotapi.Message) {
 
 	if err != nil {
 		log.Printf("Ошибка генерации программы: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка генерации программы")
+		msg := tgbotapi.NewMessage(chatID, b.t("plans_generate_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -553,7 +503,7 @@ func (b *Bot) createTrainingPlan(chatID int64, message *tgbotapi.Message) {
 	tx, err := b.db.Begin()
 	if err != nil {
 		log.Printf("Ошибка транзакции: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка создания плана")
+		msg := tgbotapi.NewMessage(chatID, b.t("plans_save_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -571,7 +521,7 @@ func (b *Bot) createTrainingPlan(chatID int64, message *tgbotapi.Message) {
 	if err != nil {
 		tx.Rollback()
 		log.Printf("Ошибка создания плана: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка сохранения плана")
+		msg := tgbotapi.NewMessage(chatID, b.t("plans_save_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -591,7 +541,7 @@ func (b *Bot) createTrainingPlan(chatID int64, message *tgbotapi.Message) {
 		if err != nil {
 			tx.Rollback()
 			log.Printf("Ошибка создания мезоцикла: %v", err)
-			msg := tgbotapi.NewMessage(chatID, "Ошибка сохранения периодизации")
+			msg := tgbotapi.NewMessage(chatID, b.t("plans_save_error", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -635,7 +585,7 @@ func (b *Bot) createTrainingPlan(chatID int64, message *tgbotapi.Message) {
 				var workoutID int
 				workoutName := day.Name
 				if workoutName == "" {
-					workoutName = fmt.Sprintf("Неделя %d, День %d", week.WeekNum, day.DayNum)
+					workoutName = b.tf("program_workout_default_name", chatID, week.WeekNum, day.DayNum)
 				}
 
 				err = tx.QueryRow(`
@@ -695,7 +645,7 @@ func (b *Bot) createTrainingPlan(chatID int64, message *tgbotapi.Message) {
 
 	if err = tx.Commit(); err != nil {
 		log.Printf("Ошибка коммита: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка сохранения плана")
+		msg := tgbotapi.NewMessage(chatID, b.t("plans_save_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -711,24 +661,19 @@ func (b *Bot) createTrainingPlan(chatID int64, message *tgbotapi.Message) {
 	}
 
 	// Show success message
-	responseText := fmt.Sprintf("✅ План создан!\n\n"+
-		"📋 %s\n"+
-		"📅 %d недель, %d тренировок/неделю\n\n"+
-		"Периодизация:\n", planName, weeks, days)
+	responseText := b.tf("plans_created", chatID, planName, b.tn("weeks", chatID, weeks), days) + "\n"
 
 	for _, meso := range plan.Mesocycles {
-		responseText += fmt.Sprintf("• Нед. %d-%d: %s (%s)\n",
-			meso.WeekStart, meso.WeekEnd, meso.Name, meso.Phase.NameRu())
+		responseText += b.tf("plans_created_meso", chatID,
+			meso.WeekStart, meso.WeekEnd, meso.Name, meso.Phase.NameRu()) + "\n"
 	}
 
-	responseText += fmt.Sprintf("\n🏋️ Сгенерировано:\n"+
-		"• %d тренировок\n"+
-		"• %d упражнений всего\n", totalWorkouts, totalExercises)
+	responseText += "\n" + b.tf("plans_created_generated", chatID, totalWorkouts, totalExercises) + "\n"
 
 	if len(client1PMs) > 0 {
-		responseText += fmt.Sprintf("\n📈 Прогрессия весов рассчитана для %d упражнений с 1ПМ\n", len(client1PMs))
+		responseText += "\n" + b.tf("plans_created_progression", chatID, len(client1PMs)) + "\n"
 	} else {
-		responseText += "\n💡 Добавьте 1ПМ для расчёта конкретных весов\n"
+		responseText += "\n" + b.t("plans_created_no_1pm", chatID) + "\n"
 	}
 
 	msg := tgbotapi.NewMessage(chatID, responseText)
@@ -785,14 +730,14 @@ func (b *Bot) showPlansList(chatID int64) {
 		LIMIT 20`)
 	if err != nil {
 		log.Printf("Ошибка получения планов: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка загрузки планов")
+		msg := tgbotapi.NewMessage(chatID, b.t("plans_load_error", chatID))
 		b.api.Send(msg)
 		return
 	}
 	defer rows.Close()
 
 	var text strings.Builder
-	text.WriteString("📋 Тренировочные планы:\n\n")
+	text.WriteString(b.t("plans_list_title", chatID) + "\n\n")
 
 	count := 0
 	for rows.Next() {
@@ -813,16 +758,16 @@ func (b *Bot) showPlansList(chatID int64) {
 			statusEmoji = "📝"
 		}
 
-		text.WriteString(fmt.Sprintf("%s #%d %s\n   👤 %s | %d нед. | с %s\n\n",
-			statusEmoji, id, name, clientName, weeks, startDate.Format("02.01.2006")))
+		text.WriteString(b.tf("plans_list_item", chatID,
+			statusEmoji, id, name, clientName, weeks, startDate.Format("02.01.2006")) + "\n\n")
 		count++
 	}
 
 	if count == 0 {
-		text.WriteString("Нет планов. Создайте первый план!")
+		text.WriteString(b.t("plans_list_empty", chatID))
 	}
 
-	text.WriteString("\nИспользуйте кнопки меню для действий.")
+	text.WriteString("\n" + b.t("plans_list_hint", chatID))
 	msg := tgbotapi.NewMessage(chatID, text.String())
 	b.api.Send(msg)
 }
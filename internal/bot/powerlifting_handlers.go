@@ -9,6 +9,7 @@ import (
 
 	"workbot/clients/ai"
 	"workbot/internal/gsheets"
+	"workbot/internal/i18n"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -56,39 +57,30 @@ func getPLGenerator() *ai.ProgramGenerator {
 func (b *Bot) handlePowerliftingMenu(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 
-	msg := tgbotapi.NewMessage(chatID, "🏋️ Генератор программ\n\n"+
-		"Силовые программы:\n"+
-		"• Шейко (КМС/МС уровень)\n"+
-		"• Головинский (циклы 2/7/11 недель)\n"+
-		"• Верхошанский (специализация)\n"+
-		"• Муравьёв (классическая периодизация)\n"+
-		"• Русский цикл (6 недель)\n\n"+
-		"Фитнес/силовой спорт:\n"+
-		"• Ягодичный мост 12 недель (Hip Thrust)\n\n"+
-		"Выберите действие:")
+	msg := tgbotapi.NewMessage(chatID, b.t("pl_menu_text", chatID))
 
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("PL: Троеборье"),
+			tgbotapi.NewKeyboardButton(b.t("pl_btn_powerlifting", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("PL: Жим лёжа"),
-			tgbotapi.NewKeyboardButton("PL: Присед"),
+			tgbotapi.NewKeyboardButton(b.t("pl_btn_bench", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("pl_btn_squat", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("PL: Становая тяга"),
+			tgbotapi.NewKeyboardButton(b.t("pl_btn_deadlift", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("PL: Ягодичный мост"),
+			tgbotapi.NewKeyboardButton(b.t("pl_btn_hip_thrust", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("PL: Авто-подбор"),
+			tgbotapi.NewKeyboardButton(b.t("pl_btn_auto", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("PL: Список шаблонов"),
+			tgbotapi.NewKeyboardButton(b.t("pl_btn_templates", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Назад"),
+			tgbotapi.NewKeyboardButton(b.t("back", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -105,7 +97,7 @@ func (b *Bot) handlePLLiftType(message *tgbotapi.Message, liftType ai.LiftType)
 
 	gen := getPLGenerator()
 	if gen == nil {
-		msg := tgbotapi.NewMessage(chatID, "Ошибка инициализации генератора")
+		msg := tgbotapi.NewMessage(chatID, b.t("program_generator_init_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -152,12 +144,11 @@ func (b *Bot) handlePLLiftType(message *tgbotapi.Message, liftType ai.LiftType)
 	}
 
 	buttons = append(buttons, tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton("Отмена"),
+		tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 	))
 
-	liftName := getLiftTypeName(liftType)
-	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf(
-		"Выбрана дисциплина: %s\n\nВыберите шаблон программы:", liftName))
+	liftName := b.liftTypeName(liftType, chatID)
+	msg := tgbotapi.NewMessage(chatID, b.tf("pl_lift_selected", chatID, liftName))
 	msg.ReplyMarkup = tgbotapi.NewReplyKeyboard(buttons...)
 	b.api.Send(msg)
 
@@ -171,7 +162,7 @@ func (b *Bot) handlePLTemplateSelection(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.handleAdminCancel(message)
 		return
 	}
@@ -192,27 +183,21 @@ func (b *Bot) handlePLTemplateSelection(message *tgbotapi.Message) {
 	var prompt string
 	switch liftType {
 	case ai.LiftTypeBench:
-		prompt = "Введите ваш 1ПМ в жиме лёжа (кг):\n\nПример: 100"
+		prompt = b.t("pl_prompt_bench", chatID)
 	case ai.LiftTypeSquat:
-		prompt = "Введите ваш 1ПМ в приседе (кг):\n\nПример: 150"
+		prompt = b.t("pl_prompt_squat", chatID)
 	case ai.LiftTypeDeadlift:
-		prompt = "Введите ваш 1ПМ в становой тяге (кг):\n\nПример: 180"
+		prompt = b.t("pl_prompt_deadlift", chatID)
 	case ai.LiftTypeHipThrust:
-		prompt = "Введите ваш 1ПМ в ягодичном мосте (кг):\n\n" +
-			"Пример: 200\n\n" +
-			"(Опционально: добавьте жим через запятую для верха тела)\n" +
-			"Пример: 200, 80"
+		prompt = b.t("pl_prompt_hip_thrust", chatID)
 	default:
-		prompt = "Введите ваши максимумы (1ПМ) через запятую:\n" +
-			"Присед, Жим, Тяга\n\n" +
-			"Пример: 150, 100, 180"
+		prompt = b.t("pl_prompt_full", chatID)
 	}
 
-	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf(
-		"Шаблон: %s\n\n%s", templateName, prompt))
+	msg := tgbotapi.NewMessage(chatID, b.tf("pl_template_selected", chatID, templateName, prompt))
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -224,7 +209,7 @@ func (b *Bot) handlePLMaxesInput(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.handleAdminCancel(message)
 		return
 	}
@@ -240,21 +225,21 @@ func (b *Bot) handlePLMaxesInput(message *tgbotapi.Message) {
 	case ai.LiftTypeBench:
 		bench, err = strconv.ParseFloat(strings.TrimSpace(text), 64)
 		if err != nil || bench <= 0 {
-			msg := tgbotapi.NewMessage(chatID, "Неверный формат. Введите число (например: 100)")
+			msg := tgbotapi.NewMessage(chatID, b.tf("pl_invalid_number", chatID, 100))
 			b.api.Send(msg)
 			return
 		}
 	case ai.LiftTypeSquat:
 		squat, err = strconv.ParseFloat(strings.TrimSpace(text), 64)
 		if err != nil || squat <= 0 {
-			msg := tgbotapi.NewMessage(chatID, "Неверный формат. Введите число (например: 150)")
+			msg := tgbotapi.NewMessage(chatID, b.tf("pl_invalid_number", chatID, 150))
 			b.api.Send(msg)
 			return
 		}
 	case ai.LiftTypeDeadlift:
 		deadlift, err = strconv.ParseFloat(strings.TrimSpace(text), 64)
 		if err != nil || deadlift <= 0 {
-			msg := tgbotapi.NewMessage(chatID, "Неверный формат. Введите число (например: 180)")
+			msg := tgbotapi.NewMessage(chatID, b.tf("pl_invalid_number", chatID, 180))
 			b.api.Send(msg)
 			return
 		}
@@ -263,7 +248,7 @@ func (b *Bot) handlePLMaxesInput(message *tgbotapi.Message) {
 		parts := strings.Split(text, ",")
 		hipThrust, err = strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
 		if err != nil || hipThrust <= 0 {
-			msg := tgbotapi.NewMessage(chatID, "Неверный формат. Введите число (например: 200)")
+			msg := tgbotapi.NewMessage(chatID, b.tf("pl_invalid_number", chatID, 200))
 			b.api.Send(msg)
 			return
 		}
@@ -275,25 +260,25 @@ func (b *Bot) handlePLMaxesInput(message *tgbotapi.Message) {
 		// Троеборье - парсим три числа
 		parts := strings.Split(text, ",")
 		if len(parts) != 3 {
-			msg := tgbotapi.NewMessage(chatID, "Неверный формат. Введите три числа через запятую:\nПрисед, Жим, Тяга\n\nПример: 150, 100, 180")
+			msg := tgbotapi.NewMessage(chatID, b.t("pl_invalid_full", chatID))
 			b.api.Send(msg)
 			return
 		}
 		squat, err = strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
 		if err != nil || squat <= 0 {
-			msg := tgbotapi.NewMessage(chatID, "Неверное значение приседа")
+			msg := tgbotapi.NewMessage(chatID, b.t("pl_invalid_squat", chatID))
 			b.api.Send(msg)
 			return
 		}
 		bench, err = strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
 		if err != nil || bench <= 0 {
-			msg := tgbotapi.NewMessage(chatID, "Неверное значение жима")
+			msg := tgbotapi.NewMessage(chatID, b.t("pl_invalid_bench", chatID))
 			b.api.Send(msg)
 			return
 		}
 		deadlift, err = strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
 		if err != nil || deadlift <= 0 {
-			msg := tgbotapi.NewMessage(chatID, "Неверное значение тяги")
+			msg := tgbotapi.NewMessage(chatID, b.t("pl_invalid_deadlift", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -311,21 +296,15 @@ func (b *Bot) handlePLMaxesInput(message *tgbotapi.Message) {
 	userStates.states[chatID] = "pl_select_days"
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Сколько тренировок в неделю?\n\n"+
-		"0 = как в шаблоне (по умолчанию)")
+	msg := tgbotapi.NewMessage(chatID, b.t("pl_days_prompt", chatID))
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Как в шаблоне"),
+			tgbotapi.NewKeyboardButton(b.t("pl_days_as_template", chatID)),
 		),
+		b.countButtons("days", chatID, 2, 3),
+		b.countButtons("days", chatID, 4),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("2 дня"),
-			tgbotapi.NewKeyboardButton("3 дня"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("4 дня"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -337,23 +316,17 @@ func (b *Bot) handlePLDaysInput(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.handleAdminCancel(message)
 		return
 	}
 
-	var days int
-	switch text {
-	case "Как в шаблоне":
-		days = 0
-	case "2 дня":
-		days = 2
-	case "3 дня":
-		days = 3
-	case "4 дня":
-		days = 4
-	default:
-		msg := tgbotapi.NewMessage(chatID, "Выберите вариант из кнопок")
+	days, ok := parseCountButton(text, 2, 3, 4)
+	if i18n.Is(text, "pl_days_as_template") {
+		days, ok = 0, true
+	}
+	if !ok {
+		msg := tgbotapi.NewMessage(chatID, b.t("select_option_menu", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -384,12 +357,12 @@ func (b *Bot) generatePLProgram(message *tgbotapi.Message) {
 	days := plStates.daysPerWeek[chatID]
 	plStates.RUnlock()
 
-	waitMsg := tgbotapi.NewMessage(chatID, "⏳ Генерирую программу...")
+	waitMsg := tgbotapi.NewMessage(chatID, b.t("program_generating", chatID))
 	b.api.Send(waitMsg)
 
 	gen := getPLGenerator()
 	if gen == nil {
-		msg := tgbotapi.NewMessage(chatID, "Ошибка генератора")
+		msg := tgbotapi.NewMessage(chatID, b.t("pl_generator_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -410,7 +383,7 @@ func (b *Bot) generatePLProgram(message *tgbotapi.Message) {
 	program, err := gen.GenerateFromTemplateWithOptions(templateName, maxes, opts)
 	if err != nil {
 		log.Printf("Ошибка генерации: %v", err)
-		msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("Ошибка генерации: %v", err))
+		msg := tgbotapi.NewMessage(chatID, b.tf("program_generation_error", chatID, err))
 		b.api.Send(msg)
 		b.handlePowerliftingMenu(message)
 		return
@@ -425,14 +398,7 @@ func (b *Bot) generatePLProgram(message *tgbotapi.Message) {
 	validation := gen.ValidateProgram(program)
 
 	// Форматируем статистику
-	statsMsg := fmt.Sprintf("✅ Программа сгенерирована!\n\n"+
-		"📊 Статистика:\n"+
-		"• Шаблон: %s\n"+
-		"• Недель: %d\n"+
-		"• Тренировок: %d\n"+
-		"• Общий КПШ: %d\n"+
-		"• Тоннаж: %.1f т\n"+
-		"• Средний КПШ/нед: %.0f\n",
+	statsMsg := b.tf("pl_generated_stats", chatID,
 		program.Name,
 		len(program.Weeks),
 		validation.Stats.TotalWorkouts,
@@ -441,7 +407,7 @@ func (b *Bot) generatePLProgram(message *tgbotapi.Message) {
 		validation.Stats.AvgKPSPerWeek)
 
 	if len(validation.Warnings) > 0 {
-		statsMsg += "\n⚠️ Предупреждения:\n"
+		statsMsg += "\n" + b.t("pl_warnings_title", chatID) + "\n"
 		for _, w := range validation.Warnings {
 			statsMsg += fmt.Sprintf("• %s\n", w)
 		}
@@ -456,26 +422,30 @@ func (b *Bot) generatePLProgram(message *tgbotapi.Message) {
 
 // showPLProgramOptions показывает меню действий с программой
 func (b *Bot) showPLProgramOptions(chatID int64) {
-	optionsMsg := tgbotapi.NewMessage(chatID, "Что сделать с программой?")
+	optionsMsg := tgbotapi.NewMessage(chatID, b.t("program_options_title", chatID))
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Показать 1-ю неделю"),
+			tgbotapi.NewKeyboardButton(b.t("program_btn_show_week1", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Показать всю программу"),
+			tgbotapi.NewKeyboardButton(b.t("program_btn_show_all", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Экспорт в файл"),
+			tgbotapi.NewKeyboardButton(b.t("pl_btn_export_file", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("program_btn_export_pdf", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("PL: Отправить клиенту"),
+			tgbotapi.NewKeyboardButton(b.t("pl_btn_send_client", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("PL: Экспорт в Google"),
+			tgbotapi.NewKeyboardButton(b.t("pl_btn_export_google", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Новая программа"),
-			tgbotapi.NewKeyboardButton("В меню PL"),
+			tgbotapi.NewKeyboardButton(b.t("program_btn_save_template", chatID)),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(b.t("program_btn_new_program", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("pl_btn_menu", chatID)),
 		),
 	)
 	optionsMsg.ReplyMarkup = keyboard
@@ -487,6 +457,12 @@ func (b *Bot) showPLProgramOptions(chatID int64) {
 	b.api.Send(optionsMsg)
 }
 
+// plReviewKeys — кнопки меню действий с PL-программой
+var plReviewKeys = []string{
+	"program_btn_show_week1", "program_btn_show_all", "pl_btn_export_file", "program_btn_export_pdf", "pl_btn_send_client",
+	"pl_btn_export_google", "program_btn_save_template", "program_btn_new_program", "pl_btn_menu",
+}
+
 // handlePLReview обрабатывает действия с программой
 func (b *Bot) handlePLReview(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
@@ -497,48 +473,55 @@ func (b *Bot) handlePLReview(message *tgbotapi.Message) {
 	plStates.RUnlock()
 
 	if program == nil {
-		msg := tgbotapi.NewMessage(chatID, "Программа не найдена")
+		msg := tgbotapi.NewMessage(chatID, b.t("program_not_found", chatID))
 		b.api.Send(msg)
 		b.handlePowerliftingMenu(message)
 		return
 	}
 
-	switch text {
-	case "Показать 1-ю неделю":
+	switch i18n.Match(text, plReviewKeys...) {
+	case "program_btn_show_week1":
 		if len(program.Weeks) > 0 {
 			formatted := ai.FormatWeekCompact(program.Weeks[0])
-			sendLongMessage(b, chatID, "📅 Неделя 1:\n\n"+formatted)
+			sendLongMessage(b, chatID, b.t("program_week1_title", chatID)+"\n\n"+formatted)
 		}
 
-	case "Показать всю программу":
+	case "program_btn_show_all":
 		formatted := ai.FormatPLProgram(program)
 		sendLongMessage(b, chatID, formatted)
 
-	case "Экспорт в файл":
+	case "pl_btn_export_file":
 		formatted := ai.FormatPLProgram(program)
 		// Отправляем как документ
 		doc := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{
 			Name:  fmt.Sprintf("%s.txt", sanitizeFilename(program.Name)),
 			Bytes: []byte(formatted),
 		})
-		doc.Caption = fmt.Sprintf("Программа: %s", program.Name)
+		doc.Caption = b.tf("pl_export_caption", chatID, program.Name)
 		b.api.Send(doc)
 
-	case "PL: Отправить клиенту":
+	case "program_btn_export_pdf":
+		b.sendPLProgramPDF(chatID, program)
+
+	case "pl_btn_send_client":
 		b.handlePLSendToClient(message)
 		return
 
-	case "PL: Экспорт в Google":
+	case "pl_btn_export_google":
 		b.handlePLExportToGoogle(message)
 		return
 
-	case "Новая программа":
+	case "program_btn_save_template":
+		b.startSaveTemplate(chatID, templateFromPL(program))
+		return
+
+	case "program_btn_new_program":
 		// Очищаем состояние
 		clearPLState(chatID)
 		b.handlePowerliftingMenu(message)
 		return
 
-	case "В меню PL":
+	case "pl_btn_menu":
 		clearPLState(chatID)
 		userStates.Lock()
 		delete(userStates.states, chatID)
@@ -560,14 +543,10 @@ func (b *Bot) handlePLAutoSelect(message *tgbotapi.Message) {
 	plStates.selectedLift[chatID] = ai.LiftTypeFull
 	plStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "🎯 Авто-подбор программы\n\n"+
-		"Система подберёт оптимальный шаблон на основе вашего уровня.\n\n"+
-		"Введите ваши максимумы (1ПМ) через запятую:\n"+
-		"Присед, Жим, Тяга\n\n"+
-		"Пример: 150, 100, 180")
+	msg := tgbotapi.NewMessage(chatID, b.t("pl_auto_prompt", chatID))
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -579,14 +558,14 @@ func (b *Bot) handlePLAutoMaxesInput(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.handleAdminCancel(message)
 		return
 	}
 
 	parts := strings.Split(text, ",")
 	if len(parts) != 3 {
-		msg := tgbotapi.NewMessage(chatID, "Введите три числа через запятую:\nПрисед, Жим, Тяга")
+		msg := tgbotapi.NewMessage(chatID, b.t("pl_auto_invalid_count", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -596,7 +575,7 @@ func (b *Bot) handlePLAutoMaxesInput(message *tgbotapi.Message) {
 	deadlift, err3 := strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
 
 	if err1 != nil || err2 != nil || err3 != nil || squat <= 0 || bench <= 0 || deadlift <= 0 {
-		msg := tgbotapi.NewMessage(chatID, "Неверный формат. Пример: 150, 100, 180")
+		msg := tgbotapi.NewMessage(chatID, b.t("pl_auto_invalid", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -605,12 +584,12 @@ func (b *Bot) handlePLAutoMaxesInput(message *tgbotapi.Message) {
 	delete(userStates.states, chatID)
 	userStates.Unlock()
 
-	waitMsg := tgbotapi.NewMessage(chatID, "⏳ Анализирую уровень и подбираю программу...")
+	waitMsg := tgbotapi.NewMessage(chatID, b.t("pl_auto_analyzing", chatID))
 	b.api.Send(waitMsg)
 
 	gen := getPLGenerator()
 	if gen == nil {
-		msg := tgbotapi.NewMessage(chatID, "Ошибка генератора")
+		msg := tgbotapi.NewMessage(chatID, b.t("pl_generator_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -629,7 +608,7 @@ func (b *Bot) handlePLAutoMaxesInput(message *tgbotapi.Message) {
 	program, err := gen.GenerateAutomatic(maxes, opts)
 	if err != nil {
 		log.Printf("Ошибка авто-генерации: %v", err)
-		msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("Ошибка: %v", err))
+		msg := tgbotapi.NewMessage(chatID, b.tf("pl_error", chatID, err))
 		b.api.Send(msg)
 		b.handlePowerliftingMenu(message)
 		return
@@ -648,29 +627,18 @@ func (b *Bot) handlePLAutoMaxesInput(message *tgbotapi.Message) {
 	var level string
 	switch {
 	case total < 350:
-		level = "Новичок"
+		level = b.t("pl_level_novice", chatID)
 	case total < 500:
-		level = "Средний"
+		level = b.t("pl_level_intermediate", chatID)
 	case total < 650:
-		level = "КМС"
+		level = b.t("pl_level_cms", chatID)
 	default:
-		level = "МС+"
+		level = b.t("pl_level_ms", chatID)
 	}
 
 	validation := gen.ValidateProgram(program)
 
-	statsMsg := fmt.Sprintf("🎯 Программа подобрана автоматически!\n\n"+
-		"📊 Ваши данные:\n"+
-		"• Присед: %.0f кг\n"+
-		"• Жим: %.0f кг\n"+
-		"• Тяга: %.0f кг\n"+
-		"• Сумма: %.0f кг\n"+
-		"• Уровень: %s\n\n"+
-		"📋 Выбранная программа: %s\n"+
-		"• Недель: %d\n"+
-		"• Тренировок: %d\n"+
-		"• Общий КПШ: %d\n"+
-		"• Тоннаж: %.1f т\n",
+	statsMsg := b.tf("pl_auto_stats", chatID,
 		squat, bench, deadlift, total, level,
 		program.Name,
 		len(program.Weeks),
@@ -691,7 +659,7 @@ func (b *Bot) handlePLListTemplates(message *tgbotapi.Message) {
 
 	gen := getPLGenerator()
 	if gen == nil {
-		msg := tgbotapi.NewMessage(chatID, "Ошибка генератора")
+		msg := tgbotapi.NewMessage(chatID, b.t("pl_generator_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -699,18 +667,13 @@ func (b *Bot) handlePLListTemplates(message *tgbotapi.Message) {
 	templates := gen.ListTemplates()
 
 	var sb strings.Builder
-	sb.WriteString("📋 Доступные шаблоны программ:\n\n")
+	sb.WriteString(b.t("pl_templates_title", chatID) + "\n\n")
 
 	for i, t := range templates {
 		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, t))
 	}
 
-	sb.WriteString("\n📖 Описание методик:\n\n")
-	sb.WriteString("**Шейко** — много подходов × мало повторов, 80-90% интенсивность\n")
-	sb.WriteString("**Головинский** — волнообразная периодизация, чёткие проценты\n")
-	sb.WriteString("**Верхошанский** — блочная специализация на одном движении\n")
-	sb.WriteString("**Муравьёв** — классическая 4-недельная периодизация\n")
-	sb.WriteString("**Русский цикл** — 6 недель агрессивной прогрессии\n")
+	sb.WriteString("\n" + b.t("pl_templates_methods", chatID))
 
 	msg := tgbotapi.NewMessage(chatID, sb.String())
 	msg.ParseMode = "Markdown"
@@ -721,18 +684,19 @@ func (b *Bot) handlePLListTemplates(message *tgbotapi.Message) {
 
 // Вспомогательные функции
 
-func getLiftTypeName(lt ai.LiftType) string {
+// liftTypeName возвращает название дисциплины на языке пользователя
+func (b *Bot) liftTypeName(lt ai.LiftType, chatID int64) string {
 	switch lt {
 	case ai.LiftTypeBench:
-		return "Жим лёжа"
+		return b.t("pl_lift_bench", chatID)
 	case ai.LiftTypeSquat:
-		return "Присед"
+		return b.t("pl_lift_squat", chatID)
 	case ai.LiftTypeDeadlift:
-		return "Становая тяга"
+		return b.t("pl_lift_deadlift", chatID)
 	case ai.LiftTypeHipThrust:
-		return "Ягодичный мост"
+		return b.t("pl_lift_hip_thrust", chatID)
 	default:
-		return "Троеборье"
+		return b.t("pl_lift_full", chatID)
 	}
 }
 
@@ -814,7 +778,7 @@ func (b *Bot) handlePLSendToClient(message *tgbotapi.Message) {
 	plStates.RUnlock()
 
 	if program == nil {
-		b.sendMessage(chatID, "Программа не найдена")
+		b.sendMessage(chatID, b.t("program_not_found", chatID))
 		b.handlePowerliftingMenu(message)
 		return
 	}
@@ -827,7 +791,7 @@ func (b *Bot) handlePLSendToClient(message *tgbotapi.Message) {
 		WHERE a.telegram_id IS NULL AND c.deleted_at IS NULL
 		ORDER BY c.name`)
 	if err != nil {
-		b.sendError(chatID, "Ошибка загрузки клиентов", err)
+		b.sendError(chatID, b.t("admin_clients_load_error", chatID), err)
 		return
 	}
 	defer rows.Close()
@@ -853,18 +817,17 @@ func (b *Bot) handlePLSendToClient(message *tgbotapi.Message) {
 	}
 
 	if len(buttons) == 0 {
-		b.sendMessage(chatID, "Нет клиентов для отправки")
+		b.sendMessage(chatID, b.t("pl_no_clients", chatID))
 		b.showPLProgramOptions(chatID)
 		return
 	}
 
 	buttons = append(buttons, tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton("Отмена"),
+		tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 	))
 
 	keyboard := tgbotapi.NewReplyKeyboard(buttons...)
-	b.sendMessageWithKeyboard(chatID, fmt.Sprintf(
-		"📤 Отправка программы: %s\n\nВыберите клиента:", program.Name), keyboard)
+	b.sendMessageWithKeyboard(chatID, b.tf("pl_send_select_client", chatID, program.Name), keyboard)
 
 	userStates.Lock()
 	userStates.states[chatID] = "pl_select_client"
@@ -876,14 +839,14 @@ func (b *Bot) handlePLClientSelection(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.showPLProgramOptions(chatID)
 		return
 	}
 
 	clientID := parseIDFromBrackets(text)
 	if clientID == 0 {
-		b.sendMessage(chatID, "Ошибка выбора клиента")
+		b.sendMessage(chatID, b.t("admin_client_select_error", chatID))
 		return
 	}
 
@@ -892,7 +855,7 @@ func (b *Bot) handlePLClientSelection(message *tgbotapi.Message) {
 	plStates.RUnlock()
 
 	if program == nil {
-		b.sendMessage(chatID, "Программа не найдена")
+		b.sendMessage(chatID, b.t("program_not_found", chatID))
 		b.handlePowerliftingMenu(message)
 		return
 	}
@@ -903,12 +866,12 @@ func (b *Bot) handlePLClientSelection(message *tgbotapi.Message) {
 	err := b.db.QueryRow("SELECT telegram_id, name, surname FROM public.clients WHERE id = $1", clientID).
 		Scan(&telegramID, &name, &surname)
 	if err != nil {
-		b.sendError(chatID, "Клиент не найден", err)
+		b.sendError(chatID, b.t("admin_client_not_found", chatID), err)
 		return
 	}
 
 	if telegramID == 0 {
-		b.sendMessage(chatID, fmt.Sprintf("❌ У клиента %s %s нет Telegram ID. Программа не может быть отправлена.", name, surname))
+		b.sendMessage(chatID, b.tf("pl_client_no_telegram", chatID, name, surname))
 		b.showPLProgramOptions(chatID)
 		return
 	}
@@ -917,12 +880,7 @@ func (b *Bot) handlePLClientSelection(message *tgbotapi.Message) {
 	formatted := ai.FormatPLProgram(program)
 
 	// Отправляем текст
-	introMsg := fmt.Sprintf("🏋️ Программа тренировок: %s\n\n"+
-		"📊 Параметры:\n"+
-		"• Недель: %d\n"+
-		"• КПШ: %d\n"+
-		"• Тоннаж: %.1f т\n\n"+
-		"Полная программа в прикреплённом файле.",
+	introMsg := b.tf("pl_client_intro", telegramID,
 		program.Name, len(program.Weeks), program.TotalKPS, program.TotalTonnage)
 
 	clientMsg := tgbotapi.NewMessage(telegramID, introMsg)
@@ -933,11 +891,11 @@ func (b *Bot) handlePLClientSelection(message *tgbotapi.Message) {
 		Name:  fmt.Sprintf("%s.txt", sanitizeFilename(program.Name)),
 		Bytes: []byte(formatted),
 	})
-	doc.Caption = "Программа тренировок"
+	doc.Caption = b.t("pl_client_doc_caption", telegramID)
 	b.api.Send(doc)
 
 	// Подтверждение тренеру
-	b.sendMessage(chatID, fmt.Sprintf("✅ Программа отправлена клиенту %s %s", name, surname))
+	b.sendMessage(chatID, b.tf("program_sent_to_client", chatID, name, surname))
 
 	userStates.Lock()
 	delete(userStates.states, chatID)
@@ -955,18 +913,18 @@ func (b *Bot) handlePLExportToGoogle(message *tgbotapi.Message) {
 	plStates.RUnlock()
 
 	if program == nil {
-		b.sendMessage(chatID, "Программа не найдена")
+		b.sendMessage(chatID, b.t("program_not_found", chatID))
 		b.handlePowerliftingMenu(message)
 		return
 	}
 
 	if b.sheetsClient == nil {
-		b.sendMessage(chatID, "❌ Google Sheets не настроен")
+		b.sendMessage(chatID, b.t("sheets_not_configured", chatID))
 		b.showPLProgramOptions(chatID)
 		return
 	}
 
-	waitMsg := tgbotapi.NewMessage(chatID, "⏳ Создаю таблицу в Google Sheets...")
+	waitMsg := tgbotapi.NewMessage(chatID, b.t("sheets_creating", chatID))
 	b.api.Send(waitMsg)
 
 	// Конвертируем в формат gsheets
@@ -975,14 +933,14 @@ func (b *Bot) handlePLExportToGoogle(message *tgbotapi.Message) {
 	// Создаём таблицу
 	spreadsheetID, err := b.sheetsClient.CreatePLProgramSpreadsheet(plData)
 	if err != nil {
-		b.sendError(chatID, "Ошибка создания таблицы", err)
+		b.sendError(chatID, b.t("sheets_create_error", chatID), err)
 		b.showPLProgramOptions(chatID)
 		return
 	}
 
 	url := fmt.Sprintf("https://docs.google.com/spreadsheets/d/%s", spreadsheetID)
 
-	b.sendMessage(chatID, fmt.Sprintf("✅ Таблица создана!\n\n📊 %s\n\n🔗 %s", program.Name, url))
+	b.sendMessage(chatID, b.tf("sheets_created", chatID, program.Name, url))
 	b.showPLProgramOptions(chatID)
 }
 
@@ -0,0 +1,123 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"workbot/internal/i18n"
+	"workbot/internal/interchange"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	// maxProgramFileSize максимальный размер импортируемого файла программы
+	maxProgramFileSize = 1 << 20
+	// maxImportErrorsShown сколько ошибок проверки показывать тренеру
+	maxImportErrorsShown = 15
+)
+
+var programFileClient = &http.Client{Timeout: 30 * time.Second}
+
+// isProgramFile проверяет, похож ли документ на файл программы (JSON/YAML)
+func isProgramFile(doc *tgbotapi.Document) bool {
+	if doc == nil {
+		return false
+	}
+	_, ok := interchange.FormatFromFilename(doc.FileName)
+	return ok
+}
+
+// handleProgramImport импортирует программу из присланного файла и предлагает сохранить её как шаблон
+func (b *Bot) handleProgramImport(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	doc := message.Document
+
+	format, _ := interchange.FormatFromFilename(doc.FileName)
+	if doc.FileSize > maxProgramFileSize {
+		b.sendMessage(chatID, b.t("import_file_too_large", chatID))
+		return
+	}
+
+	data, err := b.downloadFile(doc.FileID, maxProgramFileSize)
+	if err != nil {
+		b.sendError(chatID, b.t("import_download_error", chatID), err)
+		return
+	}
+
+	program, err := interchange.Decode(data, format)
+	if err != nil {
+		b.sendMessage(chatID, formatImportError(doc.FileName, err, b.getLanguage(chatID)))
+		return
+	}
+
+	draft := interchange.ToTemplate(program)
+	b.sendMessage(chatID, b.tf("import_file_read", chatID,
+		doc.FileName, program.Name, draft.TotalWeeks, draft.DaysPerWeek, len(draft.Exercises)))
+	b.startSaveTemplate(chatID, draft)
+}
+
+// formatImportError форматирует ошибку импорта с путями к неверным полям
+func formatImportError(fileName string, err error, lang i18n.Language) string {
+	var verrs interchange.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return i18n.Tf("import_read_error", lang, fileName, err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(i18n.Tf("import_errors_found", lang, fileName, len(verrs)) + "\n\n")
+	for i, e := range verrs {
+		if i == maxImportErrorsShown {
+			sb.WriteString(i18n.Tf("import_errors_more", lang, len(verrs)-maxImportErrorsShown) + "\n")
+			break
+		}
+		sb.WriteString("• " + e.Error() + "\n")
+	}
+	sb.WriteString("\n" + i18n.T("import_format_doc", lang))
+	return sb.String()
+}
+
+// downloadFile скачивает файл Telegram по его ID, читая не больше maxSize байт
+func (b *Bot) downloadFile(fileID string, maxSize int64) ([]byte, error) {
+	url, err := b.api.GetFileDirectURL(fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := programFileClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("статус %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxSize))
+}
+
+// exportTemplate отправляет шаблон файлом в формате обмена
+func (b *Bot) exportTemplate(chatID int64, templateID int, format interchange.Format) {
+	t, err := b.repo.Template.GetByID(templateID)
+	if err != nil || t == nil || !t.CanView(chatID) {
+		b.sendError(chatID, b.t("tpl_not_found", chatID), err)
+		return
+	}
+
+	data, err := interchange.Encode(interchange.FromTemplate(t), format)
+	if err != nil {
+		b.sendError(chatID, b.t("tpl_export_error", chatID), err)
+		return
+	}
+
+	doc := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{
+		Name:  fmt.Sprintf("%s.%s", sanitizeFilename(t.Name), format),
+		Bytes: data,
+	})
+	doc.Caption = b.tf("tpl_export_caption", chatID, t.Name)
+	b.api.Send(doc)
+}
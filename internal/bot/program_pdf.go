@@ -0,0 +1,88 @@
+package bot
+
+import (
+	"fmt"
+
+	"workbot/clients/ai"
+	"workbot/internal/i18n"
+	"workbot/internal/interchange"
+	"workbot/internal/models"
+	"workbot/internal/programpdf"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// pdfGoals — цели и фокусы фаз, для которых есть подписи pdf_program_goal_<код>
+var pdfGoals = []string{"strength", "hypertrophy", "fat_loss", "weight_loss", "hyrox", "endurance", "general", "competition"}
+
+// programPDFLabels возвращает подписи печатной программы на языке пользователя
+func programPDFLabels(lang i18n.Language) programpdf.Labels {
+	l := programpdf.Labels{
+		Client:     i18n.T("pdf_program_client", lang),
+		Goal:       i18n.T("pdf_program_goal", lang),
+		Start:      i18n.T("pdf_program_start", lang),
+		Duration:   i18n.T("pdf_program_duration", lang),
+		Phases:     i18n.T("pdf_program_phases", lang),
+		PhaseName:  i18n.T("pdf_program_col_phase", lang),
+		PhaseWeeks: i18n.T("pdf_program_col_weeks", lang),
+		PhaseFocus: i18n.T("pdf_program_col_focus", lang),
+		OneRM:      i18n.T("pdf_program_one_rm", lang),
+		OneRMCol:   i18n.T("pdf_program_col_one_rm", lang),
+		Workout:    i18n.T("pdf_program_workout", lang),
+		Deload:     i18n.T("pdf_program_deload", lang),
+		Exercise:   i18n.T("pdf_program_col_exercise", lang),
+		Set:        i18n.T("pdf_program_col_set", lang),
+		Plan:       i18n.T("pdf_program_col_plan", lang),
+		Weight:     i18n.T("pdf_program_col_weight", lang),
+		Reps:       i18n.T("pdf_program_col_reps", lang),
+		Kg:         i18n.T("pdf_program_kg", lang),
+		Rest:       i18n.T("pdf_program_rest", lang),
+		Tempo:      i18n.T("pdf_program_tempo", lang),
+		Notes:      i18n.T("pdf_program_notes", lang),
+		Goals:      make(map[string]string, len(pdfGoals)),
+	}
+	for _, goal := range pdfGoals {
+		l.Goals[goal] = i18n.T("pdf_program_goal_"+goal, lang)
+	}
+	return l
+}
+
+// sendProgramPDF отправляет программу документом PDF для печати
+func (b *Bot) sendProgramPDF(chatID int64, program *interchange.Program, opts programpdf.Options) {
+	opts.Labels = programPDFLabels(b.getLanguage(chatID))
+	data, err := programpdf.Bytes(program, opts)
+	if err != nil {
+		b.sendError(chatID, b.t("program_pdf_error", chatID), err)
+		return
+	}
+
+	doc := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{
+		Name:  fmt.Sprintf("%s.pdf", sanitizeFilename(program.Name)),
+		Bytes: data,
+	})
+	doc.Caption = b.tf("program_pdf_caption", chatID, program.Name)
+	b.api.Send(doc)
+}
+
+// sendPLProgramPDF печатает PL программу: проценты пересчитываются от 1ПМ
+// упражнения так же, как при генерации, включая вариации и подсобку
+func (b *Bot) sendPLProgramPDF(chatID int64, program *ai.PLGeneratedProgram) {
+	b.sendProgramPDF(chatID, interchange.FromPL(program), programpdf.Options{OneRM: program.AthleteMaxes.ForExercise})
+}
+
+// sendFitnessProgramPDF печатает FIT программу. В формате обмена процент
+// вытесняет рассчитанный вес, а на печати нужны оба — вес возвращается
+// из сгенерированной программы
+func (b *Bot) sendFitnessProgramPDF(chatID int64, program *models.GeneratedProgram) {
+	p := interchange.FromGenerated(program)
+	for wi, gw := range program.Weeks {
+		for di, gd := range gw.Days {
+			for ei, ex := range gd.Exercises {
+				if ex.Weight > 0 {
+					p.Weeks[wi].Days[di].Exercises[ei].Sets[0].WeightKg = ex.Weight
+				}
+			}
+		}
+	}
+	b.sendProgramPDF(chatID, p, programpdf.Options{})
+}
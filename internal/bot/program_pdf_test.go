@@ -0,0 +1,19 @@
+package bot
+
+import (
+	"reflect"
+	"testing"
+
+	"workbot/internal/i18n"
+	"workbot/internal/programpdf"
+)
+
+func TestProgramPDFLabels(t *testing.T) {
+	// Русские подписи бота совпадают с подписями plgen и plancli
+	if got, want := programPDFLabels(i18n.LangRussian), programpdf.DefaultLabels(); !reflect.DeepEqual(got, want) {
+		t.Errorf("programPDFLabels(ru) = %+v\nwant %+v", got, want)
+	}
+	if l := programPDFLabels(i18n.LangEnglish); l.Goals["fat_loss"] != "fat loss" || l.Workout != "Week %d · day %d" {
+		t.Errorf("programPDFLabels(en) = %+v", l)
+	}
+}
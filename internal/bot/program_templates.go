@@ -0,0 +1,710 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"workbot/clients/ai"
+	"workbot/internal/generator"
+	"workbot/internal/i18n"
+	"workbot/internal/interchange"
+	"workbot/internal/models"
+	"workbot/internal/training"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	stateTemplateName = "template_name"
+	// templateWeightIncrement шаг округления весов при назначении шаблона
+	templateWeightIncrement = 2.5
+	// templateClientsPageSize клиентов на странице выбора при назначении
+	templateClientsPageSize = 15
+)
+
+// templateDrafts хранит шаблон, ожидающий ввода названия
+var templateDrafts = struct {
+	sync.RWMutex
+	data map[int64]*models.ProgramTemplate
+}{data: make(map[int64]*models.ProgramTemplate)}
+
+// handleTemplatesMenu показывает библиотеку шаблонов
+func (b *Bot) handleTemplatesMenu(chatID int64, messageID int) {
+	templates, err := b.repo.Template.ListAvailable(chatID)
+	if err != nil {
+		b.sendError(chatID, b.t("tpl_load_error", chatID), err)
+		return
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, t := range templates {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				b.tf("tpl_list_item", chatID, templateIcon(&t, chatID), t.Name, t.TotalWeeks, t.DaysPerWeek),
+				fmt.Sprintf("tpl_view_%d", t.ID),
+			),
+		))
+	}
+
+	text := b.t("tpl_menu_text", chatID)
+	if len(rows) == 0 {
+		text = b.t("tpl_menu_empty", chatID)
+	}
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	if messageID > 0 {
+		b.editMessage(chatID, messageID, text, &keyboard)
+		return
+	}
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = "Markdown"
+	if len(rows) > 0 {
+		msg.ReplyMarkup = keyboard
+	}
+	b.api.Send(msg)
+}
+
+// templateIcon возвращает значок происхождения шаблона
+func templateIcon(t *models.ProgramTemplate, trainerID int64) string {
+	switch {
+	case t.IsBuiltIn():
+		return "⭐"
+	case t.TrainerID == trainerID:
+		return "📋"
+	default:
+		return "👥"
+	}
+}
+
+// showTemplate показывает карточку шаблона с первой неделей
+func (b *Bot) showTemplate(chatID int64, templateID int, week int, messageID int) {
+	t, err := b.repo.Template.GetByID(templateID)
+	if err != nil || t == nil || !t.CanView(chatID) {
+		b.sendError(chatID, b.t("tpl_not_found", chatID), err)
+		return
+	}
+	if week < 1 || week > t.TotalWeeks {
+		week = 1
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("%s *%s*\n", templateIcon(t, chatID), t.Name))
+	if t.Description != "" {
+		text.WriteString(t.Description + "\n")
+	}
+	text.WriteString("\n" + b.tf("tpl_summary", chatID, t.TotalWeeks, t.DaysPerWeek, t.ExerciseCount) + "\n")
+	switch {
+	case t.IsBuiltIn():
+		text.WriteString(b.t("tpl_builtin", chatID) + "\n")
+	case t.TrainerID == chatID:
+		if t.IsShared {
+			text.WriteString(b.t("tpl_shared", chatID) + "\n")
+		}
+	default:
+		text.WriteString(b.tf("tpl_author", chatID, t.OwnerName) + "\n")
+	}
+
+	text.WriteString("\n" + b.tf("tpl_week_title", chatID, week) + "\n")
+	text.WriteString(formatTemplateWeek(t, week, b.getLanguage(chatID)))
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if t.TotalWeeks > 1 {
+		var weekRow []tgbotapi.InlineKeyboardButton
+		if week > 1 {
+			weekRow = append(weekRow, tgbotapi.NewInlineKeyboardButtonData(
+				b.tf("tpl_btn_prev_week", chatID, week-1), fmt.Sprintf("tpl_week_%d_%d", t.ID, week-1)))
+		}
+		if week < t.TotalWeeks {
+			weekRow = append(weekRow, tgbotapi.NewInlineKeyboardButtonData(
+				b.tf("tpl_btn_next_week", chatID, week+1), fmt.Sprintf("tpl_week_%d_%d", t.ID, week+1)))
+		}
+		rows = append(rows, weekRow)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("tpl_btn_assign", chatID), fmt.Sprintf("tpl_assign_%d_0", t.ID)),
+		tgbotapi.NewInlineKeyboardButtonData(b.t("tpl_btn_clone", chatID), fmt.Sprintf("tpl_clone_%d", t.ID)),
+	))
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("📤 JSON", fmt.Sprintf("tpl_exp_%d_json", t.ID)),
+		tgbotapi.NewInlineKeyboardButtonData("📤 YAML", fmt.Sprintf("tpl_exp_%d_yaml", t.ID)),
+	))
+	if t.CanEdit(chatID) {
+		shareLabel := b.t("tpl_btn_share", chatID)
+		if t.IsShared {
+			shareLabel = b.t("tpl_btn_unshare", chatID)
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(shareLabel, fmt.Sprintf("tpl_share_%d", t.ID)),
+			tgbotapi.NewInlineKeyboardButtonData(b.t("groups_btn_delete", chatID), fmt.Sprintf("tpl_del_%d", t.ID)),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("tpl_btn_back", chatID), "tpl_list"),
+	))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	if messageID > 0 {
+		b.editMessage(chatID, messageID, text.String(), &keyboard)
+		return
+	}
+	msg := tgbotapi.NewMessage(chatID, text.String())
+	msg.ParseMode = "Markdown"
+	msg.ReplyMarkup = keyboard
+	b.api.Send(msg)
+}
+
+// formatTemplateWeek форматирует неделю шаблона
+func formatTemplateWeek(t *models.ProgramTemplate, week int, lang i18n.Language) string {
+	var sb strings.Builder
+	lastDay := 0
+	for _, ex := range t.Exercises {
+		if ex.WeekNum != week {
+			continue
+		}
+		if ex.DayNum != lastDay {
+			lastDay = ex.DayNum
+			dayName := ex.DayName
+			if dayName == "" {
+				dayName = i18n.Tf("tpl_day", lang, ex.DayNum)
+			}
+			sb.WriteString(fmt.Sprintf("\n_%s_\n", dayName))
+		}
+		line := fmt.Sprintf("• %s %d×%s", ex.ExerciseName, ex.Sets, ex.Reps)
+		if ex.Load != "" {
+			line += " · " + ex.Load
+		}
+		sb.WriteString(line + "\n")
+	}
+	if sb.Len() == 0 {
+		return i18n.T("tpl_no_exercises", lang) + "\n"
+	}
+	return sb.String()
+}
+
+// handleTemplateCallback обрабатывает inline-кнопки библиотеки шаблонов
+func (b *Bot) handleTemplateCallback(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	data := callback.Data
+
+	if !b.isAdmin(chatID) {
+		return
+	}
+
+	switch {
+	case data == "tpl_list":
+		b.handleTemplatesMenu(chatID, messageID)
+
+	case strings.HasPrefix(data, "tpl_view_"):
+		templateID, _ := strconv.Atoi(strings.TrimPrefix(data, "tpl_view_"))
+		b.showTemplate(chatID, templateID, 1, messageID)
+
+	case strings.HasPrefix(data, "tpl_week_"):
+		parts := strings.Split(strings.TrimPrefix(data, "tpl_week_"), "_")
+		if len(parts) != 2 {
+			return
+		}
+		templateID, _ := strconv.Atoi(parts[0])
+		week, _ := strconv.Atoi(parts[1])
+		b.showTemplate(chatID, templateID, week, messageID)
+
+	case strings.HasPrefix(data, "tpl_assign_"):
+		parts := strings.Split(strings.TrimPrefix(data, "tpl_assign_"), "_")
+		if len(parts) != 2 {
+			return
+		}
+		templateID, _ := strconv.Atoi(parts[0])
+		page, _ := strconv.Atoi(parts[1])
+		b.showTemplateClients(chatID, templateID, page, messageID)
+
+	case strings.HasPrefix(data, "tpl_client_"):
+		parts := strings.Split(strings.TrimPrefix(data, "tpl_client_"), "_")
+		if len(parts) != 2 {
+			return
+		}
+		templateID, _ := strconv.Atoi(parts[0])
+		clientID, _ := strconv.Atoi(parts[1])
+		b.showTemplateStartDates(chatID, templateID, clientID, messageID)
+
+	case strings.HasPrefix(data, "tpl_start_"):
+		parts := strings.Split(strings.TrimPrefix(data, "tpl_start_"), "_")
+		if len(parts) != 3 {
+			return
+		}
+		templateID, _ := strconv.Atoi(parts[0])
+		clientID, _ := strconv.Atoi(parts[1])
+		startDate, err := time.ParseInLocation("20060102", parts[2], b.userLocation(chatID))
+		if err != nil {
+			return
+		}
+		b.assignTemplate(chatID, templateID, clientID, startDate, messageID)
+
+	case strings.HasPrefix(data, "tpl_exp_"):
+		parts := strings.Split(strings.TrimPrefix(data, "tpl_exp_"), "_")
+		if len(parts) != 2 {
+			return
+		}
+		templateID, _ := strconv.Atoi(parts[0])
+		b.exportTemplate(chatID, templateID, interchange.Format(parts[1]))
+
+	case strings.HasPrefix(data, "tpl_clone_"):
+		templateID, _ := strconv.Atoi(strings.TrimPrefix(data, "tpl_clone_"))
+		t, err := b.repo.Template.GetByID(templateID)
+		if err != nil || t == nil || !t.CanView(chatID) {
+			b.sendError(chatID, b.t("tpl_not_found", chatID), err)
+			return
+		}
+		newID, err := b.repo.Template.Clone(templateID, chatID, b.tf("tpl_copy_name", chatID, t.Name))
+		if err != nil {
+			b.sendError(chatID, b.t("tpl_clone_error", chatID), err)
+			return
+		}
+		b.showTemplate(chatID, newID, 1, messageID)
+
+	case strings.HasPrefix(data, "tpl_share_"):
+		templateID, _ := strconv.Atoi(strings.TrimPrefix(data, "tpl_share_"))
+		t, err := b.repo.Template.GetByID(templateID)
+		if err != nil || t == nil || !t.CanEdit(chatID) {
+			return
+		}
+		if err := b.repo.Template.SetShared(templateID, chatID, !t.IsShared); err != nil {
+			b.sendError(chatID, b.t("tpl_share_error", chatID), err)
+			return
+		}
+		b.showTemplate(chatID, templateID, 1, messageID)
+
+	case strings.HasPrefix(data, "tpl_del_"):
+		templateID, _ := strconv.Atoi(strings.TrimPrefix(data, "tpl_del_"))
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(b.t("groups_btn_delete_confirm", chatID), fmt.Sprintf("tpl_delok_%d", templateID)),
+				tgbotapi.NewInlineKeyboardButtonData(b.t("btn_cancel_inline", chatID), fmt.Sprintf("tpl_view_%d", templateID)),
+			),
+		)
+		b.editMessage(chatID, messageID, b.t("tpl_delete_confirm", chatID), &keyboard)
+
+	case strings.HasPrefix(data, "tpl_delok_"):
+		templateID, _ := strconv.Atoi(strings.TrimPrefix(data, "tpl_delok_"))
+		if err := b.repo.Template.Delete(templateID, chatID); err != nil {
+			b.sendError(chatID, b.t("tpl_delete_error", chatID), err)
+			return
+		}
+		b.handleTemplatesMenu(chatID, messageID)
+	}
+}
+
+// showTemplateClients показывает клиентов для назначения шаблона
+func (b *Bot) showTemplateClients(chatID int64, templateID, page, messageID int) {
+	clients, err := b.repo.Client.GetAllActive()
+	if err != nil {
+		b.sendError(chatID, b.t("admin_clients_load_error", chatID), err)
+		return
+	}
+	if len(clients) == 0 {
+		b.sendMessage(chatID, b.t("info_clients_empty", chatID))
+		return
+	}
+
+	pages := (len(clients) + templateClientsPageSize - 1) / templateClientsPageSize
+	if page >= pages {
+		page = pages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	from := page * templateClientsPageSize
+	to := from + templateClientsPageSize
+	if to > len(clients) {
+		to = len(clients)
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, c := range clients[from:to] {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("%s %s", c.Name, c.Surname),
+				fmt.Sprintf("tpl_client_%d_%d", templateID, c.ID),
+			),
+		))
+	}
+	var navRow []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		navRow = append(navRow, tgbotapi.NewInlineKeyboardButtonData("⬅️", fmt.Sprintf("tpl_assign_%d_%d", templateID, page-1)))
+	}
+	if page < pages-1 {
+		navRow = append(navRow, tgbotapi.NewInlineKeyboardButtonData("➡️", fmt.Sprintf("tpl_assign_%d_%d", templateID, page+1)))
+	}
+	if len(navRow) > 0 {
+		rows = append(rows, navRow)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("btn_cancel_inline", chatID), fmt.Sprintf("tpl_view_%d", templateID)),
+	))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	b.editMessage(chatID, messageID, b.t("tpl_select_client", chatID), &keyboard)
+}
+
+// showTemplateStartDates предлагает дату начала программы
+func (b *Bot) showTemplateStartDates(chatID int64, templateID, clientID, messageID int) {
+	today := time.Now().In(b.userLocation(chatID))
+	monday := nextMonday(today)
+
+	rows := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				b.tf("tpl_start_today", chatID, today.Format("02.01")),
+				fmt.Sprintf("tpl_start_%d_%d_%s", templateID, clientID, today.Format("20060102")),
+			),
+		),
+	}
+	if monday.Format("20060102") != today.Format("20060102") {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				b.tf("tpl_start_monday", chatID, monday.Format("02.01")),
+				fmt.Sprintf("tpl_start_%d_%d_%s", templateID, clientID, monday.Format("20060102")),
+			),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("btn_cancel_inline", chatID), fmt.Sprintf("tpl_view_%d", templateID)),
+	))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	b.editMessage(chatID, messageID, b.t("tpl_select_start", chatID), &keyboard)
+}
+
+// nextMonday возвращает ближайший понедельник (сегодня, если сегодня понедельник)
+func nextMonday(t time.Time) time.Time {
+	days := (int(time.Monday) - int(t.Weekday()) + 7) % 7
+	return t.AddDate(0, 0, days)
+}
+
+// assignTemplate создаёт клиенту программу из шаблона с подстановкой 1ПМ и дат
+func (b *Bot) assignTemplate(chatID int64, templateID, clientID int, startDate time.Time, messageID int) {
+	t, err := b.repo.Template.GetByID(templateID)
+	if err != nil || t == nil || !t.CanView(chatID) {
+		b.sendError(chatID, b.t("tpl_not_found", chatID), err)
+		return
+	}
+	client, err := b.repo.Client.GetByID(clientID)
+	if err != nil || client == nil {
+		b.sendError(chatID, b.t("admin_client_not_found", chatID), err)
+		return
+	}
+
+	pmByName, err := b.repo.Exercise.GetClient1PMByName(clientID)
+	if err != nil {
+		log.Printf("Ошибка получения 1ПМ клиента: %v", err)
+	}
+
+	program, missing := buildProgramFromTemplate(t, clientID, startDate, pmByName, b.getLanguage(chatID))
+
+	paused, err := b.repo.Program.PauseActivePrograms(clientID)
+	if err != nil {
+		b.sendError(chatID, b.t("tpl_pause_error", chatID), err)
+		return
+	}
+	programID, err := b.repo.Program.CreateProgram(program, t.ID)
+	if err != nil {
+		b.sendError(chatID, b.t("tpl_assign_error", chatID), err)
+		return
+	}
+	log.Printf("Шаблон %d назначен клиенту %d (программа %d)", t.ID, clientID, programID)
+
+	var text strings.Builder
+	text.WriteString(b.tf("tpl_assigned", chatID, t.Name, client.Name, client.Surname) + "\n\n")
+	text.WriteString(fmt.Sprintf("📅 %s — %s\n", program.StartDate.Format("02.01.2006"), program.EndDate.Format("02.01.2006")))
+	text.WriteString(b.tf("tpl_assigned_workouts", chatID, len(program.Workouts)) + "\n")
+	if paused > 0 {
+		text.WriteString(b.t("tpl_previous_paused", chatID) + "\n")
+	}
+	if len(missing) > 0 {
+		text.WriteString("\n" + b.t("tpl_missing_1pm", chatID) + "\n")
+		for _, name := range missing {
+			text.WriteString("• " + name + "\n")
+		}
+	}
+	text.WriteString("\n" + b.t("tpl_assigned_hint", chatID))
+
+	b.editMessage(chatID, messageID, text.String(), nil)
+}
+
+// buildProgramFromTemplate строит программу клиента из шаблона.
+// Плейсхолдеры % от 1ПМ пересчитываются в вес по pmByName, тренировки раскладываются по датам
+// начиная со startDate; дни без названия получают «Неделя N, День M» на языке lang.
+// Возвращает также упражнения, для которых не нашлось 1ПМ
+func buildProgramFromTemplate(t *models.ProgramTemplate, clientID int, startDate time.Time, pmByName map[string]float64, lang i18n.Language) (*models.Program, []string) {
+	start := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, startDate.Location())
+	end := start.AddDate(0, 0, t.TotalWeeks*7-1)
+
+	pmByKey := make(map[string]float64, len(pmByName))
+	for name, pm := range pmByName {
+		pmByKey[generator.NormalizeExerciseName(name)] = pm
+	}
+
+	program := &models.Program{
+		ClientID:    clientID,
+		Name:        t.Name,
+		Goal:        t.Goal,
+		Description: t.Description,
+		TotalWeeks:  t.TotalWeeks,
+		DaysPerWeek: t.DaysPerWeek,
+		StartDate:   start,
+		EndDate:     &end,
+		Status:      models.ProgramStatusActive,
+	}
+
+	missingSet := make(map[string]bool)
+	var missing []string
+	workoutIdx := make(map[[2]int]int)
+
+	for _, ex := range t.Exercises {
+		key := [2]int{ex.WeekNum, ex.DayNum}
+		idx, ok := workoutIdx[key]
+		if !ok {
+			name := ex.DayName
+			if name == "" {
+				name = i18n.Tf("program_workout_default_name", lang, ex.WeekNum, ex.DayNum)
+			}
+			date := start.AddDate(0, 0, (ex.WeekNum-1)*7+training.TemplateDayOffset(ex.DayNum, t.DaysPerWeek))
+			program.Workouts = append(program.Workouts, models.Workout{
+				WeekNum:     ex.WeekNum,
+				DayNum:      ex.DayNum,
+				OrderInWeek: ex.DayNum,
+				Name:        name,
+				Date:        &date,
+			})
+			idx = len(program.Workouts) - 1
+			workoutIdx[key] = idx
+		}
+
+		we := models.WorkoutExercise{
+			OrderNum:     ex.OrderNum,
+			ExerciseName: ex.ExerciseName,
+			Sets:         ex.Sets,
+			Reps:         ex.Reps,
+			RestSeconds:  ex.RestSeconds,
+			Tempo:        ex.Tempo,
+			Notes:        ex.Notes,
+		}
+
+		spec, err := training.ParseLoadSpec(ex.Load)
+		if err != nil {
+			// Нераспознанная нагрузка сохраняется как подсказка клиенту
+			we.Notes = strings.TrimSpace(ex.Load + " " + we.Notes)
+		} else {
+			pm := pmByKey[generator.NormalizeExerciseName(ex.ExerciseName)]
+			we.Weight = spec.ResolveWeight(pm, templateWeightIncrement)
+			we.WeightPercent = spec.Percent
+			we.RPE = spec.RPE
+			if spec.Percent > 0 && pm == 0 && !missingSet[ex.ExerciseName] {
+				missingSet[ex.ExerciseName] = true
+				missing = append(missing, ex.ExerciseName)
+			}
+		}
+
+		program.Workouts[idx].Exercises = append(program.Workouts[idx].Exercises, we)
+	}
+
+	sort.SliceStable(program.Workouts, func(i, j int) bool {
+		if program.Workouts[i].WeekNum != program.Workouts[j].WeekNum {
+			return program.Workouts[i].WeekNum < program.Workouts[j].WeekNum
+		}
+		return program.Workouts[i].DayNum < program.Workouts[j].DayNum
+	})
+
+	return program, missing
+}
+
+// templateFromProgram создаёт шаблон из программы клиента (в том числе отредактированной вручную)
+func templateFromProgram(p *models.Program) *models.ProgramTemplate {
+	t := &models.ProgramTemplate{
+		Name:        p.Name,
+		Description: p.Description,
+		Goal:        p.Goal,
+		TotalWeeks:  p.TotalWeeks,
+		DaysPerWeek: p.DaysPerWeek,
+	}
+
+	for _, w := range p.Workouts {
+		for _, ex := range w.Exercises {
+			t.Exercises = append(t.Exercises, models.ProgramTemplateExercise{
+				WeekNum:      w.WeekNum,
+				DayNum:       w.DayNum,
+				DayName:      w.Name,
+				OrderNum:     ex.OrderNum,
+				ExerciseName: ex.ExerciseName,
+				Sets:         ex.Sets,
+				Reps:         ex.Reps,
+				Load:         training.LoadSpecFromPrescription(ex.Weight, ex.WeightPercent, ex.RPE).String(),
+				RestSeconds:  ex.RestSeconds,
+				Tempo:        ex.Tempo,
+				Notes:        ex.Notes,
+			})
+		}
+	}
+
+	return t
+}
+
+// templateFromGenerated создаёт шаблон из сгенерированной FIT программы
+func templateFromGenerated(p *models.GeneratedProgram) *models.ProgramTemplate {
+	t := &models.ProgramTemplate{
+		Name:        i18n.Tf("fit_template_name", i18n.DefaultLang, fitnessProgramTypeName(string(p.Goal), i18n.DefaultLang), p.TotalWeeks),
+		Goal:        string(p.Goal),
+		TotalWeeks:  p.TotalWeeks,
+		DaysPerWeek: p.DaysPerWeek,
+	}
+
+	for _, week := range p.Weeks {
+		for _, day := range week.Days {
+			for _, ex := range day.Exercises {
+				t.Exercises = append(t.Exercises, models.ProgramTemplateExercise{
+					WeekNum:      week.WeekNum,
+					DayNum:       day.DayNum,
+					DayName:      day.Name,
+					OrderNum:     ex.OrderNum,
+					ExerciseName: ex.ExerciseName,
+					Sets:         ex.Sets,
+					Reps:         ex.Reps,
+					Load:         training.LoadSpecFromPrescription(ex.Weight, ex.WeightPercent, ex.RPE).String(),
+					RestSeconds:  ex.RestSeconds,
+					Tempo:        ex.Tempo,
+					Notes:        ex.Notes,
+				})
+			}
+		}
+	}
+
+	return t
+}
+
+// templateFromPL создаёт шаблон из сгенерированной PL программы.
+// Каждая группа подходов (% × повторы × подходы) становится отдельной строкой шаблона
+func templateFromPL(p *ai.PLGeneratedProgram) *models.ProgramTemplate {
+	t := &models.ProgramTemplate{
+		Name:       p.Name,
+		Goal:       "strength",
+		TotalWeeks: len(p.Weeks),
+	}
+
+	for _, week := range p.Weeks {
+		if len(week.Workouts) > t.DaysPerWeek {
+			t.DaysPerWeek = len(week.Workouts)
+		}
+		for dayIdx, workout := range week.Workouts {
+			dayNum := dayIdx + 1
+			order := 0
+			for _, ex := range workout.Exercises {
+				for _, set := range ex.Sets {
+					order++
+					spec := training.LoadSpec{Percent: set.Percent}
+					if set.Percent <= 0 {
+						spec.Weight = set.WeightKg
+					}
+					sets := set.Sets
+					if sets <= 0 {
+						sets = 1
+					}
+					t.Exercises = append(t.Exercises, models.ProgramTemplateExercise{
+						WeekNum:      week.WeekNum,
+						DayNum:       dayNum,
+						DayName:      workout.Name,
+						OrderNum:     order,
+						ExerciseName: ex.Name,
+						Sets:         sets,
+						Reps:         strconv.Itoa(set.Reps),
+						Load:         spec.String(),
+						RestSeconds:  180,
+					})
+				}
+			}
+		}
+	}
+
+	return t
+}
+
+// startSaveTemplate запоминает черновик шаблона и запрашивает название
+func (b *Bot) startSaveTemplate(chatID int64, draft *models.ProgramTemplate) {
+	if draft == nil || len(draft.Exercises) == 0 {
+		b.sendMessage(chatID, b.t("tpl_no_exercises_to_save", chatID))
+		return
+	}
+
+	templateDrafts.Lock()
+	templateDrafts.data[chatID] = draft
+	templateDrafts.Unlock()
+
+	setState(chatID, stateTemplateName)
+	b.sendMessageWithKeyboard(chatID,
+		b.tf("tpl_enter_name", chatID, draft.Name),
+		b.createCancelKeyboard(chatID))
+}
+
+// saveClientProgramAsTemplate сохраняет активную программу клиента как шаблон
+func (b *Bot) saveClientProgramAsTemplate(chatID int64, clientID int) {
+	program, err := b.repo.Program.GetActiveProgram(clientID)
+	if err != nil {
+		b.sendError(chatID, b.t("tpl_program_load_error", chatID), err)
+		return
+	}
+	if program == nil {
+		b.sendMessage(chatID, b.t("tpl_no_active_program", chatID))
+		return
+	}
+	b.startSaveTemplate(chatID, templateFromProgram(program))
+}
+
+// handleTemplateState обрабатывает ввод названия шаблона
+func (b *Bot) handleTemplateState(message *tgbotapi.Message, state string) {
+	chatID := message.Chat.ID
+	name := strings.TrimSpace(message.Text)
+
+	if i18n.Is(name, "cancel") {
+		templateDrafts.Lock()
+		delete(templateDrafts.data, chatID)
+		templateDrafts.Unlock()
+		b.handleAdminCancel(message)
+		return
+	}
+	if state != stateTemplateName {
+		return
+	}
+
+	templateDrafts.RLock()
+	draft := templateDrafts.data[chatID]
+	templateDrafts.RUnlock()
+	if draft == nil {
+		clearState(chatID)
+		b.handleAdminStart(message)
+		return
+	}
+
+	if name == "" || len([]rune(name)) > 200 {
+		b.sendMessage(chatID, b.t("tpl_name_length", chatID))
+		return
+	}
+
+	draft.Name = name
+	draft.TrainerID = chatID
+	templateID, err := b.repo.Template.Create(draft)
+	if err != nil {
+		b.sendError(chatID, b.t("tpl_save_error", chatID), err)
+		return
+	}
+
+	templateDrafts.Lock()
+	delete(templateDrafts.data, chatID)
+	templateDrafts.Unlock()
+	clearState(chatID)
+
+	b.handleAdminStart(message)
+	b.showTemplate(chatID, templateID, 1, 0)
+}
@@ -0,0 +1,103 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"workbot/internal/i18n"
+	"workbot/internal/models"
+)
+
+func TestBuildProgramFromTemplate(t *testing.T) {
+	tpl := &models.ProgramTemplate{
+		Name:        "PL 3x",
+		TotalWeeks:  2,
+		DaysPerWeek: 3,
+		Exercises: []models.ProgramTemplateExercise{
+			{WeekNum: 1, DayNum: 1, OrderNum: 1, ExerciseName: "Приседания со штангой", Sets: 5, Reps: "5", Load: "75%1PM"},
+			{WeekNum: 1, DayNum: 2, OrderNum: 1, ExerciseName: "Жим лёжа", Sets: 4, Reps: "6", Load: "80%1PM @RPE8"},
+			{WeekNum: 1, DayNum: 3, OrderNum: 1, ExerciseName: "Становая тяга", Sets: 3, Reps: "3", Load: "85%"},
+			{WeekNum: 2, DayNum: 1, OrderNum: 1, ExerciseName: "Планка", Sets: 3, Reps: "60с", Load: "на время"},
+			{WeekNum: 2, DayNum: 1, OrderNum: 2, ExerciseName: "Выпады", Sets: 3, Reps: "10", Load: "20кг"},
+		},
+	}
+	pm := map[string]float64{
+		"Приседания со штангой": 140,
+		"Жим лежа":              100,
+	}
+	start := time.Date(2025, 3, 3, 15, 30, 0, 0, time.UTC)
+
+	program, missing := buildProgramFromTemplate(tpl, 7, start, pm, i18n.LangRussian)
+
+	if program.ClientID != 7 || len(program.Workouts) != 4 {
+		t.Fatalf("got client %d, %d workouts", program.ClientID, len(program.Workouts))
+	}
+	if want := time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC); !program.EndDate.Equal(want) {
+		t.Errorf("EndDate = %v, want %v", program.EndDate, want)
+	}
+
+	wantDates := []string{"2025-03-03", "2025-03-05", "2025-03-07", "2025-03-10"}
+	for i, w := range program.Workouts {
+		if got := w.Date.Format("2006-01-02"); got != wantDates[i] {
+			t.Errorf("workout %d date = %s, want %s", i, got, wantDates[i])
+		}
+	}
+	if got := program.Workouts[3].Name; got != "Неделя 2, День 1" {
+		t.Errorf("default workout name = %q", got)
+	}
+
+	squat := program.Workouts[0].Exercises[0]
+	if squat.Weight != 105 || squat.WeightPercent != 75 {
+		t.Errorf("squat = %v kg @ %v%%, want 105 @ 75", squat.Weight, squat.WeightPercent)
+	}
+	bench := program.Workouts[1].Exercises[0]
+	if bench.Weight != 80 || bench.RPE != 8 {
+		t.Errorf("bench = %v kg @RPE %v, want 80 @RPE 8", bench.Weight, bench.RPE)
+	}
+	deadlift := program.Workouts[2].Exercises[0]
+	if deadlift.Weight != 0 || deadlift.WeightPercent != 85 {
+		t.Errorf("deadlift = %v kg @ %v%%, want 0 @ 85", deadlift.Weight, deadlift.WeightPercent)
+	}
+	if len(missing) != 1 || missing[0] != "Становая тяга" {
+		t.Errorf("missing = %v, want [Становая тяга]", missing)
+	}
+
+	week2 := program.Workouts[3].Exercises
+	if week2[0].Notes != "на время" {
+		t.Errorf("unparsed load notes = %q", week2[0].Notes)
+	}
+	if week2[1].Weight != 20 {
+		t.Errorf("fixed weight = %v, want 20", week2[1].Weight)
+	}
+}
+
+func TestTemplateFromProgramRoundTrip(t *testing.T) {
+	date := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	program := &models.Program{
+		Name:        "Сила",
+		TotalWeeks:  1,
+		DaysPerWeek: 2,
+		Workouts: []models.Workout{
+			{WeekNum: 1, DayNum: 1, Name: "День A", Date: &date, Exercises: []models.WorkoutExercise{
+				{OrderNum: 1, ExerciseName: "Жим лёжа", Sets: 5, Reps: "5", Weight: 75, WeightPercent: 75},
+				{OrderNum: 2, ExerciseName: "Подтягивания", Sets: 3, Reps: "8", RPE: 8},
+			}},
+		},
+	}
+
+	tpl := templateFromProgram(program)
+	if len(tpl.Exercises) != 2 {
+		t.Fatalf("got %d exercises", len(tpl.Exercises))
+	}
+	if tpl.Exercises[0].Load != "75%1PM" || tpl.Exercises[1].Load != "@RPE8" {
+		t.Errorf("loads = %q, %q", tpl.Exercises[0].Load, tpl.Exercises[1].Load)
+	}
+
+	rebuilt, _ := buildProgramFromTemplate(tpl, 1, date, map[string]float64{"Жим лёжа": 120}, i18n.LangRussian)
+	if got := rebuilt.Workouts[0].Exercises[0].Weight; got != 90 {
+		t.Errorf("rebuilt bench = %v, want 90", got)
+	}
+	if rebuilt.Workouts[0].Name != "День A" {
+		t.Errorf("rebuilt name = %q", rebuilt.Workouts[0].Name)
+	}
+}
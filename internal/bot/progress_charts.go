@@ -0,0 +1,597 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"workbot/internal/charts"
+	"workbot/internal/training"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Виды графиков прогресса
+const (
+	chartWeight       = "weight"
+	chartMeasurements = "meas"
+	chartOnePM        = "1pm"
+	chartTonnage      = "tonnage"
+	chartReadiness    = "ready"
+	chartE1RM         = "e1rm"
+)
+
+// chartKinds — порядок кнопок выбора графика
+var chartKinds = []string{chartWeight, chartMeasurements, chartOnePM, chartTonnage, chartReadiness, chartE1RM}
+
+// chartRange — период графика; months = 0 — за всё время
+type chartRange struct {
+	code   string
+	months int
+}
+
+var chartRanges = []chartRange{
+	{"1m", 1},
+	{"3m", 3},
+	{"6m", 6},
+	{"1y", 12},
+	{"all", 0},
+}
+
+const (
+	defaultChartRange = "6m"
+	maxChartLifts     = 6 // упражнений на графике 1ПМ, остальные не помещаются в легенду
+	chartKindsPerRow  = 3
+)
+
+// chartSince возвращает начало периода; для «всё время» — нулевое время
+func chartSince(code string, now time.Time) time.Time {
+	for _, r := range chartRanges {
+		if r.code == code && r.months > 0 {
+			return now.AddDate(0, -r.months, 0)
+		}
+	}
+	return time.Time{}
+}
+
+// chartDateFormat подписывает даты месяцем, если график охватывает больше года
+func chartDateFormat(from, to time.Time) string {
+	if to.Sub(from) > 365*24*time.Hour {
+		return "01.2006"
+	}
+	return "02.01"
+}
+
+// chartCallback формирует данные кнопки: chart_<вид>_<период>_<клиент>
+func chartCallback(kind, rng string, clientID int) string {
+	return fmt.Sprintf("chart_%s_%s_%d", kind, rng, clientID)
+}
+
+// parseChartCallback разбирает данные кнопки графика
+func parseChartCallback(data string) (kind, rng string, clientID int, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(data, "chart_"), "_")
+	if len(parts) != 3 {
+		return "", "", 0, false
+	}
+	clientID, err := strconv.Atoi(parts[2])
+	if err != nil || clientID <= 0 {
+		return "", "", 0, false
+	}
+	kind, rng = parts[0], parts[1]
+
+	validKind := false
+	for _, k := range chartKinds {
+		validKind = validKind || k == kind
+	}
+	validRange := false
+	for _, r := range chartRanges {
+		validRange = validRange || r.code == rng
+	}
+	return kind, rng, clientID, validKind && validRange
+}
+
+// chartKeyboard — кнопки выбора графика и периода; текущий выбор отмечен точкой
+func (b *Bot) chartKeyboard(chatID int64, clientID int, kind, rng string) tgbotapi.InlineKeyboardMarkup {
+	mark := func(label string, selected bool) string {
+		if selected {
+			return "• " + label
+		}
+		return label
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var kinds, ranges []tgbotapi.InlineKeyboardButton
+	for _, k := range chartKinds {
+		kinds = append(kinds, tgbotapi.NewInlineKeyboardButtonData(
+			mark(b.t("chart_btn_"+k, chatID), k == kind), chartCallback(k, rng, clientID)))
+		if len(kinds) == chartKindsPerRow {
+			rows = append(rows, kinds)
+			kinds = nil
+		}
+	}
+	if len(kinds) > 0 {
+		rows = append(rows, kinds)
+	}
+	for _, r := range chartRanges {
+		ranges = append(ranges, tgbotapi.NewInlineKeyboardButtonData(
+			mark(b.t("chart_range_"+r.code, chatID), r.code == rng), chartCallback(kind, r.code, clientID)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(append(rows, ranges)...)
+}
+
+// canViewClientProgress проверяет доступ к графикам и фото: тренер видит любого клиента, клиент — только себя
+func (b *Bot) canViewClientProgress(chatID int64, clientID int) bool {
+	if b.isAdmin(chatID) {
+		return true
+	}
+	ownID, err := b.repo.Program.GetClientByTelegramID(chatID)
+	return err == nil && ownID == clientID
+}
+
+// sendProgressChart отправляет график картинкой с кнопками выбора вида и периода
+func (b *Bot) sendProgressChart(chatID int64, clientID int, kind, rng string) {
+	chart, caption, err := b.buildProgressChart(chatID, clientID, kind, rng)
+	if err != nil {
+		log.Printf("Ошибка построения графика %s клиента %d: %v", kind, clientID, err)
+		b.sendMessage(chatID, b.t("progress_load_error", chatID))
+		return
+	}
+	keyboard := b.chartKeyboard(chatID, clientID, kind, rng)
+
+	if chart.Empty() {
+		msg := tgbotapi.NewMessage(chatID, caption)
+		msg.ParseMode = "Markdown"
+		msg.ReplyMarkup = keyboard
+		b.api.Send(msg)
+		return
+	}
+
+	data, err := chart.PNG()
+	if err != nil {
+		log.Printf("Ошибка отрисовки графика %s клиента %d: %v", kind, clientID, err)
+		b.sendMessage(chatID, b.t("progress_load_error", chatID))
+		return
+	}
+
+	photo := tgbotapi.NewPhoto(chatID, tgbotapi.FileBytes{Name: kind + ".png", Bytes: data})
+	photo.Caption = caption
+	photo.ParseMode = "Markdown"
+	photo.ReplyMarkup = keyboard
+	if _, err := b.api.Send(photo); err != nil {
+		log.Printf("Ошибка отправки графика: %v", err)
+	}
+}
+
+// handleChartCallback переключает вид или период графика в том же сообщении
+func (b *Bot) handleChartCallback(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+
+	kind, rng, clientID, ok := parseChartCallback(callback.Data)
+	if !ok || !b.canViewClientProgress(chatID, clientID) {
+		return
+	}
+
+	chart, caption, err := b.buildProgressChart(chatID, clientID, kind, rng)
+	if err != nil {
+		log.Printf("Ошибка построения графика %s клиента %d: %v", kind, clientID, err)
+		return
+	}
+	keyboard := b.chartKeyboard(chatID, clientID, kind, rng)
+	isPhoto := len(callback.Message.Photo) > 0
+
+	switch {
+	case isPhoto && !chart.Empty():
+		data, err := chart.PNG()
+		if err != nil {
+			log.Printf("Ошибка отрисовки графика %s клиента %d: %v", kind, clientID, err)
+			return
+		}
+		media := tgbotapi.NewInputMediaPhoto(tgbotapi.FileBytes{Name: kind + ".png", Bytes: data})
+		media.Caption = caption
+		media.ParseMode = "Markdown"
+		edit := tgbotapi.EditMessageMediaConfig{
+			BaseEdit: tgbotapi.BaseEdit{ChatID: chatID, MessageID: messageID, ReplyMarkup: &keyboard},
+			Media:    media,
+		}
+		if _, err := b.api.Request(edit); err != nil {
+			log.Printf("Ошибка обновления графика: %v", err)
+		}
+
+	case !isPhoto && chart.Empty():
+		edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, caption, keyboard)
+		edit.ParseMode = "Markdown"
+		b.api.Send(edit)
+
+	default:
+		// Картинку нельзя превратить в текст и наоборот — отправляем заново
+		b.api.Request(tgbotapi.NewDeleteMessage(chatID, messageID))
+		b.sendProgressChart(chatID, clientID, kind, rng)
+	}
+}
+
+// buildProgressChart собирает график и подпись к нему на языке chatID.
+// Для пустого графика подпись сообщает, что данных за период нет
+func (b *Bot) buildProgressChart(chatID int64, clientID int, kind, rng string) (charts.Chart, string, error) {
+	since := chartSince(rng, time.Now())
+
+	var (
+		chart   charts.Chart
+		caption string
+		err     error
+	)
+	switch kind {
+	case chartMeasurements:
+		chart, caption, err = b.measurementsChart(chatID, clientID, since)
+	case chartOnePM:
+		chart, caption, err = b.onePMChart(chatID, clientID, since)
+	case chartTonnage:
+		chart, caption, err = b.tonnageChart(chatID, clientID, since)
+	case chartReadiness:
+		chart, caption, err = b.readinessChart(chatID, clientID, since)
+	case chartE1RM:
+		chart, caption, err = b.e1rmChart(chatID, clientID, since)
+	default:
+		chart, caption, err = b.weightChart(chatID, clientID, since)
+	}
+	if err != nil {
+		return chart, "", err
+	}
+
+	if chart.Empty() {
+		caption = b.t("chart_title_"+kind, chatID) + "\n\n" + b.t("chart_no_data", chatID)
+	}
+	if b.isAdmin(chatID) {
+		var name, surname string
+		if err := b.db.QueryRow("SELECT name, surname FROM public.clients WHERE id = $1", clientID).
+			Scan(&name, &surname); err == nil {
+			caption = b.tf("chart_client", chatID, name, surname) + "\n" + caption
+		}
+	}
+	return chart, caption, nil
+}
+
+// seriesPeriod возвращает первую и последнюю дату графика
+func seriesPeriod(chart charts.Chart) (time.Time, time.Time) {
+	var from, to time.Time
+	for _, s := range chart.Series {
+		if len(s.Points) == 0 {
+			continue
+		}
+		if first := s.Points[0].Time; from.IsZero() || first.Before(from) {
+			from = first
+		}
+		if last := s.Points[len(s.Points)-1].Time; last.After(to) {
+			to = last
+		}
+	}
+	return from, to
+}
+
+// periodLine подписывает период графика
+func (b *Bot) periodLine(chatID int64, chart charts.Chart) string {
+	from, to := seriesPeriod(chart)
+	return b.tf("progress_measurements_period", chatID, from.Format("02.01.2006"), to.Format("02.01.2006"))
+}
+
+// weightChart — вес тела по записям прогресса
+func (b *Bot) weightChart(chatID int64, clientID int, since time.Time) (charts.Chart, string, error) {
+	chart := charts.Chart{
+		Title: b.t("chart_image_weight", chatID),
+		Unit:  b.t("chart_unit_kg", chatID),
+	}
+
+	rows, err := b.db.Query(`
+		SELECT record_date, weight
+		FROM public.client_progress
+		WHERE client_id = $1 AND weight > 0 AND record_date >= $2
+		ORDER BY record_date`, clientID, since)
+	if err != nil {
+		return chart, "", err
+	}
+	defer rows.Close()
+
+	var series charts.Series
+	for rows.Next() {
+		var p charts.Point
+		if err := rows.Scan(&p.Time, &p.Value); err != nil {
+			return chart, "", err
+		}
+		series.Points = append(series.Points, p)
+	}
+	if err := rows.Err(); err != nil {
+		return chart, "", err
+	}
+	chart.Series = []charts.Series{series}
+	if len(series.Points) == 0 {
+		return chart, "", nil
+	}
+
+	from, to := seriesPeriod(chart)
+	chart.DateFormat = chartDateFormat(from, to)
+
+	var caption strings.Builder
+	caption.WriteString(b.t("chart_title_weight", chatID) + "\n")
+	caption.WriteString(b.periodLine(chatID, chart) + "\n")
+	if n := len(series.Points); n >= 2 {
+		first, last := series.Points[0].Value, series.Points[n-1].Value
+		diff := last - first
+
+		arrow := "➡️"
+		if diff > 0 {
+			arrow = "⬆️"
+		} else if diff < 0 {
+			arrow = "⬇️"
+		}
+		caption.WriteString("\n" + b.t("progress_weight_stats", chatID) + "\n")
+		caption.WriteString("  " + b.tf("progress_weight_start", chatID, first) + "\n")
+		caption.WriteString("  " + b.tf("progress_weight_now", chatID, last) + "\n")
+		caption.WriteString("  " + b.tf("progress_weight_change", chatID, diff, arrow))
+	}
+	return chart, caption.String(), nil
+}
+
+// measurementsChart — обхваты тела: по линии на каждый замер
+func (b *Bot) measurementsChart(chatID int64, clientID int, since time.Time) (charts.Chart, string, error) {
+	chart := charts.Chart{
+		Title: b.t("chart_image_meas", chatID),
+		Unit:  b.t("chart_unit_cm", chatID),
+	}
+
+	rows, err := b.db.Query(`
+		SELECT record_date, COALESCE(chest, 0), COALESCE(waist, 0), COALESCE(hips, 0),
+		       COALESCE(biceps, 0), COALESCE(thigh, 0)
+		FROM public.client_progress
+		WHERE client_id = $1 AND record_date >= $2
+		  AND (chest > 0 OR waist > 0 OR hips > 0 OR biceps > 0 OR thigh > 0)
+		ORDER BY record_date`, clientID, since)
+	if err != nil {
+		return chart, "", err
+	}
+	defer rows.Close()
+
+	keys := []string{"progress_row_chest", "progress_row_waist", "progress_row_hips", "progress_row_biceps", "progress_row_thigh"}
+	all := make([]charts.Series, len(keys))
+	for i, key := range keys {
+		all[i].Name = b.t(key, chatID)
+	}
+	for rows.Next() {
+		var date time.Time
+		values := make([]float64, len(keys))
+		if err := rows.Scan(&date, &values[0], &values[1], &values[2], &values[3], &values[4]); err != nil {
+			return chart, "", err
+		}
+		for i, v := range values {
+			if v > 0 {
+				all[i].Points = append(all[i].Points, charts.Point{Time: date, Value: v})
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return chart, "", err
+	}
+	for _, s := range all {
+		if len(s.Points) > 0 {
+			chart.Series = append(chart.Series, s)
+		}
+	}
+	if chart.Empty() {
+		return chart, "", nil
+	}
+
+	from, to := seriesPeriod(chart)
+	chart.DateFormat = chartDateFormat(from, to)
+
+	var caption strings.Builder
+	caption.WriteString(b.t("chart_title_meas", chatID) + "\n")
+	caption.WriteString(b.periodLine(chatID, chart) + "\n\n")
+	caption.WriteString("```\n")
+	caption.WriteString(b.t("progress_measurements_header", chatID) + "\n")
+	caption.WriteString("─────────────────────────────────\n")
+	for _, s := range chart.Series {
+		first, last := s.Points[0].Value, s.Points[len(s.Points)-1].Value
+		caption.WriteString(fmt.Sprintf("%-8s %5.1f   %5.1f   %s\n", s.Name, first, last, formatDiff(last-first)))
+	}
+	caption.WriteString("```")
+	return chart, caption.String(), nil
+}
+
+// onePMChart — 1ПМ по упражнениям; если упражнений много, берутся самые часто тестируемые
+func (b *Bot) onePMChart(chatID int64, clientID int, since time.Time) (charts.Chart, string, error) {
+	chart := charts.Chart{
+		Title: b.t("chart_image_1pm", chatID),
+		Unit:  b.t("chart_unit_kg", chatID),
+	}
+
+	rows, err := b.db.Query(`
+		SELECT e.name, t.test_date, t.one_pm_kg
+		FROM public.exercise_1pm t
+		JOIN public.exercises e ON e.id = t.exercise_id
+		WHERE t.client_id = $1 AND t.test_date >= $2
+		ORDER BY e.name, t.test_date, t.id`, clientID, since)
+	if err != nil {
+		return chart, "", err
+	}
+	defer rows.Close()
+
+	var lifts []charts.Series
+	for rows.Next() {
+		var name string
+		var p charts.Point
+		if err := rows.Scan(&name, &p.Time, &p.Value); err != nil {
+			return chart, "", err
+		}
+		if n := len(lifts); n == 0 || lifts[n-1].Name != name {
+			lifts = append(lifts, charts.Series{Name: name})
+		}
+		lifts[len(lifts)-1].Points = append(lifts[len(lifts)-1].Points, p)
+	}
+	if err := rows.Err(); err != nil {
+		return chart, "", err
+	}
+	if len(lifts) == 0 {
+		return chart, "", nil
+	}
+
+	if len(lifts) > maxChartLifts {
+		sort.SliceStable(lifts, func(i, j int) bool { return len(lifts[i].Points) > len(lifts[j].Points) })
+		lifts = lifts[:maxChartLifts]
+	}
+	chart.Series = lifts
+
+	from, to := seriesPeriod(chart)
+	chart.DateFormat = chartDateFormat(from, to)
+
+	var caption strings.Builder
+	caption.WriteString(b.t("chart_title_1pm", chatID) + "\n")
+	caption.WriteString(b.periodLine(chatID, chart) + "\n")
+	for _, s := range lifts {
+		first, last := s.Points[0].Value, s.Points[len(s.Points)-1].Value
+		caption.WriteString("\n" + b.tf("chart_1pm_item", chatID, s.Name, first, last, last-first))
+	}
+	return chart, caption.String(), nil
+}
+
+// tonnageChart — тоннаж выполненных тренировок по неделям
+func (b *Bot) tonnageChart(chatID int64, clientID int, since time.Time) (charts.Chart, string, error) {
+	chart := charts.Chart{
+		Title: b.t("chart_image_tonnage", chatID),
+		Unit:  b.t("chart_unit_kg", chatID),
+		Kind:  charts.Bar,
+	}
+
+	weeks, err := b.repo.Program.GetWeeklyTonnage(clientID, since)
+	if err != nil {
+		return chart, "", err
+	}
+
+	var series charts.Series
+	var total float64
+	best := -1
+	for i, w := range weeks {
+		if w.Tonnage <= 0 {
+			continue
+		}
+		series.Points = append(series.Points, charts.Point{Time: w.WeekStart, Value: w.Tonnage})
+		total += w.Tonnage
+		if best < 0 || w.Tonnage > weeks[best].Tonnage {
+			best = i
+		}
+	}
+	chart.Series = []charts.Series{series}
+	if len(series.Points) == 0 {
+		return chart, "", nil
+	}
+
+	from, to := seriesPeriod(chart)
+	chart.DateFormat = chartDateFormat(from, to)
+
+	var caption strings.Builder
+	caption.WriteString(b.t("chart_title_tonnage", chatID) + "\n")
+	caption.WriteString(b.periodLine(chatID, chart) + "\n\n")
+	caption.WriteString(b.tf("chart_tonnage_total", chatID, total) + "\n")
+	caption.WriteString(b.tf("chart_tonnage_avg", chatID, total/float64(len(series.Points))) + "\n")
+	caption.WriteString(b.tf("chart_tonnage_best", chatID, weeks[best].WeekStart.Format("02.01.2006"), weeks[best].Tonnage))
+	return chart, caption.String(), nil
+}
+
+// readinessChart — оценка готовности перед тренировками по дням
+func (b *Bot) readinessChart(chatID int64, clientID int, since time.Time) (charts.Chart, string, error) {
+	chart := charts.Chart{
+		Title: b.t("chart_image_ready", chatID),
+		Unit:  b.t("chart_unit_points", chatID),
+	}
+
+	rows, err := b.db.Query(`
+		SELECT DATE(created_at) AS day, AVG(score)
+		FROM public.readiness_checkins
+		WHERE client_id = $1 AND created_at >= $2
+		GROUP BY day
+		ORDER BY day`, clientID, since)
+	if err != nil {
+		return chart, "", err
+	}
+	defer rows.Close()
+
+	var series charts.Series
+	var total float64
+	low := 0
+	for rows.Next() {
+		var p charts.Point
+		if err := rows.Scan(&p.Time, &p.Value); err != nil {
+			return chart, "", err
+		}
+		series.Points = append(series.Points, p)
+		total += p.Value
+		if training.AdjustmentFor(int(p.Value+0.5)).Zone == training.ReadinessLow {
+			low++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return chart, "", err
+	}
+	chart.Series = []charts.Series{series}
+	if len(series.Points) == 0 {
+		return chart, "", nil
+	}
+
+	from, to := seriesPeriod(chart)
+	chart.DateFormat = chartDateFormat(from, to)
+
+	last := series.Points[len(series.Points)-1]
+	var caption strings.Builder
+	caption.WriteString(b.t("chart_title_ready", chatID) + "\n")
+	caption.WriteString(b.periodLine(chatID, chart) + "\n\n")
+	caption.WriteString(b.tf("chart_ready_avg", chatID, total/float64(len(series.Points))) + "\n")
+	caption.WriteString(b.tf("chart_ready_last", chatID, last.Time.Format("02.01.2006"), last.Value) + "\n")
+	caption.WriteString(b.tf("chart_ready_low", chatID, low, len(series.Points)))
+	return chart, caption.String(), nil
+}
+
+// e1rmChart — расчётный 1ПМ по топ-сетам с RPE; подпись показывает изменение
+// и текущий скользящий e1RM, от которого назначается вес
+func (b *Bot) e1rmChart(chatID int64, clientID int, since time.Time) (charts.Chart, string, error) {
+	chart := charts.Chart{
+		Title: b.t("chart_image_e1rm", chatID),
+		Unit:  b.t("chart_unit_kg", chatID),
+	}
+
+	sets, err := b.repo.Program.GetRPESets(clientID, since)
+	if err != nil {
+		return chart, "", err
+	}
+	lifts := groupLiftE1RM(sets)
+	if len(lifts) == 0 {
+		return chart, "", nil
+	}
+	if len(lifts) > maxChartLifts {
+		sort.SliceStable(lifts, func(i, j int) bool { return len(lifts[i].Tops) > len(lifts[j].Tops) })
+		lifts = lifts[:maxChartLifts]
+	}
+	for _, lift := range lifts {
+		s := charts.Series{Name: lift.Name}
+		for _, top := range lift.Tops {
+			s.Points = append(s.Points, charts.Point{Time: top.Date, Value: top.E1RM})
+		}
+		chart.Series = append(chart.Series, s)
+	}
+
+	from, to := seriesPeriod(chart)
+	chart.DateFormat = chartDateFormat(from, to)
+
+	now := time.Now()
+	var caption strings.Builder
+	caption.WriteString(b.t("chart_title_e1rm", chatID) + "\n")
+	caption.WriteString(b.periodLine(chatID, chart) + "\n")
+	for _, lift := range lifts {
+		first, last := lift.Tops[0].E1RM, lift.Tops[len(lift.Tops)-1].E1RM
+		caption.WriteString("\n" + b.tf("chart_1pm_item", chatID, lift.Name, first, last, last-first))
+		if rolling := training.RollingE1RM(lift.Tops, now); rolling > 0 {
+			caption.WriteString(" " + b.tf("chart_e1rm_now", chatID, rolling))
+		}
+	}
+	return chart, caption.String(), nil
+}
@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChartCallbackRoundTrip(t *testing.T) {
+	for _, kind := range chartKinds {
+		for _, r := range chartRanges {
+			data := chartCallback(kind, r.code, 42)
+			if len(data) > 64 {
+				t.Errorf("%q длиннее 64 байт — Telegram не примет кнопку", data)
+			}
+			gotKind, gotRange, clientID, ok := parseChartCallback(data)
+			if !ok || gotKind != kind || gotRange != r.code || clientID != 42 {
+				t.Errorf("parseChartCallback(%q) = %q, %q, %d, %v", data, gotKind, gotRange, clientID, ok)
+			}
+		}
+	}
+}
+
+func TestParseChartCallbackRejectsInvalid(t *testing.T) {
+	for _, data := range []string{
+		"chart_weight_6m",
+		"chart_weight_6m_abc",
+		"chart_weight_6m_0",
+		"chart_pulse_6m_1",
+		"chart_weight_2y_1",
+		"chart_weight_6m_1_extra",
+	} {
+		if _, _, _, ok := parseChartCallback(data); ok {
+			t.Errorf("parseChartCallback(%q) принял неверные данные", data)
+		}
+	}
+}
+
+func TestChartSince(t *testing.T) {
+	now := time.Date(2026, 5, 31, 12, 0, 0, 0, time.UTC)
+	if got := chartSince("3m", now); !got.Equal(now.AddDate(0, -3, 0)) {
+		t.Errorf("chartSince(3m) = %v", got)
+	}
+	if got := chartSince("all", now); !got.IsZero() {
+		t.Errorf("chartSince(all) = %v, хотим нулевое время", got)
+	}
+}
+
+func TestChartDateFormat(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := chartDateFormat(from, from.AddDate(0, 6, 0)); got != "02.01" {
+		t.Errorf("полгода: формат %q, хотим 02.01", got)
+	}
+	if got := chartDateFormat(from, from.AddDate(2, 0, 0)); got != "01.2006" {
+		t.Errorf("два года: формат %q, хотим 01.2006", got)
+	}
+}
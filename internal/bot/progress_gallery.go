@@ -0,0 +1,538 @@
+package bot
+
+import (
+	"fmt"
+	"image"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"workbot/internal/collage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// photoAngles — ракурсы фото прогресса в порядке съёмки
+var photoAngles = []string{"front", "side", "back"}
+
+const (
+	galleryDays      = 20       // дат в списке галереи
+	maxPhotoFileSize = 10 << 20 // фото прогресса для коллажа
+	galleryDateCode  = "20060102"
+)
+
+// photoDay — дата, за которую есть фото, и её ракурсы
+type photoDay struct {
+	date   time.Time
+	angles []string
+}
+
+// saveProgressPhotos сохраняет фото ракурсов за сегодня; повторное фото ракурса заменяет прежнее.
+// progressID = 0 — фото добавлены из галереи без записи прогресса
+func (b *Bot) saveProgressPhotos(clientID, progressID int, photos map[string]string) error {
+	var pid interface{}
+	if progressID > 0 {
+		pid = progressID
+	}
+	for _, angle := range photoAngles {
+		fileID, ok := photos[angle]
+		if !ok {
+			continue
+		}
+		_, err := b.db.Exec(`
+			INSERT INTO public.progress_photos (client_id, progress_id, taken_on, angle, file_id)
+			VALUES ($1, $2, CURRENT_DATE, $3, $4)
+			ON CONFLICT (client_id, taken_on, angle) DO UPDATE
+			SET file_id = EXCLUDED.file_id,
+			    progress_id = COALESCE(EXCLUDED.progress_id, progress_photos.progress_id),
+			    created_at = NOW()`,
+			clientID, pid, angle, fileID)
+		if err != nil {
+			return fmt.Errorf("ошибка сохранения фото %s: %w", angle, err)
+		}
+	}
+	return nil
+}
+
+// photoDays возвращает последние даты с фото
+func (b *Bot) photoDays(clientID int) ([]photoDay, error) {
+	rows, err := b.db.Query(`
+		SELECT taken_on, string_agg(angle, ',' ORDER BY array_position(ARRAY['front', 'side', 'back']::varchar[], angle))
+		FROM public.progress_photos
+		WHERE client_id = $1
+		GROUP BY taken_on
+		ORDER BY taken_on DESC
+		LIMIT $2`, clientID, galleryDays)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var days []photoDay
+	for rows.Next() {
+		var d photoDay
+		var angles string
+		if err := rows.Scan(&d.date, &angles); err != nil {
+			return nil, err
+		}
+		d.angles = strings.Split(angles, ",")
+		days = append(days, d)
+	}
+	return days, rows.Err()
+}
+
+// dayPhotos возвращает фото за дату: ракурс → file_id
+func (b *Bot) dayPhotos(clientID int, date time.Time) (map[string]string, error) {
+	rows, err := b.db.Query(`
+		SELECT angle, file_id FROM public.progress_photos
+		WHERE client_id = $1 AND taken_on = $2`, clientID, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	photos := make(map[string]string)
+	for rows.Next() {
+		var angle, fileID string
+		if err := rows.Scan(&angle, &fileID); err != nil {
+			return nil, err
+		}
+		photos[angle] = fileID
+	}
+	return photos, rows.Err()
+}
+
+// isClientOwner сообщает, что chatID — сам клиент clientID. Удалять и добавлять фото может только он
+func (b *Bot) isClientOwner(chatID int64, clientID int) bool {
+	ownID, err := b.repo.Program.GetClientByTelegramID(chatID)
+	return err == nil && ownID == clientID
+}
+
+// handleProgressGallery открывает клиенту его галерею
+func (b *Bot) handleProgressGallery(chatID int64) {
+	clientID, err := b.repo.Program.GetClientByTelegramID(chatID)
+	if err != nil || clientID == 0 {
+		b.sendMessage(chatID, b.t("reg_not_registered", chatID))
+		return
+	}
+	b.showPhotoGallery(chatID, clientID, 0, "")
+}
+
+// showPhotoGallery показывает даты с фото. messageID > 0 — заменить сообщение,
+// notice — строка над списком, например об удалении
+func (b *Bot) showPhotoGallery(chatID int64, clientID int, messageID int, notice string) {
+	days, err := b.photoDays(clientID)
+	if err != nil {
+		log.Printf("Ошибка загрузки галереи клиента %d: %v", clientID, err)
+		b.sendMessage(chatID, b.t("gallery_load_error", chatID))
+		return
+	}
+
+	var text strings.Builder
+	if notice != "" {
+		text.WriteString(notice + "\n\n")
+	}
+	text.WriteString(b.t("gallery_title", chatID) + "\n")
+	if !b.isClientOwner(chatID, clientID) {
+		var name, surname string
+		if err := b.db.QueryRow("SELECT name, surname FROM public.clients WHERE id = $1", clientID).
+			Scan(&name, &surname); err == nil {
+			text.WriteString(b.tf("chart_client", chatID, name, surname) + "\n")
+		}
+	}
+	text.WriteString("\n")
+	if len(days) == 0 {
+		text.WriteString(b.t("gallery_empty", chatID))
+	} else {
+		text.WriteString(b.t("gallery_choose_day", chatID))
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	rows = append(rows, b.dayButtons(chatID, days, time.Time{}, func(d time.Time) string {
+		return galleryCallback("day", clientID, d.Format(galleryDateCode))
+	})...)
+	if len(days) >= 2 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("gallery_btn_compare", chatID), galleryCallback("cmp", clientID))))
+	}
+	if b.isClientOwner(chatID, clientID) {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("gallery_btn_add", chatID), galleryCallback("add", clientID))))
+	}
+
+	b.sendOrEditInline(chatID, messageID, text.String(), rows)
+}
+
+// dayButtons — кнопки дат по две в ряд; дата exclude пропускается
+func (b *Bot) dayButtons(chatID int64, days []photoDay, exclude time.Time, data func(time.Time) string) [][]tgbotapi.InlineKeyboardButton {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+	for _, d := range days {
+		if d.date.Equal(exclude) {
+			continue
+		}
+		label := b.tf("gallery_day_btn", chatID, d.date.Format("02.01.2006"), len(d.angles))
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, data(d.date)))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// sendOrEditInline отправляет сообщение с inline-кнопками или заменяет сообщение messageID
+func (b *Bot) sendOrEditInline(chatID int64, messageID int, text string, rows [][]tgbotapi.InlineKeyboardButton) {
+	if messageID > 0 {
+		edit := tgbotapi.NewEditMessageText(chatID, messageID, text)
+		edit.ParseMode = "Markdown"
+		if len(rows) > 0 {
+			markup := tgbotapi.NewInlineKeyboardMarkup(rows...)
+			edit.ReplyMarkup = &markup
+		}
+		b.api.Send(edit)
+		return
+	}
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = "Markdown"
+	if len(rows) > 0 {
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	}
+	b.api.Send(msg)
+}
+
+// galleryCallback формирует данные кнопки: gal_<действие>_<клиент>[_<аргументы>]
+func galleryCallback(action string, clientID int, args ...string) string {
+	parts := append([]string{"gal", action, strconv.Itoa(clientID)}, args...)
+	return strings.Join(parts, "_")
+}
+
+// parseGalleryCallback разбирает данные кнопки галереи
+func parseGalleryCallback(data string) (action string, clientID int, args []string, ok bool) {
+	parts := strings.Split(data, "_")
+	if len(parts) < 3 || parts[0] != "gal" {
+		return "", 0, nil, false
+	}
+	clientID, err := strconv.Atoi(parts[2])
+	if err != nil || clientID <= 0 {
+		return "", 0, nil, false
+	}
+	return parts[1], clientID, parts[3:], true
+}
+
+// parseGalleryDates разбирает даты из аргументов кнопки
+func parseGalleryDates(args []string, n int) ([]time.Time, bool) {
+	if len(args) < n {
+		return nil, false
+	}
+	dates := make([]time.Time, n)
+	for i := 0; i < n; i++ {
+		d, err := time.Parse(galleryDateCode, args[i])
+		if err != nil {
+			return nil, false
+		}
+		dates[i] = d
+	}
+	return dates, true
+}
+
+// handleGalleryCallback обрабатывает кнопки галереи
+func (b *Bot) handleGalleryCallback(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	// Сообщение с фото нельзя превратить в текст — список открывается новым сообщением
+	if len(callback.Message.Photo) > 0 {
+		messageID = 0
+	}
+
+	action, clientID, args, ok := parseGalleryCallback(callback.Data)
+	if !ok || !b.canViewClientProgress(chatID, clientID) {
+		return
+	}
+
+	switch action {
+	case "list":
+		b.showPhotoGallery(chatID, clientID, messageID, "")
+
+	case "day":
+		if dates, ok := parseGalleryDates(args, 1); ok {
+			b.sendPhotoDay(chatID, clientID, dates[0])
+		}
+
+	case "cmp":
+		b.askCompareDate(chatID, clientID, messageID, time.Time{})
+
+	case "from":
+		if dates, ok := parseGalleryDates(args, 1); ok {
+			b.askCompareDate(chatID, clientID, messageID, dates[0])
+		}
+
+	case "to":
+		if dates, ok := parseGalleryDates(args, 2); ok {
+			b.sendPhotoComparison(chatID, clientID, dates[0], dates[1])
+		}
+
+	case "del":
+		if dates, ok := parseGalleryDates(args, 1); ok && b.isClientOwner(chatID, clientID) {
+			b.askDeletePhotos(chatID, clientID, messageID, dates[0])
+		}
+
+	case "rm":
+		if dates, ok := parseGalleryDates(args, 1); ok && len(args) == 2 && b.isClientOwner(chatID, clientID) {
+			b.deletePhotos(chatID, clientID, messageID, dates[0], args[1])
+		}
+
+	case "add":
+		if b.isClientOwner(chatID, clientID) {
+			b.startAddPhotos(chatID, clientID)
+		}
+	}
+}
+
+// sendPhotoDay отправляет фото за дату и кнопки действий с ними
+func (b *Bot) sendPhotoDay(chatID int64, clientID int, date time.Time) {
+	photos, err := b.dayPhotos(clientID, date)
+	if err != nil {
+		log.Printf("Ошибка загрузки фото клиента %d: %v", clientID, err)
+		b.sendMessage(chatID, b.t("gallery_load_error", chatID))
+		return
+	}
+	if len(photos) == 0 {
+		b.showPhotoGallery(chatID, clientID, 0, "")
+		return
+	}
+
+	var media []interface{}
+	var angles []string
+	for _, angle := range photoAngles {
+		fileID, ok := photos[angle]
+		if !ok {
+			continue
+		}
+		item := tgbotapi.NewInputMediaPhoto(tgbotapi.FileID(fileID))
+		item.Caption = b.t("photo_angle_"+angle, chatID)
+		media = append(media, item)
+		angles = append(angles, item.Caption)
+	}
+
+	// Альбом Telegram — от 2 фото, одно фото отправляется отдельно
+	if len(media) == 1 {
+		item := media[0].(tgbotapi.InputMediaPhoto)
+		photo := tgbotapi.NewPhoto(chatID, item.Media)
+		photo.Caption = item.Caption
+		b.api.Send(photo)
+	} else {
+		b.api.Request(tgbotapi.NewMediaGroup(chatID, media))
+	}
+
+	day := date.Format(galleryDateCode)
+	var rows [][]tgbotapi.InlineKeyboardButton
+	days, err := b.photoDays(clientID)
+	if err == nil && len(days) >= 2 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("gallery_btn_compare_with", chatID), galleryCallback("from", clientID, day))))
+	}
+	if b.isClientOwner(chatID, clientID) {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("gallery_btn_delete", chatID), galleryCallback("del", clientID, day))))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("gallery_btn_back", chatID), galleryCallback("list", clientID))))
+
+	text := b.tf("gallery_day_title", chatID, date.Format("02.01.2006"), strings.Join(angles, ", "))
+	b.sendOrEditInline(chatID, 0, text, rows)
+}
+
+// askCompareDate просит выбрать дату «до», а затем — дату «после»
+func (b *Bot) askCompareDate(chatID int64, clientID int, messageID int, before time.Time) {
+	days, err := b.photoDays(clientID)
+	if err != nil {
+		log.Printf("Ошибка загрузки галереи клиента %d: %v", clientID, err)
+		b.sendMessage(chatID, b.t("gallery_load_error", chatID))
+		return
+	}
+
+	var text string
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if before.IsZero() {
+		text = b.t("gallery_pick_before", chatID)
+		rows = b.dayButtons(chatID, days, time.Time{}, func(d time.Time) string {
+			return galleryCallback("from", clientID, d.Format(galleryDateCode))
+		})
+	} else {
+		text = b.tf("gallery_pick_after", chatID, before.Format("02.01.2006"))
+		rows = b.dayButtons(chatID, days, before, func(d time.Time) string {
+			return galleryCallback("to", clientID, before.Format(galleryDateCode), d.Format(galleryDateCode))
+		})
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("gallery_btn_back", chatID), galleryCallback("list", clientID))))
+
+	b.sendOrEditInline(chatID, messageID, text, rows)
+}
+
+// sendPhotoComparison собирает коллаж «до/после»: столбцы — даты, строки — ракурсы
+func (b *Bot) sendPhotoComparison(chatID int64, clientID int, before, after time.Time) {
+	if after.Before(before) {
+		before, after = after, before
+	}
+	b.api.Request(tgbotapi.NewChatAction(chatID, tgbotapi.ChatUploadPhoto))
+
+	beforePhotos, err := b.dayPhotos(clientID, before)
+	if err != nil {
+		log.Printf("Ошибка загрузки фото клиента %d: %v", clientID, err)
+		b.sendMessage(chatID, b.t("gallery_load_error", chatID))
+		return
+	}
+	afterPhotos, err := b.dayPhotos(clientID, after)
+	if err != nil {
+		log.Printf("Ошибка загрузки фото клиента %d: %v", clientID, err)
+		b.sendMessage(chatID, b.t("gallery_load_error", chatID))
+		return
+	}
+
+	var rows [][]collage.Cell
+	for _, angle := range photoAngles {
+		if beforePhotos[angle] == "" && afterPhotos[angle] == "" {
+			continue
+		}
+		label := b.t("photo_angle_"+angle, chatID)
+		rows = append(rows, []collage.Cell{
+			{Image: b.loadPhotoImage(beforePhotos[angle]), Label: label},
+			{Image: b.loadPhotoImage(afterPhotos[angle]), Label: label},
+		})
+	}
+	if len(rows) == 0 {
+		b.showPhotoGallery(chatID, clientID, 0, "")
+		return
+	}
+
+	header := []string{
+		b.tf("gallery_before", chatID, before.Format("02.01.2006")),
+		b.tf("gallery_after", chatID, after.Format("02.01.2006")),
+	}
+	img, err := collage.Compose(header, rows, collage.Options{EmptyLabel: b.t("gallery_no_photo", chatID)})
+	if err == nil {
+		var data []byte
+		if data, err = collage.JPEG(img); err == nil {
+			photo := tgbotapi.NewPhoto(chatID, tgbotapi.FileBytes{Name: "comparison.jpg", Bytes: data})
+			photo.Caption = b.tf("gallery_compare_caption", chatID, before.Format("02.01.2006"), after.Format("02.01.2006"))
+			photo.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(b.t("gallery_btn_back", chatID), galleryCallback("list", clientID))))
+			_, err = b.api.Send(photo)
+		}
+	}
+	if err != nil {
+		log.Printf("Ошибка отправки сравнения фото клиента %d: %v", clientID, err)
+		b.sendMessage(chatID, b.t("gallery_compare_error", chatID))
+	}
+}
+
+// loadPhotoImage скачивает фото из Telegram; при ошибке ячейка коллажа остаётся пустой
+func (b *Bot) loadPhotoImage(fileID string) image.Image {
+	if fileID == "" {
+		return nil
+	}
+	data, err := b.downloadFile(fileID, maxPhotoFileSize)
+	if err != nil {
+		log.Printf("Ошибка скачивания фото прогресса: %v", err)
+		return nil
+	}
+	img, err := collage.Decode(data)
+	if err != nil {
+		log.Printf("Ошибка чтения фото прогресса: %v", err)
+		return nil
+	}
+	return img
+}
+
+// askDeletePhotos предлагает удалить один ракурс или все фото за дату
+func (b *Bot) askDeletePhotos(chatID int64, clientID int, messageID int, date time.Time) {
+	photos, err := b.dayPhotos(clientID, date)
+	if err != nil {
+		log.Printf("Ошибка загрузки фото клиента %d: %v", clientID, err)
+		b.sendMessage(chatID, b.t("gallery_load_error", chatID))
+		return
+	}
+
+	day := date.Format(galleryDateCode)
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, angle := range photoAngles {
+		if photos[angle] == "" {
+			continue
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(
+			b.tf("gallery_delete_angle", chatID, b.t("photo_angle_"+angle, chatID)), galleryCallback("rm", clientID, day, angle))))
+	}
+	if len(photos) > 1 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(
+			b.t("gallery_delete_all", chatID), galleryCallback("rm", clientID, day, "all"))))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("cancel", chatID), galleryCallback("list", clientID))))
+
+	b.sendOrEditInline(chatID, messageID, b.tf("gallery_delete_title", chatID, date.Format("02.01.2006")), rows)
+}
+
+// deletePhotos удаляет ракурс angle или все фото за дату ("all")
+func (b *Bot) deletePhotos(chatID int64, clientID int, messageID int, date time.Time, angle string) {
+	query := `DELETE FROM public.progress_photos WHERE client_id = $1 AND taken_on = $2`
+	args := []interface{}{clientID, date}
+	if angle != "all" {
+		query += ` AND angle = $3`
+		args = append(args, angle)
+	}
+	if _, err := b.db.Exec(query, args...); err != nil {
+		log.Printf("Ошибка удаления фото клиента %d: %v", clientID, err)
+		b.sendMessage(chatID, b.t("gallery_load_error", chatID))
+		return
+	}
+	b.showPhotoGallery(chatID, clientID, messageID, b.t("gallery_deleted", chatID))
+}
+
+// startAddPhotos запускает съёмку ракурсов без записи веса и замеров
+func (b *Bot) startAddPhotos(chatID int64, clientID int) {
+	progressStore.Lock()
+	progressStore.data[chatID] = &ProgressState{
+		ClientID:   clientID,
+		Step:       "photo",
+		PhotosOnly: true,
+	}
+	progressStore.Unlock()
+
+	setState(chatID, stateProgressPhoto)
+	b.askPhoto(chatID, 0)
+}
+
+// savePhotosOnly сохраняет фото, добавленные из галереи
+func (b *Bot) savePhotosOnly(chatID int64) {
+	progressStore.Lock()
+	pState := progressStore.data[chatID]
+	delete(progressStore.data, chatID)
+	progressStore.Unlock()
+	clearState(chatID)
+
+	if pState == nil {
+		b.restoreMainMenu(chatID)
+		return
+	}
+	if len(pState.Photos) == 0 {
+		b.sendMessage(chatID, b.t("gallery_photos_none", chatID))
+		b.restoreMainMenu(chatID)
+		return
+	}
+
+	if err := b.saveProgressPhotos(pState.ClientID, 0, pState.Photos); err != nil {
+		log.Printf("Ошибка сохранения фото: %v", err)
+		b.sendMessage(chatID, b.t("progress_save_error", chatID))
+		b.restoreMainMenu(chatID)
+		return
+	}
+	b.sendMessage(chatID, b.tf("gallery_photos_saved", chatID, len(pState.Photos)))
+	b.restoreMainMenu(chatID)
+	b.showPhotoGallery(chatID, pState.ClientID, 0, "")
+}
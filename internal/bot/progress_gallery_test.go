@@ -0,0 +1,46 @@
+package bot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGalleryCallbackRoundTrip(t *testing.T) {
+	data := galleryCallback("rm", 17, "20260301", "side")
+	if data != "gal_rm_17_20260301_side" {
+		t.Fatalf("galleryCallback = %q", data)
+	}
+	if len(galleryCallback("to", 1<<30, "20260301", "20260601")) > 64 {
+		t.Error("данные кнопки длиннее 64 байт")
+	}
+
+	action, clientID, args, ok := parseGalleryCallback(data)
+	if !ok || action != "rm" || clientID != 17 || !reflect.DeepEqual(args, []string{"20260301", "side"}) {
+		t.Errorf("parseGalleryCallback = %q, %d, %v, %v", action, clientID, args, ok)
+	}
+
+	for _, bad := range []string{"gal_list", "gal_list_x", "gal_list_0", "chart_list_1"} {
+		if _, _, _, ok := parseGalleryCallback(bad); ok {
+			t.Errorf("parseGalleryCallback(%q) принял неверные данные", bad)
+		}
+	}
+}
+
+func TestParseGalleryDates(t *testing.T) {
+	dates, ok := parseGalleryDates([]string{"20260301", "20260601"}, 2)
+	if !ok {
+		t.Fatal("даты не разобраны")
+	}
+	want := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	if !dates[1].Equal(want) {
+		t.Errorf("вторая дата %v, хотим %v", dates[1], want)
+	}
+
+	if _, ok := parseGalleryDates([]string{"20260301"}, 2); ok {
+		t.Error("принята одна дата вместо двух")
+	}
+	if _, ok := parseGalleryDates([]string{"2026-03-01"}, 1); ok {
+		t.Error("принята дата в неверном формате")
+	}
+}
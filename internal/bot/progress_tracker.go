@@ -8,39 +8,42 @@ import (
 	"sync"
 	"time"
 
+	"workbot/internal/i18n"
+
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 // ProgressEntry хранит запись прогресса клиента
 type ProgressEntry struct {
-	ID          int
-	ClientID    int
-	RecordDate  time.Time
-	Weight      float64 // кг
-	BodyFat     float64 // % жира (опционально)
-	Chest       float64 // см
-	Waist       float64 // см
-	Hips        float64 // см
-	Biceps      float64 // см
-	Thigh       float64 // см
-	PhotoFileID string  // Telegram file_id фотографии (опционально)
-	Notes       string
-	CreatedAt   time.Time
+	ID         int
+	ClientID   int
+	RecordDate time.Time
+	Weight     float64 // кг
+	BodyFat    float64 // % жира (опционально)
+	Chest      float64 // см
+	Waist      float64 // см
+	Hips       float64 // см
+	Biceps     float64 // см
+	Thigh      float64 // см
+	Notes      string
+	CreatedAt  time.Time
 }
 
 // ProgressState хранит состояние ввода прогресса
 type ProgressState struct {
-	ClientID    int
-	Step        string // "weight", "measurements", "photo", "notes"
-	Weight      float64
-	BodyFat     float64
-	Chest       float64
-	Waist       float64
-	Hips        float64
-	Biceps      float64
-	Thigh       float64
-	PhotoFileID string
-	Notes       string
+	ClientID   int
+	Step       string // "weight", "measurements", "photo", "notes"
+	Weight     float64
+	BodyFat    float64
+	Chest      float64
+	Waist      float64
+	Hips       float64
+	Biceps     float64
+	Thigh      float64
+	Photos     map[string]string // ракурс → file_id
+	PhotoAngle int               // индекс в photoAngles, который сейчас ожидается
+	PhotosOnly bool              // фото добавляются из галереи, без веса и замеров
+	Notes      string
 }
 
 var progressStore = struct {
@@ -63,7 +66,8 @@ func (b *Bot) handleProgressMenu(message *tgbotapi.Message) {
 
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("🏋️ Прогресс программы"),
+			tgbotapi.NewKeyboardButton(b.t("progress_btn_program", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("progress_btn_hyrox", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
 			tgbotapi.NewKeyboardButton(b.t("progress_btn_record", chatID)),
@@ -73,6 +77,10 @@ func (b *Bot) handleProgressMenu(message *tgbotapi.Message) {
 			tgbotapi.NewKeyboardButton(b.t("progress_btn_weight", chatID)),
 			tgbotapi.NewKeyboardButton(b.t("progress_btn_measurements", chatID)),
 		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(b.t("progress_btn_gallery", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("progress_btn_nutrition", chatID)),
+		),
 		tgbotapi.NewKeyboardButtonRow(
 			tgbotapi.NewKeyboardButton(b.t("back", chatID)),
 		),
@@ -111,7 +119,7 @@ func (b *Bot) handleStartProgress(chatID int64) {
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, "⚖️ *"+b.t("progress_enter_weight", chatID)+"*\n\nНапример: 75.5")
+	msg := tgbotapi.NewMessage(chatID, b.t("progress_enter_weight", chatID))
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 	b.api.Send(msg)
@@ -122,7 +130,7 @@ func (b *Bot) processProgressState(message *tgbotapi.Message, state string) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" || text == "Cancel" {
+	if i18n.Is(text, "cancel") {
 		b.cancelProgress(chatID)
 		return
 	}
@@ -137,7 +145,7 @@ func (b *Bot) processProgressState(message *tgbotapi.Message, state string) {
 
 	switch state {
 	case stateProgressWeight:
-		if text != "Пропустить" && text != "Skip" {
+		if !i18n.Is(text, "skip") {
 			weight, err := strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
 			if err != nil || weight <= 0 || weight > 500 {
 				progressStore.Unlock()
@@ -153,29 +161,28 @@ func (b *Bot) processProgressState(message *tgbotapi.Message, state string) {
 		b.askMeasurements(chatID)
 
 	case stateProgressMeasurements:
-		if text != "Пропустить" && text != "Skip" {
+		if !i18n.Is(text, "skip") {
 			b.parseMeasurements(pState, text)
 		}
 		pState.Step = "photo"
 		progressStore.Unlock()
 
 		setState(chatID, stateProgressPhoto)
-		b.askPhoto(chatID)
+		b.askPhoto(chatID, 0)
 
 	case stateProgressPhoto:
 		// Фото обрабатывается в handleProgressPhoto
-		if text == "Пропустить" || text == "Skip" {
-			pState.Step = "notes"
+		if i18n.Is(text, "skip") {
+			pState.PhotoAngle++
 			progressStore.Unlock()
-			setState(chatID, stateProgressNotes)
-			b.askNotes(chatID)
+			b.nextPhotoStep(chatID)
 		} else {
 			progressStore.Unlock()
 			b.sendMessage(chatID, b.t("progress_send_photo", chatID))
 		}
 
 	case stateProgressNotes:
-		if text != "Пропустить" && text != "Skip" {
+		if !i18n.Is(text, "skip") {
 			pState.Notes = text
 		}
 		progressStore.Unlock()
@@ -201,33 +208,54 @@ func (b *Bot) handleProgressPhoto(message *tgbotapi.Message) {
 		progressStore.Unlock()
 		return
 	}
-	pState.PhotoFileID = photo.FileID
-	pState.Step = "notes"
+	if pState.Photos == nil {
+		pState.Photos = make(map[string]string)
+	}
+	if pState.PhotoAngle < len(photoAngles) {
+		pState.Photos[photoAngles[pState.PhotoAngle]] = photo.FileID
+	}
+	pState.PhotoAngle++
+	progressStore.Unlock()
+
+	b.nextPhotoStep(chatID)
+}
+
+// nextPhotoStep спрашивает следующий ракурс; после последнего — заметки,
+// а при добавлении из галереи сразу сохраняет фото
+func (b *Bot) nextPhotoStep(chatID int64) {
+	progressStore.Lock()
+	pState := progressStore.data[chatID]
+	if pState == nil {
+		progressStore.Unlock()
+		return
+	}
+	angle, photosOnly := pState.PhotoAngle, pState.PhotosOnly
+	if angle >= len(photoAngles) {
+		pState.Step = "notes"
+	}
 	progressStore.Unlock()
 
-	setState(chatID, stateProgressNotes)
-	b.askNotes(chatID)
+	switch {
+	case angle < len(photoAngles):
+		b.askPhoto(chatID, angle)
+	case photosOnly:
+		b.savePhotosOnly(chatID)
+	default:
+		setState(chatID, stateProgressNotes)
+		b.askNotes(chatID)
+	}
 }
 
 // askMeasurements спрашивает замеры
 func (b *Bot) askMeasurements(chatID int64) {
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Пропустить"),
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("skip", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 
-	text := `📏 *Введите замеры тела (см)*
-
-Формат: грудь/талия/бёдра/бицепс/бедро
-Пример: 100/80/95/35/55
-
-Можно указать не все значения:
-• 100/80/95 — только грудь, талия, бёдра
-• /80/ — только талия
-
-Или нажмите "Пропустить"`
+	text := b.t("progress_enter_measurements", chatID)
 
 	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ParseMode = "Markdown"
@@ -265,16 +293,18 @@ func (b *Bot) parseMeasurements(pState *ProgressState, text string) {
 	}
 }
 
-// askPhoto спрашивает фото
-func (b *Bot) askPhoto(chatID int64) {
+// askPhoto спрашивает фото ракурса photoAngles[angle]
+func (b *Bot) askPhoto(chatID int64, angle int) {
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Пропустить"),
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("skip", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, "📷 *Отправьте фото прогресса*\n\nИли нажмите \"Пропустить\"")
+	text := b.tf("progress_ask_photo_angle", chatID,
+		b.t("photo_angle_"+photoAngles[angle], chatID), angle+1, len(photoAngles))
+	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 	b.api.Send(msg)
@@ -284,12 +314,12 @@ func (b *Bot) askPhoto(chatID int64) {
 func (b *Bot) askNotes(chatID int64) {
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Пропустить"),
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("skip", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, "📝 *Добавьте заметки* (опционально)\n\nНапример: \"Начал новую диету\" или \"Пропустил тренировки на неделе\"")
+	msg := tgbotapi.NewMessage(chatID, b.t("progress_ask_notes", chatID))
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = keyboard
 	b.api.Send(msg)
@@ -307,17 +337,22 @@ func (b *Bot) saveProgress(chatID int64) {
 	}
 
 	// Сохраняем в БД
-	_, err := b.db.Exec(`
+	var progressID int
+	err := b.db.QueryRow(`
 		INSERT INTO public.client_progress
-		(client_id, record_date, weight, body_fat, chest, waist, hips, biceps, thigh, photo_file_id, notes)
-		VALUES ($1, CURRENT_DATE, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		(client_id, record_date, weight, body_fat, chest, waist, hips, biceps, thigh, notes)
+		VALUES ($1, CURRENT_DATE, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`,
 		pState.ClientID, pState.Weight, pState.BodyFat,
 		pState.Chest, pState.Waist, pState.Hips, pState.Biceps, pState.Thigh,
-		pState.PhotoFileID, pState.Notes)
+		pState.Notes).Scan(&progressID)
+	if err == nil {
+		err = b.saveProgressPhotos(pState.ClientID, progressID, pState.Photos)
+	}
 
 	if err != nil {
 		log.Printf("Ошибка сохранения прогресса: %v", err)
-		b.sendMessage(chatID, "❌ Ошибка сохранения прогресса")
+		b.sendMessage(chatID, b.t("progress_save_error", chatID))
 	} else {
 		b.sendProgressSummary(chatID, pState)
 	}
@@ -334,39 +369,38 @@ func (b *Bot) saveProgress(chatID int64) {
 // sendProgressSummary отправляет сводку записанного прогресса
 func (b *Bot) sendProgressSummary(chatID int64, pState *ProgressState) {
 	var summary strings.Builder
-	summary.WriteString("✅ *Прогресс записан!*\n\n")
-	summary.WriteString(fmt.Sprintf("📅 Дата: %s\n\n", time.Now().Format("02.01.2006")))
+	summary.WriteString(b.t("progress_saved", chatID) + "\n\n")
+	summary.WriteString(b.tf("progress_summary_date", chatID, time.Now().Format("02.01.2006")) + "\n\n")
 
 	if pState.Weight > 0 {
-		summary.WriteString(fmt.Sprintf("⚖️ Вес: %.1f кг\n", pState.Weight))
+		summary.WriteString(b.tf("progress_summary_weight", chatID, pState.Weight) + "\n")
 	}
 
 	hasMeasurements := pState.Chest > 0 || pState.Waist > 0 || pState.Hips > 0 || pState.Biceps > 0 || pState.Thigh > 0
 	if hasMeasurements {
-		summary.WriteString("\n📏 *Замеры:*\n")
-		if pState.Chest > 0 {
-			summary.WriteString(fmt.Sprintf("  • Грудь: %.1f см\n", pState.Chest))
-		}
-		if pState.Waist > 0 {
-			summary.WriteString(fmt.Sprintf("  • Талия: %.1f см\n", pState.Waist))
-		}
-		if pState.Hips > 0 {
-			summary.WriteString(fmt.Sprintf("  • Бёдра: %.1f см\n", pState.Hips))
-		}
-		if pState.Biceps > 0 {
-			summary.WriteString(fmt.Sprintf("  • Бицепс: %.1f см\n", pState.Biceps))
-		}
-		if pState.Thigh > 0 {
-			summary.WriteString(fmt.Sprintf("  • Бедро: %.1f см\n", pState.Thigh))
+		summary.WriteString("\n" + b.t("progress_summary_measurements", chatID) + "\n")
+		for _, m := range []struct {
+			key   string
+			value float64
+		}{
+			{"progress_chest", pState.Chest},
+			{"progress_waist", pState.Waist},
+			{"progress_hips", pState.Hips},
+			{"progress_biceps", pState.Biceps},
+			{"progress_thigh", pState.Thigh},
+		} {
+			if m.value > 0 {
+				summary.WriteString("  • " + b.tf(m.key, chatID, m.value) + "\n")
+			}
 		}
 	}
 
-	if pState.PhotoFileID != "" {
-		summary.WriteString("\n📷 Фото сохранено\n")
+	if len(pState.Photos) > 0 {
+		summary.WriteString("\n" + b.tf("progress_summary_photo", chatID, len(pState.Photos)) + "\n")
 	}
 
 	if pState.Notes != "" {
-		summary.WriteString(fmt.Sprintf("\n📝 Заметки: %s\n", pState.Notes))
+		summary.WriteString("\n" + b.tf("progress_summary_notes", chatID, pState.Notes) + "\n")
 	}
 
 	msg := tgbotapi.NewMessage(chatID, summary.String())
@@ -402,7 +436,7 @@ func (b *Bot) handleViewProgress(chatID int64) {
 		LIMIT 10`, clientID)
 	if err != nil {
 		log.Printf("Ошибка получения прогресса: %v", err)
-		b.sendMessage(chatID, "Ошибка загрузки прогресса")
+		b.sendMessage(chatID, b.t("progress_load_error", chatID))
 		return
 	}
 	defer rows.Close()
@@ -421,10 +455,10 @@ func (b *Bot) handleViewProgress(chatID int64) {
 		entry := fmt.Sprintf("📅 *%s*\n", date.Format("02.01.2006"))
 
 		if weight > 0 {
-			entry += fmt.Sprintf("  ⚖️ Вес: %.1f кг\n", weight)
+			entry += "  " + b.tf("progress_summary_weight", chatID, weight) + "\n"
 		}
 		if chest > 0 || waist > 0 || hips > 0 {
-			entry += fmt.Sprintf("  📏 %s\n", formatMeasurements(chest, waist, hips, biceps, thigh))
+			entry += fmt.Sprintf("  📏 %s\n", b.formatMeasurements(chatID, chest, waist, hips, biceps, thigh))
 		}
 		if notes != "" {
 			entry += fmt.Sprintf("  📝 %s\n", notes)
@@ -438,182 +472,30 @@ func (b *Bot) handleViewProgress(chatID int64) {
 		return
 	}
 
-	message := "📊 *Ваш прогресс (последние 10 записей):*\n\n" + strings.Join(entries, "\n")
+	message := b.tf("progress_history_title", chatID, 10) + "\n\n" + strings.Join(entries, "\n")
 	msg := tgbotapi.NewMessage(chatID, message)
 	msg.ParseMode = "Markdown"
 	b.api.Send(msg)
 }
 
-// handleWeightDynamics показывает динамику веса
+// handleWeightDynamics показывает график веса
 func (b *Bot) handleWeightDynamics(chatID int64) {
-	var clientID int
-	err := b.db.QueryRow("SELECT id FROM public.clients WHERE telegram_id = $1", chatID).Scan(&clientID)
-	if err != nil {
-		b.sendMessage(chatID, b.t("reg_not_registered", chatID))
-		return
-	}
-
-	rows, err := b.db.Query(`
-		SELECT record_date, weight
-		FROM public.client_progress
-		WHERE client_id = $1 AND weight > 0
-		ORDER BY record_date DESC
-		LIMIT 12`, clientID)
-	if err != nil {
-		log.Printf("Ошибка получения динамики веса: %v", err)
-		b.sendMessage(chatID, "Ошибка загрузки данных")
-		return
-	}
-	defer rows.Close()
-
-	type weightEntry struct {
-		date   time.Time
-		weight float64
-	}
-	var entries []weightEntry
-
-	for rows.Next() {
-		var dateStr string
-		var weight float64
-		if err := rows.Scan(&dateStr, &weight); err != nil {
-			continue
-		}
-		date, _ := time.Parse("2006-01-02T15:04:05Z", dateStr)
-		entries = append(entries, weightEntry{date, weight})
-	}
-
-	if len(entries) == 0 {
-		b.sendMessage(chatID, "📈 Недостаточно данных для отображения динамики веса.\n\nЗаписывайте вес регулярно!")
-		return
-	}
-
-	var message strings.Builder
-	message.WriteString("📈 *Динамика веса*\n\n")
-
-	// Показываем график (текстовый)
-	maxWeight := entries[0].weight
-	minWeight := entries[0].weight
-	for _, e := range entries {
-		if e.weight > maxWeight {
-			maxWeight = e.weight
-		}
-		if e.weight < minWeight {
-			minWeight = e.weight
-		}
-	}
-
-	// Переворачиваем для хронологического порядка
-	for i := len(entries) - 1; i >= 0; i-- {
-		e := entries[i]
-		bar := generateWeightBar(e.weight, minWeight, maxWeight)
-		message.WriteString(fmt.Sprintf("%s │%s %.1f кг\n", e.date.Format("02.01"), bar, e.weight))
-	}
-
-	// Статистика
-	if len(entries) >= 2 {
-		first := entries[len(entries)-1].weight
-		last := entries[0].weight
-		diff := last - first
-
-		message.WriteString("\n📊 *Статистика:*\n")
-		message.WriteString(fmt.Sprintf("  Начало: %.1f кг\n", first))
-		message.WriteString(fmt.Sprintf("  Сейчас: %.1f кг\n", last))
-
-		if diff > 0 {
-			message.WriteString(fmt.Sprintf("  Изменение: +%.1f кг ⬆️\n", diff))
-		} else if diff < 0 {
-			message.WriteString(fmt.Sprintf("  Изменение: %.1f кг ⬇️\n", diff))
-		} else {
-			message.WriteString("  Изменение: 0 кг ➡️\n")
-		}
-	}
-
-	msg := tgbotapi.NewMessage(chatID, message.String())
-	msg.ParseMode = "Markdown"
-	b.api.Send(msg)
+	b.handleProgressChart(chatID, chartWeight)
 }
 
-// generateWeightBar генерирует текстовую полоску для графика
-func generateWeightBar(value, min, max float64) string {
-	if max == min {
-		return "████████"
-	}
-	ratio := (value - min) / (max - min)
-	length := int(ratio*10) + 1
-	if length > 10 {
-		length = 10
-	}
-	return strings.Repeat("█", length) + strings.Repeat("░", 10-length)
+// handleMeasurementsDynamics показывает график замеров
+func (b *Bot) handleMeasurementsDynamics(chatID int64) {
+	b.handleProgressChart(chatID, chartMeasurements)
 }
 
-// handleMeasurementsDynamics показывает динамику замеров
-func (b *Bot) handleMeasurementsDynamics(chatID int64) {
-	var clientID int
-	err := b.db.QueryRow("SELECT id FROM public.clients WHERE telegram_id = $1", chatID).Scan(&clientID)
-	if err != nil {
+// handleProgressChart отправляет клиенту его график за период по умолчанию
+func (b *Bot) handleProgressChart(chatID int64, kind string) {
+	clientID, err := b.repo.Program.GetClientByTelegramID(chatID)
+	if err != nil || clientID == 0 {
 		b.sendMessage(chatID, b.t("reg_not_registered", chatID))
 		return
 	}
-
-	// Получаем первую и последнюю записи с замерами
-	var firstDate, lastDate string
-	var firstChest, firstWaist, firstHips, lastChest, lastWaist, lastHips float64
-
-	// Первая запись
-	err = b.db.QueryRow(`
-		SELECT record_date, COALESCE(chest, 0), COALESCE(waist, 0), COALESCE(hips, 0)
-		FROM public.client_progress
-		WHERE client_id = $1 AND (chest > 0 OR waist > 0 OR hips > 0)
-		ORDER BY record_date ASC
-		LIMIT 1`, clientID).Scan(&firstDate, &firstChest, &firstWaist, &firstHips)
-	if err != nil {
-		b.sendMessage(chatID, "📏 Недостаточно данных для отображения динамики замеров.\n\nЗаписывайте замеры регулярно!")
-		return
-	}
-
-	// Последняя запись
-	err = b.db.QueryRow(`
-		SELECT record_date, COALESCE(chest, 0), COALESCE(waist, 0), COALESCE(hips, 0)
-		FROM public.client_progress
-		WHERE client_id = $1 AND (chest > 0 OR waist > 0 OR hips > 0)
-		ORDER BY record_date DESC
-		LIMIT 1`, clientID).Scan(&lastDate, &lastChest, &lastWaist, &lastHips)
-	if err != nil {
-		b.sendMessage(chatID, "Ошибка загрузки данных")
-		return
-	}
-
-	firstTime, _ := time.Parse("2006-01-02T15:04:05Z", firstDate)
-	lastTime, _ := time.Parse("2006-01-02T15:04:05Z", lastDate)
-
-	var message strings.Builder
-	message.WriteString("📏 *Динамика замеров*\n\n")
-	message.WriteString(fmt.Sprintf("📅 Период: %s — %s\n\n", firstTime.Format("02.01.2006"), lastTime.Format("02.01.2006")))
-
-	message.WriteString("```\n")
-	message.WriteString("           Было    Стало   Разница\n")
-	message.WriteString("─────────────────────────────────\n")
-
-	if firstChest > 0 || lastChest > 0 {
-		diff := lastChest - firstChest
-		diffStr := formatDiff(diff)
-		message.WriteString(fmt.Sprintf("Грудь    %5.1f   %5.1f   %s\n", firstChest, lastChest, diffStr))
-	}
-	if firstWaist > 0 || lastWaist > 0 {
-		diff := lastWaist - firstWaist
-		diffStr := formatDiff(diff)
-		message.WriteString(fmt.Sprintf("Талия    %5.1f   %5.1f   %s\n", firstWaist, lastWaist, diffStr))
-	}
-	if firstHips > 0 || lastHips > 0 {
-		diff := lastHips - firstHips
-		diffStr := formatDiff(diff)
-		message.WriteString(fmt.Sprintf("Бёдра    %5.1f   %5.1f   %s\n", firstHips, lastHips, diffStr))
-	}
-	message.WriteString("```")
-
-	msg := tgbotapi.NewMessage(chatID, message.String())
-	msg.ParseMode = "Markdown"
-	b.api.Send(msg)
+	b.sendProgressChart(chatID, clientID, kind, defaultChartRange)
 }
 
 // formatDiff форматирует разницу
@@ -626,23 +508,22 @@ func formatDiff(diff float64) string {
 	return "  0  →"
 }
 
-// formatMeasurements форматирует замеры в строку
-func formatMeasurements(chest, waist, hips, biceps, thigh float64) string {
+// formatMeasurements форматирует замеры в короткую строку: Гр:100/Т:80
+func (b *Bot) formatMeasurements(chatID int64, chest, waist, hips, biceps, thigh float64) string {
 	var parts []string
-	if chest > 0 {
-		parts = append(parts, fmt.Sprintf("Гр:%.0f", chest))
-	}
-	if waist > 0 {
-		parts = append(parts, fmt.Sprintf("Т:%.0f", waist))
-	}
-	if hips > 0 {
-		parts = append(parts, fmt.Sprintf("Б:%.0f", hips))
-	}
-	if biceps > 0 {
-		parts = append(parts, fmt.Sprintf("Би:%.0f", biceps))
-	}
-	if thigh > 0 {
-		parts = append(parts, fmt.Sprintf("Бд:%.0f", thigh))
+	for _, m := range []struct {
+		key   string
+		value float64
+	}{
+		{"progress_short_chest", chest},
+		{"progress_short_waist", waist},
+		{"progress_short_hips", hips},
+		{"progress_short_biceps", biceps},
+		{"progress_short_thigh", thigh},
+	} {
+		if m.value > 0 {
+			parts = append(parts, b.tf(m.key, chatID, m.value))
+		}
 	}
 	return strings.Join(parts, "/")
 }
@@ -661,12 +542,12 @@ func (b *Bot) handleClientProgramProgress(chatID int64) {
 	// Получаем прогресс программы
 	progress, err := b.repo.Program.GetProgramProgress(clientID)
 	if err != nil {
-		b.sendMessage(chatID, "Ошибка загрузки прогресса программы")
+		b.sendMessage(chatID, b.t("program_progress_load_error", chatID))
 		return
 	}
 
 	if progress == nil {
-		b.sendMessage(chatID, "🏋️ У вас пока нет активной программы тренировок.\n\nОбратитесь к тренеру для получения программы!")
+		b.sendMessage(chatID, b.t("program_progress_none", chatID))
 		return
 	}
 
@@ -675,56 +556,56 @@ func (b *Bot) handleClientProgramProgress(chatID int64) {
 
 	// Формируем текст
 	var text strings.Builder
-	text.WriteString("🏋️ *Прогресс программы*\n\n")
+	text.WriteString(b.t("program_progress_title", chatID) + "\n\n")
 	text.WriteString(fmt.Sprintf("📋 *%s*\n", progress.ProgramName))
 	if progress.Goal != "" {
-		text.WriteString(fmt.Sprintf("🎯 Цель: %s\n", progress.Goal))
+		text.WriteString(b.tf("program_progress_goal", chatID, progress.Goal) + "\n")
 	}
 	text.WriteString("\n")
 
 	// Прогресс-бар и проценты
-	text.WriteString(fmt.Sprintf("*Выполнено: %.0f%%*\n", progress.ProgressPercent))
+	text.WriteString(b.tf("program_progress_percent", chatID, progress.ProgressPercent) + "\n")
 	text.WriteString(progressBar)
 	text.WriteString("\n\n")
 
 	// Статистика по неделям
-	text.WriteString(fmt.Sprintf("📅 *Неделя:* %d из %d\n", progress.CurrentWeek, progress.TotalWeeks))
-	text.WriteString(fmt.Sprintf("🗓️ *Тренировок в неделю:* %d\n\n", progress.DaysPerWeek))
+	text.WriteString(b.tf("program_progress_week", chatID, progress.CurrentWeek, progress.TotalWeeks) + "\n")
+	text.WriteString(b.tf("program_progress_days_per_week", chatID, progress.DaysPerWeek) + "\n\n")
 
 	// Статистика по тренировкам
-	text.WriteString("*Статистика тренировок:*\n")
-	text.WriteString(fmt.Sprintf("✅ Выполнено: %d\n", progress.CompletedCount))
+	text.WriteString(b.t("program_progress_stats", chatID) + "\n")
+	text.WriteString(b.tf("program_progress_completed", chatID, progress.CompletedCount) + "\n")
 	if progress.SentCount > 0 {
-		text.WriteString(fmt.Sprintf("📤 Ожидает выполнения: %d\n", progress.SentCount))
+		text.WriteString(b.tf("program_progress_sent", chatID, progress.SentCount) + "\n")
 	}
-	text.WriteString(fmt.Sprintf("⏳ Впереди: %d\n", progress.PendingCount))
+	text.WriteString(b.tf("program_progress_pending", chatID, progress.PendingCount) + "\n")
 	if progress.SkippedCount > 0 {
-		text.WriteString(fmt.Sprintf("⏭️ Пропущено: %d\n", progress.SkippedCount))
+		text.WriteString(b.tf("program_progress_skipped", chatID, progress.SkippedCount) + "\n")
 	}
 
 	// Следующая тренировка
 	if progress.NextWorkout != nil {
-		text.WriteString(fmt.Sprintf("\n📌 *Следующая тренировка:*\n%s (Неделя %d, День %d)\n",
-			progress.NextWorkout.Name, progress.NextWorkout.WeekNum, progress.NextWorkout.DayNum))
+		text.WriteString("\n" + b.tf("program_progress_next", chatID,
+			progress.NextWorkout.Name, progress.NextWorkout.WeekNum, progress.NextWorkout.DayNum) + "\n")
 
 		if progress.NextWorkout.Status == "sent" {
-			text.WriteString("\n💪 Тренировка уже отправлена — напиши /workouts чтобы начать!")
+			text.WriteString("\n" + b.t("program_progress_next_sent", chatID))
 		}
 	} else if progress.PendingCount == 0 && progress.SentCount == 0 {
-		text.WriteString("\n\n🎉 *Поздравляем!*\nВы выполнили все тренировки программы! 🏆")
+		text.WriteString("\n\n" + b.t("program_progress_done", chatID))
 	}
 
 	// Мотивация
 	if progress.ProgressPercent > 0 && progress.ProgressPercent < 100 {
 		text.WriteString("\n\n")
 		if progress.ProgressPercent < 25 {
-			text.WriteString("🚀 Отличное начало! Продолжайте в том же духе!")
+			text.WriteString(b.t("program_progress_motivation_25", chatID))
 		} else if progress.ProgressPercent < 50 {
-			text.WriteString("💪 Вы на правильном пути! Уже почти половина!")
+			text.WriteString(b.t("program_progress_motivation_50", chatID))
 		} else if progress.ProgressPercent < 75 {
-			text.WriteString("🔥 Больше половины позади! Не сдавайтесь!")
+			text.WriteString(b.t("program_progress_motivation_75", chatID))
 		} else {
-			text.WriteString("🏆 Финишная прямая! Ещё немного до цели!")
+			text.WriteString(b.t("program_progress_motivation_100", chatID))
 		}
 	}
 
@@ -740,32 +621,35 @@ func (b *Bot) handleAdminViewClientProgress(chatID int64, clientID int) {
 	var name, surname string
 	err := b.db.QueryRow("SELECT name, surname FROM public.clients WHERE id = $1", clientID).Scan(&name, &surname)
 	if err != nil {
-		b.sendMessage(chatID, "Клиент не найден")
+		b.sendMessage(chatID, b.t("admin_client_not_found", chatID))
 		return
 	}
 
 	rows, err := b.db.Query(`
-		SELECT record_date, weight, chest, waist, hips, biceps, thigh, photo_file_id, notes
-		FROM public.client_progress
-		WHERE client_id = $1
-		ORDER BY record_date DESC
+		SELECT cp.record_date, cp.weight, cp.chest, cp.waist, cp.hips, cp.biceps, cp.thigh, cp.notes,
+		       EXISTS(SELECT 1 FROM public.progress_photos pp
+		              WHERE pp.client_id = cp.client_id AND pp.taken_on = cp.record_date)
+		FROM public.client_progress cp
+		WHERE cp.client_id = $1
+		ORDER BY cp.record_date DESC
 		LIMIT 10`, clientID)
 	if err != nil {
 		log.Printf("Ошибка получения прогресса клиента: %v", err)
-		b.sendMessage(chatID, "Ошибка загрузки прогресса")
+		b.sendMessage(chatID, b.t("progress_load_error", chatID))
 		return
 	}
 	defer rows.Close()
 
 	var entries []string
-	var photos []string
+	hasPhotos := false
 
 	for rows.Next() {
 		var dateStr string
 		var weight, chest, waist, hips, biceps, thigh float64
-		var photoFileID, notes string
+		var notes string
+		var photo bool
 
-		if err := rows.Scan(&dateStr, &weight, &chest, &waist, &hips, &biceps, &thigh, &photoFileID, &notes); err != nil {
+		if err := rows.Scan(&dateStr, &weight, &chest, &waist, &hips, &biceps, &thigh, &notes, &photo); err != nil {
 			continue
 		}
 
@@ -773,42 +657,34 @@ func (b *Bot) handleAdminViewClientProgress(chatID int64, clientID int) {
 		entry := fmt.Sprintf("📅 *%s*\n", date.Format("02.01.2006"))
 
 		if weight > 0 {
-			entry += fmt.Sprintf("  ⚖️ Вес: %.1f кг\n", weight)
+			entry += "  " + b.tf("progress_summary_weight", chatID, weight) + "\n"
 		}
 		if chest > 0 || waist > 0 || hips > 0 {
-			entry += fmt.Sprintf("  📏 %s\n", formatMeasurements(chest, waist, hips, biceps, thigh))
+			entry += fmt.Sprintf("  📏 %s\n", b.formatMeasurements(chatID, chest, waist, hips, biceps, thigh))
 		}
 		if notes != "" {
 			entry += fmt.Sprintf("  📝 %s\n", notes)
 		}
-		if photoFileID != "" {
-			entry += "  📷 Есть фото\n"
-			photos = append(photos, photoFileID)
+		if photo {
+			entry += "  " + b.t("progress_has_photo", chatID) + "\n"
+			hasPhotos = true
 		}
 
 		entries = append(entries, entry)
 	}
 
 	if len(entries) == 0 {
-		b.sendMessage(chatID, fmt.Sprintf("📊 У клиента %s %s пока нет записей прогресса", name, surname))
+		b.sendMessage(chatID, b.tf("progress_client_empty", chatID, name, surname))
 		return
 	}
 
-	message := fmt.Sprintf("📊 *Прогресс клиента %s %s*\n\n", name, surname) + strings.Join(entries, "\n")
+	message := b.tf("progress_client_title", chatID, name, surname) + "\n\n" + strings.Join(entries, "\n")
 	msg := tgbotapi.NewMessage(chatID, message)
 	msg.ParseMode = "Markdown"
 	b.api.Send(msg)
 
-	// Отправляем фотографии (последние 3)
-	if len(photos) > 0 {
-		count := 3
-		if len(photos) < count {
-			count = len(photos)
-		}
-		for i := 0; i < count; i++ {
-			photo := tgbotapi.NewPhoto(chatID, tgbotapi.FileID(photos[i]))
-			photo.Caption = fmt.Sprintf("Фото прогресса #%d", i+1)
-			b.api.Send(photo)
-		}
+	// Фото открываются в галерее — её видят только клиент и тренеры
+	if hasPhotos {
+		b.showPhotoGallery(chatID, clientID, 0, "")
 	}
 }
@@ -0,0 +1,319 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"workbot/internal/training"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Шаги анкеты готовности
+const (
+	readinessSleep  = "sleep"
+	readinessSore   = "sore"
+	readinessStress = "stress"
+	readinessMood   = "mood"
+	readinessHR     = "hr"
+)
+
+// readinessSteps — порядок вопросов анкеты
+var readinessSteps = []string{readinessSleep, readinessSore, readinessStress, readinessMood, readinessHR}
+
+// stateReadinessHR — клиент вводит пульс покоя текстом
+const stateReadinessHR = "readiness_hr"
+
+const (
+	// readinessBaselineDays и readinessBaselineMin — обычный пульс покоя считается
+	// по анкетам за 30 дней, если замеров не меньше трёх
+	readinessBaselineDays = 30
+	readinessBaselineMin  = 3
+	// readinessSleepOptions — варианты сна на кнопках: первая — «5 и меньше», последняя — «9 и больше»
+	readinessSleepMin = 5
+	readinessSleepMax = 9
+)
+
+// nextReadinessStep возвращает шаг после step или "" после последнего
+func nextReadinessStep(step string) string {
+	for i, s := range readinessSteps {
+		if s == step && i+1 < len(readinessSteps) {
+			return readinessSteps[i+1]
+		}
+	}
+	return ""
+}
+
+// askReadiness показывает вопрос анкеты готовности в карточке тренировки
+func (b *Bot) askReadiness(chatID int64, messageID int, step string) {
+	session := getWorkoutSession(chatID)
+	if session == nil {
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(b.t("readiness_title", chatID) + "\n\n")
+	text.WriteString(b.t("readiness_ask_"+step, chatID))
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	switch step {
+	case readinessSleep:
+		var row []tgbotapi.InlineKeyboardButton
+		for h := readinessSleepMin; h <= readinessSleepMax; h++ {
+			label := b.tf("readiness_sleep_hours", chatID, h)
+			switch h {
+			case readinessSleepMin:
+				label = b.tf("readiness_sleep_less", chatID, h)
+			case readinessSleepMax:
+				label = b.tf("readiness_sleep_more", chatID, h)
+			}
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("workout_rd_%s_%d", step, h)))
+		}
+		rows = append(rows, row)
+	case readinessHR:
+		session.MessageID = messageID
+		setWorkoutSession(chatID, session)
+		setState(chatID, stateReadinessHR)
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("skip", chatID), "workout_rd_hr_0")))
+	default:
+		var row []tgbotapi.InlineKeyboardButton
+		for v := 1; v <= 5; v++ {
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(strconv.Itoa(v), fmt.Sprintf("workout_rd_%s_%d", step, v)))
+		}
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("readiness_btn_skip", chatID), "workout_rd_skip")))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	b.editMessage(chatID, messageID, text.String(), &keyboard)
+}
+
+// handleReadinessCallback обрабатывает кнопки анкеты: workout_rd_<шаг>_<значение>,
+// workout_rd_skip, workout_rd_apply, workout_rd_keep
+func (b *Bot) handleReadinessCallback(chatID int64, data string, messageID int) {
+	session := getWorkoutSession(chatID)
+	if session == nil {
+		return
+	}
+
+	switch data {
+	case "skip", "keep":
+		clearState(chatID)
+		b.showCurrentExercise(chatID, messageID)
+		return
+	case "apply":
+		b.applyReadinessAdjustment(chatID, messageID)
+		return
+	}
+
+	parts := strings.Split(data, "_")
+	if len(parts) != 2 {
+		return
+	}
+	step := parts[0]
+	value, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return
+	}
+
+	switch step {
+	case readinessSleep:
+		session.Readiness.SleepHours = float64(value)
+	case readinessSore:
+		session.Readiness.Soreness = value
+	case readinessStress:
+		session.Readiness.Stress = value
+	case readinessMood:
+		session.Readiness.Mood = value
+	case readinessHR:
+		session.Readiness.RestingHR = value
+		clearState(chatID)
+	default:
+		return
+	}
+	setWorkoutSession(chatID, session)
+
+	if next := nextReadinessStep(step); next != "" {
+		b.askReadiness(chatID, messageID, next)
+		return
+	}
+	b.finishReadiness(chatID, messageID)
+}
+
+// handleReadinessHRInput принимает пульс покоя, введённый текстом
+func (b *Bot) handleReadinessHRInput(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	session := getWorkoutSession(chatID)
+	if session == nil {
+		clearState(chatID)
+		return
+	}
+
+	hr, err := strconv.Atoi(strings.TrimSpace(message.Text))
+	if err != nil || hr < 30 || hr > 120 {
+		b.sendMessage(chatID, b.t("readiness_invalid_hr", chatID))
+		return
+	}
+	clearState(chatID)
+
+	session.Readiness.RestingHR = hr
+	setWorkoutSession(chatID, session)
+
+	// Убираем кнопки у вопроса, результат — новым сообщением под ответом
+	if session.MessageID > 0 {
+		b.editMessage(chatID, session.MessageID, b.tf("readiness_hr_saved", chatID, hr), nil)
+	}
+	b.finishReadiness(chatID, 0)
+}
+
+// finishReadiness считает готовность, сохраняет анкету и предлагает корректировку.
+// messageID == 0 — результат отправляется новым сообщением
+func (b *Bot) finishReadiness(chatID int64, messageID int) {
+	session := getWorkoutSession(chatID)
+	if session == nil {
+		return
+	}
+
+	clientID, err := b.repo.Program.GetClientIDByWorkout(session.WorkoutID)
+	if err != nil {
+		log.Printf("Ошибка получения клиента тренировки %d: %v", session.WorkoutID, err)
+	}
+	if clientID > 0 && session.Readiness.RestingHR > 0 {
+		session.Readiness.BaselineHR = b.restingHRBaseline(clientID)
+	}
+
+	score := training.ReadinessScore(session.Readiness)
+	adj := training.AdjustmentFor(score)
+	if clientID > 0 {
+		if id, err := b.saveReadiness(clientID, session.WorkoutID, session.Readiness, score, adj.Zone); err != nil {
+			log.Printf("Ошибка сохранения анкеты готовности: %v", err)
+		} else {
+			session.ReadinessID = id
+		}
+	}
+	setWorkoutSession(chatID, session)
+
+	var text strings.Builder
+	text.WriteString(b.tf("readiness_score", chatID, score) + "\n")
+	text.WriteString(b.t("readiness_zone_"+string(adj.Zone), chatID))
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if adj.Changes() {
+		text.WriteString("\n\n" + b.formatReadinessAdjustment(chatID, adj))
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("readiness_btn_apply", chatID), "workout_rd_apply"),
+			tgbotapi.NewInlineKeyboardButtonData(b.t("readiness_btn_keep", chatID), "workout_rd_keep"),
+		))
+	} else {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("readiness_btn_start", chatID), "workout_rd_keep")))
+	}
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	if messageID > 0 {
+		b.editMessage(chatID, messageID, text.String(), &keyboard)
+		return
+	}
+	msg := tgbotapi.NewMessage(chatID, text.String())
+	msg.ParseMode = "Markdown"
+	msg.ReplyMarkup = keyboard
+	b.api.Send(msg)
+}
+
+// formatReadinessAdjustment описывает предлагаемое снижение нагрузки
+func (b *Bot) formatReadinessAdjustment(chatID int64, adj training.LoadAdjustment) string {
+	percent := int((1-adj.IntensityFactor)*100 + 0.5)
+	if adj.SetsDelta != 0 {
+		return b.tf("readiness_offer_sets", chatID, percent, -adj.SetsDelta)
+	}
+	return b.tf("readiness_offer", chatID, percent)
+}
+
+// applyReadinessAdjustment снижает вес и подходы невыполненных упражнений тренировки
+func (b *Bot) applyReadinessAdjustment(chatID int64, messageID int) {
+	session := getWorkoutSession(chatID)
+	if session == nil {
+		return
+	}
+
+	adj := training.AdjustmentFor(training.ReadinessScore(session.Readiness))
+	for i := range session.Exercises {
+		e := &session.Exercises[i]
+		if e.Completed {
+			continue
+		}
+		weight, sets := adj.Apply(e.Weight, e.Sets)
+		percent := e.WeightPercent * adj.IntensityFactor
+		if err := b.repo.Program.AdjustExerciseLoad(e.ID, sets, weight, percent); err != nil {
+			log.Printf("Ошибка корректировки упражнения %d: %v", e.ID, err)
+			continue
+		}
+		e.Weight, e.Sets, e.WeightPercent = weight, sets, percent
+	}
+	setWorkoutSession(chatID, session)
+
+	if session.ReadinessID > 0 {
+		if _, err := b.db.Exec("UPDATE public.readiness_checkins SET adjusted = true WHERE id = $1", session.ReadinessID); err != nil {
+			log.Printf("Ошибка отметки корректировки готовности: %v", err)
+		}
+	}
+
+	b.showCurrentExercise(chatID, messageID)
+}
+
+// restingHRBaseline — средний пульс покоя клиента за 30 дней или 0, если замеров мало
+func (b *Bot) restingHRBaseline(clientID int) float64 {
+	var avg float64
+	var count int
+	err := b.db.QueryRow(`
+		SELECT COALESCE(AVG(resting_hr), 0), COUNT(resting_hr)
+		FROM public.readiness_checkins
+		WHERE client_id = $1 AND resting_hr IS NOT NULL AND created_at >= $2`,
+		clientID, time.Now().AddDate(0, 0, -readinessBaselineDays)).Scan(&avg, &count)
+	if err != nil {
+		log.Printf("Ошибка расчёта пульса покоя клиента %d: %v", clientID, err)
+		return 0
+	}
+	if count < readinessBaselineMin {
+		return 0
+	}
+	return avg
+}
+
+// saveReadiness сохраняет анкету готовности и возвращает её ID
+func (b *Bot) saveReadiness(clientID, workoutID int, in training.ReadinessInput, score int, zone training.ReadinessZone) (int, error) {
+	nullInt := func(v int) interface{} {
+		if v <= 0 {
+			return nil
+		}
+		return v
+	}
+	var sleep interface{}
+	if in.SleepHours > 0 {
+		sleep = in.SleepHours
+	}
+
+	var id int
+	err := b.db.QueryRow(`
+		INSERT INTO public.readiness_checkins
+		(client_id, workout_id, sleep_hours, soreness, stress, mood, resting_hr, score, zone)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`,
+		clientID, workoutID, sleep, nullInt(in.Soreness), nullInt(in.Stress), nullInt(in.Mood),
+		nullInt(in.RestingHR), score, string(zone)).Scan(&id)
+	return id, err
+}
+
+// workoutReadiness возвращает последнюю анкету готовности тренировки; ok == false — анкеты не было
+func (b *Bot) workoutReadiness(workoutID int) (score int, zone string, adjusted bool, ok bool) {
+	err := b.db.QueryRow(`
+		SELECT score, zone, adjusted FROM public.readiness_checkins
+		WHERE workout_id = $1
+		ORDER BY created_at DESC LIMIT 1`, workoutID).Scan(&score, &zone, &adjusted)
+	return score, zone, adjusted, err == nil
+}
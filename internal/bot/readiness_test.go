@@ -0,0 +1,16 @@
+package bot
+
+import "testing"
+
+func TestNextReadinessStep(t *testing.T) {
+	var got []string
+	for step := readinessSleep; step != ""; step = nextReadinessStep(step) {
+		got = append(got, step)
+	}
+	if len(got) != len(readinessSteps) {
+		t.Fatalf("шаги анкеты = %v, хотим %v", got, readinessSteps)
+	}
+	if next := nextReadinessStep("unknown"); next != "" {
+		t.Errorf("nextReadinessStep(unknown) = %q, хотим пустую строку", next)
+	}
+}
@@ -1,7 +1,6 @@
 package bot
 
 import (
-	"fmt"
 	"log"
 	"regexp"
 	"strings"
@@ -10,6 +9,7 @@ import (
 	"unicode"
 
 	"workbot/internal/excel"
+	"workbot/internal/i18n"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -25,16 +25,16 @@ var (
 func validateName(name string) (string, error) {
 	name = strings.TrimSpace(name)
 	if len(name) < 2 {
-		return "", fmt.Errorf("имя должно содержать минимум 2 символа")
+		return "", localizedError("validation_name_min")
 	}
 	if len(name) > 50 {
-		return "", fmt.Errorf("имя слишком длинное (максимум 50 символов)")
+		return "", localizedError("validation_name_max")
 	}
 
 	// Проверяем что имя содержит только буквы, дефис и пробелы
 	for _, r := range name {
 		if !unicode.IsLetter(r) && r != '-' && r != ' ' {
-			return "", fmt.Errorf("имя должно содержать только буквы")
+			return "", localizedError("validation_name_letters")
 		}
 	}
 
@@ -60,10 +60,10 @@ func validatePhone(phone string) (string, error) {
 	}, phone)
 
 	if len(cleaned) < 7 {
-		return "", fmt.Errorf("номер телефона слишком короткий")
+		return "", localizedError("validation_phone_short")
 	}
 	if len(cleaned) > 15 {
-		return "", fmt.Errorf("номер телефона слишком длинный")
+		return "", localizedError("validation_phone_long")
 	}
 
 	// Проверяем что есть хотя бы 7 цифр
@@ -74,7 +74,7 @@ func validatePhone(phone string) (string, error) {
 		}
 	}
 	if digitCount < 7 {
-		return "", fmt.Errorf("номер телефона должен содержать минимум 7 цифр")
+		return "", localizedError("validation_phone_digits")
 	}
 
 	return phone, nil
@@ -85,27 +85,27 @@ func validateBirthDate(date string) (string, error) {
 	date = strings.TrimSpace(date)
 
 	if !dateRegex.MatchString(date) {
-		return "", fmt.Errorf("введите дату в формате ДД.ММ.ГГГГ (например: 15.03.1990)")
+		return "", localizedError("validation_date_format")
 	}
 
 	// Парсим дату
 	parsedDate, err := time.Parse("02.01.2006", date)
 	if err != nil {
-		return "", fmt.Errorf("некорректная дата, используйте формат ДД.ММ.ГГГГ")
+		return "", localizedError("validation_date_invalid")
 	}
 
 	// Проверяем что дата не в будущем
 	if parsedDate.After(time.Now()) {
-		return "", fmt.Errorf("дата рождения не может быть в будущем")
+		return "", localizedError("validation_date_future")
 	}
 
 	// Проверяем что возраст разумный (от 5 до 120 лет)
 	age := time.Now().Year() - parsedDate.Year()
 	if age < 5 {
-		return "", fmt.Errorf("возраст должен быть не менее 5 лет")
+		return "", localizedError("validation_age_min")
 	}
 	if age > 120 {
-		return "", fmt.Errorf("проверьте год рождения")
+		return "", localizedError("validation_age_max")
 	}
 
 	return date, nil
@@ -203,7 +203,7 @@ func (b *Bot) processRegistration(message *tgbotapi.Message, state string) {
 	text := message.Text
 
 	// Проверяем отмену на обоих языках
-	if text == "Отмена" || text == "Cancel" {
+	if i18n.Is(text, "cancel") {
 		b.cancelRegistration(chatID)
 		return
 	}
@@ -221,7 +221,7 @@ func (b *Bot) processRegistration(message *tgbotapi.Message, state string) {
 		validatedName, err := validateName(text)
 		if err != nil {
 			registrationStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, "❌ "+b.t("validation_name_letters", chatID)+"\n\n"+b.t("reg_enter_name", chatID))
+			msg := tgbotapi.NewMessage(chatID, "❌ "+b.errorText(err, chatID)+"\n\n"+b.t("reg_enter_name", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -240,7 +240,7 @@ func (b *Bot) processRegistration(message *tgbotapi.Message, state string) {
 		validatedSurname, err := validateName(text)
 		if err != nil {
 			registrationStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, "❌ "+b.t("validation_name_letters", chatID)+"\n\n"+b.t("reg_enter_surname", chatID))
+			msg := tgbotapi.NewMessage(chatID, "❌ "+b.errorText(err, chatID)+"\n\n"+b.t("reg_enter_surname", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -259,7 +259,7 @@ func (b *Bot) processRegistration(message *tgbotapi.Message, state string) {
 		validatedPhone, err := validatePhone(text)
 		if err != nil {
 			registrationStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, "❌ "+b.t("validation_phone_digits", chatID)+"\n\n"+b.t("reg_enter_phone", chatID))
+			msg := tgbotapi.NewMessage(chatID, "❌ "+b.errorText(err, chatID)+"\n\n"+b.t("reg_enter_phone", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -278,7 +278,7 @@ func (b *Bot) processRegistration(message *tgbotapi.Message, state string) {
 		validatedDate, err := validateBirthDate(text)
 		if err != nil {
 			registrationStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, "❌ "+b.t("validation_date_format", chatID))
+			msg := tgbotapi.NewMessage(chatID, "❌ "+b.errorText(err, chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -342,6 +342,7 @@ func (b *Bot) completeRegistration(chatID int64, name, surname, phone, birthDate
 	successMsg := tgbotapi.NewMessage(chatID, b.tf("reg_success", chatID, clientID, name, surname, phone, birthDate))
 	b.api.Send(successMsg)
 	b.restoreMainMenu(chatID)
+	b.showTimezonePicker(chatID, 0, b.t("tz_select", chatID))
 }
 
 // cancelRegistration отменяет регистрацию
@@ -373,10 +374,10 @@ func (b *Bot) startAddClient(message *tgbotapi.Message) {
 	userStates.states[chatID] = stateAddClientName
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Добавление нового клиента\n\nВведите имя:")
+	msg := tgbotapi.NewMessage(chatID, b.t("admin_add_client_title", chatID)+"\n\n"+b.t("admin_enter_name", chatID))
 	msg.ReplyMarkup = tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 	b.api.Send(msg)
@@ -387,7 +388,7 @@ func (b *Bot) processAddClient(message *tgbotapi.Message, state string) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.cancelAddClient(chatID, message)
 		return
 	}
@@ -405,7 +406,7 @@ func (b *Bot) processAddClient(message *tgbotapi.Message, state string) {
 		validatedName, err := validateName(text)
 		if err != nil {
 			addClientStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ %s\n\nВведите имя:", err.Error()))
+			msg := tgbotapi.NewMessage(chatID, "❌ "+b.errorText(err, chatID)+"\n\n"+b.t("admin_enter_name", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -417,14 +418,14 @@ func (b *Bot) processAddClient(message *tgbotapi.Message, state string) {
 		userStates.states[chatID] = stateAddClientSurname
 		userStates.Unlock()
 
-		msg := tgbotapi.NewMessage(chatID, "Введите фамилию:")
+		msg := tgbotapi.NewMessage(chatID, b.t("admin_enter_surname", chatID))
 		b.api.Send(msg)
 
 	case stateAddClientSurname:
 		validatedSurname, err := validateName(text)
 		if err != nil {
 			addClientStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ %s\n\nВведите фамилию:", err.Error()))
+			msg := tgbotapi.NewMessage(chatID, "❌ "+b.errorText(err, chatID)+"\n\n"+b.t("admin_enter_surname", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -436,14 +437,14 @@ func (b *Bot) processAddClient(message *tgbotapi.Message, state string) {
 		userStates.states[chatID] = stateAddClientPhone
 		userStates.Unlock()
 
-		msg := tgbotapi.NewMessage(chatID, "Введите номер телефона:")
+		msg := tgbotapi.NewMessage(chatID, b.t("admin_enter_phone", chatID))
 		b.api.Send(msg)
 
 	case stateAddClientPhone:
 		validatedPhone, err := validatePhone(text)
 		if err != nil {
 			addClientStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ %s\n\nВведите номер телефона:", err.Error()))
+			msg := tgbotapi.NewMessage(chatID, "❌ "+b.errorText(err, chatID)+"\n\n"+b.t("admin_enter_phone", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -455,14 +456,14 @@ func (b *Bot) processAddClient(message *tgbotapi.Message, state string) {
 		userStates.states[chatID] = stateAddClientBirthDate
 		userStates.Unlock()
 
-		msg := tgbotapi.NewMessage(chatID, "Введите дату рождения (ДД.ММ.ГГГГ):")
+		msg := tgbotapi.NewMessage(chatID, b.t("reg_enter_birthdate", chatID))
 		b.api.Send(msg)
 
 	case stateAddClientBirthDate:
 		validatedDate, err := validateBirthDate(text)
 		if err != nil {
 			addClientStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ %s", err.Error()))
+			msg := tgbotapi.NewMessage(chatID, "❌ "+b.errorText(err, chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -497,7 +498,7 @@ func (b *Bot) completeAddClient(chatID int64, name, surname, phone, birthDate st
 	).Scan(&clientID)
 	if err != nil {
 		log.Println("Ошибка сохранения клиента:", err)
-		errMsg := tgbotapi.NewMessage(chatID, "Ошибка при добавлении клиента.")
+		errMsg := tgbotapi.NewMessage(chatID, b.t("admin_add_client_error", chatID))
 		b.api.Send(errMsg)
 		b.handleAdminStart(message)
 		return
@@ -519,14 +520,12 @@ func (b *Bot) completeAddClient(chatID int64, name, surname, phone, birthDate st
 			if err != nil {
 				log.Printf("Ошибка сохранения google_sheet_id: %v", err)
 			}
-			sheetURL = fmt.Sprintf("\n\nGoogle таблица: https://docs.google.com/spreadsheets/d/%s", sheetID)
+			sheetURL = "\n\n" + b.tf("admin_client_sheet", chatID, sheetID)
 		}
 	}
 
-	successMsg := tgbotapi.NewMessage(chatID, fmt.Sprintf(
-		"Клиент добавлен!\n\nID: %d\nИмя: %s %s\nТелефон: %s\nДата рождения: %s%s",
-		clientID, name, surname, phone, birthDate, sheetURL,
-	))
+	successMsg := tgbotapi.NewMessage(chatID, b.tf("admin_add_client_success", chatID,
+		clientID, name, surname, phone, birthDate)+sheetURL)
 	b.api.Send(successMsg)
 	b.handleAdminStart(message)
 }
@@ -541,7 +540,7 @@ func (b *Bot) cancelAddClient(chatID int64, message *tgbotapi.Message) {
 	delete(userStates.states, chatID)
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Отменено")
+	msg := tgbotapi.NewMessage(chatID, b.t("cancelled", chatID))
 	b.api.Send(msg)
 	b.handleAdminStart(message)
 }
@@ -5,8 +5,10 @@ import (
 	"log"
 	"strings"
 	"sync"
+	"time"
 
 	"workbot/internal/calendar"
+	"workbot/internal/i18n"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -42,21 +44,21 @@ const (
 func (b *Bot) handleScheduleMenu(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 
-	msg := tgbotapi.NewMessage(chatID, "Управление расписанием:")
+	msg := tgbotapi.NewMessage(chatID, b.t("schedule_menu_title", chatID))
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Добавить слот"),
-			tgbotapi.NewKeyboardButton("Мое расписание"),
+			tgbotapi.NewKeyboardButton(b.t("admin_schedule_add_slot", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("admin_schedule_my", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Удалить слот"),
-			tgbotapi.NewKeyboardButton("Записи клиентов"),
+			tgbotapi.NewKeyboardButton(b.t("admin_schedule_delete_slot", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("admin_schedule_appointments", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Управление записями"),
+			tgbotapi.NewKeyboardButton(b.t("admin_schedule_manage", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Назад"),
+			tgbotapi.NewKeyboardButton(b.t("back", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -77,26 +79,26 @@ func (b *Bot) handleAddScheduleSlot(message *tgbotapi.Message) {
 
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Понедельник"),
-			tgbotapi.NewKeyboardButton("Вторник"),
+			tgbotapi.NewKeyboardButton(b.getDayName(1, chatID)),
+			tgbotapi.NewKeyboardButton(b.getDayName(2, chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Среда"),
-			tgbotapi.NewKeyboardButton("Четверг"),
+			tgbotapi.NewKeyboardButton(b.getDayName(3, chatID)),
+			tgbotapi.NewKeyboardButton(b.getDayName(4, chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Пятница"),
-			tgbotapi.NewKeyboardButton("Суббота"),
+			tgbotapi.NewKeyboardButton(b.getDayName(5, chatID)),
+			tgbotapi.NewKeyboardButton(b.getDayName(6, chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Воскресенье"),
+			tgbotapi.NewKeyboardButton(b.getDayName(0, chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отмена"),
+			tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 		),
 	)
 
-	msg := tgbotapi.NewMessage(chatID, "Выберите день недели:")
+	msg := tgbotapi.NewMessage(chatID, b.t("schedule_select_day", chatID))
 	msg.ReplyMarkup = keyboard
 	b.api.Send(msg)
 }
@@ -106,7 +108,7 @@ func (b *Bot) processSchedule(message *tgbotapi.Message, state string) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.cancelSchedule(chatID)
 		return
 	}
@@ -124,7 +126,7 @@ func (b *Bot) processSchedule(message *tgbotapi.Message, state string) {
 		dayNum := parseDayOfWeek(text)
 		if dayNum < 0 {
 			scheduleStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, "Выберите день из списка")
+			msg := tgbotapi.NewMessage(chatID, b.t("schedule_select_day", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -136,14 +138,14 @@ func (b *Bot) processSchedule(message *tgbotapi.Message, state string) {
 		userStates.states[chatID] = stateScheduleStart
 		userStates.Unlock()
 
-		msg := tgbotapi.NewMessage(chatID, "Введите время начала работы (например, 09:00):")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_enter_start", chatID))
 		msg.ReplyMarkup = tgbotapi.NewReplyKeyboard(
 			tgbotapi.NewKeyboardButtonRow(
 				tgbotapi.NewKeyboardButton("09:00"),
 				tgbotapi.NewKeyboardButton("10:00"),
 			),
 			tgbotapi.NewKeyboardButtonRow(
-				tgbotapi.NewKeyboardButton("Отмена"),
+				tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 			),
 		)
 		b.api.Send(msg)
@@ -152,7 +154,7 @@ func (b *Bot) processSchedule(message *tgbotapi.Message, state string) {
 		_, _, err := calendar.ParseTime(text)
 		if err != nil {
 			scheduleStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, "Неверный формат. Введите время как ЧЧ:ММ")
+			msg := tgbotapi.NewMessage(chatID, b.t("schedule_invalid_time", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -164,14 +166,14 @@ func (b *Bot) processSchedule(message *tgbotapi.Message, state string) {
 		userStates.states[chatID] = stateScheduleEnd
 		userStates.Unlock()
 
-		msg := tgbotapi.NewMessage(chatID, "Введите время окончания работы (например, 21:00):")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_enter_end", chatID))
 		msg.ReplyMarkup = tgbotapi.NewReplyKeyboard(
 			tgbotapi.NewKeyboardButtonRow(
 				tgbotapi.NewKeyboardButton("20:00"),
 				tgbotapi.NewKeyboardButton("21:00"),
 			),
 			tgbotapi.NewKeyboardButtonRow(
-				tgbotapi.NewKeyboardButton("Отмена"),
+				tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 			),
 		)
 		b.api.Send(msg)
@@ -180,7 +182,7 @@ func (b *Bot) processSchedule(message *tgbotapi.Message, state string) {
 		_, _, err := calendar.ParseTime(text)
 		if err != nil {
 			scheduleStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, "Неверный формат. Введите время как ЧЧ:ММ")
+			msg := tgbotapi.NewMessage(chatID, b.t("schedule_invalid_time", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -192,14 +194,14 @@ func (b *Bot) processSchedule(message *tgbotapi.Message, state string) {
 		userStates.states[chatID] = stateScheduleDuration
 		userStates.Unlock()
 
-		msg := tgbotapi.NewMessage(chatID, "Выберите длительность тренировки (в минутах):")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_select_duration", chatID))
 		msg.ReplyMarkup = tgbotapi.NewReplyKeyboard(
 			tgbotapi.NewKeyboardButtonRow(
 				tgbotapi.NewKeyboardButton("60"),
 				tgbotapi.NewKeyboardButton("90"),
 			),
 			tgbotapi.NewKeyboardButtonRow(
-				tgbotapi.NewKeyboardButton("Отмена"),
+				tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 			),
 		)
 		b.api.Send(msg)
@@ -209,7 +211,7 @@ func (b *Bot) processSchedule(message *tgbotapi.Message, state string) {
 		_, err := fmt.Sscanf(text, "%d", &duration)
 		if err != nil || duration < 30 || duration > 180 {
 			scheduleStore.Unlock()
-			msg := tgbotapi.NewMessage(chatID, "Введите число от 30 до 180")
+			msg := tgbotapi.NewMessage(chatID, b.t("schedule_invalid_duration", chatID))
 			b.api.Send(msg)
 			return
 		}
@@ -243,12 +245,11 @@ func (b *Bot) saveScheduleSlot(chatID int64, dayOfWeek int, startTime, endTime s
 
 	if err != nil {
 		log.Printf("Ошибка сохранения расписания: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка сохранения расписания.")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_save_error", chatID))
 		b.api.Send(msg)
 	} else {
-		dayName := getDayName(dayOfWeek)
-		msg := tgbotapi.NewMessage(chatID, fmt.Sprintf(
-			"Расписание сохранено!\n\n%s: %s - %s\nДлительность тренировки: %d мин",
+		dayName := b.getDayName(dayOfWeek, chatID)
+		msg := tgbotapi.NewMessage(chatID, b.tf("schedule_saved", chatID,
 			dayName, startTime, endTime, duration))
 		b.api.Send(msg)
 	}
@@ -267,7 +268,7 @@ func (b *Bot) handleShowSchedule(message *tgbotapi.Message) {
 		ORDER BY day_of_week, start_time`, chatID)
 	if err != nil {
 		log.Printf("Ошибка получения расписания: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка загрузки расписания.")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_load_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -280,18 +281,18 @@ func (b *Bot) handleShowSchedule(message *tgbotapi.Message) {
 		if err := rows.Scan(&dayOfWeek, &startTime, &endTime, &duration); err != nil {
 			continue
 		}
-		dayName := getDayName(dayOfWeek)
-		schedule = append(schedule, fmt.Sprintf("%s: %s - %s (%d мин)",
+		dayName := b.getDayName(dayOfWeek, chatID)
+		schedule = append(schedule, b.tf("schedule_item", chatID,
 			dayName, startTime, endTime, duration))
 	}
 
 	if len(schedule) == 0 {
-		msg := tgbotapi.NewMessage(chatID, "Расписание не настроено. Нажмите 'Добавить слот'.")
+		msg := tgbotapi.NewMessage(chatID, b.tf("schedule_empty", chatID, b.t("admin_schedule_add_slot", chatID)))
 		b.api.Send(msg)
 		return
 	}
 
-	msg := tgbotapi.NewMessage(chatID, "Ваше расписание:\n\n"+strings.Join(schedule, "\n"))
+	msg := tgbotapi.NewMessage(chatID, b.t("schedule_title", chatID)+"\n\n"+strings.Join(schedule, "\n"))
 	b.api.Send(msg)
 }
 
@@ -308,7 +309,7 @@ func (b *Bot) handleTrainerAppointments(message *tgbotapi.Message) {
 		LIMIT 20`, chatID)
 	if err != nil {
 		log.Printf("Ошибка получения записей: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка загрузки записей.")
+		msg := tgbotapi.NewMessage(chatID, b.t("error_try_later", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -322,19 +323,18 @@ func (b *Bot) handleTrainerAppointments(message *tgbotapi.Message) {
 			continue
 		}
 
-		statusText := getStatusText(status)
-		appointments = append(appointments, fmt.Sprintf(
-			"#%d: %s %s\n    %s в %s (%s)",
+		statusText := b.getStatusTextLocalized(status, chatID)
+		appointments = append(appointments, b.tf("schedule_appointment_item", chatID,
 			id, name, surname, dateStr[:10], timeStr, statusText))
 	}
 
 	if len(appointments) == 0 {
-		msg := tgbotapi.NewMessage(chatID, "Нет предстоящих записей.")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_no_appointments", chatID))
 		b.api.Send(msg)
 		return
 	}
 
-	msg := tgbotapi.NewMessage(chatID, "Записи клиентов:\n\n"+strings.Join(appointments, "\n\n"))
+	msg := tgbotapi.NewMessage(chatID, b.t("schedule_appointments_title", chatID)+"\n\n"+strings.Join(appointments, "\n\n"))
 	b.api.Send(msg)
 }
 
@@ -348,33 +348,32 @@ func (b *Bot) cancelSchedule(chatID int64) {
 	delete(userStates.states, chatID)
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Настройка отменена.")
+	msg := tgbotapi.NewMessage(chatID, b.t("cancelled", chatID))
 	b.api.Send(msg)
 	b.handleScheduleMenu(&tgbotapi.Message{Chat: &tgbotapi.Chat{ID: chatID}})
 }
 
 // Вспомогательные функции
 
+// weekdayButtonKeys — названия дней недели для кнопок, индекс совпадает с time.Weekday
+var weekdayButtonKeys = []string{
+	"weekday_btn_sun", "weekday_btn_mon", "weekday_btn_tue",
+	"weekday_btn_wed", "weekday_btn_thu", "weekday_btn_fri", "weekday_btn_sat",
+}
+
 func parseDayOfWeek(text string) int {
-	days := map[string]int{
-		"Воскресенье": 0,
-		"Понедельник": 1,
-		"Вторник":     2,
-		"Среда":       3,
-		"Четверг":     4,
-		"Пятница":     5,
-		"Суббота":     6,
-	}
-	if day, ok := days[text]; ok {
-		return day
+	key := i18n.Match(text, weekdayButtonKeys...)
+	for day, k := range weekdayButtonKeys {
+		if k == key {
+			return day
+		}
 	}
 	return -1
 }
 
-func getDayName(day int) string {
-	days := []string{"Воскресенье", "Понедельник", "Вторник", "Среда", "Четверг", "Пятница", "Суббота"}
-	if day >= 0 && day < len(days) {
-		return days[day]
+func (b *Bot) getDayName(day int, chatID int64) string {
+	if day >= 0 && day < len(weekdayButtonKeys) {
+		return b.t(weekdayButtonKeys[day], chatID)
 	}
 	return ""
 }
@@ -391,7 +390,7 @@ func (b *Bot) handleDeleteScheduleSlot(message *tgbotapi.Message) {
 		ORDER BY day_of_week, start_time`, chatID)
 	if err != nil {
 		log.Printf("Ошибка получения расписания: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка загрузки расписания.")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_load_error", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -404,7 +403,7 @@ func (b *Bot) handleDeleteScheduleSlot(message *tgbotapi.Message) {
 		if err := rows.Scan(&id, &dayOfWeek, &startTime, &endTime, &duration); err != nil {
 			continue
 		}
-		dayName := getDayName(dayOfWeek)
+		dayName := b.getDayName(dayOfWeek, chatID)
 		buttonText := fmt.Sprintf("DEL>> %s %s-%s [%d]", dayName, startTime, endTime, id)
 		buttons = append(buttons, tgbotapi.NewKeyboardButtonRow(
 			tgbotapi.NewKeyboardButton(buttonText),
@@ -412,21 +411,21 @@ func (b *Bot) handleDeleteScheduleSlot(message *tgbotapi.Message) {
 	}
 
 	if len(buttons) == 0 {
-		msg := tgbotapi.NewMessage(chatID, "Нет слотов для удаления.")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_no_slots", chatID))
 		b.api.Send(msg)
 		b.handleScheduleMenu(message)
 		return
 	}
 
 	buttons = append(buttons, tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton("Отмена"),
+		tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 	))
 
 	userStates.Lock()
 	userStates.states[chatID] = stateScheduleDeleteSelect
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Выберите слот для удаления:")
+	msg := tgbotapi.NewMessage(chatID, b.t("schedule_select_slot", chatID))
 	msg.ReplyMarkup = tgbotapi.NewReplyKeyboard(buttons...)
 	b.api.Send(msg)
 }
@@ -436,7 +435,7 @@ func (b *Bot) handleDeleteSlotSelection(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		b.cancelSchedule(chatID)
 		return
 	}
@@ -445,7 +444,7 @@ func (b *Bot) handleDeleteSlotSelection(message *tgbotapi.Message) {
 	start := strings.LastIndex(text, "[")
 	end := strings.LastIndex(text, "]")
 	if start == -1 || end == -1 || start >= end {
-		msg := tgbotapi.NewMessage(chatID, "Ошибка выбора слота")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_select_slot", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -453,7 +452,7 @@ func (b *Bot) handleDeleteSlotSelection(message *tgbotapi.Message) {
 	var slotID int
 	_, err := fmt.Sscanf(text[start+1:end], "%d", &slotID)
 	if err != nil {
-		msg := tgbotapi.NewMessage(chatID, "Ошибка выбора слота")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_select_slot", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -463,10 +462,10 @@ func (b *Bot) handleDeleteSlotSelection(message *tgbotapi.Message) {
 		slotID, chatID)
 	if err != nil {
 		log.Printf("Ошибка удаления слота: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка удаления слота.")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_slot_delete_error", chatID))
 		b.api.Send(msg)
 	} else {
-		msg := tgbotapi.NewMessage(chatID, "Слот удалён из расписания.")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_slot_deleted", chatID))
 		b.api.Send(msg)
 	}
 
@@ -490,7 +489,7 @@ func (b *Bot) handleManageAppointments(message *tgbotapi.Message) {
 		LIMIT 15`, chatID)
 	if err != nil {
 		log.Printf("Ошибка получения записей: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка загрузки записей.")
+		msg := tgbotapi.NewMessage(chatID, b.t("error_try_later", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -512,21 +511,21 @@ func (b *Bot) handleManageAppointments(message *tgbotapi.Message) {
 	}
 
 	if len(buttons) == 0 {
-		msg := tgbotapi.NewMessage(chatID, "Нет активных записей для управления.")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_no_appointments", chatID))
 		b.api.Send(msg)
 		b.handleScheduleMenu(message)
 		return
 	}
 
 	buttons = append(buttons, tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton("Отмена"),
+		tgbotapi.NewKeyboardButton(b.t("cancel", chatID)),
 	))
 
 	userStates.Lock()
 	userStates.states[chatID] = stateAppointmentManage
 	userStates.Unlock()
 
-	msg := tgbotapi.NewMessage(chatID, "Выберите запись для управления:")
+	msg := tgbotapi.NewMessage(chatID, b.t("schedule_select_appointment", chatID))
 	msg.ReplyMarkup = tgbotapi.NewReplyKeyboard(buttons...)
 	b.api.Send(msg)
 }
@@ -542,7 +541,7 @@ func (b *Bot) handleAppointmentSelection(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	if text == "Отмена" {
+	if i18n.Is(text, "cancel") {
 		userStates.Lock()
 		delete(userStates.states, chatID)
 		userStates.Unlock()
@@ -554,7 +553,7 @@ func (b *Bot) handleAppointmentSelection(message *tgbotapi.Message) {
 	start := strings.LastIndex(text, "[")
 	end := strings.LastIndex(text, "]")
 	if start == -1 || end == -1 || start >= end {
-		msg := tgbotapi.NewMessage(chatID, "Ошибка выбора записи")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_select_appointment", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -562,7 +561,7 @@ func (b *Bot) handleAppointmentSelection(message *tgbotapi.Message) {
 	var appointmentID int
 	_, err := fmt.Sscanf(text[start+1:end], "%d", &appointmentID)
 	if err != nil {
-		msg := tgbotapi.NewMessage(chatID, "Ошибка выбора записи")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_select_appointment", chatID))
 		b.api.Send(msg)
 		return
 	}
@@ -581,20 +580,14 @@ func (b *Bot) handleAppointmentSelection(message *tgbotapi.Message) {
 		WHERE a.id = $1`, appointmentID).Scan(&clientName, &clientSurname, &dateStr, &timeStr, &status)
 	if err != nil {
 		log.Printf("Ошибка получения записи: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Запись не найдена.")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_appointment_not_found", chatID))
 		b.api.Send(msg)
 		b.handleScheduleMenu(message)
 		return
 	}
 
-	statusText := getStatusText(status)
-	detailsMsg := fmt.Sprintf(
-		"Запись #%d\n\n"+
-			"Клиент: %s %s\n"+
-			"Дата: %s\n"+
-			"Время: %s\n"+
-			"Статус: %s\n\n"+
-			"Выберите действие:",
+	statusText := b.getStatusTextLocalized(status, chatID)
+	detailsMsg := b.tf("schedule_appointment_details", chatID,
 		appointmentID, clientName, clientSurname, dateStr[:10], timeStr, statusText)
 
 	userStates.Lock()
@@ -604,14 +597,14 @@ func (b *Bot) handleAppointmentSelection(message *tgbotapi.Message) {
 	msg := tgbotapi.NewMessage(chatID, detailsMsg)
 	keyboard := tgbotapi.NewReplyKeyboard(
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Подтвердить"),
-			tgbotapi.NewKeyboardButton("Завершить"),
+			tgbotapi.NewKeyboardButton(b.t("confirm", chatID)),
+			tgbotapi.NewKeyboardButton(b.t("schedule_btn_complete", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Отменить запись"),
+			tgbotapi.NewKeyboardButton(b.t("appointments_cancel_btn", chatID)),
 		),
 		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Назад"),
+			tgbotapi.NewKeyboardButton(b.t("back", chatID)),
 		),
 	)
 	msg.ReplyMarkup = keyboard
@@ -623,7 +616,7 @@ func (b *Bot) handleAppointmentAction(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	action := message.Text
 
-	if action == "Назад" {
+	if i18n.Is(action, "back") {
 		userStates.Lock()
 		delete(userStates.states, chatID)
 		userStates.Unlock()
@@ -639,37 +632,33 @@ func (b *Bot) handleAppointmentAction(message *tgbotapi.Message) {
 	appointmentStore.RUnlock()
 
 	if appointmentID == 0 {
-		msg := tgbotapi.NewMessage(chatID, "Запись не выбрана")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_appointment_not_selected", chatID))
 		b.api.Send(msg)
 		b.handleScheduleMenu(message)
 		return
 	}
 
 	var newStatus string
-	var statusMsg string
-
-	switch action {
-	case "Подтвердить":
+	switch i18n.Match(action, "confirm", "schedule_btn_complete", "appointments_cancel_btn") {
+	case "confirm":
 		newStatus = "confirmed"
-		statusMsg = "Запись подтверждена"
-	case "Завершить":
+	case "schedule_btn_complete":
 		newStatus = "completed"
-		statusMsg = "Тренировка завершена"
-	case "Отменить запись":
+	case "appointments_cancel_btn":
 		newStatus = "cancelled"
-		statusMsg = "Запись отменена"
 	default:
-		msg := tgbotapi.NewMessage(chatID, "Неизвестное действие")
+		msg := tgbotapi.NewMessage(chatID, b.t("select_action_menu", chatID))
 		b.api.Send(msg)
 		return
 	}
+	statusMsg := b.t("schedule_status_set_"+newStatus, chatID)
 
 	// Обновляем статус
-	_, err := b.db.Exec("UPDATE public.appointments SET status = $1, updated_at = NOW() WHERE id = $2",
+	_, err := b.db.Exec("UPDATE public.appointments SET status = $1, ics_sequence = ics_sequence + 1, updated_at = NOW() WHERE id = $2",
 		newStatus, appointmentID)
 	if err != nil {
 		log.Printf("Ошибка обновления статуса: %v", err)
-		msg := tgbotapi.NewMessage(chatID, "Ошибка обновления статуса.")
+		msg := tgbotapi.NewMessage(chatID, b.t("schedule_status_error", chatID))
 		b.api.Send(msg)
 	} else {
 		msg := tgbotapi.NewMessage(chatID, statusMsg)
@@ -677,6 +666,12 @@ func (b *Bot) handleAppointmentAction(message *tgbotapi.Message) {
 
 		// Уведомляем клиента об изменении статуса
 		b.notifyClientAboutStatusChange(appointmentID, newStatus)
+
+		// Проведённая тренировка списывается с абонемента, отменённая — возвращается
+		b.updateAppointmentBalance(chatID, appointmentID, newStatus)
+
+		// Отменённая запись удаляется из Google Calendar, остальные обновляются
+		b.requestGoogleSync()
 	}
 
 	// Очищаем состояние
@@ -693,32 +688,39 @@ func (b *Bot) handleAppointmentAction(message *tgbotapi.Message) {
 // notifyClientAboutStatusChange уведомляет клиента об изменении статуса записи
 func (b *Bot) notifyClientAboutStatusChange(appointmentID int, newStatus string) {
 	// Получаем данные записи и клиента
-	var clientTelegramID int64
+	var clientTelegramID, trainerID int64
 	var clientName, dateStr, timeStr string
 	err := b.db.QueryRow(`
-		SELECT c.telegram_id, c.name, a.appointment_date, TO_CHAR(a.start_time, 'HH24:MI')
+		SELECT c.telegram_id, c.name, a.trainer_id, a.appointment_date, TO_CHAR(a.start_time, 'HH24:MI')
 		FROM public.appointments a
 		JOIN public.clients c ON a.client_id = c.id
 		WHERE a.id = $1 AND c.telegram_id IS NOT NULL`, appointmentID).
-		Scan(&clientTelegramID, &clientName, &dateStr, &timeStr)
+		Scan(&clientTelegramID, &clientName, &trainerID, &dateStr, &timeStr)
 	if err != nil || clientTelegramID == 0 {
 		return // Клиент без telegram или ошибка
 	}
 
+	// Переводим время записи (пояс тренера) в пояс клиента
+	if date, err := time.Parse("2006-01-02", dateStr[:10]); err == nil {
+		start := b.appointmentStart(trainerID, date, timeStr).In(b.userLocation(clientTelegramID))
+		dateStr, timeStr = start.Format("02.01.2006"), start.Format("15:04")
+	}
+
 	var statusMsg string
 	switch newStatus {
-	case "confirmed":
-		statusMsg = fmt.Sprintf("Ваша запись на тренировку подтверждена!\n\nДата: %s\nВремя: %s", dateStr[:10], timeStr)
-	case "completed":
-		statusMsg = fmt.Sprintf("Тренировка %s в %s завершена. Отличная работа!", dateStr[:10], timeStr)
+	case "confirmed", "completed":
+		statusMsg = b.tf("schedule_client_notify_"+newStatus, clientTelegramID, dateStr[:10], timeStr)
 	case "cancelled":
-		statusMsg = fmt.Sprintf("Ваша запись на %s в %s была отменена.\n\nДля записи на другое время нажмите 'Записаться на тренировку'", dateStr[:10], timeStr)
+		statusMsg = b.tf("schedule_client_notify_cancelled", clientTelegramID, dateStr[:10], timeStr,
+			b.t("btn_book_training", clientTelegramID))
 	default:
 		return
 	}
 
 	msg := tgbotapi.NewMessage(clientTelegramID, statusMsg)
-	b.api.Send(msg)
+	if err := b.outbox.Enqueue(msg); err != nil {
+		log.Printf("Ошибка постановки уведомления клиенту %d в очередь: %v", clientTelegramID, err)
+	}
 }
 
 // getStatusEmoji возвращает эмодзи для статуса
@@ -0,0 +1,68 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"workbot/internal/outbox"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// telegramAPI — Bot API, отправки которого идут через outbox.Sender:
+// с лимитами Telegram, повтором после 429 и отметкой клиентов, заблокировавших бота.
+// Остальные методы (получение обновлений, файлов) берутся из BotAPI без изменений.
+// Send и Request ждут лимитов в вызывающей горутине, поэтому обработчики обновлений
+// работают вне цикла получения (см. chatDispatcher)
+type telegramAPI struct {
+	*tgbotapi.BotAPI
+	sender *outbox.Sender
+}
+
+// Send отправляет сообщение с учётом лимитов
+func (a *telegramAPI) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	return a.sender.Send(context.Background(), c)
+}
+
+// Request выполняет запрос с учётом лимитов
+func (a *telegramAPI) Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	return a.sender.Request(context.Background(), c)
+}
+
+// botBlockedCache помнит, у каких чатов уже проверена отметка о блокировке бота,
+// чтобы не обновлять таблицу клиентов на каждое сообщение
+var botBlockedCache = struct {
+	sync.Mutex
+	checked map[int64]bool
+}{
+	checked: make(map[int64]bool),
+}
+
+// markClientBlocked отмечает клиента, заблокировавшего бота (ответ 403)
+func (b *Bot) markClientBlocked(chatID int64) {
+	botBlockedCache.Lock()
+	delete(botBlockedCache.checked, chatID)
+	botBlockedCache.Unlock()
+
+	if err := b.repo.Client.MarkBotBlocked(chatID); err != nil {
+		log.Printf("Ошибка отметки блокировки бота клиентом %d: %v", chatID, err)
+		return
+	}
+	log.Printf("Клиент %d заблокировал бота", chatID)
+}
+
+// clearClientBlocked снимает отметку о блокировке: клиент снова пишет боту
+func (b *Bot) clearClientBlocked(chatID int64) {
+	botBlockedCache.Lock()
+	defer botBlockedCache.Unlock()
+
+	if botBlockedCache.checked[chatID] {
+		return
+	}
+	if err := b.repo.Client.ClearBotBlocked(chatID); err != nil {
+		log.Printf("Ошибка снятия отметки блокировки бота клиентом %d: %v", chatID, err)
+		return
+	}
+	botBlockedCache.checked[chatID] = true
+}
@@ -0,0 +1,199 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"workbot/internal/calendar"
+	"workbot/internal/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const stateTimezoneInput = "tz_input"
+
+// tzCache кэширует часовые пояса пользователей (IANA имя, "" — пояс по умолчанию)
+var tzCache = struct {
+	sync.RWMutex
+	cache map[int64]string
+}{cache: make(map[int64]string)}
+
+// defaultLocation возвращает часовой пояс по умолчанию из конфигурации
+func (b *Bot) defaultLocation() *time.Location {
+	if b.config == nil {
+		return time.Local
+	}
+	return calendar.LoadLocation(b.config.DefaultTimezone, time.Local)
+}
+
+// getTimezone возвращает IANA имя пояса пользователя (тренера или клиента)
+func (b *Bot) getTimezone(telegramID int64) string {
+	tzCache.RLock()
+	if name, ok := tzCache.cache[telegramID]; ok {
+		tzCache.RUnlock()
+		return name
+	}
+	tzCache.RUnlock()
+
+	var name string
+	err := b.db.QueryRow(`
+		SELECT COALESCE(
+			(SELECT timezone FROM public.admins WHERE telegram_id = $1),
+			(SELECT timezone FROM public.clients WHERE telegram_id = $1 AND deleted_at IS NULL LIMIT 1),
+			'')`, telegramID).Scan(&name)
+	if err != nil {
+		log.Printf("Ошибка получения часового пояса %d: %v", telegramID, err)
+		return ""
+	}
+
+	tzCache.Lock()
+	tzCache.cache[telegramID] = name
+	tzCache.Unlock()
+	return name
+}
+
+// userLocation возвращает часовой пояс пользователя
+func (b *Bot) userLocation(telegramID int64) *time.Location {
+	return calendar.LoadLocation(b.getTimezone(telegramID), b.defaultLocation())
+}
+
+// setTimezone сохраняет часовой пояс тренера или клиента
+func (b *Bot) setTimezone(telegramID int64, name string) error {
+	table := "clients"
+	if b.isAdmin(telegramID) {
+		table = "admins"
+	}
+	_, err := b.db.Exec(
+		fmt.Sprintf("UPDATE public.%s SET timezone = $1 WHERE telegram_id = $2", table),
+		name, telegramID)
+	if err != nil {
+		return err
+	}
+
+	tzCache.Lock()
+	tzCache.cache[telegramID] = name
+	tzCache.Unlock()
+	return nil
+}
+
+// appointmentStart возвращает момент начала записи: дата и время записи хранятся в поясе тренера
+func (b *Bot) appointmentStart(trainerID int64, date time.Time, startTime string) time.Time {
+	hour, minute, err := calendar.ParseTime(startTime)
+	if err != nil {
+		hour, minute = 0, 0
+	}
+	return calendar.CombineDateTimeIn(date, hour, minute, b.userLocation(trainerID))
+}
+
+// formatForUser форматирует момент в поясе пользователя
+func (b *Bot) formatForUser(telegramID int64, t time.Time, layout string) string {
+	return t.In(b.userLocation(telegramID)).Format(layout)
+}
+
+// timezoneLabel возвращает подпись текущего пояса пользователя
+func (b *Bot) timezoneLabel(telegramID int64) string {
+	return calendar.ZoneLabel(b.getTimezone(telegramID), b.userLocation(telegramID), time.Now())
+}
+
+// showTimezonePicker показывает выбор часового пояса (messageID > 0 — редактирует сообщение)
+func (b *Bot) showTimezonePicker(chatID int64, messageID int, text string) {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	now := time.Now()
+	for i := 0; i < len(calendar.CommonZones); i += 2 {
+		var row []tgbotapi.InlineKeyboardButton
+		for j := i; j < i+2 && j < len(calendar.CommonZones); j++ {
+			z := calendar.CommonZones[j]
+			loc := calendar.LoadLocation(z.Name, time.UTC)
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("%s %s", z.Label, calendar.FormatOffset(now, loc)),
+				fmt.Sprintf("tz_set_%d", j)))
+		}
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("tz_btn_custom", chatID), "tz_custom"),
+	))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	if messageID > 0 {
+		edit := tgbotapi.NewEditMessageText(chatID, messageID, text)
+		edit.ReplyMarkup = &keyboard
+		b.api.Send(edit)
+		return
+	}
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = keyboard
+	b.api.Send(msg)
+}
+
+// handleTimezoneCallback обрабатывает выбор часового пояса
+func (b *Bot) handleTimezoneCallback(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+	data := callback.Data
+
+	b.api.Send(tgbotapi.NewCallback(callback.ID, ""))
+
+	switch {
+	case data == "tz_custom":
+		setState(chatID, stateTimezoneInput)
+		b.api.Send(tgbotapi.NewDeleteMessage(chatID, messageID))
+		msg := tgbotapi.NewMessage(chatID, b.t("tz_enter", chatID))
+		msg.ReplyMarkup = tgbotapi.NewReplyKeyboard(
+			tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(b.t("cancel", chatID))),
+		)
+		b.api.Send(msg)
+
+	case strings.HasPrefix(data, "tz_set_"):
+		idx, err := strconv.Atoi(strings.TrimPrefix(data, "tz_set_"))
+		if err != nil || idx < 0 || idx >= len(calendar.CommonZones) {
+			return
+		}
+		if err := b.setTimezone(chatID, calendar.CommonZones[idx].Name); err != nil {
+			log.Printf("Ошибка сохранения часового пояса: %v", err)
+			return
+		}
+		edit := tgbotapi.NewEditMessageText(chatID, messageID, b.tf("tz_changed", chatID, b.timezoneLabel(chatID)))
+		b.api.Send(edit)
+	}
+}
+
+// handleTimezoneInput обрабатывает ввод часового пояса текстом
+func (b *Bot) handleTimezoneInput(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	text := strings.TrimSpace(message.Text)
+
+	if i18n.Is(text, "cancel") {
+		clearState(chatID)
+		b.restoreMenu(chatID, message)
+		return
+	}
+
+	name, err := calendar.ParseTimezone(text)
+	if err != nil {
+		b.sendMessage(chatID, b.tf("tz_invalid", chatID, err.Error()))
+		return
+	}
+	if err := b.setTimezone(chatID, name); err != nil {
+		log.Printf("Ошибка сохранения часового пояса: %v", err)
+		b.sendMessage(chatID, b.t("error", chatID))
+		return
+	}
+
+	clearState(chatID)
+	b.sendMessage(chatID, b.tf("tz_changed", chatID, b.timezoneLabel(chatID)))
+	b.restoreMenu(chatID, message)
+}
+
+// restoreMenu возвращает главное меню тренера или клиента
+func (b *Bot) restoreMenu(chatID int64, message *tgbotapi.Message) {
+	if b.isAdmin(chatID) {
+		b.handleAdminStart(message)
+		return
+	}
+	b.restoreMainMenu(chatID)
+}
@@ -0,0 +1,321 @@
+package bot
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"workbot/internal/generator/progression"
+	"workbot/internal/i18n"
+	"workbot/internal/models"
+	"workbot/internal/training"
+)
+
+// loadHistoryDays — глубина журнала нагрузки для модели: подготовленность по Банистеру
+// затухает за 42 дня, четырёх таких периодов достаточно
+const loadHistoryDays = 168
+
+// inolMaxIntensity — потолок интенсивности подхода для INOL: при 100% формула вырождается
+const inolMaxIntensity = 99.0
+
+// workoutINOL — суммарный INOL тренировки по упражнениям с известным % от 1ПМ.
+// 1ПМ восстанавливается из планового веса и процента; для записанных подходов берётся
+// их фактический вес, без подходов — план упражнения
+func workoutINOL(exercises []models.WorkoutExercise, sets map[int][]models.WorkoutSet) float64 {
+	var data []progression.ExerciseINOLData
+	for _, e := range exercises {
+		if e.WeightPercent <= 0 || e.Weight <= 0 {
+			continue
+		}
+		oneRM := e.Weight / e.WeightPercent * 100
+
+		if logged := sets[e.ID]; len(logged) > 0 {
+			for _, s := range logged {
+				if s.Weight <= 0 || s.Reps <= 0 {
+					continue
+				}
+				data = append(data, progression.ExerciseINOLData{
+					Sets:             1,
+					Reps:             s.Reps,
+					IntensityPercent: min(s.Weight/oneRM*100, inolMaxIntensity),
+				})
+			}
+			continue
+		}
+
+		if reps := parsePlannedReps(e.Reps); e.Completed && e.Sets > 0 && reps > 0 {
+			data = append(data, progression.ExerciseINOLData{
+				Sets:             e.Sets,
+				Reps:             reps,
+				IntensityPercent: min(e.WeightPercent, inolMaxIntensity),
+			})
+		}
+	}
+	return progression.CalculateWorkoutINOL(data)
+}
+
+// recordTrainingLoad записывает нагрузку завершённой тренировки и пересчитывает
+// ACWR и модель Банистера по журналу клиента. prev — зона ACWR после предыдущей
+// тренировки; ok = false, если нагрузка уже записана или запись не удалась
+func (b *Bot) recordTrainingLoad(workoutID, clientID, rpe, minutes int, loc *time.Location) (state training.LoadState, prev training.ACWRZone, ok bool) {
+	prev = training.ACWRUnknown
+	var prevZone string
+	err := b.db.QueryRow(`
+		SELECT acwr_zone FROM public.training_loads
+		WHERE client_id = $1
+		ORDER BY performed_at DESC LIMIT 1`, clientID).Scan(&prevZone)
+	if err == nil {
+		prev = training.ACWRZone(prevZone)
+	} else if err != sql.ErrNoRows {
+		log.Printf("Ошибка чтения журнала нагрузки: %v", err)
+		return state, prev, false
+	}
+
+	exercises, err := b.repo.Program.GetExercisesByWorkout(workoutID)
+	if err != nil {
+		log.Printf("Ошибка получения упражнений для нагрузки: %v", err)
+	}
+	sets := make(map[int][]models.WorkoutSet, len(exercises))
+	for _, e := range exercises {
+		if logged, err := b.repo.Program.GetWorkoutSets(e.ID); err == nil {
+			sets[e.ID] = logged
+		}
+	}
+	tonnage, _ := b.repo.Program.GetWorkoutTonnage(workoutID)
+
+	var id int
+	err = b.db.QueryRow(`
+		INSERT INTO public.training_loads (client_id, workout_id, duration_min, session_rpe, srpe_load, tonnage, inol)
+		VALUES ($1, $2, $3, NULLIF($4, 0), $5, $6, $7)
+		ON CONFLICT (workout_id) DO NOTHING
+		RETURNING id`,
+		clientID, workoutID, max(minutes, 0), rpe, training.SessionLoad(rpe, minutes), tonnage,
+		workoutINOL(exercises, sets)).Scan(&id)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("Ошибка записи нагрузки тренировки: %v", err)
+		}
+		return state, prev, false
+	}
+
+	state, err = b.clientLoadState(clientID, time.Now().In(loc))
+	if err != nil {
+		log.Printf("Ошибка расчёта модели нагрузки: %v", err)
+		return state, prev, false
+	}
+
+	var acwr interface{}
+	if state.ACWR > 0 {
+		acwr = state.ACWR
+	}
+	if _, err := b.db.Exec(`
+		UPDATE public.training_loads
+		SET acute_load = $2, chronic_load = $3, acwr = $4, acwr_zone = $5, fitness = $6, fatigue = $7
+		WHERE id = $1`,
+		id, state.Acute, state.Chronic, acwr, string(state.Zone), state.Fitness, state.Fatigue); err != nil {
+		log.Printf("Ошибка сохранения состояния нагрузки: %v", err)
+	}
+	return state, prev, true
+}
+
+// clientLoadState рассчитывает ACWR и модель Банистера клиента на момент now
+func (b *Bot) clientLoadState(clientID int, now time.Time) (training.LoadState, error) {
+	rows, err := b.db.Query(`
+		SELECT performed_at, srpe_load FROM public.training_loads
+		WHERE client_id = $1 AND performed_at >= $2
+		ORDER BY performed_at`, clientID, now.AddDate(0, 0, -loadHistoryDays))
+	if err != nil {
+		return training.LoadState{}, err
+	}
+	defer rows.Close()
+
+	var loads []training.DailyLoad
+	for rows.Next() {
+		var l training.DailyLoad
+		if err := rows.Scan(&l.Date, &l.Load); err != nil {
+			return training.LoadState{}, err
+		}
+		loads = append(loads, l)
+	}
+	if err := rows.Err(); err != nil {
+		return training.LoadState{}, err
+	}
+	return training.LoadModel(loads, now), nil
+}
+
+// workoutLoad — записанная нагрузка тренировки: sRPE и ACWR с зоной после неё
+func (b *Bot) workoutLoad(workoutID int) (srpe, acwr float64, zone string, ok bool) {
+	var a sql.NullFloat64
+	err := b.db.QueryRow(`
+		SELECT srpe_load, acwr, acwr_zone FROM public.training_loads
+		WHERE workout_id = $1`, workoutID).Scan(&srpe, &a, &zone)
+	return srpe, a.Float64, zone, err == nil
+}
+
+// formatLoadLine — строка нагрузки для уведомления тренеру о тренировке
+func (b *Bot) formatLoadLine(chatID int64, srpe, acwr float64, zone string) string {
+	if zone == string(training.ACWRUnknown) || acwr == 0 {
+		return b.tf("load_trainer_line", chatID, srpe)
+	}
+	return b.tf("load_trainer_line_acwr", chatID, srpe, acwr, b.t("load_zone_short_"+zone, chatID))
+}
+
+// loadZoneAlert — нужен ли сигнал тренеру: зона ACWR сменилась и вышла из нормы
+func loadZoneAlert(prev, zone training.ACWRZone) bool {
+	if zone == prev {
+		return false
+	}
+	return zone == training.ACWRLow || zone == training.ACWRHigh || zone == training.ACWRDanger
+}
+
+// deloadOffer — текст рекомендации разгрузки и кнопка её вставки в активную программу
+// клиента; пустой текст — разгрузка не нужна
+func (b *Bot) deloadOffer(chatID int64, clientID int, advice training.DeloadAdvice) (string, []tgbotapi.InlineKeyboardButton) {
+	if advice.Reason == "" {
+		return "", nil
+	}
+	reason := b.t("load_deload_reason_"+string(advice.Reason), chatID)
+
+	program, err := b.repo.Program.GetActiveProgram(clientID)
+	if err != nil || program == nil {
+		return b.tf("load_deload_no_week", chatID, reason), nil
+	}
+	week, err := b.repo.Program.NextUnstartedWeek(program.ID)
+	if err != nil || week == 0 {
+		return b.tf("load_deload_no_week", chatID, reason), nil
+	}
+
+	key := "load_deload_next"
+	if advice.Urgent {
+		key = "load_deload_urgent"
+	}
+	return b.tf(key, chatID, reason, week), tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			b.tf("load_btn_deload", chatID, week),
+			fmt.Sprintf("load_deload_%d_%d", program.ID, week),
+		),
+	)
+}
+
+// formatLoadState — ACWR и модель Банистера клиента
+func (b *Bot) formatLoadState(chatID int64, s training.LoadState) string {
+	if s.Zone == training.ACWRUnknown {
+		return b.tf("load_state_unknown", chatID, s.HistoryDays, training.ACWRMinHistoryDays)
+	}
+	return b.tf("load_state", chatID, s.ACWR, b.t("load_zone_short_"+string(s.Zone), chatID),
+		s.Acute, s.Chronic, s.Fitness, s.Fatigue, s.Performance)
+}
+
+// notifyTrainerTrainingLoad сообщает тренеру о выходе ACWR из безопасной зоны
+// и, если модель советует, предлагает вставить разгрузочную неделю
+func (b *Bot) notifyTrainerTrainingLoad(clientID int, state training.LoadState) {
+	trainerID, err := b.repo.Admin.GetFirst()
+	if err != nil {
+		log.Printf("Ошибка получения тренера: %v", err)
+		return
+	}
+	client, _ := b.repo.Client.GetByID(clientID)
+	if client == nil {
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(b.tf("load_alert_title", trainerID, client.Name, client.Surname) + "\n\n")
+	text.WriteString(b.t("load_alert_zone_"+string(state.Zone), trainerID) + "\n")
+	text.WriteString(b.formatLoadState(trainerID, state))
+
+	msg := tgbotapi.NewMessage(trainerID, "")
+	if offer, row := b.deloadOffer(trainerID, clientID, training.RecommendDeload(state)); offer != "" {
+		text.WriteString("\n\n" + offer)
+		if row != nil {
+			msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(row)
+		}
+	}
+	msg.Text = text.String()
+	msg.ParseMode = "Markdown"
+	if err := b.outbox.Enqueue(msg); err != nil {
+		log.Printf("Ошибка постановки уведомления о нагрузке в очередь: %v", err)
+	}
+}
+
+// trackTrainingLoad записывает нагрузку завершённой тренировки и при смене зоны
+// ACWR предупреждает тренера
+func (b *Bot) trackTrainingLoad(workoutID int, clientChatID int64, rpe, minutes int) {
+	clientID, err := b.repo.Program.GetClientIDByWorkout(workoutID)
+	if err != nil {
+		log.Printf("Ошибка получения клиента тренировки: %v", err)
+		return
+	}
+	state, prev, ok := b.recordTrainingLoad(workoutID, clientID, rpe, minutes, b.userLocation(clientChatID))
+	if ok && loadZoneAlert(prev, state.Zone) {
+		b.notifyTrainerTrainingLoad(clientID, state)
+	}
+}
+
+// showTrainingLoad показывает тренеру текущую нагрузку клиента и рекомендацию разгрузки
+func (b *Bot) showTrainingLoad(chatID int64, clientID, messageID int) {
+	state, err := b.clientLoadState(clientID, time.Now().In(b.userLocation(chatID)))
+	if err != nil {
+		b.sendError(chatID, b.t("error", chatID), err)
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(b.t("load_view_title", chatID) + "\n\n")
+	if state.HistoryDays == 0 {
+		text.WriteString(b.t("load_view_empty", chatID))
+	} else {
+		text.WriteString(b.formatLoadState(chatID, state))
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if offer, row := b.deloadOffer(chatID, clientID, training.RecommendDeload(state)); offer != "" {
+		text.WriteString("\n\n" + offer)
+		if row != nil {
+			rows = append(rows, row)
+		}
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("workout_btn_back_progress", chatID), fmt.Sprintf("prog_back_%d", clientID)),
+	))
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	b.editMessage(chatID, messageID, text.String(), &keyboard)
+}
+
+// handleLoadCallback обрабатывает кнопки журнала нагрузки (только тренер)
+func (b *Bot) handleLoadCallback(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	b.api.Send(tgbotapi.NewCallback(callback.ID, ""))
+	if !b.isAdmin(chatID) {
+		return
+	}
+
+	if args, ok := strings.CutPrefix(callback.Data, "load_deload_"); ok {
+		parts := strings.Split(args, "_")
+		if len(parts) != 2 {
+			return
+		}
+		programID, err1 := strconv.Atoi(parts[0])
+		week, err2 := strconv.Atoi(parts[1])
+		if err1 != nil || err2 != nil {
+			return
+		}
+
+		// Убираем кнопку, чтобы повторное нажатие не вставило вторую разгрузку
+		b.api.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, callback.Message.MessageID,
+			tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}}))
+
+		// Название тренировки хранится в БД на языке по умолчанию
+		n, err := b.repo.Program.InsertDeloadWeek(programID, week, i18n.T("load_deload_name_prefix", i18n.DefaultLang))
+		if err != nil {
+			b.sendError(chatID, b.t("load_deload_error", chatID), err)
+			return
+		}
+		b.sendMessage(chatID, b.tf("load_deload_done", chatID, week, n))
+	}
+}
@@ -0,0 +1,60 @@
+package bot
+
+import (
+	"math"
+	"testing"
+
+	"workbot/internal/models"
+	"workbot/internal/training"
+)
+
+func TestWorkoutINOL(t *testing.T) {
+	exercises := []models.WorkoutExercise{
+		// Присед 100 кг = 80% от 1ПМ 125 кг, подходы записаны
+		{ID: 1, Sets: 3, Reps: "5", Weight: 100, WeightPercent: 80, Completed: true},
+		// Жим без записи подходов: план 3×5 на 75%
+		{ID: 2, Sets: 3, Reps: "5", Weight: 60, WeightPercent: 75, Completed: true},
+		// Подтягивания без % от 1ПМ в INOL не входят
+		{ID: 3, Sets: 4, Reps: "8", Completed: true},
+		// Невыполненное упражнение без подходов не считается
+		{ID: 4, Sets: 3, Reps: "5", Weight: 50, WeightPercent: 70},
+	}
+	sets := map[int][]models.WorkoutSet{
+		1: {
+			{Reps: 5, Weight: 100},
+			{Reps: 5, Weight: 100},
+			{Reps: 3, Weight: 112.5}, // 90%
+		},
+	}
+
+	want := 5.0/20 + 5.0/20 + 3.0/10 + 3*5.0/25
+	if got := workoutINOL(exercises, sets); math.Abs(got-want) > 1e-9 {
+		t.Errorf("workoutINOL = %v, want %v", got, want)
+	}
+
+	// Подход тяжелее расчётного 1ПМ не даёт деления на ноль
+	heavy := map[int][]models.WorkoutSet{1: {{Reps: 1, Weight: 130}}}
+	if got := workoutINOL(exercises[:1], heavy); math.Abs(got-1) > 1e-9 {
+		t.Errorf("workoutINOL при весе выше 1ПМ = %v, want 1", got)
+	}
+}
+
+func TestLoadZoneAlert(t *testing.T) {
+	tests := []struct {
+		prev, zone training.ACWRZone
+		want       bool
+	}{
+		{training.ACWRSafe, training.ACWRHigh, true},
+		{training.ACWRHigh, training.ACWRDanger, true},
+		{training.ACWRUnknown, training.ACWRLow, true},
+		{training.ACWRHigh, training.ACWRHigh, false},
+		{training.ACWRHigh, training.ACWRSafe, false},
+		{training.ACWRUnknown, training.ACWRSafe, false},
+	}
+
+	for _, tt := range tests {
+		if got := loadZoneAlert(tt.prev, tt.zone); got != tt.want {
+			t.Errorf("loadZoneAlert(%s, %s) = %v, want %v", tt.prev, tt.zone, got, tt.want)
+		}
+	}
+}
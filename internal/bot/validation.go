@@ -3,16 +3,36 @@ package bot
 import (
 	"regexp"
 	"strings"
+
+	"workbot/internal/i18n"
 )
 
-// ValidationError represents a validation error
+// ValidationError represents a validation error; Message is a locale key
 type ValidationError struct {
 	Field   string
 	Message string
 }
 
 func (e ValidationError) Error() string {
-	return e.Message
+	return i18n.T(e.Message, i18n.DefaultLang)
+}
+
+// localizedError — ошибка валидации, текст которой хранится в файлах локализации под ключом
+type localizedError string
+
+func (e localizedError) Error() string {
+	return i18n.T(string(e), i18n.DefaultLang)
+}
+
+// errorText возвращает текст ошибки на языке пользователя
+func (b *Bot) errorText(err error, chatID int64) string {
+	switch e := err.(type) {
+	case localizedError:
+		return b.t(string(e), chatID)
+	case ValidationError:
+		return b.t(e.Message, chatID)
+	}
+	return err.Error()
 }
 
 // validateName и validatePhone определены в registration.go
@@ -20,10 +40,10 @@ func (e ValidationError) Error() string {
 // validateWeight validates weight in kg
 func validateWeight(weight float64) error {
 	if weight <= 0 {
-		return ValidationError{Field: "weight", Message: "Вес должен быть положительным числом"}
+		return ValidationError{Field: "weight", Message: "validation_weight_positive"}
 	}
 	if weight > 500 {
-		return ValidationError{Field: "weight", Message: "Вес слишком большой (максимум 500 кг)"}
+		return ValidationError{Field: "weight", Message: "validation_weight_max"}
 	}
 	return nil
 }
@@ -31,10 +51,10 @@ func validateWeight(weight float64) error {
 // validateReps validates repetitions count
 func validateReps(reps int) error {
 	if reps <= 0 {
-		return ValidationError{Field: "reps", Message: "Количество повторений должно быть положительным"}
+		return ValidationError{Field: "reps", Message: "validation_reps_positive"}
 	}
 	if reps > 100 {
-		return ValidationError{Field: "reps", Message: "Слишком много повторений (максимум 100)"}
+		return ValidationError{Field: "reps", Message: "validation_reps_max"}
 	}
 	return nil
 }
@@ -42,10 +62,10 @@ func validateReps(reps int) error {
 // validateSets validates sets count
 func validateSets(sets int) error {
 	if sets <= 0 {
-		return ValidationError{Field: "sets", Message: "Количество подходов должно быть положительным"}
+		return ValidationError{Field: "sets", Message: "validation_sets_positive"}
 	}
 	if sets > 20 {
-		return ValidationError{Field: "sets", Message: "Слишком много подходов (максимум 20)"}
+		return ValidationError{Field: "sets", Message: "validation_sets_max"}
 	}
 	return nil
 }
@@ -54,13 +74,13 @@ func validateSets(sets int) error {
 func validateExerciseName(name string) error {
 	name = strings.TrimSpace(name)
 	if name == "" {
-		return ValidationError{Field: "exercise_name", Message: "Название упражнения не может быть пустым"}
+		return ValidationError{Field: "exercise_name", Message: "validation_exercise_empty"}
 	}
 	if len(name) < 3 {
-		return ValidationError{Field: "exercise_name", Message: "Название слишком короткое (минимум 3 символа)"}
+		return ValidationError{Field: "exercise_name", Message: "validation_exercise_short"}
 	}
 	if len(name) > 100 {
-		return ValidationError{Field: "exercise_name", Message: "Название слишком длинное (максимум 100 символов)"}
+		return ValidationError{Field: "exercise_name", Message: "validation_exercise_long"}
 	}
 	return nil
 }
@@ -74,7 +94,7 @@ var datePatterns = []*regexp.Regexp{
 func validateDateString(date string) error {
 	date = strings.TrimSpace(date)
 	if date == "" {
-		return ValidationError{Field: "date", Message: "Дата не может быть пустой"}
+		return ValidationError{Field: "date", Message: "validation_date_empty"}
 	}
 
 	for _, pattern := range datePatterns {
@@ -83,16 +103,16 @@ func validateDateString(date string) error {
 		}
 	}
 
-	return ValidationError{Field: "date", Message: "Неверный формат даты. Используйте ДД.ММ.ГГГГ или ДД.ММ"}
+	return ValidationError{Field: "date", Message: "validation_date_short_format"}
 }
 
 // validateWeeks validates training plan weeks
 func validateWeeks(weeks int) error {
 	if weeks < 1 {
-		return ValidationError{Field: "weeks", Message: "Минимум 1 неделя"}
+		return ValidationError{Field: "weeks", Message: "validation_weeks_min"}
 	}
 	if weeks > 52 {
-		return ValidationError{Field: "weeks", Message: "Максимум 52 недели"}
+		return ValidationError{Field: "weeks", Message: "validation_weeks_max"}
 	}
 	return nil
 }
@@ -100,10 +120,10 @@ func validateWeeks(weeks int) error {
 // validateDaysPerWeek validates training days per week
 func validateDaysPerWeek(days int) error {
 	if days < 1 {
-		return ValidationError{Field: "days_per_week", Message: "Минимум 1 день в неделю"}
+		return ValidationError{Field: "days_per_week", Message: "validation_days_min"}
 	}
 	if days > 7 {
-		return ValidationError{Field: "days_per_week", Message: "Максимум 7 дней в неделю"}
+		return ValidationError{Field: "days_per_week", Message: "validation_days_max"}
 	}
 	return nil
 }
@@ -111,10 +131,10 @@ func validateDaysPerWeek(days int) error {
 // validate1PM validates 1PM value
 func validate1PM(weight float64) error {
 	if weight <= 0 {
-		return ValidationError{Field: "1pm", Message: "1ПМ должен быть положительным числом"}
+		return ValidationError{Field: "1pm", Message: "validation_1pm_positive"}
 	}
 	if weight > 600 {
-		return ValidationError{Field: "1pm", Message: "1ПМ слишком большой (максимум 600 кг)"}
+		return ValidationError{Field: "1pm", Message: "validation_1pm_max"}
 	}
 	return nil
 }
@@ -122,7 +142,7 @@ func validate1PM(weight float64) error {
 // validateIntensityPercent validates intensity percentage
 func validateIntensityPercent(percent float64) error {
 	if percent < 0 || percent > 100 {
-		return ValidationError{Field: "intensity", Message: "Интенсивность должна быть от 0 до 100%"}
+		return ValidationError{Field: "intensity", Message: "validation_intensity_range"}
 	}
 	return nil
 }
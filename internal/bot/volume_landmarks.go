@@ -0,0 +1,395 @@
+package bot
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"workbot/internal/generator/progression"
+	"workbot/internal/repository"
+	"workbot/internal/training"
+)
+
+// landmarkMuscles — мышечные группы с табличными ориентирами объёма, в порядке вывода
+var landmarkMuscles = []string{"chest", "back", "shoulders", "quads", "hamstrings", "glutes", "biceps", "triceps", "calves", "core"}
+
+// Сравнение результатов за блок: среднее двух первых и двух последних тренировок упражнения
+const (
+	perfCompareSessions = 2
+	perfMinSessions     = 3
+)
+
+// landmarkChange — пересчёт ориентиров одной мышечной группы по итогам блока
+type landmarkChange struct {
+	Muscle  string
+	Before  float64 // фактор адаптации до блока
+	After   float64
+	Outcome progression.BlockOutcome
+	Reason  progression.AdaptationReason
+	Date    time.Time
+}
+
+// setE1RM оценивает 1ПМ по подходу: по таблице RPE, если RPE указан, иначе по Эпли.
+// 0 — подход без веса или больше 12 повторов
+func setE1RM(s repository.LoggedSets) float64 {
+	if s.Weight <= 0 || s.Reps < 1 || s.Reps > training.RPEChartMaxReps {
+		return 0
+	}
+	if s.RPE > 0 {
+		if e1rm := training.EstimateE1RM(s.Weight, s.Reps, s.RPE); e1rm > 0 {
+			return e1rm
+		}
+	}
+	return training.Calculate1PM(s.Weight, s.Reps, "epley")
+}
+
+// performanceTrend — изменение e1RM упражнения за блок в процентах по лучшим подходам
+// тренировок; ok = false, если тренировок слишком мало для сравнения
+func performanceTrend(sets []repository.LoggedSets) (float64, bool) {
+	best := make(map[string]float64)
+	for _, s := range sets {
+		day := s.Date.Format("2006-01-02")
+		if e1rm := setE1RM(s); e1rm > best[day] {
+			best[day] = e1rm
+		}
+	}
+	if len(best) < perfMinSessions {
+		return 0, false
+	}
+
+	days := make([]string, 0, len(best))
+	for d := range best {
+		days = append(days, d)
+	}
+	sort.Strings(days)
+
+	var first, last float64
+	for i := 0; i < perfCompareSessions; i++ {
+		first += best[days[i]]
+		last += best[days[len(days)-1-i]]
+	}
+	if first == 0 {
+		return 0, false
+	}
+	return (last - first) / first * 100, true
+}
+
+// blockVolume подводит итоги блока по мышечным группам: рабочих подходов в неделю
+// и средняя динамика e1RM упражнений группы. muscleOf возвращает основную мышечную
+// группу упражнения ("" — неизвестна). RPE тренировок и боль заполняет вызывающий
+func blockVolume(sets []repository.LoggedSets, muscleOf func(string) string) map[string]progression.BlockOutcome {
+	weeks := make(map[int]bool)
+	totals := make(map[string]int)
+	byExercise := make(map[string][]repository.LoggedSets)
+	muscles := make(map[string]string)
+	for _, s := range sets {
+		weeks[s.WeekNum] = true
+		key := liftKey(s.ExerciseName)
+		muscle, ok := muscles[key]
+		if !ok {
+			muscle = muscleOf(s.ExerciseName)
+			muscles[key] = muscle
+		}
+		if muscle == "" || s.Reps <= 0 {
+			continue
+		}
+		totals[muscle] += s.Sets
+		byExercise[key] = append(byExercise[key], s)
+	}
+	if len(weeks) == 0 {
+		return nil
+	}
+
+	trends := make(map[string][]float64)
+	for key, exSets := range byExercise {
+		if pct, ok := performanceTrend(exSets); ok {
+			trends[muscles[key]] = append(trends[muscles[key]], pct)
+		}
+	}
+
+	outcomes := make(map[string]progression.BlockOutcome, len(totals))
+	for muscle, total := range totals {
+		o := progression.BlockOutcome{WeeklySets: float64(total) / float64(len(weeks))}
+		if t := trends[muscle]; len(t) > 0 {
+			var sum float64
+			for _, pct := range t {
+				sum += pct
+			}
+			o.Performance = sum / float64(len(t))
+			o.HasPerformance = true
+		}
+		outcomes[muscle] = o
+	}
+	return outcomes
+}
+
+// exerciseMuscle возвращает основную мышечную группу упражнения по базе генератора
+func exerciseMuscle(name string) string {
+	selector := getFitnessSelector()
+	if selector == nil {
+		return ""
+	}
+	ex := selector.FindExerciseByName(name)
+	if ex == nil || len(ex.PrimaryMuscles) == 0 {
+		return ""
+	}
+	return string(ex.PrimaryMuscles[0])
+}
+
+// loadVolumeFactors возвращает факторы адаптации объёма клиента по мышечным группам
+func (b *Bot) loadVolumeFactors(clientID int) map[string]float64 {
+	rows, err := b.db.Query(`
+		SELECT muscle_group, adaptation_factor
+		FROM public.client_volume_landmarks
+		WHERE client_id = $1`, clientID)
+	if err != nil {
+		log.Printf("Ошибка загрузки ориентиров объёма клиента %d: %v", clientID, err)
+		return nil
+	}
+	defer rows.Close()
+
+	factors := make(map[string]float64)
+	for rows.Next() {
+		var muscle string
+		var factor float64
+		if err := rows.Scan(&muscle, &factor); err != nil {
+			log.Printf("Ошибка чтения ориентиров объёма: %v", err)
+			return nil
+		}
+		factors[muscle] = factor
+	}
+	return factors
+}
+
+// programFatigue возвращает средний RPE тренировок программы и среднюю мышечную боль
+// из анкет готовности перед ними; 0 — данных нет
+func (b *Bot) programFatigue(programID int) (sessionRPE, soreness float64) {
+	var rpe, sore sql.NullFloat64
+	if err := b.db.QueryRow(`
+		SELECT AVG(session_rpe) FROM public.program_workouts
+		WHERE program_id = $1 AND status = 'completed'`, programID).Scan(&rpe); err != nil {
+		log.Printf("Ошибка расчёта RPE программы %d: %v", programID, err)
+	}
+	if err := b.db.QueryRow(`
+		SELECT AVG(rc.soreness) FROM public.readiness_checkins rc
+		JOIN public.program_workouts pw ON pw.id = rc.workout_id
+		WHERE pw.program_id = $1`, programID).Scan(&sore); err != nil {
+		log.Printf("Ошибка расчёта мышечной боли программы %d: %v", programID, err)
+	}
+	return rpe.Float64, sore.Float64
+}
+
+// adaptVolumeLandmarks подводит итоги мезоцикла, когда выполнена последняя тренировка
+// программы: пересчитывает ориентиры объёма клиента и сообщает тренеру, что и почему
+// изменилось. Повторно программа не оценивается
+func (b *Bot) adaptVolumeLandmarks(workoutID int) {
+	workout, err := b.repo.Program.GetWorkoutByID(workoutID)
+	if err != nil || workout == nil {
+		return
+	}
+	if open, err := b.repo.Program.CountOpenWorkouts(workout.ProgramID); err != nil || open > 0 {
+		return
+	}
+	var done bool
+	if err := b.db.QueryRow("SELECT EXISTS(SELECT 1 FROM public.volume_landmark_history WHERE program_id = $1)",
+		workout.ProgramID).Scan(&done); err != nil || done {
+		return
+	}
+
+	clientID, err := b.repo.Program.GetClientIDByWorkout(workoutID)
+	if err != nil || clientID == 0 {
+		return
+	}
+	sets, err := b.repo.Program.GetProgramSetLog(workout.ProgramID)
+	if err != nil {
+		log.Printf("Ошибка получения подходов программы %d: %v", workout.ProgramID, err)
+		return
+	}
+	outcomes := blockVolume(sets, exerciseMuscle)
+	if len(outcomes) == 0 {
+		return
+	}
+
+	sessionRPE, soreness := b.programFatigue(workout.ProgramID)
+	factors := b.loadVolumeFactors(clientID)
+
+	var changes []landmarkChange
+	for _, muscle := range landmarkMuscles {
+		o, ok := outcomes[muscle]
+		if !ok {
+			continue
+		}
+		o.SessionRPE, o.Soreness = sessionRPE, soreness
+		wc := progression.NewClientWorkCapacity(muscle, factors[muscle])
+		before := wc.AdaptationFactor
+		reason := wc.Adapt(o)
+		changes = append(changes, landmarkChange{
+			Muscle: muscle, Before: before, After: wc.AdaptationFactor, Outcome: o, Reason: reason,
+		})
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	if err := b.saveLandmarkChanges(clientID, workout.ProgramID, changes); err != nil {
+		log.Printf("Ошибка сохранения ориентиров объёма клиента %d: %v", clientID, err)
+		return
+	}
+	b.notifyTrainerVolumeLandmarks(clientID, workout.ProgramID, changes)
+}
+
+// saveLandmarkChanges сохраняет новые ориентиры и историю изменений одной транзакцией
+func (b *Bot) saveLandmarkChanges(clientID, programID int, changes []landmarkChange) error {
+	tx, err := b.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, c := range changes {
+		wc := progression.NewClientWorkCapacity(c.Muscle, c.After)
+		if _, err := tx.Exec(`
+			INSERT INTO public.client_volume_landmarks (client_id, muscle_group, adaptation_factor, mev, mav, mrv)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (client_id, muscle_group) DO UPDATE SET
+				adaptation_factor = EXCLUDED.adaptation_factor,
+				mev = EXCLUDED.mev, mav = EXCLUDED.mav, mrv = EXCLUDED.mrv,
+				updated_at = NOW()`,
+			clientID, c.Muscle, c.After, wc.CurrentMEV, wc.CurrentMAV, wc.CurrentMRV); err != nil {
+			return err
+		}
+
+		var perf interface{}
+		if c.Outcome.HasPerformance {
+			perf = c.Outcome.Performance
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO public.volume_landmark_history
+				(client_id, program_id, muscle_group, factor_before, factor_after, weekly_sets,
+				 session_rpe, soreness, performance, reason)
+			VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7::numeric, 0), NULLIF($8::numeric, 0), $9, $10)
+			ON CONFLICT (program_id, muscle_group) DO NOTHING`,
+			clientID, programID, c.Muscle, c.Before, c.After, c.Outcome.WeeklySets,
+			c.Outcome.SessionRPE, c.Outcome.Soreness, perf, string(c.Reason)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// formatLandmarkChange — строка об изменении ориентиров: MEV/MAV/MRV до и после,
+// итоги блока и причина
+func (b *Bot) formatLandmarkChange(chatID int64, c landmarkChange) string {
+	before := progression.NewClientWorkCapacity(c.Muscle, c.Before)
+	after := progression.NewClientWorkCapacity(c.Muscle, c.After)
+	muscle := b.t("muscle_"+c.Muscle, chatID)
+
+	var line string
+	if before.CurrentMEV == after.CurrentMEV && before.CurrentMAV == after.CurrentMAV && before.CurrentMRV == after.CurrentMRV {
+		line = b.tf("volume_landmarks_same", chatID, muscle, after.CurrentMEV, after.CurrentMAV, after.CurrentMRV)
+	} else {
+		line = b.tf("volume_landmarks_changed", chatID, muscle,
+			before.CurrentMEV, before.CurrentMAV, before.CurrentMRV, after.CurrentMEV, after.CurrentMAV, after.CurrentMRV)
+	}
+
+	facts := []string{b.tf("volume_landmarks_sets", chatID, c.Outcome.WeeklySets)}
+	if c.Outcome.SessionRPE > 0 {
+		facts = append(facts, b.tf("volume_landmarks_rpe", chatID, c.Outcome.SessionRPE))
+	}
+	if c.Outcome.Soreness > 0 {
+		facts = append(facts, b.tf("volume_landmarks_soreness", chatID, c.Outcome.Soreness))
+	}
+	if c.Outcome.HasPerformance {
+		facts = append(facts, b.tf("volume_landmarks_perf", chatID, c.Outcome.Performance))
+	}
+	return line + "\n   " + strings.Join(facts, " · ") + "\n   " + b.t("volume_reason_"+string(c.Reason), chatID)
+}
+
+// notifyTrainerVolumeLandmarks сообщает тренеру об адаптации объёма по итогам блока
+func (b *Bot) notifyTrainerVolumeLandmarks(clientID, programID int, changes []landmarkChange) {
+	trainerID, err := b.repo.Admin.GetFirst()
+	if err != nil {
+		log.Printf("Ошибка получения тренера: %v", err)
+		return
+	}
+	client, _ := b.repo.Client.GetByID(clientID)
+	program, _ := b.repo.Program.GetProgramByID(programID)
+	if client == nil || program == nil {
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(b.tf("volume_landmarks_title", trainerID, client.Name, client.Surname) + "\n")
+	text.WriteString(b.tf("volume_landmarks_block", trainerID, program.Name) + "\n")
+	for _, c := range changes {
+		text.WriteString("\n" + b.formatLandmarkChange(trainerID, c) + "\n")
+	}
+
+	msg := tgbotapi.NewMessage(trainerID, text.String())
+	msg.ParseMode = "Markdown"
+	if err := b.outbox.Enqueue(msg); err != nil {
+		log.Printf("Ошибка постановки уведомления об объёме в очередь: %v", err)
+	}
+}
+
+// showVolumeLandmarks показывает тренеру текущие ориентиры объёма клиента
+// и последнюю причину изменения по каждой мышечной группе
+func (b *Bot) showVolumeLandmarks(chatID int64, clientID, messageID int) {
+	rows, err := b.db.Query(`
+		SELECT DISTINCT ON (muscle_group) muscle_group, factor_before, factor_after, weekly_sets,
+		       COALESCE(session_rpe, 0), COALESCE(soreness, 0), performance, reason, created_at
+		FROM public.volume_landmark_history
+		WHERE client_id = $1
+		ORDER BY muscle_group, created_at DESC`, clientID)
+	if err != nil {
+		b.sendError(chatID, b.t("error", chatID), err)
+		return
+	}
+	defer rows.Close()
+
+	latest := make(map[string]landmarkChange)
+	var last time.Time
+	for rows.Next() {
+		var c landmarkChange
+		var perf sql.NullFloat64
+		var reason string
+		if err := rows.Scan(&c.Muscle, &c.Before, &c.After, &c.Outcome.WeeklySets,
+			&c.Outcome.SessionRPE, &c.Outcome.Soreness, &perf, &reason, &c.Date); err != nil {
+			b.sendError(chatID, b.t("error", chatID), err)
+			return
+		}
+		c.Outcome.Performance, c.Outcome.HasPerformance = perf.Float64, perf.Valid
+		c.Reason = progression.AdaptationReason(reason)
+		latest[c.Muscle] = c
+		if c.Date.After(last) {
+			last = c.Date
+		}
+	}
+	if err := rows.Err(); err != nil {
+		b.sendError(chatID, b.t("error", chatID), err)
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(b.t("volume_landmarks_view_title", chatID) + "\n")
+	if len(latest) == 0 {
+		text.WriteString("\n" + b.t("volume_landmarks_empty", chatID))
+	} else {
+		text.WriteString(b.tf("volume_landmarks_updated", chatID, last.In(b.userLocation(chatID)).Format("02.01.2006")) + "\n")
+		for _, muscle := range landmarkMuscles {
+			if c, ok := latest[muscle]; ok {
+				text.WriteString("\n" + b.formatLandmarkChange(chatID, c) + "\n")
+			}
+		}
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("workout_btn_back_progress", chatID), fmt.Sprintf("prog_back_%d", clientID)),
+	))
+	b.editMessage(chatID, messageID, text.String(), &keyboard)
+}
@@ -0,0 +1,59 @@
+package bot
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"workbot/internal/repository"
+)
+
+func TestBlockVolume(t *testing.T) {
+	day := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	muscles := map[string]string{"жим лежа": "chest", "разведения": "chest", "присед": "quads"}
+	muscleOf := func(name string) string { return muscles[liftKey(name)] }
+
+	var sets []repository.LoggedSets
+	// Жим: 4 недели по 2 тренировки, 4 подхода, вес растёт на 2.5 кг в неделю
+	for week := 1; week <= 4; week++ {
+		for session := 0; session < 2; session++ {
+			date := day.AddDate(0, 0, (week-1)*7+session*3)
+			for i := 0; i < 4; i++ {
+				sets = append(sets, repository.LoggedSets{
+					ExerciseName: "Жим лёжа", WeekNum: week, Date: date, Sets: 1,
+					Reps: 8, Weight: 80 + 2.5*float64(week-1),
+				})
+			}
+		}
+	}
+	// Разведения без записи по подходам: 3 подхода раз в неделю
+	for week := 1; week <= 4; week++ {
+		sets = append(sets, repository.LoggedSets{
+			ExerciseName: "Разведения", WeekNum: week, Date: day.AddDate(0, 0, (week-1)*7), Sets: 3, Reps: 12, Weight: 14,
+		})
+	}
+	// Присед: одна тренировка — сравнивать не с чем; неизвестное упражнение не учитывается
+	sets = append(sets,
+		repository.LoggedSets{ExerciseName: "Присед", WeekNum: 1, Date: day, Sets: 1, Reps: 5, Weight: 100, RPE: 8},
+		repository.LoggedSets{ExerciseName: "Планка", WeekNum: 1, Date: day, Sets: 3, Reps: 1},
+	)
+
+	got := blockVolume(sets, muscleOf)
+	if len(got) != 2 {
+		t.Fatalf("blockVolume = %+v, хотим chest и quads", got)
+	}
+
+	chest := got["chest"]
+	if chest.WeeklySets != 11 { // (32 + 12) / 4
+		t.Errorf("chest WeeklySets = %v, хотим 11", chest.WeeklySets)
+	}
+	// Лучшие подходы: 80, 80 в начале и 87.5, 87.5 в конце; разведения: 14 → 14
+	wantPerf := (87.5/80*100 - 100) / 2
+	if !chest.HasPerformance || math.Abs(chest.Performance-wantPerf) > 0.01 {
+		t.Errorf("chest Performance = %v (%v), хотим %.2f", chest.Performance, chest.HasPerformance, wantPerf)
+	}
+
+	if quads := got["quads"]; quads.WeeklySets != 0.25 || quads.HasPerformance {
+		t.Errorf("quads = %+v, хотим 0.25 подхода в неделю без динамики", quads)
+	}
+}
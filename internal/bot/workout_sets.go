@@ -0,0 +1,451 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"workbot/internal/models"
+	"workbot/internal/training"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	// weightStep шаг изменения веса кнопками ±
+	weightStep = 2.5
+	// defaultRestSeconds отдых, если в плане не указан
+	defaultRestSeconds = 90
+	// restTimerTick как часто обновлять сообщение с обратным отсчётом
+	restTimerTick = 15 * time.Second
+)
+
+// restTimers хранит каналы остановки активных таймеров отдыха
+var restTimers = struct {
+	sync.Mutex
+	stop map[int64]chan struct{}
+}{stop: make(map[int64]chan struct{})}
+
+// ensureSetSuggestion заполняет предложение для следующего подхода:
+// последний записанный подход или плановые повторы и вес
+func ensureSetSuggestion(session *WorkoutSession, exercise models.WorkoutExercise, sets []models.WorkoutSet) {
+	if session.NextSetFor == exercise.ID {
+		return
+	}
+	session.NextSetFor = exercise.ID
+	if len(sets) > 0 {
+		last := sets[len(sets)-1]
+		session.NextSetReps = last.Reps
+		session.NextSetWeight = last.Weight
+		return
+	}
+	session.NextSetReps = parsePlannedReps(exercise.Reps)
+	session.NextSetWeight = exercise.Weight
+}
+
+// parsePlannedReps извлекает число повторов из плана ("8-10" → 8, "5" → 5)
+func parsePlannedReps(reps string) int {
+	reps = strings.TrimSpace(reps)
+	end := 0
+	for end < len(reps) && reps[end] >= '0' && reps[end] <= '9' {
+		end++
+	}
+	n, err := strconv.Atoi(reps[:end])
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// parseSetInput разбирает подход, введённый текстом.
+// Поддерживаемые форматы: "5x100", "5х100 @8", "5 100 8", "12" (без веса)
+func parseSetInput(text string) (reps int, weight float64, rpe float64, err error) {
+	s := strings.ToLower(strings.TrimSpace(text))
+	s = strings.NewReplacer(",", ".", "х", "x", "×", "x", "*", "x", "кг", "", "kg", "", "rpe", "@").Replace(s)
+
+	if i := strings.Index(s, "@"); i >= 0 {
+		rpe, err = strconv.ParseFloat(strings.TrimSpace(s[i+1:]), 64)
+		if err != nil {
+			return 0, 0, 0, ValidationError{Field: "rpe", Message: "validation_rpe_invalid"}
+		}
+		s = s[:i]
+	}
+
+	var fields []string
+	if strings.Contains(s, "x") {
+		for _, f := range strings.Split(s, "x") {
+			fields = append(fields, strings.TrimSpace(f))
+		}
+	} else {
+		fields = strings.Fields(s)
+	}
+
+	if len(fields) == 0 || len(fields) > 3 {
+		return 0, 0, 0, ValidationError{Field: "set", Message: "validation_set_format"}
+	}
+
+	reps, err = strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, 0, ValidationError{Field: "reps", Message: "validation_reps_invalid"}
+	}
+	if err := validateReps(reps); err != nil {
+		return 0, 0, 0, err
+	}
+
+	if len(fields) >= 2 {
+		weight, err = strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return 0, 0, 0, ValidationError{Field: "weight", Message: "validation_weight_invalid"}
+		}
+		if err := validateWeight(weight); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+
+	if len(fields) == 3 {
+		if rpe > 0 {
+			return 0, 0, 0, ValidationError{Field: "rpe", Message: "validation_rpe_twice"}
+		}
+		rpe, err = strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return 0, 0, 0, ValidationError{Field: "rpe", Message: "validation_rpe_invalid"}
+		}
+	}
+
+	if rpe != 0 && (rpe < 1 || rpe > 10) {
+		return 0, 0, 0, ValidationError{Field: "rpe", Message: "validation_rpe_range"}
+	}
+
+	return reps, weight, rpe, nil
+}
+
+// formatRestDuration форматирует секунды как м:сс
+func formatRestDuration(seconds int) string {
+	if seconds < 0 {
+		seconds = 0
+	}
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// formatSetLoad форматирует повторы и вес подхода для пользователя
+func (b *Bot) formatSetLoad(chatID int64, reps int, weight float64) string {
+	if weight > 0 {
+		return b.tf("workout_set_load", chatID, reps, weight)
+	}
+	return b.tf("workout_set_load_reps", chatID, reps)
+}
+
+// writeLoggedSets добавляет к тексту упражнения список записанных подходов
+func (b *Bot) writeLoggedSets(text *strings.Builder, chatID int64, sets []models.WorkoutSet) {
+	if len(sets) == 0 {
+		return
+	}
+	text.WriteString("\n\n")
+	text.WriteString(b.t("workout_sets_logged", chatID))
+	for _, s := range sets {
+		text.WriteString(fmt.Sprintf("\n%d) %s", s.SetNum, b.formatSetLoad(chatID, s.Reps, s.Weight)))
+		if s.RPE > 0 {
+			text.WriteString(b.tf("workout_set_rpe", chatID, s.RPE))
+			if e1rm := training.EstimateE1RM(s.Weight, s.Reps, s.RPE); e1rm > 0 {
+				text.WriteString(b.tf("workout_set_e1rm", chatID, e1rm))
+			}
+		}
+	}
+}
+
+// setLoggingRows возвращает кнопки записи подхода для текущего упражнения
+func (b *Bot) setLoggingRows(chatID int64, session *WorkoutSession, exercise models.WorkoutExercise, sets []models.WorkoutSet) [][]tgbotapi.InlineKeyboardButton {
+	var rows [][]tgbotapi.InlineKeyboardButton
+
+	if session.NextSetReps > 0 {
+		logBtn := tgbotapi.NewInlineKeyboardButtonData(
+			"✅ "+b.formatSetLoad(chatID, session.NextSetReps, session.NextSetWeight),
+			fmt.Sprintf("workout_setlog_%d", exercise.ID),
+		)
+		if session.NextSetWeight > 0 {
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("➖2.5", fmt.Sprintf("workout_setadj_%d_minus", exercise.ID)),
+				logBtn,
+				tgbotapi.NewInlineKeyboardButtonData("➕2.5", fmt.Sprintf("workout_setadj_%d_plus", exercise.ID)),
+			))
+		} else {
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(logBtn))
+		}
+	}
+
+	inputRow := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			b.t("workout_btn_set_input", chatID),
+			fmt.Sprintf("workout_setinput_%d", exercise.ID),
+		),
+	)
+	if len(sets) > 0 {
+		inputRow = append(inputRow, tgbotapi.NewInlineKeyboardButtonData(
+			b.t("workout_btn_set_undo", chatID),
+			fmt.Sprintf("workout_setundo_%d", exercise.ID),
+		))
+	}
+	rows = append(rows, inputRow)
+
+	return rows
+}
+
+// handleSetCallback обрабатывает кнопки записи подходов
+func (b *Bot) handleSetCallback(chatID int64, data string, messageID int) {
+	session := getWorkoutSession(chatID)
+	if session == nil {
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(data, "workout_setlog_"):
+		exerciseID, _ := strconv.Atoi(strings.TrimPrefix(data, "workout_setlog_"))
+		if session.NextSetFor != exerciseID || session.NextSetReps <= 0 {
+			return
+		}
+		b.logWorkoutSet(chatID, exerciseID, session.NextSetReps, session.NextSetWeight, 0)
+
+	case strings.HasPrefix(data, "workout_setadj_"):
+		parts := strings.Split(strings.TrimPrefix(data, "workout_setadj_"), "_")
+		if len(parts) != 2 {
+			return
+		}
+		exerciseID, _ := strconv.Atoi(parts[0])
+		if session.NextSetFor != exerciseID {
+			return
+		}
+		if parts[1] == "plus" {
+			session.NextSetWeight += weightStep
+		} else if session.NextSetWeight > weightStep {
+			session.NextSetWeight -= weightStep
+		}
+		setWorkoutSession(chatID, session)
+		b.showCurrentExercise(chatID, messageID)
+
+	case strings.HasPrefix(data, "workout_setinput_"):
+		exerciseID, _ := strconv.Atoi(strings.TrimPrefix(data, "workout_setinput_"))
+		setState(chatID, fmt.Sprintf("workout_set_%d", exerciseID))
+		b.sendMessage(chatID, b.t("workout_set_enter", chatID))
+
+	case strings.HasPrefix(data, "workout_setundo_"):
+		exerciseID, _ := strconv.Atoi(strings.TrimPrefix(data, "workout_setundo_"))
+		if !b.ownsSessionExercise(chatID, session, exerciseID) {
+			return
+		}
+		if err := b.repo.Program.DeleteLastWorkoutSet(exerciseID); err != nil {
+			log.Printf("Ошибка удаления подхода: %v", err)
+			return
+		}
+		if err := b.repo.Program.SyncExerciseResultFromSets(exerciseID); err != nil {
+			log.Printf("Ошибка обновления результата упражнения: %v", err)
+		}
+		b.stopRestTimer(chatID)
+		session.NextSetFor = 0
+		setWorkoutSession(chatID, session)
+		b.showCurrentExercise(chatID, messageID)
+
+	case strings.HasPrefix(data, "workout_setrpe_"):
+		// workout_setrpe_<exerciseID>_<setID>_<rpe>
+		parts := strings.Split(strings.TrimPrefix(data, "workout_setrpe_"), "_")
+		if len(parts) != 3 {
+			return
+		}
+		exerciseID, _ := strconv.Atoi(parts[0])
+		setID, _ := strconv.Atoi(parts[1])
+		rpe, _ := strconv.ParseFloat(parts[2], 64)
+		if !b.ownsSessionExercise(chatID, session, exerciseID) {
+			return
+		}
+		if err := b.repo.Program.UpdateWorkoutSetRPE(exerciseID, setID, rpe); err != nil {
+			log.Printf("Ошибка сохранения RPE подхода: %v", err)
+			return
+		}
+		if err := b.repo.Program.SyncExerciseResultFromSets(exerciseID); err != nil {
+			log.Printf("Ошибка обновления результата упражнения: %v", err)
+		}
+		if session.MessageID > 0 {
+			b.showCurrentExercise(chatID, session.MessageID)
+		}
+	}
+}
+
+// ownsSessionExercise проверяет, что упражнение из кнопки относится к активной тренировке
+// и эта тренировка — клиента chatID. Устаревшая или подделанная кнопка не меняет чужой журнал
+func (b *Bot) ownsSessionExercise(chatID int64, session *WorkoutSession, exerciseID int) bool {
+	exercise, err := b.repo.Program.GetExerciseByID(exerciseID)
+	if err != nil || exercise == nil || exercise.WorkoutID != session.WorkoutID {
+		return false
+	}
+	clientID, err := b.repo.Program.GetClientIDByWorkout(exercise.WorkoutID)
+	if err != nil || clientID == 0 {
+		return false
+	}
+	return b.isClientOwner(chatID, clientID)
+}
+
+// handleWorkoutSetInput обрабатывает подход, введённый текстом
+func (b *Bot) handleWorkoutSetInput(message *tgbotapi.Message, exerciseIDStr string) {
+	chatID := message.Chat.ID
+
+	reps, weight, rpe, err := parseSetInput(message.Text)
+	if err != nil {
+		b.sendMessage(chatID, b.errorText(err, chatID)+"\n"+b.t("workout_set_invalid", chatID))
+		return
+	}
+
+	clearState(chatID)
+	exerciseID, _ := strconv.Atoi(exerciseIDStr)
+	b.logWorkoutSet(chatID, exerciseID, reps, weight, rpe)
+}
+
+// logWorkoutSet сохраняет подход, обновляет карточку упражнения и запускает таймер отдыха
+func (b *Bot) logWorkoutSet(chatID int64, exerciseID, reps int, weight, rpe float64) {
+	session := getWorkoutSession(chatID)
+	if session == nil {
+		return
+	}
+	idx := findSessionExercise(session, exerciseID)
+	if idx < 0 {
+		return
+	}
+	exercise := session.Exercises[idx]
+
+	set, err := b.repo.Program.AddWorkoutSet(exerciseID, reps, weight, rpe)
+	if err != nil {
+		b.sendError(chatID, b.t("error", chatID), err)
+		return
+	}
+	if err := b.repo.Program.SyncExerciseResultFromSets(exerciseID); err != nil {
+		log.Printf("Ошибка обновления результата упражнения: %v", err)
+	}
+
+	// Следующий подход по умолчанию повторяет только что записанный
+	session.NextSetFor = exerciseID
+	session.NextSetReps = reps
+	session.NextSetWeight = weight
+	setWorkoutSession(chatID, session)
+
+	if session.MessageID > 0 {
+		b.showCurrentExercise(chatID, session.MessageID)
+	}
+
+	b.startRestTimer(chatID, exercise, set)
+}
+
+// startRestTimer отправляет сообщение с обратным отсчётом отдыха и кнопками RPE
+func (b *Bot) startRestTimer(chatID int64, exercise models.WorkoutExercise, set *models.WorkoutSet) {
+	b.stopRestTimer(chatID)
+
+	restSeconds := exercise.RestSeconds
+	if restSeconds <= 0 {
+		restSeconds = defaultRestSeconds
+	}
+
+	keyboard := b.setRPEKeyboard(exercise.ID, set.ID)
+	header := b.tf("workout_set_logged", chatID, set.SetNum, b.formatSetLoad(chatID, set.Reps, set.Weight))
+
+	msg := tgbotapi.NewMessage(chatID, b.restTimerText(chatID, header, restSeconds, set.RPE == 0))
+	msg.ReplyMarkup = keyboard
+	sent, err := b.api.Send(msg)
+	if err != nil {
+		log.Printf("Ошибка отправки таймера отдыха: %v", err)
+		return
+	}
+
+	stop := make(chan struct{})
+	restTimers.Lock()
+	restTimers.stop[chatID] = stop
+	restTimers.Unlock()
+
+	nextSet := set.SetNum + 1
+	hasNextSet := nextSet <= exercise.Sets
+	go b.runRestTimer(chatID, sent.MessageID, header, restSeconds, keyboard, stop, nextSet, hasNextSet)
+}
+
+// runRestTimer обновляет обратный отсчёт и уведомляет об окончании отдыха
+func (b *Bot) runRestTimer(chatID int64, messageID int, header string, restSeconds int,
+	keyboard tgbotapi.InlineKeyboardMarkup, stop chan struct{}, nextSet int, hasNextSet bool) {
+
+	end := time.Now().Add(time.Duration(restSeconds) * time.Second)
+
+	for {
+		remaining := time.Until(end)
+		if remaining <= 0 {
+			break
+		}
+		wait := restTimerTick
+		if remaining < wait {
+			wait = remaining
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(wait):
+		}
+
+		remaining = time.Until(end)
+		if remaining > 0 {
+			edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID,
+				b.restTimerText(chatID, header, int(remaining.Seconds()+0.5), true), keyboard)
+			if _, err := b.api.Send(edit); err != nil {
+				log.Printf("Ошибка обновления таймера отдыха: %v", err)
+			}
+		}
+	}
+
+	restTimers.Lock()
+	if restTimers.stop[chatID] != stop {
+		// Таймер уже заменён новым подходом
+		restTimers.Unlock()
+		return
+	}
+	delete(restTimers.stop, chatID)
+	restTimers.Unlock()
+
+	edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID,
+		header+"\n"+b.t("workout_rest_done", chatID)+"\n\n"+b.t("workout_set_rate", chatID), keyboard)
+	if _, err := b.api.Send(edit); err != nil {
+		log.Printf("Ошибка обновления таймера отдыха: %v", err)
+	}
+
+	// Отдельное сообщение, чтобы пришло уведомление
+	if hasNextSet {
+		b.sendMessage(chatID, b.tf("workout_rest_over", chatID, nextSet))
+	} else {
+		b.sendMessage(chatID, b.t("workout_rest_over_next", chatID))
+	}
+}
+
+// stopRestTimer останавливает активный таймер отдыха
+func (b *Bot) stopRestTimer(chatID int64) {
+	restTimers.Lock()
+	defer restTimers.Unlock()
+	if stop, ok := restTimers.stop[chatID]; ok {
+		close(stop)
+		delete(restTimers.stop, chatID)
+	}
+}
+
+// restTimerText формирует текст сообщения таймера отдыха
+func (b *Bot) restTimerText(chatID int64, header string, remaining int, askRPE bool) string {
+	text := header + "\n" + b.tf("workout_rest_running", chatID, formatRestDuration(remaining))
+	if askRPE {
+		text += "\n\n" + b.t("workout_set_rate", chatID)
+	}
+	return text
+}
+
+// setRPEKeyboard кнопки оценки RPE подхода
+func (b *Bot) setRPEKeyboard(exerciseID, setID int) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for _, rpe := range []string{"6", "7", "8", "9", "10"} {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			rpe, fmt.Sprintf("workout_setrpe_%d_%d_%s", exerciseID, setID, rpe),
+		))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(row)
+}
@@ -0,0 +1,84 @@
+package bot
+
+import "testing"
+
+func TestParseSetInput(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantReps   int
+		wantWeight float64
+		wantRPE    float64
+		wantErr    bool
+	}{
+		{"latin x", "5x100", 5, 100, 0, false},
+		{"cyrillic x", "5х100", 5, 100, 0, false},
+		{"multiply sign", "8 × 62,5", 8, 62.5, 0, false},
+		{"with rpe", "5x100 @8", 5, 100, 8, false},
+		{"with rpe word", "5x100 rpe 8.5", 5, 100, 8.5, false},
+		{"space separated", "5 100", 5, 100, 0, false},
+		{"space separated with rpe", "5 100 9", 5, 100, 9, false},
+		{"with kg", "10x40кг", 10, 40, 0, false},
+		{"reps only", "12", 12, 0, 0, false},
+		{"reps only with rpe", "12 @7", 12, 0, 7, false},
+		{"empty", "", 0, 0, 0, true},
+		{"text", "много", 0, 0, 0, true},
+		{"zero reps", "0x100", 0, 0, 0, true},
+		{"too heavy", "1x600", 0, 0, 0, true},
+		{"rpe out of range", "5x100 @11", 0, 0, 0, true},
+		{"rpe twice", "5 100 8 @9", 0, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reps, weight, rpe, err := parseSetInput(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSetInput(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if reps != tt.wantReps || weight != tt.wantWeight || rpe != tt.wantRPE {
+				t.Errorf("parseSetInput(%q) = %d, %v, %v; want %d, %v, %v",
+					tt.input, reps, weight, rpe, tt.wantReps, tt.wantWeight, tt.wantRPE)
+			}
+		})
+	}
+}
+
+func TestParsePlannedReps(t *testing.T) {
+	tests := []struct {
+		reps string
+		want int
+	}{
+		{"5", 5},
+		{"8-10", 8},
+		{" 12 ", 12},
+		{"AMRAP", 0},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		if got := parsePlannedReps(tt.reps); got != tt.want {
+			t.Errorf("parsePlannedReps(%q) = %d, want %d", tt.reps, got, tt.want)
+		}
+	}
+}
+
+func TestFormatRestDuration(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    string
+	}{
+		{90, "1:30"},
+		{180, "3:00"},
+		{5, "0:05"},
+		{-1, "0:00"},
+	}
+
+	for _, tt := range tests {
+		if got := formatRestDuration(tt.seconds); got != tt.want {
+			t.Errorf("formatRestDuration(%d) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
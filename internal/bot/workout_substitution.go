@@ -0,0 +1,360 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"workbot/internal/generator"
+	"workbot/internal/i18n"
+	"workbot/internal/models"
+	"workbot/internal/training"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	// maxSubstituteOptions сколько вариантов замены показывать клиенту
+	maxSubstituteOptions = 4
+	// substitutionSuggestThreshold после скольких одинаковых замен предлагать тренеру правку программы
+	substitutionSuggestThreshold = 2
+)
+
+// substitutionReasons причины замены в порядке отображения
+var substitutionReasons = []string{"busy", "noequip", "knee", "lower_back", "shoulder", "wrist"}
+
+// substitutionReasonZones причины, связанные с болью, и соответствующие зоны тела
+var substitutionReasonZones = map[string]models.BodyZone{
+	"knee":       models.ZoneKnee,
+	"lower_back": models.ZoneLowerBack,
+	"shoulder":   models.ZoneShoulder,
+	"wrist":      models.ZoneWrist,
+}
+
+// findSessionExercise возвращает индекс упражнения в сессии по ID
+func findSessionExercise(session *WorkoutSession, exerciseID int) int {
+	for i := range session.Exercises {
+		if session.Exercises[i].ID == exerciseID {
+			return i
+		}
+	}
+	return -1
+}
+
+// showSubstitutionReasons спрашивает причину замены упражнения
+func (b *Bot) showSubstitutionReasons(chatID int64, exerciseID int, messageID int) {
+	session := getWorkoutSession(chatID)
+	if session == nil {
+		return
+	}
+	idx := findSessionExercise(session, exerciseID)
+	if idx < 0 {
+		return
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+	for _, reason := range substitutionReasons {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			b.t("workout_swap_reason_"+reason, chatID),
+			fmt.Sprintf("workout_swapwhy_%d_%s", exerciseID, reason),
+		))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(b.t("workout_btn_back", chatID), "workout_swapback"),
+	))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	text := b.tf("workout_swap_reason_title", chatID, session.Exercises[idx].ExerciseName)
+	b.editMessage(chatID, messageID, text, &keyboard)
+}
+
+// showSubstitutes показывает варианты замены с учётом оборудования и ограничений клиента
+func (b *Bot) showSubstitutes(chatID int64, exerciseID int, reason string, messageID int) {
+	session := getWorkoutSession(chatID)
+	if session == nil {
+		return
+	}
+	idx := findSessionExercise(session, exerciseID)
+	if idx < 0 {
+		return
+	}
+	exercise := session.Exercises[idx]
+
+	substitutes := b.findSubstitutes(session.WorkoutID, exercise.ExerciseName, reason)
+
+	backKeyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("workout_btn_back", chatID), "workout_swapback"),
+		),
+	)
+
+	if len(substitutes) == 0 {
+		b.editMessage(chatID, messageID, b.tf("workout_swap_none", chatID, exercise.ExerciseName), &backKeyboard)
+		return
+	}
+
+	session.Substitutes = substitutes
+	session.SubstituteReason = reason
+	setWorkoutSession(chatID, session)
+
+	lang := b.getLanguage(chatID)
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, sub := range substitutes {
+		name := sub.NameRu
+		if lang == i18n.LangEnglish && sub.NameEn != "" {
+			name = sub.NameEn
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"🔁 "+name,
+				fmt.Sprintf("workout_swapto_%d_%d", exerciseID, i),
+			),
+		))
+	}
+	rows = append(rows, backKeyboard.InlineKeyboard...)
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	b.editMessage(chatID, messageID, b.tf("workout_swap_choose", chatID, exercise.ExerciseName), &keyboard)
+}
+
+// findSubstitutes подбирает замены через ExerciseSelector
+func (b *Bot) findSubstitutes(workoutID int, exerciseName, reason string) []models.ExerciseExt {
+	selector := getFitnessSelector()
+	if selector == nil {
+		return nil
+	}
+
+	original := selector.FindExerciseByName(exerciseName)
+	if original == nil {
+		return nil
+	}
+
+	criteria := generator.SelectionCriteria{}
+
+	clientID, _ := b.repo.Program.GetClientIDByWorkout(workoutID)
+	if profile, err := b.loadClientProfile(clientID); err == nil {
+		criteria.Equipment = profile.AvailableEquip
+		criteria.Constraints = profile.Constraints
+	}
+
+	switch reason {
+	case "busy", "noequip":
+		// Оборудование исходного упражнения недоступно
+		criteria.ExcludeEquipment = busyEquipment(original.Equipment)
+	default:
+		if zone, ok := substitutionReasonZones[reason]; ok {
+			criteria.Constraints = append(criteria.Constraints, models.ClientConstraint{
+				BodyZone: zone,
+				Severity: models.SeverityAbsolute,
+			})
+			criteria.MaxDifficulty = original.Difficulty
+		}
+	}
+
+	return selector.FindSubstitutes(original, criteria, maxSubstituteOptions)
+}
+
+// busyEquipment возвращает оборудование исходного упражнения, которое считается занятым.
+// Скамья и собственный вес не занимаются — их хватает на всех
+func busyEquipment(equipment []models.EquipmentType) []models.EquipmentType {
+	var busy []models.EquipmentType
+	for _, eq := range equipment {
+		if eq != models.EquipmentBench && eq != models.EquipmentBodyweight {
+			busy = append(busy, eq)
+		}
+	}
+	return busy
+}
+
+// applySubstitution заменяет упражнение в тренировке и пересчитывает рабочий вес
+func (b *Bot) applySubstitution(chatID int64, exerciseID int, subIdx int, messageID int) {
+	session := getWorkoutSession(chatID)
+	if session == nil || subIdx < 0 || subIdx >= len(session.Substitutes) {
+		return
+	}
+	idx := findSessionExercise(session, exerciseID)
+	if idx < 0 {
+		return
+	}
+
+	exercise := &session.Exercises[idx]
+	substitute := session.Substitutes[subIdx]
+	reason := session.SubstituteReason
+
+	clientID, _ := b.repo.Program.GetClientIDByWorkout(session.WorkoutID)
+	newWeight := b.resolveSubstituteWeight(clientID, exercise, &substitute)
+
+	if err := b.repo.Program.SubstituteExercise(exercise.ID, substitute.NameRu, newWeight, reason); err != nil {
+		b.sendError(chatID, b.t("error", chatID), err)
+		return
+	}
+
+	original := exercise.OriginalExerciseName
+	if original == "" {
+		original = exercise.ExerciseName
+	}
+
+	exercise.OriginalExerciseName = original
+	exercise.SubstitutionReason = reason
+	exercise.ExerciseName = substitute.NameRu
+	exercise.Weight = newWeight
+	if newWeight == 0 && exercise.RPE == 0 {
+		exercise.Notes = strings.TrimSpace(exercise.Notes + "\n" + b.t("workout_swap_pick_weight", chatID))
+	}
+
+	session.Substitutes = nil
+	session.SubstituteReason = ""
+	setWorkoutSession(chatID, session)
+
+	b.checkRepeatedSubstitution(session.WorkoutID, clientID, exercise.ID, original, substitute.NameRu)
+
+	b.showCurrentExercise(chatID, messageID)
+}
+
+// resolveSubstituteWeight пересчитывает рабочий вес замены от 1ПМ клиента.
+// Возвращает 0, если 1ПМ для замены неизвестен (вес подбирается по ощущениям).
+func (b *Bot) resolveSubstituteWeight(clientID int, exercise *models.WorkoutExercise, substitute *models.ExerciseExt) float64 {
+	pmByName, err := b.repo.Exercise.GetClient1PMByName(clientID)
+	if err != nil || len(pmByName) == 0 {
+		return 0
+	}
+	return substituteWeight(pmByName, exercise, substitute)
+}
+
+// substituteWeight считает вес замены по таблице 1ПМ клиента.
+// Процент берётся из плана, а если его нет — из отношения планового веса к 1ПМ исходного упражнения.
+func substituteWeight(pmByName map[string]float64, exercise *models.WorkoutExercise, substitute *models.ExerciseExt) float64 {
+	lookup := func(names ...string) float64 {
+		for _, name := range names {
+			key := generator.NormalizeExerciseName(name)
+			if key == "" {
+				continue
+			}
+			for pmName, pm := range pmByName {
+				if generator.NormalizeExerciseName(pmName) == key {
+					return pm
+				}
+			}
+		}
+		return 0
+	}
+
+	percent := exercise.WeightPercent
+	if percent <= 0 && exercise.Weight > 0 {
+		if pm := lookup(exercise.ExerciseName, exercise.OriginalExerciseName); pm > 0 {
+			percent = exercise.Weight / pm * 100
+		}
+	}
+	if percent <= 0 {
+		return 0
+	}
+
+	pm := lookup(substitute.NameRu, substitute.NameEn)
+	if pm <= 0 {
+		return 0
+	}
+	return training.CalculateWorkingWeightRound(pm, percent, 2.5)
+}
+
+// substitutionWeightRatio — во сколько раз вес замены отличается от исходного.
+// 0 — пересчитать нельзя, плановый вес сбрасывается
+func substitutionWeightRatio(originalWeight, newWeight float64) float64 {
+	if originalWeight <= 0 || newWeight <= 0 {
+		return 0
+	}
+	return newWeight / originalWeight
+}
+
+// isRepeatedSubstitution — замена повторяется достаточно часто, чтобы предложить её тренеру
+func isRepeatedSubstitution(count int) bool {
+	return count >= substitutionSuggestThreshold
+}
+
+// checkRepeatedSubstitution предлагает тренеру закрепить замену, если она повторяется
+func (b *Bot) checkRepeatedSubstitution(workoutID, clientID, exerciseID int, original, replacement string) {
+	workout, err := b.repo.Program.GetWorkoutByID(workoutID)
+	if err != nil || workout == nil {
+		return
+	}
+
+	count, err := b.repo.Program.CountProgramSubstitutions(workout.ProgramID, original, replacement)
+	if err != nil {
+		log.Printf("Ошибка подсчёта замен: %v", err)
+		return
+	}
+	if !isRepeatedSubstitution(count) {
+		return
+	}
+
+	trainerID, err := b.repo.Admin.GetFirst()
+	if err != nil {
+		log.Printf("Ошибка получения тренера: %v", err)
+		return
+	}
+
+	clientName := b.t("substitution_client", trainerID)
+	if client, _ := b.repo.Client.GetByID(clientID); client != nil {
+		clientName = fmt.Sprintf("%s %s", client.Name, client.Surname)
+	}
+
+	// Имена подставляются в Markdown-шаблон: «_» и «*» в них ломают разметку
+	text := b.tf("substitution_suggest", trainerID,
+		tgbotapi.EscapeText(tgbotapi.ModeMarkdown, clientName),
+		tgbotapi.EscapeText(tgbotapi.ModeMarkdown, original),
+		tgbotapi.EscapeText(tgbotapi.ModeMarkdown, replacement),
+		count)
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(b.t("substitution_btn_apply", trainerID), fmt.Sprintf("prog_swapall_%d", exerciseID)),
+			tgbotapi.NewInlineKeyboardButtonData(b.t("substitution_btn_keep", trainerID), "prog_swapkeep"),
+		),
+	)
+
+	msg := tgbotapi.NewMessage(trainerID, text)
+	msg.ParseMode = "Markdown"
+	msg.ReplyMarkup = keyboard
+	if _, err := b.api.Send(msg); err != nil {
+		log.Printf("Ошибка отправки предложения замены тренеру: %v", err)
+	}
+}
+
+// applySubstitutionToProgram закрепляет замену во всех оставшихся тренировках программы (для тренера)
+func (b *Bot) applySubstitutionToProgram(adminChatID int64, exerciseID int, messageID int) {
+	exercise, err := b.repo.Program.GetExerciseByID(exerciseID)
+	if err != nil || exercise == nil || !exercise.IsSubstituted() {
+		b.editMessage(adminChatID, messageID, b.t("substitution_not_found", adminChatID), nil)
+		return
+	}
+
+	workout, err := b.repo.Program.GetWorkoutByID(exercise.WorkoutID)
+	if err != nil || workout == nil {
+		b.editMessage(adminChatID, messageID, b.t("substitution_workout_not_found", adminChatID), nil)
+		return
+	}
+
+	// Соотношение весов замены и оригинала для пересчёта плана
+	var ratio float64
+	originalWeight, newWeight, err := b.repo.Program.GetSubstitutionWeights(exerciseID)
+	if err == nil {
+		ratio = substitutionWeightRatio(originalWeight, newWeight)
+	}
+
+	updated, err := b.repo.Program.ApplySubstitutionToProgram(
+		workout.ProgramID, exercise.OriginalExerciseName, exercise.ExerciseName, exercise.SubstitutionReason, ratio)
+	if err != nil {
+		b.sendError(adminChatID, b.t("substitution_update_error", adminChatID), err)
+		return
+	}
+
+	b.editMessage(adminChatID, messageID, b.tf("substitution_applied", adminChatID,
+		exercise.OriginalExerciseName, exercise.ExerciseName, updated), nil)
+}
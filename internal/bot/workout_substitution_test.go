@@ -0,0 +1,72 @@
+package bot
+
+import (
+	"testing"
+
+	"workbot/internal/models"
+)
+
+func TestSubstituteWeight(t *testing.T) {
+	pm := map[string]float64{
+		"Присед со штангой":  200,
+		"Фронтальный присед": 150,
+		"Жим лёжа":           140,
+	}
+	front := &models.ExerciseExt{NameRu: "Фронтальный присед", NameEn: "Front Squat"}
+
+	tests := []struct {
+		name     string
+		exercise models.WorkoutExercise
+		sub      *models.ExerciseExt
+		want     float64
+	}{
+		// Процент из плана переносится на 1ПМ замены
+		{"процент из плана", models.WorkoutExercise{ExerciseName: "Присед со штангой", WeightPercent: 80, Weight: 160}, front, 120},
+		// 170 кг от 1ПМ 200 — 85%, от 150 — 127.5
+		{"процент из веса", models.WorkoutExercise{ExerciseName: "Присед со штангой", Weight: 170}, front, 127.5},
+		// Повторная замена: 1ПМ ищется по исходному упражнению, регистр и «ё» не важны
+		{"по исходному", models.WorkoutExercise{ExerciseName: "Гакк-присед", OriginalExerciseName: "присед СО штангой", Weight: 100}, front, 75},
+		{"замена без 1ПМ", models.WorkoutExercise{ExerciseName: "Присед со штангой", WeightPercent: 80}, &models.ExerciseExt{NameRu: "Гакк-присед"}, 0},
+		{"без веса в плане", models.WorkoutExercise{ExerciseName: "Присед со штангой"}, front, 0},
+		{"замена по ё", models.WorkoutExercise{ExerciseName: "Присед со штангой", WeightPercent: 70}, &models.ExerciseExt{NameRu: "Жим лежа"}, 97.5},
+	}
+	for _, tt := range tests {
+		if got := substituteWeight(pm, &tt.exercise, tt.sub); got != tt.want {
+			t.Errorf("%s: substituteWeight = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSubstitutionWeightRatio(t *testing.T) {
+	tests := []struct {
+		original, replacement, want float64
+	}{
+		{100, 75, 0.75},
+		{82.5, 90, 90 / 82.5},
+		{0, 75, 0},
+		{100, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := substitutionWeightRatio(tt.original, tt.replacement); got != tt.want {
+			t.Errorf("substitutionWeightRatio(%v, %v) = %v, want %v", tt.original, tt.replacement, got, tt.want)
+		}
+	}
+}
+
+func TestIsRepeatedSubstitution(t *testing.T) {
+	for count, want := range map[int]bool{0: false, 1: false, substitutionSuggestThreshold: true, 5: true} {
+		if got := isRepeatedSubstitution(count); got != want {
+			t.Errorf("isRepeatedSubstitution(%d) = %v, want %v", count, got, want)
+		}
+	}
+}
+
+func TestBusyEquipment(t *testing.T) {
+	got := busyEquipment([]models.EquipmentType{models.EquipmentBarbell, models.EquipmentBench, models.EquipmentBodyweight})
+	if len(got) != 1 || got[0] != models.EquipmentBarbell {
+		t.Errorf("busyEquipment = %v, want [barbell]", got)
+	}
+	if got := busyEquipment(nil); len(got) != 0 {
+		t.Errorf("busyEquipment(nil) = %v", got)
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"sync"
 	"time"
 
+	"workbot/internal/i18n"
 	"workbot/internal/models"
+	"workbot/internal/training"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -21,6 +23,23 @@ type WorkoutSession struct {
 	Exercises       []models.WorkoutExercise
 	CompletedCount  int
 	SkippedCount    int
+
+	// Замена упражнения: предложенные варианты и причина
+	Substitutes      []models.ExerciseExt
+	SubstituteReason string
+
+	// Карточка упражнения и предложение для следующего подхода
+	MessageID     int
+	NextSetFor    int
+	NextSetReps   int
+	NextSetWeight float64
+
+	// Анкета готовности перед тренировкой и сохранённая запись readiness_checkins
+	Readiness   training.ReadinessInput
+	ReadinessID int
+
+	// RPETargets — упражнения, вес которых назначен по целевому RPE: ID → e1RM
+	RPETargets map[int]float64
 }
 
 var workoutSessions = struct {
@@ -55,19 +74,19 @@ func (b *Bot) handleSendWorkoutToClient(clientID int, adminChatID int64) {
 	var telegramID int64
 	err := b.db.QueryRow("SELECT telegram_id FROM public.clients WHERE id = $1", clientID).Scan(&telegramID)
 	if err != nil {
-		b.sendMessage(adminChatID, "Ошибка: клиент не найден или не имеет telegram_id")
+		b.sendMessage(adminChatID, b.t("workout_client_no_telegram", adminChatID))
 		return
 	}
 
 	// Получаем следующую ожидающую тренировку
 	workout, err := b.repo.Program.GetNextPendingWorkout(clientID)
 	if err != nil {
-		b.sendError(adminChatID, "Ошибка получения тренировки", err)
+		b.sendError(adminChatID, b.t("workout_load_error", adminChatID), err)
 		return
 	}
 
 	if workout == nil {
-		b.sendMessage(adminChatID, "Нет ожидающих тренировок для этого клиента")
+		b.sendMessage(adminChatID, b.t("workout_client_no_pending", adminChatID))
 		return
 	}
 
@@ -79,25 +98,25 @@ func (b *Bot) handleSendWorkoutToClient(clientID int, adminChatID int64) {
 		log.Printf("Ошибка отметки тренировки как отправленной: %v", err)
 	}
 
-	b.sendMessage(adminChatID, fmt.Sprintf("✅ Тренировка \"%s\" отправлена клиенту", workout.Name))
+	b.sendMessage(adminChatID, b.tf("workout_sent_to_client", adminChatID, workout.Name))
 }
 
 // showProgramProgress показывает прогресс по программе клиента (для тренера)
 func (b *Bot) showProgramProgress(clientID int, adminChatID int64) {
 	progress, err := b.repo.Program.GetProgramProgress(clientID)
 	if err != nil {
-		b.sendError(adminChatID, "Ошибка получения прогресса", err)
+		b.sendError(adminChatID, b.t("workout_progress_error", adminChatID), err)
 		return
 	}
 
 	if progress == nil {
-		b.sendMessage(adminChatID, "У клиента нет активной программы")
+		b.sendMessage(adminChatID, b.t("tpl_no_active_program", adminChatID))
 		return
 	}
 
 	// Получаем имя клиента
 	client, _ := b.repo.Client.GetByID(clientID)
-	clientName := "Клиент"
+	clientName := b.t("substitution_client", adminChatID)
 	if client != nil {
 		clientName = fmt.Sprintf("%s %s", client.Name, client.Surname)
 	}
@@ -107,29 +126,29 @@ func (b *Bot) showProgramProgress(clientID int, adminChatID int64) {
 
 	// Формируем текст
 	var text strings.Builder
-	text.WriteString(fmt.Sprintf("📊 *Прогресс программы*\n\n"))
-	text.WriteString(fmt.Sprintf("👤 *Клиент:* %s\n", clientName))
-	text.WriteString(fmt.Sprintf("📋 *Программа:* %s\n", progress.ProgramName))
+	text.WriteString(b.t("workout_progress_title", adminChatID) + "\n\n")
+	text.WriteString(b.tf("workout_progress_client", adminChatID, clientName) + "\n")
+	text.WriteString(b.tf("workout_progress_program", adminChatID, progress.ProgramName) + "\n")
 	if progress.Goal != "" {
-		text.WriteString(fmt.Sprintf("🎯 *Цель:* %s\n", progress.Goal))
+		text.WriteString(b.tf("workout_progress_goal", adminChatID, progress.Goal) + "\n")
 	}
 	text.WriteString("\n")
-	text.WriteString(fmt.Sprintf("📅 *Неделя:* %d из %d\n", progress.CurrentWeek, progress.TotalWeeks))
-	text.WriteString(fmt.Sprintf("🏋️ *Тренировок:* %d в неделю\n", progress.DaysPerWeek))
+	text.WriteString(b.tf("workout_progress_week", adminChatID, progress.CurrentWeek, progress.TotalWeeks) + "\n")
+	text.WriteString(b.tf("workout_progress_per_week", adminChatID, progress.DaysPerWeek) + "\n")
 	text.WriteString("\n")
-	text.WriteString(fmt.Sprintf("*Статистика:*\n"))
-	text.WriteString(fmt.Sprintf("✅ Выполнено: %d\n", progress.CompletedCount))
-	text.WriteString(fmt.Sprintf("📤 Отправлено: %d\n", progress.SentCount))
-	text.WriteString(fmt.Sprintf("⏳ Ожидает: %d\n", progress.PendingCount))
+	text.WriteString(b.t("workout_progress_stats", adminChatID) + "\n")
+	text.WriteString(b.tf("workout_progress_completed", adminChatID, progress.CompletedCount) + "\n")
+	text.WriteString(b.tf("workout_progress_sent", adminChatID, progress.SentCount) + "\n")
+	text.WriteString(b.tf("workout_progress_pending", adminChatID, progress.PendingCount) + "\n")
 	if progress.SkippedCount > 0 {
-		text.WriteString(fmt.Sprintf("⏭️ Пропущено: %d\n", progress.SkippedCount))
+		text.WriteString(b.tf("workout_progress_skipped", adminChatID, progress.SkippedCount) + "\n")
 	}
 	text.WriteString("\n")
-	text.WriteString(fmt.Sprintf("*Прогресс:* %.0f%%\n", progress.ProgressPercent))
+	text.WriteString(b.tf("workout_progress_percent", adminChatID, progress.ProgressPercent) + "\n")
 	text.WriteString(progressBar)
 
 	if progress.NextWorkout != nil {
-		text.WriteString(fmt.Sprintf("\n\n📌 *Следующая:* %s (Нед.%d, День %d)",
+		text.WriteString("\n\n" + b.tf("workout_progress_next", adminChatID,
 			progress.NextWorkout.Name, progress.NextWorkout.WeekNum, progress.NextWorkout.DayNum))
 	}
 
@@ -139,13 +158,13 @@ func (b *Bot) showProgramProgress(clientID int, adminChatID int64) {
 	if progress.NextWorkout != nil {
 		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
-				"👁️ Превью тренировки",
+				b.t("workout_btn_preview", adminChatID),
 				fmt.Sprintf("prog_preview_%d", progress.NextWorkout.ID),
 			),
 		))
 		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
-				"📤 Отправить тренировку",
+				b.t("workout_btn_send", adminChatID),
 				fmt.Sprintf("prog_send_%d_%d", clientID, progress.NextWorkout.ID),
 			),
 		))
@@ -154,7 +173,7 @@ func (b *Bot) showProgramProgress(clientID int, adminChatID int64) {
 	if progress.PendingCount > 0 {
 		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(
-				fmt.Sprintf("📦 Отправить неделю %d", progress.CurrentWeek),
+				b.tf("workout_btn_send_week", adminChatID, progress.CurrentWeek),
 				fmt.Sprintf("prog_send_week_%d_%d_%d", clientID, progress.ProgramID, progress.CurrentWeek),
 			),
 		))
@@ -162,10 +181,30 @@ func (b *Bot) showProgramProgress(clientID int, adminChatID int64) {
 
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
 		tgbotapi.NewInlineKeyboardButtonData(
-			"🔔 Напомнить клиенту",
+			b.t("workout_btn_remind", adminChatID),
 			fmt.Sprintf("prog_remind_%d", clientID),
 		),
 	))
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			b.t("workout_btn_volume", adminChatID),
+			fmt.Sprintf("prog_volume_%d", clientID),
+		),
+		tgbotapi.NewInlineKeyboardButtonData(
+			b.t("workout_btn_hyrox", adminChatID),
+			fmt.Sprintf("prog_hyrox_%d", clientID),
+		),
+	))
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(
+			b.t("workout_btn_load", adminChatID),
+			fmt.Sprintf("prog_load_%d", clientID),
+		),
+		tgbotapi.NewInlineKeyboardButtonData(
+			b.t("workout_btn_cardio", adminChatID),
+			fmt.Sprintf("prog_cardio_%d", clientID),
+		),
+	))
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
 
@@ -219,6 +258,36 @@ func (b *Bot) handleProgramCallback(callback *tgbotapi.CallbackQuery) {
 		clientID, _ := strconv.Atoi(clientIDStr)
 		b.sendWorkoutReminder(chatID, clientID)
 
+	case strings.HasPrefix(data, "prog_swapall_"):
+		// Закрепить замену упражнения в программе
+		exerciseIDStr := strings.TrimPrefix(data, "prog_swapall_")
+		exerciseID, _ := strconv.Atoi(exerciseIDStr)
+		b.applySubstitutionToProgram(chatID, exerciseID, callback.Message.MessageID)
+
+	case data == "prog_swapkeep":
+		// Оставить программу без изменений
+		b.editMessage(chatID, callback.Message.MessageID, b.t("substitution_kept", chatID), nil)
+
+	case strings.HasPrefix(data, "prog_volume_"):
+		// Ориентиры объёма клиента
+		clientID, _ := strconv.Atoi(strings.TrimPrefix(data, "prog_volume_"))
+		b.showVolumeLandmarks(chatID, clientID, callback.Message.MessageID)
+
+	case strings.HasPrefix(data, "prog_load_"):
+		// Нагрузка клиента: ACWR и модель Банистера
+		clientID, _ := strconv.Atoi(strings.TrimPrefix(data, "prog_load_"))
+		b.showTrainingLoad(chatID, clientID, callback.Message.MessageID)
+
+	case strings.HasPrefix(data, "prog_cardio_"):
+		// Загруженные кардиотренировки клиента
+		clientID, _ := strconv.Atoi(strings.TrimPrefix(data, "prog_cardio_"))
+		b.showCardioSessions(chatID, clientID, callback.Message.MessageID)
+
+	case strings.HasPrefix(data, "prog_hyrox_"):
+		// Раскладка забега Hyrox и симуляции клиента
+		clientID, _ := strconv.Atoi(strings.TrimPrefix(data, "prog_hyrox_"))
+		b.showHyrox(chatID, clientID, callback.Message.MessageID)
+
 	case strings.HasPrefix(data, "prog_back_"):
 		// Вернуться к прогрессу
 		clientIDStr := strings.TrimPrefix(data, "prog_back_")
@@ -231,34 +300,50 @@ func (b *Bot) handleProgramCallback(callback *tgbotapi.CallbackQuery) {
 func (b *Bot) showWorkoutPreview(chatID int64, workoutID int, messageID int) {
 	workout, err := b.repo.Program.GetWorkoutByID(workoutID)
 	if err != nil || workout == nil {
-		b.sendMessage(chatID, "Ошибка: тренировка не найдена")
+		b.sendMessage(chatID, b.t("substitution_workout_not_found", chatID))
 		return
 	}
 
 	// Получаем client_id для кнопки "назад"
 	clientID, _ := b.repo.Program.GetClientIDByWorkout(workoutID)
 
+	// Вес упражнений с целевым RPE будет назначен по e1RM в начале тренировки
+	var e1rms map[string]float64
+	for _, ex := range workout.Exercises {
+		if ex.RPE > 0 && clientID > 0 {
+			e1rms = b.rollingE1RMs(clientID, time.Now())
+			break
+		}
+	}
+
 	var text strings.Builder
-	text.WriteString(fmt.Sprintf("👁️ *Превью тренировки*\n\n"))
+	text.WriteString(b.t("workout_preview_title", chatID) + "\n\n")
 	text.WriteString(fmt.Sprintf("📋 *%s*\n", workout.Name))
-	text.WriteString(fmt.Sprintf("📅 Неделя %d, День %d\n", workout.WeekNum, workout.DayNum))
-	text.WriteString(fmt.Sprintf("🏋️ Упражнений: %d\n\n", len(workout.Exercises)))
+	text.WriteString(b.tf("workout_preview_week_day", chatID, workout.WeekNum, workout.DayNum) + "\n")
+	text.WriteString(b.tf("workout_preview_exercises", chatID, len(workout.Exercises)) + "\n\n")
 
 	for i, ex := range workout.Exercises {
 		text.WriteString(fmt.Sprintf("*%d. %s*\n", i+1, ex.ExerciseName))
+		if ex.IsSubstituted() {
+			text.WriteString("   " + b.tf("workout_exercise_substituted", chatID, ex.OriginalExerciseName) + "\n")
+		}
 		text.WriteString(fmt.Sprintf("   %d×%s", ex.Sets, ex.Reps))
 		if ex.Weight > 0 {
-			text.WriteString(fmt.Sprintf(" @ %.0fкг", ex.Weight))
+			text.WriteString(" @ " + b.tf("workout_preview_weight", chatID, ex.Weight))
 			if ex.WeightPercent > 0 {
 				text.WriteString(fmt.Sprintf(" (%.0f%%)", ex.WeightPercent))
 			}
 		}
 		if ex.RPE > 0 {
 			text.WriteString(fmt.Sprintf(" RPE %.1f", ex.RPE))
+			e1rm := e1rms[liftKey(ex.ExerciseName)]
+			if weight := rpeTargetWeight(ex, e1rm); weight > 0 {
+				text.WriteString(" " + b.tf("workout_preview_rpe_target", chatID, weight, e1rm))
+			}
 		}
 		text.WriteString("\n")
 		if ex.RestSeconds > 0 {
-			text.WriteString(fmt.Sprintf("   ⏱️ Отдых: %d сек\n", ex.RestSeconds))
+			text.WriteString("   " + b.tf("workout_exercise_rest", chatID, ex.RestSeconds) + "\n")
 		}
 		if ex.Notes != "" {
 			text.WriteString(fmt.Sprintf("   📝 %s\n", ex.Notes))
@@ -269,13 +354,13 @@ func (b *Bot) showWorkoutPreview(chatID int64, workoutID int, messageID int) {
 	var rows [][]tgbotapi.InlineKeyboardButton
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
 		tgbotapi.NewInlineKeyboardButtonData(
-			"📤 Отправить клиенту",
+			b.t("workout_btn_send_client", chatID),
 			fmt.Sprintf("prog_send_%d_%d", clientID, workoutID),
 		),
 	))
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
 		tgbotapi.NewInlineKeyboardButtonData(
-			"◀️ Назад к прогрессу",
+			b.t("workout_btn_back_progress", chatID),
 			fmt.Sprintf("prog_back_%d", clientID),
 		),
 	))
@@ -298,19 +383,19 @@ func (b *Bot) sendWeekWorkouts(adminChatID int64, clientID int, programID int, w
 	var telegramID int64
 	err := b.db.QueryRow("SELECT telegram_id FROM public.clients WHERE id = $1", clientID).Scan(&telegramID)
 	if err != nil || telegramID == 0 {
-		b.sendMessage(adminChatID, "Ошибка: клиент не найден или не имеет telegram_id")
+		b.sendMessage(adminChatID, b.t("workout_client_no_telegram", adminChatID))
 		return
 	}
 
 	// Получаем неотправленные тренировки недели
 	workouts, err := b.repo.Program.GetPendingWorkoutsForWeek(programID, weekNum)
 	if err != nil {
-		b.sendError(adminChatID, "Ошибка получения тренировок", err)
+		b.sendError(adminChatID, b.t("workout_load_error", adminChatID), err)
 		return
 	}
 
 	if len(workouts) == 0 {
-		b.sendMessage(adminChatID, fmt.Sprintf("Нет неотправленных тренировок на неделе %d", weekNum))
+		b.sendMessage(adminChatID, b.tf("workout_week_no_pending", adminChatID, weekNum))
 		return
 	}
 
@@ -327,7 +412,7 @@ func (b *Bot) sendWeekWorkouts(adminChatID int64, clientID int, programID int, w
 		time.Sleep(500 * time.Millisecond)
 	}
 
-	b.sendMessage(adminChatID, fmt.Sprintf("✅ Отправлено %d тренировок (Неделя %d)", sentCount, weekNum))
+	b.sendMessage(adminChatID, b.tf("workout_week_sent", adminChatID, b.tn("trainings", adminChatID, sentCount), weekNum))
 
 	// Показываем обновлённый прогресс
 	b.showProgramProgress(clientID, adminChatID)
@@ -339,14 +424,14 @@ func (b *Bot) sendSpecificWorkout(adminChatID int64, clientID int, workoutID int
 	var telegramID int64
 	err := b.db.QueryRow("SELECT telegram_id FROM public.clients WHERE id = $1", clientID).Scan(&telegramID)
 	if err != nil || telegramID == 0 {
-		b.sendMessage(adminChatID, "Ошибка: клиент не найден или не имеет telegram_id")
+		b.sendMessage(adminChatID, b.t("workout_client_no_telegram", adminChatID))
 		return
 	}
 
 	// Получаем тренировку
 	workout, err := b.repo.Program.GetWorkoutByID(workoutID)
 	if err != nil || workout == nil {
-		b.sendError(adminChatID, "Ошибка получения тренировки", err)
+		b.sendError(adminChatID, b.t("workout_load_error", adminChatID), err)
 		return
 	}
 
@@ -358,7 +443,7 @@ func (b *Bot) sendSpecificWorkout(adminChatID int64, clientID int, workoutID int
 		log.Printf("Ошибка отметки тренировки как отправленной: %v", err)
 	}
 
-	b.sendMessage(adminChatID, fmt.Sprintf("✅ Тренировка \"%s\" отправлена клиенту", workout.Name))
+	b.sendMessage(adminChatID, b.tf("workout_sent_to_client", adminChatID, workout.Name))
 
 	// Показываем обновлённый прогресс
 	b.showProgramProgress(clientID, adminChatID)
@@ -371,36 +456,35 @@ func (b *Bot) sendWorkoutReminder(adminChatID int64, clientID int) {
 	var name string
 	err := b.db.QueryRow("SELECT telegram_id, name FROM public.clients WHERE id = $1", clientID).Scan(&telegramID, &name)
 	if err != nil || telegramID == 0 {
-		b.sendMessage(adminChatID, "Ошибка: клиент не найден или не имеет telegram_id")
+		b.sendMessage(adminChatID, b.t("workout_client_no_telegram", adminChatID))
 		return
 	}
 
 	// Получаем прогресс
 	progress, err := b.repo.Program.GetProgramProgress(clientID)
 	if err != nil || progress == nil {
-		b.sendMessage(adminChatID, "Ошибка получения прогресса программы")
+		b.sendMessage(adminChatID, b.t("workout_progress_error", adminChatID))
 		return
 	}
 
 	// Формируем напоминание
 	var text strings.Builder
-	text.WriteString(fmt.Sprintf("👋 Привет, %s!\n\n", name))
+	text.WriteString(b.tf("workout_remind_hello", telegramID, name) + "\n\n")
 
 	if progress.SentCount > 0 && progress.NextWorkout != nil && progress.NextWorkout.Status == "sent" {
 		// Есть отправленная, но не выполненная тренировка
-		text.WriteString("🏋️ У тебя есть запланированная тренировка!\n\n")
+		text.WriteString(b.t("workout_remind_scheduled", telegramID) + "\n\n")
 		text.WriteString(fmt.Sprintf("📋 *%s*\n", progress.NextWorkout.Name))
-		text.WriteString(fmt.Sprintf("Неделя %d, День %d\n\n", progress.NextWorkout.WeekNum, progress.NextWorkout.DayNum))
-		text.WriteString("Напиши /workouts чтобы начать 💪")
+		text.WriteString(b.tf("workout_remind_week_day", telegramID, progress.NextWorkout.WeekNum, progress.NextWorkout.DayNum) + "\n\n")
+		text.WriteString(b.t("workout_remind_start", telegramID))
 	} else if progress.PendingCount > 0 {
 		// Есть неотправленные тренировки
-		text.WriteString("📅 Готов к следующей тренировке?\n\n")
-		text.WriteString(fmt.Sprintf("Прогресс программы: %.0f%% (%d/%d)\n\n",
-			progress.ProgressPercent, progress.CompletedCount, progress.TotalWorkouts))
-		text.WriteString("Напиши /workouts чтобы получить тренировку 💪")
+		text.WriteString(b.t("workout_remind_ready", telegramID) + "\n\n")
+		text.WriteString(b.tf("workout_remind_progress", telegramID,
+			progress.ProgressPercent, progress.CompletedCount, progress.TotalWorkouts) + "\n\n")
+		text.WriteString(b.t("workout_remind_get", telegramID))
 	} else {
-		text.WriteString("🎉 Все тренировки программы выполнены!\n")
-		text.WriteString("Отличная работа! 🏆")
+		text.WriteString(b.t("workout_remind_all_done", telegramID))
 	}
 
 	msg := tgbotapi.NewMessage(telegramID, text.String())
@@ -408,11 +492,11 @@ func (b *Bot) sendWorkoutReminder(adminChatID int64, clientID int) {
 
 	if _, err := b.api.Send(msg); err != nil {
 		log.Printf("Ошибка отправки напоминания: %v", err)
-		b.sendMessage(adminChatID, "❌ Ошибка отправки напоминания")
+		b.sendMessage(adminChatID, b.t("workout_remind_error", adminChatID))
 		return
 	}
 
-	b.sendMessage(adminChatID, fmt.Sprintf("✅ Напоминание отправлено клиенту %s", name))
+	b.sendMessage(adminChatID, b.tf("workout_remind_sent", adminChatID, name))
 }
 
 // makeProgressBar создаёт текстовый прогресс-бар
@@ -430,7 +514,7 @@ func makeProgressBar(percent float64, width int) string {
 }
 
 // sendWorkoutToClient отправляет тренировку клиенту с inline-кнопками
-func (b *Bot) sendWorkoutToClient(chatID int64, workout *models.Workout) {
+func (b *Bot) sendWorkoutToClient(chatID int64, workout *models.Workout) error {
 	// Рассчитываем примерную длительность (2.5 мин на упражнение)
 	estimatedDuration := len(workout.Exercises) * 3
 
@@ -465,7 +549,9 @@ func (b *Bot) sendWorkoutToClient(chatID int64, workout *models.Workout) {
 
 	if _, err := b.api.Send(msg); err != nil {
 		log.Printf("Ошибка отправки тренировки клиенту: %v", err)
+		return err
 	}
+	return nil
 }
 
 // handleWorkoutCallback обрабатывает callback-запросы связанные с тренировкой
@@ -506,6 +592,37 @@ func (b *Bot) handleWorkoutCallback(callback *tgbotapi.CallbackQuery) {
 		exerciseID, _ := strconv.Atoi(exerciseIDStr)
 		b.askForWeight(chatID, exerciseID)
 
+	case strings.HasPrefix(data, "workout_swap_"):
+		// Заменить упражнение — выбор причины
+		exerciseIDStr := strings.TrimPrefix(data, "workout_swap_")
+		exerciseID, _ := strconv.Atoi(exerciseIDStr)
+		b.showSubstitutionReasons(chatID, exerciseID, callback.Message.MessageID)
+
+	case strings.HasPrefix(data, "workout_swapwhy_"):
+		// Причина выбрана — показываем варианты замены
+		parts := strings.SplitN(strings.TrimPrefix(data, "workout_swapwhy_"), "_", 2)
+		if len(parts) == 2 {
+			exerciseID, _ := strconv.Atoi(parts[0])
+			b.showSubstitutes(chatID, exerciseID, parts[1], callback.Message.MessageID)
+		}
+
+	case strings.HasPrefix(data, "workout_swapto_"):
+		// Замена выбрана
+		parts := strings.Split(strings.TrimPrefix(data, "workout_swapto_"), "_")
+		if len(parts) == 2 {
+			exerciseID, _ := strconv.Atoi(parts[0])
+			idx, _ := strconv.Atoi(parts[1])
+			b.applySubstitution(chatID, exerciseID, idx, callback.Message.MessageID)
+		}
+
+	case strings.HasPrefix(data, "workout_set"):
+		// Запись подходов и RPE подхода
+		b.handleSetCallback(chatID, data, callback.Message.MessageID)
+
+	case data == "workout_swapback":
+		// Вернуться к упражнению без замены
+		b.showCurrentExercise(chatID, callback.Message.MessageID)
+
 	case strings.HasPrefix(data, "workout_next_"):
 		// Следующее упражнение
 		b.showNextExercise(chatID, callback.Message.MessageID)
@@ -518,6 +635,10 @@ func (b *Bot) handleWorkoutCallback(callback *tgbotapi.CallbackQuery) {
 		// Завершить тренировку
 		b.finishWorkout(chatID, callback.Message.MessageID)
 
+	case strings.HasPrefix(data, "workout_rd_"):
+		// Анкета готовности
+		b.handleReadinessCallback(chatID, strings.TrimPrefix(data, "workout_rd_"), callback.Message.MessageID)
+
 	case strings.HasPrefix(data, "workout_feeling_"):
 		// Самочувствие
 		feeling := strings.TrimPrefix(data, "workout_feeling_")
@@ -548,10 +669,17 @@ func (b *Bot) startWorkoutSession(chatID int64, workoutID int, messageID int) {
 		CompletedCount:  0,
 		SkippedCount:    0,
 	}
+
+	// Вес упражнений с целевым RPE — по скользящему e1RM клиента
+	if clientID, err := b.repo.Program.GetClientIDByWorkout(workoutID); err != nil {
+		log.Printf("Ошибка получения клиента тренировки %d: %v", workoutID, err)
+	} else if clientID > 0 {
+		b.applyRPETargets(session, clientID)
+	}
 	setWorkoutSession(chatID, session)
 
-	// Показываем первое упражнение
-	b.showCurrentExercise(chatID, messageID)
+	// Перед первым упражнением — анкета готовности
+	b.askReadiness(chatID, messageID, readinessSleep)
 }
 
 // showCurrentExercise показывает текущее упражнение
@@ -571,6 +699,10 @@ func (b *Bot) showCurrentExercise(chatID int64, messageID int) {
 	text.WriteString(b.tf("workout_exercise_title", chatID, current, total))
 	text.WriteString("\n\n")
 	text.WriteString(b.tf("workout_exercise_name", chatID, exercise.ExerciseName))
+	if exercise.IsSubstituted() {
+		text.WriteString("\n")
+		text.WriteString(b.tf("workout_exercise_substituted", chatID, exercise.OriginalExerciseName))
+	}
 	text.WriteString("\n\n")
 	text.WriteString(b.tf("workout_exercise_sets", chatID, exercise.Sets))
 	text.WriteString("\n")
@@ -583,6 +715,10 @@ func (b *Bot) showCurrentExercise(chatID int64, messageID int) {
 			weightText += b.tf("workout_exercise_weight_percent", chatID, int(exercise.WeightPercent))
 		}
 		text.WriteString(weightText)
+		if e1rm, ok := session.RPETargets[exercise.ID]; ok {
+			text.WriteString("\n")
+			text.WriteString(b.tf("workout_exercise_rpe_target", chatID, e1rm))
+		}
 	}
 
 	if exercise.RestSeconds > 0 {
@@ -605,9 +741,22 @@ func (b *Bot) showCurrentExercise(chatID int64, messageID int) {
 		text.WriteString(b.tf("workout_exercise_notes", chatID, exercise.Notes))
 	}
 
+	// Записанные подходы
+	sets, err := b.repo.Program.GetWorkoutSets(exercise.ID)
+	if err != nil {
+		log.Printf("Ошибка получения подходов: %v", err)
+	}
+	ensureSetSuggestion(session, exercise, sets)
+	session.MessageID = messageID
+	setWorkoutSession(chatID, session)
+	b.writeLoggedSets(&text, chatID, sets)
+
 	// Кнопки
 	var rows [][]tgbotapi.InlineKeyboardButton
 
+	// Запись подходов
+	rows = append(rows, b.setLoggingRows(chatID, session, exercise, sets)...)
+
 	// Основные кнопки действий
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
 		tgbotapi.NewInlineKeyboardButtonData(
@@ -620,15 +769,19 @@ func (b *Bot) showCurrentExercise(chatID int64, messageID int) {
 		),
 	))
 
-	// Кнопка изменения веса
+	// Кнопки изменения веса и замены упражнения
+	var editRow []tgbotapi.InlineKeyboardButton
 	if exercise.Weight > 0 {
-		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(
-				b.t("workout_btn_change_weight", chatID),
-				fmt.Sprintf("workout_weight_%d", exercise.ID),
-			),
+		editRow = append(editRow, tgbotapi.NewInlineKeyboardButtonData(
+			b.t("workout_btn_change_weight", chatID),
+			fmt.Sprintf("workout_weight_%d", exercise.ID),
 		))
 	}
+	editRow = append(editRow, tgbotapi.NewInlineKeyboardButtonData(
+		b.t("workout_btn_replace", chatID),
+		fmt.Sprintf("workout_swap_%d", exercise.ID),
+	))
+	rows = append(rows, editRow)
 
 	// Навигация
 	var navRow []tgbotapi.InlineKeyboardButton
@@ -667,8 +820,17 @@ func (b *Bot) markExerciseDone(chatID int64, exerciseID int, messageID int) {
 		return
 	}
 
-	// Отмечаем в БД
-	if err := b.repo.Program.MarkExerciseCompleted(exerciseID); err != nil {
+	// Отмечаем в БД: если подходы записаны, результат считается по ним
+	sets, err := b.repo.Program.GetWorkoutSets(exerciseID)
+	if err != nil {
+		log.Printf("Ошибка получения подходов: %v", err)
+	}
+	if len(sets) > 0 {
+		err = b.repo.Program.SyncExerciseResultFromSets(exerciseID)
+	} else {
+		err = b.repo.Program.MarkExerciseCompleted(exerciseID)
+	}
+	if err != nil {
 		log.Printf("Ошибка отметки упражнения: %v", err)
 	}
 
@@ -764,6 +926,8 @@ func (b *Bot) finishWorkout(chatID int64, messageID int) {
 		return
 	}
 
+	b.stopRestTimer(chatID)
+
 	duration := int(time.Since(session.StartTime).Minutes())
 	total := len(session.Exercises)
 
@@ -855,25 +1019,28 @@ func (b *Bot) saveWorkoutRPE(chatID int64, rpe int, messageID int) {
 		feeling = strings.TrimPrefix(state, "workout_feeling_")
 	}
 
-	// Формируем feedback
-	feelingText := map[string]string{
-		"great": "💪 Отлично",
-		"good":  "👍 Хорошо",
-		"tired": "😓 Устал",
-		"bad":   "😞 Плохо",
+	// Формируем feedback (хранится в БД на языке по умолчанию)
+	feelingText := ""
+	if feeling != "" {
+		feelingText = i18n.T("workout_post_feeling_"+feeling, i18n.DefaultLang)
 	}
-
-	feedback := fmt.Sprintf("RPE: %d/10\nСамочувствие: %s", rpe, feelingText[feeling])
+	feedback := i18n.Tf("workout_feedback_summary", i18n.DefaultLang, rpe, feelingText)
 
 	// Отмечаем тренировку как завершённую
-	if err := b.repo.Program.MarkWorkoutCompleted(session.WorkoutID, feedback); err != nil {
+	if err := b.repo.Program.MarkWorkoutCompleted(session.WorkoutID, feedback, rpe); err != nil {
 		log.Printf("Ошибка завершения тренировки: %v", err)
 	}
 
-	// Отправляем уведомление тренеру
+	// Записываем нагрузку (sRPE) до уведомления — в нём строка с ACWR
 	duration := int(time.Since(session.StartTime).Minutes())
+	b.trackTrainingLoad(session.WorkoutID, chatID, rpe, duration)
+
+	// Отправляем уведомление тренеру
 	b.notifyTrainerWorkoutCompleted(session.WorkoutID, chatID, duration, rpe, feeling)
 
+	// Последняя тренировка программы завершает мезоцикл — пересчитываем ориентиры объёма
+	b.adaptVolumeLandmarks(session.WorkoutID)
+
 	// Очищаем сессию
 	clearWorkoutSession(chatID)
 	clearState(chatID)
@@ -911,7 +1078,7 @@ func (b *Bot) notifyTrainerWorkoutCompleted(workoutID int, clientChatID int64, d
 	// Получаем имя клиента
 	clientID, _ := b.repo.Program.GetClientIDByWorkout(workoutID)
 	client, _ := b.repo.Client.GetByID(clientID)
-	clientName := "Клиент"
+	clientName := b.t("substitution_client", trainerID)
 	if client != nil {
 		clientName = fmt.Sprintf("%s %s", client.Name, client.Surname)
 	}
@@ -934,22 +1101,9 @@ func (b *Bot) notifyTrainerWorkoutCompleted(workoutID int, clientChatID int64, d
 	}
 
 	// Форматируем тоннаж
-	tonnageStr := formatTonnage(stats.Tonnage)
-
-	text := fmt.Sprintf(`🏋️ *Тренировка завершена!*
-
-👤 *Клиент:* %s
-📋 *Тренировка:* %s (Неделя %d, День %d)
-
-📊 *Статистика:*
-• Тоннаж: *%s*
-• Выполнено: %d/%d упражнений %s
-• Выполнение плана: %.0f%%
-• Длительность: %d мин
+	tonnageStr := formatTonnage(stats.Tonnage, b.getLanguage(trainerID))
 
-💭 *Обратная связь:*
-• RPE: %d/10
-• Самочувствие: %s`,
+	text := b.tf("workout_trainer_completed", trainerID,
 		clientName,
 		workout.Name, workout.WeekNum, workout.DayNum,
 		tonnageStr,
@@ -959,21 +1113,30 @@ func (b *Bot) notifyTrainerWorkoutCompleted(workoutID int, clientChatID int64, d
 		rpe,
 		feelingEmoji[feeling],
 	)
+	if score, zone, adjusted, ok := b.workoutReadiness(workoutID); ok {
+		text += "\n" + b.tf("readiness_trainer_line", trainerID, score, b.t("readiness_zone_short_"+zone, trainerID))
+		if adjusted {
+			text += " " + b.t("readiness_trainer_adjusted", trainerID)
+		}
+	}
+	if srpe, acwr, zone, ok := b.workoutLoad(workoutID); ok {
+		text += "\n" + b.formatLoadLine(trainerID, srpe, acwr, zone)
+	}
 
 	msg := tgbotapi.NewMessage(trainerID, text)
 	msg.ParseMode = "Markdown"
 
-	if _, err := b.api.Send(msg); err != nil {
-		log.Printf("Ошибка отправки уведомления тренеру: %v", err)
+	if err := b.outbox.Enqueue(msg); err != nil {
+		log.Printf("Ошибка постановки уведомления тренеру в очередь: %v", err)
 	}
 }
 
 // formatTonnage форматирует тоннаж в читаемый вид
-func formatTonnage(tonnage float64) string {
+func formatTonnage(tonnage float64, lang i18n.Language) string {
 	if tonnage >= 1000 {
-		return fmt.Sprintf("%.1f т", tonnage/1000)
+		return i18n.Tf("tonnage_tons", lang, tonnage/1000)
 	}
-	return fmt.Sprintf("%.0f кг", tonnage)
+	return i18n.Tf("tonnage_kg", lang, tonnage)
 }
 
 // handleMyWorkouts показывает текущие тренировки клиента из программы
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // Event представляет событие календаря
@@ -15,34 +16,105 @@ type Event struct {
 	StartTime   time.Time
 	EndTime     time.Time
 	Reminder    int // минут до события
+
+	// Поля подписки: календарь сверяет события по UID и берёт версию с большим SEQUENCE
+	Sequence     int
+	Cancelled    bool      // STATUS:CANCELLED — событие остаётся в ленте, календарь его вычёркивает
+	AllDay       bool      // событие на весь день StartTime (тренировка программы без времени)
+	LastModified time.Time // нулевое — не выводится
 }
 
 // GenerateICS генерирует содержимое .ics файла для события
 func GenerateICS(event Event) string {
 	var sb strings.Builder
+	writeHeader(&sb)
+	writeEvent(&sb, event, time.Now())
+	sb.WriteString("END:VCALENDAR\r\n")
+	return sb.String()
+}
+
+// GenerateMultipleICS генерирует .ics файл с несколькими событиями
+func GenerateMultipleICS(events []Event) string {
+	var sb strings.Builder
+	writeHeader(&sb)
+	sb.WriteString("X-WR-CALNAME:Тренировки\r\n")
+
+	now := time.Now()
+	for _, event := range events {
+		writeEvent(&sb, event, now)
+	}
+
+	sb.WriteString("END:VCALENDAR\r\n")
+	return sb.String()
+}
+
+// FeedRefresh — как часто календарь перечитывает подписку
+const FeedRefresh = time.Hour
+
+// GenerateFeed генерирует ленту для подписки по ссылке: название календаря,
+// интервал обновления и события со SEQUENCE и статусом. now — DTSTAMP событий
+func GenerateFeed(name string, events []Event, now time.Time) string {
+	var sb strings.Builder
+	writeHeader(&sb)
+	writeLine(&sb, "X-WR-CALNAME:"+escapeICS(name))
+	refresh := fmt.Sprintf("PT%dM", int(FeedRefresh.Minutes()))
+	sb.WriteString("REFRESH-INTERVAL;VALUE=DURATION:" + refresh + "\r\n")
+	sb.WriteString("X-PUBLISHED-TTL:" + refresh + "\r\n")
 
+	for _, event := range events {
+		writeEvent(&sb, event, now)
+	}
+
+	sb.WriteString("END:VCALENDAR\r\n")
+	return sb.String()
+}
+
+// writeHeader пишет начало VCALENDAR
+func writeHeader(sb *strings.Builder) {
 	sb.WriteString("BEGIN:VCALENDAR\r\n")
 	sb.WriteString("VERSION:2.0\r\n")
 	sb.WriteString("PRODID:-//WorkBot//Training Calendar//RU\r\n")
 	sb.WriteString("CALSCALE:GREGORIAN\r\n")
 	sb.WriteString("METHOD:PUBLISH\r\n")
+}
 
+// writeEvent пишет VEVENT. SEQUENCE, STATUS и LAST-MODIFIED выводятся,
+// только если заданы, — разовый экспорт остаётся прежним
+func writeEvent(sb *strings.Builder, event Event, stamp time.Time) {
 	sb.WriteString("BEGIN:VEVENT\r\n")
-	sb.WriteString(fmt.Sprintf("UID:%s\r\n", event.UID))
-	sb.WriteString(fmt.Sprintf("DTSTAMP:%s\r\n", formatICSTime(time.Now())))
-	sb.WriteString(fmt.Sprintf("DTSTART:%s\r\n", formatICSTime(event.StartTime)))
-	sb.WriteString(fmt.Sprintf("DTEND:%s\r\n", formatICSTime(event.EndTime)))
-	sb.WriteString(fmt.Sprintf("SUMMARY:%s\r\n", escapeICS(event.Summary)))
+	writeLine(sb, "UID:"+event.UID)
+	sb.WriteString(fmt.Sprintf("DTSTAMP:%s\r\n", formatICSTime(stamp)))
+	if event.AllDay {
+		end := event.EndTime
+		if !end.After(event.StartTime) {
+			end = event.StartTime.AddDate(0, 0, 1)
+		}
+		sb.WriteString(fmt.Sprintf("DTSTART;VALUE=DATE:%s\r\n", event.StartTime.Format("20060102")))
+		sb.WriteString(fmt.Sprintf("DTEND;VALUE=DATE:%s\r\n", end.Format("20060102")))
+	} else {
+		sb.WriteString(fmt.Sprintf("DTSTART:%s\r\n", formatICSTime(event.StartTime)))
+		sb.WriteString(fmt.Sprintf("DTEND:%s\r\n", formatICSTime(event.EndTime)))
+	}
+	writeLine(sb, "SUMMARY:"+escapeICS(event.Summary))
 
 	if event.Description != "" {
-		sb.WriteString(fmt.Sprintf("DESCRIPTION:%s\r\n", escapeICS(event.Description)))
+		writeLine(sb, "DESCRIPTION:"+escapeICS(event.Description))
 	}
 	if event.Location != "" {
-		sb.WriteString(fmt.Sprintf("LOCATION:%s\r\n", escapeICS(event.Location)))
+		writeLine(sb, "LOCATION:"+escapeICS(event.Location))
+	}
+	if event.Sequence > 0 {
+		sb.WriteString(fmt.Sprintf("SEQUENCE:%d\r\n", event.Sequence))
+	}
+	if event.Cancelled {
+		sb.WriteString("STATUS:CANCELLED\r\n")
+	}
+	if !event.LastModified.IsZero() {
+		sb.WriteString(fmt.Sprintf("LAST-MODIFIED:%s\r\n", formatICSTime(event.LastModified)))
 	}
 
-	// Напоминание
-	if event.Reminder > 0 {
+	// Напоминание; отменённому событию не нужно
+	if event.Reminder > 0 && !event.Cancelled {
 		sb.WriteString("BEGIN:VALARM\r\n")
 		sb.WriteString("ACTION:DISPLAY\r\n")
 		sb.WriteString(fmt.Sprintf("TRIGGER:-PT%dM\r\n", event.Reminder))
@@ -51,51 +123,28 @@ func GenerateICS(event Event) string {
 	}
 
 	sb.WriteString("END:VEVENT\r\n")
-	sb.WriteString("END:VCALENDAR\r\n")
-
-	return sb.String()
 }
 
-// GenerateMultipleICS генерирует .ics файл с несколькими событиями
-func GenerateMultipleICS(events []Event) string {
-	var sb strings.Builder
-
-	sb.WriteString("BEGIN:VCALENDAR\r\n")
-	sb.WriteString("VERSION:2.0\r\n")
-	sb.WriteString("PRODID:-//WorkBot//Training Calendar//RU\r\n")
-	sb.WriteString("CALSCALE:GREGORIAN\r\n")
-	sb.WriteString("METHOD:PUBLISH\r\n")
-	sb.WriteString("X-WR-CALNAME:Тренировки\r\n")
-
-	for _, event := range events {
-		sb.WriteString("BEGIN:VEVENT\r\n")
-		sb.WriteString(fmt.Sprintf("UID:%s\r\n", event.UID))
-		sb.WriteString(fmt.Sprintf("DTSTAMP:%s\r\n", formatICSTime(time.Now())))
-		sb.WriteString(fmt.Sprintf("DTSTART:%s\r\n", formatICSTime(event.StartTime)))
-		sb.WriteString(fmt.Sprintf("DTEND:%s\r\n", formatICSTime(event.EndTime)))
-		sb.WriteString(fmt.Sprintf("SUMMARY:%s\r\n", escapeICS(event.Summary)))
-
-		if event.Description != "" {
-			sb.WriteString(fmt.Sprintf("DESCRIPTION:%s\r\n", escapeICS(event.Description)))
-		}
-		if event.Location != "" {
-			sb.WriteString(fmt.Sprintf("LOCATION:%s\r\n", escapeICS(event.Location)))
+// maxLineOctets — длина строки iCalendar без CRLF (RFC 5545, 3.1)
+const maxLineOctets = 75
+
+// writeLine пишет строку, перенося длинную на продолжения с пробелом в начале.
+// Граница переноса не разрезает символ UTF-8
+func writeLine(sb *strings.Builder, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
 		}
-
-		if event.Reminder > 0 {
-			sb.WriteString("BEGIN:VALARM\r\n")
-			sb.WriteString("ACTION:DISPLAY\r\n")
-			sb.WriteString(fmt.Sprintf("TRIGGER:-PT%dM\r\n", event.Reminder))
-			sb.WriteString("DESCRIPTION:Напоминание о тренировке\r\n")
-			sb.WriteString("END:VALARM\r\n")
-		}
-
-		sb.WriteString("END:VEVENT\r\n")
+		sb.WriteString(line[:cut])
+		sb.WriteString("\r\n ")
+		line = line[cut:]
+		// Пробел продолжения входит в длину строки
+		limit = maxLineOctets - 1
 	}
-
-	sb.WriteString("END:VCALENDAR\r\n")
-
-	return sb.String()
+	sb.WriteString(line)
+	sb.WriteString("\r\n")
 }
 
 // formatICSTime форматирует время в формат iCalendar
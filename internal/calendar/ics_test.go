@@ -0,0 +1,89 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateFeed(t *testing.T) {
+	now := time.Date(2030, 3, 1, 9, 0, 0, 0, time.UTC)
+	moscow := mustLoad(t, "Europe/Moscow")
+	events := []Event{
+		{
+			UID:       "training-7@workbot",
+			Summary:   "Тренировка",
+			StartTime: time.Date(2030, 3, 5, 10, 0, 0, 0, moscow),
+			EndTime:   time.Date(2030, 3, 5, 11, 0, 0, 0, moscow),
+			Reminder:  60,
+			Sequence:  2,
+		},
+		{
+			UID:       "training-8@workbot",
+			Summary:   "Тренировка",
+			StartTime: time.Date(2030, 3, 6, 10, 0, 0, 0, moscow),
+			EndTime:   time.Date(2030, 3, 6, 11, 0, 0, 0, moscow),
+			Reminder:  60,
+			Sequence:  1,
+			Cancelled: true,
+		},
+		{
+			UID:       "workout-3@workbot",
+			Summary:   "Присед, жим",
+			StartTime: time.Date(2030, 3, 7, 0, 0, 0, 0, time.UTC),
+			AllDay:    true,
+		},
+	}
+
+	feed := GenerateFeed("Тренировки, Иван", events, now)
+	for _, want := range []string{
+		"X-WR-CALNAME:Тренировки\\, Иван\r\n",
+		"REFRESH-INTERVAL;VALUE=DURATION:PT60M\r\n",
+		"DTSTAMP:20300301T090000Z\r\n",
+		"UID:training-7@workbot\r\nDTSTAMP:20300301T090000Z\r\nDTSTART:20300305T070000Z\r\n",
+		"SEQUENCE:2\r\n",
+		"SEQUENCE:1\r\nSTATUS:CANCELLED\r\nEND:VEVENT\r\n",
+		"DTSTART;VALUE=DATE:20300307\r\nDTEND;VALUE=DATE:20300308\r\n",
+	} {
+		if !strings.Contains(feed, want) {
+			t.Errorf("нет %q в\n%s", want, feed)
+		}
+	}
+	// Напоминание только у действующей записи
+	if n := strings.Count(feed, "BEGIN:VALARM"); n != 1 {
+		t.Errorf("VALARM = %d, want 1", n)
+	}
+	// У события без изменений SEQUENCE не выводится
+	workout := feed[strings.Index(feed, "UID:workout-3"):]
+	if strings.Contains(workout, "SEQUENCE") || strings.Contains(workout, "STATUS") {
+		t.Errorf("лишние поля у тренировки программы:\n%s", workout)
+	}
+}
+
+func TestWriteLineFolding(t *testing.T) {
+	var sb strings.Builder
+	line := "DESCRIPTION:" + strings.Repeat("Жим лёжа ", 20)
+	writeLine(&sb, line)
+
+	out := sb.String()
+	parts := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+	if len(parts) < 3 {
+		t.Fatalf("строка не перенесена: %q", out)
+	}
+	var joined strings.Builder
+	for i, p := range parts {
+		if len(p) > maxLineOctets {
+			t.Errorf("строка %d длиннее %d байт: %d", i, maxLineOctets, len(p))
+		}
+		if i > 0 {
+			if !strings.HasPrefix(p, " ") {
+				t.Errorf("продолжение %d без пробела: %q", i, p)
+			}
+			p = p[1:]
+		}
+		joined.WriteString(p)
+	}
+	if joined.String() != line {
+		t.Errorf("после склейки %q, want %q", joined.String(), line)
+	}
+}
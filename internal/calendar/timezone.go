@@ -0,0 +1,187 @@
+package calendar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata" // база часовых поясов на случай её отсутствия в образе
+)
+
+// ZoneOption часовой пояс для выбора в меню
+type ZoneOption struct {
+	Name  string // IANA имя
+	Label string // подпись кнопки
+}
+
+// CommonZones часовые пояса, предлагаемые при выборе
+var CommonZones = []ZoneOption{
+	{"Europe/Kaliningrad", "Калининград"},
+	{"Europe/Moscow", "Москва"},
+	{"Europe/Samara", "Самара"},
+	{"Asia/Yekaterinburg", "Екатеринбург"},
+	{"Asia/Omsk", "Омск"},
+	{"Asia/Novosibirsk", "Новосибирск"},
+	{"Asia/Krasnoyarsk", "Красноярск"},
+	{"Asia/Irkutsk", "Иркутск"},
+	{"Asia/Yakutsk", "Якутск"},
+	{"Asia/Vladivostok", "Владивосток"},
+	{"Asia/Magadan", "Магадан"},
+	{"Asia/Kamchatka", "Камчатка"},
+	{"Europe/Minsk", "Минск"},
+	{"Europe/Kyiv", "Киев"},
+	{"Asia/Almaty", "Алматы"},
+	{"Asia/Tashkent", "Ташкент"},
+	{"Asia/Tbilisi", "Тбилиси"},
+	{"Asia/Yerevan", "Ереван"},
+	{"Asia/Dubai", "Дубай"},
+	{"Europe/Berlin", "Берлин"},
+	{"Europe/London", "Лондон"},
+	{"America/New_York", "Нью-Йорк"},
+}
+
+var locationCache = struct {
+	sync.RWMutex
+	data map[string]*time.Location
+}{data: make(map[string]*time.Location)}
+
+// LoadLocation загружает часовой пояс по IANA имени с кэшированием.
+// Пустое или неизвестное имя возвращает fallback
+func LoadLocation(name string, fallback *time.Location) *time.Location {
+	if name == "" {
+		return fallback
+	}
+
+	locationCache.RLock()
+	loc, ok := locationCache.data[name]
+	locationCache.RUnlock()
+	if ok {
+		return loc
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fallback
+	}
+
+	locationCache.Lock()
+	locationCache.data[name] = loc
+	locationCache.Unlock()
+	return loc
+}
+
+// ParseTimezone разбирает часовой пояс, введённый пользователем, и возвращает IANA имя.
+// Принимает IANA имена (Europe/Moscow), названия городов из CommonZones
+// и фиксированные смещения: UTC, UTC+3, GMT-5, +4
+func ParseTimezone(input string) (string, error) {
+	s := strings.TrimSpace(input)
+	if s == "" {
+		return "", fmt.Errorf("часовой пояс не указан")
+	}
+
+	for _, z := range CommonZones {
+		if strings.EqualFold(s, z.Label) || strings.EqualFold(s, z.Name) {
+			return z.Name, nil
+		}
+	}
+
+	upper := strings.ToUpper(s)
+	for _, prefix := range []string{"UTC", "GMT"} {
+		if strings.HasPrefix(upper, prefix) {
+			upper = strings.TrimSpace(strings.TrimPrefix(upper, prefix))
+			if upper == "" || upper == "0" || upper == "+0" || upper == "-0" {
+				return "UTC", nil
+			}
+			break
+		}
+	}
+	if upper[0] == '+' || upper[0] == '-' {
+		digits := strings.TrimSuffix(strings.TrimSuffix(upper[1:], ":00"), "00")
+		if digits == "" {
+			digits = "0"
+		}
+		hours, err := strconv.Atoi(digits)
+		if err != nil || hours > 14 || (upper[0] == '-' && hours > 12) {
+			return "", fmt.Errorf("неверное смещение %q, используйте целые часы, например UTC+3", input)
+		}
+		if hours == 0 {
+			return "UTC", nil
+		}
+		// В базе tz знак Etc/GMT инвертирован: Etc/GMT-3 = UTC+3
+		sign := "-"
+		if upper[0] == '-' {
+			sign = "+"
+		}
+		return fmt.Sprintf("Etc/GMT%s%d", sign, hours), nil
+	}
+
+	if loc, err := time.LoadLocation(s); err == nil && s != "Local" {
+		return loc.String(), nil
+	}
+	return "", fmt.Errorf("неизвестный часовой пояс %q", input)
+}
+
+// FormatOffset возвращает смещение пояса в момент t, например "UTC+3" или "UTC-3:30"
+func FormatOffset(t time.Time, loc *time.Location) string {
+	_, offset := t.In(loc).Zone()
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	hours, minutes := offset/3600, offset%3600/60
+	if minutes != 0 {
+		return fmt.Sprintf("UTC%s%d:%02d", sign, hours, minutes)
+	}
+	return fmt.Sprintf("UTC%s%d", sign, hours)
+}
+
+// ZoneLabel возвращает подпись пояса: название города (если известно) и текущее смещение
+func ZoneLabel(name string, loc *time.Location, now time.Time) string {
+	for _, z := range CommonZones {
+		if z.Name == name {
+			return fmt.Sprintf("%s (%s)", z.Label, FormatOffset(now, loc))
+		}
+	}
+	if name == "" {
+		name = loc.String()
+	}
+	return fmt.Sprintf("%s (%s)", name, FormatOffset(now, loc))
+}
+
+// CombineDateTimeIn объединяет дату и время в часовом поясе loc.
+// Время в «пропущенный» при переходе на летнее время час сдвигается вперёд
+func CombineDateTimeIn(date time.Time, hour, minute int, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, loc)
+}
+
+// NextDailyAt возвращает ближайший момент после now, когда в поясе loc наступит hour:00.
+// Переход на летнее/зимнее время учитывается: интервал может быть 23 или 25 часов
+func NextDailyAt(now time.Time, hour int, loc *time.Location) time.Time {
+	local := now.In(loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), hour, 0, 0, 0, loc)
+	if !next.After(now) {
+		next = time.Date(local.Year(), local.Month(), local.Day()+1, hour, 0, 0, 0, loc)
+	}
+	return next
+}
+
+// SameLocalDay проверяет, что моменты a и b приходятся на один календарный день в поясе loc
+func SameLocalDay(a, b time.Time, loc *time.Location) bool {
+	ay, am, ad := a.In(loc).Date()
+	by, bm, bd := b.In(loc).Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// DayBeforeReminderDue проверяет, пора ли отправить напоминание за день:
+// в поясе клиента уже наступил час sendHour, а тренировка start — завтра.
+// Напоминание, пропущенное в sendHour (например, бот был выключен), уходит позже в тот же день
+func DayBeforeReminderDue(start, now time.Time, sendHour int, loc *time.Location) bool {
+	if now.In(loc).Hour() < sendHour {
+		return false
+	}
+	local := now.In(loc)
+	tomorrow := time.Date(local.Year(), local.Month(), local.Day()+1, 12, 0, 0, 0, loc)
+	return SameLocalDay(start, tomorrow, loc)
+}
@@ -0,0 +1,190 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("LoadLocation(%q): %v", name, err)
+	}
+	return loc
+}
+
+func TestParseTimezone(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"Europe/Moscow", "Europe/Moscow", false},
+		{"москва", "Europe/Moscow", false},
+		{"Новосибирск", "Asia/Novosibirsk", false},
+		{"America/Sao_Paulo", "America/Sao_Paulo", false},
+		{"UTC", "UTC", false},
+		{"utc+3", "Etc/GMT-3", false},
+		{"GMT-5", "Etc/GMT+5", false},
+		{"+05:00", "Etc/GMT-5", false},
+		{"+0", "UTC", false},
+		{"UTC+5:30", "", true},
+		{"UTC+15", "", true},
+		{"Марс/Олимп", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimezone(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseTimezone(%q) = %q, %v; want %q, err=%v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestFormatOffset(t *testing.T) {
+	berlin := mustLoad(t, "Europe/Berlin")
+	winter := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	summer := time.Date(2025, 7, 15, 12, 0, 0, 0, time.UTC)
+
+	if got := FormatOffset(winter, berlin); got != "UTC+1" {
+		t.Errorf("winter offset = %s", got)
+	}
+	if got := FormatOffset(summer, berlin); got != "UTC+2" {
+		t.Errorf("summer offset = %s", got)
+	}
+	if got := FormatOffset(winter, mustLoad(t, "America/St_Johns")); got != "UTC-3:30" {
+		t.Errorf("St Johns offset = %s", got)
+	}
+	if got := ZoneLabel("Europe/Moscow", mustLoad(t, "Europe/Moscow"), winter); got != "Москва (UTC+3)" {
+		t.Errorf("ZoneLabel = %s", got)
+	}
+}
+
+func TestCombineDateTimeInDST(t *testing.T) {
+	berlin := mustLoad(t, "Europe/Berlin")
+
+	// 30 марта 2025 в Берлине часы переводятся с 02:00 на 03:00
+	springDay := time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC)
+	morning := CombineDateTimeIn(springDay, 10, 0, berlin)
+	if got := morning.UTC().Format("15:04"); got != "08:00" {
+		t.Errorf("10:00 CEST = %s UTC, want 08:00", got)
+	}
+	dayBefore := CombineDateTimeIn(springDay.AddDate(0, 0, -1), 10, 0, berlin)
+	if got := morning.Sub(dayBefore); got != 23*time.Hour {
+		t.Errorf("10:00 to 10:00 over spring forward = %v, want 23h", got)
+	}
+
+	// Несуществующее время 02:30 сдвигается вперёд
+	gap := CombineDateTimeIn(springDay, 2, 30, berlin)
+	if got := gap.Format("15:04 MST"); got != "03:30 CEST" {
+		t.Errorf("02:30 in the gap = %s", got)
+	}
+
+	// Одинаковое локальное время у тренера и клиента в разных поясах
+	ny := mustLoad(t, "America/New_York")
+	slot := CombineDateTimeIn(time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC), 18, 0, berlin)
+	if got := slot.In(ny).Format("15:04"); got != "13:00" {
+		t.Errorf("18:00 Berlin in New York during DST mismatch week = %s, want 13:00", got)
+	}
+}
+
+func TestNextDailyAtDST(t *testing.T) {
+	berlin := mustLoad(t, "Europe/Berlin")
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+		gap  time.Duration
+	}{
+		{
+			name: "before 9 same day",
+			now:  time.Date(2025, 6, 10, 7, 0, 0, 0, berlin),
+			want: time.Date(2025, 6, 10, 9, 0, 0, 0, berlin),
+			gap:  2 * time.Hour,
+		},
+		{
+			name: "exactly 9 moves to next day",
+			now:  time.Date(2025, 6, 10, 9, 0, 0, 0, berlin),
+			want: time.Date(2025, 6, 11, 9, 0, 0, 0, berlin),
+			gap:  24 * time.Hour,
+		},
+		{
+			name: "spring forward night is 23h",
+			now:  time.Date(2025, 3, 29, 9, 0, 0, 0, berlin),
+			want: time.Date(2025, 3, 30, 9, 0, 0, 0, berlin),
+			gap:  23 * time.Hour,
+		},
+		{
+			name: "fall back night is 25h",
+			now:  time.Date(2025, 10, 25, 9, 0, 0, 0, berlin),
+			want: time.Date(2025, 10, 26, 9, 0, 0, 0, berlin),
+			gap:  25 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextDailyAt(tt.now, 9, berlin)
+			if !got.Equal(tt.want) {
+				t.Errorf("NextDailyAt = %v, want %v", got, tt.want)
+			}
+			if d := got.Sub(tt.now); d != tt.gap {
+				t.Errorf("wait = %v, want %v", d, tt.gap)
+			}
+			if got.In(berlin).Hour() != 9 {
+				t.Errorf("local hour = %d", got.In(berlin).Hour())
+			}
+		})
+	}
+}
+
+func TestDayBeforeReminderDue(t *testing.T) {
+	moscow := mustLoad(t, "Europe/Moscow")
+	vladivostok := mustLoad(t, "Asia/Vladivostok")
+	berlin := mustLoad(t, "Europe/Berlin")
+
+	// Тренировка в Москве 16 июня 08:00 = 15:00 во Владивостоке
+	start := CombineDateTimeIn(time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC), 8, 0, moscow)
+
+	if !DayBeforeReminderDue(start, time.Date(2025, 6, 15, 9, 10, 0, 0, moscow), 9, moscow) {
+		t.Error("moscow client should get reminder at 09:10 the day before")
+	}
+	if DayBeforeReminderDue(start, time.Date(2025, 6, 15, 8, 50, 0, 0, moscow), 9, moscow) {
+		t.Error("no reminder before 9 o'clock")
+	}
+	if !DayBeforeReminderDue(start, time.Date(2025, 6, 15, 14, 0, 0, 0, moscow), 9, moscow) {
+		t.Error("missed morning reminder should catch up later the same day")
+	}
+	if DayBeforeReminderDue(start, time.Date(2025, 6, 16, 7, 0, 0, 0, moscow), 9, moscow) {
+		t.Error("no day-before reminder on the training day")
+	}
+	if !DayBeforeReminderDue(start, time.Date(2025, 6, 15, 9, 5, 0, 0, vladivostok), 9, vladivostok) {
+		t.Error("vladivostok client should get reminder at their 09:05")
+	}
+	if DayBeforeReminderDue(start, time.Date(2025, 6, 15, 8, 30, 0, 0, vladivostok), 9, vladivostok) {
+		t.Error("vladivostok 08:30 is before reminder time")
+	}
+	if DayBeforeReminderDue(start, time.Date(2025, 6, 16, 3, 5, 0, 0, moscow), 9, vladivostok) {
+		t.Error("moscow 03:05 is 10:05 in vladivostok — training is today there, not tomorrow")
+	}
+
+	// Тренировка в понедельник после перевода часов (Берлин, 27 октября 2025 — понедельник после 26-го)
+	monday := CombineDateTimeIn(time.Date(2025, 10, 27, 0, 0, 0, 0, time.UTC), 7, 0, berlin)
+	if !DayBeforeReminderDue(monday, time.Date(2025, 10, 26, 9, 30, 0, 0, berlin), 9, berlin) {
+		t.Error("reminder on the fall-back day should still fire at local 09:xx")
+	}
+}
+
+func TestGenerateICSUsesUTC(t *testing.T) {
+	berlin := mustLoad(t, "Europe/Berlin")
+	start := CombineDateTimeIn(time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), 18, 0, berlin)
+
+	ics := GenerateICS(Event{UID: "x", Summary: "Тренировка", StartTime: start, EndTime: start.Add(time.Hour)})
+	if !strings.Contains(ics, "DTSTART:20250331T160000Z") || !strings.Contains(ics, "DTEND:20250331T170000Z") {
+		t.Errorf("unexpected ICS times:\n%s", ics)
+	}
+}
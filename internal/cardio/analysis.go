@@ -0,0 +1,178 @@
+package cardio
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// maxGap — промежуток между точками длиннее этого считается паузой записи
+	maxGap = 30 * time.Second
+	// minMovingSpeed — медленнее (м/с) клиент стоит: время не входит в темп
+	minMovingSpeed = 0.5
+	// splitMeters — длина сплита
+	splitMeters = 1000.0
+	// minLastSplit — последний неполный сплит показывается, если он не короче 100 м
+	minLastSplit = 100.0
+)
+
+// Zone — пульсовая зона в ударах в минуту, границы включительно
+type Zone struct {
+	Name      string
+	Low, High int
+}
+
+// Split — отрезок дистанции; время — только в движении
+type Split struct {
+	Num      int
+	Distance float64 // м; у последнего может быть меньше километра
+	Moving   time.Duration
+	AvgHR    int
+}
+
+// Pace — темп сплита на километр
+func (s Split) Pace() time.Duration {
+	if s.Distance <= 0 {
+		return 0
+	}
+	return time.Duration(float64(s.Moving) / s.Distance * splitMeters)
+}
+
+// Summary — итоги записанной тренировки
+type Summary struct {
+	Start    time.Time
+	Duration time.Duration // от первой точки до последней
+	Moving   time.Duration // без пауз и стоянок
+	Distance float64       // м
+	AvgHR    int           // средний по времени
+	MaxHR    int
+	Splits   []Split
+	// ZoneSeconds — секунд в каждой зоне (в порядке зон Analyze). Пульс ниже
+	// первой зоны относится к первой, выше последней — к последней
+	ZoneSeconds []int
+
+	hrSeconds map[int]float64 // секунд на каждом значении пульса
+}
+
+// Pace — средний темп на километр по времени в движении
+func (s Summary) Pace() time.Duration {
+	if s.Distance <= 0 {
+		return 0
+	}
+	return time.Duration(float64(s.Moving) / s.Distance * splitMeters)
+}
+
+// HasHR — в записи есть пульс
+func (s Summary) HasHR() bool {
+	return len(s.hrSeconds) > 0
+}
+
+// TimeInRange — секунды с пульсом ниже, внутри и выше диапазона [low, high]
+func (s Summary) TimeInRange(low, high int) (below, in, above float64) {
+	for hr, sec := range s.hrSeconds {
+		switch {
+		case hr < low:
+			below += sec
+		case hr > high:
+			above += sec
+		default:
+			in += sec
+		}
+	}
+	return below, in, above
+}
+
+// Analyze считает итоги тренировки. zones — пульсовые зоны по возрастанию
+func Analyze(a *Activity, zones []Zone) Summary {
+	pts := a.Points
+	s := Summary{ZoneSeconds: make([]int, len(zones)), hrSeconds: make(map[int]float64)}
+	if len(pts) == 0 {
+		return s
+	}
+	s.Start = pts[0].Time
+	s.Duration = pts[len(pts)-1].Time.Sub(pts[0].Time)
+	s.Distance = pts[len(pts)-1].Distance - pts[0].Distance
+
+	zoneSec := make([]float64, len(zones))
+	var hrWeighted, hrTime float64
+
+	split := Split{Num: 1}
+	var splitHR, splitHRTime float64
+	splitStart := pts[0].Distance
+	closeSplit := func(end float64) {
+		split.Distance = end - splitStart
+		if splitHRTime > 0 {
+			split.AvgHR = int(math.Round(splitHR / splitHRTime))
+		}
+		s.Splits = append(s.Splits, split)
+		split = Split{Num: split.Num + 1}
+		splitHR, splitHRTime = 0, 0
+		splitStart = end
+	}
+
+	for i, p := range pts {
+		if p.HR > s.MaxHR {
+			s.MaxHR = p.HR
+		}
+		if i == 0 {
+			continue
+		}
+		prev := pts[i-1]
+		dt := p.Time.Sub(prev.Time)
+		if dt <= 0 || dt > maxGap {
+			continue
+		}
+		sec := dt.Seconds()
+
+		if prev.HR > 0 {
+			hrWeighted += float64(prev.HR) * sec
+			hrTime += sec
+			s.hrSeconds[prev.HR] += sec
+			if len(zones) > 0 {
+				zoneSec[zoneIndex(zones, prev.HR)] += sec
+			}
+			splitHR += float64(prev.HR) * sec
+			splitHRTime += sec
+		}
+
+		dd := p.Distance - prev.Distance
+		if dd/sec < minMovingSpeed {
+			continue
+		}
+		s.Moving += dt
+
+		// Сплит может закончиться внутри отрезка: время делится пропорционально дистанции
+		from := prev.Distance
+		remaining := dt
+		for p.Distance >= splitStart+splitMeters {
+			boundary := splitStart + splitMeters
+			part := time.Duration(float64(remaining) * (boundary - from) / (p.Distance - from))
+			split.Moving += part
+			remaining -= part
+			from = boundary
+			closeSplit(boundary)
+		}
+		split.Moving += remaining
+	}
+	if last := pts[len(pts)-1].Distance; last-splitStart >= minLastSplit {
+		closeSplit(last)
+	}
+
+	if hrTime > 0 {
+		s.AvgHR = int(math.Round(hrWeighted / hrTime))
+	}
+	for i, sec := range zoneSec {
+		s.ZoneSeconds[i] = int(math.Round(sec))
+	}
+	return s
+}
+
+// zoneIndex — зона пульса; вне зон — крайняя
+func zoneIndex(zones []Zone, hr int) int {
+	for i, z := range zones {
+		if hr <= z.High {
+			return i
+		}
+	}
+	return len(zones) - 1
+}
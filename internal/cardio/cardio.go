@@ -0,0 +1,155 @@
+// Package cardio разбирает записи кардиотренировок из файлов часов и трекеров
+// (GPX, TCX, FIT) и сравнивает их с назначением тренера.
+//
+// Файл превращается в последовательность точек (время, координаты, дистанция,
+// пульс). По ним считаются длительность, дистанция, сплиты по километрам и время
+// в пульсовых зонах (analysis.go). Назначение извлекается из упражнений
+// запланированной тренировки (prescription.go), а соответствие ему даёт оценку
+// 0–100 и поправку объёма следующих кардиотренировок (compliance.go).
+// Пакет не знает о базе и боте.
+package cardio
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Format — формат файла тренировки
+type Format string
+
+const (
+	FormatGPX Format = "gpx"
+	FormatTCX Format = "tcx"
+	FormatFIT Format = "fit"
+)
+
+// FormatFromFilename определяет формат по расширению файла
+func FormatFromFilename(name string) (Format, bool) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".gpx":
+		return FormatGPX, true
+	case ".tcx":
+		return FormatTCX, true
+	case ".fit":
+		return FormatFIT, true
+	}
+	return "", false
+}
+
+// Point — точка записи; нулевые поля — устройство их не записало
+type Point struct {
+	Time     time.Time
+	Lat, Lon float64
+	HasPos   bool
+	Distance float64 // накопленная дистанция, м; 0 — устройство не записало
+	HR       int     // пульс, уд/мин
+}
+
+// Activity — запись тренировки
+type Activity struct {
+	Sport  string // running, cycling… — как записало устройство; может быть пустым
+	Points []Point
+}
+
+// Ошибки разбора
+var (
+	ErrNoPoints      = errors.New("в файле нет точек с временем")
+	ErrUnknownFormat = errors.New("неизвестный формат файла")
+)
+
+// Parse разбирает файл тренировки. Точки упорядочиваются по времени, а если
+// устройство не записало дистанцию, она считается по координатам
+func Parse(data []byte, format Format) (*Activity, error) {
+	var (
+		a   *Activity
+		err error
+	)
+	switch format {
+	case FormatGPX:
+		a, err = parseGPX(data)
+	case FormatTCX:
+		a, err = parseTCX(data)
+	case FormatFIT:
+		a, err = parseFIT(data)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	points := a.Points[:0]
+	for _, p := range a.Points {
+		if !p.Time.IsZero() {
+			points = append(points, p)
+		}
+	}
+	if len(points) < 2 {
+		return nil, ErrNoPoints
+	}
+	sortPoints(points)
+	a.Points = points
+	fillDistance(a.Points)
+	return a, nil
+}
+
+// sortPoints сортирует точки по времени вставками: записи почти всегда уже упорядочены
+func sortPoints(points []Point) {
+	for i := 1; i < len(points); i++ {
+		for j := i; j > 0 && points[j].Time.Before(points[j-1].Time); j-- {
+			points[j], points[j-1] = points[j-1], points[j]
+		}
+	}
+}
+
+// fillDistance восстанавливает накопленную дистанцию: пропуски в записанной
+// дистанции заполняются предыдущим значением, а без записанной — считается по координатам
+func fillDistance(points []Point) {
+	recorded := false
+	for _, p := range points {
+		if p.Distance > 0 {
+			recorded = true
+			break
+		}
+	}
+
+	if recorded {
+		last := 0.0
+		for i := range points {
+			if points[i].Distance < last {
+				points[i].Distance = last
+			}
+			last = points[i].Distance
+		}
+		return
+	}
+
+	total := 0.0
+	var prev *Point
+	for i := range points {
+		p := &points[i]
+		if p.HasPos {
+			if prev != nil {
+				total += haversine(prev.Lat, prev.Lon, p.Lat, p.Lon)
+			}
+			prev = p
+		}
+		p.Distance = total
+	}
+}
+
+// earthRadius — средний радиус Земли, м
+const earthRadius = 6371000.0
+
+// haversine — расстояние по поверхности Земли между двумя точками, м
+func haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := math.Pi / 180
+	dLat := (lat2 - lat1) * toRad
+	dLon := (lon2 - lon1) * toRad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*toRad)*math.Cos(lat2*toRad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
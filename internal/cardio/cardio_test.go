@@ -0,0 +1,332 @@
+package cardio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testStart = time.Date(2026, 5, 10, 7, 0, 0, 0, time.UTC)
+
+// testZones — зоны для максимального пульса 190
+var testZones = []Zone{
+	{"recovery", 95, 114}, {"fat_burn", 114, 133}, {"aerobic", 133, 152},
+	{"threshold", 152, 171}, {"anaerobic", 171, 190},
+}
+
+// steadyRun — равномерный бег: точка каждые 10 секунд, 3 м/с (5:33 /км), постоянный пульс
+func steadyRun(seconds, hr int) *Activity {
+	a := &Activity{Sport: "running"}
+	for t := 0; t <= seconds; t += 10 {
+		a.Points = append(a.Points, Point{
+			Time: testStart.Add(time.Duration(t) * time.Second), Distance: float64(t) * 3, HR: hr,
+		})
+	}
+	return a
+}
+
+func TestParseGPX(t *testing.T) {
+	gpx := `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" xmlns="http://www.topografix.com/GPX/1/1"
+     xmlns:gpxtpx="http://www.garmin.com/xmlschemas/TrackPointExtension/v1">
+  <trk><type>running</type><trkseg>
+    <trkpt lat="55.750000" lon="37.600000"><time>2026-05-10T07:00:10Z</time>
+      <extensions><gpxtpx:TrackPointExtension><gpxtpx:hr>142</gpxtpx:hr></gpxtpx:TrackPointExtension></extensions></trkpt>
+    <trkpt lat="55.750000" lon="37.600000"><time>2026-05-10T07:00:00Z</time>
+      <extensions><gpxtpx:TrackPointExtension><gpxtpx:hr>120</gpxtpx:hr></gpxtpx:TrackPointExtension></extensions></trkpt>
+    <trkpt lat="55.750900" lon="37.600000"><time>2026-05-10T07:00:20Z</time></trkpt>
+  </trkseg></trk>
+</gpx>`
+
+	a, err := Parse([]byte("\xef\xbb\xbf"+gpx), FormatGPX)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if a.Sport != "running" || len(a.Points) != 3 {
+		t.Fatalf("Sport = %q, точек %d; want running, 3", a.Sport, len(a.Points))
+	}
+	if a.Points[0].HR != 120 || a.Points[1].HR != 142 {
+		t.Errorf("пульс %d, %d: точки не упорядочены по времени", a.Points[0].HR, a.Points[1].HR)
+	}
+	// 0.0009° широты ≈ 100 м
+	if d := a.Points[2].Distance; math.Abs(d-100) > 1 {
+		t.Errorf("дистанция по координатам = %.1f, want ≈ 100", d)
+	}
+}
+
+func TestParseTCX(t *testing.T) {
+	tcx := `<?xml version="1.0" encoding="UTF-8"?>
+<TrainingCenterDatabase xmlns="http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2">
+  <Activities><Activity Sport="Running"><Lap StartTime="2026-05-10T07:00:00Z"><Track>
+    <Trackpoint><Time>2026-05-10T07:00:00Z</Time><DistanceMeters>0</DistanceMeters><HeartRateBpm><Value>110</Value></HeartRateBpm></Trackpoint>
+    <Trackpoint><Time>2026-05-10T07:00:10Z</Time><HeartRateBpm><Value>125</Value></HeartRateBpm></Trackpoint>
+    <Trackpoint><Time>2026-05-10T07:00:20Z</Time><Position><LatitudeDegrees>55.75</LatitudeDegrees><LongitudeDegrees>37.6</LongitudeDegrees></Position><DistanceMeters>62.5</DistanceMeters><HeartRateBpm><Value>131</Value></HeartRateBpm></Trackpoint>
+  </Track></Lap></Activity></Activities>
+</TrainingCenterDatabase>`
+
+	a, err := Parse([]byte(tcx), FormatTCX)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if a.Sport != "Running" || len(a.Points) != 3 {
+		t.Fatalf("Sport = %q, точек %d", a.Sport, len(a.Points))
+	}
+	if !a.Points[2].HasPos || a.Points[2].Distance != 62.5 || a.Points[2].HR != 131 {
+		t.Errorf("последняя точка = %+v", a.Points[2])
+	}
+	// Пропуск в записанной дистанции заполняется предыдущим значением
+	if a.Points[1].Distance != 0 {
+		t.Errorf("дистанция точки без DistanceMeters = %v, want 0", a.Points[1].Distance)
+	}
+}
+
+// fitBuilder собирает FIT-файл для тестов
+type fitBuilder struct{ buf bytes.Buffer }
+
+func (f *fitBuilder) define(local byte, global uint16, fields ...[2]byte) {
+	f.buf.WriteByte(0x40 | local)
+	f.buf.Write([]byte{0, 0}) // reserved, little-endian
+	binary.Write(&f.buf, binary.LittleEndian, global)
+	f.buf.WriteByte(byte(len(fields)))
+	for _, fd := range fields {
+		f.buf.Write([]byte{fd[0], fd[1], 0})
+	}
+}
+
+func (f *fitBuilder) record(header byte, values ...interface{}) {
+	f.buf.WriteByte(header)
+	for _, v := range values {
+		binary.Write(&f.buf, binary.LittleEndian, v)
+	}
+}
+
+func (f *fitBuilder) bytes() []byte {
+	var out bytes.Buffer
+	out.Write([]byte{14, 0x20, 0x08, 0x08})
+	binary.Write(&out, binary.LittleEndian, uint32(f.buf.Len()))
+	out.WriteString(".FIT")
+	out.Write([]byte{0, 0})
+	out.Write(f.buf.Bytes())
+	out.Write([]byte{0, 0}) // CRC файла не проверяется
+	return out.Bytes()
+}
+
+func TestParseFIT(t *testing.T) {
+	ts := uint32(testStart.Sub(fitEpoch).Seconds())
+	semicircles := func(deg float64) int32 { return int32(deg / fitSemicircle) }
+
+	var f fitBuilder
+	f.define(0, fitMsgRecord, [2]byte{fitFieldTimestamp, 4}, [2]byte{fitRecordLat, 4},
+		[2]byte{fitRecordLon, 4}, [2]byte{fitRecordDistance, 4}, [2]byte{fitRecordHR, 1})
+	f.record(0x00, ts, semicircles(55.75), semicircles(37.6), uint32(0), uint8(118))
+	// Координаты не записаны (недопустимое значение FIT)
+	f.record(0x00, ts+5, uint32(0x7FFFFFFF), uint32(0x7FFFFFFF), uint32(1500), uint8(0xFF))
+	// Сжатый заголовок времени: +5 секунд к последней метке
+	f.define(1, fitMsgRecord, [2]byte{fitRecordDistance, 4}, [2]byte{fitRecordHR, 1})
+	f.record(0x80|1<<5|byte((ts+10)&0x1F), uint32(3000), uint8(131))
+	f.define(2, fitMsgSession, [2]byte{fitSessionSport, 1})
+	f.record(0x02, uint8(1))
+
+	a, err := Parse(f.bytes(), FormatFIT)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if a.Sport != "running" || len(a.Points) != 3 {
+		t.Fatalf("Sport = %q, точек %d; want running, 3", a.Sport, len(a.Points))
+	}
+	p0, p1, p2 := a.Points[0], a.Points[1], a.Points[2]
+	if !p0.HasPos || math.Abs(p0.Lat-55.75) > 1e-6 || math.Abs(p0.Lon-37.6) > 1e-6 || p0.HR != 118 {
+		t.Errorf("первая точка = %+v", p0)
+	}
+	if p1.HasPos || p1.HR != 0 || p1.Distance != 15 {
+		t.Errorf("вторая точка = %+v, want без координат и пульса, 15 м", p1)
+	}
+	if !p2.Time.Equal(testStart.Add(10*time.Second)) || p2.Distance != 30 || p2.HR != 131 {
+		t.Errorf("точка со сжатым временем = %+v", p2)
+	}
+
+	if _, err := Parse([]byte("not a fit file"), FormatFIT); err == nil {
+		t.Error("Parse без заголовка FIT: ошибки нет")
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	a := steadyRun(1200, 140) // 20 минут по 3 м/с
+	// Автопауза 2 минуты в середине: запись продолжается с того же места
+	for i := range a.Points {
+		if a.Points[i].Time.Sub(testStart) > 600*time.Second {
+			a.Points[i].Time = a.Points[i].Time.Add(2 * time.Minute)
+			a.Points[i].Distance -= 30
+		}
+	}
+	s := Analyze(a, testZones)
+
+	if s.Duration != 22*time.Minute {
+		t.Errorf("Duration = %v, want 22m", s.Duration)
+	}
+	if s.Moving != 1190*time.Second {
+		t.Errorf("Moving = %v, want 19m50s (без паузы)", s.Moving)
+	}
+	if s.Distance != 3570 {
+		t.Errorf("Distance = %v, want 3570", s.Distance)
+	}
+	if s.AvgHR != 140 || s.MaxHR != 140 {
+		t.Errorf("AvgHR/MaxHR = %d/%d, want 140/140", s.AvgHR, s.MaxHR)
+	}
+	if s.ZoneSeconds[2] != 1190 {
+		t.Errorf("ZoneSeconds = %v, want 1190 с в аэробной зоне", s.ZoneSeconds)
+	}
+
+	if len(s.Splits) != 4 {
+		t.Fatalf("сплитов %d, want 4 (3 полных и 570 м)", len(s.Splits))
+	}
+	for _, sp := range s.Splits[:3] {
+		if sp.Distance != 1000 || math.Abs(sp.Pace().Seconds()-333.3) > 0.5 {
+			t.Errorf("сплит %d: %.0f м, темп %v", sp.Num, sp.Distance, sp.Pace())
+		}
+	}
+	if last := s.Splits[3]; math.Abs(last.Distance-570) > 1e-9 {
+		t.Errorf("последний сплит = %.0f м, want 570", last.Distance)
+	}
+}
+
+func TestParsePrescription(t *testing.T) {
+	tests := []struct {
+		name      string
+		exercises []PlannedExercise
+		ok        bool
+		want      Prescription
+	}{
+		{
+			"LISS", []PlannedExercise{{Name: "LISS Кардио", Sets: 1, Reps: "35 мин",
+				Notes: "Зона жиросжигания, можно поддерживать разговор. Пульс: 65% от максимума"}},
+			true, Prescription{Duration: 35 * time.Minute, HRLowPct: 0.60, HRHighPct: 0.70},
+		},
+		{
+			"HIIT", []PlannedExercise{
+				{Name: "Разминка", Sets: 1, Reps: "5 мин"},
+				{Name: "HIIT интервалы", Sets: 8, Reps: "30 сек работа / 30 сек отдых"},
+				{Name: "Заминка", Sets: 1, Reps: "5 мин"},
+			},
+			true, Prescription{Duration: 18 * time.Minute, Intervals: 8, Work: 30 * time.Second, Rest: 30 * time.Second},
+		},
+		{
+			"интервалы Hyrox", []PlannedExercise{
+				{Name: "Разминка", Sets: 1, Reps: "10 мин"},
+				{Name: "Интервалы 1 км", Sets: 6, Reps: "1000м", Notes: "Темп: 4:30-5:00/км. Цель: 6 км общего объёма"},
+				{Name: "Заминка", Sets: 1, Reps: "5 мин"},
+			},
+			true, Prescription{Duration: 15 * time.Minute, Distance: 6000, PaceFast: 270 * time.Second, PaceSlow: 300 * time.Second},
+		},
+		{
+			"темповый бег", []PlannedExercise{{Name: "Темповый бег", Sets: 1, Reps: "5500м",
+				Notes: "Равномерный темп 5:00-5:30/км. Зона ЧСС 80-85%"}},
+			true, Prescription{Distance: 5500, HRLowPct: 0.80, HRHighPct: 0.85, PaceFast: 300 * time.Second, PaceSlow: 330 * time.Second},
+		},
+		{
+			"восстановительный", []PlannedExercise{{Name: "Восстановительный бег", Sets: 1, Reps: "30-40 мин",
+				Notes: "Очень лёгкий темп, ЧСС < 140"}},
+			true, Prescription{Duration: 30 * time.Minute, HRCapBPM: 140},
+		},
+		{
+			"силовая с финишером", []PlannedExercise{
+				{Name: "Разминка", Sets: 1, Reps: "5 мин"},
+				{Name: "Присед", Sets: 4, Reps: "8-10"},
+				{Name: "Жим лёжа", Sets: 4, Reps: "8-10"},
+				{Name: "Тяга", Sets: 3, Reps: "10"},
+				{Name: "Финишер (круговая)", Sets: 3, Reps: "45 сек работа / 15 сек отдых"},
+			},
+			false, Prescription{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParsePrescription(tt.exercises)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("ParsePrescription = %+v, %v; want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	run := Analyze(steadyRun(1800, 140), testZones) // 30 минут, 5.4 км, 5:33 /км
+
+	tests := []struct {
+		name      string
+		p         Prescription
+		score     int
+		volume    float64
+		inZone    float64
+		paceShare float64
+	}{
+		// Пульс 140 в 70–80% от 190 (133–152), объём и темп выполнены
+		{"всё по плану", Prescription{Duration: 30 * time.Minute, HRLowPct: 0.7, HRHighPct: 0.8}, 100, 1, 1, 0},
+		// Пульс выше потолка: объём 100 × 0.4, пульс 0 × 0.4
+		{"пульс выше цели", Prescription{Duration: 30 * time.Minute, HRCapBPM: 130}, 50, 1, 0, 0},
+		// Дистанция 5.4 из 10 км: 0.54 / 0.95 × 100 ≈ 56.8; темп 5:33 медленнее 5:00–5:20
+		{"недовыполнение", Prescription{Distance: 10000, PaceFast: 300 * time.Second, PaceSlow: 320 * time.Second}, 38, 0.54, 0, 0},
+		{"в темпе", Prescription{Distance: 5000, PaceFast: 320 * time.Second, PaceSlow: 340 * time.Second}, 100, 1.08, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Evaluate(run, tt.p, 190)
+			if !c.Rated || c.Score != tt.score || math.Abs(c.Volume-tt.volume) > 0.01 ||
+				math.Abs(c.InZone-tt.inZone) > 0.01 || math.Abs(c.PaceInRange-tt.paceShare) > 0.01 {
+				t.Errorf("Evaluate = %+v; want score %d, volume %.2f, in zone %.2f, pace %.2f",
+					c, tt.score, tt.volume, tt.inZone, tt.paceShare)
+			}
+		})
+	}
+
+	// Дистанционное назначение без дистанции в записи и без пульса — оценивать нечего
+	if c := Evaluate(Summary{Duration: time.Hour}, Prescription{Distance: 5000}, 190); c.Rated {
+		t.Errorf("Evaluate без данных = %+v, want Rated = false", c)
+	}
+}
+
+func TestNextVolumeFactor(t *testing.T) {
+	tests := []struct {
+		name    string
+		current float64
+		c       Compliance
+		want    float64
+		reason  AdjustReason
+	}{
+		{"первая тренировка, всё выполнено", 0, Compliance{Rated: true, Score: 95, Volume: 1}, 1.05, AdjustProgress},
+		{"недовыполнение", 1.1, Compliance{Rated: true, Score: 70, Volume: 0.8}, 1.05, AdjustIncomplete},
+		{"пульс выше цели", 1, Compliance{Rated: true, Score: 60, Volume: 1, HasHR: true, AboveZone: 0.4}, 0.95, AdjustOverreach},
+		{"хорошо, но пульс местами выше", 1, Compliance{Rated: true, Score: 92, Volume: 1, HasHR: true, AboveZone: 0.2}, 1, AdjustStable},
+		{"упор в максимум", 1.3, Compliance{Rated: true, Score: 100, Volume: 1}, 1.3, AdjustStable},
+		{"упор в минимум", 0.7, Compliance{Rated: true, Score: 40, Volume: 0.5}, 0.7, AdjustStable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := NextVolumeFactor(tt.current, tt.c)
+			if got != tt.want || reason != tt.reason {
+				t.Errorf("NextVolumeFactor = %v, %s; want %v, %s", got, reason, tt.want, tt.reason)
+			}
+		})
+	}
+}
+
+func TestFormatFromFilename(t *testing.T) {
+	for name, want := range map[string]Format{"run.GPX": FormatGPX, "a.tcx": FormatTCX, "2026-05-10.fit": FormatFIT} {
+		if got, ok := FormatFromFilename(name); !ok || got != want {
+			t.Errorf("FormatFromFilename(%q) = %q, %v", name, got, ok)
+		}
+	}
+	if _, ok := FormatFromFilename("program.json"); ok {
+		t.Error("FormatFromFilename(program.json): ok = true")
+	}
+	if _, err := Parse([]byte("<gpx/>"), FormatGPX); err == nil || !strings.Contains(fmt.Sprint(err), "точек") {
+		t.Errorf("Parse пустого GPX: %v, want ErrNoPoints", err)
+	}
+}
@@ -0,0 +1,160 @@
+package cardio
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// volumeFull — объём засчитывается полностью с 95% назначенного
+	volumeFull = 0.95
+	// volumeExcess — больше 130% назначенного — перевыполнение, оценка снижается
+	volumeExcess = 1.3
+	// hrTolerance — допуск к границам целевого пульса, уд/мин
+	hrTolerance = 3
+	// paceTolerance — допуск к границам целевого темпа
+	paceTolerance = 5 * time.Second
+	// minPaceSplit — сплиты короче 500 м в оценку темпа не входят
+	minPaceSplit = 500.0
+)
+
+// Веса составляющих оценки; отсутствующие составляющие не учитываются
+const (
+	weightVolume = 0.4
+	weightHR     = 0.4
+	weightPace   = 0.2
+)
+
+// Compliance — соответствие тренировки назначению
+type Compliance struct {
+	Rated  bool    // есть хотя бы одна составляющая оценки
+	Score  int     // 0–100
+	Volume float64 // выполненная дистанция (или длительность) к назначенной; 0 — не оценивался
+
+	HasHR                        bool
+	BelowZone, InZone, AboveZone float64 // доли времени с пульсом относительно целевого
+
+	HasPace     bool
+	PaceInRange float64 // доля сплитов в целевом темпе
+}
+
+// Evaluate сравнивает итоги тренировки с назначением. Оценка — взвешенное среднее
+// объёма (40%), времени в целевом пульсе (40%) и сплитов в целевом темпе (20%)
+func Evaluate(s Summary, p Prescription, maxHR int) Compliance {
+	var c Compliance
+	var score, weight float64
+
+	// Дистанционное назначение без дистанции в записи (дорожка без датчика) объёмом не оценивается:
+	// длительность такого назначения — только разминка и заминка
+	switch {
+	case p.Distance > 0:
+		if s.Distance > 0 {
+			c.Volume = s.Distance / p.Distance
+		}
+	case p.Duration > 0:
+		c.Volume = s.Duration.Seconds() / p.Duration.Seconds()
+	}
+	if c.Volume > 0 {
+		score += weightVolume * volumeScore(c.Volume)
+		weight += weightVolume
+	}
+
+	if low, high, ok := p.HRRange(maxHR); ok && s.HasHR() {
+		below, in, above := s.TimeInRange(low-hrTolerance, high+hrTolerance)
+		if total := below + in + above; total > 0 {
+			c.HasHR = true
+			c.BelowZone, c.InZone, c.AboveZone = below/total, in/total, above/total
+			score += weightHR * c.InZone * 100
+			weight += weightHR
+		}
+	}
+
+	if p.PaceFast > 0 {
+		var counted, inRange int
+		for _, sp := range s.Splits {
+			if sp.Distance < minPaceSplit {
+				continue
+			}
+			counted++
+			if pace := sp.Pace(); pace >= p.PaceFast-paceTolerance && pace <= p.PaceSlow+paceTolerance {
+				inRange++
+			}
+		}
+		if counted > 0 {
+			c.HasPace = true
+			c.PaceInRange = float64(inRange) / float64(counted)
+			score += weightPace * c.PaceInRange * 100
+			weight += weightPace
+		}
+	}
+
+	if weight > 0 {
+		c.Rated = true
+		c.Score = int(math.Round(score / weight))
+	}
+	return c
+}
+
+// volumeScore — оценка объёма 0–100: недовыполнение снижает её пропорционально,
+// перевыполнение больше чем на 30% — на процент за процент
+func volumeScore(ratio float64) float64 {
+	switch {
+	case ratio >= volumeExcess:
+		return math.Max(0, 100-(ratio-volumeExcess)*100)
+	case ratio >= volumeFull:
+		return 100
+	}
+	return ratio / volumeFull * 100
+}
+
+// Поправка объёма следующих кардиотренировок: множитель длительности,
+// дистанции и числа интервалов в CardioParams
+const (
+	VolumeFactorStep = 0.05
+	VolumeFactorMin  = 0.7
+	VolumeFactorMax  = 1.3
+)
+
+// AdjustReason — почему изменился объём кардио
+type AdjustReason string
+
+const (
+	AdjustProgress   AdjustReason = "progress"   // назначение выполнено — объём выше
+	AdjustIncomplete AdjustReason = "incomplete" // сделано меньше 85% объёма — ниже
+	AdjustOverreach  AdjustReason = "overreach"  // пульс выше цели больше 30% времени — ниже
+	AdjustStable     AdjustReason = "stable"     // без изменений
+)
+
+// Пороги поправки
+const (
+	incompleteVolume = 0.85
+	overreachShare   = 0.3
+	progressScore    = 90
+	progressAbove    = 0.1
+)
+
+// NextVolumeFactor — множитель объёма следующих кардиотренировок по итогам текущей.
+// current = 0 — поправок ещё не было (1.0)
+func NextVolumeFactor(current float64, c Compliance) (float64, AdjustReason) {
+	if current <= 0 {
+		current = 1
+	}
+
+	var delta float64
+	reason := AdjustStable
+	switch {
+	case c.Volume > 0 && c.Volume < incompleteVolume:
+		delta, reason = -VolumeFactorStep, AdjustIncomplete
+	case c.HasHR && c.AboveZone > overreachShare:
+		delta, reason = -VolumeFactorStep, AdjustOverreach
+	case c.Score >= progressScore && (!c.HasHR || c.AboveZone < progressAbove):
+		delta, reason = VolumeFactorStep, AdjustProgress
+	}
+
+	next := math.Round((current+delta)*100) / 100
+	next = math.Min(VolumeFactorMax, math.Max(VolumeFactorMin, next))
+	if next == current && reason != AdjustStable {
+		reason = AdjustStable // упёрлись в границу
+	}
+	return next, reason
+}
@@ -0,0 +1,225 @@
+package cardio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Разбор бинарного FIT (Garmin Flexible and Interoperable Data Transfer) —
+// только то, что нужно для анализа: сообщения record (время, координаты,
+// дистанция, пульс) и вид спорта из session. Остальные сообщения пропускаются
+// по их определениям
+
+// Глобальные номера сообщений и полей профиля FIT
+const (
+	fitMsgSession = 18
+	fitMsgRecord  = 20
+
+	fitFieldTimestamp = 253
+	fitRecordLat      = 0
+	fitRecordLon      = 1
+	fitRecordHR       = 3
+	fitRecordDistance = 5
+	fitSessionSport   = 5
+)
+
+// fitEpoch — начало отсчёта времени FIT: 31.12.1989 00:00 UTC
+var fitEpoch = time.Date(1989, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// fitSemicircle — градусов в одной «полуокружности» FIT (180 / 2^31)
+const fitSemicircle = 180.0 / (1 << 31)
+
+// fitSports — коды вида спорта FIT в названиях, как в TCX/GPX
+var fitSports = map[uint64]string{
+	0: "generic", 1: "running", 2: "cycling", 4: "fitness_equipment",
+	5: "swimming", 11: "walking", 15: "rowing", 17: "hiking",
+}
+
+var errFITHeader = errors.New("FIT: неверный заголовок")
+
+type fitField struct {
+	num  byte
+	size int
+}
+
+type fitDefinition struct {
+	global   uint16
+	order    binary.ByteOrder
+	fields   []fitField
+	devBytes int // суммарный размер полей разработчика — пропускаются
+}
+
+// parseFIT разбирает FIT-файл
+func parseFIT(data []byte) (*Activity, error) {
+	if len(data) < 12 {
+		return nil, errFITHeader
+	}
+	headerSize := int(data[0])
+	if headerSize < 12 || len(data) < headerSize || string(data[8:12]) != ".FIT" {
+		return nil, errFITHeader
+	}
+	end := headerSize + int(binary.LittleEndian.Uint32(data[4:8]))
+	if end > len(data) {
+		end = len(data) // обрезанный файл: читаем сколько есть
+	}
+
+	a := &Activity{}
+	defs := make(map[byte]*fitDefinition)
+	var lastTimestamp uint32
+	pos := headerSize
+
+	for pos < end {
+		header := data[pos]
+		pos++
+
+		// Сжатый заголовок времени: смещение 5 бит от последней метки
+		if header&0x80 != 0 {
+			local := (header >> 5) & 0x03
+			offset := uint32(header & 0x1F)
+			lastTimestamp += (offset - lastTimestamp&0x1F) & 0x1F
+			def := defs[local]
+			if def == nil {
+				return nil, fmt.Errorf("FIT: данные без определения (тип %d)", local)
+			}
+			values, n, err := readFITValues(data[pos:end], def)
+			if err != nil {
+				return nil, err
+			}
+			pos += n
+			values[fitFieldTimestamp] = uint64(lastTimestamp)
+			collectFIT(a, def.global, values)
+			continue
+		}
+
+		local := header & 0x0F
+		if header&0x40 != 0 {
+			def, n, err := readFITDefinition(data[pos:end], header&0x20 != 0)
+			if err != nil {
+				return nil, err
+			}
+			pos += n
+			defs[local] = def
+			continue
+		}
+
+		def := defs[local]
+		if def == nil {
+			return nil, fmt.Errorf("FIT: данные без определения (тип %d)", local)
+		}
+		values, n, err := readFITValues(data[pos:end], def)
+		if err != nil {
+			return nil, err
+		}
+		pos += n
+		if ts, ok := values[fitFieldTimestamp]; ok {
+			lastTimestamp = uint32(ts)
+		}
+		collectFIT(a, def.global, values)
+	}
+	return a, nil
+}
+
+// readFITDefinition читает сообщение-определение; n — его размер в байтах
+func readFITDefinition(b []byte, dev bool) (*fitDefinition, int, error) {
+	if len(b) < 5 {
+		return nil, 0, errFITTruncated
+	}
+	def := &fitDefinition{order: binary.LittleEndian}
+	if b[1] == 1 {
+		def.order = binary.BigEndian
+	}
+	def.global = def.order.Uint16(b[2:4])
+	count := int(b[4])
+	n := 5
+	if len(b) < n+count*3 {
+		return nil, 0, errFITTruncated
+	}
+	for i := 0; i < count; i++ {
+		def.fields = append(def.fields, fitField{num: b[n], size: int(b[n+1])})
+		n += 3
+	}
+	if dev {
+		if len(b) < n+1 {
+			return nil, 0, errFITTruncated
+		}
+		devCount := int(b[n])
+		n++
+		if len(b) < n+devCount*3 {
+			return nil, 0, errFITTruncated
+		}
+		for i := 0; i < devCount; i++ {
+			def.devBytes += int(b[n+1])
+			n += 3
+		}
+	}
+	return def, n, nil
+}
+
+var errFITTruncated = errors.New("FIT: файл обрезан")
+
+// readFITValues читает сообщение данных. Поля размером 1, 2 и 4 байта
+// возвращаются как беззнаковые числа; недопустимые значения FIT (все биты
+// единицы, у знаковых 0x7F…) пропускаются. n — размер сообщения в байтах
+func readFITValues(b []byte, def *fitDefinition) (map[byte]uint64, int, error) {
+	values := make(map[byte]uint64, len(def.fields))
+	n := 0
+	for _, f := range def.fields {
+		if len(b) < n+f.size {
+			return nil, 0, errFITTruncated
+		}
+		raw := b[n : n+f.size]
+		n += f.size
+
+		var v, invalid uint64
+		switch f.size {
+		case 1:
+			v, invalid = uint64(raw[0]), 0xFF
+		case 2:
+			v, invalid = uint64(def.order.Uint16(raw)), 0xFFFF
+		case 4:
+			v, invalid = uint64(def.order.Uint32(raw)), 0xFFFFFFFF
+		default:
+			continue // строки и массивы не нужны
+		}
+		if v == invalid || (f.size == 4 && v == 0x7FFFFFFF) {
+			continue
+		}
+		values[f.num] = v
+	}
+	if len(b) < n+def.devBytes {
+		return nil, 0, errFITTruncated
+	}
+	return values, n + def.devBytes, nil
+}
+
+// collectFIT переносит нужные поля сообщения в запись тренировки
+func collectFIT(a *Activity, global uint16, values map[byte]uint64) {
+	switch global {
+	case fitMsgRecord:
+		ts, ok := values[fitFieldTimestamp]
+		if !ok {
+			return
+		}
+		p := Point{Time: fitEpoch.Add(time.Duration(ts) * time.Second)}
+		lat, okLat := values[fitRecordLat]
+		lon, okLon := values[fitRecordLon]
+		if okLat && okLon {
+			p.Lat = float64(int32(uint32(lat))) * fitSemicircle
+			p.Lon = float64(int32(uint32(lon))) * fitSemicircle
+			p.HasPos = true
+		}
+		if d, ok := values[fitRecordDistance]; ok {
+			p.Distance = float64(d) / 100 // сантиметры
+		}
+		if hr, ok := values[fitRecordHR]; ok {
+			p.HR = int(hr)
+		}
+		a.Points = append(a.Points, p)
+	case fitMsgSession:
+		if sport, ok := values[fitSessionSport]; ok && a.Sport == "" {
+			a.Sport = fitSports[sport]
+		}
+	}
+}
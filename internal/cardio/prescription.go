@@ -0,0 +1,156 @@
+package cardio
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PlannedExercise — упражнение запланированной тренировки в том виде, как его
+// записывают генераторы и тренер: повторы и заметки — свободный текст
+type PlannedExercise struct {
+	Name  string
+	Sets  int
+	Reps  string // «30-45 мин», «1000м», «30 сек работа / 30 сек отдых»
+	Notes string // «Пульс: 65% от максимума», «Темп: 4:30-5:00/км», «ЧСС < 140»
+}
+
+// Prescription — назначение кардиотренировки; нулевые поля — не заданы
+type Prescription struct {
+	Duration time.Duration // вся тренировка, включая разминку и заминку
+	Distance float64       // м, основная часть
+
+	Intervals  int
+	Work, Rest time.Duration
+
+	HRLowPct, HRHighPct float64 // целевой пульс, доля от максимального
+	HRCapBPM            int     // «ЧСС < 140»
+
+	PaceFast, PaceSlow time.Duration // целевой темп на километр
+}
+
+// hrTargetSpread — разброс вокруг одиночного целевого % пульса («65% от максимума»)
+const hrTargetSpread = 0.05
+
+var (
+	reIntervals = regexp.MustCompile(`(?i)(\d+)\s*(?:сек|sec|s)\S*\s*(?:работ\S*|work)\s*/\s*(\d+)\s*(?:сек|sec|s)\S*\s*(?:отдых|rest)`)
+	reMinutes   = regexp.MustCompile(`(?i)(\d+)(?:\s*[-–]\s*\d+)?\s*(?:мин|min)`)
+	reKm        = regexp.MustCompile(`(?i)(\d+(?:[.,]\d+)?)\s*(?:км|km)(?:$|[^\p{L}])`)
+	reMeters    = regexp.MustCompile(`(?i)(\d+)\s*(?:м|m)(?:$|[^\p{L}])`)
+	reHRPct     = regexp.MustCompile(`(?i)(\d+)\s*%\s*(?:от\s+максимума|of\s+max)`)
+	reHRRange   = regexp.MustCompile(`(?i)(?:ЧСС|HR)\D{0,12}?(\d+)\s*[-–]\s*(\d+)\s*%`)
+	reHRCap     = regexp.MustCompile(`(?i)(?:ЧСС|HR)\s*<\s*(\d+)`)
+	rePace      = regexp.MustCompile(`(\d+):(\d{2})\s*[-–]\s*(\d+):(\d{2})\s*/\s*(?:км|km)`)
+)
+
+// isWarmup — разминка и заминка входят в длительность, но не делают тренировку кардио
+func isWarmup(name string) bool {
+	n := strings.ToLower(name)
+	for _, w := range []string{"разминка", "заминка", "warm", "cool"} {
+		if strings.Contains(n, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePrescription извлекает назначение из упражнений тренировки. ok = false —
+// тренировка не кардио: меньше половины основных упражнений заданы временем,
+// дистанцией или интервалами (силовая тренировка с финишером — не кардио)
+func ParsePrescription(exercises []PlannedExercise) (Prescription, bool) {
+	var p Prescription
+	main, cardio := 0, 0
+
+	for _, e := range exercises {
+		sets := max(e.Sets, 1)
+		warmup := isWarmup(e.Name)
+		if !warmup {
+			main++
+		}
+
+		isCardio := true
+		if m := reIntervals.FindStringSubmatch(e.Reps); m != nil {
+			work, rest := seconds(m[1]), seconds(m[2])
+			p.Duration += time.Duration(sets) * (work + rest)
+			if !warmup {
+				p.Intervals += sets
+				p.Work, p.Rest = work, rest
+			}
+		} else if m := reKm.FindStringSubmatch(e.Reps); m != nil {
+			if !warmup {
+				p.Distance += parseNumber(m[1]) * 1000 * float64(sets)
+			}
+		} else if m := reMinutes.FindStringSubmatch(e.Reps); m != nil {
+			// Диапазон «30-45 мин» выполнен, если сделана нижняя граница
+			minutes, _ := strconv.Atoi(m[1])
+			p.Duration += time.Duration(minutes*sets) * time.Minute
+		} else if m := reMeters.FindStringSubmatch(e.Reps); m != nil {
+			if !warmup {
+				meters, _ := strconv.Atoi(m[1])
+				p.Distance += float64(meters * sets)
+			}
+		} else {
+			isCardio = false
+		}
+		if isCardio && !warmup {
+			cardio++
+			p.parseTargets(e.Reps + " " + e.Notes)
+		}
+	}
+
+	if cardio == 0 || cardio*2 < main {
+		return Prescription{}, false
+	}
+	return p, true
+}
+
+// parseTargets берёт целевой пульс и темп из текста; первое найденное значение остаётся
+func (p *Prescription) parseTargets(text string) {
+	if p.HRLowPct == 0 {
+		if m := reHRRange.FindStringSubmatch(text); m != nil {
+			p.HRLowPct, p.HRHighPct = parseNumber(m[1])/100, parseNumber(m[2])/100
+		} else if m := reHRPct.FindStringSubmatch(text); m != nil {
+			target := parseNumber(m[1]) / 100
+			p.HRLowPct = math.Round((target-hrTargetSpread)*100) / 100
+			p.HRHighPct = math.Round((target+hrTargetSpread)*100) / 100
+		}
+	}
+	if p.HRCapBPM == 0 {
+		if m := reHRCap.FindStringSubmatch(text); m != nil {
+			p.HRCapBPM, _ = strconv.Atoi(m[1])
+		}
+	}
+	if p.PaceFast == 0 {
+		if m := rePace.FindStringSubmatch(text); m != nil {
+			p.PaceFast = minSec(m[1], m[2])
+			p.PaceSlow = minSec(m[3], m[4])
+		}
+	}
+}
+
+// HRRange — целевой пульс в ударах в минуту; ok = false — не задан
+func (p Prescription) HRRange(maxHR int) (low, high int, ok bool) {
+	switch {
+	case p.HRLowPct > 0:
+		return int(p.HRLowPct * float64(maxHR)), int(p.HRHighPct * float64(maxHR)), true
+	case p.HRCapBPM > 0:
+		return 0, p.HRCapBPM, true
+	}
+	return 0, 0, false
+}
+
+func seconds(s string) time.Duration {
+	n, _ := strconv.Atoi(s)
+	return time.Duration(n) * time.Second
+}
+
+func minSec(m, s string) time.Duration {
+	return time.Duration(parseNumber(m))*time.Minute + seconds(s)
+}
+
+func parseNumber(s string) float64 {
+	v, _ := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+	return v
+}
@@ -0,0 +1,133 @@
+package cardio
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Теги XML указаны без пространств имён: encoding/xml тогда сопоставляет
+// элементы по локальному имени, и расширения Garmin (gpxtpx:hr, ns3:TPX)
+// читаются независимо от префикса
+
+type gpxFile struct {
+	Tracks []struct {
+		Type     string `xml:"type"`
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+type gpxPoint struct {
+	Lat        float64 `xml:"lat,attr"`
+	Lon        float64 `xml:"lon,attr"`
+	Time       string  `xml:"time"`
+	Extensions struct {
+		HR  int `xml:"hr"`
+		TPX struct {
+			HR int `xml:"hr"`
+		} `xml:"TrackPointExtension"`
+	} `xml:"extensions"`
+}
+
+// parseGPX разбирает GPX: пульс — из расширения Garmin TrackPointExtension
+func parseGPX(data []byte) (*Activity, error) {
+	var f gpxFile
+	if err := decodeXML(data, &f); err != nil {
+		return nil, fmt.Errorf("GPX: %w", err)
+	}
+
+	a := &Activity{}
+	for _, trk := range f.Tracks {
+		if a.Sport == "" {
+			a.Sport = trk.Type
+		}
+		for _, seg := range trk.Segments {
+			for _, p := range seg.Points {
+				hr := p.Extensions.TPX.HR
+				if hr == 0 {
+					hr = p.Extensions.HR
+				}
+				a.Points = append(a.Points, Point{
+					Time:   parseXMLTime(p.Time),
+					Lat:    p.Lat,
+					Lon:    p.Lon,
+					HasPos: p.Lat != 0 || p.Lon != 0,
+					HR:     hr,
+				})
+			}
+		}
+	}
+	return a, nil
+}
+
+type tcxFile struct {
+	Activities []struct {
+		Sport string `xml:"Sport,attr"`
+		Laps  []struct {
+			Tracks []struct {
+				Points []tcxPoint `xml:"Trackpoint"`
+			} `xml:"Track"`
+		} `xml:"Lap"`
+	} `xml:"Activities>Activity"`
+}
+
+type tcxPoint struct {
+	Time     string `xml:"Time"`
+	Position *struct {
+		Lat float64 `xml:"LatitudeDegrees"`
+		Lon float64 `xml:"LongitudeDegrees"`
+	} `xml:"Position"`
+	Distance float64 `xml:"DistanceMeters"`
+	HR       struct {
+		Value int `xml:"Value"`
+	} `xml:"HeartRateBpm"`
+}
+
+// parseTCX разбирает Garmin Training Center XML
+func parseTCX(data []byte) (*Activity, error) {
+	var f tcxFile
+	if err := decodeXML(data, &f); err != nil {
+		return nil, fmt.Errorf("TCX: %w", err)
+	}
+
+	a := &Activity{}
+	for _, act := range f.Activities {
+		if a.Sport == "" {
+			a.Sport = act.Sport
+		}
+		for _, lap := range act.Laps {
+			for _, trk := range lap.Tracks {
+				for _, p := range trk.Points {
+					pt := Point{Time: parseXMLTime(p.Time), Distance: p.Distance, HR: p.HR.Value}
+					if p.Position != nil {
+						pt.Lat, pt.Lon, pt.HasPos = p.Position.Lat, p.Position.Lon, true
+					}
+					a.Points = append(a.Points, pt)
+				}
+			}
+		}
+	}
+	return a, nil
+}
+
+// decodeXML разбирает XML без проверки кодировки: файлы часов бывают с UTF-8 BOM
+// и нестандартным объявлением encoding
+func decodeXML(data []byte, v interface{}) error {
+	d := xml.NewDecoder(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	d.CharsetReader = func(_ string, r io.Reader) (io.Reader, error) { return r, nil }
+	return d.Decode(v)
+}
+
+// parseXMLTime разбирает время ISO 8601; нулевое время — не удалось
+func parseXMLTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}
+	}
+	return t.UTC()
+}
@@ -0,0 +1,124 @@
+package charts
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// niceTicks подбирает «круглые» деления оси значений: шаг 1, 2 или 5 × 10ⁿ
+// и границы, кратные шагу, так чтобы делений было около n
+func niceTicks(lo, hi float64, n int) []float64 {
+	if n < 2 {
+		n = 2
+	}
+	if hi < lo {
+		lo, hi = hi, lo
+	}
+	if hi == lo {
+		if lo == 0 {
+			hi = 1
+		} else {
+			pad := math.Abs(lo) * 0.05
+			lo, hi = lo-pad, hi+pad
+		}
+	}
+
+	step := niceNum((hi - lo) / float64(n-1))
+	// Допуск защищает от ошибок округления: 0.9/0.1 = 9.000000000000002
+	min := math.Floor(lo/step+1e-9) * step
+	max := math.Ceil(hi/step-1e-9) * step
+	count := int(math.Round((max - min) / step))
+
+	ticks := make([]float64, 0, count+1)
+	for i := 0; i <= count; i++ {
+		ticks = append(ticks, min+float64(i)*step)
+	}
+	return ticks
+}
+
+// niceNum округляет x до ближайшего из 1, 2, 5 или 10 × 10ⁿ
+func niceNum(x float64) float64 {
+	exp := math.Floor(math.Log10(x))
+	f := x / math.Pow(10, exp)
+
+	var nice float64
+	switch {
+	case f < 1.5:
+		nice = 1
+	case f < 3:
+		nice = 2
+	case f < 7:
+		nice = 5
+	default:
+		nice = 10
+	}
+	return nice * math.Pow(10, exp)
+}
+
+// timeTicks возвращает не больше max дат для подписей оси времени с шагом в целые дни
+func timeTicks(from, to time.Time, max int) []time.Time {
+	if max < 2 {
+		max = 2
+	}
+	start := truncateDay(from)
+	end := truncateDay(to)
+	days := int(end.Sub(start).Hours()/24 + 0.5)
+
+	step := (days + max - 2) / (max - 1)
+	if step < 1 {
+		step = 1
+	}
+
+	var ticks []time.Time
+	for d := 0; d <= days; d += step {
+		ticks = append(ticks, start.AddDate(0, 0, d))
+	}
+	return ticks
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// formatValue подписывает деление оси: знаков после запятой столько,
+// сколько нужно для шага, тысячи разделяются пробелом
+func formatValue(v, step float64) string {
+	decimals := 0
+	for s := step; s < 1 && decimals < 3; s *= 10 {
+		decimals++
+	}
+	if math.Abs(v) < math.Pow(10, -float64(decimals))/2 {
+		v = 0
+	}
+	return groupThousands(strconv.FormatFloat(v, 'f', decimals, 64))
+}
+
+// groupThousands разбивает целую часть числа на группы по три цифры
+func groupThousands(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i:]
+	}
+	if len(intPart) <= 4 {
+		return sign + intPart + frac
+	}
+
+	var b strings.Builder
+	lead := len(intPart) % 3
+	if lead > 0 {
+		b.WriteString(intPart[:lead])
+	}
+	for i := lead; i < len(intPart); i += 3 {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(intPart[i : i+3])
+	}
+	return sign + b.String() + frac
+}
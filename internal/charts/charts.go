@@ -0,0 +1,174 @@
+// Package charts рисует графики прогресса в PNG без внешних зависимостей.
+//
+// Линейные графики (вес, замеры, 1ПМ) и столбчатые (тоннаж по неделям)
+// рисуются на image.RGBA: линии сглаживаются растеризатором
+// golang.org/x/image/vector, подписи выводятся шрифтом Go Regular,
+// в котором есть кириллица. Пакет не знает о базе и боте — на вход
+// получает ряды точек, поэтому один и тот же график отправляется клиенту,
+// показывается тренеру и вставляется в выгружаемые отчёты.
+package charts
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"time"
+)
+
+// Kind — вид графика
+type Kind int
+
+const (
+	// Line — ломаная по точкам
+	Line Kind = iota
+	// Bar — столбцы, ось значений начинается с нуля
+	Bar
+)
+
+const (
+	defaultWidth      = 1000
+	defaultHeight     = 600
+	defaultDateFormat = "02.01"
+)
+
+// ErrNoData — в рядах нет ни одной точки
+var ErrNoData = errors.New("charts: нет данных для графика")
+
+// Point — значение на дату
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Series — именованный ряд точек; точки должны идти по возрастанию времени
+type Series struct {
+	Name   string
+	Points []Point
+}
+
+// Chart описывает график
+type Chart struct {
+	Title  string
+	Unit   string // подпись оси значений: «кг», «см»
+	Kind   Kind
+	Series []Series
+
+	Width, Height int    // по умолчанию 1000×600
+	DateFormat    string // формат подписей дат, по умолчанию «02.01»
+}
+
+// palette — цвета рядов по порядку
+var palette = []color.RGBA{
+	{0x1f, 0x77, 0xb4, 0xff},
+	{0xd6, 0x27, 0x28, 0xff},
+	{0x2c, 0xa0, 0x2c, 0xff},
+	{0xff, 0x7f, 0x0e, 0xff},
+	{0x94, 0x67, 0xbd, 0xff},
+	{0x8c, 0x56, 0x4b, 0xff},
+	{0xe3, 0x77, 0xc2, 0xff},
+	{0x17, 0xbe, 0xcf, 0xff},
+}
+
+// SeriesColor возвращает цвет ряда с номером i
+func SeriesColor(i int) color.RGBA {
+	return palette[i%len(palette)]
+}
+
+// Empty сообщает, что рисовать нечего
+func (c Chart) Empty() bool {
+	for _, s := range c.Series {
+		if len(s.Points) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Image рисует график
+func (c Chart) Image() (*image.RGBA, error) {
+	if c.Empty() {
+		return nil, ErrNoData
+	}
+	cv, err := newCanvas(c.size())
+	if err != nil {
+		return nil, err
+	}
+	cv.draw(c)
+	return cv.img, nil
+}
+
+// Render рисует график и записывает его в w в формате PNG
+func (c Chart) Render(w io.Writer) error {
+	img, err := c.Image()
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+// PNG возвращает график в формате PNG
+func (c Chart) PNG() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := c.Render(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c Chart) size() (int, int) {
+	w, h := c.Width, c.Height
+	if w <= 0 {
+		w = defaultWidth
+	}
+	if h <= 0 {
+		h = defaultHeight
+	}
+	return w, h
+}
+
+func (c Chart) dateFormat() string {
+	if c.DateFormat != "" {
+		return c.DateFormat
+	}
+	return defaultDateFormat
+}
+
+// timeRange возвращает первую и последнюю дату по всем рядам
+func (c Chart) timeRange() (time.Time, time.Time) {
+	var from, to time.Time
+	for _, s := range c.Series {
+		for _, p := range s.Points {
+			if from.IsZero() || p.Time.Before(from) {
+				from = p.Time
+			}
+			if to.IsZero() || p.Time.After(to) {
+				to = p.Time
+			}
+		}
+	}
+	return from, to
+}
+
+// valueRange возвращает минимум и максимум значений; у столбцов ось начинается с нуля
+func (c Chart) valueRange() (float64, float64) {
+	first := true
+	var lo, hi float64
+	for _, s := range c.Series {
+		for _, p := range s.Points {
+			if first || p.Value < lo {
+				lo = p.Value
+			}
+			if first || p.Value > hi {
+				hi = p.Value
+			}
+			first = false
+		}
+	}
+	if c.Kind == Bar && lo > 0 {
+		lo = 0
+	}
+	return lo, hi
+}
@@ -0,0 +1,176 @@
+package charts
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"math"
+	"testing"
+	"time"
+)
+
+func day(d int) time.Time {
+	return time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, d)
+}
+
+func TestNiceTicks(t *testing.T) {
+	tests := []struct {
+		lo, hi     float64
+		first, end float64
+		step       float64
+	}{
+		{0, 10, 0, 10, 2},
+		{71.3, 78.9, 70, 80, 2},
+		{0, 12500, 0, 14000, 2000},
+		{0.2, 0.9, 0.2, 0.9, 0.1},
+		{80, 80, 76, 84, 2},
+		{0, 0, 0, 1, 0.2},
+	}
+	for _, tt := range tests {
+		ticks := niceTicks(tt.lo, tt.hi, 6)
+		step := ticks[1] - ticks[0]
+		if !near(ticks[0], tt.first) || !near(ticks[len(ticks)-1], tt.end) || !near(step, tt.step) {
+			t.Errorf("niceTicks(%v, %v) = %v, хотим %v..%v с шагом %v", tt.lo, tt.hi, ticks, tt.first, tt.end, tt.step)
+		}
+		if ticks[0] > tt.lo || ticks[len(ticks)-1] < tt.hi {
+			t.Errorf("niceTicks(%v, %v) = %v не накрывает диапазон", tt.lo, tt.hi, ticks)
+		}
+	}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestTimeTicks(t *testing.T) {
+	ticks := timeTicks(day(0), day(30), 7)
+	if len(ticks) > 7 {
+		t.Fatalf("делений %d, хотим не больше 7", len(ticks))
+	}
+	if !ticks[0].Equal(day(0)) {
+		t.Errorf("первое деление %v, хотим %v", ticks[0], day(0))
+	}
+	for i := 1; i < len(ticks); i++ {
+		if ticks[i].Sub(ticks[i-1]) != 5*24*time.Hour {
+			t.Errorf("шаг между делениями %v, хотим 5 дней", ticks[i].Sub(ticks[i-1]))
+		}
+	}
+
+	if got := timeTicks(day(0), day(0), 7); len(got) != 1 {
+		t.Errorf("для одной даты делений %d, хотим 1", len(got))
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		v, step float64
+		want    string
+	}{
+		{72, 2, "72"},
+		{72.5, 0.5, "72.5"},
+		{0.25, 0.05, "0.25"},
+		{12500, 2000, "12 500"},
+		{1000, 200, "1000"},
+		{-1234567, 100000, "-1 234 567"},
+		{1e-12, 0.1, "0.0"},
+	}
+	for _, tt := range tests {
+		if got := formatValue(tt.v, tt.step); got != tt.want {
+			t.Errorf("formatValue(%v, %v) = %q, хотим %q", tt.v, tt.step, got, tt.want)
+		}
+	}
+}
+
+func TestRenderLine(t *testing.T) {
+	c := Chart{
+		Title: "Вес тела",
+		Unit:  "кг",
+		Series: []Series{{Name: "Вес", Points: []Point{
+			{day(0), 82.4}, {day(7), 81.9}, {day(14), 81.1}, {day(28), 80.2},
+		}}},
+		Width:  640,
+		Height: 400,
+	}
+
+	data, err := c.PNG()
+	if err != nil {
+		t.Fatalf("PNG: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("результат не PNG: %v", err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 640, 400) {
+		t.Errorf("размер %v, хотим 640×400", img.Bounds())
+	}
+	if n := countColor(img, SeriesColor(0)); n < 100 {
+		t.Errorf("пикселей цвета ряда %d — линия не нарисована", n)
+	}
+}
+
+func TestRenderBarsMultipleSeries(t *testing.T) {
+	c := Chart{
+		Kind: Bar,
+		Series: []Series{
+			{Name: "Присед", Points: []Point{{day(0), 5200}, {day(7), 6100}}},
+			{Name: "Жим", Points: []Point{{day(0), 3100}, {day(7), 2800}, {day(14), 3300}}},
+		},
+	}
+
+	img, err := c.Image()
+	if err != nil {
+		t.Fatalf("Image: %v", err)
+	}
+	if img.Bounds().Dx() != defaultWidth || img.Bounds().Dy() != defaultHeight {
+		t.Errorf("размер по умолчанию %v", img.Bounds())
+	}
+	for i := range c.Series {
+		if n := countColor(img, SeriesColor(i)); n < 500 {
+			t.Errorf("ряд %d: пикселей цвета %d — столбцы не нарисованы", i, n)
+		}
+	}
+}
+
+func TestBarAxisStartsAtZero(t *testing.T) {
+	c := Chart{Kind: Bar, Series: []Series{{Points: []Point{{day(0), 5000}, {day(7), 6000}}}}}
+	if lo, _ := c.valueRange(); lo != 0 {
+		t.Errorf("нижняя граница столбцов %v, хотим 0", lo)
+	}
+	c.Kind = Line
+	if lo, _ := c.valueRange(); lo != 5000 {
+		t.Errorf("нижняя граница линии %v, хотим 5000", lo)
+	}
+}
+
+func TestRenderSinglePoint(t *testing.T) {
+	c := Chart{Series: []Series{{Points: []Point{{day(0), 80}}}}}
+	if _, err := c.PNG(); err != nil {
+		t.Fatalf("одна точка: %v", err)
+	}
+}
+
+func TestRenderNoData(t *testing.T) {
+	c := Chart{Title: "Пусто", Series: []Series{{Name: "Вес"}}}
+	if !c.Empty() {
+		t.Error("Empty() = false для рядов без точек")
+	}
+	if _, err := c.PNG(); !errors.Is(err, ErrNoData) {
+		t.Errorf("ошибка %v, хотим ErrNoData", err)
+	}
+}
+
+func countColor(img image.Image, want interface{ RGBA() (r, g, b, a uint32) }) int {
+	wr, wg, wb, _ := want.RGBA()
+	n := 0
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, _ := img.At(x, y).RGBA()
+			if r == wr && g == wg && bl == wb {
+				n++
+			}
+		}
+	}
+	return n
+}
@@ -0,0 +1,403 @@
+package charts
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+var (
+	background = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	textColor  = color.RGBA{0x33, 0x33, 0x33, 0xff}
+	axisColor  = color.RGBA{0x66, 0x66, 0x66, 0xff}
+	gridColor  = color.RGBA{0xe4, 0xe4, 0xe4, 0xff}
+)
+
+const (
+	lineWidth    = 3
+	markerRadius = 4.5
+	maxMarkers   = 60 // при большем числе точек маркеры сливаются в линию
+	maxBarLabels = 16 // подписи значений над столбцами, если столбцов немного
+	yTicks       = 6
+)
+
+// Шрифт разбирается один раз; Face не потокобезопасен и создаётся на каждый рисунок
+var (
+	fontOnce sync.Once
+	fontData *opentype.Font
+	fontErr  error
+)
+
+func loadFont() (*opentype.Font, error) {
+	fontOnce.Do(func() {
+		fontData, fontErr = opentype.Parse(goregular.TTF)
+	})
+	return fontData, fontErr
+}
+
+type align int
+
+const (
+	alignLeft align = iota
+	alignCenter
+	alignRight
+)
+
+// canvas — холст графика с подготовленными шрифтами
+type canvas struct {
+	img       *image.RGBA
+	titleFace font.Face
+	labelFace font.Face
+	titleSize int
+	labelSize int
+}
+
+func newCanvas(width, height int) (*canvas, error) {
+	f, err := loadFont()
+	if err != nil {
+		return nil, err
+	}
+	cv := &canvas{
+		img:       image.NewRGBA(image.Rect(0, 0, width, height)),
+		titleSize: maxInt(height/25, 14),
+		labelSize: maxInt(height/40, 10),
+	}
+	if cv.titleFace, err = newFace(f, cv.titleSize); err != nil {
+		return nil, err
+	}
+	if cv.labelFace, err = newFace(f, cv.labelSize); err != nil {
+		return nil, err
+	}
+	return cv, nil
+}
+
+func newFace(f *opentype.Font, size int) (font.Face, error) {
+	return opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    float64(size),
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+}
+
+// draw рисует график целиком
+func (cv *canvas) draw(c Chart) {
+	b := cv.img.Bounds()
+	draw.Draw(cv.img, b, image.NewUniform(background), image.Point{}, draw.Src)
+
+	pad := cv.labelSize
+	y := pad + cv.titleSize
+	if c.Title != "" {
+		cv.text(cv.titleFace, c.Title, pad*2, y, textColor, alignLeft)
+		y += cv.titleSize / 2
+	}
+	if len(c.Series) > 1 {
+		y += cv.labelSize + pad/2
+		cv.legend(c.Series, pad*2, y)
+	}
+
+	lo, hi := c.valueRange()
+	ticks := niceTicks(lo, hi, yTicks)
+	step := ticks[1] - ticks[0]
+	labels := make([]string, len(ticks))
+	labelWidth := 0
+	for i, v := range ticks {
+		labels[i] = formatValue(v, step)
+		if w := font.MeasureString(cv.labelFace, labels[i]).Ceil(); w > labelWidth {
+			labelWidth = w
+		}
+	}
+
+	plot := image.Rect(pad*2+labelWidth+pad, y+pad*2+cv.labelSize, b.Dx()-pad*2, b.Dy()-pad*2-cv.labelSize)
+	if c.Unit != "" {
+		cv.text(cv.labelFace, c.Unit, plot.Min.X-pad, plot.Min.Y-pad, axisColor, alignRight)
+	}
+
+	// Ось значений: сетка и подписи
+	yMin, yMax := ticks[0], ticks[len(ticks)-1]
+	toY := func(v float64) float64 {
+		return float64(plot.Max.Y) - (v-yMin)/(yMax-yMin)*float64(plot.Dy())
+	}
+	for i, v := range ticks {
+		ty := int(math.Round(toY(v)))
+		cv.hline(plot.Min.X, plot.Max.X, ty, gridColor)
+		cv.text(cv.labelFace, labels[i], plot.Min.X-pad/2, ty+cv.labelSize*35/100, axisColor, alignRight)
+	}
+
+	// Ось времени; столбцам нужно поле в половину промежутка по краям
+	from, to := c.timeRange()
+	var slot time.Duration
+	if c.Kind == Bar {
+		slot = c.minGap()
+		from, to = from.Add(-slot/2), to.Add(slot/2)
+	} else if !to.After(from) {
+		from, to = from.AddDate(0, 0, -1), to.AddDate(0, 0, 1)
+	}
+	span := to.Sub(from).Seconds()
+	toX := func(t time.Time) float64 {
+		return float64(plot.Min.X) + t.Sub(from).Seconds()/span*float64(plot.Dx())
+	}
+
+	cv.timeAxis(c, plot, from, to, toX)
+
+	cv.hline(plot.Min.X, plot.Max.X, plot.Max.Y, axisColor)
+	cv.vline(plot.Min.X, plot.Min.Y, plot.Max.Y, axisColor)
+
+	switch c.Kind {
+	case Bar:
+		slotWidth := slot.Seconds() / span * float64(plot.Dx())
+		cv.bars(c, slotWidth, toX, toY, step, yMin)
+	default:
+		for i, s := range c.Series {
+			cv.line(s.Points, SeriesColor(i), toX, toY)
+		}
+	}
+}
+
+// timeAxis подписывает даты под осью. У столбцов подписан каждый столбец,
+// если подписи помещаются; иначе даты идут с равным шагом в днях
+func (cv *canvas) timeAxis(c Chart, plot image.Rectangle, from, to time.Time, toX func(time.Time) float64) {
+	layout := c.dateFormat()
+	sample := font.MeasureString(cv.labelFace, time.Date(2000, 12, 28, 0, 0, 0, 0, time.UTC).Format(layout)).Ceil()
+	maxLabels := plot.Dx() / (sample + cv.labelSize*2)
+
+	var ticks []time.Time
+	if c.Kind == Bar {
+		ticks = c.times()
+	}
+	if len(ticks) == 0 || len(ticks) > maxLabels {
+		ticks = nil
+		for _, t := range timeTicks(from, to, maxLabels) {
+			if !t.Before(from) && !t.After(to) {
+				ticks = append(ticks, t)
+			}
+		}
+	}
+
+	y := plot.Max.Y + cv.labelSize/2 + cv.labelSize
+	for _, t := range ticks {
+		x := int(math.Round(toX(t)))
+		cv.vline(x, plot.Max.Y, plot.Max.Y+cv.labelSize/3, axisColor)
+		cv.text(cv.labelFace, t.Format(layout), x, y, axisColor, alignCenter)
+	}
+}
+
+// legend рисует подписи рядов с цветными квадратами в одну строку
+func (cv *canvas) legend(series []Series, x, y int) {
+	box := cv.labelSize * 8 / 10
+	for i, s := range series {
+		r := image.Rect(x, y-box, x+box, y)
+		draw.Draw(cv.img, r, image.NewUniform(SeriesColor(i)), image.Point{}, draw.Src)
+		x += box + cv.labelSize/2
+		cv.text(cv.labelFace, s.Name, x, y, textColor, alignLeft)
+		x += font.MeasureString(cv.labelFace, s.Name).Ceil() + cv.labelSize*3/2
+	}
+}
+
+// line рисует ломаную ряда и маркеры точек
+func (cv *canvas) line(points []Point, col color.RGBA, toX func(time.Time) float64, toY func(float64) float64) {
+	if len(points) == 0 {
+		return
+	}
+	pts := make([]fpoint, len(points))
+	for i, p := range points {
+		pts[i] = fpoint{toX(p.Time), toY(p.Value)}
+	}
+
+	var shapes [][]fpoint
+	for i := 1; i < len(pts); i++ {
+		shapes = append(shapes, segment(pts[i-1], pts[i], lineWidth))
+	}
+	radius := float64(lineWidth) / 2
+	if len(pts) <= maxMarkers {
+		radius = markerRadius
+	}
+	for _, p := range pts {
+		shapes = append(shapes, disc(p, radius))
+	}
+	cv.fill(shapes, col)
+}
+
+// bars рисует столбцы; столбцы разных рядов за одну дату стоят рядом
+func (cv *canvas) bars(c Chart, slotWidth float64, toX func(time.Time) float64, toY func(float64) float64, step, yMin float64) {
+	n := len(c.Series)
+	groupWidth := slotWidth * 0.7
+	barWidth := groupWidth / float64(n)
+	zero := toY(math.Max(0, yMin))
+
+	total := 0
+	for _, s := range c.Series {
+		total += len(s.Points)
+	}
+
+	for i, s := range c.Series {
+		col := image.NewUniform(SeriesColor(i))
+		for _, p := range s.Points {
+			left := toX(p.Time) - groupWidth/2 + float64(i)*barWidth
+			top := toY(p.Value)
+			r := image.Rect(int(math.Round(left)), int(math.Round(math.Min(top, zero))),
+				int(math.Round(left+barWidth))-1, int(math.Round(math.Max(top, zero))))
+			if r.Dx() < 1 {
+				r.Max.X = r.Min.X + 1
+			}
+			draw.Draw(cv.img, r, col, image.Point{}, draw.Src)
+
+			if total <= maxBarLabels {
+				cv.text(cv.labelFace, formatValue(p.Value, step), (r.Min.X+r.Max.X)/2, r.Min.Y-cv.labelSize/3, textColor, alignCenter)
+			}
+		}
+	}
+}
+
+// times возвращает различные даты всех рядов по возрастанию
+func (c Chart) times() []time.Time {
+	seen := make(map[time.Time]bool)
+	var out []time.Time
+	for _, s := range c.Series {
+		for _, p := range s.Points {
+			if !seen[p.Time] {
+				seen[p.Time] = true
+				out = append(out, p.Time)
+			}
+		}
+	}
+	for i := 1; i < len(out); i++ {
+		for j := i; j > 0 && out[j].Before(out[j-1]); j-- {
+			out[j], out[j-1] = out[j-1], out[j]
+		}
+	}
+	return out
+}
+
+// minGap — наименьший промежуток между соседними датами, не меньше суток
+func (c Chart) minGap() time.Duration {
+	gap := time.Duration(0)
+	ts := c.times()
+	for i := 1; i < len(ts); i++ {
+		if d := ts[i].Sub(ts[i-1]); gap == 0 || d < gap {
+			gap = d
+		}
+	}
+	if gap < 24*time.Hour {
+		gap = 24 * time.Hour
+	}
+	return gap
+}
+
+// fpoint — точка в пикселях холста
+type fpoint struct{ x, y float64 }
+
+// segment возвращает прямоугольник толщиной width вдоль отрезка a–b
+func segment(a, b fpoint, width float64) []fpoint {
+	dx, dy := b.x-a.x, b.y-a.y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return nil
+	}
+	nx, ny := -dy/length*width/2, dx/length*width/2
+	return []fpoint{
+		{a.x + nx, a.y + ny},
+		{b.x + nx, b.y + ny},
+		{b.x - nx, b.y - ny},
+		{a.x - nx, a.y - ny},
+	}
+}
+
+// disc возвращает многоугольник, приближающий круг
+func disc(c fpoint, r float64) []fpoint {
+	const n = 20
+	pts := make([]fpoint, n)
+	for i := range pts {
+		a := 2 * math.Pi * float64(i) / n
+		pts[i] = fpoint{c.x + r*math.Cos(a), c.y + r*math.Sin(a)}
+	}
+	return pts
+}
+
+// fill закрашивает объединение многоугольников со сглаживанием.
+// Растеризатор складывает площади с учётом направления обхода, поэтому
+// все фигуры приводятся к одному направлению — иначе пересечения вычитаются
+func (cv *canvas) fill(shapes [][]fpoint, col color.RGBA) {
+	bounds := image.Rectangle{}
+	for _, s := range shapes {
+		for _, p := range s {
+			r := image.Rect(int(math.Floor(p.x)), int(math.Floor(p.y)), int(math.Ceil(p.x))+1, int(math.Ceil(p.y))+1)
+			bounds = bounds.Union(r)
+		}
+	}
+	bounds = bounds.Intersect(cv.img.Bounds())
+	if bounds.Empty() {
+		return
+	}
+
+	z := vector.NewRasterizer(bounds.Dx(), bounds.Dy())
+	z.DrawOp = draw.Over
+	ox, oy := float64(bounds.Min.X), float64(bounds.Min.Y)
+	for _, s := range shapes {
+		if len(s) < 3 {
+			continue
+		}
+		if signedArea(s) < 0 {
+			s = reversed(s)
+		}
+		z.MoveTo(float32(s[0].x-ox), float32(s[0].y-oy))
+		for _, p := range s[1:] {
+			z.LineTo(float32(p.x-ox), float32(p.y-oy))
+		}
+		z.ClosePath()
+	}
+	z.Draw(cv.img, bounds, image.NewUniform(col), image.Point{})
+}
+
+func signedArea(pts []fpoint) float64 {
+	var a float64
+	for i := range pts {
+		j := (i + 1) % len(pts)
+		a += pts[i].x*pts[j].y - pts[j].x*pts[i].y
+	}
+	return a / 2
+}
+
+func reversed(pts []fpoint) []fpoint {
+	out := make([]fpoint, len(pts))
+	for i, p := range pts {
+		out[len(pts)-1-i] = p
+	}
+	return out
+}
+
+func (cv *canvas) hline(x0, x1, y int, col color.RGBA) {
+	draw.Draw(cv.img, image.Rect(x0, y, x1+1, y+1), image.NewUniform(col), image.Point{}, draw.Src)
+}
+
+func (cv *canvas) vline(x, y0, y1 int, col color.RGBA) {
+	draw.Draw(cv.img, image.Rect(x, y0, x+1, y1+1), image.NewUniform(col), image.Point{}, draw.Src)
+}
+
+// text выводит строку; y — базовая линия, x — левый край, центр или правый край по align
+func (cv *canvas) text(face font.Face, s string, x, y int, col color.RGBA, a align) {
+	d := font.Drawer{Dst: cv.img, Src: image.NewUniform(col), Face: face}
+	switch a {
+	case alignCenter:
+		x -= d.MeasureString(s).Ceil() / 2
+	case alignRight:
+		x -= d.MeasureString(s).Ceil()
+	}
+	d.Dot = fixed.P(x, y)
+	d.DrawString(s)
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
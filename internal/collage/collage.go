@@ -0,0 +1,183 @@
+// Package collage собирает фотографии прогресса в одну картинку.
+//
+// Compose раскладывает фотографии по сетке: столбцы — даты («до» и «после»),
+// строки — ракурсы. Каждое фото вписывается в ячейку с сохранением пропорций
+// и подписывается; пустая ячейка закрашивается и помечается. Масштабирование —
+// golang.org/x/image/draw (Catmull-Rom), подписи — шрифт Go Regular с кириллицей.
+package collage
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png" // фото может прийти в PNG, если клиент отправил его файлом
+	"sync"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	defaultCellWidth  = 540
+	defaultCellHeight = 720
+	jpegQuality       = 90
+)
+
+var (
+	background = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	emptyColor = color.RGBA{0xee, 0xee, 0xee, 0xff}
+	textColor  = color.RGBA{0x33, 0x33, 0x33, 0xff}
+	mutedColor = color.RGBA{0x88, 0x88, 0x88, 0xff}
+)
+
+// Cell — фотография с подписью; Image == nil — пустая ячейка
+type Cell struct {
+	Image image.Image
+	Label string
+}
+
+// Options задаёт размеры ячеек и подпись пустой ячейки
+type Options struct {
+	CellWidth  int // по умолчанию 540
+	CellHeight int // по умолчанию 720 — портретный кадр 3:4
+	EmptyLabel string
+}
+
+// Шрифт разбирается один раз; Face не потокобезопасен и создаётся на каждый коллаж
+var (
+	fontOnce sync.Once
+	fontData *opentype.Font
+	fontErr  error
+)
+
+func loadFont() (*opentype.Font, error) {
+	fontOnce.Do(func() {
+		fontData, fontErr = opentype.Parse(goregular.TTF)
+	})
+	return fontData, fontErr
+}
+
+// Decode разбирает JPEG или PNG
+func Decode(data []byte) (image.Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, err
+}
+
+// JPEG кодирует коллаж для отправки фотографией
+func JPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Compose собирает сетку: над каждым столбцом — заголовок из header,
+// под каждой фотографией — её подпись. Число столбцов — len(header)
+func Compose(header []string, rows [][]Cell, opts Options) (*image.RGBA, error) {
+	f, err := loadFont()
+	if err != nil {
+		return nil, err
+	}
+	cw, ch := opts.CellWidth, opts.CellHeight
+	if cw <= 0 {
+		cw = defaultCellWidth
+	}
+	if ch <= 0 {
+		ch = defaultCellHeight
+	}
+
+	headerSize := maxInt(cw/18, 16)
+	labelSize := maxInt(cw/24, 12)
+	headerFace, err := newFace(f, headerSize)
+	if err != nil {
+		return nil, err
+	}
+	labelFace, err := newFace(f, labelSize)
+	if err != nil {
+		return nil, err
+	}
+
+	gap := labelSize
+	headerBand := headerSize * 2
+	labelBand := labelSize * 2
+	cols := len(header)
+	width := cols*cw + (cols+1)*gap
+	height := headerBand + len(rows)*(ch+labelBand) + gap
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	for c, title := range header {
+		x := gap + c*(cw+gap) + cw/2
+		drawText(img, headerFace, title, x, headerSize*3/2, textColor)
+	}
+
+	for r, row := range rows {
+		top := headerBand + r*(ch+labelBand)
+		for c := 0; c < cols; c++ {
+			var cell Cell
+			if c < len(row) {
+				cell = row[c]
+			}
+			left := gap + c*(cw+gap)
+			box := image.Rect(left, top, left+cw, top+ch)
+
+			if cell.Image == nil {
+				draw.Draw(img, box, image.NewUniform(emptyColor), image.Point{}, draw.Src)
+				drawText(img, labelFace, opts.EmptyLabel, left+cw/2, top+ch/2, mutedColor)
+			} else {
+				xdraw.CatmullRom.Scale(img, fit(cell.Image.Bounds(), box), cell.Image, cell.Image.Bounds(), draw.Src, nil)
+			}
+			drawText(img, labelFace, cell.Label, left+cw/2, top+ch+labelSize*3/2, textColor)
+		}
+	}
+	return img, nil
+}
+
+// fit вписывает src в box с сохранением пропорций и центрирует
+func fit(src, box image.Rectangle) image.Rectangle {
+	sw, sh := src.Dx(), src.Dy()
+	if sw == 0 || sh == 0 {
+		return box
+	}
+	w, h := box.Dx(), box.Dy()
+	if sw*h > sh*w {
+		h = sh * w / sw
+	} else {
+		w = sw * h / sh
+	}
+	x := box.Min.X + (box.Dx()-w)/2
+	y := box.Min.Y + (box.Dy()-h)/2
+	return image.Rect(x, y, x+w, y+h)
+}
+
+func newFace(f *opentype.Font, size int) (font.Face, error) {
+	return opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    float64(size),
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+}
+
+// drawText выводит строку с центром в x и базовой линией y
+func drawText(img *image.RGBA, face font.Face, s string, x, y int, col color.RGBA) {
+	if s == "" {
+		return
+	}
+	d := font.Drawer{Dst: img, Src: image.NewUniform(col), Face: face}
+	d.Dot = fixed.P(x-d.MeasureString(s).Ceil()/2, y)
+	d.DrawString(s)
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
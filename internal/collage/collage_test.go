@@ -0,0 +1,98 @@
+package collage
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"testing"
+)
+
+func solid(w, h int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+	return img
+}
+
+func TestFit(t *testing.T) {
+	box := image.Rect(10, 20, 310, 420) // 300×400
+	tests := []struct {
+		src  image.Rectangle
+		want image.Rectangle
+	}{
+		{image.Rect(0, 0, 600, 800), image.Rect(10, 20, 310, 420)},  // те же пропорции
+		{image.Rect(0, 0, 800, 400), image.Rect(10, 145, 310, 295)}, // широкое — полосы сверху и снизу
+		{image.Rect(0, 0, 200, 800), image.Rect(110, 20, 210, 420)}, // узкое — полосы по бокам
+	}
+	for _, tt := range tests {
+		if got := fit(tt.src, box); got != tt.want {
+			t.Errorf("fit(%v) = %v, хотим %v", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestComposeSideBySide(t *testing.T) {
+	red := color.RGBA{0xd0, 0x10, 0x10, 0xff}
+	blue := color.RGBA{0x10, 0x10, 0xd0, 0xff}
+
+	rows := [][]Cell{
+		{{Image: solid(300, 400, red), Label: "спереди"}, {Image: solid(600, 800, blue), Label: "спереди"}},
+		{{Label: "сбоку"}, {Image: solid(300, 400, blue), Label: "сбоку"}},
+	}
+	img, err := Compose([]string{"До: 01.03.2026", "После: 01.06.2026"}, rows, Options{CellWidth: 300, CellHeight: 400, EmptyLabel: "нет фото"})
+	if err != nil {
+		t.Fatalf("Compose: %v", err)
+	}
+
+	gap := 300 / 24
+	wantWidth := 2*300 + 3*gap
+	if img.Bounds().Dx() != wantWidth {
+		t.Errorf("ширина %d, хотим %d", img.Bounds().Dx(), wantWidth)
+	}
+
+	// Центр первой ячейки — фото «до», второй — «после», под ним пустая ячейка
+	headerBand := (300 / 18) * 2
+	labelBand := gap * 2
+	at := func(col, row int) color.RGBA {
+		x := gap + col*(300+gap) + 150
+		y := headerBand + row*(400+labelBand) + 100
+		return img.RGBAAt(x, y)
+	}
+	if got := at(0, 0); got != red {
+		t.Errorf("ячейка «до» %v, хотим %v", got, red)
+	}
+	if got := at(1, 0); got != blue {
+		t.Errorf("ячейка «после» %v, хотим %v", got, blue)
+	}
+	if got := at(0, 1); got != emptyColor {
+		t.Errorf("пустая ячейка %v, хотим %v", got, emptyColor)
+	}
+}
+
+func TestDecodeAndJPEG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, solid(40, 30, color.RGBA{1, 2, 3, 0xff})); err != nil {
+		t.Fatal(err)
+	}
+	img, err := Decode(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Decode PNG: %v", err)
+	}
+
+	data, err := JPEG(img)
+	if err != nil {
+		t.Fatalf("JPEG: %v", err)
+	}
+	back, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode JPEG: %v", err)
+	}
+	if back.Bounds() != img.Bounds() {
+		t.Errorf("размер после JPEG %v, хотим %v", back.Bounds(), img.Bounds())
+	}
+
+	if _, err := Decode([]byte("не картинка")); err == nil {
+		t.Error("Decode принял не картинку")
+	}
+}
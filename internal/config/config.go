@@ -28,6 +28,24 @@ type Config struct {
 	// Google OAuth2 (альтернатива Service Account)
 	GoogleOAuthCredPath string
 	GoogleTokenPath     string
+
+	// Google Calendar тренера (через OAuth2): ID календаря, например "primary".
+	// Пусто — синхронизация записей отключена
+	GoogleCalendarID string
+
+	// Часовой пояс по умолчанию для клиентов и тренеров без своего пояса (IANA).
+	// Пусто — часовой пояс сервера
+	DefaultTimezone string
+
+	// Telegram Payments: токен платёжной системы из @BotFather и валюта пакетов.
+	// Без токена оплата вносится тренером вручную
+	PaymentsProviderToken string
+	PaymentsCurrency      string
+
+	// Лента календаря по секретной ссылке: адрес HTTP-сервера (например, ":8080")
+	// и внешний адрес, с которого календари забирают ленту. Пусто — подписка отключена
+	CalendarFeedAddr string
+	CalendarFeedURL  string
 }
 
 // Load загружает конфигурацию из переменных окружения или .env файла
@@ -70,6 +88,15 @@ func Load() (*Config, error) {
 
 		GoogleOAuthCredPath: getEnv("GOOGLE_OAUTH_CREDENTIALS_PATH", ""),
 		GoogleTokenPath:     getEnv("GOOGLE_TOKEN_PATH", ""),
+		GoogleCalendarID:    getEnv("GOOGLE_CALENDAR_ID", ""),
+
+		DefaultTimezone: getEnv("DEFAULT_TIMEZONE", ""),
+
+		PaymentsProviderToken: getEnv("PAYMENTS_PROVIDER_TOKEN", ""),
+		PaymentsCurrency:      strings.ToUpper(getEnv("PAYMENTS_CURRENCY", "RUB")),
+
+		CalendarFeedAddr: getEnv("CALENDAR_FEED_ADDR", ""),
+		CalendarFeedURL:  strings.TrimSuffix(getEnv("CALENDAR_FEED_URL", ""), "/"),
 	}
 
 	if cfg.BotToken == "" {
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"workbot/internal/i18n"
 	"workbot/internal/models"
 
 	"github.com/xuri/excelize/v2"
@@ -171,8 +172,8 @@ func SaveTrainingToExcel(filePath string, db *sql.DB, clientID int, name, surnam
 	return SaveTrainingToUnified(filePath, clientID, clientName, trainingDate, exercises)
 }
 
-// GetClientTrainings возвращает последние N тренировок клиента в виде отформатированных строк
-func GetClientTrainings(filePath string, clientID int, limit int) ([]string, error) {
+// GetClientTrainings возвращает последние N тренировок клиента в виде отформатированных строк на языке lang
+func GetClientTrainings(filePath string, clientID int, limit int, lang i18n.Language) ([]string, error) {
 	f, err := excelize.OpenFile(filePath)
 	if err != nil {
 		return nil, err
@@ -240,7 +241,7 @@ func GetClientTrainings(filePath string, clientID int, limit int) ([]string, err
 
 		exStr := fmt.Sprintf("  • %s %d/%d", exercise, sets, reps)
 		if weight > 0 {
-			exStr += fmt.Sprintf(" %.0fкг", weight)
+			exStr += " " + i18n.Tf("training_weight_kg", lang, weight)
 		}
 		groups[trainingNum].exercises = append(groups[trainingNum].exercises, exStr)
 		groups[trainingNum].tonnage += tonnage
@@ -257,13 +258,14 @@ func GetClientTrainings(filePath string, clientID int, limit int) ([]string, err
 		num := groupOrder[i]
 		g := groups[num]
 		var sb strings.Builder
-		sb.WriteString(fmt.Sprintf("━━━ Тренировка #%d (%s) ━━━\n", g.num, g.date))
+		sb.WriteString(i18n.Tf("training_history_header", lang, g.num, g.date))
+		sb.WriteString("\n")
 		for _, ex := range g.exercises {
 			sb.WriteString(ex)
 			sb.WriteString("\n")
 		}
 		if g.tonnage > 0 {
-			sb.WriteString(fmt.Sprintf("Тоннаж: %.0f кг", g.tonnage))
+			sb.WriteString(i18n.Tf("training_history_tonnage", lang, g.tonnage))
 		}
 		result = append(result, sb.String())
 	}
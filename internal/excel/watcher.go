@@ -1,6 +1,7 @@
 package excel
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -9,7 +10,9 @@ import (
 	"strings"
 	"time"
 
+	"workbot/internal/i18n"
 	"workbot/internal/models"
+	"workbot/internal/scheduler"
 
 	"github.com/fsnotify/fsnotify"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -56,8 +59,6 @@ func (w *Watcher) StartWatching() {
 		log.Printf("Ошибка создания папки клиентов: %v", err)
 	}
 
-	go w.startDBSync(30 * time.Second)
-
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		log.Printf("Ошибка создания наблюдателя: %v", err)
@@ -112,11 +113,11 @@ func GetUnsentTrainings(filePath string, db *sql.DB, clientID int) ([]models.Tra
 	return GetUnsentTrainingsUnified(filePath, clientID)
 }
 
-// FormatTrainingMessage форматирует тренировку для отправки
-func FormatTrainingMessage(group *models.TrainingGroup) string {
-	header := "Тренировка"
+// FormatTrainingMessage форматирует тренировку для отправки клиенту на языке lang
+func FormatTrainingMessage(group *models.TrainingGroup, lang i18n.Language) string {
+	header := i18n.T("training_header", lang)
 	if group.TrainingNum > 0 {
-		header = fmt.Sprintf("Тренировка #%d", group.TrainingNum)
+		header = i18n.Tf("training_header_num", lang, group.TrainingNum)
 	}
 
 	var exercises string
@@ -126,11 +127,11 @@ func FormatTrainingMessage(group *models.TrainingGroup) string {
 	for i, ex := range group.Exercises {
 		var weightStr string
 		if ex.Weight > 0 {
-			weightStr = fmt.Sprintf("%.0f кг", ex.Weight)
+			weightStr = i18n.Tf("training_weight_kg", lang, ex.Weight)
 			tonnage := float64(ex.Sets) * float64(ex.Reps) * ex.Weight
 			totalTonnage += tonnage
 		} else {
-			weightStr = "свой вес"
+			weightStr = i18n.T("training_bodyweight", lang)
 			totalVolume += ex.Sets * ex.Reps
 		}
 
@@ -140,16 +141,16 @@ func FormatTrainingMessage(group *models.TrainingGroup) string {
 
 	var summary string
 	if totalTonnage > 0 {
-		summary = fmt.Sprintf("Общий тоннаж: %.0f кг", totalTonnage)
+		summary = i18n.Tf("training_total_tonnage", lang, totalTonnage)
 	}
 	if totalVolume > 0 {
 		if summary != "" {
 			summary += "\n"
 		}
-		summary += fmt.Sprintf("Объём (свой вес): %d повторений", totalVolume)
+		summary += i18n.Tn("training_bodyweight_volume", lang, totalVolume)
 	}
 
-	return fmt.Sprintf("%s\n\n%s\n%s\n\nУдачной тренировки!", header, exercises, summary)
+	return fmt.Sprintf("%s\n\n%s\n%s\n\n%s", header, exercises, summary, i18n.T("training_good_luck", lang))
 }
 
 // MarkTrainingGroupAsSent помечает все упражнения группы как отправленные
@@ -162,15 +163,19 @@ func MarkTrainingGroupAsSent(filePath string, group *models.TrainingGroup) error
 	return nil
 }
 
-func (w *Watcher) startDBSync(interval time.Duration) {
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
-
-	log.Printf("Фоновая синхронизация БД -> Excel запущена (интервал: %v)", interval)
-
-	for range ticker.C {
-		if err := SyncClientsFromDB(w.filePath, w.db); err != nil {
-			log.Printf("Ошибка фоновой синхронизации: %v", err)
-		}
+// RegisterJobs регистрирует в планировщике фоновую синхронизацию БД -> Excel
+func (w *Watcher) RegisterJobs(jobs *scheduler.Scheduler) error {
+	if FilePath == "" {
+		return nil
 	}
+	return jobs.Register(scheduler.Job{
+		Name:        "excel_db_sync",
+		Description: "Синхронизация клиентов из БД в Excel журнал",
+		Schedule:    "@every 30s",
+		Timeout:     2 * time.Minute,
+		MaxAttempts: 1, // следующий плановый запуск через 30 секунд и так станет повтором
+		Handler: func(ctx context.Context, run scheduler.Run) error {
+			return SyncClientsFromDB(w.filePath, w.db)
+		},
+	})
 }
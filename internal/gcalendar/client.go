@@ -0,0 +1,180 @@
+// Package gcalendar синхронизирует записи на тренировки с Google Calendar тренера:
+// бот создаёт, меняет и удаляет события записей, а события, которые тренер
+// добавил сам, читает как занятое время.
+package gcalendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+
+	"workbot/internal/gsheets"
+)
+
+// appointmentKey — приватное свойство события с ID записи. По нему события бота
+// отличаются от занятости, которую тренер вносит сам
+const appointmentKey = "workbot_appointment"
+
+// listPageSize — событий на страницу при чтении занятости
+const listPageSize = 250
+
+// ErrNotFound — события нет: удалено в календаре или ID устарел
+var ErrNotFound = errors.New("событие не найдено в Google Calendar")
+
+// Client работает с одним календарём тренера
+type Client struct {
+	service    *calendar.Service
+	calendarID string
+}
+
+// Event — событие записи на тренировку
+type Event struct {
+	AppointmentID int
+	Summary       string
+	Description   string
+	StartTime     time.Time
+	EndTime       time.Time
+}
+
+// Busy — занятый промежуток из событий, добавленных тренером
+type Busy struct {
+	EventID string
+	Summary string
+	Start   time.Time
+	End     time.Time
+}
+
+// newClient создаёт клиент поверх готового сервиса Calendar
+func newClient(srv *calendar.Service, calendarID string) *Client {
+	return &Client{service: srv, calendarID: calendarID}
+}
+
+// NewOAuthClient создаёт клиент по OAuth credentials и токену Google Sheets.
+// Токен должен быть получен cmd/oauth_setup с доступом к календарю
+func NewOAuthClient(oauthCredPath, tokenPath, calendarID string) (*Client, error) {
+	ctx := context.Background()
+
+	httpClient, err := gsheets.OAuthHTTPClient(ctx, oauthCredPath, tokenPath, calendar.CalendarEventsScope)
+	if err != nil {
+		return nil, err
+	}
+
+	srv, err := calendar.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания Calendar сервиса: %w", err)
+	}
+
+	log.Printf("Google Calendar клиент инициализирован (%s)", calendarID)
+	return newClient(srv, calendarID), nil
+}
+
+// CreateEvent создаёт событие записи и возвращает его ID
+func (c *Client) CreateEvent(ctx context.Context, e Event) (string, error) {
+	created, err := c.service.Events.Insert(c.calendarID, toAPI(e)).Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("создание события: %w", err)
+	}
+	return created.Id, nil
+}
+
+// UpdateEvent заменяет событие записи; ErrNotFound — события уже нет
+func (c *Client) UpdateEvent(ctx context.Context, eventID string, e Event) error {
+	_, err := c.service.Events.Update(c.calendarID, eventID, toAPI(e)).Context(ctx).Do()
+	if isNotFound(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("изменение события %s: %w", eventID, err)
+	}
+	return nil
+}
+
+// DeleteEvent удаляет событие; уже удалённое — не ошибка
+func (c *Client) DeleteEvent(ctx context.Context, eventID string) error {
+	err := c.service.Events.Delete(c.calendarID, eventID).Context(ctx).Do()
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("удаление события %s: %w", eventID, err)
+	}
+	return nil
+}
+
+// BusySlots возвращает занятость тренера в [from, to): непрозрачные события,
+// кроме созданных ботом. Событие на весь день занимает день целиком в поясе loc
+func (c *Client) BusySlots(ctx context.Context, from, to time.Time, loc *time.Location) ([]Busy, error) {
+	var busy []Busy
+	call := c.service.Events.List(c.calendarID).
+		TimeMin(from.Format(time.RFC3339)).
+		TimeMax(to.Format(time.RFC3339)).
+		SingleEvents(true).
+		OrderBy("startTime").
+		MaxResults(listPageSize)
+
+	err := call.Pages(ctx, func(page *calendar.Events) error {
+		for _, ev := range page.Items {
+			if !blocksTime(ev) {
+				continue
+			}
+			start, err := eventTime(ev.Start, loc)
+			if err != nil {
+				return fmt.Errorf("событие %s: %w", ev.Id, err)
+			}
+			end, err := eventTime(ev.End, loc)
+			if err != nil {
+				return fmt.Errorf("событие %s: %w", ev.Id, err)
+			}
+			busy = append(busy, Busy{EventID: ev.Id, Summary: ev.Summary, Start: start, End: end})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("чтение событий: %w", err)
+	}
+	return busy, nil
+}
+
+// blocksTime — событие занимает время тренера: не отменено, не «свободен»
+// и создано не ботом
+func blocksTime(ev *calendar.Event) bool {
+	if ev.Status == "cancelled" || ev.Transparency == "transparent" {
+		return false
+	}
+	if ev.ExtendedProperties != nil && ev.ExtendedProperties.Private[appointmentKey] != "" {
+		return false
+	}
+	return ev.Start != nil && ev.End != nil
+}
+
+// eventTime разбирает начало или конец события: момент или дату события на весь день
+func eventTime(t *calendar.EventDateTime, loc *time.Location) (time.Time, error) {
+	if t.DateTime != "" {
+		return time.Parse(time.RFC3339, t.DateTime)
+	}
+	return time.ParseInLocation("2006-01-02", t.Date, loc)
+}
+
+// toAPI переводит событие записи в формат Calendar API
+func toAPI(e Event) *calendar.Event {
+	return &calendar.Event{
+		Summary:     e.Summary,
+		Description: e.Description,
+		Start:       &calendar.EventDateTime{DateTime: e.StartTime.Format(time.RFC3339)},
+		End:         &calendar.EventDateTime{DateTime: e.EndTime.Format(time.RFC3339)},
+		ExtendedProperties: &calendar.EventExtendedProperties{
+			Private: map[string]string{appointmentKey: strconv.Itoa(e.AppointmentID)},
+		},
+	}
+}
+
+// isNotFound — событие удалено (404) или удалено окончательно (410)
+func isNotFound(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && (apiErr.Code == http.StatusNotFound || apiErr.Code == http.StatusGone)
+}
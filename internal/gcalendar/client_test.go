@@ -0,0 +1,126 @@
+package gcalendar
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func testEvent(start time.Time) Event {
+	return Event{
+		AppointmentID: 42,
+		Summary:       "Тренировка: Иван Петров",
+		StartTime:     start,
+		EndTime:       start.Add(time.Hour),
+	}
+}
+
+func TestEventLifecycle(t *testing.T) {
+	fake := newFakeCalendar()
+	c := newTestClient(t, fake)
+	ctx := context.Background()
+	moscow, _ := time.LoadLocation("Europe/Moscow")
+	start := time.Date(2030, 3, 15, 10, 0, 0, 0, moscow)
+
+	id, err := c.CreateEvent(ctx, testEvent(start))
+	if err != nil {
+		t.Fatalf("CreateEvent: %v", err)
+	}
+	ev := fake.events[id]
+	if ev.Start.DateTime != "2030-03-15T10:00:00+03:00" || ev.ExtendedProperties.Private[appointmentKey] != "42" {
+		t.Errorf("создано %+v", ev)
+	}
+
+	moved := testEvent(start.Add(2 * time.Hour))
+	if err := c.UpdateEvent(ctx, id, moved); err != nil {
+		t.Fatalf("UpdateEvent: %v", err)
+	}
+	if got := fake.events[id].Start.DateTime; got != "2030-03-15T12:00:00+03:00" {
+		t.Errorf("после переноса начало %s", got)
+	}
+
+	if err := c.DeleteEvent(ctx, id); err != nil {
+		t.Fatalf("DeleteEvent: %v", err)
+	}
+	// Повторное удаление и изменение удалённого события
+	if err := c.DeleteEvent(ctx, id); err != nil {
+		t.Errorf("повторный DeleteEvent: %v", err)
+	}
+	if err := c.UpdateEvent(ctx, id, moved); !errors.Is(err, ErrNotFound) {
+		t.Errorf("UpdateEvent удалённого = %v, want ErrNotFound", err)
+	}
+
+	want := []string{
+		"POST /calendars/primary/events",
+		"PUT /calendars/primary/events/" + id,
+		"DELETE /calendars/primary/events/" + id,
+		"DELETE /calendars/primary/events/" + id,
+		"PUT /calendars/primary/events/" + id,
+	}
+	if !reflect.DeepEqual(fake.requests, want) {
+		t.Errorf("requests = %v, want %v", fake.requests, want)
+	}
+}
+
+func TestBusySlots(t *testing.T) {
+	fake := newFakeCalendar()
+	c := newTestClient(t, fake)
+	ctx := context.Background()
+	moscow, _ := time.LoadLocation("Europe/Moscow")
+
+	// Событие бота — не занятость
+	if _, err := c.CreateEvent(ctx, testEvent(time.Date(2030, 3, 15, 10, 0, 0, 0, moscow))); err != nil {
+		t.Fatal(err)
+	}
+	fake.add(&calendar.Event{
+		Summary: "Врач",
+		Start:   &calendar.EventDateTime{DateTime: "2030-03-15T14:00:00+03:00"},
+		End:     &calendar.EventDateTime{DateTime: "2030-03-15T15:30:00+03:00"},
+	})
+	fake.add(&calendar.Event{
+		Summary:      "Напоминание",
+		Transparency: "transparent",
+		Start:        &calendar.EventDateTime{DateTime: "2030-03-15T16:00:00+03:00"},
+		End:          &calendar.EventDateTime{DateTime: "2030-03-15T17:00:00+03:00"},
+	})
+	fake.add(&calendar.Event{
+		Summary: "Отменённая встреча",
+		Status:  "cancelled",
+		Start:   &calendar.EventDateTime{DateTime: "2030-03-15T18:00:00+03:00"},
+		End:     &calendar.EventDateTime{DateTime: "2030-03-15T19:00:00+03:00"},
+	})
+	fake.add(&calendar.Event{
+		Summary: "Соревнования",
+		Start:   &calendar.EventDateTime{Date: "2030-03-16"},
+		End:     &calendar.EventDateTime{Date: "2030-03-17"},
+	})
+
+	busy, err := c.BusySlots(ctx, time.Date(2030, 3, 15, 0, 0, 0, 0, moscow), time.Date(2030, 3, 20, 0, 0, 0, 0, moscow), moscow)
+	if err != nil {
+		t.Fatalf("BusySlots: %v", err)
+	}
+	if len(busy) != 2 {
+		t.Fatalf("busy = %+v, want 2", busy)
+	}
+	if busy[0].Summary != "Врач" || busy[0].End.Sub(busy[0].Start) != 90*time.Minute {
+		t.Errorf("busy[0] = %+v", busy[0])
+	}
+	if !busy[1].Start.Equal(time.Date(2030, 3, 16, 0, 0, 0, 0, moscow)) || busy[1].End.Sub(busy[1].Start) != 24*time.Hour {
+		t.Errorf("событие на весь день = %+v", busy[1])
+	}
+
+	// Пять событий по две на страницу — три запроса списка
+	lists := 0
+	for _, r := range fake.requests {
+		if r == "GET /calendars/primary/events" {
+			lists++
+		}
+	}
+	if lists != 3 {
+		t.Errorf("запросов списка %d, want 3", lists)
+	}
+}
@@ -0,0 +1,134 @@
+package gcalendar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+// fakeCalendar — локальный HTTP-сервер, отвечающий как Calendar API v3
+// для одного календаря. Список событий отдаётся страницами по pageSize
+type fakeCalendar struct {
+	mu       sync.Mutex
+	requests []string                   // "POST /calendars/primary/events" в порядке поступления
+	events   map[string]*calendar.Event // ID → событие
+	nextID   int
+	pageSize int
+}
+
+func newFakeCalendar() *fakeCalendar {
+	return &fakeCalendar{events: make(map[string]*calendar.Event), pageSize: 2}
+}
+
+func (f *fakeCalendar) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	w.Header().Set("Content-Type", "application/json")
+
+	const prefix = "/calendars/primary/events"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+	id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, prefix), "/")
+
+	switch {
+	case r.Method == http.MethodPost && id == "":
+		var ev calendar.Event
+		json.NewDecoder(r.Body).Decode(&ev)
+		f.nextID++
+		ev.Id = fmt.Sprintf("ev%d", f.nextID)
+		ev.Status = "confirmed"
+		f.events[ev.Id] = &ev
+		json.NewEncoder(w).Encode(ev)
+
+	case r.Method == http.MethodPut:
+		if _, ok := f.events[id]; !ok {
+			writeError(w, http.StatusNotFound)
+			return
+		}
+		var ev calendar.Event
+		json.NewDecoder(r.Body).Decode(&ev)
+		ev.Id, ev.Status = id, "confirmed"
+		f.events[id] = &ev
+		json.NewEncoder(w).Encode(ev)
+
+	case r.Method == http.MethodDelete:
+		if _, ok := f.events[id]; !ok {
+			writeError(w, http.StatusGone)
+			return
+		}
+		delete(f.events, id)
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.Method == http.MethodGet && id == "":
+		f.list(w, r)
+
+	default:
+		writeError(w, http.StatusNotFound)
+	}
+}
+
+// list отдаёт события по возрастанию ID, страница задаётся pageToken — номером первого
+func (f *fakeCalendar) list(w http.ResponseWriter, r *http.Request) {
+	ids := make([]string, 0, len(f.events))
+	for id := range f.events {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	from, _ := strconv.Atoi(r.URL.Query().Get("pageToken"))
+	page := calendar.Events{}
+	for i := from; i < len(ids) && i < from+f.pageSize; i++ {
+		page.Items = append(page.Items, f.events[ids[i]])
+	}
+	if from+f.pageSize < len(ids) {
+		page.NextPageToken = strconv.Itoa(from + f.pageSize)
+	}
+	json.NewEncoder(w).Encode(page)
+}
+
+// add добавляет событие, как если бы его создал тренер
+func (f *fakeCalendar) add(ev *calendar.Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	ev.Id = fmt.Sprintf("ev%d", f.nextID)
+	if ev.Status == "" {
+		ev.Status = "confirmed"
+	}
+	f.events[ev.Id] = ev
+}
+
+func writeError(w http.ResponseWriter, code int) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{"code": code, "message": http.StatusText(code)},
+	})
+}
+
+// newTestClient создаёт клиент, работающий с fake-сервером
+func newTestClient(t *testing.T, fake *fakeCalendar) *Client {
+	t.Helper()
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	calSrv, err := calendar.NewService(context.Background(),
+		option.WithEndpoint(srv.URL+"/"), option.WithHTTPClient(srv.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newClient(calSrv, "primary")
+}
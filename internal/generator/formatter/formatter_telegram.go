@@ -4,15 +4,28 @@ import (
 	"fmt"
 	"strings"
 
+	"workbot/internal/i18n"
 	"workbot/internal/models"
 )
 
 // TelegramFormatter - форматтер для Telegram
-type TelegramFormatter struct{}
+type TelegramFormatter struct {
+	Lang i18n.Language // Язык текста программы
+}
+
+// NewTelegramFormatter создаёт новый форматтер для языка lang
+func NewTelegramFormatter(lang i18n.Language) *TelegramFormatter {
+	return &TelegramFormatter{Lang: lang}
+}
+
+// t возвращает перевод ключа на языке форматтера
+func (f *TelegramFormatter) t(key string) string {
+	return i18n.T(key, f.Lang)
+}
 
-// NewTelegramFormatter создаёт новый форматтер
-func NewTelegramFormatter() *TelegramFormatter {
-	return &TelegramFormatter{}
+// tf возвращает форматированный перевод на языке форматтера
+func (f *TelegramFormatter) tf(key string, args ...interface{}) string {
+	return i18n.Tf(key, f.Lang, args...)
 }
 
 // FormatProgram форматирует всю программу
@@ -47,39 +60,10 @@ func (f *TelegramFormatter) FormatProgram(program *models.GeneratedProgram) stri
 
 // formatHeader форматирует заголовок программы
 func (f *TelegramFormatter) formatHeader(program *models.GeneratedProgram) string {
-	goalNames := map[models.TrainingGoal]string{
-		models.GoalStrength:    "Сила",
-		models.GoalHypertrophy: "Набор массы",
-		models.GoalFatLoss:     "Жиросжигание",
-		models.GoalHyrox:       "Hyrox",
-		models.GoalEndurance:   "Выносливость",
-		models.GoalGeneral:     "ОФП",
-	}
-
-	periodNames := map[models.PeriodizationType]string{
-		models.PeriodLinear:     "линейная",
-		models.PeriodUndulating: "волнообразная",
-		models.PeriodBlock:      "блочная",
-		models.PeriodReverse:    "обратная",
-	}
-
-	goal := goalNames[program.Goal]
-	if goal == "" {
-		goal = string(program.Goal)
-	}
-
-	period := periodNames[program.Periodization]
-	if period == "" {
-		period = string(program.Periodization)
-	}
+	goal := f.t("gen_goal_" + string(program.Goal))
+	period := f.t("gen_period_" + string(program.Periodization))
 
-	return fmt.Sprintf(`ПРОГРАММА: %s
-Клиент: %s
-
-Длительность: %d недель
-Тренировок в неделю: %d
-Периодизация: %s
-`,
+	return f.tf("gen_header",
 		goal,
 		program.ClientName,
 		program.TotalWeeks,
@@ -96,13 +80,13 @@ func (f *TelegramFormatter) formatPhases(phases []models.ProgramPhase) string {
 
 	var sb strings.Builder
 	sb.WriteString("══════════════════════════════════\n")
-	sb.WriteString("ФАЗЫ ПРОГРАММЫ\n")
+	sb.WriteString(f.t("gen_phases_title") + "\n")
 	sb.WriteString("══════════════════════════════════\n\n")
 
 	for _, phase := range phases {
-		sb.WriteString(fmt.Sprintf("▸ %s (недели %d-%d)\n", phase.Name, phase.WeekStart, phase.WeekEnd))
+		sb.WriteString(f.tf("gen_phase", phase.Name, phase.WeekStart, phase.WeekEnd) + "\n")
 		sb.WriteString(fmt.Sprintf("  %s\n", phase.Focus))
-		sb.WriteString(fmt.Sprintf("  Интенсивность: %.0f-%.0f%%\n", phase.IntensityMin, phase.IntensityMax))
+		sb.WriteString("  " + f.tf("gen_phase_intensity", phase.IntensityMin, phase.IntensityMax) + "\n")
 		sb.WriteString("\n")
 	}
 
@@ -114,9 +98,9 @@ func (f *TelegramFormatter) formatWeek(week models.GeneratedWeek) string {
 	var sb strings.Builder
 
 	// Заголовок недели
-	weekHeader := fmt.Sprintf("НЕДЕЛЯ %d", week.WeekNum)
+	weekHeader := f.tf("gen_week", week.WeekNum)
 	if week.IsDeload {
-		weekHeader += " (РАЗГРУЗКА)"
+		weekHeader += " " + f.t("gen_deload")
 	}
 	if week.PhaseName != "" {
 		weekHeader += fmt.Sprintf(" | %s", week.PhaseName)
@@ -125,7 +109,7 @@ func (f *TelegramFormatter) formatWeek(week models.GeneratedWeek) string {
 	sb.WriteString("┌─────────────────────────────────\n")
 	sb.WriteString(fmt.Sprintf("│ %s\n", weekHeader))
 	if !week.IsDeload {
-		sb.WriteString(fmt.Sprintf("│ Интенсивность: %.0f%% | RPE: %.1f\n", week.IntensityPercent, week.RPETarget))
+		sb.WriteString("│ " + f.tf("gen_week_intensity", week.IntensityPercent, week.RPETarget) + "\n")
 	}
 	sb.WriteString("└─────────────────────────────────\n\n")
 
@@ -151,7 +135,7 @@ func (f *TelegramFormatter) formatDay(day models.GeneratedDay) string {
 	}
 
 	if day.EstimatedDuration > 0 {
-		sb.WriteString(fmt.Sprintf("⏱ ~%d мин\n", day.EstimatedDuration))
+		sb.WriteString(f.tf("gen_day_duration", day.EstimatedDuration) + "\n")
 	}
 
 	return sb.String()
@@ -169,24 +153,24 @@ func (f *TelegramFormatter) formatExercise(ex models.GeneratedExercise) string {
 
 	// Вес или уровень TRX
 	if ex.Weight > 0 {
-		params += fmt.Sprintf(" @%.1f кг", ex.Weight)
+		params += " @" + f.tf("gen_weight_kg", ex.Weight)
 		if ex.WeightPercent > 0 {
 			params += fmt.Sprintf(" (%.0f%%)", ex.WeightPercent)
 		}
 	} else if ex.TRXLevel > 0 {
-		params += fmt.Sprintf(" @уровень %d", ex.TRXLevel)
+		params += " @" + f.tf("gen_trx_level", ex.TRXLevel)
 	}
 
 	sb.WriteString(params)
 
 	// Темп (если есть)
 	if ex.Tempo != "" {
-		sb.WriteString(fmt.Sprintf(" | Темп %s", ex.Tempo))
+		sb.WriteString(" | " + f.tf("gen_tempo", ex.Tempo))
 	}
 
 	// Отдых
 	if ex.RestSeconds > 0 {
-		sb.WriteString(fmt.Sprintf(" | Отдых %s", formatRest(ex.RestSeconds)))
+		sb.WriteString(" | " + f.tf("gen_rest", f.formatRest(ex.RestSeconds)))
 	}
 
 	sb.WriteString("\n")
@@ -214,25 +198,25 @@ func (f *TelegramFormatter) formatStatistics(stats models.ProgramStats) string {
 	var sb strings.Builder
 
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━━━\n")
-	sb.WriteString("СТАТИСТИКА ПРОГРАММЫ\n")
+	sb.WriteString(f.t("gen_stats_title") + "\n")
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━━━\n")
 
-	sb.WriteString(fmt.Sprintf("Всего тренировок: %d\n", stats.TotalWorkouts))
-	sb.WriteString(fmt.Sprintf("Всего подходов: %d\n", stats.TotalSets))
+	sb.WriteString(f.tf("gen_stats_workouts", stats.TotalWorkouts) + "\n")
+	sb.WriteString(f.tf("gen_stats_sets", stats.TotalSets) + "\n")
 
 	if stats.TotalVolume > 0 {
-		sb.WriteString(fmt.Sprintf("Общий тоннаж: %.0f кг\n", stats.TotalVolume))
+		sb.WriteString(f.tf("gen_stats_volume", stats.TotalVolume) + "\n")
 	}
 
 	if stats.AvgWorkoutDur > 0 {
-		sb.WriteString(fmt.Sprintf("Средняя тренировка: ~%d мин\n", stats.AvgWorkoutDur))
+		sb.WriteString(f.tf("gen_stats_avg_duration", stats.AvgWorkoutDur) + "\n")
 	}
 
 	// Объём по мышцам
 	if len(stats.SetsPerMuscle) > 0 {
-		sb.WriteString("\nОбъём по мышцам (подходов/неделю):\n")
+		sb.WriteString("\n" + f.t("gen_stats_muscles") + "\n")
 		for muscle, sets := range stats.SetsPerMuscle {
-			muscleName := getMuscleNameRu(muscle)
+			muscleName := f.t("muscle_" + string(muscle))
 			sb.WriteString(fmt.Sprintf("  %s: %d\n", muscleName, sets))
 		}
 	}
@@ -250,7 +234,7 @@ func (f *TelegramFormatter) formatMovementBalance(b *models.MovementBalance) str
 	var sb strings.Builder
 
 	sb.WriteString("\n━━━━━━━━━━━━━━━━━━━━━\n")
-	sb.WriteString("БАЛАНС ПАТТЕРНОВ\n")
+	sb.WriteString(f.t("gen_balance_title") + "\n")
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━━━\n")
 
 	// Push/Pull
@@ -285,20 +269,20 @@ func (f *TelegramFormatter) formatMovementBalance(b *models.MovementBalance) str
 
 	// Bi/Uni
 	if b.BilateralLegSets > 0 || b.UnilateralLegSets > 0 {
-		sb.WriteString(fmt.Sprintf("Bi/Uni (ноги): %d/%d\n", b.BilateralLegSets, b.UnilateralLegSets))
+		sb.WriteString(f.tf("gen_balance_legs", b.BilateralLegSets, b.UnilateralLegSets) + "\n")
 	}
 
 	// Core
 	if b.CoreSets > 0 {
-		sb.WriteString(fmt.Sprintf("Core: %d сетов\n", b.CoreSets))
+		sb.WriteString(f.tf("gen_balance_core", b.CoreSets) + "\n")
 	}
 
 	// Оценка
-	sb.WriteString(fmt.Sprintf("\nОценка: %d/100 %s\n", b.OverallScore, getAssessmentEmoji(b.Assessment)))
+	sb.WriteString("\n" + f.tf("gen_balance_score", b.OverallScore, getAssessmentEmoji(b.Assessment)) + "\n")
 
 	// Рекомендации
 	if len(b.Recommendations) > 0 {
-		sb.WriteString("\nРекомендации:\n")
+		sb.WriteString("\n" + f.t("gen_balance_recommendations") + "\n")
 		for _, rec := range b.Recommendations {
 			sb.WriteString(fmt.Sprintf("• %s\n", rec))
 		}
@@ -341,7 +325,7 @@ func getAssessmentEmoji(assessment string) string {
 func (f *TelegramFormatter) formatSubstitutions(subs []models.Substitution) string {
 	var sb strings.Builder
 
-	sb.WriteString("ЗАМЕНЫ ИЗ-ЗА ОГРАНИЧЕНИЙ:\n")
+	sb.WriteString(f.t("gen_substitutions_title") + "\n")
 
 	for _, sub := range subs {
 		sb.WriteString(fmt.Sprintf("- %s → %s (%s)\n", sub.OriginalName, sub.ReplacedName, sub.Reason))
@@ -359,7 +343,7 @@ func (f *TelegramFormatter) FormatWeekOnly(week models.GeneratedWeek) string {
 func (f *TelegramFormatter) FormatDayOnly(day models.GeneratedDay, weekNum int) string {
 	var sb strings.Builder
 
-	sb.WriteString(fmt.Sprintf("📅 НЕДЕЛЯ %d\n\n", weekNum))
+	sb.WriteString("📅 " + f.tf("gen_week", weekNum) + "\n\n")
 	sb.WriteString(f.formatDay(day))
 
 	return sb.String()
@@ -367,40 +351,14 @@ func (f *TelegramFormatter) FormatDayOnly(day models.GeneratedDay, weekNum int)
 
 // === Утилиты ===
 
-func formatRest(seconds int) string {
+func (f *TelegramFormatter) formatRest(seconds int) string {
 	if seconds >= 60 {
 		mins := seconds / 60
 		secs := seconds % 60
 		if secs == 0 {
-			return fmt.Sprintf("%d мин", mins)
+			return f.tf("gen_rest_minutes", mins)
 		}
 		return fmt.Sprintf("%d:%02d", mins, secs)
 	}
-	return fmt.Sprintf("%d сек", seconds)
-}
-
-func getMuscleNameRu(muscle models.MuscleGroupExt) string {
-	names := map[models.MuscleGroupExt]string{
-		models.MuscleChest:        "Грудь",
-		models.MuscleBack:         "Спина",
-		models.MuscleShoulders:    "Плечи",
-		models.MuscleBiceps:       "Бицепс",
-		models.MuscleTriceps:      "Трицепс",
-		models.MuscleQuads:        "Квадрицепс",
-		models.MuscleHamstrings:   "Бицепс бедра",
-		models.MuscleGlutes:       "Ягодицы",
-		models.MuscleCore:         "Кор",
-		models.MuscleCalves:       "Икры",
-		models.MuscleTraps:        "Трапеции",
-		models.MuscleRearDelts:    "Задние дельты",
-		models.MuscleForearms:     "Предплечья",
-		models.MuscleLowerBack:    "Поясница",
-		models.MuscleFullBody:     "Всё тело",
-		models.MuscleCardioSystem: "Кардио",
-	}
-
-	if name, ok := names[muscle]; ok {
-		return name
-	}
-	return string(muscle)
+	return f.tf("gen_rest_seconds", seconds)
 }
@@ -267,7 +267,7 @@ func (g *FatLossGenerator) generateHIITExercises(weekNum int, isDeload bool) []m
 		age = g.client.Age
 	}
 
-	cardioProg := progression.NewCardioProgression("fat_loss", string(g.client.Experience), age)
+	cardioProg := progression.NewCardioProgression("fat_loss", string(g.client.Experience), age).WithVolumeFactor(g.client.CardioFactor)
 	params := cardioProg.GetHIITParams(weekNum)
 
 	if isDeload {
@@ -306,7 +306,7 @@ func (g *FatLossGenerator) generateLISSExercises(weekNum int) []models.Generated
 		age = g.client.Age
 	}
 
-	cardioProg := progression.NewCardioProgression("fat_loss", string(g.client.Experience), age)
+	cardioProg := progression.NewCardioProgression("fat_loss", string(g.client.Experience), age).WithVolumeFactor(g.client.CardioFactor)
 	params := cardioProg.GetLISSParams(weekNum)
 
 	duration := int(params.Value / 60) // секунды в минуты
@@ -181,12 +181,13 @@ func (g *HypertrophyGenerator) initWorkCapacities(priorities []string) map[strin
 	muscleGroups := []string{"chest", "back", "shoulders", "quads", "hamstrings", "glutes", "biceps", "triceps", "calves", "core"}
 
 	for _, mg := range muscleGroups {
-		wc := progression.NewWorkCapacity(mg)
+		// Ориентиры клиента, адаптированные по итогам прошлых блоков
+		wc := progression.NewClientWorkCapacity(mg, g.client.VolumeFactors[mg])
 
 		// Увеличиваем capacity для приоритетных групп
 		for _, priority := range priorities {
 			if priority == mg {
-				wc.AdaptationFactor = 1.1 // +10% объёма
+				wc.AdaptationFactor *= 1.1 // +10% объёма
 				break
 			}
 		}
@@ -809,6 +810,11 @@ func (g *HypertrophyGenerator) convertToGeneratedExercise(
 		}
 	}
 
+	// Подходы по ориентирам объёма клиента
+	if !isDeload {
+		genEx.Sets = progression.ScaleSets(genEx.Sets, g.client.VolumeFactors[string(genEx.MuscleGroup)])
+	}
+
 	// Добавляем альтернативу
 	if result.Alternative != nil {
 		alt := models.GeneratedExercise{
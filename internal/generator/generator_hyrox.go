@@ -2,8 +2,11 @@ package generator
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"workbot/internal/generator/progression"
+	"workbot/internal/hyrox"
 	"workbot/internal/models"
 )
 
@@ -212,7 +215,7 @@ func (g *HyroxGenerator) generateDayHyrox(dayNum int, dayType string, weekNum in
 	if g.client.Age > 0 {
 		age = g.client.Age
 	}
-	cardioProg := progression.NewCardioProgression("hyrox", string(g.client.Experience), age)
+	cardioProg := progression.NewCardioProgression("hyrox", string(g.client.Experience), age).WithVolumeFactor(g.client.CardioFactor)
 
 	switch dayType {
 	case "strength_upper", "strength_lower", "strength_full":
@@ -557,61 +560,97 @@ func (g *HyroxGenerator) generateStrengthCircuit(weekNum int) []models.Generated
 	}
 }
 
-// generateSimulation генерирует симуляцию Hyrox
+// hyroxSimDefaults — станции неполных симуляций, если акцента нет
+var hyroxSimDefaults = map[string][]string{
+	"mini": {hyrox.SkiErg, hyrox.WallBall},
+	"half": {hyrox.SkiErg, hyrox.SledPush, hyrox.SledPull, hyrox.WallBall},
+}
+
+// generateSimulation генерирует симуляцию Hyrox. Неполные симуляции строятся вокруг
+// станций, на которых клиент терял время в последней симуляции; при заданной
+// раскладке забега в заметках — целевое время отрезков
 func (g *HyroxGenerator) generateSimulation(simType string, weekNum int, cardioProg *progression.CardioProgression) []models.GeneratedExercise {
 	var exercises []models.GeneratedExercise
 
 	switch simType {
-	case "mini":
-		// 2 станции + 2 км бега
-		exercises = []models.GeneratedExercise{
-			{OrderNum: 1, ExerciseName: "Бег", Sets: 1, Reps: "1000 м", Notes: "Соревновательный темп"},
-			{OrderNum: 2, ExerciseName: "Ski Erg", Sets: 1, Reps: "1000 м", Notes: "Без перерыва"},
-			{OrderNum: 3, ExerciseName: "Бег", Sets: 1, Reps: "1000 м", Notes: "Соревновательный темп"},
-			{OrderNum: 4, ExerciseName: "Wall Balls", Sets: 1, Reps: "100", Notes: "Без перерыва"},
+	case "mini", "half":
+		// mini: 2 станции + 2 км бега, half: 4 станции + 4 км бега
+		defaults := hyroxSimDefaults[simType]
+		stations := hyrox.SimulationStations(len(defaults), g.client.HyroxFocus, defaults)
+		runNotes, stationNotes := "Соревновательный темп", "Без перерыва"
+		if simType == "half" {
+			runNotes, stationNotes = "Без перерывов между станциями", "Без перерывов между станциями"
 		}
-
-	case "half":
-		// 4 станции + 4 км бега
-		exercises = []models.GeneratedExercise{
-			{OrderNum: 1, ExerciseName: "Бег", Sets: 1, Reps: "1000 м"},
-			{OrderNum: 2, ExerciseName: "Ski Erg", Sets: 1, Reps: "1000 м"},
-			{OrderNum: 3, ExerciseName: "Бег", Sets: 1, Reps: "1000 м"},
-			{OrderNum: 4, ExerciseName: "Sled Push", Sets: 1, Reps: "50 м"},
-			{OrderNum: 5, ExerciseName: "Бег", Sets: 1, Reps: "1000 м"},
-			{OrderNum: 6, ExerciseName: "Sled Pull", Sets: 1, Reps: "50 м"},
-			{OrderNum: 7, ExerciseName: "Бег", Sets: 1, Reps: "1000 м"},
-			{OrderNum: 8, ExerciseName: "Wall Balls", Sets: 1, Reps: "100"},
-		}
-		for i := range exercises {
-			exercises[i].Notes = "Без перерывов между станциями"
+		for _, id := range stations {
+			// Бег перед станцией — тот же круг, что и в забеге
+			exercises = append(exercises,
+				g.simulationRun(len(exercises)+1, hyrox.StationIndex(id)+1, runNotes),
+				models.GeneratedExercise{
+					OrderNum:     len(exercises) + 2,
+					ExerciseName: hyrox.StationName(id),
+					Sets:         1,
+					Reps:         hyrox.StationWork(id),
+					Notes:        g.withHyroxTarget(stationNotes, id),
+				})
 		}
 
 	case "full":
 		// Полная симуляция: 8 станций + 8 км бега
-		stations := []string{"Ski Erg 1000м", "Sled Push 50м", "Sled Pull 50м", "Burpee Broad Jump 80м",
-			"Rowing 1000м", "Farmer's Carry 200м", "Sandbag Lunges 100м", "Wall Balls 100"}
-
-		for i, station := range stations {
-			exercises = append(exercises, models.GeneratedExercise{
-				OrderNum:     i*2 + 1,
-				ExerciseName: "Бег",
-				Sets:         1,
-				Reps:         "1000 м",
-			})
-			exercises = append(exercises, models.GeneratedExercise{
-				OrderNum:     i*2 + 2,
-				ExerciseName: station,
-				Sets:         1,
-				Reps:         "-",
-			})
+		for i, id := range hyrox.Stations {
+			exercises = append(exercises,
+				g.simulationRun(i*2+1, i+1, ""),
+				models.GeneratedExercise{
+					OrderNum:     i*2 + 2,
+					ExerciseName: hyrox.StationName(id) + " " + strings.ReplaceAll(hyrox.StationWork(id), " ", ""),
+					Sets:         1,
+					Reps:         "-",
+					Notes:        g.withHyroxTarget("", id),
+				})
 		}
-		exercises[0].Notes = "ПОЛНАЯ СИМУЛЯЦИЯ HYROX"
+		exercises[0].Notes = joinNotes("ПОЛНАЯ СИМУЛЯЦИЯ HYROX", exercises[0].Notes)
 	}
 
 	return exercises
 }
 
+// simulationRun — беговой отрезок симуляции перед станцией круга num
+func (g *HyroxGenerator) simulationRun(orderNum, num int, notes string) models.GeneratedExercise {
+	if g.client.HyroxFading {
+		notes = joinNotes(notes, "Первые километры не быстрее цели")
+	}
+	for _, id := range g.client.HyroxFocus {
+		if id == hyrox.Run {
+			notes = joinNotes(notes, "Акцент: темп бега после станций")
+		}
+	}
+	return models.GeneratedExercise{
+		OrderNum:     orderNum,
+		ExerciseName: "Бег",
+		Sets:         1,
+		Reps:         "1000 м",
+		Notes:        g.withHyroxTarget(notes, hyrox.RunID(num)),
+	}
+}
+
+// withHyroxTarget добавляет к заметке целевое время отрезка из раскладки клиента
+func (g *HyroxGenerator) withHyroxTarget(notes, segmentID string) string {
+	if sec := g.client.HyroxSplits[segmentID]; sec > 0 {
+		return joinNotes(notes, "Цель: "+hyrox.FormatTime(time.Duration(sec)*time.Second))
+	}
+	return notes
+}
+
+// joinNotes соединяет непустые заметки через точку
+func joinNotes(notes ...string) string {
+	var parts []string
+	for _, n := range notes {
+		if n != "" {
+			parts = append(parts, n)
+		}
+	}
+	return strings.Join(parts, ". ")
+}
+
 // === Вспомогательные методы ===
 
 func (g *HyroxGenerator) getPhaseForWeek(weekNum, totalWeeks int) string {
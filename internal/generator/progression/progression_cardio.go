@@ -7,6 +7,10 @@ type CardioProgression struct {
 	Goal       string  // fat_loss/hyrox/endurance
 	Experience string  // beginner/intermediate/advanced
 	MaxHR      int     // Максимальный пульс (220 - возраст)
+
+	// VolumeFactor - поправка объёма по загруженным тренировкам клиента (1.0 - без поправки):
+	// множитель длительности LISS, раундов HIIT и беговых отрезков Hyrox
+	VolumeFactor float64
 }
 
 // NewCardioProgression создаёт новый калькулятор кардио прогрессии
@@ -17,10 +21,28 @@ func NewCardioProgression(goal, experience string, age int) *CardioProgression {
 	}
 
 	return &CardioProgression{
-		Goal:       goal,
-		Experience: experience,
-		MaxHR:      maxHR,
+		Goal:         goal,
+		Experience:   experience,
+		MaxHR:        maxHR,
+		VolumeFactor: 1.0,
+	}
+}
+
+// WithVolumeFactor задаёт поправку объёма; 0 - поправок ещё не было
+func (cp *CardioProgression) WithVolumeFactor(factor float64) *CardioProgression {
+	if factor > 0 {
+		cp.VolumeFactor = factor
+	}
+	return cp
+}
+
+// scaleCount масштабирует число раундов/отрезков по поправке объёма, не меньше minimum
+func (cp *CardioProgression) scaleCount(n, minimum int) int {
+	scaled := int(math.Round(float64(n) * cp.VolumeFactor))
+	if scaled < minimum {
+		scaled = minimum
 	}
+	return scaled
 }
 
 // CardioParams - параметры кардио упражнения
@@ -68,6 +90,9 @@ func (cp *CardioProgression) GetLISSParams(weekNum int) CardioParams {
 	durationBonus := ((weekNum - 1) / 2) * 5 * 60
 	duration := baseDuration + durationBonus
 
+	// Поправка по выполненным тренировкам, с точностью до минуты
+	duration = int(math.Round(float64(duration)*cp.VolumeFactor/60)) * 60
+
 	// Максимум 60 минут
 	if duration > 60*60 {
 		duration = 60 * 60
@@ -106,7 +131,7 @@ func (cp *CardioProgression) GetHIITParams(weekNum int) CardioParams {
 
 	// Прогрессия: добавляем раунды
 	roundsBonus := (weekNum - 1) / 3
-	rounds += roundsBonus
+	rounds = cp.scaleCount(rounds+roundsBonus, 4)
 	if rounds > 15 {
 		rounds = 15
 	}
@@ -228,7 +253,7 @@ func (cp *CardioProgression) GetHyroxRunParams(weekNum int, phase string) Cardio
 	return CardioParams{
 		Mode:        "distance",
 		Value:       standardDistance * distancePct,
-		Sets:        sets,
+		Sets:        cp.scaleCount(sets, 2),
 		RestSeconds: getRunRestForPhase(phase),
 		Pace:        getPaceForPhase(phase),
 		Notes:       notes,
@@ -293,19 +318,23 @@ func getRunRestForPhase(phase string) int {
 	}
 }
 
+// HRZoneNames - пульсовые зоны по возрастанию
+var HRZoneNames = []string{"recovery", "fat_burn", "aerobic", "threshold", "anaerobic"}
+
+// hrZones - границы зон в долях от максимального пульса
+var hrZones = map[string][2]float64{
+	"recovery":  {0.50, 0.60},
+	"fat_burn":  {0.60, 0.70},
+	"aerobic":   {0.70, 0.80},
+	"threshold": {0.80, 0.90},
+	"anaerobic": {0.90, 1.00},
+}
+
 // GetHRZone возвращает зону пульса
 func (cp *CardioProgression) GetHRZone(zoneName string) (int, int) {
-	zones := map[string][2]float64{
-		"recovery":  {0.50, 0.60},
-		"fat_burn":  {0.60, 0.70},
-		"aerobic":   {0.70, 0.80},
-		"threshold": {0.80, 0.90},
-		"anaerobic": {0.90, 1.00},
-	}
-
-	zone, ok := zones[zoneName]
+	zone, ok := hrZones[zoneName]
 	if !ok {
-		zone = zones["aerobic"]
+		zone = hrZones["aerobic"]
 	}
 
 	return int(float64(cp.MaxHR) * zone[0]), int(float64(cp.MaxHR) * zone[1])
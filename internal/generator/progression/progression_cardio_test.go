@@ -0,0 +1,35 @@
+package progression
+
+import "testing"
+
+func TestCardioVolumeFactor(t *testing.T) {
+	base := NewCardioProgression("fat_loss", "intermediate", 30)
+	adjusted := NewCardioProgression("fat_loss", "intermediate", 30).WithVolumeFactor(1.2)
+
+	// LISS: 30 мин × 1.2 = 36 мин
+	if got := adjusted.GetLISSParams(1).Value; got != 36*60 {
+		t.Errorf("LISS с поправкой 1.2 = %v с, want %d", got, 36*60)
+	}
+	if got := base.GetLISSParams(1).Value; got != 30*60 {
+		t.Errorf("LISS без поправки = %v с, want %d", got, 30*60)
+	}
+
+	// HIIT: 8 раундов × 1.2 ≈ 10
+	if got := adjusted.GetHIITParams(1).Sets; got != 10 {
+		t.Errorf("HIIT с поправкой 1.2 = %d раундов, want 10", got)
+	}
+	// Раундов не меньше 4 при любой поправке
+	if got := NewCardioProgression("fat_loss", "beginner", 30).WithVolumeFactor(0.5).GetHIITParams(1).Sets; got != 4 {
+		t.Errorf("HIIT с поправкой 0.5 = %d раундов, want 4", got)
+	}
+
+	// Hyrox: 8 отрезков × 0.7 ≈ 6
+	if got := NewCardioProgression("hyrox", "advanced", 30).WithVolumeFactor(0.7).GetHyroxRunParams(1, "specific").Sets; got != 6 {
+		t.Errorf("Hyrox specific с поправкой 0.7 = %d отрезков, want 6", got)
+	}
+
+	// Нулевая поправка — поправок ещё не было
+	if f := NewCardioProgression("hyrox", "advanced", 30).WithVolumeFactor(0).VolumeFactor; f != 1 {
+		t.Errorf("WithVolumeFactor(0) = %v, want 1", f)
+	}
+}
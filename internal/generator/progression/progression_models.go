@@ -1,6 +1,10 @@
 package progression
 
-import "math"
+import (
+	"math"
+
+	"workbot/internal/training"
+)
 
 // ProgressionModel тип модели прогрессии
 type ProgressionModel string
@@ -205,6 +209,9 @@ func (p *AdvancedWeightProgression) GetParams(weekNum, totalWeeks int, dayIntens
 	case ProgressionStepLoading:
 		intensity, reps, sets, rpe = p.calculateStepLoading(weekNum, isDeload)
 
+	case ProgressionRPEBased:
+		intensity, reps, sets, rpe = p.calculateRPEBased(weekNum, isDeload, dayIntensity)
+
 	default:
 		intensity = p.config.StartIntensity
 		reps = 8
@@ -212,8 +219,10 @@ func (p *AdvancedWeightProgression) GetParams(weekNum, totalWeeks int, dayIntens
 		rpe = 7.5
 	}
 
-	// Корректируем по типу дня (H/M/L)
-	intensity = p.adjustForDayIntensity(intensity, dayIntensity)
+	// Корректируем по типу дня (H/M/L); RPE-модель учитывает день в самом RPE
+	if p.config.Model != ProgressionRPEBased {
+		intensity = p.adjustForDayIntensity(intensity, dayIntensity)
+	}
 
 	return ProgressionParams{
 		Intensity:   intensity,
@@ -357,6 +366,38 @@ func (p *AdvancedWeightProgression) calculateStepLoading(weekNum int, isDeload b
 	return
 }
 
+// calculateRPEBased RPE-прогрессия: повторы постоянны, целевой RPE растёт
+// внутри 3-недельной волны (7.5→8→8.5) и от волны к волне, но не выше 9.
+// Вес — по таблице RPE от OnePM, для этой модели это скользящий e1RM клиента
+// (training.RollingE1RM), поэтому нагрузка следует за фактической формой
+func (p *AdvancedWeightProgression) calculateRPEBased(weekNum int, isDeload bool, dayType DayIntensity) (intensity float64, reps, sets int, rpe float64) {
+	reps = getRepsForIntensity(p.config.StartIntensity)
+	if reps > training.RPEChartMaxReps {
+		reps = training.RPEChartMaxReps
+	}
+
+	if isDeload {
+		rpe = 6.0
+		return training.RPEPercent(reps, rpe), reps, 3, rpe
+	}
+
+	wavePosition := (weekNum - 1) % 3
+	waveNumber := (weekNum - 1) / 3
+	rpe = 7.5 + float64(wavePosition)*0.5 + float64(waveNumber)*0.5
+
+	switch dayType {
+	case DayHeavy:
+		rpe += 0.5
+	case DayLight:
+		rpe -= 1.0
+	}
+	rpe = math.Max(training.RPEChartMinRPE, math.Min(rpe, 9.0))
+
+	intensity = training.RPEPercent(reps, rpe)
+	sets = getSetsForStrength(intensity)
+	return
+}
+
 // adjustForDayIntensity корректирует интенсивность по типу дня
 func (p *AdvancedWeightProgression) adjustForDayIntensity(intensity float64, dayType DayIntensity) float64 {
 	switch dayType {
@@ -1483,3 +1524,95 @@ func (wc *WorkCapacity) IncreaseCapacity() {
 func (wc *WorkCapacity) DecreaseCapacity() {
 	wc.AdaptationFactor = math.Max(wc.AdaptationFactor-0.05, 0.7) // мин -30%
 }
+
+// NewClientWorkCapacity создаёт work capacity с сохранённым фактором адаптации клиента
+func NewClientWorkCapacity(muscleGroup string, factor float64) *WorkCapacity {
+	wc := NewWorkCapacity(muscleGroup)
+	if factor > 0 {
+		wc.AdaptationFactor = factor
+		wc.refreshLandmarks()
+	}
+	return wc
+}
+
+// refreshLandmarks пересчитывает текущие MEV/MAV/MRV по фактору адаптации
+func (wc *WorkCapacity) refreshLandmarks() {
+	if VolumeLandmarks[wc.MuscleGroup] == nil {
+		return
+	}
+	wc.CurrentMEV = wc.GetAdaptedVolume(VolumeMEV)
+	wc.CurrentMAV = wc.GetAdaptedVolume(VolumeMAV)
+	wc.CurrentMRV = wc.GetAdaptedVolume(VolumeMRV)
+}
+
+// BlockOutcome итоги мезоцикла по мышечной группе
+type BlockOutcome struct {
+	WeeklySets     float64 // Рабочих подходов в неделю (среднее за блок)
+	SessionRPE     float64 // Средний RPE тренировок, 0 — нет данных
+	Soreness       float64 // Средняя мышечная боль 1–5, 0 — нет данных
+	Performance    float64 // Изменение e1RM упражнений группы за блок, %
+	HasPerformance bool    // Было с чем сравнить результаты
+}
+
+// AdaptationReason почему изменились (или нет) ориентиры объёма
+type AdaptationReason string
+
+const (
+	AdaptProgress   AdaptationReason = "progress"   // Прогресс при хорошем восстановлении → объём выше
+	AdaptFatigue    AdaptationReason = "fatigue"    // Высокий RPE или боль → объём ниже
+	AdaptRegression AdaptationReason = "regression" // Результаты упали → объём ниже
+	AdaptStable     AdaptationReason = "stable"     // Объём подходит, без изменений
+	AdaptLowVolume  AdaptationReason = "low_volume" // Сделано меньше MEV — выводы делать рано
+)
+
+// Пороги адаптации объёма
+const (
+	adaptMinVolumeShare = 0.75 // Доля MEV, ниже которой блок не оценивается
+	adaptHighVolume     = 0.8  // Доля MAV, после которой прогресс поднимает объём
+	adaptProgressPct    = 1.0  // Рост e1RM за блок, %
+	adaptRegressionPct  = -2.5 // Падение e1RM за блок, %
+	adaptHighRPE        = 9.0
+	adaptLowRPE         = 8.0
+	adaptHighSoreness   = 3.5
+	adaptLowSoreness    = 2.5
+)
+
+// Adapt меняет фактор адаптации по итогам блока и возвращает причину.
+// Объём снижается при падении результатов или высокой усталости; растёт,
+// если клиент прогрессировал на объёме не ниже 80% MAV и хорошо восстанавливался
+func (wc *WorkCapacity) Adapt(o BlockOutcome) AdaptationReason {
+	mev := float64(wc.GetAdaptedVolume(VolumeMEV))
+	if o.WeeklySets <= 0 || o.WeeklySets < mev*adaptMinVolumeShare {
+		return AdaptLowVolume
+	}
+
+	reason := AdaptStable
+	switch {
+	case o.HasPerformance && o.Performance <= adaptRegressionPct:
+		wc.DecreaseCapacity()
+		reason = AdaptRegression
+	case o.SessionRPE >= adaptHighRPE || o.Soreness >= adaptHighSoreness:
+		wc.DecreaseCapacity()
+		reason = AdaptFatigue
+	case o.HasPerformance && o.Performance >= adaptProgressPct &&
+		o.WeeklySets >= float64(wc.GetAdaptedVolume(VolumeMAV))*adaptHighVolume &&
+		(o.SessionRPE == 0 || o.SessionRPE <= adaptLowRPE) &&
+		(o.Soreness == 0 || o.Soreness <= adaptLowSoreness):
+		wc.IncreaseCapacity()
+		reason = AdaptProgress
+	}
+	wc.refreshLandmarks()
+	return reason
+}
+
+// ScaleSets масштабирует подходы упражнения фактором адаптации клиента (не меньше 2)
+func ScaleSets(sets int, factor float64) int {
+	if factor <= 0 || factor == 1 {
+		return sets
+	}
+	scaled := int(math.Round(float64(sets) * factor))
+	if scaled < 2 {
+		scaled = 2
+	}
+	return scaled
+}
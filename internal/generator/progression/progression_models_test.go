@@ -0,0 +1,61 @@
+package progression
+
+import "testing"
+
+func TestWorkCapacityAdapt(t *testing.T) {
+	tests := []struct {
+		name    string
+		outcome BlockOutcome
+		reason  AdaptationReason
+		factor  float64
+	}{
+		{"progress on high volume", BlockOutcome{WeeklySets: 14, SessionRPE: 7.5, Soreness: 2, Performance: 3, HasPerformance: true}, AdaptProgress, 1.05},
+		{"progress on low volume", BlockOutcome{WeeklySets: 10, SessionRPE: 7.5, Performance: 3, HasPerformance: true}, AdaptStable, 1.0},
+		{"regression", BlockOutcome{WeeklySets: 14, Performance: -4, HasPerformance: true}, AdaptRegression, 0.95},
+		{"high soreness", BlockOutcome{WeeklySets: 14, Soreness: 4, Performance: 2, HasPerformance: true}, AdaptFatigue, 0.95},
+		{"high session RPE", BlockOutcome{WeeklySets: 14, SessionRPE: 9.5}, AdaptFatigue, 0.95},
+		{"below MEV", BlockOutcome{WeeklySets: 5, Soreness: 5}, AdaptLowVolume, 1.0},
+		{"no performance data", BlockOutcome{WeeklySets: 16, SessionRPE: 7}, AdaptStable, 1.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wc := NewWorkCapacity("chest") // MEV 10, MAV 14, MRV 20
+			if got := wc.Adapt(tt.outcome); got != tt.reason {
+				t.Errorf("Adapt = %q, want %q", got, tt.reason)
+			}
+			if diff := wc.AdaptationFactor - tt.factor; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("AdaptationFactor = %v, want %v", wc.AdaptationFactor, tt.factor)
+			}
+		})
+	}
+}
+
+func TestNewClientWorkCapacity(t *testing.T) {
+	wc := NewClientWorkCapacity("back", 1.1) // 10/16/22 по таблице
+	if wc.CurrentMEV != 11 || wc.CurrentMAV != 18 || wc.CurrentMRV != 24 {
+		t.Errorf("landmarks = %d/%d/%d, want 11/18/24", wc.CurrentMEV, wc.CurrentMAV, wc.CurrentMRV)
+	}
+	if wc := NewClientWorkCapacity("back", 0); wc.AdaptationFactor != 1 || wc.CurrentMRV != 22 {
+		t.Errorf("without factor = %+v, want table values", wc)
+	}
+}
+
+func TestScaleSets(t *testing.T) {
+	tests := []struct {
+		sets   int
+		factor float64
+		want   int
+	}{
+		{4, 0, 4},
+		{4, 1, 4},
+		{4, 1.25, 5},
+		{3, 0.7, 2},
+		{2, 0.7, 2},
+	}
+	for _, tt := range tests {
+		if got := ScaleSets(tt.sets, tt.factor); got != tt.want {
+			t.Errorf("ScaleSets(%d, %v) = %d, want %d", tt.sets, tt.factor, got, tt.want)
+		}
+	}
+}
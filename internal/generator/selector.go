@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"workbot/internal/models"
@@ -82,6 +83,7 @@ type SelectionCriteria struct {
 	MaxDifficulty    models.DifficultyLevel  // Максимальная сложность
 	RequireCompound  bool                    // Требуется базовое упражнение
 	ExcludeIDs       []string                // Исключить эти упражнения
+	ExcludeEquipment []models.EquipmentType  // Занятое оборудование: упражнения с ним не подходят
 }
 
 // SelectionResult - результат подбора
@@ -215,6 +217,15 @@ func (s *ExerciseSelector) exercisePassesFilters(ex *models.ExerciseExt, criteri
 		}
 	}
 
+	// Занятое оборудование исключает упражнение, даже если остальное доступно
+	for _, reqEquip := range ex.Equipment {
+		for _, busyEquip := range criteria.ExcludeEquipment {
+			if reqEquip == busyEquip {
+				return false
+			}
+		}
+	}
+
 	// Проверка сложности
 	if criteria.MaxDifficulty > 0 && ex.Difficulty > criteria.MaxDifficulty {
 		return false
@@ -421,6 +432,110 @@ func (s *ExerciseSelector) GetExercisesByEquipment(equipment models.EquipmentTyp
 	return result
 }
 
+// FindExerciseByName ищет упражнение по русскому или английскому названию
+func (s *ExerciseSelector) FindExerciseByName(name string) *models.ExerciseExt {
+	needle := NormalizeExerciseName(name)
+	if needle == "" {
+		return nil
+	}
+
+	for i := range s.exercises {
+		if NormalizeExerciseName(s.exercises[i].NameRu) == needle ||
+			NormalizeExerciseName(s.exercises[i].NameEn) == needle {
+			return &s.exercises[i]
+		}
+	}
+
+	// Частичное совпадение (например, "Жим лёжа (пауза)")
+	for i := range s.exercises {
+		nameRu := NormalizeExerciseName(s.exercises[i].NameRu)
+		if nameRu != "" && strings.HasPrefix(needle, nameRu) {
+			return &s.exercises[i]
+		}
+	}
+	return nil
+}
+
+// FindSubstitutes подбирает замены упражнению во время тренировки.
+// Кандидаты должны совпадать по типу движения и нагружать хотя бы одну
+// из основных мышц оригинала; фильтры по оборудованию и ограничениям
+// берутся из criteria. Альтернативы из базы идут первыми.
+func (s *ExerciseSelector) FindSubstitutes(original *models.ExerciseExt, criteria SelectionCriteria, limit int) []models.ExerciseExt {
+	if original == nil {
+		return nil
+	}
+
+	criteria.MovementType = original.MovementType
+	criteria.PrimaryMuscle = ""
+	criteria.ExcludeIDs = append(criteria.ExcludeIDs, original.ID)
+
+	type candidate struct {
+		exercise models.ExerciseExt
+		score    int
+	}
+
+	// Приоритет из таблицы альтернатив: 1 = лучшая замена
+	altPriority := make(map[string]int)
+	for _, alt := range s.alternatives[original.ID] {
+		altPriority[alt.AlternativeID] = alt.Priority
+	}
+
+	var candidates []candidate
+	for i := range s.exercises {
+		ex := &s.exercises[i]
+		if !s.exercisePassesFilters(ex, criteria) {
+			continue
+		}
+		if !sharesPrimaryMuscle(ex, original) {
+			continue
+		}
+
+		score := s.scoreExercise(ex, criteria)
+		if p, ok := altPriority[ex.ID]; ok {
+			score += 20 - p
+		}
+		if ex.Pattern == original.Pattern {
+			score += 2
+		}
+		if ex.IsCompound == original.IsCompound {
+			score += 2
+		}
+		candidates = append(candidates, candidate{exercise: *ex, score: score})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	result := make([]models.ExerciseExt, 0, len(candidates))
+	for _, c := range candidates {
+		result = append(result, c.exercise)
+	}
+	return result
+}
+
+// sharesPrimaryMuscle проверяет пересечение основных мышц
+func sharesPrimaryMuscle(a, b *models.ExerciseExt) bool {
+	for _, m1 := range a.PrimaryMuscles {
+		for _, m2 := range b.PrimaryMuscles {
+			if m1 == m2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NormalizeExerciseName приводит название к виду для сравнения (регистр, «ё»)
+func NormalizeExerciseName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.ReplaceAll(name, "ё", "е")
+}
+
 // ===============================================
 // БАЛАНС-AWARE СЕЛЕКТОР УПРАЖНЕНИЙ
 // ===============================================
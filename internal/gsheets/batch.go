@@ -0,0 +1,183 @@
+package gsheets
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// Write — набор изменений одной таблицы, который отправляется минимальным
+// числом запросов: все диапазоны — одним values.batchUpdate, строки в конец
+// листа — одним values.append на диапазон, оформление — одним batchUpdate.
+// Write сериализуется в JSON и хранится в очереди, если Google недоступен
+type Write struct {
+	SpreadsheetID string               `json:"spreadsheet_id"`
+	Updates       []*sheets.ValueRange `json:"updates,omitempty"` // запись значений по диапазонам
+	Appends       []*sheets.ValueRange `json:"appends,omitempty"` // добавление строк после последней заполненной
+	Formats       []*sheets.Request    `json:"formats,omitempty"` // оформление
+}
+
+// Empty сообщает, что изменений нет
+func (w *Write) Empty() bool {
+	return len(w.Updates) == 0 && len(w.Appends) == 0 && len(w.Formats) == 0
+}
+
+// update добавляет запись строк на лист, начиная со строки startRow
+func (w *Write) update(sheetName string, startRow int, values [][]interface{}) {
+	w.Updates = append(w.Updates, &sheets.ValueRange{
+		Range:  fmt.Sprintf("%s!A%d", sheetName, startRow),
+		Values: values,
+	})
+}
+
+// append добавляет строки в конец листа
+func (w *Write) append(sheetName string, values [][]interface{}) {
+	w.Appends = append(w.Appends, &sheets.ValueRange{
+		Range:  sheetName + "!A1",
+		Values: values,
+	})
+}
+
+// formatHeaders добавляет оформление первой строки листа (жирный шрифт, цвет фона)
+func (w *Write) formatHeaders(sheetIndex int64) {
+	w.Formats = append(w.Formats, &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Range: &sheets.GridRange{
+				SheetId:          sheetIndex,
+				StartRowIndex:    0,
+				EndRowIndex:      1,
+				StartColumnIndex: 0,
+				EndColumnIndex:   10,
+			},
+			Cell: &sheets.CellData{
+				UserEnteredFormat: &sheets.CellFormat{
+					BackgroundColor: &sheets.Color{
+						Red:   0.2,
+						Green: 0.4,
+						Blue:  0.8,
+					},
+					TextFormat: &sheets.TextFormat{
+						Bold: true,
+						ForegroundColor: &sheets.Color{
+							Red:   1,
+							Green: 1,
+							Blue:  1,
+						},
+					},
+				},
+			},
+			Fields: "userEnteredFormat(backgroundColor,textFormat)",
+		},
+	})
+}
+
+// Merge объединяет изменения одних и тех же таблиц, сохраняя порядок.
+// Строки, добавляемые в один и тот же диапазон, склеиваются в одно добавление
+func Merge(writes []Write) []Write {
+	var merged []Write
+	index := make(map[string]int)
+
+	for _, w := range writes {
+		i, ok := index[w.SpreadsheetID]
+		if !ok {
+			index[w.SpreadsheetID] = len(merged)
+			merged = append(merged, Write{SpreadsheetID: w.SpreadsheetID})
+			i = len(merged) - 1
+		}
+		m := &merged[i]
+		m.Updates = append(m.Updates, w.Updates...)
+		m.Formats = append(m.Formats, w.Formats...)
+
+		for _, a := range w.Appends {
+			joined := false
+			for _, existing := range m.Appends {
+				if existing.Range == a.Range {
+					existing.Values = append(existing.Values, a.Values...)
+					joined = true
+					break
+				}
+			}
+			if !joined {
+				values := append([][]interface{}(nil), a.Values...)
+				m.Appends = append(m.Appends, &sheets.ValueRange{Range: a.Range, Values: values})
+			}
+		}
+	}
+	return merged
+}
+
+// Apply отправляет изменения в таблицу с повторами при временных ошибках
+func (c *Client) Apply(ctx context.Context, w Write) error {
+	_, err := c.apply(ctx, w)
+	return err
+}
+
+// apply отправляет изменения и при ошибке возвращает ещё не отправленную часть,
+// чтобы повтор из очереди не добавил одни и те же строки дважды
+func (c *Client) apply(ctx context.Context, w Write) (Write, error) {
+	if len(w.Updates) > 0 {
+		req := &sheets.BatchUpdateValuesRequest{
+			ValueInputOption: "USER_ENTERED",
+			Data:             w.Updates,
+		}
+		err := c.call(ctx, "запись значений", func() error {
+			_, err := c.sheets.Spreadsheets.Values.BatchUpdate(w.SpreadsheetID, req).Context(ctx).Do()
+			return err
+		})
+		if err != nil {
+			return w, fmt.Errorf("ошибка записи значений: %w", err)
+		}
+		w.Updates = nil
+	}
+
+	for len(w.Appends) > 0 {
+		a := w.Appends[0]
+		err := c.call(ctx, "добавление строк", func() error {
+			_, err := c.sheets.Spreadsheets.Values.Append(w.SpreadsheetID, a.Range, &sheets.ValueRange{Values: a.Values}).
+				ValueInputOption("USER_ENTERED").
+				Context(ctx).
+				Do()
+			return err
+		})
+		if err != nil {
+			return w, fmt.Errorf("ошибка добавления строк: %w", err)
+		}
+		w.Appends = w.Appends[1:]
+	}
+
+	if len(w.Formats) > 0 {
+		req := &sheets.BatchUpdateSpreadsheetRequest{Requests: w.Formats}
+		err := c.call(ctx, "оформление", func() error {
+			_, err := c.sheets.Spreadsheets.BatchUpdate(w.SpreadsheetID, req).Context(ctx).Do()
+			return err
+		})
+		if err != nil {
+			// Оформление не критично: данные уже записаны
+			log.Printf("Ошибка форматирования: %v", err)
+		}
+		w.Formats = nil
+	}
+	return w, nil
+}
+
+// applyOrDefer отправляет изменения, а если Google недоступен — откладывает их в очередь.
+// Без очереди ошибка возвращается вызывающему
+func (c *Client) applyOrDefer(ctx context.Context, w Write) error {
+	remaining, err := c.apply(ctx, w)
+	if err == nil {
+		if c.queue != nil {
+			c.queue.markSynced(w.SpreadsheetID)
+		}
+		return nil
+	}
+	if c.queue == nil || !IsRetryable(err) {
+		return err
+	}
+	if qErr := c.queue.Enqueue(remaining, err); qErr != nil {
+		return fmt.Errorf("%v; не удалось отложить запись: %w", err, qErr)
+	}
+	log.Printf("Google Sheets недоступен, запись в %s отложена: %v", w.SpreadsheetID, err)
+	return nil
+}
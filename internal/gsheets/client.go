@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"time"
 
@@ -17,9 +18,23 @@ import (
 
 // Client клиент для работы с Google Sheets
 type Client struct {
-	sheets   *sheets.Service
-	drive    *drive.Service
-	folderID string
+	sheets     *sheets.Service
+	drive      *drive.Service
+	folderID   string
+	queue      *Queue // очередь отложенных записей; nil — ошибки возвращаются сразу
+	maxRetries int
+	sleep      func(ctx context.Context, d time.Duration) error
+}
+
+// newClient создаёт клиент поверх готовых сервисов Sheets и Drive
+func newClient(sheetsSrv *sheets.Service, driveSrv *drive.Service, folderID string) *Client {
+	return &Client{
+		sheets:     sheetsSrv,
+		drive:      driveSrv,
+		folderID:   folderID,
+		maxRetries: defaultMaxRetries,
+		sleep:      sleep,
+	}
 }
 
 // NewClient создаёт новый клиент Google Sheets (Service Account)
@@ -55,27 +70,45 @@ func NewClient(credentialsPath, folderID string) (*Client, error) {
 		return nil, fmt.Errorf("ошибка создания Drive сервиса: %w", err)
 	}
 
-	return &Client{
-		sheets:   sheetsSrv,
-		drive:    driveSrv,
-		folderID: folderID,
-	}, nil
+	return newClient(sheetsSrv, driveSrv, folderID), nil
 }
 
 // NewOAuthClient создаёт клиент Google Sheets через OAuth2
 func NewOAuthClient(oauthCredPath, tokenPath, folderID string) (*Client, error) {
 	ctx := context.Background()
 
+	httpClient, err := OAuthHTTPClient(ctx, oauthCredPath, tokenPath, sheets.SpreadsheetsScope, drive.DriveScope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Создаём сервисы
+	sheetsSrv, err := sheets.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания Sheets сервиса: %w", err)
+	}
+
+	driveSrv, err := drive.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания Drive сервиса: %w", err)
+	}
+
+	log.Println("Google Sheets OAuth2 клиент инициализирован")
+
+	return newClient(sheetsSrv, driveSrv, folderID), nil
+}
+
+// OAuthHTTPClient возвращает HTTP клиент Google API по OAuth credentials и токену,
+// сохранённому cmd/oauth_setup. Токен обновляется автоматически. Его же использует
+// Google Calendar: scopes задаются при получении токена, здесь они только для конфигурации
+func OAuthHTTPClient(ctx context.Context, oauthCredPath, tokenPath string, scopes ...string) (*http.Client, error) {
 	// Читаем OAuth credentials
 	credData, err := os.ReadFile(oauthCredPath)
 	if err != nil {
 		return nil, fmt.Errorf("не удалось прочитать OAuth credentials: %w", err)
 	}
 
-	oauthConfig, err := google.ConfigFromJSON(credData,
-		sheets.SpreadsheetsScope,
-		drive.DriveScope,
-	)
+	oauthConfig, err := google.ConfigFromJSON(credData, scopes...)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка парсинга OAuth config: %w", err)
 	}
@@ -104,24 +137,7 @@ func NewOAuthClient(oauthCredPath, tokenPath, folderID string) (*Client, error)
 		}
 	}
 
-	// Создаём сервисы
-	sheetsSrv, err := sheets.NewService(ctx, option.WithHTTPClient(httpClient))
-	if err != nil {
-		return nil, fmt.Errorf("ошибка создания Sheets сервиса: %w", err)
-	}
-
-	driveSrv, err := drive.NewService(ctx, option.WithHTTPClient(httpClient))
-	if err != nil {
-		return nil, fmt.Errorf("ошибка создания Drive сервиса: %w", err)
-	}
-
-	log.Println("Google Sheets OAuth2 клиент инициализирован")
-
-	return &Client{
-		sheets:   sheetsSrv,
-		drive:    driveSrv,
-		folderID: folderID,
-	}, nil
+	return httpClient, nil
 }
 
 // saveToken сохраняет токен в файл
@@ -167,36 +183,18 @@ func (c *Client) CreateClientSpreadsheet(clientID int, name, surname string) (st
 		},
 	}
 
-	created, err := c.sheets.Spreadsheets.Create(spreadsheet).Context(ctx).Do()
+	spreadsheetID, err := c.createSpreadsheet(ctx, spreadsheet)
 	if err != nil {
-		return "", fmt.Errorf("ошибка создания таблицы: %w", err)
-	}
-
-	spreadsheetID := created.SpreadsheetId
-
-	// Перемещаем в папку WorkBot (если указана)
-	if c.folderID != "" {
-		_, err = c.drive.Files.Update(spreadsheetID, nil).
-			AddParents(c.folderID).
-			Context(ctx).
-			Do()
-		if err != nil {
-			log.Printf("Предупреждение: не удалось переместить таблицу в папку: %v", err)
-			// Не возвращаем ошибку — таблица создана, просто не в папке
-		}
+		return "", err
 	}
 
 	// Добавляем заголовки на лист "Тренировки"
 	headers := []interface{}{
 		"Дата", "№ тренировки", "Упражнение", "Подходы", "Повторы", "Вес (кг)", "Тоннаж", "Заметки",
 	}
-	err = c.writeRow(spreadsheetID, "Тренировки", 1, headers)
-	if err != nil {
-		log.Printf("Ошибка записи заголовков: %v", err)
-	}
-
-	// Форматируем заголовки
-	c.formatHeaders(spreadsheetID, 0)
+	w := Write{SpreadsheetID: spreadsheetID}
+	w.update("Тренировки", 1, [][]interface{}{headers})
+	w.formatHeaders(0)
 
 	// Добавляем поля анкеты
 	anketaFields := [][]interface{}{
@@ -211,8 +209,13 @@ func (c *Client) CreateClientSpreadsheet(clientID int, name, surname string) (st
 		{"Травмы/ограничения", ""},
 		{"Примечания", ""},
 	}
-	c.writeRows(spreadsheetID, "Анкета", 1, anketaFields)
-	c.formatHeaders(spreadsheetID, 1)
+	w.update("Анкета", 1, anketaFields)
+	w.formatHeaders(1)
+
+	// Таблица уже создана: если заполнить не удалось, запись ждёт в очереди
+	if err := c.applyOrDefer(ctx, w); err != nil {
+		log.Printf("Ошибка заполнения таблицы клиента: %v", err)
+	}
 
 	log.Printf("Создана Google таблица для %s %s: %s", name, surname, spreadsheetID)
 	return spreadsheetID, nil
@@ -222,14 +225,6 @@ func (c *Client) CreateClientSpreadsheet(clientID int, name, surname string) (st
 func (c *Client) AddTraining(spreadsheetID string, trainingDate time.Time, trainingNum int, exercises []TrainingExercise) error {
 	ctx := context.Background()
 
-	// Получаем текущие данные чтобы найти последнюю строку
-	resp, err := c.sheets.Spreadsheets.Values.Get(spreadsheetID, "Тренировки!A:A").Context(ctx).Do()
-	if err != nil {
-		return fmt.Errorf("ошибка чтения таблицы: %w", err)
-	}
-
-	nextRow := len(resp.Values) + 1
-
 	// Формируем данные для записи
 	var values [][]interface{}
 	for i, ex := range exercises {
@@ -250,17 +245,10 @@ func (c *Client) AddTraining(spreadsheetID string, trainingDate time.Time, train
 		values = append(values, row)
 	}
 
-	// Записываем
-	writeRange := fmt.Sprintf("Тренировки!A%d", nextRow)
-	valueRange := &sheets.ValueRange{
-		Values: values,
-	}
-
-	_, err = c.sheets.Spreadsheets.Values.Append(spreadsheetID, writeRange, valueRange).
-		ValueInputOption("USER_ENTERED").
-		Context(ctx).
-		Do()
-	if err != nil {
+	// Строки добавляются после последней заполненной; при недоступности Google запись откладывается
+	w := Write{SpreadsheetID: spreadsheetID}
+	w.append("Тренировки", values)
+	if err := c.applyOrDefer(ctx, w); err != nil {
 		return fmt.Errorf("ошибка записи тренировки: %w", err)
 	}
 
@@ -277,78 +265,34 @@ type TrainingExercise struct {
 	Notes  string
 }
 
-// writeRow записывает одну строку
-func (c *Client) writeRow(spreadsheetID, sheetName string, row int, values []interface{}) error {
-	ctx := context.Background()
-	writeRange := fmt.Sprintf("%s!A%d", sheetName, row)
-	valueRange := &sheets.ValueRange{
-		Values: [][]interface{}{values},
-	}
-	_, err := c.sheets.Spreadsheets.Values.Update(spreadsheetID, writeRange, valueRange).
-		ValueInputOption("USER_ENTERED").
-		Context(ctx).
-		Do()
-	return err
-}
-
-// writeRows записывает несколько строк
-func (c *Client) writeRows(spreadsheetID, sheetName string, startRow int, values [][]interface{}) error {
-	ctx := context.Background()
-	writeRange := fmt.Sprintf("%s!A%d", sheetName, startRow)
-	valueRange := &sheets.ValueRange{
-		Values: values,
-	}
-	_, err := c.sheets.Spreadsheets.Values.Update(spreadsheetID, writeRange, valueRange).
-		ValueInputOption("USER_ENTERED").
-		Context(ctx).
-		Do()
-	return err
-}
-
-// formatHeaders форматирует заголовки (жирный шрифт, цвет фона)
-func (c *Client) formatHeaders(spreadsheetID string, sheetIndex int64) {
-	ctx := context.Background()
-
-	requests := []*sheets.Request{
-		{
-			RepeatCell: &sheets.RepeatCellRequest{
-				Range: &sheets.GridRange{
-					SheetId:          sheetIndex,
-					StartRowIndex:    0,
-					EndRowIndex:      1,
-					StartColumnIndex: 0,
-					EndColumnIndex:   10,
-				},
-				Cell: &sheets.CellData{
-					UserEnteredFormat: &sheets.CellFormat{
-						BackgroundColor: &sheets.Color{
-							Red:   0.2,
-							Green: 0.4,
-							Blue:  0.8,
-						},
-						TextFormat: &sheets.TextFormat{
-							Bold: true,
-							ForegroundColor: &sheets.Color{
-								Red:   1,
-								Green: 1,
-								Blue:  1,
-							},
-						},
-					},
-				},
-				Fields: "userEnteredFormat(backgroundColor,textFormat)",
-			},
-		},
+// createSpreadsheet создаёт таблицу и перемещает её в папку WorkBot (если указана)
+func (c *Client) createSpreadsheet(ctx context.Context, spreadsheet *sheets.Spreadsheet) (string, error) {
+	var created *sheets.Spreadsheet
+	err := c.call(ctx, "создание таблицы", func() error {
+		var err error
+		created, err = c.sheets.Spreadsheets.Create(spreadsheet).Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("ошибка создания таблицы: %w", err)
 	}
 
-	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
-		Requests: requests,
-	}
+	spreadsheetID := created.SpreadsheetId
 
-	_, err := c.sheets.Spreadsheets.BatchUpdate(spreadsheetID, batchUpdateRequest).Context(ctx).Do()
-	if err != nil {
-		log.Printf("Ошибка форматирования: %v", err)
+	if c.folderID != "" {
+		err = c.call(ctx, "перемещение в папку", func() error {
+			_, err := c.drive.Files.Update(spreadsheetID, nil).
+				AddParents(c.folderID).
+				Context(ctx).
+				Do()
+			return err
+		})
+		if err != nil {
+			log.Printf("Предупреждение: не удалось переместить таблицу в папку: %v", err)
+			// Не возвращаем ошибку — таблица создана, просто не в папке
+		}
 	}
+	return spreadsheetID, nil
 }
 
 // GetSpreadsheetURL возвращает URL таблицы
@@ -542,48 +486,42 @@ func (c *Client) CreateProgramSpreadsheet(program ProgramData) (string, error) {
 		Sheets:     sheetsList,
 	}
 
-	created, err := c.sheets.Spreadsheets.Create(spreadsheet).Context(ctx).Do()
+	spreadsheetID, err := c.createSpreadsheet(ctx, spreadsheet)
 	if err != nil {
-		return "", fmt.Errorf("ошибка создания таблицы: %w", err)
+		return "", err
 	}
 
-	spreadsheetID := created.SpreadsheetId
-
-	// Перемещаем в папку
-	if c.folderID != "" {
-		_, err = c.drive.Files.Update(spreadsheetID, nil).
-			AddParents(c.folderID).
-			Context(ctx).
-			Do()
-		if err != nil {
-			log.Printf("Предупреждение: не удалось переместить в папку: %v", err)
-		}
-	}
+	// Все листы заполняются одним набором запросов
+	w := Write{SpreadsheetID: spreadsheetID}
 
 	// Заполняем лист "Обзор"
-	c.fillOverviewSheet(spreadsheetID, config.OverviewSheet, program)
+	fillOverviewSheet(&w, config.OverviewSheet, program)
 
 	// Заполняем листы недель
 	for _, week := range program.Weeks {
 		sheetName := fmt.Sprintf("%s%d", config.WeekSheetPrefix, week.WeekNum)
-		c.fillWeekSheet(spreadsheetID, sheetName, week, program.OnePMData)
+		fillWeekSheet(&w, sheetName, week, program.OnePMData)
 	}
 
 	// Заполняем лист 1ПМ
-	c.fillOnePMSheet(spreadsheetID, config.OnePMSheet, program.OnePMData)
+	fillOnePMSheet(&w, config.OnePMSheet, program.OnePMData)
 
 	// Подготавливаем журнал
-	c.fillJournalSheet(spreadsheetID, config.JournalSheet, program)
+	fillJournalSheet(&w, config.JournalSheet, program)
 
 	// Заполняем справочник
-	c.fillReferenceSheet(spreadsheetID, config.ReferenceSheet)
+	fillReferenceSheet(&w, config.ReferenceSheet)
+
+	if err := c.applyOrDefer(ctx, w); err != nil {
+		return "", fmt.Errorf("ошибка заполнения таблицы: %w", err)
+	}
 
 	log.Printf("Создана программа в Google Sheets: %s", spreadsheetID)
 	return spreadsheetID, nil
 }
 
 // fillOverviewSheet заполняет лист обзора
-func (c *Client) fillOverviewSheet(spreadsheetID, sheetName string, program ProgramData) {
+func fillOverviewSheet(w *Write, sheetName string, program ProgramData) {
 	data := [][]interface{}{
 		{"ПРОГРАММА ТРЕНИРОВОК", "", "", ""},
 		{"", "", "", ""},
@@ -614,12 +552,12 @@ func (c *Client) fillOverviewSheet(spreadsheetID, sheetName string, program Prog
 		})
 	}
 
-	c.writeRows(spreadsheetID, sheetName, 1, data)
-	c.formatHeaders(spreadsheetID, 0)
+	w.update(sheetName, 1, data)
+	w.formatHeaders(0)
 }
 
 // fillWeekSheet заполняет лист недели
-func (c *Client) fillWeekSheet(spreadsheetID, sheetName string, week WeekData, onePMData map[string]float64) {
+func fillWeekSheet(w *Write, sheetName string, week WeekData, onePMData map[string]float64) {
 	// Заголовок недели
 	deloadMark := ""
 	if week.IsDeload {
@@ -696,11 +634,11 @@ func (c *Client) fillWeekSheet(spreadsheetID, sheetName string, week WeekData, o
 		data = append(data, []interface{}{"", "", "", "", "", "", "", "", "", "", "", ""})
 	}
 
-	c.writeRows(spreadsheetID, sheetName, 1, data)
+	w.update(sheetName, 1, data)
 }
 
 // fillOnePMSheet заполняет лист 1ПМ
-func (c *Client) fillOnePMSheet(spreadsheetID, sheetName string, onePMData map[string]float64) {
+func fillOnePMSheet(w *Write, sheetName string, onePMData map[string]float64) {
 	data := [][]interface{}{
 		{"ДАННЫЕ 1ПМ (Одноповторный максимум)", "", ""},
 		{"", "", ""},
@@ -711,11 +649,11 @@ func (c *Client) fillOnePMSheet(spreadsheetID, sheetName string, onePMData map[s
 		data = append(data, []interface{}{name, value, ""})
 	}
 
-	c.writeRows(spreadsheetID, sheetName, 1, data)
+	w.update(sheetName, 1, data)
 }
 
 // fillJournalSheet заполняет журнал выполнения
-func (c *Client) fillJournalSheet(spreadsheetID, sheetName string, program ProgramData) {
+func fillJournalSheet(w *Write, sheetName string, program ProgramData) {
 	data := [][]interface{}{
 		{"ЖУРНАЛ ТРЕНИРОВОК", "", "", "", "", "", "", "", ""},
 		{"", "", "", "", "", "", "", "", ""},
@@ -743,11 +681,11 @@ func (c *Client) fillJournalSheet(spreadsheetID, sheetName string, program Progr
 		}
 	}
 
-	c.writeRows(spreadsheetID, sheetName, 1, data)
+	w.update(sheetName, 1, data)
 }
 
 // fillReferenceSheet заполняет справочный лист с группами мышц и типами движений
-func (c *Client) fillReferenceSheet(spreadsheetID, sheetName string) {
+func fillReferenceSheet(w *Write, sheetName string) {
 	data := [][]interface{}{
 		{"СПРАВОЧНИК", "", "", ""},
 		{"", "", "", ""},
@@ -780,7 +718,7 @@ func (c *Client) fillReferenceSheet(spreadsheetID, sheetName string) {
 		data = append(data, row)
 	}
 
-	c.writeRows(spreadsheetID, sheetName, 1, data)
+	w.update(sheetName, 1, data)
 }
 
 // addDataValidation добавляет data validation для колонок группа мышц и тип движения
@@ -842,7 +780,10 @@ func (c *Client) addDataValidation(spreadsheetID string, sheetID int64, muscleGr
 	}
 
 	batchRequest := &sheets.BatchUpdateSpreadsheetRequest{Requests: requests}
-	_, err := c.sheets.Spreadsheets.BatchUpdate(spreadsheetID, batchRequest).Context(ctx).Do()
+	err := c.call(ctx, "валидация", func() error {
+		_, err := c.sheets.Spreadsheets.BatchUpdate(spreadsheetID, batchRequest).Context(ctx).Do()
+		return err
+	})
 	if err != nil {
 		log.Printf("Ошибка добавления валидации: %v", err)
 	}
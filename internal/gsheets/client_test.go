@@ -0,0 +1,210 @@
+package gsheets
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+func testExercises() []TrainingExercise {
+	return []TrainingExercise{
+		{Name: "Присед", Sets: 5, Reps: 5, Weight: 100},
+		{Name: "Жим лёжа", Sets: 3, Reps: 8, Weight: 70},
+	}
+}
+
+func TestCreateProgramSpreadsheetBatchesWrites(t *testing.T) {
+	fake := newFakeSheets()
+	c, _ := newTestClient(t, fake)
+
+	program := ProgramData{
+		ClientName:  "Иван Петров",
+		ProgramName: "Сила",
+		TotalWeeks:  2,
+		OnePMData:   map[string]float64{"Присед": 140},
+		Weeks: []WeekData{
+			{WeekNum: 1, Workouts: []WorkoutData{{DayNum: 1, Name: "A", Exercises: []ExerciseData{{Name: "Присед", Sets: 5, Reps: "5", WeightPercent: 75}}}}},
+			{WeekNum: 2, Workouts: []WorkoutData{{DayNum: 1, Name: "A", Exercises: []ExerciseData{{Name: "Присед", Sets: 5, Reps: "3", WeightPercent: 80}}}}},
+		},
+	}
+
+	id, err := c.CreateProgramSpreadsheet(program)
+	if err != nil {
+		t.Fatalf("CreateProgramSpreadsheet: %v", err)
+	}
+	if id != "sheet-1" {
+		t.Errorf("id = %q", id)
+	}
+
+	// Создание, одна запись всех листов и одно оформление
+	want := []string{
+		"POST /v4/spreadsheets",
+		"POST /v4/spreadsheets/sheet-1/values:batchUpdate",
+		"POST /v4/spreadsheets/sheet-1:batchUpdate",
+	}
+	if !reflect.DeepEqual(fake.requests, want) {
+		t.Errorf("requests = %v, want %v", fake.requests, want)
+	}
+	// Обзор, 2 недели, 1ПМ, журнал, справочник
+	if len(fake.updates) != 6 {
+		t.Errorf("values batch has %d ranges, want 6", len(fake.updates))
+	}
+	if _, ok := fake.updates["Неделя_2!A1"]; !ok {
+		t.Errorf("week 2 sheet not written: %v", fake.updates)
+	}
+}
+
+func TestAddTrainingRetriesOnQuotaAndServerErrors(t *testing.T) {
+	fake := newFakeSheets()
+	fake.failures = []int{429, 503}
+	c, slept := newTestClient(t, fake)
+
+	date := time.Date(2030, 5, 10, 0, 0, 0, 0, time.UTC)
+	if err := c.AddTraining("sheet-1", date, 3, testExercises()); err != nil {
+		t.Fatalf("AddTraining: %v", err)
+	}
+
+	if len(fake.requests) != 3 {
+		t.Errorf("requests = %v, want 3 attempts", fake.requests)
+	}
+	if want := []time.Duration{time.Second, 2 * time.Second}; !reflect.DeepEqual(*slept, want) {
+		t.Errorf("slept %v, want %v", *slept, want)
+	}
+	rows := fake.appended["Тренировки!A1"]
+	if len(rows) != 2 || rows[0][0] != "10.05.2030" || rows[1][2] != "Жим лёжа" {
+		t.Errorf("appended rows = %v", rows)
+	}
+}
+
+func TestRetryAfterHeaderIsRespected(t *testing.T) {
+	fake := newFakeSheets()
+	fake.failures = []int{503}
+	fake.retryAfter = "7"
+	c, slept := newTestClient(t, fake)
+
+	if err := c.AddTraining("sheet-1", time.Now(), 1, testExercises()); err != nil {
+		t.Fatalf("AddTraining: %v", err)
+	}
+	if want := []time.Duration{7 * time.Second}; !reflect.DeepEqual(*slept, want) {
+		t.Errorf("slept %v, want %v", *slept, want)
+	}
+}
+
+func TestNoRetryOnBadRequest(t *testing.T) {
+	fake := newFakeSheets()
+	fake.failures = []int{400}
+	c, slept := newTestClient(t, fake)
+
+	err := c.AddTraining("sheet-1", time.Now(), 1, testExercises())
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if IsRetryable(err) || len(fake.requests) != 1 || len(*slept) != 0 {
+		t.Errorf("err = %v, requests = %d, slept = %v; want single failed request", err, len(fake.requests), *slept)
+	}
+}
+
+func TestGivesUpAfterMaxRetries(t *testing.T) {
+	fake := newFakeSheets()
+	fake.failures = []int{429, 429, 429, 429, 429}
+	c, _ := newTestClient(t, fake)
+	c.maxRetries = 2
+
+	err := c.AddTraining("sheet-1", time.Now(), 1, testExercises())
+	if err == nil || !IsRetryable(err) {
+		t.Fatalf("err = %v, want retryable error (no queue configured)", err)
+	}
+	if len(fake.requests) != 3 {
+		t.Errorf("requests = %d, want 3", len(fake.requests))
+	}
+}
+
+func TestApplyReturnsUnsentRemainder(t *testing.T) {
+	fake := newFakeSheets()
+	c, _ := newTestClient(t, fake)
+	c.maxRetries = 0
+
+	w := Write{SpreadsheetID: "sheet-1"}
+	w.update("Обзор", 1, [][]interface{}{{"a"}})
+	w.append("Журнал", [][]interface{}{{"b"}})
+
+	// Значения записались, добавление строк упало — в остатке только добавление
+	fake.failSuffix = ":append"
+	remaining, err := c.apply(context.Background(), w)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if len(remaining.Updates) != 0 || len(remaining.Appends) != 1 {
+		t.Errorf("remaining = %+v, want only the append", remaining)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := Write{SpreadsheetID: "s1"}
+	a.append("Тренировки", [][]interface{}{{"row1"}})
+	a.update("Анкета", 1, [][]interface{}{{"x"}})
+	b := Write{SpreadsheetID: "s2"}
+	b.append("Тренировки", [][]interface{}{{"other"}})
+	c := Write{SpreadsheetID: "s1"}
+	c.append("Тренировки", [][]interface{}{{"row2"}, {"row3"}})
+	c.formatHeaders(0)
+
+	merged := Merge([]Write{a, b, c})
+	if len(merged) != 2 || merged[0].SpreadsheetID != "s1" || merged[1].SpreadsheetID != "s2" {
+		t.Fatalf("merged = %+v", merged)
+	}
+	s1 := merged[0]
+	if len(s1.Appends) != 1 || len(s1.Appends[0].Values) != 3 || s1.Appends[0].Values[2][0] != "row3" {
+		t.Errorf("appends not coalesced: %+v", s1.Appends)
+	}
+	if len(s1.Updates) != 1 || len(s1.Formats) != 1 {
+		t.Errorf("updates = %d, formats = %d; want 1 and 1", len(s1.Updates), len(s1.Formats))
+	}
+	if len(a.Appends[0].Values) != 1 {
+		t.Errorf("Merge modified its input: %v", a.Appends[0].Values)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"quota", &googleapi.Error{Code: 429}, true},
+		{"unavailable", &googleapi.Error{Code: 503}, true},
+		{"legacy rate limit", &googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}}, true},
+		{"forbidden", &googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "forbidden"}}}, false},
+		{"bad request", &googleapi.Error{Code: 400}, false},
+		{"network", &url.Error{Op: "Post", URL: "https://sheets.googleapis.com", Err: errors.New("connection refused")}, true},
+		{"other", errors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.want {
+			t.Errorf("%s: IsRetryable = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, time.Second},
+		{1, time.Second},
+		{3, 4 * time.Second},
+		{7, time.Minute},
+		{50, time.Minute},
+	}
+	for _, tt := range tests {
+		if got := backoff(tt.attempt); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
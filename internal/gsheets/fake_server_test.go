@@ -0,0 +1,119 @@
+package gsheets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+)
+
+// fakeSheets — локальный HTTP-сервер, отвечающий как Sheets API v4.
+// Первые запросы могут завершаться ошибками из failures
+type fakeSheets struct {
+	mu         sync.Mutex
+	requests   []string                   // "POST /v4/..." в порядке поступления
+	updates    map[string][][]interface{} // диапазон → значения из values:batchUpdate
+	appended   map[string][][]interface{} // диапазон → добавленные строки
+	formats    int                        // число запросов оформления
+	failures   []int                      // коды ответов для первых запросов
+	retryAfter string                     // заголовок Retry-After для ошибок
+	failSuffix string                     // запросы с таким окончанием пути всегда завершаются ошибкой 503
+}
+
+func newFakeSheets() *fakeSheets {
+	return &fakeSheets{
+		updates:  make(map[string][][]interface{}),
+		appended: make(map[string][][]interface{}),
+	}
+}
+
+func (f *fakeSheets) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := r.URL.Path
+	f.requests = append(f.requests, r.Method+" "+path)
+
+	if len(f.failures) > 0 || (f.failSuffix != "" && strings.HasSuffix(path, f.failSuffix)) {
+		code := http.StatusServiceUnavailable
+		if len(f.failures) > 0 {
+			code = f.failures[0]
+			f.failures = f.failures[1:]
+		}
+		if f.retryAfter != "" {
+			w.Header().Set("Retry-After", f.retryAfter)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{"code": code, "message": http.StatusText(code)},
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case path == "/v4/spreadsheets":
+		var req sheets.Spreadsheet
+		json.NewDecoder(r.Body).Decode(&req)
+		json.NewEncoder(w).Encode(sheets.Spreadsheet{SpreadsheetId: "sheet-1", Properties: req.Properties})
+
+	case strings.HasSuffix(path, "/values:batchUpdate"):
+		var req sheets.BatchUpdateValuesRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		for _, vr := range req.Data {
+			f.updates[vr.Range] = vr.Values
+		}
+		w.Write([]byte("{}"))
+
+	case strings.HasSuffix(path, ":append"):
+		var req sheets.ValueRange
+		json.NewDecoder(r.Body).Decode(&req)
+		rng := path[strings.LastIndex(path, "/values/")+len("/values/") : len(path)-len(":append")]
+		f.appended[rng] = append(f.appended[rng], req.Values...)
+		w.Write([]byte("{}"))
+
+	case strings.HasSuffix(path, ":batchUpdate"):
+		var req sheets.BatchUpdateSpreadsheetRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		f.formats += len(req.Requests)
+		w.Write([]byte("{}"))
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// newTestClient создаёт клиент, работающий с fake-сервером; паузы между повторами записываются в slept
+func newTestClient(t *testing.T, fake *fakeSheets) (*Client, *[]time.Duration) {
+	t.Helper()
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	ctx := context.Background()
+	opts := []option.ClientOption{option.WithEndpoint(srv.URL + "/"), option.WithHTTPClient(srv.Client())}
+	sheetsSrv, err := sheets.NewService(ctx, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	driveSrv, err := drive.NewService(ctx, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := newClient(sheetsSrv, driveSrv, "")
+	var slept []time.Duration
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+	return c, &slept
+}
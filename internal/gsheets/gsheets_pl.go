@@ -89,27 +89,20 @@ func (c *Client) createPLProgramFromGenerated(program interface{}) (string, erro
 		},
 	}
 
-	created, err := c.sheets.Spreadsheets.Create(spreadsheet).Context(ctx).Do()
+	spreadsheetID, err := c.createSpreadsheet(ctx, spreadsheet)
 	if err != nil {
-		return "", fmt.Errorf("ошибка создания таблицы: %w", err)
-	}
-
-	spreadsheetID := created.SpreadsheetId
-
-	if c.folderID != "" {
-		if _, err = c.drive.Files.Update(spreadsheetID, nil).
-			AddParents(c.folderID).
-			Context(ctx).
-			Do(); err != nil {
-			log.Printf("Предупреждение: не удалось переместить таблицу: %v", err)
-		}
+		return "", err
 	}
 
 	headers := []interface{}{
 		"Неделя", "Тренировка", "Упражнение", "Подходы×Повторы", "Вес (кг)", "%1ПМ", "КПШ", "Тоннаж",
 	}
-	c.writeRow(spreadsheetID, "Программа", 1, headers)
-	c.formatHeaders(spreadsheetID, 0)
+	w := Write{SpreadsheetID: spreadsheetID}
+	w.update("Программа", 1, [][]interface{}{headers})
+	w.formatHeaders(0)
+	if err := c.applyOrDefer(ctx, w); err != nil {
+		return "", fmt.Errorf("ошибка заполнения таблицы: %w", err)
+	}
 
 	log.Printf("Создана Google таблица для PL программы: %s", spreadsheetID)
 	return spreadsheetID, nil
@@ -139,23 +132,13 @@ func (c *Client) createPLProgramSheet(ctx context.Context, program *PLProgramDat
 		Sheets:     sheetsList,
 	}
 
-	created, err := c.sheets.Spreadsheets.Create(spreadsheet).Context(ctx).Do()
+	spreadsheetID, err := c.createSpreadsheet(ctx, spreadsheet)
 	if err != nil {
-		return "", fmt.Errorf("ошибка создания таблицы: %w", err)
+		return "", err
 	}
 
-	spreadsheetID := created.SpreadsheetId
-
-	// Перемещаем в папку
-	if c.folderID != "" {
-		_, err = c.drive.Files.Update(spreadsheetID, nil).
-			AddParents(c.folderID).
-			Context(ctx).
-			Do()
-		if err != nil {
-			log.Printf("Предупреждение: не удалось переместить таблицу: %v", err)
-		}
-	}
+	// Все листы заполняются одним набором запросов
+	w := Write{SpreadsheetID: spreadsheetID}
 
 	// Заполняем обзорный лист
 	overviewData := [][]interface{}{
@@ -182,14 +165,14 @@ func (c *Client) createPLProgramSheet(ctx context.Context, program *PLProgramDat
 		[]interface{}{"Общий тоннаж", fmt.Sprintf("%.1f т", program.TotalTonnage)},
 		[]interface{}{"Недель", len(program.Weeks)},
 	)
-	c.writeRows(spreadsheetID, "Обзор", 1, overviewData)
-	c.formatHeaders(spreadsheetID, 0)
+	w.update("Обзор", 1, overviewData)
+	w.formatHeaders(0)
 
 	// Заполняем листы недель
 	for i, week := range program.Weeks {
 		sheetName := fmt.Sprintf("Неделя_%d", i+1)
 
-		// Собираем все данные для записи одним batch
+		// Собираем все данные недели
 		data := [][]interface{}{
 			{"Тренировка", "Упражнение", "Подходы×Повторы", "Вес (кг)", "%1ПМ", "КПШ", "Тоннаж"},
 		}
@@ -237,8 +220,12 @@ func (c *Client) createPLProgramSheet(ctx context.Context, program *PLProgramDat
 			"Итого неделя:", "", "", "", "", week.TotalKPS, fmt.Sprintf("%.2f т", week.Tonnage),
 		})
 
-		c.writeRows(spreadsheetID, sheetName, 1, data)
-		c.formatHeaders(spreadsheetID, int64(i+1))
+		w.update(sheetName, 1, data)
+		w.formatHeaders(int64(i + 1))
+	}
+
+	if err := c.applyOrDefer(ctx, w); err != nil {
+		return "", fmt.Errorf("ошибка заполнения таблицы: %w", err)
 	}
 
 	log.Printf("Создана Google таблица для PL программы: %s", spreadsheetID)
@@ -30,7 +30,12 @@ func (c *Client) ReadProgramFromSheet(spreadsheetID string) (*ProgramData, error
 	config := DefaultProgramSheetConfig()
 
 	// Читаем обзор
-	overviewResp, err := c.sheets.Spreadsheets.Values.Get(spreadsheetID, config.OverviewSheet+"!A1:D50").Context(ctx).Do()
+	var overviewResp *sheets.ValueRange
+	err := c.call(ctx, "чтение обзора", func() error {
+		var err error
+		overviewResp, err = c.sheets.Spreadsheets.Values.Get(spreadsheetID, config.OverviewSheet+"!A1:D50").Context(ctx).Do()
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("ошибка чтения обзора: %w", err)
 	}
@@ -62,7 +67,12 @@ func (c *Client) ReadProgramFromSheet(spreadsheetID string) (*ProgramData, error
 	}
 
 	// Получаем список листов чтобы найти листы недель
-	spreadsheet, err := c.sheets.Spreadsheets.Get(spreadsheetID).Context(ctx).Do()
+	var spreadsheet *sheets.Spreadsheet
+	err = c.call(ctx, "чтение структуры", func() error {
+		var err error
+		spreadsheet, err = c.sheets.Spreadsheets.Get(spreadsheetID).Context(ctx).Do()
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения структуры: %w", err)
 	}
@@ -88,7 +98,12 @@ func (c *Client) ReadProgramFromSheet(spreadsheetID string) (*ProgramData, error
 func (c *Client) readWeekSheet(spreadsheetID, sheetName string) (*WeekData, error) {
 	ctx := context.Background()
 
-	resp, err := c.sheets.Spreadsheets.Values.Get(spreadsheetID, sheetName+"!A1:L100").Context(ctx).Do()
+	var resp *sheets.ValueRange
+	err := c.call(ctx, "чтение недели", func() error {
+		var err error
+		resp, err = c.sheets.Spreadsheets.Values.Get(spreadsheetID, sheetName+"!A1:L100").Context(ctx).Do()
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -184,7 +199,12 @@ func (c *Client) WriteWorkoutResult(spreadsheetID string, result WorkoutResult)
 	config := DefaultProgramSheetConfig()
 
 	// Получаем текущие данные журнала
-	resp, err := c.sheets.Spreadsheets.Values.Get(spreadsheetID, config.JournalSheet+"!A:K").Context(ctx).Do()
+	var resp *sheets.ValueRange
+	err := c.call(ctx, "чтение журнала", func() error {
+		var err error
+		resp, err = c.sheets.Spreadsheets.Values.Get(spreadsheetID, config.JournalSheet+"!A:K").Context(ctx).Do()
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("ошибка чтения журнала: %w", err)
 	}
@@ -228,10 +248,13 @@ func (c *Client) WriteWorkoutResult(spreadsheetID string, result WorkoutResult)
 			}}
 
 			valueRange := &sheets.ValueRange{Values: values}
-			_, err = c.sheets.Spreadsheets.Values.Update(spreadsheetID, updateRange, valueRange).
-				ValueInputOption("USER_ENTERED").
-				Context(ctx).
-				Do()
+			err = c.call(ctx, "запись результата", func() error {
+				_, err := c.sheets.Spreadsheets.Values.Update(spreadsheetID, updateRange, valueRange).
+					ValueInputOption("USER_ENTERED").
+					Context(ctx).
+					Do()
+				return err
+			})
 			if err != nil {
 				return fmt.Errorf("ошибка записи результата: %w", err)
 			}
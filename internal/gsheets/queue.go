@@ -0,0 +1,259 @@
+package gsheets
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"workbot/internal/scheduler"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// queueBatchSize — сколько отложенных записей разбирается за один проход
+	queueBatchSize = 500
+	// queueMaxAttempts — после стольких неудачных попыток запись помечается неудачной
+	queueMaxAttempts = 10
+)
+
+// Queue — очередь записей в Google Sheets, отложенных из-за недоступности Google
+// (таблица sheets_pending_writes). Записи одной таблицы при разборе объединяются
+// в один набор запросов. Разбор выполняет задача планировщика, поэтому в
+// каждый момент очередь разбирает только один экземпляр бота
+type Queue struct {
+	db     *sql.DB
+	client *Client
+}
+
+// SyncStatus — состояние синхронизации таблицы для карточки клиента
+type SyncStatus struct {
+	Pending       int          // записей ждут отправки
+	Failed        int          // записей не удалось отправить
+	PendingSince  sql.NullTime // когда появилась самая старая отложенная запись
+	NextAttemptAt sql.NullTime
+	LastSyncedAt  sql.NullTime // последняя успешная запись
+	LastError     string
+}
+
+// pendingWrite — строка очереди
+type pendingWrite struct {
+	id        int64
+	attempts  int
+	createdAt time.Time
+	write     Write
+}
+
+// NewQueue создаёт очередь и подключает её к клиенту: записи, которые не
+// удалось отправить из-за недоступности Google, клиент будет откладывать в неё
+func NewQueue(db *sql.DB, client *Client) *Queue {
+	q := &Queue{db: db, client: client}
+	client.queue = q
+	return q
+}
+
+// Enqueue откладывает изменения таблицы; cause — ошибка, из-за которой запись не удалась
+func (q *Queue) Enqueue(w Write, cause error) error {
+	if w.Empty() {
+		return nil
+	}
+	payload, err := json.Marshal(w)
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации записи: %w", err)
+	}
+	if _, err := q.db.Exec(`
+		INSERT INTO public.sheets_pending_writes (spreadsheet_id, payload, last_error)
+		VALUES ($1, $2, $3)`, w.SpreadsheetID, string(payload), errorText(cause)); err != nil {
+		return fmt.Errorf("ошибка сохранения отложенной записи: %w", err)
+	}
+	q.recordError(w.SpreadsheetID, cause)
+	return nil
+}
+
+// Drain отправляет отложенные записи, срок повтора которых наступил.
+// Записи одной таблицы объединяются; при ошибке неотправленный остаток
+// сохраняется одной строкой с задержкой следующей попытки
+func (q *Queue) Drain(ctx context.Context) error {
+	pending, err := q.loadDue(time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Группируем по таблицам, сохраняя порядок постановки в очередь
+	var order []string
+	groups := make(map[string][]pendingWrite)
+	for _, p := range pending {
+		if _, ok := groups[p.write.SpreadsheetID]; !ok {
+			order = append(order, p.write.SpreadsheetID)
+		}
+		groups[p.write.SpreadsheetID] = append(groups[p.write.SpreadsheetID], p)
+	}
+
+	var failed int
+	for _, spreadsheetID := range order {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := q.drainSpreadsheet(ctx, groups[spreadsheetID]); err != nil {
+			log.Printf("Google Sheets: отложенные записи %s не отправлены: %v", spreadsheetID, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("не удалось синхронизировать таблиц: %d из %d", failed, len(order))
+	}
+	return nil
+}
+
+// drainSpreadsheet отправляет отложенные записи одной таблицы
+func (q *Queue) drainSpreadsheet(ctx context.Context, group []pendingWrite) error {
+	writes := make([]Write, len(group))
+	ids := make([]int64, len(group))
+	attempts := 0
+	createdAt := group[0].createdAt
+	for i, p := range group {
+		writes[i] = p.write
+		ids[i] = p.id
+		if p.attempts > attempts {
+			attempts = p.attempts
+		}
+		if p.createdAt.Before(createdAt) {
+			createdAt = p.createdAt
+		}
+	}
+	merged := Merge(writes)[0]
+
+	remaining, applyErr := q.client.apply(ctx, merged)
+	if applyErr == nil {
+		if _, err := q.db.Exec(`DELETE FROM public.sheets_pending_writes WHERE id = ANY($1)`, pq.Array(ids)); err != nil {
+			return err
+		}
+		q.markSynced(merged.SpreadsheetID)
+		return nil
+	}
+
+	attempts++
+	status := "pending"
+	if !IsRetryable(applyErr) || attempts >= queueMaxAttempts {
+		status = "failed"
+	}
+	payload, err := json.Marshal(remaining)
+	if err != nil {
+		return err
+	}
+
+	tx, err := q.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM public.sheets_pending_writes WHERE id = ANY($1)`, pq.Array(ids)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`
+		INSERT INTO public.sheets_pending_writes
+			(spreadsheet_id, payload, status, attempts, next_attempt_at, last_error, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		merged.SpreadsheetID, string(payload), status, attempts,
+		time.Now().Add(scheduler.Backoff(attempts)), errorText(applyErr), createdAt); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	q.recordError(merged.SpreadsheetID, applyErr)
+	return applyErr
+}
+
+// loadDue загружает отложенные записи, срок повтора которых наступил
+func (q *Queue) loadDue(now time.Time) ([]pendingWrite, error) {
+	rows, err := q.db.Query(`
+		SELECT id, attempts, created_at, payload
+		FROM public.sheets_pending_writes
+		WHERE status = 'pending' AND next_attempt_at <= $1
+		ORDER BY id
+		LIMIT $2`, now, queueBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения очереди Google Sheets: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []pendingWrite
+	for rows.Next() {
+		var p pendingWrite
+		var payload []byte
+		if err := rows.Scan(&p.id, &p.attempts, &p.createdAt, &payload); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &p.write); err != nil {
+			log.Printf("Google Sheets: повреждённая отложенная запись %d: %v", p.id, err)
+			continue
+		}
+		pending = append(pending, p)
+	}
+	return pending, rows.Err()
+}
+
+// Status возвращает состояние синхронизации таблицы
+func (q *Queue) Status(spreadsheetID string) (SyncStatus, error) {
+	var st SyncStatus
+	err := q.db.QueryRow(`
+		SELECT COUNT(*) FILTER (WHERE status = 'pending'),
+		       COUNT(*) FILTER (WHERE status = 'failed'),
+		       MIN(created_at) FILTER (WHERE status = 'pending'),
+		       MIN(next_attempt_at) FILTER (WHERE status = 'pending')
+		FROM public.sheets_pending_writes
+		WHERE spreadsheet_id = $1`, spreadsheetID).
+		Scan(&st.Pending, &st.Failed, &st.PendingSince, &st.NextAttemptAt)
+	if err != nil {
+		return st, err
+	}
+
+	var lastError sql.NullString
+	err = q.db.QueryRow(`
+		SELECT last_synced_at, last_error
+		FROM public.sheets_sync_state
+		WHERE spreadsheet_id = $1`, spreadsheetID).
+		Scan(&st.LastSyncedAt, &lastError)
+	if err != nil && err != sql.ErrNoRows {
+		return st, err
+	}
+	st.LastError = lastError.String
+	return st, nil
+}
+
+// markSynced записывает успешную синхронизацию таблицы
+func (q *Queue) markSynced(spreadsheetID string) {
+	_, err := q.db.Exec(`
+		INSERT INTO public.sheets_sync_state (spreadsheet_id, last_synced_at, last_error, updated_at)
+		VALUES ($1, NOW(), NULL, NOW())
+		ON CONFLICT (spreadsheet_id) DO UPDATE SET
+			last_synced_at = NOW(), last_error = NULL, updated_at = NOW()`, spreadsheetID)
+	if err != nil {
+		log.Printf("Ошибка сохранения состояния синхронизации %s: %v", spreadsheetID, err)
+	}
+}
+
+// recordError записывает последнюю ошибку синхронизации таблицы
+func (q *Queue) recordError(spreadsheetID string, cause error) {
+	_, err := q.db.Exec(`
+		INSERT INTO public.sheets_sync_state (spreadsheet_id, last_error, last_error_at, updated_at)
+		VALUES ($1, $2, NOW(), NOW())
+		ON CONFLICT (spreadsheet_id) DO UPDATE SET
+			last_error = EXCLUDED.last_error, last_error_at = NOW(), updated_at = NOW()`,
+		spreadsheetID, errorText(cause))
+	if err != nil {
+		log.Printf("Ошибка сохранения состояния синхронизации %s: %v", spreadsheetID, err)
+	}
+}
+
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,100 @@
+package gsheets
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+const (
+	defaultMaxRetries = 5
+	baseRetryDelay    = time.Second
+	maxRetryDelay     = time.Minute
+)
+
+// call выполняет запрос к Google API, повторяя его при исчерпании квоты (429),
+// ошибках сервера (5xx) и недоступности сети. Задержка растёт экспоненциально
+// или берётся из заголовка Retry-After
+func (c *Client) call(ctx context.Context, op string, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !IsRetryable(err) || attempt > c.maxRetries {
+			return err
+		}
+
+		delay := retryDelay(err, attempt)
+		log.Printf("Google Sheets: %s не выполнено (попытка %d/%d), повтор через %s: %v",
+			op, attempt, c.maxRetries+1, delay, err)
+		if err := c.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// IsRetryable сообщает, что запрос стоит повторить позже: квота, сбой Google или сети
+func IsRetryable(err error) bool {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case 429, 500, 502, 503, 504:
+			return true
+		case 403:
+			// Старый формат ошибок квоты: 403 с причиной rateLimitExceeded
+			for _, item := range apiErr.Errors {
+				if item.Reason == "rateLimitExceeded" || item.Reason == "userRateLimitExceeded" {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	var netErr net.Error
+	var urlErr *url.Error
+	return errors.As(err, &netErr) || errors.As(err, &urlErr)
+}
+
+// retryDelay возвращает задержку перед повтором: Retry-After из ответа
+// или 1, 2, 4 … 60 секунд после attempt неудачных попыток
+func retryDelay(err error, attempt int) time.Duration {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Header != nil {
+		if seconds, convErr := strconv.Atoi(apiErr.Header.Get("Retry-After")); convErr == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return backoff(attempt)
+}
+
+// backoff возвращает экспоненциальную задержку после attempt неудачных попыток: 1, 2, 4 … 60 секунд
+func backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := baseRetryDelay
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return d
+}
+
+// sleep ждёт d или отмены ctx
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,142 @@
+package hyrox
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+const (
+	// focusMinLoss и focusMinDelta — группа отрезков попадает в акцент следующих
+	// симуляций, если потеряно не меньше 5% плана и не меньше 10 секунд
+	focusMinLoss  = 0.05
+	focusMinDelta = 10 * time.Second
+	// focusMax — сколько групп держать в акценте
+	focusMax = 3
+	// fadeThreshold — бег просел, если последние два километра отстают от плана
+	// на 5% больше, чем первые два
+	fadeThreshold = 0.05
+	// fadeRuns — сколько первых и последних беговых отрезков сравнивается
+	fadeRuns = 2
+)
+
+var ErrSplitCount = errors.New("hyrox: число отрезков не совпадает с раскладкой")
+
+// Loss — отрезок или группа отрезков: план, факт и потеря времени
+type Loss struct {
+	ID     string
+	Kind   Kind
+	Target time.Duration
+	Actual time.Duration
+	Delta  time.Duration // больше нуля — медленнее плана
+}
+
+// Pct — потеря относительно плана
+func (l Loss) Pct() float64 {
+	if l.Target <= 0 {
+		return 0
+	}
+	return l.Delta.Seconds() / l.Target.Seconds()
+}
+
+// Analysis — разбор симуляции относительно раскладки
+type Analysis struct {
+	Target, Actual time.Duration // только по записанным отрезкам
+	Splits         []Loss        // записанные отрезки в порядке забега
+	Groups         []Loss        // бег целиком, станции, roxzone; по убыванию потери
+	RunFade        float64       // насколько последние километры отстают от плана сильнее первых
+	Focus          []string      // группы для акцента следующих симуляций: Run или ID станций
+}
+
+// Fading — бег проседает к концу забега: первые километры слишком быстрые
+func (a Analysis) Fading() bool {
+	return a.RunFade > fadeThreshold
+}
+
+// Delta — итоговое отставание от плана
+func (a Analysis) Delta() time.Duration {
+	return a.Actual - a.Target
+}
+
+// Analyze сравнивает времена отрезков симуляции с раскладкой. actual — в порядке
+// plan.Segments; нулевые значения — отрезок не записан (обычно roxzone)
+func Analyze(plan Plan, actual []time.Duration) (Analysis, error) {
+	if len(actual) != len(plan.Segments) {
+		return Analysis{}, ErrSplitCount
+	}
+
+	var a Analysis
+	groups := make(map[string]*Loss)
+	var order []string
+	var runPct []float64
+	for i, s := range plan.Segments {
+		if actual[i] <= 0 {
+			continue
+		}
+		l := Loss{ID: s.ID, Kind: s.Kind, Target: s.Target, Actual: actual[i], Delta: actual[i] - s.Target}
+		a.Splits = append(a.Splits, l)
+		a.Target += l.Target
+		a.Actual += l.Actual
+		if s.Kind == KindRun {
+			runPct = append(runPct, l.Pct())
+		}
+
+		g, ok := groups[s.Group()]
+		if !ok {
+			g = &Loss{ID: s.Group(), Kind: s.Kind}
+			groups[s.Group()] = g
+			order = append(order, s.Group())
+		}
+		g.Target += l.Target
+		g.Actual += l.Actual
+		g.Delta += l.Delta
+	}
+
+	for _, id := range order {
+		a.Groups = append(a.Groups, *groups[id])
+	}
+	sort.SliceStable(a.Groups, func(i, j int) bool { return a.Groups[i].Delta > a.Groups[j].Delta })
+
+	if len(runPct) >= 2*fadeRuns {
+		a.RunFade = mean(runPct[len(runPct)-fadeRuns:]) - mean(runPct[:fadeRuns])
+	}
+
+	for _, g := range a.Groups {
+		if len(a.Focus) == focusMax {
+			break
+		}
+		if g.Kind != KindRoxzone && g.Delta >= focusMinDelta && g.Pct() >= focusMinLoss {
+			a.Focus = append(a.Focus, g.ID)
+		}
+	}
+	return a, nil
+}
+
+// SimulationStations выбирает n станций для неполной симуляции: сначала станции
+// из акцента, затем станции по умолчанию; результат — в порядке забега
+func SimulationStations(n int, focus, defaults []string) []string {
+	picked := make(map[string]bool, n)
+	var stations []string
+	add := func(id string) {
+		if len(stations) < n && StationIndex(id) >= 0 && !picked[id] {
+			picked[id] = true
+			stations = append(stations, id)
+		}
+	}
+	for _, id := range focus {
+		add(id)
+	}
+	for _, id := range defaults {
+		add(id)
+	}
+	sort.Slice(stations, func(i, j int) bool { return StationIndex(stations[i]) < StationIndex(stations[j]) })
+	return stations
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
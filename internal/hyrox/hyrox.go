@@ -0,0 +1,294 @@
+// Package hyrox раскладывает целевое время забега Hyrox на отрезки и разбирает
+// результаты симуляций.
+//
+// Забег — 8 раз по 1 км бега и станции в фиксированном порядке, плюс переходы
+// (roxzone). По контрольным замерам атлета (свежий 1 км, SkiErg и гребля 1000 м,
+// толкание и тяга саней) строится прогноз каждого отрезка с поправкой на
+// усталость, а целевое время распределяется пропорционально прогнозу (PlanRace).
+// Недостающие замеры оцениваются по уровню атлета относительно эталонного забега.
+// Результаты симуляции сравниваются с планом: где теряется время, проседает ли
+// бег к концу и на какие станции сместить следующие симуляции (analysis.go).
+// Пакет не знает о базе и боте.
+package hyrox
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Kind — тип отрезка забега
+type Kind string
+
+const (
+	KindRun     Kind = "run"
+	KindStation Kind = "station"
+	KindRoxzone Kind = "roxzone"
+)
+
+// Идентификаторы станций совпадают с progression.HyroxStations
+const (
+	SkiErg     = "ski_erg"
+	SledPush   = "sled_push"
+	SledPull   = "sled_pull"
+	BurpeeBJ   = "burpee_bj"
+	Rowing     = "rowing"
+	FarmerWalk = "farmer_walk"
+	Sandbag    = "sandbag"
+	WallBall   = "wall_ball"
+
+	// Run — замер и группа беговых отрезков; Roxzone — все переходы одним отрезком
+	Run     = "run"
+	Roxzone = "roxzone"
+)
+
+// Stations — станции в порядке забега; перед каждой — 1 км бега
+var Stations = []string{SkiErg, SledPush, SledPull, BurpeeBJ, Rowing, FarmerWalk, Sandbag, WallBall}
+
+// Runs — число беговых отрезков
+const Runs = 8
+
+// station — название и соревновательный объём станции
+type station struct {
+	name, work string
+}
+
+var stationInfo = map[string]station{
+	SkiErg:     {"Ski Erg", "1000 м"},
+	SledPush:   {"Sled Push", "50 м"},
+	SledPull:   {"Sled Pull", "50 м"},
+	BurpeeBJ:   {"Burpee Broad Jump", "80 м"},
+	Rowing:     {"Rowing", "1000 м"},
+	FarmerWalk: {"Farmer's Carry", "200 м"},
+	Sandbag:    {"Sandbag Lunges", "100 м"},
+	WallBall:   {"Wall Balls", "100"},
+}
+
+// StationName — название станции; для неизвестного ID — сам ID
+func StationName(id string) string {
+	if s, ok := stationInfo[id]; ok {
+		return s.name
+	}
+	return id
+}
+
+// StationWork — соревновательный объём станции: «1000 м», «100»
+func StationWork(id string) string {
+	return stationInfo[id].work
+}
+
+// StationIndex — номер станции в забеге с нуля; -1 — не станция
+func StationIndex(id string) int {
+	for i, s := range Stations {
+		if s == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Segment — отрезок забега с целевым временем
+type Segment struct {
+	ID     string // run_1…run_8, ID станции, roxzone
+	Kind   Kind
+	Num    int // номер круга 1–8; у roxzone — 0
+	Target time.Duration
+}
+
+// RunID — идентификатор n-го бегового отрезка
+func RunID(n int) string {
+	return Run + "_" + strconv.Itoa(n)
+}
+
+// Group — группа отрезка для анализа: все беговые отрезки — одна группа
+func (s Segment) Group() string {
+	if s.Kind == KindRun {
+		return Run
+	}
+	return s.ID
+}
+
+// Layout — отрезки забега по порядку без целевого времени: бег, станция, …, roxzone
+func Layout() []Segment {
+	segments := make([]Segment, 0, 2*Runs+1)
+	for i, id := range Stations {
+		segments = append(segments,
+			Segment{ID: RunID(i + 1), Kind: KindRun, Num: i + 1},
+			Segment{ID: id, Kind: KindStation, Num: i + 1})
+	}
+	return append(segments, Segment{ID: Roxzone, Kind: KindRoxzone})
+}
+
+// Benchmarks — контрольные замеры: Run — свежий 1 км, станции — полный
+// соревновательный объём отдельно от забега. Ключи — Run и ID станций
+type Benchmarks map[string]time.Duration
+
+// referenceRace — эталонный забег (около 1:25, средний уровень open):
+// по нему оцениваются отрезки без замеров
+var referenceRace = map[string]time.Duration{
+	Run:        5*time.Minute + 15*time.Second,
+	SkiErg:     4*time.Minute + 40*time.Second,
+	SledPush:   3*time.Minute + 30*time.Second,
+	SledPull:   4*time.Minute + 30*time.Second,
+	BurpeeBJ:   5 * time.Minute,
+	Rowing:     4*time.Minute + 50*time.Second,
+	FarmerWalk: 1*time.Minute + 50*time.Second,
+	Sandbag:    4*time.Minute + 30*time.Second,
+	WallBall:   5*time.Minute + 45*time.Second,
+	Roxzone:    8 * time.Minute,
+}
+
+const (
+	// runFatigueStart и runFatigueStep — бег в забеге медленнее свежего километра:
+	// первый отрезок на 8%, каждый следующий ещё на 1%
+	runFatigueStart = 1.08
+	runFatigueStep  = 0.01
+	// ergFatigue — SkiErg и гребля в забеге медленнее отдельного замера на 5%
+	ergFatigue = 1.05
+	// realisticScale — цель быстрее прогноза больше чем на 5% за один цикл не достигается
+	realisticScale = 0.95
+)
+
+var (
+	ErrNoRunBenchmark = errors.New("hyrox: нужен замер 1 км бега")
+	ErrTarget         = errors.New("hyrox: целевое время должно быть положительным")
+)
+
+// runFatigue — множитель свежего километра для n-го бегового отрезка
+func runFatigue(n int) float64 {
+	return runFatigueStart + float64(n-1)*runFatigueStep
+}
+
+// raceFactor — множитель замера для отрезка в забеге
+func raceFactor(s Segment) float64 {
+	switch {
+	case s.Kind == KindRun:
+		return runFatigue(s.Num)
+	case s.ID == SkiErg || s.ID == Rowing:
+		return ergFatigue
+	}
+	return 1
+}
+
+// Plan — раскладка забега на целевое время
+type Plan struct {
+	Target    time.Duration
+	Predicted time.Duration // сумма прогноза отрезков по замерам
+	Segments  []Segment     // Layout() с целевым временем
+	Estimated []string      // замеры, оценённые по уровню атлета
+}
+
+// PlanRace строит раскладку на целевое время target по замерам b. Прогноз каждого
+// отрезка — замер с поправкой на усталость в забеге; отрезки без замера (и roxzone)
+// оцениваются как эталон, умноженный на средний уровень атлета по известным
+// замерам. Цель делится пропорционально прогнозу. target = 0 — раскладка прогноза
+func PlanRace(target time.Duration, b Benchmarks) (Plan, error) {
+	if b[Run] <= 0 {
+		return Plan{}, ErrNoRunBenchmark
+	}
+	if target < 0 {
+		return Plan{}, ErrTarget
+	}
+
+	level := athleteLevel(b)
+	plan := Plan{Segments: Layout()}
+	predicted := make([]float64, len(plan.Segments))
+	var total float64
+	for i, s := range plan.Segments {
+		bench := b[s.Group()]
+		if s.Kind == KindRoxzone || bench <= 0 {
+			predicted[i] = referenceRace[s.Group()].Seconds() * level
+			if s.Kind == KindStation {
+				plan.Estimated = append(plan.Estimated, s.ID)
+			}
+		} else {
+			predicted[i] = bench.Seconds() * raceFactor(s)
+		}
+		total += predicted[i]
+	}
+
+	plan.Predicted = seconds(total)
+	plan.Target = target
+	if target == 0 {
+		plan.Target = plan.Predicted
+	}
+
+	// Округление до секунды; остаток уходит в roxzone, чтобы сумма совпала с целью
+	scale := plan.Target.Seconds() / total
+	var sum time.Duration
+	last := len(plan.Segments) - 1
+	for i := range plan.Segments[:last] {
+		plan.Segments[i].Target = seconds(predicted[i] * scale)
+		sum += plan.Segments[i].Target
+	}
+	plan.Segments[last].Target = plan.Target - sum
+	return plan, nil
+}
+
+// athleteLevel — среднее по замерам отношение прогноза к эталонному забегу
+// (меньше 1 — атлет быстрее эталона); бег считается одним замером
+func athleteLevel(b Benchmarks) float64 {
+	var sum float64
+	var n int
+	for _, id := range append([]string{Run}, Stations...) {
+		bench := b[id]
+		if bench <= 0 {
+			continue
+		}
+		factor := raceFactor(Segment{ID: id, Kind: KindStation})
+		if id == Run {
+			factor = runFatigueStart + runFatigueStep*float64(Runs-1)/2
+		}
+		sum += bench.Seconds() * factor / referenceRace[id].Seconds()
+		n++
+	}
+	return sum / float64(n)
+}
+
+// Scale — отношение цели к прогнозу (меньше 1 — цель быстрее прогноза)
+func (p Plan) Scale() float64 {
+	if p.Predicted <= 0 {
+		return 1
+	}
+	return p.Target.Seconds() / p.Predicted.Seconds()
+}
+
+// Realistic — цель не быстрее прогноза больше чем на 5%
+func (p Plan) Realistic() bool {
+	return p.Scale() >= realisticScale
+}
+
+// Segment — отрезок по идентификатору
+func (p Plan) Segment(id string) (Segment, bool) {
+	for _, s := range p.Segments {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return Segment{}, false
+}
+
+// Splits — целевое время отрезков в секундах по идентификатору
+func (p Plan) Splits() map[string]int {
+	splits := make(map[string]int, len(p.Segments))
+	for _, s := range p.Segments {
+		splits[s.ID] = int(s.Target.Seconds())
+	}
+	return splits
+}
+
+// RunPace — средний целевой темп беговых отрезков на километр
+func (p Plan) RunPace() time.Duration {
+	var sum time.Duration
+	for _, s := range p.Segments {
+		if s.Kind == KindRun {
+			sum += s.Target
+		}
+	}
+	return (sum / Runs).Round(time.Second)
+}
+
+func seconds(v float64) time.Duration {
+	return time.Duration(math.Round(v)) * time.Second
+}
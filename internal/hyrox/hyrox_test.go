@@ -0,0 +1,230 @@
+package hyrox
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func mustDuration(t *testing.T, s string) time.Duration {
+	t.Helper()
+	d, err := ParseDuration(s)
+	if err != nil {
+		t.Fatalf("ParseDuration(%q): %v", s, err)
+	}
+	return d
+}
+
+// testBenchmarks — атлет чуть быстрее эталона, без замеров burpee, farmer, lunges, wall balls
+func testBenchmarks(t *testing.T) Benchmarks {
+	return Benchmarks{
+		Run:      mustDuration(t, "4:30"),
+		SkiErg:   mustDuration(t, "4:20"),
+		Rowing:   mustDuration(t, "4:30"),
+		SledPush: mustDuration(t, "3:00"),
+		SledPull: mustDuration(t, "4:00"),
+	}
+}
+
+func TestPlanRace(t *testing.T) {
+	target := mustDuration(t, "1:20:00")
+	plan, err := PlanRace(target, testBenchmarks(t))
+	if err != nil {
+		t.Fatalf("PlanRace: %v", err)
+	}
+	if len(plan.Segments) != 2*Runs+1 {
+		t.Fatalf("отрезков %d, want %d", len(plan.Segments), 2*Runs+1)
+	}
+
+	var sum time.Duration
+	for _, s := range plan.Segments {
+		if s.Target <= 0 {
+			t.Errorf("%s: цель %v", s.ID, s.Target)
+		}
+		sum += s.Target
+	}
+	if sum != target {
+		t.Errorf("сумма отрезков %v, want %v", sum, target)
+	}
+
+	// Бег замедляется к концу забега
+	first, _ := plan.Segment(RunID(1))
+	last, _ := plan.Segment(RunID(Runs))
+	if last.Target <= first.Target {
+		t.Errorf("run_8 %v не медленнее run_1 %v", last.Target, first.Target)
+	}
+
+	want := []string{BurpeeBJ, FarmerWalk, Sandbag, WallBall}
+	if !reflect.DeepEqual(plan.Estimated, want) {
+		t.Errorf("Estimated = %v, want %v", plan.Estimated, want)
+	}
+	if !plan.Realistic() {
+		t.Errorf("цель %v при прогнозе %v должна быть реальной", plan.Target, plan.Predicted)
+	}
+}
+
+func TestPlanRacePrediction(t *testing.T) {
+	plan, err := PlanRace(0, testBenchmarks(t))
+	if err != nil {
+		t.Fatalf("PlanRace: %v", err)
+	}
+	if plan.Target != plan.Predicted {
+		t.Errorf("без цели Target %v, want прогноз %v", plan.Target, plan.Predicted)
+	}
+	// Свежий километр 4:30 — в забеге первый отрезок на 8% медленнее
+	if s, _ := plan.Segment(RunID(1)); s.Target != mustDuration(t, "4:52") {
+		t.Errorf("run_1 = %v, want 4:52", FormatTime(s.Target))
+	}
+	// Sled Push в забеге — как замер
+	if s, _ := plan.Segment(SledPush); s.Target != mustDuration(t, "3:00") {
+		t.Errorf("sled_push = %v, want 3:00", FormatTime(s.Target))
+	}
+
+	fast, _ := PlanRace(plan.Predicted*8/10, testBenchmarks(t))
+	if fast.Realistic() {
+		t.Errorf("цель на 20%% быстрее прогноза не должна быть реальной")
+	}
+
+	if _, err := PlanRace(0, Benchmarks{SkiErg: time.Minute}); !errors.Is(err, ErrNoRunBenchmark) {
+		t.Errorf("без бега err = %v, want ErrNoRunBenchmark", err)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	plan, err := PlanRace(0, testBenchmarks(t))
+	if err != nil {
+		t.Fatalf("PlanRace: %v", err)
+	}
+
+	actual := make([]time.Duration, len(plan.Segments))
+	for i, s := range plan.Segments {
+		actual[i] = s.Target
+		switch {
+		case s.ID == SledPull:
+			actual[i] += 45 * time.Second // +19%
+		case s.ID == WallBall:
+			actual[i] += 30 * time.Second
+		case s.ID == Rowing:
+			actual[i] += 5 * time.Second // меньше 10 секунд — не акцент
+		case s.Kind == KindRun && s.Num <= 2:
+			actual[i] -= 10 * time.Second // слишком быстрый старт
+		case s.Kind == KindRun && s.Num >= 7:
+			actual[i] += 20 * time.Second
+		case s.Kind == KindRoxzone:
+			actual[i] = 0 // переходы не записаны
+		}
+	}
+
+	a, err := Analyze(plan, actual)
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	if len(a.Splits) != 2*Runs {
+		t.Errorf("записанных отрезков %d, want %d", len(a.Splits), 2*Runs)
+	}
+	if want := 45*time.Second + 30*time.Second + 5*time.Second + 20*time.Second; a.Delta() != want {
+		t.Errorf("Delta = %v, want %v", a.Delta(), want)
+	}
+	if a.Groups[0].ID != SledPull {
+		t.Errorf("больше всего потеряно на %s, want sled_pull", a.Groups[0].ID)
+	}
+	if !a.Fading() {
+		t.Errorf("RunFade = %.3f: просадка бега не найдена", a.RunFade)
+	}
+	if want := []string{SledPull, WallBall}; !reflect.DeepEqual(a.Focus, want) {
+		t.Errorf("Focus = %v, want %v", a.Focus, want)
+	}
+
+	if _, err := Analyze(plan, actual[:5]); !errors.Is(err, ErrSplitCount) {
+		t.Errorf("err = %v, want ErrSplitCount", err)
+	}
+}
+
+func TestSimulationStations(t *testing.T) {
+	defaults := []string{SkiErg, SledPush, SledPull, WallBall}
+	tests := []struct {
+		n     int
+		focus []string
+		want  []string
+	}{
+		{4, nil, defaults},
+		{2, []string{WallBall, Run, SledPull}, []string{SledPull, WallBall}},
+		{4, []string{Sandbag}, []string{SkiErg, SledPush, SledPull, Sandbag}},
+	}
+	for _, tt := range tests {
+		if got := SimulationStations(tt.n, tt.focus, defaults); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SimulationStations(%d, %v) = %v, want %v", tt.n, tt.focus, got, tt.want)
+		}
+	}
+}
+
+func TestParseBenchmarks(t *testing.T) {
+	target, b, err := ParseBenchmarks("1:25:00; бег 4:30, Ski 4:20 row=4:10\npush 3:00 pull 4:10")
+	if err != nil {
+		t.Fatalf("ParseBenchmarks: %v", err)
+	}
+	if target != mustDuration(t, "1:25:00") {
+		t.Errorf("target = %v", target)
+	}
+	want := Benchmarks{
+		Run: mustDuration(t, "4:30"), SkiErg: mustDuration(t, "4:20"), Rowing: mustDuration(t, "4:10"),
+		SledPush: mustDuration(t, "3:00"), SledPull: mustDuration(t, "4:10"),
+	}
+	if !reflect.DeepEqual(b, want) {
+		t.Errorf("benchmarks = %v, want %v", b, want)
+	}
+	if _, b, err := ParseBenchmarks("біг 4:30 веслування 4:10"); err != nil || b[Run] != want[Run] || b[Rowing] != want[Rowing] {
+		t.Errorf("ParseBenchmarks (uk) = %v, %v", b, err)
+	}
+
+	bad := []struct {
+		text string
+		err  error
+	}{
+		{"ski 4:20", ErrNoRunBenchmark},
+		{"бег 4:30 ski", ErrSyntax},
+		{"бег 4:3x", ErrSyntax},
+		{"бег 4:30 плавание 10:00", ErrSyntax},
+		{"бег 0:10", ErrRange},
+		{"10:00 бег 4:30", ErrRange},
+	}
+	for _, tt := range bad {
+		if _, _, err := ParseBenchmarks(tt.text); !errors.Is(err, tt.err) {
+			t.Errorf("ParseBenchmarks(%q) err = %v, want %v", tt.text, err, tt.err)
+		}
+	}
+}
+
+func TestParseSplits(t *testing.T) {
+	text := "5:00 4:40 5:05 3:20 5:10 4:30 5:15 5:00 5:10 4:50 5:15 1:50 5:20 4:30 5:30 5:45"
+	splits, err := ParseSplits(text)
+	if err != nil {
+		t.Fatalf("ParseSplits: %v", err)
+	}
+	if len(splits) != 2*Runs+1 || splits[2*Runs] != 0 {
+		t.Errorf("без переходов: %d отрезков, roxzone %v", len(splits), splits[len(splits)-1])
+	}
+
+	splits, err = ParseSplits(text + " 7:30")
+	if err != nil || splits[2*Runs] != mustDuration(t, "7:30") {
+		t.Errorf("с переходами: roxzone %v, err %v", splits[2*Runs], err)
+	}
+
+	if _, err := ParseSplits("5:00 4:40"); !errors.Is(err, ErrSplitCount) {
+		t.Errorf("err = %v, want ErrSplitCount", err)
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	tests := map[time.Duration]string{
+		272 * time.Second:  "4:32",
+		5100 * time.Second: "1:25:00",
+		-45 * time.Second:  "-0:45",
+	}
+	for d, want := range tests {
+		if got := FormatTime(d); got != want {
+			t.Errorf("FormatTime(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package hyrox
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Допустимые значения: замер отрезка — от 30 секунд до 20 минут, цель — от 40 минут до 4 часов
+const (
+	minBenchmark = 30 * time.Second
+	maxBenchmark = 20 * time.Minute
+	minTarget    = 40 * time.Minute
+	maxTarget    = 4 * time.Hour
+)
+
+var (
+	ErrSyntax = errors.New("hyrox: не удалось разобрать")
+	ErrRange  = errors.New("hyrox: значение вне допустимого диапазона")
+)
+
+// benchmarkAliases — как замеры называют в сообщении; ключ — в нижнем регистре
+var benchmarkAliases = map[string]string{
+	"run": Run, "бег": Run, "біг": Run,
+	"ski": SkiErg, "skierg": SkiErg, "ski_erg": SkiErg, "лыжи": SkiErg,
+	"push": SledPush, "sled_push": SledPush, "толкание": SledPush,
+	"pull": SledPull, "sled_pull": SledPull, "тяга": SledPull,
+	"burpee": BurpeeBJ, "burpees": BurpeeBJ, "burpee_bj": BurpeeBJ, "бёрпи": BurpeeBJ, "берпи": BurpeeBJ,
+	"row": Rowing, "rowing": Rowing, "гребля": Rowing, "веслування": Rowing,
+	"farmer": FarmerWalk, "farmers": FarmerWalk, "farmer_walk": FarmerWalk, "фермер": FarmerWalk,
+	"lunges": Sandbag, "sandbag": Sandbag, "выпады": Sandbag, "випади": Sandbag,
+	"wb": WallBall, "wallball": WallBall, "wallballs": WallBall, "wall_ball": WallBall,
+}
+
+// ParseDuration разбирает время «м:сс» или «ч:мм:сс»
+func ParseDuration(s string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+	var total int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || (i > 0 && (n >= 60 || len(p) != 2)) {
+			return 0, fmt.Errorf("%w: %q", ErrSyntax, s)
+		}
+		total = total*60 + n
+	}
+	return time.Duration(total) * time.Second, nil
+}
+
+// FormatTime форматирует время как «ч:мм:сс» или «м:сс»
+func FormatTime(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	sec := int(d.Round(time.Second).Seconds())
+	if sec >= 3600 {
+		return fmt.Sprintf("%s%d:%02d:%02d", sign, sec/3600, sec%3600/60, sec%60)
+	}
+	return fmt.Sprintf("%s%d:%02d", sign, sec/60, sec%60)
+}
+
+// fields делит текст на слова: пробелы, переводы строк, запятые, точки с запятой и «=»
+func fields(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return unicode.IsSpace(r) || r == ',' || r == ';' || r == '='
+	})
+}
+
+// ParseBenchmarks разбирает цель и замеры из текста: «1:25:00 бег 4:30 ski 4:20
+// row 4:10 push 3:00 pull 4:10». Время без названия — цель (необязательна)
+func ParseBenchmarks(text string) (time.Duration, Benchmarks, error) {
+	var target time.Duration
+	b := make(Benchmarks)
+	words := fields(text)
+	for i := 0; i < len(words); i++ {
+		if id, ok := benchmarkAliases[words[i]]; ok {
+			if i+1 == len(words) {
+				return 0, nil, fmt.Errorf("%w: %q без времени", ErrSyntax, words[i])
+			}
+			i++
+			d, err := ParseDuration(words[i])
+			if err != nil {
+				return 0, nil, err
+			}
+			if d < minBenchmark || d > maxBenchmark {
+				return 0, nil, fmt.Errorf("%w: %s %s", ErrRange, words[i-1], words[i])
+			}
+			b[id] = d
+			continue
+		}
+
+		d, err := ParseDuration(words[i])
+		if err != nil || target > 0 {
+			return 0, nil, fmt.Errorf("%w: %q", ErrSyntax, words[i])
+		}
+		if d < minTarget || d > maxTarget {
+			return 0, nil, fmt.Errorf("%w: %s", ErrRange, words[i])
+		}
+		target = d
+	}
+	if b[Run] <= 0 {
+		return 0, nil, ErrNoRunBenchmark
+	}
+	return target, b, nil
+}
+
+// ParseSplits разбирает времена отрезков симуляции в порядке забега: бег, станция, …
+// и необязательное общее время переходов последним. Результат — по одному значению
+// на отрезок Layout(); незаписанный roxzone — ноль
+func ParseSplits(text string) ([]time.Duration, error) {
+	words := fields(text)
+	segments := len(Layout())
+	if len(words) != segments && len(words) != segments-1 {
+		return nil, fmt.Errorf("%w: %d вместо %d", ErrSplitCount, len(words), segments-1)
+	}
+
+	splits := make([]time.Duration, segments)
+	for i, w := range words {
+		d, err := ParseDuration(w)
+		if err != nil {
+			return nil, err
+		}
+		if d <= 0 || d > maxBenchmark {
+			return nil, fmt.Errorf("%w: %s", ErrRange, w)
+		}
+		splits[i] = d
+	}
+	return splits, nil
+}
@@ -3,14 +3,18 @@ package i18n
 import (
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
-	"path/filepath"
+	"path"
+	"sort"
 	"strings"
 	"sync"
+
+	"workbot/locales"
 )
 
-// Language представляет поддерживаемый язык
+// Language представляет поддерживаемый язык (код файла локализации: ru, en, uk, kk...)
 type Language string
 
 const (
@@ -19,57 +23,110 @@ const (
 	DefaultLang Language = LangRussian
 )
 
+// Служебные ключи, которые должен содержать каждый файл локализации
+const (
+	keyLanguageName = "language_name"
+	keyLanguageFlag = "language_flag"
+)
+
 // translations хранит все переводы
 var translations = struct {
 	sync.RWMutex
 	data map[Language]map[string]string
 }{data: make(map[Language]map[string]string)}
 
-// Load загружает переводы из файлов локализации
+// Load загружает переводы из каталога локализации.
+// Язык определяется по имени файла: достаточно положить uk.json рядом с ru.json.
+// Файлы из каталога дополняют и переопределяют встроенные в бинарник локализации
 func Load(localesDir string) error {
-	translations.Lock()
-	defer translations.Unlock()
+	if err := loadEmbedded(); err != nil {
+		return err
+	}
+	return LoadFS(os.DirFS(localesDir))
+}
 
-	languages := []Language{LangRussian, LangEnglish}
+// LoadFS загружает все файлы *.json из fsys
+func LoadFS(fsys fs.FS) error {
+	return loadFS(fsys, true)
+}
 
-	for _, lang := range languages {
-		filePath := filepath.Join(localesDir, string(lang)+".json")
-		data, err := os.ReadFile(filePath)
+// loadFS загружает файлы локализации; без replace уже загруженные языки не перезаписываются
+func loadFS(fsys fs.FS, replace bool) error {
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("файлы локализации не найдены")
+	}
+
+	loaded := make(map[Language]map[string]string, len(files))
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
 		if err != nil {
-			return fmt.Errorf("ошибка чтения файла локализации %s: %w", filePath, err)
+			return fmt.Errorf("ошибка чтения файла локализации %s: %w", file, err)
 		}
 
 		var langData map[string]string
 		if err := json.Unmarshal(data, &langData); err != nil {
-			return fmt.Errorf("ошибка парсинга файла локализации %s: %w", filePath, err)
+			return fmt.Errorf("ошибка парсинга файла локализации %s: %w", file, err)
 		}
 
+		lang := Language(strings.ToLower(strings.TrimSuffix(path.Base(file), ".json")))
+		loaded[lang] = langData
+	}
+
+	translations.Lock()
+	defer translations.Unlock()
+	for lang, langData := range loaded {
+		if _, ok := translations.data[lang]; ok && !replace {
+			continue
+		}
 		translations.data[lang] = langData
 		log.Printf("Загружена локализация: %s (%d ключей)", lang, len(langData))
 	}
-
 	return nil
 }
 
-// T возвращает перевод для указанного ключа и языка
-func T(key string, lang Language) string {
+var (
+	embeddedOnce sync.Once
+	embeddedErr  error
+)
+
+// loadEmbedded один раз загружает локализации, встроенные в бинарник.
+// Языки, уже загруженные из каталога, не перезаписываются
+func loadEmbedded() error {
+	embeddedOnce.Do(func() {
+		embeddedErr = loadFS(locales.FS, false)
+	})
+	return embeddedErr
+}
+
+// lookup ищет перевод ключа с запасным вариантом на языке по умолчанию
+func lookup(key string, lang Language) (string, bool) {
+	if err := loadEmbedded(); err != nil {
+		log.Printf("Ошибка загрузки встроенной локализации: %v", err)
+	}
+
 	translations.RLock()
 	defer translations.RUnlock()
 
 	// Пробуем получить перевод для указанного языка
-	if langData, ok := translations.data[lang]; ok {
-		if text, ok := langData[key]; ok {
-			return text
-		}
+	if text, ok := translations.data[lang][key]; ok {
+		return text, true
 	}
 
 	// Fallback на русский
-	if lang != DefaultLang {
-		if langData, ok := translations.data[DefaultLang]; ok {
-			if text, ok := langData[key]; ok {
-				return text
-			}
-		}
+	if text, ok := translations.data[DefaultLang][key]; ok {
+		return text, true
+	}
+	return "", false
+}
+
+// T возвращает перевод для указанного ключа и языка
+func T(key string, lang Language) string {
+	if text, ok := lookup(key, lang); ok {
+		return text
 	}
 
 	// Если ключ не найден, возвращаем сам ключ
@@ -86,42 +143,84 @@ func Tf(key string, lang Language, args ...interface{}) string {
 	return fmt.Sprintf(template, args...)
 }
 
+// Match возвращает тот из ключей keys, перевод которого на любом языке совпадает с text.
+// Используется для обработки нажатий кнопок reply-клавиатуры по ключу, а не по тексту
+func Match(text string, keys ...string) string {
+	if text == "" {
+		return ""
+	}
+	if err := loadEmbedded(); err != nil {
+		log.Printf("Ошибка загрузки встроенной локализации: %v", err)
+	}
+
+	translations.RLock()
+	defer translations.RUnlock()
+	for _, key := range keys {
+		for _, langData := range translations.data {
+			if langData[key] == text {
+				return key
+			}
+		}
+	}
+	return ""
+}
+
+// Is проверяет, что text — перевод ключа key на одном из языков
+func Is(text, key string) bool {
+	return Match(text, key) == key
+}
+
+// Languages возвращает загруженные языки: сначала язык по умолчанию, затем по алфавиту
+func Languages() []Language {
+	if err := loadEmbedded(); err != nil {
+		log.Printf("Ошибка загрузки встроенной локализации: %v", err)
+	}
+
+	translations.RLock()
+	defer translations.RUnlock()
+	langs := make([]Language, 0, len(translations.data))
+	for lang := range translations.data {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool {
+		if (langs[i] == DefaultLang) != (langs[j] == DefaultLang) {
+			return langs[i] == DefaultLang
+		}
+		return langs[i] < langs[j]
+	})
+	return langs
+}
+
 // IsValidLanguage проверяет, является ли язык поддерживаемым
 func IsValidLanguage(lang string) bool {
-	switch Language(strings.ToLower(lang)) {
-	case LangRussian, LangEnglish:
-		return true
-	default:
-		return false
+	for _, l := range Languages() {
+		if l == Language(strings.ToLower(lang)) {
+			return true
+		}
 	}
+	return false
 }
 
-// ParseLanguage преобразует строку в Language
+// ParseLanguage преобразует строку в Language; неизвестный язык заменяется языком по умолчанию
 func ParseLanguage(lang string) Language {
-	switch Language(strings.ToLower(lang)) {
-	case LangEnglish:
-		return LangEnglish
-	default:
-		return LangRussian
+	if IsValidLanguage(lang) {
+		return Language(strings.ToLower(lang))
 	}
+	return DefaultLang
 }
 
 // GetLanguageName возвращает название языка на этом языке
 func GetLanguageName(lang Language) string {
-	switch lang {
-	case LangEnglish:
-		return "English"
-	default:
-		return "Русский"
+	if name, ok := lookup(keyLanguageName, lang); ok {
+		return name
 	}
+	return string(lang)
 }
 
 // GetLanguageFlag возвращает флаг для языка
 func GetLanguageFlag(lang Language) string {
-	switch lang {
-	case LangEnglish:
-		return "🇬🇧"
-	default:
-		return "🇷🇺"
+	if flag, ok := lookup(keyLanguageFlag, lang); ok {
+		return flag
 	}
+	return "🌐"
 }
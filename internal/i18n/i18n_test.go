@@ -0,0 +1,95 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestPluralCategory(t *testing.T) {
+	tests := []struct {
+		lang Language
+		n    int
+		want string
+	}{
+		{"ru", 1, PluralOne},
+		{"ru", 21, PluralOne},
+		{"ru", 2, PluralFew},
+		{"ru", 4, PluralFew},
+		{"ru", 22, PluralFew},
+		{"ru", 5, PluralMany},
+		{"ru", 11, PluralMany},
+		{"ru", 12, PluralMany},
+		{"ru", 14, PluralMany},
+		{"ru", 111, PluralMany},
+		{"ru", 0, PluralMany},
+		{"uk", 3, PluralFew},
+		{"en", 1, PluralOne},
+		{"en", 0, PluralOther},
+		{"en", 21, PluralOther},
+		{"kk", 2, PluralOther},
+		{"xx", 1, PluralOne},
+	}
+	for _, tt := range tests {
+		if got := PluralCategory(tt.lang, tt.n); got != tt.want {
+			t.Errorf("PluralCategory(%s, %d) = %s, want %s", tt.lang, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestDropInLanguage(t *testing.T) {
+	fsys := fstest.MapFS{
+		"kk.json": {Data: []byte(`{
+			"language_name": "Қазақша",
+			"language_flag": "🇰🇿",
+			"cancel": "Болдырмау",
+			"days.one": "%d күн",
+			"days.other": "%d күн"
+		}`)},
+	}
+	if err := LoadFS(fsys); err != nil {
+		t.Fatal(err)
+	}
+
+	if !IsValidLanguage("kk") || ParseLanguage("KK") != "kk" {
+		t.Fatal("kk should be available after loading kk.json")
+	}
+	if got := GetLanguageName("kk"); got != "Қазақша" {
+		t.Errorf("GetLanguageName(kk) = %q", got)
+	}
+	if got := Tn("days", "kk", 5); got != "5 күн" {
+		t.Errorf("Tn(days, kk, 5) = %q", got)
+	}
+	// Ключа нет в kk.json — берётся русский перевод
+	if got := T("back", "kk"); got != T("back", LangRussian) {
+		t.Errorf("fallback to default language: got %q", got)
+	}
+	if langs := Languages(); langs[0] != DefaultLang {
+		t.Errorf("default language should be first, got %v", langs)
+	}
+}
+
+func TestTnRussian(t *testing.T) {
+	for n, want := range map[int]string{1: "1 день", 3: "3 дня", 5: "5 дней", 11: "11 дней", 21: "21 день"} {
+		if got := Tn("days", LangRussian, n); got != want {
+			t.Errorf("Tn(days, ru, %d) = %q, want %q", n, got, want)
+		}
+	}
+	if got := Tn("days", LangEnglish, 1); got != "1 day" {
+		t.Errorf("Tn(days, en, 1) = %q", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	if got := Match("Cancel", "back", "cancel"); got != "cancel" {
+		t.Errorf("Match(Cancel) = %q", got)
+	}
+	if got := Match("Отмена", "back", "cancel"); got != "cancel" {
+		t.Errorf("Match(Отмена) = %q", got)
+	}
+	if got := Match("что-то", "back", "cancel"); got != "" {
+		t.Errorf("Match(unknown) = %q", got)
+	}
+	if !Is("Назад", "back") || Is("Назад", "cancel") {
+		t.Error("Is mismatch")
+	}
+}
@@ -0,0 +1,74 @@
+package i18n
+
+import (
+	"fmt"
+	"log"
+)
+
+// Категории множественного числа CLDR
+const (
+	PluralOne   = "one"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// pluralRules — правила CLDR для целых чисел.
+// Языки, которых здесь нет, используют правило «one/other», как английский
+var pluralRules = map[Language]func(n int) string{
+	"ru": slavicPlural,
+	"uk": slavicPlural,
+	"be": slavicPlural,
+	"en": oneOtherPlural,
+	"kk": oneOtherPlural,
+	"de": oneOtherPlural,
+}
+
+// slavicPlural — 1 день, 2 дня, 5 дней, 11 дней, 21 день
+func slavicPlural(n int) string {
+	if n < 0 {
+		n = -n
+	}
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return PluralOne
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// oneOtherPlural — 1 day, 2 days
+func oneOtherPlural(n int) string {
+	if n == 1 || n == -1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// PluralCategory возвращает категорию множественного числа для n в языке lang
+func PluralCategory(lang Language, n int) string {
+	if rule, ok := pluralRules[lang]; ok {
+		return rule(n)
+	}
+	return oneOtherPlural(n)
+}
+
+// Tn возвращает перевод с учётом числа n. В файле локализации ключ задаётся
+// формами key.one, key.few, key.many, key.other; отсутствующая форма заменяется key.other.
+// Без args строка форматируется самим числом: "days.few": "%d дня"
+func Tn(key string, lang Language, n int, args ...interface{}) string {
+	template, ok := lookup(key+"."+PluralCategory(lang, n), lang)
+	if !ok {
+		template, ok = lookup(key+"."+PluralOther, lang)
+	}
+	if !ok {
+		log.Printf("Перевод не найден: key=%s (n=%d), lang=%s", key, n, lang)
+		return fmt.Sprintf("%d %s", n, key)
+	}
+	if len(args) == 0 {
+		args = []interface{}{n}
+	}
+	return fmt.Sprintf(template, args...)
+}
@@ -0,0 +1,353 @@
+package interchange
+
+import (
+	"fmt"
+	"time"
+
+	"workbot/clients/ai"
+	"workbot/internal/models"
+	"workbot/internal/training"
+)
+
+// loadSpec переводит группу подходов в спецификацию нагрузки шаблона
+func (s SetGroup) loadSpec() training.LoadSpec {
+	return training.LoadSpec{Percent: s.Percent, RPE: s.RPE, Weight: s.WeightKg}
+}
+
+// setGroupFromLoad создаёт группу подходов из спецификации нагрузки
+func setGroupFromLoad(sets int, reps string, spec training.LoadSpec) SetGroup {
+	return SetGroup{Sets: sets, Reps: reps, Percent: spec.Percent, WeightKg: spec.Weight, RPE: spec.RPE}
+}
+
+// FromTemplate конвертирует шаблон программы в формат обмена.
+// Подряд идущие строки одного упражнения в дне объединяются в одно упражнение с несколькими группами подходов
+func FromTemplate(t *models.ProgramTemplate) *Program {
+	p := &Program{
+		Version:     SchemaVersion,
+		Name:        t.Name,
+		Description: t.Description,
+		Goal:        t.Goal,
+		DaysPerWeek: t.DaysPerWeek,
+	}
+
+	for _, row := range t.Exercises {
+		week := p.week(row.WeekNum)
+		day := week.day(row.DayNum, row.DayName)
+
+		notes := row.Notes
+		spec, err := training.ParseLoadSpec(row.Load)
+		if err != nil {
+			// Нераспознанная нагрузка сохраняется в заметках
+			notes = joinNotes(row.Load, notes)
+			spec = training.LoadSpec{}
+		}
+		group := setGroupFromLoad(row.Sets, row.Reps, spec)
+
+		if n := len(day.Exercises); n > 0 {
+			last := &day.Exercises[n-1]
+			if last.Name == row.ExerciseName && last.Tempo == row.Tempo &&
+				last.RestSeconds == row.RestSeconds && last.Notes == notes {
+				last.Sets = append(last.Sets, group)
+				continue
+			}
+		}
+		day.Exercises = append(day.Exercises, Exercise{
+			Name:        row.ExerciseName,
+			Tempo:       row.Tempo,
+			RestSeconds: row.RestSeconds,
+			Notes:       notes,
+			Sets:        []SetGroup{group},
+		})
+	}
+
+	return p
+}
+
+// ToTemplate конвертирует программу в шаблон: каждая группа подходов становится строкой шаблона
+func ToTemplate(p *Program) *models.ProgramTemplate {
+	t := &models.ProgramTemplate{
+		Name:        p.Name,
+		Description: p.Description,
+		Goal:        p.Goal,
+		TotalWeeks:  p.TotalWeeks(),
+		DaysPerWeek: p.daysPerWeek(),
+	}
+
+	for _, w := range p.Weeks {
+		for _, d := range w.Days {
+			order := 0
+			for _, ex := range d.Exercises {
+				for _, s := range ex.Sets {
+					order++
+					t.Exercises = append(t.Exercises, models.ProgramTemplateExercise{
+						WeekNum:      w.Week,
+						DayNum:       d.Day,
+						DayName:      d.Name,
+						OrderNum:     order,
+						ExerciseName: ex.Name,
+						Sets:         s.Sets,
+						Reps:         s.Reps,
+						Load:         s.loadSpec().String(),
+						RestSeconds:  ex.RestSeconds,
+						Tempo:        ex.Tempo,
+						Notes:        ex.Notes,
+					})
+				}
+			}
+		}
+	}
+
+	return t
+}
+
+// FromGenerated конвертирует сгенерированную FIT программу
+func FromGenerated(g *models.GeneratedProgram) *Program {
+	p := &Program{
+		Version:     SchemaVersion,
+		Name:        fmt.Sprintf("%s (%d нед.)", g.Goal, g.TotalWeeks),
+		Goal:        string(g.Goal),
+		Client:      g.ClientName,
+		DaysPerWeek: g.DaysPerWeek,
+	}
+
+	for _, ph := range g.Phases {
+		p.Phases = append(p.Phases, Phase{Name: ph.Name, WeekStart: ph.WeekStart, WeekEnd: ph.WeekEnd, Focus: ph.Focus})
+	}
+	for _, gw := range g.Weeks {
+		week := Week{Week: gw.WeekNum, Phase: gw.PhaseName, Deload: gw.IsDeload}
+		for _, gd := range gw.Days {
+			day := Day{Day: gd.DayNum, Name: gd.Name}
+			for _, ex := range gd.Exercises {
+				spec := training.LoadSpecFromPrescription(ex.Weight, ex.WeightPercent, ex.RPE)
+				day.Exercises = append(day.Exercises, Exercise{
+					Name:        ex.ExerciseName,
+					Tempo:       ex.Tempo,
+					RestSeconds: ex.RestSeconds,
+					Notes:       ex.Notes,
+					Sets:        []SetGroup{setGroupFromLoad(ex.Sets, ex.Reps, spec)},
+				})
+			}
+			week.Days = append(week.Days, day)
+		}
+		p.Weeks = append(p.Weeks, week)
+	}
+
+	return p
+}
+
+// FromPL конвертирует сгенерированную PL программу, включая 1ПМ атлета
+func FromPL(g *ai.PLGeneratedProgram) *Program {
+	p := &Program{
+		Version: SchemaVersion,
+		Name:    g.Name,
+		Goal:    "strength",
+		OneRM:   plOneRM(g.AthleteMaxes),
+	}
+
+	for _, gw := range g.Weeks {
+		week := Week{Week: gw.WeekNum, Phase: gw.Phase}
+		for i, gd := range gw.Workouts {
+			day := Day{Day: i + 1, Name: gd.Name}
+			for _, ex := range gd.Exercises {
+				exercise := Exercise{Name: ex.Name, RestSeconds: 180}
+				for _, s := range ex.Sets {
+					group := SetGroup{Sets: s.Sets, Reps: s.RepsLabel(), Percent: s.Percent}
+					if group.Sets <= 0 {
+						group.Sets = 1
+					}
+					if s.Percent <= 0 {
+						group.WeightKg = s.WeightKg
+					}
+					exercise.Sets = append(exercise.Sets, group)
+				}
+				if len(exercise.Sets) > 0 {
+					day.Exercises = append(day.Exercises, exercise)
+				}
+			}
+			week.Days = append(week.Days, day)
+		}
+		if len(gw.Workouts) > p.DaysPerWeek {
+			p.DaysPerWeek = len(gw.Workouts)
+		}
+		p.Weeks = append(p.Weeks, week)
+	}
+
+	return p
+}
+
+// plOneRM переводит 1ПМ атлета в карту по названиям упражнений PL генератора
+func plOneRM(m ai.AthleteMaxes) map[string]float64 {
+	pm := make(map[string]float64)
+	for name, v := range map[string]float64{
+		"Присед":         m.Squat,
+		"Жим лёжа":       m.Bench,
+		"Становая тяга":  m.Deadlift,
+		"Ягодичный мост": m.HipThrust,
+	} {
+		if v > 0 {
+			pm[name] = v
+		}
+	}
+	if len(pm) == 0 {
+		return nil
+	}
+	return pm
+}
+
+// FromTrainingPlan конвертирует план plancli (models.TrainingPlan)
+func FromTrainingPlan(plan *models.TrainingPlan) *Program {
+	p := &Program{
+		Version:     SchemaVersion,
+		Name:        plan.Name,
+		Description: plan.Description,
+		Goal:        plan.Goal,
+		Client:      plan.ClientName,
+		DaysPerWeek: plan.DaysPerWeek,
+		OneRM:       plan.OnePMData,
+	}
+	if !plan.StartDate.IsZero() {
+		p.StartDate = plan.StartDate.Format("2006-01-02")
+	}
+
+	for _, m := range plan.Mesocycles {
+		p.Phases = append(p.Phases, Phase{Name: m.Name, WeekStart: m.WeekStart, WeekEnd: m.WeekEnd, Focus: string(m.Phase)})
+	}
+	for _, tw := range plan.Weeks {
+		week := Week{Week: tw.WeekNum, Phase: string(tw.Phase), Deload: tw.IsDeload}
+		for _, dw := range tw.Workouts {
+			day := Day{Day: dw.DayNum, Name: dw.Name}
+			for _, ex := range dw.Exercises {
+				spec := training.LoadSpecFromPrescription(ex.WeightKg, ex.WeightPercent, ex.RPE)
+				day.Exercises = append(day.Exercises, Exercise{
+					Name:        ex.ExerciseName,
+					Tempo:       ex.Tempo,
+					RestSeconds: ex.RestSeconds,
+					Notes:       ex.Notes,
+					Sets:        []SetGroup{setGroupFromLoad(ex.Sets, ex.Reps, spec)},
+				})
+			}
+			week.Days = append(week.Days, day)
+		}
+		p.Weeks = append(p.Weeks, week)
+	}
+
+	return p
+}
+
+// ToTrainingPlan конвертирует программу в план plancli.
+// Каждая группа подходов становится отдельной строкой упражнения
+func ToTrainingPlan(p *Program) *models.TrainingPlan {
+	plan := &models.TrainingPlan{
+		Name:        p.Name,
+		Description: p.Description,
+		Goal:        p.Goal,
+		ClientName:  p.Client,
+		DaysPerWeek: p.daysPerWeek(),
+		TotalWeeks:  p.TotalWeeks(),
+		OnePMData:   p.OneRM,
+		Status:      models.PlanStatusDraft,
+	}
+
+	plan.StartDate = time.Now()
+	if p.StartDate != "" {
+		if d, err := time.Parse("2006-01-02", p.StartDate); err == nil {
+			plan.StartDate = d
+		}
+	}
+	end := plan.StartDate.AddDate(0, 0, plan.TotalWeeks*7-1)
+	plan.EndDate = &end
+
+	for i, ph := range p.Phases {
+		plan.Mesocycles = append(plan.Mesocycles, models.Mesocycle{
+			Name:      ph.Name,
+			Phase:     models.PlanPhase(ph.Focus),
+			WeekStart: ph.WeekStart,
+			WeekEnd:   ph.WeekEnd,
+			OrderNum:  i + 1,
+		})
+	}
+	for _, w := range p.Weeks {
+		tw := models.TrainingWeek{WeekNum: w.Week, Phase: models.PlanPhase(w.Phase), IsDeload: w.Deload}
+		for _, d := range w.Days {
+			dw := models.DayWorkout{DayNum: d.Day, Name: d.Name}
+			for _, ex := range d.Exercises {
+				for _, s := range ex.Sets {
+					dw.Exercises = append(dw.Exercises, models.WorkoutExerciseV2{
+						OrderNum:      len(dw.Exercises) + 1,
+						ExerciseName:  ex.Name,
+						Sets:          s.Sets,
+						Reps:          s.Reps,
+						WeightPercent: s.Percent,
+						WeightKg:      s.WeightKg,
+						RestSeconds:   ex.RestSeconds,
+						Tempo:         ex.Tempo,
+						RPE:           s.RPE,
+						Notes:         ex.Notes,
+					})
+				}
+			}
+			tw.Workouts = append(tw.Workouts, dw)
+		}
+		plan.Weeks = append(plan.Weeks, tw)
+	}
+
+	return plan
+}
+
+// TotalWeeks возвращает количество недель программы (по максимальному номеру недели)
+func (p *Program) TotalWeeks() int {
+	total := 0
+	for _, w := range p.Weeks {
+		if w.Week > total {
+			total = w.Week
+		}
+	}
+	return total
+}
+
+// daysPerWeek возвращает заданное число тренировок в неделю или максимум по неделям
+func (p *Program) daysPerWeek() int {
+	if p.DaysPerWeek > 0 {
+		return p.DaysPerWeek
+	}
+	days := 0
+	for _, w := range p.Weeks {
+		if len(w.Days) > days {
+			days = len(w.Days)
+		}
+	}
+	return days
+}
+
+// week возвращает неделю с номером num, добавляя её при необходимости
+func (p *Program) week(num int) *Week {
+	for i := range p.Weeks {
+		if p.Weeks[i].Week == num {
+			return &p.Weeks[i]
+		}
+	}
+	p.Weeks = append(p.Weeks, Week{Week: num})
+	return &p.Weeks[len(p.Weeks)-1]
+}
+
+// day возвращает день с номером num, добавляя его при необходимости
+func (w *Week) day(num int, name string) *Day {
+	for i := range w.Days {
+		if w.Days[i].Day == num {
+			return &w.Days[i]
+		}
+	}
+	w.Days = append(w.Days, Day{Day: num, Name: name})
+	return &w.Days[len(w.Days)-1]
+}
+
+func joinNotes(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	default:
+		return a + " " + b
+	}
+}
@@ -0,0 +1,180 @@
+// Package interchange описывает версионированный формат обмена программами
+// тренировок (JSON/YAML) и конвертацию в модели бота и обратно.
+// Описание формата: docs/program_format.md
+package interchange
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// SchemaVersion текущая версия формата
+const SchemaVersion = 1
+
+// Format формат файла программы
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// Program программа тренировок в формате обмена
+type Program struct {
+	Version     int                `json:"version" yaml:"version"`
+	Name        string             `json:"name" yaml:"name"`
+	Description string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Goal        string             `json:"goal,omitempty" yaml:"goal,omitempty"`
+	Client      string             `json:"client,omitempty" yaml:"client,omitempty"`
+	StartDate   string             `json:"start_date,omitempty" yaml:"start_date,omitempty"` // YYYY-MM-DD
+	DaysPerWeek int                `json:"days_per_week,omitempty" yaml:"days_per_week,omitempty"`
+	OneRM       map[string]float64 `json:"one_rm,omitempty" yaml:"one_rm,omitempty"` // 1ПМ по упражнениям, кг
+	Phases      []Phase            `json:"phases,omitempty" yaml:"phases,omitempty"`
+	Weeks       []Week             `json:"weeks" yaml:"weeks"`
+}
+
+// Phase фаза (мезоцикл) программы
+type Phase struct {
+	Name      string `json:"name" yaml:"name"`
+	WeekStart int    `json:"week_start" yaml:"week_start"`
+	WeekEnd   int    `json:"week_end" yaml:"week_end"`
+	Focus     string `json:"focus,omitempty" yaml:"focus,omitempty"`
+}
+
+// Week неделя программы
+type Week struct {
+	Week   int    `json:"week" yaml:"week"`
+	Phase  string `json:"phase,omitempty" yaml:"phase,omitempty"`
+	Deload bool   `json:"deload,omitempty" yaml:"deload,omitempty"`
+	Days   []Day  `json:"days" yaml:"days"`
+}
+
+// Day тренировочный день
+type Day struct {
+	Day       int        `json:"day" yaml:"day"`
+	Name      string     `json:"name,omitempty" yaml:"name,omitempty"`
+	Exercises []Exercise `json:"exercises" yaml:"exercises"`
+}
+
+// Exercise упражнение с группами подходов
+type Exercise struct {
+	Name        string     `json:"name" yaml:"name"`
+	Tempo       string     `json:"tempo,omitempty" yaml:"tempo,omitempty"`               // "3-1-1-0"
+	RestSeconds int        `json:"rest_seconds,omitempty" yaml:"rest_seconds,omitempty"` // отдых между подходами
+	Notes       string     `json:"notes,omitempty" yaml:"notes,omitempty"`
+	Sets        []SetGroup `json:"sets" yaml:"sets"`
+}
+
+// SetGroup группа одинаковых подходов: sets × reps с нагрузкой.
+// Нагрузка задаётся одним из способов: percent (% от 1ПМ), weight_kg (абсолютный вес)
+// и/или rpe (целевое усилие)
+type SetGroup struct {
+	Sets     int     `json:"sets" yaml:"sets"`
+	Reps     string  `json:"reps" yaml:"reps"` // "5", "8-10", "AMRAP", "30с"
+	Percent  float64 `json:"percent,omitempty" yaml:"percent,omitempty"`
+	WeightKg float64 `json:"weight_kg,omitempty" yaml:"weight_kg,omitempty"`
+	RPE      float64 `json:"rpe,omitempty" yaml:"rpe,omitempty"`
+}
+
+// FormatFromFilename определяет формат по расширению файла
+func FormatFromFilename(name string) (Format, bool) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return FormatJSON, true
+	case ".yaml", ".yml":
+		return FormatYAML, true
+	default:
+		return "", false
+	}
+}
+
+// utf8BOM метка порядка байт, которую добавляют некоторые редакторы
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// DetectFormat определяет формат по содержимому: JSON начинается с '{'
+func DetectFormat(data []byte) Format {
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(data, utf8BOM), " \t\r\n")
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+// Decode разбирает и проверяет программу.
+// Ошибка разбора содержит строку и столбец, ошибки проверки — путь к полю (ValidationErrors)
+func Decode(data []byte, format Format) (*Program, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+
+	var p Program
+	switch format {
+	case FormatJSON:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&p); err != nil {
+			return nil, jsonSyntaxError(data, err)
+		}
+	case FormatYAML:
+		if err := yaml.UnmarshalStrict(data, &p); err != nil {
+			return nil, fmt.Errorf("ошибка YAML: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("неизвестный формат: %q", format)
+	}
+
+	if errs := Validate(&p); len(errs) > 0 {
+		return nil, errs
+	}
+	return &p, nil
+}
+
+// Encode сериализует программу в выбранный формат
+func Encode(p *Program, format Format) ([]byte, error) {
+	if p.Version == 0 {
+		p.Version = SchemaVersion
+	}
+
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(p, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case FormatYAML:
+		return yaml.Marshal(p)
+	default:
+		return nil, fmt.Errorf("неизвестный формат: %q", format)
+	}
+}
+
+// jsonSyntaxError дополняет ошибку JSON номером строки и столбца
+func jsonSyntaxError(data []byte, err error) error {
+	var offset int64
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		offset = e.Offset
+	case *json.UnmarshalTypeError:
+		if e.Field != "" {
+			return fmt.Errorf("ошибка JSON: %s: ожидается %s, получено %s", e.Field, e.Type, e.Value)
+		}
+		offset = e.Offset
+	default:
+		return fmt.Errorf("ошибка JSON: %w", err)
+	}
+
+	line, col := 1, 1
+	for i := int64(0); i < offset && i < int64(len(data)); i++ {
+		if data[i] == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return fmt.Errorf("ошибка JSON (строка %d, столбец %d): %w", line, col, err)
+}
@@ -0,0 +1,161 @@
+package interchange
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleYAML = `version: 1
+name: Сила 3x
+goal: strength
+start_date: "2025-03-03"
+one_rm:
+  Жим лёжа: 100
+phases:
+  - name: Накопление
+    week_start: 1
+    week_end: 1
+weeks:
+  - week: 1
+    phase: Накопление
+    days:
+      - day: 1
+        name: День A
+        exercises:
+          - name: Жим лёжа
+            rest_seconds: 180
+            sets:
+              - {sets: 1, reps: "3", percent: 85}
+              - {sets: 3, reps: "5", percent: 75, rpe: 8}
+          - name: Подтягивания
+            tempo: 2-0-1-0
+            sets:
+              - {sets: 3, reps: 8-10, rpe: 8}
+          - name: Выпады
+            sets:
+              - {sets: 3, reps: "12", weight_kg: 20}
+`
+
+func TestDecodeRoundTrip(t *testing.T) {
+	p, err := Decode([]byte(sampleYAML), FormatYAML)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if p.Name != "Сила 3x" || len(p.Weeks[0].Days[0].Exercises) != 3 {
+		t.Fatalf("unexpected program: %+v", p)
+	}
+
+	for _, format := range []Format{FormatJSON, FormatYAML} {
+		data, err := Encode(p, format)
+		if err != nil {
+			t.Fatalf("Encode %s: %v", format, err)
+		}
+		if got := DetectFormat(data); got != format {
+			t.Errorf("DetectFormat = %s, want %s", got, format)
+		}
+		back, err := Decode(data, format)
+		if err != nil {
+			t.Fatalf("Decode %s: %v", format, err)
+		}
+		if !reflect.DeepEqual(p, back) {
+			t.Errorf("%s round trip mismatch:\n%+v\n%+v", format, p, back)
+		}
+	}
+}
+
+func TestTemplateRoundTrip(t *testing.T) {
+	p, err := Decode([]byte(sampleYAML), FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tpl := ToTemplate(p)
+	if len(tpl.Exercises) != 4 || tpl.TotalWeeks != 1 || tpl.DaysPerWeek != 1 {
+		t.Fatalf("template: %d rows, %d weeks, %d days", len(tpl.Exercises), tpl.TotalWeeks, tpl.DaysPerWeek)
+	}
+	if tpl.Exercises[1].Load != "75%1PM @RPE8" || tpl.Exercises[3].Load != "20кг" {
+		t.Errorf("loads = %q, %q", tpl.Exercises[1].Load, tpl.Exercises[3].Load)
+	}
+
+	back := FromTemplate(tpl)
+	if !reflect.DeepEqual(p.Weeks[0].Days, back.Weeks[0].Days) {
+		t.Errorf("template round trip mismatch:\n%+v\n%+v", p.Weeks[0].Days, back.Weeks[0].Days)
+	}
+}
+
+func TestValidationPaths(t *testing.T) {
+	doc := `{
+  "version": 1,
+  "name": "Тест",
+  "weeks": [
+    {"week": 1, "days": [
+      {"day": 1, "exercises": [
+        {"name": "Жим лёжа", "sets": [{"sets": 3, "reps": "5", "percent": 75, "rpe": 11}]},
+        {"name": "", "sets": [{"sets": 0, "reps": "5", "percent": 70, "weight_kg": 60}]}
+      ]},
+      {"day": 1, "exercises": []}
+    ]}
+  ]
+}`
+	_, err := Decode([]byte(doc), FormatJSON)
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+
+	want := []string{
+		"weeks[0].days[0].exercises[0].sets[0].rpe",
+		"weeks[0].days[0].exercises[1].name",
+		"weeks[0].days[0].exercises[1].sets[0].sets",
+		"weeks[0].days[0].exercises[1].sets[0]",
+		"weeks[0].days[1].day",
+		"weeks[0].days[1].exercises",
+	}
+	var got []string
+	for _, e := range verrs {
+		got = append(got, e.Path)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("paths = %v\nwant   %v", got, want)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format Format
+		want   string
+	}{
+		{"json syntax", "{\n  \"version\": 1,\n  \"name\": \"x\",,\n}", FormatJSON, "строка 3"},
+		{"json unknown field", `{"version": 1, "name": "x", "weekz": []}`, FormatJSON, "weekz"},
+		{"json type", `{"version": "1"}`, FormatJSON, "version"},
+		{"yaml unknown field", "version: 1\nname: x\nweekz: []\n", FormatYAML, "weekz"},
+		{"missing version", `{"name": "x", "weeks": []}`, FormatJSON, "version: обязательное поле"},
+		{"future version", `{"version": 99, "name": "x"}`, FormatJSON, "версия 99"},
+		{"bad start date", "version: 1\nname: x\nstart_date: 03.03.2025\n", FormatYAML, "start_date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode([]byte(tt.data), tt.format)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatFromFilename(t *testing.T) {
+	tests := map[string]Format{"plan.json": FormatJSON, "plan.YAML": FormatYAML, "plan.yml": FormatYAML}
+	for name, want := range tests {
+		if got, ok := FormatFromFilename(name); !ok || got != want {
+			t.Errorf("FormatFromFilename(%q) = %s, %v", name, got, ok)
+		}
+	}
+	if _, ok := FormatFromFilename("plan.txt"); ok {
+		t.Error("plan.txt should not be recognised")
+	}
+}
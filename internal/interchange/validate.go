@@ -0,0 +1,151 @@
+package interchange
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ValidationError ошибка проверки с путём к полю, например weeks[0].days[1].exercises[2].sets[0].rpe
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors список ошибок проверки
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// validator накапливает ошибки проверки
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) add(path, format string, args ...interface{}) {
+	v.errs = append(v.errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// Validate проверяет программу и возвращает все найденные ошибки
+func Validate(p *Program) ValidationErrors {
+	v := &validator{}
+
+	switch {
+	case p.Version == 0:
+		v.add("version", "обязательное поле (текущая версия %d)", SchemaVersion)
+	case p.Version > SchemaVersion:
+		v.add("version", "версия %d не поддерживается (максимум %d)", p.Version, SchemaVersion)
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		v.add("name", "обязательное поле")
+	}
+	if p.StartDate != "" {
+		if _, err := time.Parse("2006-01-02", p.StartDate); err != nil {
+			v.add("start_date", "ожидается дата в формате YYYY-MM-DD, получено %q", p.StartDate)
+		}
+	}
+	if p.DaysPerWeek < 0 || p.DaysPerWeek > 7 {
+		v.add("days_per_week", "должно быть от 1 до 7, получено %d", p.DaysPerWeek)
+	}
+	for name, pm := range p.OneRM {
+		if pm <= 0 {
+			v.add(fmt.Sprintf("one_rm[%q]", name), "1ПМ должен быть положительным")
+		}
+	}
+
+	for i, ph := range p.Phases {
+		path := fmt.Sprintf("phases[%d]", i)
+		if strings.TrimSpace(ph.Name) == "" {
+			v.add(path+".name", "обязательное поле")
+		}
+		if ph.WeekStart < 1 || ph.WeekEnd < ph.WeekStart {
+			v.add(path, "неверный диапазон недель %d–%d", ph.WeekStart, ph.WeekEnd)
+		} else if ph.WeekEnd > len(p.Weeks) {
+			v.add(path+".week_end", "неделя %d за пределами программы (%d нед.)", ph.WeekEnd, len(p.Weeks))
+		}
+	}
+
+	if len(p.Weeks) == 0 {
+		v.add("weeks", "программа должна содержать хотя бы одну неделю")
+	}
+	seenWeeks := make(map[int]bool)
+	for i, w := range p.Weeks {
+		path := fmt.Sprintf("weeks[%d]", i)
+		if w.Week < 1 {
+			v.add(path+".week", "номер недели должен быть положительным")
+		} else if seenWeeks[w.Week] {
+			v.add(path+".week", "неделя %d повторяется", w.Week)
+		}
+		seenWeeks[w.Week] = true
+		v.validateWeek(path, &w)
+	}
+
+	return v.errs
+}
+
+func (v *validator) validateWeek(path string, w *Week) {
+	if len(w.Days) == 0 {
+		v.add(path+".days", "неделя должна содержать хотя бы один день")
+	}
+	seenDays := make(map[int]bool)
+	for i, d := range w.Days {
+		dayPath := fmt.Sprintf("%s.days[%d]", path, i)
+		if d.Day < 1 || d.Day > 7 {
+			v.add(dayPath+".day", "номер дня должен быть от 1 до 7, получено %d", d.Day)
+		} else if seenDays[d.Day] {
+			v.add(dayPath+".day", "день %d повторяется", d.Day)
+		}
+		seenDays[d.Day] = true
+
+		if len(d.Exercises) == 0 {
+			v.add(dayPath+".exercises", "день должен содержать хотя бы одно упражнение")
+		}
+		for j, ex := range d.Exercises {
+			v.validateExercise(fmt.Sprintf("%s.exercises[%d]", dayPath, j), &ex)
+		}
+	}
+}
+
+func (v *validator) validateExercise(path string, ex *Exercise) {
+	if strings.TrimSpace(ex.Name) == "" {
+		v.add(path+".name", "обязательное поле")
+	}
+	if ex.RestSeconds < 0 || ex.RestSeconds > 3600 {
+		v.add(path+".rest_seconds", "должно быть от 0 до 3600, получено %d", ex.RestSeconds)
+	}
+	if len(ex.Sets) == 0 {
+		v.add(path+".sets", "упражнение должно содержать хотя бы одну группу подходов")
+	}
+
+	for i, s := range ex.Sets {
+		setPath := fmt.Sprintf("%s.sets[%d]", path, i)
+		if s.Sets < 1 || s.Sets > 50 {
+			v.add(setPath+".sets", "должно быть от 1 до 50, получено %d", s.Sets)
+		}
+		if strings.TrimSpace(s.Reps) == "" {
+			v.add(setPath+".reps", "обязательное поле")
+		}
+		if s.Percent < 0 || s.Percent > 120 {
+			v.add(setPath+".percent", "должно быть от 0 до 120, получено %g", s.Percent)
+		}
+		if s.WeightKg < 0 {
+			v.add(setPath+".weight_kg", "вес не может быть отрицательным")
+		}
+		if s.Percent > 0 && s.WeightKg > 0 {
+			v.add(setPath, "укажите либо percent, либо weight_kg")
+		}
+		if s.RPE != 0 && (s.RPE < 1 || s.RPE > 10) {
+			v.add(setPath+".rpe", "должно быть от 1 до 10, получено %g", s.RPE)
+		}
+	}
+}
@@ -273,6 +273,11 @@ type ClientProfile struct {
 	AvailableKBWeights []float64         `json:"available_kb_weights"` // Доступные гири (кг)
 	Location         TrainingLocation    `json:"location"`          // gym/home
 	OnePM            map[string]float64  `json:"one_pm"`            // 1ПМ по движениям
+	VolumeFactors    map[string]float64  `json:"volume_factors"`    // Адаптация объёма по мышечным группам (1.0 — по таблице)
+	CardioFactor     float64             `json:"cardio_factor"`     // Поправка объёма кардио по загруженным тренировкам (0 — без поправки)
+	HyroxSplits      map[string]int      `json:"hyrox_splits"`      // Целевое время отрезков забега Hyrox, сек (run_1…run_8, станции)
+	HyroxFocus       []string            `json:"hyrox_focus"`       // Акцент симуляций по итогам последней: run или ID станций
+	HyroxFading      bool                `json:"hyrox_fading"`      // В последней симуляции бег просел к концу
 }
 
 // ===============================================
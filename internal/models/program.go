@@ -79,6 +79,26 @@ type WorkoutExercise struct {
 	ActualWeight float64 `json:"actual_weight"`
 	ActualRPE    float64 `json:"actual_rpe"`
 	Completed    bool    `json:"completed"`
+
+	// Замена упражнения во время тренировки
+	OriginalExerciseName string `json:"original_exercise_name,omitempty"` // Что было в плане
+	SubstitutionReason   string `json:"substitution_reason,omitempty"`    // busy, noequip, knee...
+}
+
+// IsSubstituted возвращает true, если упражнение было заменено клиентом
+func (e *WorkoutExercise) IsSubstituted() bool {
+	return e.OriginalExerciseName != ""
+}
+
+// WorkoutSet представляет один выполненный подход упражнения
+type WorkoutSet struct {
+	ID                int       `json:"id"`
+	WorkoutExerciseID int       `json:"workout_exercise_id"`
+	SetNum            int       `json:"set_num"`
+	Reps              int       `json:"reps"`
+	Weight            float64   `json:"weight"`
+	RPE               float64   `json:"rpe"`
+	CreatedAt         time.Time `json:"created_at"`
 }
 
 // ClientForm представляет анкету клиента
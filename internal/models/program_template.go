@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// ProgramTemplate шаблон программы из библиотеки тренера
+type ProgramTemplate struct {
+	ID            int                       `json:"id"`
+	TrainerID     int64                     `json:"trainer_id"` // 0 — встроенный шаблон
+	OwnerName     string                    `json:"owner_name"` // Имя тренера-владельца (для общих шаблонов)
+	Name          string                    `json:"name"`
+	Description   string                    `json:"description"`
+	Goal          string                    `json:"goal"`
+	TotalWeeks    int                       `json:"total_weeks"`
+	DaysPerWeek   int                       `json:"days_per_week"`
+	IsShared      bool                      `json:"is_shared"`   // Виден другим тренерам
+	ClonedFrom    int                       `json:"cloned_from"` // ID шаблона-источника (0 если нет)
+	Exercises     []ProgramTemplateExercise `json:"exercises"`
+	ExerciseCount int                       `json:"exercise_count"`
+	CreatedAt     time.Time                 `json:"created_at"`
+	UpdatedAt     time.Time                 `json:"updated_at"`
+}
+
+// IsBuiltIn возвращает true для встроенных шаблонов
+func (t *ProgramTemplate) IsBuiltIn() bool {
+	return t.TrainerID == 0
+}
+
+// CanEdit проверяет, может ли тренер изменять или удалять шаблон
+func (t *ProgramTemplate) CanEdit(trainerID int64) bool {
+	return t.TrainerID != 0 && t.TrainerID == trainerID
+}
+
+// CanView проверяет, может ли тренер видеть шаблон: встроенный, свой или общий
+func (t *ProgramTemplate) CanView(trainerID int64) bool {
+	return t.IsBuiltIn() || t.TrainerID == trainerID || t.IsShared
+}
+
+// ProgramTemplateExercise упражнение шаблона
+type ProgramTemplateExercise struct {
+	ID           int    `json:"id"`
+	WeekNum      int    `json:"week_num"`
+	DayNum       int    `json:"day_num"`
+	DayName      string `json:"day_name"`
+	OrderNum     int    `json:"order_num"`
+	ExerciseName string `json:"exercise_name"`
+	Sets         int    `json:"sets"`
+	Reps         string `json:"reps"`
+	Load         string `json:"load"` // Плейсхолдер: "75%1PM", "@RPE8", "60кг"
+	RestSeconds  int    `json:"rest_seconds"`
+	Tempo        string `json:"tempo"`
+	Notes        string `json:"notes"`
+}
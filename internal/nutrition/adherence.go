@@ -0,0 +1,99 @@
+package nutrition
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// calorieTolerance — день в плане, если калории отличаются от цели не больше чем на 10%
+	calorieTolerance = 0.10
+	// proteinShare — белок засчитывается, если съедено не меньше 90% цели
+	proteinShare = 0.90
+)
+
+// Checkin — дневная отметка; нулевое поле — клиент его не заполнил
+type Checkin struct {
+	Date     time.Time
+	Calories int
+	Protein  int
+	Steps    int
+	Sleep    float64 // часы
+}
+
+// Summary — сводка отметок за период
+type Summary struct {
+	Days        int // дней в периоде
+	Logged      int // дней с отметкой
+	AvgCalories int // средние значения — по дням, где поле заполнено
+	AvgProtein  int
+	AvgSteps    int
+	AvgSleep    float64
+	CaloriesHit int // дней, когда калории попали в коридор ±10%
+	ProteinHit  int // дней, когда белка съедено не меньше 90% цели
+	Adherence   int // доля дней периода, когда выполнены и калории, и белок, %
+}
+
+// Summarize сводит отметки за days дней. Дни без отметки считаются
+// невыполненными: пропуски — тоже информация для тренера
+func Summarize(checkins []Checkin, t Targets, days int) Summary {
+	s := Summary{Days: days}
+	var calSum, protSum, stepSum, calN, protN, stepN, sleepN, onPlan int
+	var sleepSum float64
+
+	for _, c := range checkins {
+		if c.Calories <= 0 && c.Protein <= 0 && c.Steps <= 0 && c.Sleep <= 0 {
+			continue
+		}
+		s.Logged++
+
+		calOK := c.Calories > 0 && t.Calories > 0 &&
+			math.Abs(float64(c.Calories-t.Calories)) <= float64(t.Calories)*calorieTolerance
+		protOK := c.Protein > 0 && t.Protein > 0 &&
+			float64(c.Protein) >= float64(t.Protein)*proteinShare
+		if calOK {
+			s.CaloriesHit++
+		}
+		if protOK {
+			s.ProteinHit++
+		}
+		if calOK && protOK {
+			onPlan++
+		}
+
+		if c.Calories > 0 {
+			calSum += c.Calories
+			calN++
+		}
+		if c.Protein > 0 {
+			protSum += c.Protein
+			protN++
+		}
+		if c.Steps > 0 {
+			stepSum += c.Steps
+			stepN++
+		}
+		if c.Sleep > 0 {
+			sleepSum += c.Sleep
+			sleepN++
+		}
+	}
+
+	s.AvgCalories = avg(calSum, calN)
+	s.AvgProtein = avg(protSum, protN)
+	s.AvgSteps = avg(stepSum, stepN)
+	if sleepN > 0 {
+		s.AvgSleep = math.Round(sleepSum/float64(sleepN)*10) / 10
+	}
+	if days > 0 {
+		s.Adherence = onPlan * 100 / days
+	}
+	return s
+}
+
+func avg(sum, n int) int {
+	if n == 0 {
+		return 0
+	}
+	return int(math.Round(float64(sum) / float64(n)))
+}
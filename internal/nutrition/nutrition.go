@@ -0,0 +1,238 @@
+// Package nutrition считает энергетические потребности и цели по КБЖУ.
+//
+// Базовый обмен (BMR) оценивается по Миффлину — Сан Жеору, а если известен
+// процент жира — по Кетчу — МакАрдлу через сухую массу. Расход за сутки (TDEE)
+// — BMR, умноженный на коэффициент активности. Цель по калориям зависит от
+// задачи клиента (дефицит, поддержание, профицит), белок и жиры задаются
+// в граммах на килограмм веса, углеводы занимают оставшиеся калории.
+// Раз в неделю калории корректируются по фактической динамике веса (trend.go),
+// а дневные отметки сводятся в отчёт о соблюдении плана (adherence.go).
+// Пакет не знает о базе и боте.
+package nutrition
+
+import (
+	"errors"
+	"math"
+
+	"workbot/internal/models"
+)
+
+// Sex — пол; формула Миффлина — Сан Жеора для мужчин и женщин различается
+type Sex string
+
+const (
+	Male   Sex = "male"
+	Female Sex = "female"
+)
+
+// Activity — уровень повседневной активности вместе с тренировками
+type Activity string
+
+const (
+	Sedentary  Activity = "sedentary"   // сидячая работа, тренировок почти нет
+	Light      Activity = "light"       // 1–3 тренировки в неделю
+	Moderate   Activity = "moderate"    // 3–5 тренировок в неделю
+	Active     Activity = "active"      // 6–7 тренировок в неделю
+	VeryActive Activity = "very_active" // физическая работа и тренировки
+)
+
+// Activities — уровни активности по возрастанию
+var Activities = []Activity{Sedentary, Light, Moderate, Active, VeryActive}
+
+// Factor возвращает коэффициент активности; неизвестный уровень считается сидячим
+func (a Activity) Factor() float64 {
+	switch a {
+	case Light:
+		return 1.375
+	case Moderate:
+		return 1.55
+	case Active:
+		return 1.725
+	case VeryActive:
+		return 1.9
+	default:
+		return 1.2
+	}
+}
+
+// Goal — задача питания
+type Goal string
+
+const (
+	GoalFatLoss  Goal = "fat_loss" // снижение веса за счёт жира
+	GoalMaintain Goal = "maintain" // поддержание веса, рекомпозиция
+	GoalGain     Goal = "gain"     // набор мышечной массы
+)
+
+// Goals — задачи в порядке показа
+var Goals = []Goal{GoalFatLoss, GoalMaintain, GoalGain}
+
+// GoalFor подбирает задачу питания по цели тренировок
+func GoalFor(g models.TrainingGoal) Goal {
+	switch g {
+	case models.GoalFatLoss:
+		return GoalFatLoss
+	case models.GoalHypertrophy, models.GoalStrength:
+		return GoalGain
+	default:
+		return GoalMaintain
+	}
+}
+
+// goalParams — калории относительно TDEE, белок и жиры в г на кг веса
+// и целевой темп изменения веса в долях веса за неделю
+type goalParams struct {
+	calorieFactor float64
+	proteinPerKg  float64
+	fatPerKg      float64
+	weeklyRate    float64
+}
+
+var params = map[Goal]goalParams{
+	GoalFatLoss:  {calorieFactor: 0.80, proteinPerKg: 2.2, fatPerKg: 0.8, weeklyRate: -0.005},
+	GoalMaintain: {calorieFactor: 1.00, proteinPerKg: 1.8, fatPerKg: 0.9, weeklyRate: 0},
+	GoalGain:     {calorieFactor: 1.10, proteinPerKg: 1.8, fatPerKg: 1.0, weeklyRate: 0.0025},
+}
+
+func paramsFor(g Goal) goalParams {
+	if p, ok := params[g]; ok {
+		return p
+	}
+	return params[GoalMaintain]
+}
+
+// Formula — формула базового обмена
+type Formula string
+
+const (
+	MifflinStJeor Formula = "mifflin"
+	KatchMcArdle  Formula = "katch"
+)
+
+const (
+	// kcalPerGramProtein, kcalPerGramCarbs, kcalPerGramFat — калорийность макронутриентов
+	kcalPerGramProtein = 4
+	kcalPerGramCarbs   = 4
+	kcalPerGramFat     = 9
+	// minFatShare — жиры не опускаются ниже этой доли калорий
+	minFatShare = 0.20
+	// minBodyFat, maxBodyFat — процент жира вне диапазона считается ошибкой ввода
+	minBodyFat = 3
+	maxBodyFat = 60
+)
+
+// ErrIncomplete — данных не хватает ни для одной формулы
+var ErrIncomplete = errors.New("nutrition: недостаточно данных для расчёта")
+
+// Profile — данные клиента для расчёта; BodyFat == 0 — процент жира неизвестен
+type Profile struct {
+	Sex      Sex
+	Age      int
+	HeightCm float64
+	WeightKg float64
+	BodyFat  float64
+	Activity Activity
+}
+
+// LeanMass возвращает сухую массу в кг или 0, если процент жира неизвестен
+func (p Profile) LeanMass() float64 {
+	if p.BodyFat < minBodyFat || p.BodyFat > maxBodyFat {
+		return 0
+	}
+	return p.WeightKg * (1 - p.BodyFat/100)
+}
+
+// Energy — оценка расхода энергии, ккал в сутки
+type Energy struct {
+	BMR     float64
+	TDEE    float64
+	Formula Formula
+}
+
+// Estimate считает BMR и TDEE. Кетч — МакАрдл точнее, когда известен процент
+// жира: он не завышает обмен у людей с большим весом. Иначе — Миффлин — Сан Жеор,
+// которому нужны пол, возраст и рост
+func Estimate(p Profile) (Energy, error) {
+	if p.WeightKg <= 0 {
+		return Energy{}, ErrIncomplete
+	}
+
+	var e Energy
+	switch lbm := p.LeanMass(); {
+	case lbm > 0:
+		e.BMR = 370 + 21.6*lbm
+		e.Formula = KatchMcArdle
+	case p.HeightCm > 0 && p.Age > 0 && (p.Sex == Male || p.Sex == Female):
+		e.BMR = 10*p.WeightKg + 6.25*p.HeightCm - 5*float64(p.Age)
+		if p.Sex == Male {
+			e.BMR += 5
+		} else {
+			e.BMR -= 161
+		}
+		e.Formula = MifflinStJeor
+	default:
+		return Energy{}, ErrIncomplete
+	}
+	e.TDEE = e.BMR * p.Activity.Factor()
+	return e, nil
+}
+
+// Targets — цели на день: калории и граммы белков, жиров и углеводов
+type Targets struct {
+	Calories int
+	Protein  int
+	Fat      int
+	Carbs    int
+}
+
+// MinCalories — нижняя граница калорий, ниже которой план не опускается без врача
+func MinCalories(sex Sex) int {
+	if sex == Female {
+		return 1200
+	}
+	return 1500
+}
+
+// Plan считает расход и цели по КБЖУ для задачи g
+func Plan(p Profile, g Goal) (Targets, Energy, error) {
+	e, err := Estimate(p)
+	if err != nil {
+		return Targets{}, Energy{}, err
+	}
+	calories := roundTo(e.TDEE*paramsFor(g).calorieFactor, 10)
+	if min := MinCalories(p.Sex); calories < min {
+		calories = min
+	}
+	return Macros(calories, p.WeightKg, g), e, nil
+}
+
+// Macros раскладывает калории на макронутриенты: белок и жиры — по весу,
+// углеводы — остаток. Если белок и жиры не помещаются, жиры урезаются
+// до минимальной доли, а углеводы становятся нулевыми
+func Macros(calories int, weightKg float64, g Goal) Targets {
+	gp := paramsFor(g)
+	t := Targets{
+		Calories: calories,
+		Protein:  int(math.Round(weightKg * gp.proteinPerKg)),
+		Fat:      int(math.Round(weightKg * gp.fatPerKg)),
+	}
+
+	minFat := int(math.Round(float64(calories) * minFatShare / kcalPerGramFat))
+	if t.Fat < minFat {
+		t.Fat = minFat
+	}
+	rest := calories - t.Protein*kcalPerGramProtein - t.Fat*kcalPerGramFat
+	if rest < 0 && t.Fat > minFat {
+		t.Fat = max(minFat, t.Fat+rest/kcalPerGramFat)
+		rest = calories - t.Protein*kcalPerGramProtein - t.Fat*kcalPerGramFat
+	}
+	if rest > 0 {
+		t.Carbs = rest / kcalPerGramCarbs
+	}
+	return t
+}
+
+// roundTo округляет до кратного step
+func roundTo(v float64, step int) int {
+	return int(math.Round(v/float64(step))) * step
+}
@@ -0,0 +1,220 @@
+package nutrition
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"workbot/internal/models"
+)
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       Profile
+		bmr     float64
+		formula Formula
+	}{
+		// 10·80 + 6.25·180 − 5·30 + 5 = 1780
+		{"мужчина, Миффлин", Profile{Sex: Male, Age: 30, HeightCm: 180, WeightKg: 80}, 1780, MifflinStJeor},
+		// 10·60 + 6.25·165 − 5·25 − 161 = 1345.25
+		{"женщина, Миффлин", Profile{Sex: Female, Age: 25, HeightCm: 165, WeightKg: 60}, 1345.25, MifflinStJeor},
+		// 370 + 21.6·64 = 1752.4
+		{"известен % жира, Кетч", Profile{Sex: Male, Age: 30, HeightCm: 180, WeightKg: 80, BodyFat: 20}, 1752.4, KatchMcArdle},
+		// роста и возраста нет, но % жира известен
+		{"только вес и % жира", Profile{WeightKg: 70, BodyFat: 30}, 370 + 21.6*49, KatchMcArdle},
+		// % жира вне диапазона — ошибка ввода, считаем по Миффлину
+		{"неправдоподобный % жира", Profile{Sex: Male, Age: 30, HeightCm: 180, WeightKg: 80, BodyFat: 80}, 1780, MifflinStJeor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := Estimate(tt.p)
+			if err != nil {
+				t.Fatalf("Estimate: %v", err)
+			}
+			if math.Abs(e.BMR-tt.bmr) > 0.01 || e.Formula != tt.formula {
+				t.Errorf("BMR = %.2f (%s), хотим %.2f (%s)", e.BMR, e.Formula, tt.bmr, tt.formula)
+			}
+			if math.Abs(e.TDEE-e.BMR*1.2) > 0.01 {
+				t.Errorf("TDEE = %.2f при сидячем образе жизни, хотим %.2f", e.TDEE, e.BMR*1.2)
+			}
+		})
+	}
+
+	for _, p := range []Profile{
+		{},
+		{WeightKg: 80, HeightCm: 180, Age: 30}, // пол неизвестен
+		{WeightKg: 80, Sex: Male, Age: 30},     // рост неизвестен
+	} {
+		if _, err := Estimate(p); !errors.Is(err, ErrIncomplete) {
+			t.Errorf("Estimate(%+v) = %v, хотим ErrIncomplete", p, err)
+		}
+	}
+}
+
+func TestPlan(t *testing.T) {
+	p := Profile{Sex: Male, Age: 30, HeightCm: 180, WeightKg: 80, Activity: Moderate}
+
+	// TDEE = 1780 · 1.55 = 2759; дефицит 20% → 2207 → 2210.
+	// Белок 2.2·80 = 176, жиры 0.8·80 = 64, углеводы (2210 − 704 − 576) / 4 = 232
+	got, e, err := Plan(p, GoalFatLoss)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	want := Targets{Calories: 2210, Protein: 176, Fat: 64, Carbs: 232}
+	if got != want {
+		t.Errorf("Plan(fat_loss) = %+v, хотим %+v", got, want)
+	}
+	if math.Round(e.TDEE) != 2759 {
+		t.Errorf("TDEE = %.1f, хотим 2759", e.TDEE)
+	}
+
+	maintain, _, _ := Plan(p, GoalMaintain)
+	gain, _, _ := Plan(p, GoalGain)
+	if !(got.Calories < maintain.Calories && maintain.Calories < gain.Calories) {
+		t.Errorf("калории по задачам не упорядочены: %d, %d, %d", got.Calories, maintain.Calories, gain.Calories)
+	}
+
+	// Маленькая сидячая женщина на дефиците упирается в минимум
+	small := Profile{Sex: Female, Age: 60, HeightCm: 150, WeightKg: 45}
+	if got, _, _ := Plan(small, GoalFatLoss); got.Calories != MinCalories(Female) {
+		t.Errorf("калории %d, хотим минимум %d", got.Calories, MinCalories(Female))
+	}
+}
+
+func TestMacrosFitCalories(t *testing.T) {
+	for _, tt := range []struct {
+		calories int
+		weight   float64
+		goal     Goal
+	}{
+		{2210, 80, GoalFatLoss},
+		{3000, 90, GoalGain},
+		{1500, 140, GoalFatLoss}, // белок и жиры по весу не помещаются
+	} {
+		m := Macros(tt.calories, tt.weight, tt.goal)
+		total := m.Protein*4 + m.Fat*9 + m.Carbs*4
+		if m.Carbs > 0 && tt.calories-total >= 4 {
+			t.Errorf("Macros(%d) = %+v: %d ккал не распределены", tt.calories, m, tt.calories-total)
+		}
+		if m.Fat*9 < int(float64(tt.calories)*0.2)-9 {
+			t.Errorf("Macros(%d) = %+v: жиров меньше 20%% калорий", tt.calories, m)
+		}
+	}
+}
+
+func TestGoalFor(t *testing.T) {
+	tests := map[models.TrainingGoal]Goal{
+		models.GoalFatLoss:     GoalFatLoss,
+		models.GoalHypertrophy: GoalGain,
+		models.GoalStrength:    GoalGain,
+		models.GoalHyrox:       GoalMaintain,
+		models.GoalGeneral:     GoalMaintain,
+	}
+	for in, want := range tests {
+		if got := GoalFor(in); got != want {
+			t.Errorf("GoalFor(%s) = %s, хотим %s", in, got, want)
+		}
+	}
+}
+
+func series(start time.Time, days int, from, perDay float64) []WeightPoint {
+	var pts []WeightPoint
+	for i := 0; i < days; i++ {
+		pts = append(pts, WeightPoint{Date: start.AddDate(0, 0, i), Weight: from + perDay*float64(i)})
+	}
+	return pts
+}
+
+func TestWeeklyRate(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	rate, ok := WeeklyRate(series(start, 15, 80, -0.1))
+	if !ok || math.Abs(rate+0.7) > 1e-9 {
+		t.Errorf("WeeklyRate = %.3f, %v; хотим -0.7", rate, ok)
+	}
+
+	// Один выброс не переворачивает тренд
+	pts := series(start, 15, 80, -0.1)
+	pts[14].Weight += 1.5
+	if rate, _ := WeeklyRate(pts); rate >= 0 {
+		t.Errorf("выброс перевернул тренд: %.3f", rate)
+	}
+
+	if _, ok := WeeklyRate(series(start, 2, 80, -0.1)); ok {
+		t.Error("тренд по двум точкам")
+	}
+	if _, ok := WeeklyRate(series(start, 5, 80, -0.1)); ok {
+		t.Error("тренд по пяти дням")
+	}
+}
+
+func TestAdjust(t *testing.T) {
+	p := Profile{Sex: Male, Age: 30, HeightCm: 180, WeightKg: 80, Activity: Moderate}
+	current := Targets{Calories: 2210, Protein: 176, Fat: 64, Carbs: 232}
+
+	tests := []struct {
+		name  string
+		goal  Goal
+		rate  float64
+		delta int
+	}{
+		// цель −0.4 кг/нед; худеет на 0.1 — срезаем, но не больше 250 ккал
+		{"медленно худеет", GoalFatLoss, -0.1, -250},
+		// худеет на 0.8 — добавляем (−0.4 + 0.8)·7700/7 = 440 → 250
+		{"слишком быстро худеет", GoalFatLoss, -0.8, 250},
+		// (−0.4 + 0.55)·1100 = 165 → 170
+		{"чуть быстрее цели", GoalFatLoss, -0.55, 170},
+		{"в коридоре", GoalFatLoss, -0.45, 0},
+		// цель +0.2 кг/нед; набирает 0.6 — срезаем
+		{"набор слишком быстрый", GoalGain, 0.6, -250},
+		{"поддержание без изменений", GoalMaintain, 0.05, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Adjust(p, tt.goal, current, tt.rate)
+			if a.Delta != tt.delta {
+				t.Fatalf("Delta = %d, хотим %d", a.Delta, tt.delta)
+			}
+			if a.Targets.Calories != current.Calories+tt.delta {
+				t.Errorf("калории %d, хотим %d", a.Targets.Calories, current.Calories+tt.delta)
+			}
+			if tt.delta == 0 && a.Targets != current {
+				t.Errorf("цели изменились без коррекции: %+v", a.Targets)
+			}
+		})
+	}
+
+	// Коррекция не опускает калории ниже минимума
+	low := Targets{Calories: 1550}
+	if a := Adjust(p, GoalFatLoss, low, 0); a.Targets.Calories != MinCalories(Male) || a.Delta != -50 {
+		t.Errorf("Adjust у минимума = %+v", a)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	targets := Targets{Calories: 2000, Protein: 150}
+	checkins := []Checkin{
+		{Date: day(1), Calories: 2100, Protein: 150, Steps: 8000, Sleep: 7.5}, // в плане
+		{Date: day(2), Calories: 2500, Protein: 160, Steps: 6000, Sleep: 6},   // перебор калорий
+		{Date: day(3), Calories: 1900, Protein: 120},                          // мало белка
+		{Date: day(4), Steps: 10000},                                          // только шаги
+		{Date: day(5)},                                                        // пустая отметка
+	}
+
+	s := Summarize(checkins, targets, 7)
+	want := Summary{
+		Days: 7, Logged: 4,
+		AvgCalories: 2167, AvgProtein: 143, AvgSteps: 8000, AvgSleep: 6.8,
+		CaloriesHit: 2, ProteinHit: 2, Adherence: 14,
+	}
+	if s != want {
+		t.Errorf("Summarize =\n%+v\nхотим\n%+v", s, want)
+	}
+
+	if s := Summarize(nil, targets, 7); s.Logged != 0 || s.Adherence != 0 {
+		t.Errorf("пустая неделя: %+v", s)
+	}
+}
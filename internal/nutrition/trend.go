@@ -0,0 +1,109 @@
+package nutrition
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// TrendWindow — за сколько дней берутся взвешивания для оценки темпа
+	TrendWindow = 21 * 24 * time.Hour
+	// minTrendPoints и minTrendSpan — меньше данных дают шум, а не тренд
+	minTrendPoints = 3
+	minTrendSpan   = 7 * 24 * time.Hour
+	// kcalPerKg — энергия, соответствующая килограмму изменения веса
+	kcalPerKg = 7700
+	// rateTolerance — отклонение темпа от цели (кг в неделю), при котором калории не меняются
+	rateTolerance = 0.1
+	// maxAdjustment — предельный шаг коррекции за неделю, ккал в сутки
+	maxAdjustment = 250
+)
+
+// WeightPoint — взвешивание
+type WeightPoint struct {
+	Date   time.Time
+	Weight float64
+}
+
+// WeeklyRate оценивает изменение веса в кг за неделю наклоном прямой,
+// проведённой методом наименьших квадратов. Так один случайный замер
+// (вода, соль, время суток) не переворачивает вывод. ok == false, если
+// взвешиваний меньше трёх или они охватывают меньше недели
+func WeeklyRate(points []WeightPoint) (rate float64, ok bool) {
+	var valid []WeightPoint
+	for _, p := range points {
+		if p.Weight > 0 {
+			valid = append(valid, p)
+		}
+	}
+	if len(valid) < minTrendPoints {
+		return 0, false
+	}
+
+	first, last := valid[0].Date, valid[0].Date
+	for _, p := range valid[1:] {
+		if p.Date.Before(first) {
+			first = p.Date
+		}
+		if p.Date.After(last) {
+			last = p.Date
+		}
+	}
+	if last.Sub(first) < minTrendSpan {
+		return 0, false
+	}
+
+	var sumX, sumY, sumXX, sumXY float64
+	for _, p := range valid {
+		x := p.Date.Sub(first).Hours() / 24
+		sumX += x
+		sumY += p.Weight
+		sumXX += x * x
+		sumXY += x * p.Weight
+	}
+	n := float64(len(valid))
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0, false
+	}
+	perDay := (n*sumXY - sumX*sumY) / denom
+	return perDay * 7, true
+}
+
+// TargetRate — желаемое изменение веса в кг за неделю для задачи g
+func TargetRate(g Goal, weightKg float64) float64 {
+	return paramsFor(g).weeklyRate * weightKg
+}
+
+// Adjustment — итог недельной коррекции
+type Adjustment struct {
+	Rate       float64 // фактический темп, кг в неделю
+	TargetRate float64 // желаемый темп, кг в неделю
+	Delta      int     // изменение калорий, ккал в сутки; 0 — план не меняется
+	Targets    Targets // новые цели
+}
+
+// Adjust пересчитывает цели по фактическому темпу rate. Разница темпов
+// переводится в калории (7700 ккал на кг), шаг ограничен 250 ккал в сутки,
+// калории не опускаются ниже MinCalories. Белок и жиры пересчитываются
+// по текущему весу p.WeightKg
+func Adjust(p Profile, g Goal, current Targets, rate float64) Adjustment {
+	a := Adjustment{Rate: rate, TargetRate: TargetRate(g, p.WeightKg), Targets: current}
+
+	gap := a.TargetRate - rate
+	if math.Abs(gap) < rateTolerance {
+		return a
+	}
+	delta := gap * kcalPerKg / 7
+	delta = math.Max(-maxAdjustment, math.Min(maxAdjustment, delta))
+
+	calories := current.Calories + roundTo(delta, 10)
+	if min := MinCalories(p.Sex); calories < min {
+		calories = min
+	}
+	a.Delta = calories - current.Calories
+	if a.Delta != 0 {
+		a.Targets = Macros(calories, p.WeightKg, g)
+	}
+	return a
+}
@@ -0,0 +1,160 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limits задаёт ограничения частоты отправки.
+// Telegram допускает около 30 сообщений в секунду на бота, не чаще одного
+// сообщения в секунду в личный чат и 20 сообщений в минуту в группу
+type Limits struct {
+	GlobalRate  float64 // сообщений в секунду на весь бот
+	GlobalBurst int
+	ChatRate    float64 // сообщений в секунду в личный чат
+	ChatBurst   int
+	GroupRate   float64 // сообщений в секунду в группу (chat_id < 0)
+	GroupBurst  int
+}
+
+// DefaultLimits возвращает ограничения с небольшим запасом от лимитов Telegram.
+// Запас в 3 сообщения на чат нужен диалогам, где бот отвечает несколькими сообщениями подряд
+func DefaultLimits() Limits {
+	return Limits{
+		GlobalRate:  25,
+		GlobalBurst: 25,
+		ChatRate:    1,
+		ChatBurst:   3,
+		GroupRate:   20.0 / 60,
+		GroupBurst:  3,
+	}
+}
+
+// idleBucketTTL — через сколько простоя корзина чата удаляется из памяти
+const idleBucketTTL = time.Minute
+
+// bucket — корзина токенов. Баланс может уйти в минус: это очередь
+// уже выданных резервов, которые ждут пополнения
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take пополняет корзину на момент now и забирает один токен.
+// Возвращает, сколько нужно подождать до отправки
+func (b *bucket) take(now time.Time, rate float64, burst int) time.Duration {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * rate
+		if b.tokens > float64(burst) {
+			b.tokens = float64(burst)
+		}
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / rate * float64(time.Second))
+}
+
+// Limiter — общая и початовая корзины токенов плюс пауза после ответа 429
+type Limiter struct {
+	limits Limits
+	now    func() time.Time
+
+	mu          sync.Mutex
+	global      bucket
+	chats       map[int64]*bucket
+	pausedUntil time.Time
+	lastPrune   time.Time
+}
+
+// NewLimiter создаёт ограничитель с заданными лимитами
+func NewLimiter(limits Limits) *Limiter {
+	return newLimiterAt(limits, time.Now)
+}
+
+func newLimiterAt(limits Limits, now func() time.Time) *Limiter {
+	start := now()
+	return &Limiter{
+		limits:    limits,
+		now:       now,
+		global:    bucket{tokens: float64(limits.GlobalBurst), last: start},
+		chats:     make(map[int64]*bucket),
+		lastPrune: start,
+	}
+}
+
+// Reserve резервирует отправку в чат и возвращает необходимую задержку.
+// Резерв не отменяется: вызывающий должен выждать задержку и отправить
+func (l *Limiter) Reserve(chatID int64) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.prune(now)
+
+	wait := l.global.take(now, l.limits.GlobalRate, l.limits.GlobalBurst)
+
+	rate, burst := l.limits.ChatRate, l.limits.ChatBurst
+	if chatID < 0 {
+		rate, burst = l.limits.GroupRate, l.limits.GroupBurst
+	}
+	chat, ok := l.chats[chatID]
+	if !ok {
+		chat = &bucket{tokens: float64(burst), last: now}
+		l.chats[chatID] = chat
+	}
+	if w := chat.take(now, rate, burst); w > wait {
+		wait = w
+	}
+
+	if pause := l.pausedUntil.Sub(now); pause > wait {
+		wait = pause
+	}
+	return wait
+}
+
+// Wait резервирует отправку и ждёт своей очереди или отмены ctx
+func (l *Limiter) Wait(ctx context.Context, chatID int64) error {
+	return sleep(ctx, l.Reserve(chatID))
+}
+
+// Pause приостанавливает все отправки на d — так бот выполняет retry_after из ответа 429
+func (l *Limiter) Pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := l.now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// prune удаляет корзины чатов, которые давно не использовались и успели наполниться
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < idleBucketTTL {
+		return
+	}
+	l.lastPrune = now
+	for chatID, chat := range l.chats {
+		if now.Sub(chat.last) >= idleBucketTTL {
+			delete(l.chats, chatID)
+		}
+	}
+}
+
+// sleep ждёт d или отмены ctx
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,96 @@
+package outbox
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock — управляемые часы для ограничителя
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestLimiterChatBurstThenRate(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2030, 5, 10, 9, 0, 0, 0, time.UTC)}
+	l := newLimiterAt(DefaultLimits(), clock.Now)
+
+	for i := 0; i < 3; i++ {
+		if wait := l.Reserve(42); wait != 0 {
+			t.Fatalf("message %d within burst: wait %v, want 0", i+1, wait)
+		}
+	}
+	if wait := l.Reserve(42); wait != time.Second {
+		t.Errorf("4th message: wait %v, want 1s", wait)
+	}
+	if wait := l.Reserve(42); wait != 2*time.Second {
+		t.Errorf("5th message: wait %v, want 2s (queued behind the 4th)", wait)
+	}
+
+	// Другой чат не ждёт, пока не исчерпан общий лимит
+	if wait := l.Reserve(43); wait != 0 {
+		t.Errorf("other chat: wait %v, want 0", wait)
+	}
+
+	clock.Advance(10 * time.Second)
+	if wait := l.Reserve(42); wait != 0 {
+		t.Errorf("after refill: wait %v, want 0", wait)
+	}
+}
+
+func TestLimiterGroupRate(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2030, 5, 10, 9, 0, 0, 0, time.UTC)}
+	l := newLimiterAt(DefaultLimits(), clock.Now)
+
+	for i := 0; i < 3; i++ {
+		l.Reserve(-100)
+	}
+	// 20 сообщений в минуту — одно раз в 3 секунды
+	if wait := l.Reserve(-100); wait != 3*time.Second {
+		t.Errorf("group: wait %v, want 3s", wait)
+	}
+}
+
+func TestLimiterGlobalRate(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2030, 5, 10, 9, 0, 0, 0, time.UTC)}
+	limits := DefaultLimits()
+	l := newLimiterAt(limits, clock.Now)
+
+	for i := 0; i < limits.GlobalBurst; i++ {
+		if wait := l.Reserve(int64(i + 1)); wait != 0 {
+			t.Fatalf("chat %d: wait %v, want 0", i+1, wait)
+		}
+	}
+	want := time.Duration(float64(time.Second) / limits.GlobalRate)
+	if wait := l.Reserve(1000); wait != want {
+		t.Errorf("over global burst: wait %v, want %v", wait, want)
+	}
+}
+
+func TestLimiterPause(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2030, 5, 10, 9, 0, 0, 0, time.UTC)}
+	l := newLimiterAt(DefaultLimits(), clock.Now)
+
+	l.Pause(5 * time.Second)
+	l.Pause(2 * time.Second) // более короткая пауза не сокращает текущую
+	if wait := l.Reserve(42); wait != 5*time.Second {
+		t.Errorf("paused: wait %v, want 5s", wait)
+	}
+	clock.Advance(5 * time.Second)
+	if wait := l.Reserve(43); wait != 0 {
+		t.Errorf("after pause: wait %v, want 0", wait)
+	}
+}
+
+func TestLimiterPrunesIdleChats(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2030, 5, 10, 9, 0, 0, 0, time.UTC)}
+	l := newLimiterAt(DefaultLimits(), clock.Now)
+
+	l.Reserve(1)
+	l.Reserve(2)
+	clock.Advance(2 * idleBucketTTL)
+	l.Reserve(3)
+	if len(l.chats) != 1 {
+		t.Errorf("idle chats should be pruned, have %d buckets", len(l.chats))
+	}
+}
@@ -0,0 +1,298 @@
+// Package outbox отправляет исходящие сообщения Telegram с учётом лимитов.
+//
+// Sender ограничивает частоту отправки общей и початовыми корзинами токенов,
+// выполняет retry_after из ответов 429 и сообщает о пользователях,
+// заблокировавших бота (403). Outbox — очередь уведомлений в таблице
+// outbox_messages: сообщение сохраняется до отправки и переживает перезапуск
+// бота. Несколько экземпляров могут разбирать одну очередь — пачку сообщений
+// захватывает SELECT ... FOR UPDATE SKIP LOCKED с арендой до locked_until.
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	defaultPollInterval = 5 * time.Second
+	defaultBatchSize    = 20
+	defaultLease        = 5 * time.Minute
+	defaultMaxAttempts  = 5
+	baseRetryDelay      = 30 * time.Second
+	maxRetryDelay       = 30 * time.Minute
+)
+
+// Статусы сообщений очереди
+const (
+	StatusPending = "pending"
+	StatusSent    = "sent"
+	StatusFailed  = "failed"
+)
+
+// Outbox — персистентная очередь исходящих уведомлений
+type Outbox struct {
+	db           *sql.DB
+	sender       *Sender
+	instance     string
+	pollInterval time.Duration
+	batchSize    int
+	maxAttempts  int
+	wake         chan struct{}
+}
+
+// queued — сообщение, захваченное из очереди
+type queued struct {
+	id       int64
+	attempts int
+	msg      tgbotapi.MessageConfig
+}
+
+// New создаёт очередь, которая отправляет сообщения через sender
+func New(db *sql.DB, sender *Sender) *Outbox {
+	host, _ := os.Hostname()
+	return &Outbox{
+		db:           db,
+		sender:       sender,
+		instance:     fmt.Sprintf("%s-%d", host, os.Getpid()),
+		pollInterval: defaultPollInterval,
+		batchSize:    defaultBatchSize,
+		maxAttempts:  defaultMaxAttempts,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Enqueue ставит сообщение в очередь. Сохраняются текст, режим разметки,
+// клавиатура и отключение превью ссылок
+func (o *Outbox) Enqueue(msg tgbotapi.MessageConfig) error {
+	return o.EnqueueOnce("", msg)
+}
+
+// EnqueueOnce ставит сообщение в очередь, если сообщения с ключом key ещё не было.
+// Ключ защищает от дублей, когда задача повторяется после сбоя; пустой ключ не проверяется
+func (o *Outbox) EnqueueOnce(key string, msg tgbotapi.MessageConfig) error {
+	var markup []byte
+	if msg.ReplyMarkup != nil {
+		var err error
+		if markup, err = json.Marshal(msg.ReplyMarkup); err != nil {
+			return fmt.Errorf("ошибка сериализации клавиатуры: %w", err)
+		}
+	}
+
+	_, err := o.db.Exec(`
+		INSERT INTO public.outbox_messages
+			(dedup_key, chat_id, text, parse_mode, reply_markup, disable_preview)
+		VALUES (NULLIF($1, ''), $2, $3, $4, $5, $6)
+		ON CONFLICT (dedup_key) DO NOTHING`,
+		key, msg.ChatID, msg.Text, msg.ParseMode, nullJSON(markup), msg.DisableWebPagePreview)
+	if err != nil {
+		return fmt.Errorf("ошибка постановки сообщения в очередь: %w", err)
+	}
+
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Start запускает разбор очереди до отмены ctx
+func (o *Outbox) Start(ctx context.Context) {
+	go o.loop(ctx)
+	log.Printf("Очередь исходящих сообщений запущена (экземпляр %s)", o.instance)
+}
+
+func (o *Outbox) loop(ctx context.Context) {
+	ticker := time.NewTicker(o.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		o.deliverDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-o.wake:
+		}
+	}
+}
+
+// deliverDue отправляет все сообщения, срок которых наступил
+func (o *Outbox) deliverDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		batch, err := o.claim(time.Now())
+		if err != nil {
+			log.Printf("Очередь сообщений: ошибка захвата: %v", err)
+			return
+		}
+		if len(batch) == 0 {
+			return
+		}
+		for i, q := range batch {
+			if ctx.Err() != nil {
+				o.release(batch[i:])
+				return
+			}
+			o.deliver(ctx, q)
+		}
+	}
+}
+
+// claim захватывает пачку сообщений, которые не разбирает другой экземпляр
+func (o *Outbox) claim(now time.Time) ([]queued, error) {
+	rows, err := o.db.Query(`
+		UPDATE public.outbox_messages
+		SET locked_by = $1, locked_until = $2
+		WHERE id IN (
+			SELECT id FROM public.outbox_messages
+			WHERE status = 'pending'
+			  AND next_attempt_at <= $3
+			  AND (locked_until IS NULL OR locked_until < $3)
+			ORDER BY next_attempt_at, id
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED)
+		RETURNING id, chat_id, text, COALESCE(parse_mode, ''), reply_markup, disable_preview, attempts`,
+		o.instance, now.Add(defaultLease), now, o.batchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var batch []queued
+	for rows.Next() {
+		var (
+			q              queued
+			chatID         int64
+			text           string
+			parseMode      string
+			markup         []byte
+			disablePreview bool
+		)
+		if err := rows.Scan(&q.id, &chatID, &text, &parseMode, &markup, &disablePreview, &q.attempts); err != nil {
+			return nil, err
+		}
+		q.msg = tgbotapi.NewMessage(chatID, text)
+		q.msg.ParseMode = parseMode
+		q.msg.DisableWebPagePreview = disablePreview
+		if len(markup) > 0 {
+			q.msg.ReplyMarkup = json.RawMessage(markup)
+		}
+		batch = append(batch, q)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Порядок постановки в очередь сохраняется внутри чата
+	sort.Slice(batch, func(i, j int) bool { return batch[i].id < batch[j].id })
+	return batch, nil
+}
+
+// deliver отправляет одно сообщение и записывает результат
+func (o *Outbox) deliver(ctx context.Context, q queued) {
+	sent, err := o.sender.Send(ctx, q.msg)
+	if err == nil {
+		_, dbErr := o.db.Exec(`
+			UPDATE public.outbox_messages
+			SET status = 'sent', sent_at = NOW(), message_id = $2, attempts = attempts + 1,
+			    last_error = NULL, locked_by = NULL, locked_until = NULL
+			WHERE id = $1`, q.id, sent.MessageID)
+		if dbErr != nil {
+			log.Printf("Очередь сообщений: ошибка сохранения результата %d: %v", q.id, dbErr)
+		}
+		return
+	}
+	if ctx.Err() != nil {
+		o.release([]queued{q})
+		return
+	}
+
+	attempt := q.attempts + 1
+	status, next := nextAfterFailure(err, time.Now(), attempt, o.maxAttempts)
+	log.Printf("Очередь сообщений: ошибка отправки %d в чат %d (попытка %d/%d): %v",
+		q.id, q.msg.ChatID, attempt, o.maxAttempts, err)
+
+	_, dbErr := o.db.Exec(`
+		UPDATE public.outbox_messages
+		SET status = $2, next_attempt_at = $3, attempts = $4, last_error = $5,
+		    locked_by = NULL, locked_until = NULL
+		WHERE id = $1`, q.id, status, next, attempt, err.Error())
+	if dbErr != nil {
+		log.Printf("Очередь сообщений: ошибка сохранения результата %d: %v", q.id, dbErr)
+	}
+}
+
+// release снимает аренду с сообщений, которые не успели отправить до остановки
+func (o *Outbox) release(batch []queued) {
+	for _, q := range batch {
+		if _, err := o.db.Exec(`
+			UPDATE public.outbox_messages SET locked_by = NULL, locked_until = NULL
+			WHERE id = $1 AND status = 'pending'`, q.id); err != nil {
+			log.Printf("Очередь сообщений: ошибка снятия аренды %d: %v", q.id, err)
+		}
+	}
+}
+
+// Cleanup удаляет отправленные сообщения старше sentAge и неотправленные старше failedAge
+func (o *Outbox) Cleanup(sentAge, failedAge time.Duration) (int64, error) {
+	now := time.Now()
+	res, err := o.db.Exec(`
+		DELETE FROM public.outbox_messages
+		WHERE (status = 'sent' AND sent_at < $1)
+		   OR (status = 'failed' AND created_at < $2)`,
+		now.Add(-sentAge), now.Add(-failedAge))
+	if err != nil {
+		return 0, fmt.Errorf("ошибка очистки очереди сообщений: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// nextAfterFailure возвращает новый статус сообщения и время следующей попытки.
+// Ошибки запроса (400, 403) не исправятся повтором — сообщение сразу помечается
+// неотправленным; после 429 повтор через retry_after, иначе — с экспоненциальной задержкой
+func nextAfterFailure(err error, now time.Time, attempt, maxAttempts int) (string, time.Time) {
+	if permanent(err) || attempt >= maxAttempts {
+		return StatusFailed, now
+	}
+	if retryAfter, ok := RetryAfter(err); ok {
+		return StatusPending, now.Add(retryAfter)
+	}
+	return StatusPending, now.Add(RetryDelay(attempt))
+}
+
+// RetryDelay возвращает задержку перед повтором после attempt неудачных попыток: 30 с, 1, 2, 4 … 30 минут
+func RetryDelay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := baseRetryDelay
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return d
+}
+
+// permanent сообщает, что ошибка не исправится повтором отправки
+func permanent(err error) bool {
+	var tgErr *tgbotapi.Error
+	return errors.As(err, &tgErr) && (tgErr.Code == 400 || tgErr.Code == 403)
+}
+
+func nullJSON(b []byte) interface{} {
+	if len(b) == 0 {
+		return nil
+	}
+	return string(b)
+}
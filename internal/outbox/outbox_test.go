@@ -0,0 +1,57 @@
+package outbox
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{7, 30 * time.Minute},
+		{50, 30 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := RetryDelay(tt.attempt); got != tt.want {
+			t.Errorf("RetryDelay(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestNextAfterFailure(t *testing.T) {
+	now := time.Date(2030, 5, 10, 9, 0, 0, 0, time.UTC)
+
+	status, next := nextAfterFailure(errors.New("timeout"), now, 1, 5)
+	if status != StatusPending || !next.Equal(now.Add(30*time.Second)) {
+		t.Errorf("network error: got %s at %v, want retry in 30s", status, next)
+	}
+
+	status, next = nextAfterFailure(tooManyRequests(120), now, 2, 5)
+	if status != StatusPending || !next.Equal(now.Add(2*time.Minute)) {
+		t.Errorf("429: got %s at %v, want retry after 2m", status, next)
+	}
+
+	status, _ = nextAfterFailure(&tgbotapi.Error{Code: 403, Message: "Forbidden"}, now, 1, 5)
+	if status != StatusFailed {
+		t.Errorf("403: got %s, want failed without retry", status)
+	}
+
+	status, _ = nextAfterFailure(&tgbotapi.Error{Code: 400, Message: "Bad Request: can't parse entities"}, now, 1, 5)
+	if status != StatusFailed {
+		t.Errorf("400: got %s, want failed without retry", status)
+	}
+
+	status, _ = nextAfterFailure(errors.New("timeout"), now, 5, 5)
+	if status != StatusFailed {
+		t.Errorf("attempts exhausted: got %s, want failed", status)
+	}
+}
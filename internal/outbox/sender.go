@@ -0,0 +1,166 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultMaxRetryWait = time.Minute
+)
+
+// API — часть Telegram Bot API, через которую идут отправки
+type API interface {
+	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+	Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error)
+}
+
+// Sender отправляет сообщения с учётом лимитов Telegram.
+// Перед отправкой ждёт токен ограничителя, на ответ 429 приостанавливает
+// все отправки на retry_after и повторяет, на ответ 403 сообщает о
+// пользователе, заблокировавшем бота
+type Sender struct {
+	api          API
+	limiter      *Limiter
+	maxRetries   int
+	maxRetryWait time.Duration
+	sleep        func(ctx context.Context, d time.Duration) error
+
+	// OnBlocked вызывается, когда пользователь заблокировал бота или удалил аккаунт
+	OnBlocked func(chatID int64)
+}
+
+// NewSender создаёт отправителя поверх api
+func NewSender(api API, limiter *Limiter) *Sender {
+	return &Sender{
+		api:          api,
+		limiter:      limiter,
+		maxRetries:   defaultMaxRetries,
+		maxRetryWait: defaultMaxRetryWait,
+		sleep:        sleep,
+	}
+}
+
+// Send отправляет сообщение, ожидая лимитов и повторяя после 429
+func (s *Sender) Send(ctx context.Context, c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	var msg tgbotapi.Message
+	err := s.do(ctx, c, func() error {
+		var err error
+		msg, err = s.api.Send(c)
+		return err
+	})
+	return msg, err
+}
+
+// Request выполняет запрос к API; лимиты учитываются только для сообщений и правок
+func (s *Sender) Request(ctx context.Context, c tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	var resp *tgbotapi.APIResponse
+	err := s.do(ctx, c, func() error {
+		var err error
+		resp, err = s.api.Request(c)
+		return err
+	})
+	return resp, err
+}
+
+func (s *Sender) do(ctx context.Context, c tgbotapi.Chattable, call func() error) error {
+	chatID, limited := ChatIDOf(c)
+
+	for attempt := 0; ; attempt++ {
+		if limited {
+			if err := s.sleep(ctx, s.limiter.Reserve(chatID)); err != nil {
+				return err
+			}
+		}
+
+		err := call()
+		if err == nil {
+			return nil
+		}
+
+		if retryAfter, ok := RetryAfter(err); ok {
+			s.limiter.Pause(retryAfter)
+			if attempt >= s.maxRetries || retryAfter > s.maxRetryWait {
+				return err
+			}
+			log.Printf("Telegram: превышен лимит (чат %d), повтор через %s", chatID, retryAfter)
+			if err := s.sleep(ctx, retryAfter); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if IsBlocked(err) && chatID > 0 && s.OnBlocked != nil {
+			s.OnBlocked(chatID)
+		}
+		return err
+	}
+}
+
+// RetryAfter возвращает задержку из ответа 429 Too Many Requests
+func RetryAfter(err error) (time.Duration, bool) {
+	var tgErr *tgbotapi.Error
+	if !errors.As(err, &tgErr) || tgErr.Code != 429 {
+		return 0, false
+	}
+	if tgErr.RetryAfter <= 0 {
+		return time.Second, true
+	}
+	return time.Duration(tgErr.RetryAfter) * time.Second, true
+}
+
+// IsBlocked сообщает, что отправка невозможна: пользователь заблокировал
+// бота или удалил аккаунт (403 Forbidden)
+func IsBlocked(err error) bool {
+	var tgErr *tgbotapi.Error
+	return errors.As(err, &tgErr) && tgErr.Code == 403
+}
+
+// ChatIDOf возвращает чат, в который идёт запрос, и нужно ли учитывать его в лимитах.
+// Ответы на callback, удаление сообщений и «печатает…» в лимиты не входят
+func ChatIDOf(c tgbotapi.Chattable) (int64, bool) {
+	switch m := c.(type) {
+	case tgbotapi.MessageConfig:
+		return m.ChatID, true
+	case tgbotapi.PhotoConfig:
+		return m.ChatID, true
+	case tgbotapi.DocumentConfig:
+		return m.ChatID, true
+	case tgbotapi.AudioConfig:
+		return m.ChatID, true
+	case tgbotapi.VideoConfig:
+		return m.ChatID, true
+	case tgbotapi.VoiceConfig:
+		return m.ChatID, true
+	case tgbotapi.StickerConfig:
+		return m.ChatID, true
+	case tgbotapi.LocationConfig:
+		return m.ChatID, true
+	case tgbotapi.ContactConfig:
+		return m.ChatID, true
+	case tgbotapi.ForwardConfig:
+		return m.ChatID, true
+	case tgbotapi.CopyMessageConfig:
+		return m.ChatID, true
+	case tgbotapi.MediaGroupConfig:
+		return m.ChatID, true
+	case tgbotapi.EditMessageTextConfig:
+		return m.ChatID, m.InlineMessageID == ""
+	case tgbotapi.EditMessageCaptionConfig:
+		return m.ChatID, m.InlineMessageID == ""
+	case tgbotapi.EditMessageReplyMarkupConfig:
+		return m.ChatID, m.InlineMessageID == ""
+	case tgbotapi.EditMessageMediaConfig:
+		return m.ChatID, m.InlineMessageID == ""
+	case tgbotapi.DeleteMessageConfig:
+		return m.ChatID, false
+	case tgbotapi.ChatActionConfig:
+		return m.ChatID, false
+	}
+	return 0, false
+}
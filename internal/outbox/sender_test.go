@@ -0,0 +1,153 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// fakeAPI возвращает заранее заданные ошибки по порядку, затем успех
+type fakeAPI struct {
+	errs  []error
+	calls int
+}
+
+func (f *fakeAPI) next() error {
+	f.calls++
+	if len(f.errs) == 0 {
+		return nil
+	}
+	err := f.errs[0]
+	f.errs = f.errs[1:]
+	return err
+}
+
+func (f *fakeAPI) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	if err := f.next(); err != nil {
+		return tgbotapi.Message{}, err
+	}
+	return tgbotapi.Message{MessageID: f.calls}, nil
+}
+
+func (f *fakeAPI) Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	if err := f.next(); err != nil {
+		return nil, err
+	}
+	return &tgbotapi.APIResponse{Ok: true}, nil
+}
+
+func tooManyRequests(seconds int) error {
+	return &tgbotapi.Error{
+		Code:               429,
+		Message:            "Too Many Requests: retry after",
+		ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: seconds},
+	}
+}
+
+func newTestSender(api API) (*Sender, *[]time.Duration) {
+	var slept []time.Duration
+	clock := &fakeClock{now: time.Date(2030, 5, 10, 9, 0, 0, 0, time.UTC)}
+	s := NewSender(api, newLimiterAt(DefaultLimits(), clock.Now))
+	// Сон только сдвигает часы ограничителя; нулевые ожидания не записываются
+	s.sleep = func(ctx context.Context, d time.Duration) error {
+		if d > 0 {
+			slept = append(slept, d)
+			clock.Advance(d)
+		}
+		return nil
+	}
+	return s, &slept
+}
+
+func TestSenderRetriesAfter429(t *testing.T) {
+	api := &fakeAPI{errs: []error{tooManyRequests(7), tooManyRequests(0)}}
+	s, slept := newTestSender(api)
+
+	msg, err := s.Send(context.Background(), tgbotapi.NewMessage(42, "hi"))
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if api.calls != 3 || msg.MessageID != 3 {
+		t.Errorf("calls = %d, message = %d; want 3 attempts", api.calls, msg.MessageID)
+	}
+	want := []time.Duration{7 * time.Second, time.Second}
+	if len(*slept) != 2 || (*slept)[0] != want[0] || (*slept)[1] != want[1] {
+		t.Errorf("slept %v, want %v", *slept, want)
+	}
+}
+
+func TestSenderGivesUpOnLongRetryAfter(t *testing.T) {
+	api := &fakeAPI{errs: []error{tooManyRequests(3600)}}
+	s, slept := newTestSender(api)
+
+	if _, err := s.Send(context.Background(), tgbotapi.NewMessage(42, "hi")); err == nil {
+		t.Fatal("expected error")
+	}
+	if api.calls != 1 || len(*slept) != 0 {
+		t.Errorf("calls = %d, sleeps = %v; want no retry", api.calls, *slept)
+	}
+}
+
+func TestSenderGivesUpAfterMaxRetries(t *testing.T) {
+	api := &fakeAPI{errs: []error{tooManyRequests(1), tooManyRequests(1), tooManyRequests(1), tooManyRequests(1), tooManyRequests(1)}}
+	s, _ := newTestSender(api)
+
+	if _, err := s.Send(context.Background(), tgbotapi.NewMessage(42, "hi")); err == nil {
+		t.Fatal("expected error")
+	}
+	if api.calls != defaultMaxRetries+1 {
+		t.Errorf("calls = %d, want %d", api.calls, defaultMaxRetries+1)
+	}
+}
+
+func TestSenderReportsBlockedUser(t *testing.T) {
+	api := &fakeAPI{errs: []error{&tgbotapi.Error{Code: 403, Message: "Forbidden: bot was blocked by the user"}}}
+	s, _ := newTestSender(api)
+	var blocked []int64
+	s.OnBlocked = func(chatID int64) { blocked = append(blocked, chatID) }
+
+	_, err := s.Send(context.Background(), tgbotapi.NewMessage(42, "hi"))
+	if !IsBlocked(err) {
+		t.Fatalf("err = %v, want 403", err)
+	}
+	if api.calls != 1 || len(blocked) != 1 || blocked[0] != 42 {
+		t.Errorf("calls = %d, blocked = %v; want one call and chat 42", api.calls, blocked)
+	}
+}
+
+func TestSenderDoesNotRetryOtherErrors(t *testing.T) {
+	api := &fakeAPI{errs: []error{errors.New("connection reset")}}
+	s, _ := newTestSender(api)
+	s.OnBlocked = func(chatID int64) { t.Errorf("unexpected OnBlocked(%d)", chatID) }
+
+	if _, err := s.Send(context.Background(), tgbotapi.NewMessage(42, "hi")); err == nil {
+		t.Fatal("expected error")
+	}
+	if api.calls != 1 {
+		t.Errorf("calls = %d, want 1", api.calls)
+	}
+}
+
+func TestChatIDOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       tgbotapi.Chattable
+		chatID  int64
+		limited bool
+	}{
+		{"message", tgbotapi.NewMessage(1, "x"), 1, true},
+		{"edit", tgbotapi.NewEditMessageText(2, 10, "x"), 2, true},
+		{"document", tgbotapi.NewDocument(3, tgbotapi.FileBytes{Name: "a.txt", Bytes: []byte("a")}), 3, true},
+		{"delete", tgbotapi.NewDeleteMessage(4, 10), 4, false},
+		{"callback", tgbotapi.NewCallback("id", ""), 0, false},
+	}
+	for _, tt := range tests {
+		chatID, limited := ChatIDOf(tt.c)
+		if chatID != tt.chatID || limited != tt.limited {
+			t.Errorf("%s: got %d, %v; want %d, %v", tt.name, chatID, limited, tt.chatID, tt.limited)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package pdfdoc
+
+import (
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+)
+
+// contentWidth — ширина области текста между полями
+const contentWidth = pageWidth - 2*margin
+
+// pager раскладывает блоки сверху вниз и открывает новую страницу,
+// когда очередной строке или строке таблицы не хватает места
+type pager struct {
+	pages []pdfContent
+	y     float64 // верхняя граница свободного места на текущей странице
+}
+
+func (p *pager) newPage() {
+	p.pages = append(p.pages, pdfContent{})
+	p.y = pageHeight - margin
+}
+
+func (p *pager) current() *pdfContent {
+	return &p.pages[len(p.pages)-1]
+}
+
+// bottom — нижняя граница места под содержимое
+func (p *pager) bottom() float64 {
+	return margin + footerZone
+}
+
+// empty сообщает, что на текущей странице ещё ничего нет
+func (p *pager) empty() bool {
+	c := p.current()
+	return len(c.Text) == 0 && len(c.Table) == 0
+}
+
+// reserve переходит на новую страницу, если высоты h не хватает
+func (p *pager) reserve(h float64) {
+	if p.y-h < p.bottom() && !p.empty() {
+		p.newPage()
+	}
+}
+
+// line выводит строку, занимающую по высоте lineHeight
+func (p *pager) line(text, fontName string, size int, lineHeight float64) {
+	p.reserve(lineHeight)
+	baseline := p.y - lineHeight + (lineHeight-float64(size))/2 + 2
+	if text != "" {
+		c := p.current()
+		c.Text = append(c.Text, textAt(text, fontName, size, margin, baseline))
+	}
+	p.y -= lineHeight
+}
+
+// table выводит таблицу, перенося строки на следующие страницы с повтором шапки
+func (p *pager) table(t *Table) {
+	cols := t.cols()
+	if cols == 0 {
+		return
+	}
+	widths := columnWidths(t.Widths, cols)
+	var anchors []string
+	if len(t.Align) > 0 {
+		anchors = make([]string, cols)
+		for i := range anchors {
+			anchors[i] = string(AlignLeft)
+			if i < len(t.Align) && t.Align[i] != "" {
+				anchors[i] = string(t.Align[i])
+			}
+		}
+	}
+
+	var header *pdfTableHeader
+	if len(t.Header) > 0 {
+		header = &pdfTableHeader{
+			Values:     fitRow(t.Header, cols, widths, fontBold, tableSize),
+			ColAnchors: anchors,
+			BgCol:      headerColor,
+			Font:       pdfFont{Name: fontBold, Size: tableSize, Col: "#000000"},
+		}
+	}
+	rows := make([][]string, len(t.Rows))
+	for i, row := range t.Rows {
+		rows[i] = fitRow(row, cols, widths, fontRegular, tableSize)
+	}
+
+	headerRows := 0
+	if header != nil {
+		headerRows = 1
+	}
+	for first := true; first || len(rows) > 0; first = false {
+		fit := int((p.y-p.bottom())/tableRowHeight) - headerRows
+		if fit < 1 || (fit < len(rows) && fit < 3 && !p.empty()) {
+			p.newPage()
+			fit = int((p.y-p.bottom())/tableRowHeight) - headerRows
+		}
+		if fit > len(rows) {
+			fit = len(rows)
+		}
+		chunk := rows[:fit]
+		rows = rows[fit:]
+
+		h := float64((len(chunk) + headerRows) * tableRowHeight)
+		c := p.current()
+		c.Table = append(c.Table, pdfTable{
+			Values:     chunk,
+			Rows:       len(chunk),
+			Cols:       cols,
+			Width:      contentWidth,
+			ColWidths:  widths,
+			ColAnchors: anchors,
+			LineHeight: tableRowHeight,
+			Pos:        [2]float64{margin, p.y - h},
+			Font:       pdfFont{Name: fontRegular, Size: tableSize, Col: "#000000"},
+			Header:     header,
+			Grid:       true,
+		})
+		p.y -= h
+	}
+	p.y -= blockGap
+}
+
+// layout раскладывает блоки документа по страницам
+func layout(d *Document) []pdfContent {
+	p := &pager{}
+	p.newPage()
+	for _, b := range d.blocks {
+		switch b.kind {
+		case blockTitle:
+			for _, l := range wrap(b.text, fontBold, titleSize, contentWidth) {
+				p.line(l, fontBold, titleSize, titleSize+8)
+			}
+			p.y -= textLineHeight / 2
+		case blockHeading:
+			// Заголовок не остаётся последней строкой страницы
+			p.reserve(headingSize + 8 + 2*tableRowHeight)
+			p.y -= textLineHeight / 2
+			for _, l := range wrap(b.text, fontBold, headingSize, contentWidth) {
+				p.line(l, fontBold, headingSize, headingSize+8)
+			}
+		case blockText:
+			for _, l := range wrap(b.text, fontRegular, textSize, contentWidth) {
+				p.line(l, fontRegular, textSize, textLineHeight)
+			}
+		case blockTable:
+			p.table(b.table)
+		case blockPageBreak:
+			if !p.empty() {
+				p.newPage()
+			}
+		}
+	}
+	// Разрыв в конце документа не оставляет пустую страницу
+	if len(p.pages) > 1 && p.empty() {
+		p.pages = p.pages[:len(p.pages)-1]
+	}
+	return p.pages
+}
+
+// columnWidths возвращает ширины колонок в процентах; недостающие делят остаток поровну
+func columnWidths(widths []int, cols int) []int {
+	out := make([]int, cols)
+	used, unset := 0, 0
+	for i := range out {
+		if i < len(widths) && widths[i] > 0 {
+			out[i] = widths[i]
+			used += widths[i]
+		} else {
+			unset++
+		}
+	}
+	if unset > 0 {
+		rest := (100 - used) / unset
+		if rest < 1 {
+			rest = 1
+		}
+		for i := range out {
+			if out[i] == 0 {
+				out[i] = rest
+			}
+		}
+	}
+	return out
+}
+
+// fitRow дополняет строку до cols колонок и обрезает значения по ширине колонки
+func fitRow(row []string, cols int, widths []int, fontName string, size int) []string {
+	out := make([]string, cols)
+	for i := range out {
+		if i >= len(row) {
+			continue
+		}
+		width := contentWidth*float64(widths[i])/100 - 2*cellPadding
+		out[i] = escape(fitText(strings.ReplaceAll(row[i], "\n", " "), fontName, size, width))
+	}
+	return out
+}
+
+// fitText обрезает строку с многоточием, чтобы она поместилась в width
+func fitText(s, fontName string, size int, width float64) string {
+	if font.TextWidth(s, fontName, size) <= width {
+		return s
+	}
+	r := []rune(s)
+	for len(r) > 0 {
+		r = r[:len(r)-1]
+		candidate := strings.TrimRight(string(r), " ") + "…"
+		if font.TextWidth(candidate, fontName, size) <= width {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// wrap переносит текст по словам в строки не шире width; переводы строк сохраняются,
+// слово длиннее строки обрезается
+func wrap(text, fontName string, size int, width float64) []string {
+	var lines []string
+	for _, para := range strings.Split(text, "\n") {
+		words := strings.Fields(para)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+		line := ""
+		for _, w := range words {
+			candidate := w
+			if line != "" {
+				candidate = line + " " + w
+			}
+			if font.TextWidth(candidate, fontName, size) <= width {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			line = fitText(w, fontName, size, width)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
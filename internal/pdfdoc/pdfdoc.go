@@ -0,0 +1,263 @@
+// Package pdfdoc собирает простые печатные PDF-документы: заголовки, абзацы
+// и таблицы, которые выводятся сверху вниз на страницы A4.
+//
+// Документ описывается блоками, раскладка считается здесь же: абзацы
+// переносятся по словам, не поместившаяся таблица продолжается на следующей
+// странице с повтором шапки, слишком длинные значения ячеек обрезаются.
+// Страница собирается через pdfcpu, текст выводится шрифтами Go Regular
+// и Go Bold, в которых есть кириллица. Пакет не знает о базе и боте —
+// счета, квитанции и печатные программы собираются вызывающим кодом.
+package pdfdoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// Размеры страницы A4 и поля, пункты
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+	margin     = 40.0
+	// footerZone — высота полосы внизу страницы под нижний колонтитул
+	footerZone = 24.0
+)
+
+// Кегли и интерлиньяж
+const (
+	titleSize      = 18
+	headingSize    = 13
+	textSize       = 10
+	footerSize     = 8
+	tableSize      = 9
+	textLineHeight = 14.0
+	tableRowHeight = 16
+	// cellPadding — отступ текста ячейки от границы, с каждой стороны
+	cellPadding = 3.0
+	// blockGap — отступ после таблицы
+	blockGap = 10.0
+)
+
+// Имена шрифтов после установки в pdfcpu — PostScript-имена из файлов шрифтов
+const (
+	fontRegular = "GoRegular"
+	fontBold    = "Go-Bold"
+	// headerColor — фон шапки таблицы
+	headerColor = "#E3E8EF"
+)
+
+// ErrEmpty — в документе нет ни одного блока
+var ErrEmpty = errors.New("pdfdoc: пустой документ")
+
+// Align — выравнивание колонки таблицы
+type Align string
+
+const (
+	AlignLeft   Align = "Left"
+	AlignCenter Align = "Center"
+	AlignRight  Align = "Right"
+)
+
+// Table — таблица с необязательной шапкой
+type Table struct {
+	Header []string
+	Rows   [][]string
+	// Widths — ширины колонок в процентах; пусто — поровну
+	Widths []int
+	// Align — выравнивание колонок; пусто — по левому краю
+	Align []Align
+}
+
+// cols возвращает число колонок по шапке и самой длинной строке
+func (t *Table) cols() int {
+	n := len(t.Header)
+	for _, row := range t.Rows {
+		if len(row) > n {
+			n = len(row)
+		}
+	}
+	return n
+}
+
+type blockKind int
+
+const (
+	blockTitle blockKind = iota
+	blockHeading
+	blockText
+	blockTable
+	blockPageBreak
+)
+
+type block struct {
+	kind  blockKind
+	text  string
+	table *Table
+}
+
+// Document — печатный документ. Блоки добавляются методами Title, Heading,
+// Text, Table и PageBreak и выводятся в порядке добавления
+type Document struct {
+	// Footer печатается внизу каждой страницы слева, справа — номер страницы
+	Footer string
+	blocks []block
+}
+
+// Title добавляет заголовок документа
+func (d *Document) Title(text string) {
+	d.blocks = append(d.blocks, block{kind: blockTitle, text: text})
+}
+
+// Heading добавляет заголовок раздела
+func (d *Document) Heading(text string) {
+	d.blocks = append(d.blocks, block{kind: blockHeading, text: text})
+}
+
+// Text добавляет абзац; переводы строк сохраняются, длинные строки переносятся
+func (d *Document) Text(text string) {
+	d.blocks = append(d.blocks, block{kind: blockText, text: text})
+}
+
+// Table добавляет таблицу
+func (d *Document) Table(t Table) {
+	d.blocks = append(d.blocks, block{kind: blockTable, table: &t})
+}
+
+// PageBreak начинает следующий блок с новой страницы
+func (d *Document) PageBreak() {
+	d.blocks = append(d.blocks, block{kind: blockPageBreak})
+}
+
+// Render раскладывает документ по страницам и записывает PDF в w
+func Render(w io.Writer, d *Document) error {
+	if d == nil || len(d.blocks) == 0 {
+		return ErrEmpty
+	}
+	if err := loadFonts(); err != nil {
+		return err
+	}
+
+	pages := layout(d)
+	desc := pdfDescriptor{
+		Paper:  "A4",
+		Origin: "LowerLeft",
+		Pages:  make(map[string]pdfPage, len(pages)),
+	}
+	for i, p := range pages {
+		footer := fmt.Sprintf("%d / %d", i+1, len(pages))
+		p.Text = append(p.Text, textAt(footer, fontRegular, footerSize,
+			pageWidth-margin-font.TextWidth(footer, fontRegular, footerSize), margin/2))
+		if d.Footer != "" {
+			p.Text = append(p.Text, textAt(fitText(d.Footer, fontRegular, footerSize, contentWidth-60),
+				fontRegular, footerSize, margin, margin/2))
+		}
+		desc.Pages[fmt.Sprint(i+1)] = pdfPage{Content: p}
+	}
+
+	data, err := json.Marshal(desc)
+	if err != nil {
+		return err
+	}
+	conf := model.NewDefaultConfiguration()
+	if err := api.Create(nil, strings.NewReader(string(data)), w, conf); err != nil {
+		return fmt.Errorf("pdfdoc: %w", err)
+	}
+	return nil
+}
+
+// Описание документа в формате pdfcpu create (JSON)
+type pdfDescriptor struct {
+	Paper  string             `json:"paper"`
+	Origin string             `json:"origin"`
+	Pages  map[string]pdfPage `json:"pages"`
+}
+
+type pdfPage struct {
+	Content pdfContent `json:"content"`
+}
+
+type pdfContent struct {
+	Text  []pdfText  `json:"text,omitempty"`
+	Table []pdfTable `json:"table,omitempty"`
+}
+
+type pdfFont struct {
+	Name string `json:"name"`
+	Size int    `json:"size"`
+	Col  string `json:"col,omitempty"`
+}
+
+type pdfText struct {
+	Value string     `json:"value"`
+	Pos   [2]float64 `json:"pos"`
+	Font  pdfFont    `json:"font"`
+}
+
+type pdfTableHeader struct {
+	Values     []string `json:"values"`
+	ColAnchors []string `json:"colAnchors,omitempty"`
+	BgCol      string   `json:"bgCol,omitempty"`
+	Font       pdfFont  `json:"font"`
+}
+
+type pdfTable struct {
+	Values     [][]string      `json:"values"`
+	Rows       int             `json:"rows"`
+	Cols       int             `json:"cols"`
+	Width      float64         `json:"width"`
+	ColWidths  []int           `json:"colWidths,omitempty"`
+	ColAnchors []string        `json:"colAnchors,omitempty"`
+	LineHeight int             `json:"lheight"`
+	Pos        [2]float64      `json:"pos"`
+	Font       pdfFont         `json:"font"`
+	Header     *pdfTableHeader `json:"header,omitempty"`
+	Grid       bool            `json:"grid"`
+}
+
+func textAt(value, fontName string, size int, x, y float64) pdfText {
+	return pdfText{Value: escape(value), Pos: [2]float64{x, y}, Font: pdfFont{Name: fontName, Size: size, Col: "#000000"}}
+}
+
+// escape экранирует «%»: pdfcpu подставляет вместо %p, %P, %t и %v номер страницы,
+// число страниц, время и версию
+func escape(s string) string {
+	return strings.ReplaceAll(s, "%", "%%")
+}
+
+var (
+	fontOnce sync.Once
+	fontErr  error
+)
+
+// loadFonts устанавливает шрифты Go во временный каталог pdfcpu один раз за запуск.
+// Каталог настроек pdfcpu в домашней папке не используется
+func loadFonts() error {
+	fontOnce.Do(func() {
+		api.DisableConfigDir()
+		dir, err := os.MkdirTemp("", "workbot-pdf-fonts")
+		if err != nil {
+			fontErr = err
+			return
+		}
+		font.UserFontDir = dir
+		for name, ttf := range map[string][]byte{fontRegular: goregular.TTF, fontBold: gobold.TTF} {
+			if err := font.InstallFontFromBytes(dir, name, ttf); err != nil {
+				fontErr = fmt.Errorf("pdfdoc: шрифт %s: %w", name, err)
+				return
+			}
+		}
+		fontErr = font.LoadUserFonts()
+	})
+	return fontErr
+}
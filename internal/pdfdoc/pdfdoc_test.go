@@ -0,0 +1,107 @@
+package pdfdoc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+func TestRenderEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, &Document{}); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("Render(empty) = %v, want ErrEmpty", err)
+	}
+}
+
+func TestRenderCyrillic(t *testing.T) {
+	var d Document
+	d.Footer = "Квитанция № 12"
+	d.Title("Квитанция об оплате")
+	d.Text("Клиент: Иван Петров\nПакет: 10 тренировок — 100% предоплата")
+	d.Table(Table{
+		Header: []string{"Услуга", "Кол-во", "Сумма"},
+		Rows:   [][]string{{"Персональная тренировка", "10", "25 000,00 RUB"}},
+		Widths: []int{60, 15, 25},
+		Align:  []Align{AlignLeft, AlignRight, AlignRight},
+	})
+
+	var buf bytes.Buffer
+	if err := Render(&buf, &d); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if n := pageCount(t, buf.Bytes()); n != 1 {
+		t.Errorf("pages = %d, want 1", n)
+	}
+	text := pageText(t, buf.Bytes(), 1)
+	for _, want := range []string{"Квитанция об оплате", "100% предоплата", "Персональная тренировка", "25 000,00 RUB", "1 / 1"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("page text %q does not contain %q", text, want)
+		}
+	}
+}
+
+func TestRenderLongTableSpansPages(t *testing.T) {
+	var d Document
+	d.Title("Программа")
+	rows := make([][]string, 120)
+	for i := range rows {
+		rows[i] = []string{fmt.Sprintf("Упражнение %d с очень длинным названием, которое не поместится в колонку", i+1), "3×8"}
+	}
+	d.Table(Table{Header: []string{"Упражнение", "Подходы"}, Rows: rows, Widths: []int{80, 20}})
+	d.PageBreak()
+	d.Heading("Неделя 2")
+	d.PageBreak()
+
+	var buf bytes.Buffer
+	if err := Render(&buf, &d); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	// 120 строк по 16 пт — три страницы таблицы, четвёртая — заголовок,
+	// разрыв в конце не добавляет пустую страницу
+	if n := pageCount(t, buf.Bytes()); n != 4 {
+		t.Errorf("pages = %d, want 4", n)
+	}
+}
+
+func TestColumnWidths(t *testing.T) {
+	got := columnWidths([]int{50}, 3)
+	want := []int{50, 25, 25}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("columnWidths = %v, want %v", got, want)
+		}
+	}
+}
+
+// pageText возвращает текст страницы, строки в порядке вывода
+func pageText(t *testing.T, data []byte, page int) string {
+	t.Helper()
+	r, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("pdf.NewReader: %v", err)
+	}
+	var sb strings.Builder
+	var y float64
+	for _, tx := range r.Page(page).Content().Text {
+		if tx.Y != y {
+			sb.WriteString("\n")
+			y = tx.Y
+		}
+		sb.WriteString(tx.S)
+	}
+	return sb.String()
+}
+
+func pageCount(t *testing.T, data []byte) int {
+	t.Helper()
+	n, err := api.PageCount(bytes.NewReader(data), nil)
+	if err != nil {
+		t.Fatalf("PageCount: %v", err)
+	}
+	return n
+}
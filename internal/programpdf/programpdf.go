@@ -0,0 +1,387 @@
+// Package programpdf собирает печатную программу тренировок в PDF.
+//
+// Документ начинается с титульной страницы: клиент, цель, сроки, обзор фаз
+// и таблицы рабочих весов от 1ПМ. Дальше на каждую тренировку — отдельная
+// карточка: по строке на подход с планом и пустыми клетками для фактического
+// веса и повторений. На вход подаётся программа в формате обмена, поэтому
+// одинаково печатаются программы бота, plgen и plancli.
+package programpdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"workbot/internal/interchange"
+	"workbot/internal/pdfdoc"
+	"workbot/internal/training"
+)
+
+// defaultIncrement — шаг округления рабочих весов, кг
+const defaultIncrement = 2.5
+
+// tablePercents — проценты от 1ПМ в таблицах рабочих весов
+var tablePercents = []float64{60, 65, 70, 75, 80, 85, 90, 95}
+
+// Labels — подписи документа. Строки с глаголами формата подставляются через fmt
+type Labels struct {
+	Client   string // «Клиент: %s»
+	Goal     string // «Цель: %s»
+	Start    string // «Начало: %s»
+	Duration string // «Недель: %d, тренировок в неделю: %d»
+
+	Phases     string // заголовок обзора фаз
+	PhaseName  string
+	PhaseWeeks string
+	PhaseFocus string
+
+	OneRM    string // заголовок таблиц рабочих весов
+	OneRMCol string
+
+	Workout  string // заголовок карточки: «Неделя %d · день %d»
+	Deload   string // пометка разгрузочной недели
+	Exercise string
+	Set      string
+	Plan     string
+	Weight   string
+	Reps     string
+	Kg       string // единица веса в плане подхода
+	Rest     string // «отдых %s»
+	Tempo    string // «темп %s»
+	Notes    string // строка для заметок в конце карточки
+
+	// Goals — названия целей и фокусов фаз; неизвестные выводятся как есть
+	Goals map[string]string
+}
+
+// DefaultLabels возвращает подписи на русском
+func DefaultLabels() Labels {
+	return Labels{
+		Client:     "Клиент: %s",
+		Goal:       "Цель: %s",
+		Start:      "Начало: %s",
+		Duration:   "Недель: %d, тренировок в неделю: %d",
+		Phases:     "Фазы",
+		PhaseName:  "Фаза",
+		PhaseWeeks: "Недели",
+		PhaseFocus: "Фокус",
+		OneRM:      "1ПМ и рабочие веса",
+		OneRMCol:   "1ПМ",
+		Workout:    "Неделя %d · день %d",
+		Deload:     "разгрузка",
+		Exercise:   "Упражнение",
+		Set:        "Подход",
+		Plan:       "План",
+		Weight:     "Вес, кг",
+		Reps:       "Повт.",
+		Kg:         "кг",
+		Rest:       "отдых %s",
+		Tempo:      "темп %s",
+		Notes:      "Самочувствие и заметки: ________________________________________________",
+		Goals: map[string]string{
+			"strength":    "сила",
+			"hypertrophy": "гипертрофия",
+			"fat_loss":    "снижение веса",
+			"weight_loss": "снижение веса",
+			"hyrox":       "Hyrox",
+			"endurance":   "выносливость",
+			"general":     "ОФП",
+			"competition": "соревнования",
+		},
+	}
+}
+
+// Options — настройки печати
+type Options struct {
+	// Labels — подписи; пустые — DefaultLabels
+	Labels Labels
+	// OneRM возвращает 1ПМ упражнения для пересчёта процентов в вес;
+	// по умолчанию — Program.OneRM по точному названию
+	OneRM func(exercise string) float64
+	// Increment — шаг округления весов, по умолчанию 2.5 кг
+	Increment float64
+}
+
+// Document собирает печатный документ программы
+func Document(p *interchange.Program, opts Options) *pdfdoc.Document {
+	b := newBuilder(p, opts)
+	b.cover()
+	for _, w := range p.Weeks {
+		for _, d := range w.Days {
+			b.card(&w, &d)
+		}
+	}
+	return b.doc
+}
+
+// Render записывает программу в PDF
+func Render(w io.Writer, p *interchange.Program, opts Options) error {
+	return pdfdoc.Render(w, Document(p, opts))
+}
+
+// Bytes возвращает программу в PDF
+func Bytes(p *interchange.Program, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := Render(&buf, p, opts); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type builder struct {
+	p         *interchange.Program
+	l         Labels
+	oneRM     func(string) float64
+	increment float64
+	doc       *pdfdoc.Document
+}
+
+func newBuilder(p *interchange.Program, opts Options) *builder {
+	b := &builder{p: p, l: opts.Labels, oneRM: opts.OneRM, increment: opts.Increment, doc: &pdfdoc.Document{}}
+	if b.l.Exercise == "" {
+		b.l = DefaultLabels()
+	}
+	if b.oneRM == nil {
+		b.oneRM = func(name string) float64 { return p.OneRM[name] }
+	}
+	if b.increment <= 0 {
+		b.increment = defaultIncrement
+	}
+	b.doc.Footer = p.Name
+	if p.Client != "" {
+		b.doc.Footer += " — " + p.Client
+	}
+	return b
+}
+
+// goal возвращает название цели или фокуса фазы
+func (b *builder) goal(code string) string {
+	if name, ok := b.l.Goals[code]; ok {
+		return name
+	}
+	return code
+}
+
+// cover выводит титульную страницу: сведения о программе, фазы и таблицы 1ПМ
+func (b *builder) cover() {
+	p, l := b.p, b.l
+	b.doc.Title(p.Name)
+
+	var info []string
+	if p.Client != "" {
+		info = append(info, fmt.Sprintf(l.Client, p.Client))
+	}
+	if p.Goal != "" {
+		info = append(info, fmt.Sprintf(l.Goal, b.goal(p.Goal)))
+	}
+	if p.StartDate != "" {
+		start := p.StartDate
+		if d, err := time.Parse("2006-01-02", start); err == nil {
+			start = d.Format("02.01.2006")
+		}
+		info = append(info, fmt.Sprintf(l.Start, start))
+	}
+	info = append(info, fmt.Sprintf(l.Duration, p.TotalWeeks(), daysPerWeek(p)))
+	b.doc.Text(strings.Join(info, "\n"))
+	if p.Description != "" {
+		b.doc.Text(p.Description)
+	}
+
+	if rows := b.phaseRows(); len(rows) > 0 {
+		b.doc.Heading(l.Phases)
+		b.doc.Table(pdfdoc.Table{
+			Header: []string{l.PhaseName, l.PhaseWeeks, l.PhaseFocus},
+			Rows:   rows,
+			Widths: []int{50, 20, 30},
+			Align:  []pdfdoc.Align{pdfdoc.AlignLeft, pdfdoc.AlignCenter, pdfdoc.AlignLeft},
+		})
+	}
+
+	if len(p.OneRM) > 0 {
+		b.doc.Heading(l.OneRM)
+		b.doc.Table(b.oneRMTable())
+	}
+}
+
+// phaseRows возвращает строки обзора фаз: из описания фаз, а если его нет —
+// по подряд идущим неделям с одинаковой фазой
+func (b *builder) phaseRows() [][]string {
+	var rows [][]string
+	if len(b.p.Phases) > 0 {
+		for _, ph := range b.p.Phases {
+			rows = append(rows, []string{ph.Name, weekRange(ph.WeekStart, ph.WeekEnd), b.goal(ph.Focus)})
+		}
+		return rows
+	}
+
+	start := 0
+	for i := 1; i <= len(b.p.Weeks); i++ {
+		if i < len(b.p.Weeks) && b.p.Weeks[i].Phase == b.p.Weeks[start].Phase {
+			continue
+		}
+		if name := b.p.Weeks[start].Phase; name != "" {
+			rows = append(rows, []string{name, weekRange(b.p.Weeks[start].Week, b.p.Weeks[i-1].Week), ""})
+		}
+		start = i
+	}
+	return rows
+}
+
+// oneRMTable — рабочие веса от 1ПМ по процентам, упражнения по алфавиту
+func (b *builder) oneRMTable() pdfdoc.Table {
+	names := make([]string, 0, len(b.p.OneRM))
+	for name := range b.p.OneRM {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	header := []string{b.l.Exercise, b.l.OneRMCol}
+	widths := []int{24, 12}
+	align := []pdfdoc.Align{pdfdoc.AlignLeft, pdfdoc.AlignCenter}
+	for _, pct := range tablePercents {
+		header = append(header, formatNumber(pct)+"%")
+		widths = append(widths, 8)
+		align = append(align, pdfdoc.AlignCenter)
+	}
+
+	t := pdfdoc.Table{Header: header, Widths: widths, Align: align}
+	for _, name := range names {
+		max := b.p.OneRM[name]
+		row := []string{name, formatNumber(max)}
+		for _, pct := range tablePercents {
+			row = append(row, formatNumber(training.CalculateWorkingWeightRound(max, pct, b.increment)))
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+// card выводит карточку тренировки на отдельной странице: по строке на каждый
+// подход, колонки факта остаются пустыми для записи от руки
+func (b *builder) card(w *interchange.Week, d *interchange.Day) {
+	l := b.l
+	b.doc.PageBreak()
+
+	title := fmt.Sprintf(l.Workout, w.Week, d.Day)
+	if d.Name != "" {
+		title += " — " + d.Name
+	}
+	b.doc.Heading(title)
+	var sub []string
+	if w.Phase != "" {
+		sub = append(sub, w.Phase)
+	}
+	if w.Deload {
+		sub = append(sub, l.Deload)
+	}
+	if len(sub) > 0 {
+		b.doc.Text(strings.Join(sub, " · "))
+	}
+
+	t := pdfdoc.Table{
+		Header: []string{l.Exercise, l.Set, l.Plan, l.Weight, l.Reps},
+		Widths: []int{34, 10, 30, 13, 13},
+		Align:  []pdfdoc.Align{pdfdoc.AlignLeft, pdfdoc.AlignCenter, pdfdoc.AlignLeft, pdfdoc.AlignCenter, pdfdoc.AlignCenter},
+	}
+	var notes []string
+	for _, ex := range d.Exercises {
+		n := 0
+		for _, g := range ex.Sets {
+			plan := b.plan(ex.Name, g)
+			for i := 0; i < g.Sets; i++ {
+				n++
+				name := ""
+				if n == 1 {
+					name = ex.Name
+				}
+				t.Rows = append(t.Rows, []string{name, strconv.Itoa(n), plan, "", ""})
+			}
+		}
+		if note := b.exerciseNote(&ex); note != "" {
+			notes = append(notes, ex.Name+": "+note)
+		}
+	}
+	b.doc.Table(t)
+	if len(notes) > 0 {
+		b.doc.Text(strings.Join(notes, "\n"))
+	}
+	b.doc.Text(l.Notes)
+}
+
+// plan описывает подход: вес (с процентом, если он задан), RPE и повторения,
+// например «77.5 кг (75%) × 5 @8»
+func (b *builder) plan(exercise string, g interchange.SetGroup) string {
+	var load string
+	weight := training.LoadSpec{Percent: g.Percent, Weight: g.WeightKg}.ResolveWeight(b.oneRM(exercise), b.increment)
+	switch {
+	case weight > 0 && g.Percent > 0:
+		load = fmt.Sprintf("%s %s (%s%%)", formatNumber(weight), b.l.Kg, formatNumber(g.Percent))
+	case weight > 0:
+		load = formatNumber(weight) + " " + b.l.Kg
+	case g.Percent > 0:
+		load = formatNumber(g.Percent) + "%"
+	}
+
+	plan := g.Reps
+	if load != "" {
+		plan = load + " × " + g.Reps
+	}
+	if g.RPE > 0 {
+		plan += " @" + formatNumber(g.RPE)
+	}
+	return plan
+}
+
+// exerciseNote собирает темп, отдых и заметку упражнения в одну строку
+func (b *builder) exerciseNote(ex *interchange.Exercise) string {
+	var parts []string
+	if ex.Tempo != "" {
+		parts = append(parts, fmt.Sprintf(b.l.Tempo, ex.Tempo))
+	}
+	if ex.RestSeconds > 0 {
+		parts = append(parts, fmt.Sprintf(b.l.Rest, formatRest(ex.RestSeconds)))
+	}
+	if ex.Notes != "" {
+		parts = append(parts, ex.Notes)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// daysPerWeek — тренировок в неделю: из программы или по самой насыщенной неделе
+func daysPerWeek(p *interchange.Program) int {
+	if p.DaysPerWeek > 0 {
+		return p.DaysPerWeek
+	}
+	n := 0
+	for _, w := range p.Weeks {
+		if len(w.Days) > n {
+			n = len(w.Days)
+		}
+	}
+	return n
+}
+
+func weekRange(from, to int) string {
+	if to <= from {
+		return strconv.Itoa(from)
+	}
+	return fmt.Sprintf("%d–%d", from, to)
+}
+
+// formatRest выводит отдых в минутах и секундах: «3:00», «45с»
+func formatRest(seconds int) string {
+	if seconds < 60 {
+		return fmt.Sprintf("%dс", seconds)
+	}
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// formatNumber выводит число с точностью до десятых без лишних нулей: 77.5, 80
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64)
+}
@@ -0,0 +1,120 @@
+package programpdf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+
+	"workbot/internal/interchange"
+)
+
+func testProgram() *interchange.Program {
+	return &interchange.Program{
+		Version:   interchange.SchemaVersion,
+		Name:      "Сила 3x/нед",
+		Goal:      "strength",
+		Client:    "Иван Петров",
+		StartDate: "2026-03-02",
+		OneRM:     map[string]float64{"Жим лёжа": 100},
+		Weeks: []interchange.Week{
+			{Week: 1, Phase: "Накопление", Days: []interchange.Day{
+				{Day: 1, Name: "День A", Exercises: []interchange.Exercise{
+					{Name: "Жим лёжа", RestSeconds: 180, Sets: []interchange.SetGroup{{Sets: 3, Reps: "5", Percent: 75}}},
+					{Name: "Подтягивания", Sets: []interchange.SetGroup{{Sets: 2, Reps: "8", RPE: 8}}},
+				}},
+				{Day: 3, Name: "День B", Exercises: []interchange.Exercise{
+					{Name: "Выпады", Sets: []interchange.SetGroup{{Sets: 3, Reps: "12", WeightKg: 20}}},
+				}},
+			}},
+			{Week: 2, Phase: "Реализация", Deload: true, Days: []interchange.Day{
+				{Day: 1, Exercises: []interchange.Exercise{
+					{Name: "Жим лёжа", Sets: []interchange.SetGroup{{Sets: 1, Reps: "3+", Percent: 85}}},
+				}},
+			}},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	data, err := Bytes(testProgram(), Options{})
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	// Титульная страница и по карточке на каждую из трёх тренировок
+	n, err := api.PageCount(bytes.NewReader(data), nil)
+	if err != nil {
+		t.Fatalf("PageCount: %v", err)
+	}
+	if n != 4 {
+		t.Fatalf("pages = %d, want 4", n)
+	}
+
+	for page, want := range map[int][]string{
+		1: {"Клиент: Иван Петров", "Цель: сила", "Начало: 02.03.2026", "Недель: 2, тренировок в неделю: 2", "Накопление", "Реализация", "75"},
+		2: {"Неделя 1 · день 1 — День A", "75 кг (75%) × 5", "@8", "отдых 3:00", "Самочувствие"},
+		4: {"разгрузка", "85 кг (85%) × 3+"},
+	} {
+		text := pageText(t, data, page)
+		for _, s := range want {
+			if !strings.Contains(text, s) {
+				t.Errorf("страница %d: нет %q в\n%s", page, s, text)
+			}
+		}
+	}
+}
+
+func TestPlan(t *testing.T) {
+	b := newBuilder(testProgram(), Options{OneRM: func(name string) float64 {
+		if name == "Жим стоя" {
+			return 65
+		}
+		return 0
+	}})
+	tests := []struct {
+		exercise string
+		group    interchange.SetGroup
+		want     string
+	}{
+		{"Жим стоя", interchange.SetGroup{Reps: "5", Percent: 76.5}, "50 кг (76.5%) × 5"},
+		{"Жим лёжа", interchange.SetGroup{Reps: "5", Percent: 75}, "75% × 5"},
+		{"Выпады", interchange.SetGroup{Reps: "12", WeightKg: 20}, "20 кг × 12"},
+		{"Планка", interchange.SetGroup{Reps: "30с"}, "30с"},
+		{"Присед", interchange.SetGroup{Reps: "3", RPE: 8.5}, "3 @8.5"},
+	}
+	for _, tt := range tests {
+		if got := b.plan(tt.exercise, tt.group); got != tt.want {
+			t.Errorf("plan(%s, %+v) = %q, want %q", tt.exercise, tt.group, got, tt.want)
+		}
+	}
+}
+
+func TestPhaseRowsFromWeeks(t *testing.T) {
+	p := testProgram()
+	p.Weeks = append(p.Weeks, interchange.Week{Week: 3, Phase: "Реализация"})
+	rows := newBuilder(p, Options{}).phaseRows()
+	if len(rows) != 2 || rows[0][1] != "1" || rows[1][1] != "2–3" {
+		t.Errorf("phaseRows = %v", rows)
+	}
+}
+
+// pageText возвращает текст страницы, строки в порядке вывода
+func pageText(t *testing.T, data []byte, page int) string {
+	t.Helper()
+	r, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("pdf.NewReader: %v", err)
+	}
+	var sb strings.Builder
+	var y float64
+	for _, tx := range r.Page(page).Content().Text {
+		if tx.Y != y {
+			sb.WriteString("\n")
+			y = tx.Y
+		}
+		sb.WriteString(tx.S)
+	}
+	return sb.String()
+}
@@ -201,10 +201,10 @@ func (r *AppointmentRepository) GetBookedSlots(date time.Time) ([]string, error)
 	return slots, nil
 }
 
-// UpdateStatus обновляет статус записи
+// UpdateStatus обновляет статус записи; SEQUENCE в ленте календаря растёт
 func (r *AppointmentRepository) UpdateStatus(id int, status string) error {
 	_, err := r.db.Exec(
-		"UPDATE public.appointments SET status = $1, updated_at = NOW() WHERE id = $2",
+		"UPDATE public.appointments SET status = $1, ics_sequence = ics_sequence + 1, updated_at = NOW() WHERE id = $2",
 		status, id,
 	)
 	return err
@@ -220,3 +220,115 @@ func (r *AppointmentRepository) GetClientTelegramID(appointmentID int) (int64, e
 		WHERE a.id = $1`, appointmentID).Scan(&telegramID)
 	return telegramID, err
 }
+
+// GetActiveByDate возвращает все неотменённые записи на дату
+func (r *AppointmentRepository) GetActiveByDate(date time.Time) ([]AppointmentWithClient, error) {
+	rows, err := r.db.Query(`
+		SELECT a.id, a.client_id, a.trainer_id, a.appointment_date,
+		       TO_CHAR(a.start_time, 'HH24:MI'), TO_CHAR(a.end_time, 'HH24:MI'),
+		       a.status, COALESCE(a.notes, ''), a.created_at, a.updated_at,
+		       c.name, c.surname
+		FROM public.appointments a
+		JOIN public.clients c ON a.client_id = c.id
+		WHERE a.appointment_date = $1 AND a.status != 'cancelled'
+		ORDER BY a.start_time`, date.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var appointments []AppointmentWithClient
+	for rows.Next() {
+		var a AppointmentWithClient
+		if err := rows.Scan(&a.ID, &a.ClientID, &a.TrainerID, &a.AppointmentDate,
+			&a.StartTime, &a.EndTime, &a.Status, &a.Notes,
+			&a.CreatedAt, &a.UpdatedAt,
+			&a.ClientName, &a.ClientSurname); err != nil {
+			continue
+		}
+		appointments = append(appointments, a)
+	}
+	return appointments, nil
+}
+
+// AppointmentMove описывает перенос записи на новые дату и время
+type AppointmentMove struct {
+	ID        int
+	Date      time.Time
+	StartTime string
+	EndTime   string
+}
+
+// RescheduleBatch переносит несколько записей в одной транзакции.
+// Порядок moves важен: уникальный индекс слота проверяется на каждом UPDATE
+func (r *AppointmentRepository) RescheduleBatch(moves []AppointmentMove) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, m := range moves {
+		_, err := tx.Exec(`
+			UPDATE public.appointments
+			SET appointment_date = $1, start_time = $2, end_time = $3,
+			    reminder_1day_sent = FALSE, reminder_1hour_sent = FALSE,
+			    ics_sequence = ics_sequence + 1, updated_at = NOW()
+			WHERE id = $4`,
+			m.Date.Format("2006-01-02"), m.StartTime, m.EndTime, m.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GoogleSyncAppointment — запись, изменённая после отправки в Google Calendar
+type GoogleSyncAppointment struct {
+	AppointmentWithClient
+	GoogleEventID string
+}
+
+// GoogleSyncPending возвращает записи, изменённые после отправки в Google Calendar.
+// Прошлые записи без события не отправляются: календарь заполняется с сегодняшнего дня
+func (r *AppointmentRepository) GoogleSyncPending(limit int) ([]GoogleSyncAppointment, error) {
+	rows, err := r.db.Query(`
+		SELECT a.id, a.client_id, a.trainer_id, a.appointment_date,
+		       TO_CHAR(a.start_time, 'HH24:MI'), TO_CHAR(a.end_time, 'HH24:MI'),
+		       a.status, COALESCE(a.notes, ''), a.created_at, a.updated_at,
+		       c.name, c.surname, COALESCE(a.google_event_id, '')
+		FROM public.appointments a
+		JOIN public.clients c ON a.client_id = c.id
+		WHERE (a.google_synced_at IS NULL OR a.updated_at > a.google_synced_at)
+		  AND (a.appointment_date >= CURRENT_DATE OR a.google_event_id IS NOT NULL)
+		ORDER BY a.updated_at
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var appointments []GoogleSyncAppointment
+	for rows.Next() {
+		var a GoogleSyncAppointment
+		if err := rows.Scan(&a.ID, &a.ClientID, &a.TrainerID, &a.AppointmentDate,
+			&a.StartTime, &a.EndTime, &a.Status, &a.Notes,
+			&a.CreatedAt, &a.UpdatedAt,
+			&a.ClientName, &a.ClientSurname, &a.GoogleEventID); err != nil {
+			return nil, err
+		}
+		appointments = append(appointments, a)
+	}
+	return appointments, rows.Err()
+}
+
+// MarkGoogleSynced запоминает событие записи ("" — события нет) и версию записи
+// (updated_at), которая в нём отражена. Если запись успели изменить, она останется в очереди
+func (r *AppointmentRepository) MarkGoogleSynced(id int, eventID string, version time.Time) error {
+	_, err := r.db.Exec(`
+		UPDATE public.appointments
+		SET google_event_id = NULLIF($2, ''), google_synced_at = $3
+		WHERE id = $1`, id, eventID, version)
+	return err
+}
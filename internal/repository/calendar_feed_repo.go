@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"time"
+)
+
+// Роли ленты календаря
+const (
+	FeedRoleClient  = "client"
+	FeedRoleTrainer = "trainer"
+)
+
+// feedTokenBytes — случайных байт в токене ссылки (в base64url — 32 символа)
+const feedTokenBytes = 24
+
+// CalendarFeed — ссылка на ленту календаря
+type CalendarFeed struct {
+	Token         string
+	TelegramID    int64
+	Role          string
+	CreatedAt     time.Time
+	LastFetchedAt sql.NullTime
+}
+
+// FeedAppointment — запись на тренировку для ленты календаря
+type FeedAppointment struct {
+	AppointmentWithClient
+	Sequence int
+}
+
+// FeedWorkout — тренировка программы с плановой датой для ленты календаря
+type FeedWorkout struct {
+	ID            int
+	Name          string
+	PlannedDate   time.Time
+	Status        string
+	Sequence      int
+	ProgramName   string
+	ProgramStatus string
+}
+
+// CalendarFeedRepository работает со ссылками на ленты календаря
+type CalendarFeedRepository struct {
+	db *sql.DB
+}
+
+// NewCalendarFeedRepository создаёт репозиторий лент календаря
+func NewCalendarFeedRepository(db *sql.DB) *CalendarFeedRepository {
+	return &CalendarFeedRepository{db: db}
+}
+
+// GetActive возвращает действующую ссылку пользователя (nil — ссылки нет)
+func (r *CalendarFeedRepository) GetActive(telegramID int64) (*CalendarFeed, error) {
+	return r.scanFeed(r.db.QueryRow(`
+		SELECT token, telegram_id, role, created_at, last_fetched_at
+		FROM public.calendar_feeds
+		WHERE telegram_id = $1 AND revoked_at IS NULL`, telegramID))
+}
+
+// GetByToken возвращает действующую ссылку по токену (nil — нет или отозвана)
+func (r *CalendarFeedRepository) GetByToken(token string) (*CalendarFeed, error) {
+	return r.scanFeed(r.db.QueryRow(`
+		SELECT token, telegram_id, role, created_at, last_fetched_at
+		FROM public.calendar_feeds
+		WHERE token = $1 AND revoked_at IS NULL`, token))
+}
+
+func (r *CalendarFeedRepository) scanFeed(row *sql.Row) (*CalendarFeed, error) {
+	f := &CalendarFeed{}
+	err := row.Scan(&f.Token, &f.TelegramID, &f.Role, &f.CreatedAt, &f.LastFetchedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Issue отзывает действующую ссылку пользователя и выдаёт новую
+func (r *CalendarFeedRepository) Issue(telegramID int64, role string) (*CalendarFeed, error) {
+	buf := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	f := &CalendarFeed{
+		Token:      base64.RawURLEncoding.EncodeToString(buf),
+		TelegramID: telegramID,
+		Role:       role,
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		UPDATE public.calendar_feeds SET revoked_at = NOW()
+		WHERE telegram_id = $1 AND revoked_at IS NULL`, telegramID); err != nil {
+		return nil, err
+	}
+	if err := tx.QueryRow(`
+		INSERT INTO public.calendar_feeds (token, telegram_id, role)
+		VALUES ($1, $2, $3)
+		RETURNING created_at`, f.Token, telegramID, role).Scan(&f.CreatedAt); err != nil {
+		return nil, err
+	}
+	return f, tx.Commit()
+}
+
+// Revoke отзывает действующую ссылку пользователя; false — ссылки не было
+func (r *CalendarFeedRepository) Revoke(telegramID int64) (bool, error) {
+	result, err := r.db.Exec(`
+		UPDATE public.calendar_feeds SET revoked_at = NOW()
+		WHERE telegram_id = $1 AND revoked_at IS NULL`, telegramID)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// MarkFetched запоминает, что календарь забрал ленту
+func (r *CalendarFeedRepository) MarkFetched(token string) error {
+	_, err := r.db.Exec(
+		"UPDATE public.calendar_feeds SET last_fetched_at = NOW() WHERE token = $1", token)
+	return err
+}
+
+// ClientAppointments возвращает записи клиента с даты since, включая отменённые
+func (r *CalendarFeedRepository) ClientAppointments(telegramID int64, since time.Time) ([]FeedAppointment, error) {
+	return r.queryAppointments(`
+		WHERE c.telegram_id = $1 AND c.deleted_at IS NULL AND a.appointment_date >= $2`,
+		telegramID, since)
+}
+
+// TrainerAppointments возвращает записи к тренеру с даты since, включая отменённые
+func (r *CalendarFeedRepository) TrainerAppointments(trainerID int64, since time.Time) ([]FeedAppointment, error) {
+	return r.queryAppointments(`
+		WHERE a.trainer_id = $1 AND a.appointment_date >= $2`,
+		trainerID, since)
+}
+
+func (r *CalendarFeedRepository) queryAppointments(where string, telegramID int64, since time.Time) ([]FeedAppointment, error) {
+	rows, err := r.db.Query(`
+		SELECT a.id, a.client_id, a.trainer_id, a.appointment_date,
+		       TO_CHAR(a.start_time, 'HH24:MI'), TO_CHAR(a.end_time, 'HH24:MI'),
+		       a.status, COALESCE(a.notes, ''), a.created_at, a.updated_at,
+		       c.name, c.surname, a.ics_sequence
+		FROM public.appointments a
+		JOIN public.clients c ON a.client_id = c.id`+where+`
+		ORDER BY a.appointment_date, a.start_time`, telegramID, since.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var appointments []FeedAppointment
+	for rows.Next() {
+		var a FeedAppointment
+		if err := rows.Scan(&a.ID, &a.ClientID, &a.TrainerID, &a.AppointmentDate,
+			&a.StartTime, &a.EndTime, &a.Status, &a.Notes,
+			&a.CreatedAt, &a.UpdatedAt,
+			&a.ClientName, &a.ClientSurname, &a.Sequence); err != nil {
+			return nil, err
+		}
+		appointments = append(appointments, a)
+	}
+	return appointments, rows.Err()
+}
+
+// ClientWorkouts возвращает тренировки программ клиента с плановой датой не раньше since
+func (r *CalendarFeedRepository) ClientWorkouts(telegramID int64, since time.Time) ([]FeedWorkout, error) {
+	rows, err := r.db.Query(`
+		SELECT pw.id, pw.name, pw.planned_date, pw.status, pw.ics_sequence, p.name, p.status
+		FROM public.program_workouts pw
+		JOIN public.training_programs p ON p.id = pw.program_id
+		JOIN public.clients c ON c.id = p.client_id
+		WHERE c.telegram_id = $1 AND c.deleted_at IS NULL
+		  AND pw.planned_date IS NOT NULL AND pw.planned_date >= $2
+		ORDER BY pw.planned_date, pw.order_in_week, pw.id`, telegramID, since.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var workouts []FeedWorkout
+	for rows.Next() {
+		var w FeedWorkout
+		if err := rows.Scan(&w.ID, &w.Name, &w.PlannedDate, &w.Status, &w.Sequence,
+			&w.ProgramName, &w.ProgramStatus); err != nil {
+			return nil, err
+		}
+		workouts = append(workouts, w)
+	}
+	return workouts, rows.Err()
+}
@@ -151,6 +151,24 @@ func (r *ClientRepository) SoftDelete(clientID int) error {
 	return err
 }
 
+// MarkBotBlocked отмечает, что клиент заблокировал бота (ответ 403)
+func (r *ClientRepository) MarkBotBlocked(telegramID int64) error {
+	_, err := r.db.Exec(
+		"UPDATE public.clients SET bot_blocked_at = NOW() WHERE telegram_id = $1 AND bot_blocked_at IS NULL",
+		telegramID,
+	)
+	return err
+}
+
+// ClearBotBlocked снимает отметку о блокировке, когда клиент снова пишет боту
+func (r *ClientRepository) ClearBotBlocked(telegramID int64) error {
+	_, err := r.db.Exec(
+		"UPDATE public.clients SET bot_blocked_at = NULL WHERE telegram_id = $1 AND bot_blocked_at IS NOT NULL",
+		telegramID,
+	)
+	return err
+}
+
 // AddGoalHistory добавляет цель в историю
 func (r *ClientRepository) AddGoalHistory(clientID int, goal string) error {
 	_, err := r.db.Exec(
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+)
+
+// ClientGroup представляет группу клиентов тренера
+type ClientGroup struct {
+	ID          int
+	TrainerID   int64
+	Name        string
+	MemberCount int
+	CreatedAt   time.Time
+}
+
+// GroupMember представляет клиента в группе
+type GroupMember struct {
+	ClientID   int
+	TelegramID int64
+	Name       string
+	Surname    string
+	BotBlocked bool // клиент заблокировал бота — сообщения ему не доставляются
+}
+
+// FullName возвращает имя и фамилию клиента
+func (m GroupMember) FullName() string {
+	if m.Surname == "" {
+		return m.Name
+	}
+	return m.Name + " " + m.Surname
+}
+
+// GroupRepository работает с группами клиентов
+type GroupRepository struct {
+	db *sql.DB
+}
+
+// NewGroupRepository создаёт репозиторий групп
+func NewGroupRepository(db *sql.DB) *GroupRepository {
+	return &GroupRepository{db: db}
+}
+
+// Create создаёт группу
+func (r *GroupRepository) Create(trainerID int64, name string) (int, error) {
+	var id int
+	err := r.db.QueryRow(`
+		INSERT INTO public.client_groups (trainer_id, name)
+		VALUES ($1, $2)
+		RETURNING id`, trainerID, name).Scan(&id)
+	return id, err
+}
+
+// GetByTrainer возвращает группы тренера с количеством участников
+func (r *GroupRepository) GetByTrainer(trainerID int64) ([]ClientGroup, error) {
+	rows, err := r.db.Query(`
+		SELECT g.id, g.trainer_id, g.name, COUNT(c.id), g.created_at
+		FROM public.client_groups g
+		LEFT JOIN public.client_group_members m ON m.group_id = g.id
+		LEFT JOIN public.clients c ON c.id = m.client_id AND c.deleted_at IS NULL
+		WHERE g.trainer_id = $1
+		GROUP BY g.id
+		ORDER BY g.name`, trainerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []ClientGroup
+	for rows.Next() {
+		var g ClientGroup
+		if err := rows.Scan(&g.ID, &g.TrainerID, &g.Name, &g.MemberCount, &g.CreatedAt); err != nil {
+			continue
+		}
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+// GetByID возвращает группу по ID
+func (r *GroupRepository) GetByID(id int) (*ClientGroup, error) {
+	g := &ClientGroup{}
+	err := r.db.QueryRow(`
+		SELECT g.id, g.trainer_id, g.name, COUNT(c.id), g.created_at
+		FROM public.client_groups g
+		LEFT JOIN public.client_group_members m ON m.group_id = g.id
+		LEFT JOIN public.clients c ON c.id = m.client_id AND c.deleted_at IS NULL
+		WHERE g.id = $1
+		GROUP BY g.id`, id).Scan(&g.ID, &g.TrainerID, &g.Name, &g.MemberCount, &g.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Delete удаляет группу (клиенты остаются)
+func (r *GroupRepository) Delete(id int) error {
+	_, err := r.db.Exec("DELETE FROM public.client_groups WHERE id = $1", id)
+	return err
+}
+
+// AddMember добавляет клиента в группу
+func (r *GroupRepository) AddMember(groupID, clientID int) error {
+	_, err := r.db.Exec(`
+		INSERT INTO public.client_group_members (group_id, client_id)
+		VALUES ($1, $2)
+		ON CONFLICT (group_id, client_id) DO NOTHING`, groupID, clientID)
+	return err
+}
+
+// RemoveMember удаляет клиента из группы
+func (r *GroupRepository) RemoveMember(groupID, clientID int) error {
+	_, err := r.db.Exec(
+		"DELETE FROM public.client_group_members WHERE group_id = $1 AND client_id = $2",
+		groupID, clientID,
+	)
+	return err
+}
+
+// IsMember проверяет, состоит ли клиент в группе
+func (r *GroupRepository) IsMember(groupID, clientID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM public.client_group_members WHERE group_id = $1 AND client_id = $2)`,
+		groupID, clientID).Scan(&exists)
+	return exists, err
+}
+
+// GetMembers возвращает активных клиентов группы
+func (r *GroupRepository) GetMembers(groupID int) ([]GroupMember, error) {
+	rows, err := r.db.Query(`
+		SELECT c.id, COALESCE(c.telegram_id, 0), c.name, c.surname, c.bot_blocked_at IS NOT NULL
+		FROM public.client_group_members m
+		JOIN public.clients c ON c.id = m.client_id
+		WHERE m.group_id = $1 AND c.deleted_at IS NULL
+		ORDER BY c.name, c.surname`, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanGroupMembers(rows)
+}
+
+// GetAllClientsAsMembers возвращает всех активных клиентов (виртуальная группа "Все клиенты")
+func (r *GroupRepository) GetAllClientsAsMembers() ([]GroupMember, error) {
+	rows, err := r.db.Query(`
+		SELECT c.id, COALESCE(c.telegram_id, 0), c.name, c.surname, c.bot_blocked_at IS NOT NULL
+		FROM public.clients c
+		LEFT JOIN public.admins a ON c.telegram_id = a.telegram_id
+		WHERE a.telegram_id IS NULL AND c.deleted_at IS NULL
+		ORDER BY c.name, c.surname`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanGroupMembers(rows)
+}
+
+// scanGroupMembers сканирует строки с участниками группы
+func scanGroupMembers(rows *sql.Rows) ([]GroupMember, error) {
+	var members []GroupMember
+	for rows.Next() {
+		var m GroupMember
+		if err := rows.Scan(&m.ClientID, &m.TelegramID, &m.Name, &m.Surname, &m.BotBlocked); err != nil {
+			continue
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 
 	"workbot/internal/models"
@@ -239,7 +240,8 @@ func (r *ProgramRepository) GetExercisesByWorkout(workoutID int) ([]models.Worko
 		       COALESCE(weight, 0), COALESCE(weight_percent, 0), COALESCE(rest_seconds, 0),
 		       COALESCE(tempo, ''), COALESCE(rpe, 0), COALESCE(notes, ''),
 		       COALESCE(actual_sets, 0), COALESCE(actual_reps, 0), COALESCE(actual_weight, 0),
-		       COALESCE(actual_rpe, 0), COALESCE(completed, false)
+		       COALESCE(actual_rpe, 0), COALESCE(completed, false),
+		       COALESCE(original_exercise_name, ''), COALESCE(substitution_reason, '')
 		FROM public.workout_exercises
 		WHERE workout_id = $1
 		ORDER BY order_num`
@@ -257,6 +259,7 @@ func (r *ProgramRepository) GetExercisesByWorkout(workoutID int) ([]models.Worko
 			&e.ID, &e.WorkoutID, &e.OrderNum, &e.ExerciseName, &e.Sets, &e.Reps,
 			&e.Weight, &e.WeightPercent, &e.RestSeconds, &e.Tempo, &e.RPE, &e.Notes,
 			&e.ActualSets, &e.ActualReps, &e.ActualWeight, &e.ActualRPE, &e.Completed,
+			&e.OriginalExerciseName, &e.SubstitutionReason,
 		)
 		if err != nil {
 			return nil, err
@@ -274,7 +277,8 @@ func (r *ProgramRepository) GetExerciseByID(exerciseID int) (*models.WorkoutExer
 		       COALESCE(weight, 0), COALESCE(weight_percent, 0), COALESCE(rest_seconds, 0),
 		       COALESCE(tempo, ''), COALESCE(rpe, 0), COALESCE(notes, ''),
 		       COALESCE(actual_sets, 0), COALESCE(actual_reps, 0), COALESCE(actual_weight, 0),
-		       COALESCE(actual_rpe, 0), COALESCE(completed, false)
+		       COALESCE(actual_rpe, 0), COALESCE(completed, false),
+		       COALESCE(original_exercise_name, ''), COALESCE(substitution_reason, '')
 		FROM public.workout_exercises
 		WHERE id = $1`
 
@@ -283,6 +287,7 @@ func (r *ProgramRepository) GetExerciseByID(exerciseID int) (*models.WorkoutExer
 		&e.ID, &e.WorkoutID, &e.OrderNum, &e.ExerciseName, &e.Sets, &e.Reps,
 		&e.Weight, &e.WeightPercent, &e.RestSeconds, &e.Tempo, &e.RPE, &e.Notes,
 		&e.ActualSets, &e.ActualReps, &e.ActualWeight, &e.ActualRPE, &e.Completed,
+		&e.OriginalExerciseName, &e.SubstitutionReason,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -304,21 +309,21 @@ func (r *ProgramRepository) MarkWorkoutSent(workoutID int) error {
 	return err
 }
 
-// MarkWorkoutCompleted отмечает тренировку как выполненную
-func (r *ProgramRepository) MarkWorkoutCompleted(workoutID int, feedback string) error {
+// MarkWorkoutCompleted отмечает тренировку как выполненную; sessionRPE = 0 — клиент не оценил
+func (r *ProgramRepository) MarkWorkoutCompleted(workoutID int, feedback string, sessionRPE int) error {
 	query := `
 		UPDATE public.program_workouts
-		SET status = 'completed', completed_at = $1, feedback = $2
-		WHERE id = $3`
-	_, err := r.db.Exec(query, time.Now(), feedback, workoutID)
+		SET status = 'completed', completed_at = $1, feedback = $2, session_rpe = NULLIF($3, 0)
+		WHERE id = $4`
+	_, err := r.db.Exec(query, time.Now(), feedback, sessionRPE, workoutID)
 	return err
 }
 
-// MarkWorkoutSkipped отмечает тренировку как пропущенную
+// MarkWorkoutSkipped отмечает тренировку как пропущенную (в ленте календаря — отменена)
 func (r *ProgramRepository) MarkWorkoutSkipped(workoutID int) error {
 	query := `
 		UPDATE public.program_workouts
-		SET status = 'skipped'
+		SET status = 'skipped', ics_sequence = ics_sequence + 1
 		WHERE id = $1`
 	_, err := r.db.Exec(query, workoutID)
 	return err
@@ -357,6 +362,269 @@ func (r *ProgramRepository) MarkExerciseSkipped(exerciseID int) error {
 	return err
 }
 
+// SubstituteExercise заменяет упражнение в тренировке, сохраняя исходное.
+// При повторной замене исходным остаётся упражнение из плана.
+func (r *ProgramRepository) SubstituteExercise(exerciseID int, newName string, newWeight float64, reason string) error {
+	query := `
+		UPDATE public.workout_exercises
+		SET original_exercise_name = COALESCE(original_exercise_name, exercise_name),
+		    original_weight = COALESCE(original_weight, weight),
+		    exercise_name = $1,
+		    weight = NULLIF($2::numeric, 0),
+		    substitution_reason = $3,
+		    substituted_at = $4
+		WHERE id = $5`
+	_, err := r.db.Exec(query, newName, newWeight, reason, time.Now(), exerciseID)
+	return err
+}
+
+// AdjustExerciseLoad меняет подходы и вес упражнения перед тренировкой (по анкете
+// готовности или целевому RPE). Плановые значения сохраняются при первой корректировке
+func (r *ProgramRepository) AdjustExerciseLoad(exerciseID, sets int, weight, weightPercent float64) error {
+	query := `
+		UPDATE public.workout_exercises
+		SET planned_sets = COALESCE(planned_sets, sets),
+		    planned_weight = COALESCE(planned_weight, weight),
+		    sets = $1,
+		    weight = NULLIF($2::numeric, 0),
+		    weight_percent = NULLIF($3::numeric, 0)
+		WHERE id = $4`
+	_, err := r.db.Exec(query, sets, weight, weightPercent, exerciseID)
+	return err
+}
+
+// CountProgramSubstitutions возвращает, сколько раз в программе упражнение
+// original заменялось на replacement
+func (r *ProgramRepository) CountProgramSubstitutions(programID int, original, replacement string) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM public.workout_exercises we
+		JOIN public.program_workouts pw ON we.workout_id = pw.id
+		WHERE pw.program_id = $1
+		  AND we.original_exercise_name = $2
+		  AND we.exercise_name = $3`
+
+	var count int
+	err := r.db.QueryRow(query, programID, original, replacement).Scan(&count)
+	return count, err
+}
+
+// ApplySubstitutionToProgram заменяет упражнение во всех оставшихся тренировках программы.
+// weightRatio пересчитывает плановый вес (0 — вес сбрасывается, клиент подбирает по RPE).
+// Исходное упражнение и вес сохраняются, как при замене на тренировке, чтобы тренер видел оригинал
+func (r *ProgramRepository) ApplySubstitutionToProgram(programID int, original, replacement, reason string, weightRatio float64) (int64, error) {
+	query := `
+		UPDATE public.workout_exercises we
+		SET original_exercise_name = COALESCE(we.original_exercise_name, we.exercise_name),
+		    original_weight = COALESCE(we.original_weight, we.weight),
+		    exercise_name = $3,
+		    weight = CASE WHEN $4::numeric > 0 THEN ROUND(we.weight * $4::numeric * 2) / 2 ELSE NULL END,
+		    substitution_reason = $5,
+		    substituted_at = $6
+		FROM public.program_workouts pw
+		WHERE we.workout_id = pw.id
+		  AND pw.program_id = $1
+		  AND pw.status IN ('pending', 'sent')
+		  AND we.exercise_name = $2
+		  AND COALESCE(we.completed, false) = false`
+
+	res, err := r.db.Exec(query, programID, original, replacement, weightRatio, reason, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// GetSubstitutionWeights возвращает исходный и новый вес заменённого упражнения
+func (r *ProgramRepository) GetSubstitutionWeights(exerciseID int) (originalWeight, newWeight float64, err error) {
+	query := `
+		SELECT COALESCE(original_weight, 0), COALESCE(weight, 0)
+		FROM public.workout_exercises
+		WHERE id = $1`
+	err = r.db.QueryRow(query, exerciseID).Scan(&originalWeight, &newWeight)
+	return originalWeight, newWeight, err
+}
+
+// AddWorkoutSet записывает очередной подход упражнения
+func (r *ProgramRepository) AddWorkoutSet(exerciseID, reps int, weight, rpe float64) (*models.WorkoutSet, error) {
+	query := `
+		INSERT INTO public.workout_sets (workout_exercise_id, set_num, reps, weight, rpe)
+		SELECT $1, COALESCE(MAX(set_num), 0) + 1, $2, $3, NULLIF($4::numeric, 0)
+		FROM public.workout_sets
+		WHERE workout_exercise_id = $1
+		RETURNING id, set_num, created_at`
+
+	set := &models.WorkoutSet{
+		WorkoutExerciseID: exerciseID,
+		Reps:              reps,
+		Weight:            weight,
+		RPE:               rpe,
+	}
+	err := r.db.QueryRow(query, exerciseID, reps, weight, rpe).Scan(&set.ID, &set.SetNum, &set.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// GetWorkoutSets возвращает записанные подходы упражнения
+func (r *ProgramRepository) GetWorkoutSets(exerciseID int) ([]models.WorkoutSet, error) {
+	query := `
+		SELECT id, workout_exercise_id, set_num, reps, weight, COALESCE(rpe, 0), created_at
+		FROM public.workout_sets
+		WHERE workout_exercise_id = $1
+		ORDER BY set_num`
+
+	rows, err := r.db.Query(query, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sets []models.WorkoutSet
+	for rows.Next() {
+		var s models.WorkoutSet
+		if err := rows.Scan(&s.ID, &s.WorkoutExerciseID, &s.SetNum, &s.Reps, &s.Weight, &s.RPE, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		sets = append(sets, s)
+	}
+	return sets, rows.Err()
+}
+
+// UpdateWorkoutSetRPE сохраняет RPE подхода. Подход ищется только среди подходов
+// упражнения exerciseID, чтобы чужой setID из кнопки ничего не менял
+func (r *ProgramRepository) UpdateWorkoutSetRPE(exerciseID, setID int, rpe float64) error {
+	_, err := r.db.Exec(`
+		UPDATE public.workout_sets SET rpe = $1
+		WHERE id = $2 AND workout_exercise_id = $3`, rpe, setID, exerciseID)
+	return err
+}
+
+// DeleteLastWorkoutSet удаляет последний записанный подход упражнения
+func (r *ProgramRepository) DeleteLastWorkoutSet(exerciseID int) error {
+	query := `
+		DELETE FROM public.workout_sets
+		WHERE id = (
+			SELECT id FROM public.workout_sets
+			WHERE workout_exercise_id = $1
+			ORDER BY set_num DESC
+			LIMIT 1
+		)`
+	_, err := r.db.Exec(query, exerciseID)
+	return err
+}
+
+// SyncExerciseResultFromSets обновляет итог упражнения по записанным подходам:
+// количество подходов, средние повторы, рабочий (максимальный) вес и максимальный RPE.
+// Если подходов не осталось (отменён последний), упражнение снова считается невыполненным
+func (r *ProgramRepository) SyncExerciseResultFromSets(exerciseID int) error {
+	query := `
+		UPDATE public.workout_exercises we
+		SET actual_sets = NULLIF(s.cnt, 0),
+		    actual_reps = s.avg_reps,
+		    actual_weight = s.top_weight,
+		    actual_rpe = s.max_rpe,
+		    completed = s.cnt > 0
+		FROM (
+			SELECT COUNT(*)::int AS cnt,
+			       ROUND(AVG(reps))::int AS avg_reps,
+			       MAX(weight) AS top_weight,
+			       MAX(rpe) AS max_rpe
+			FROM public.workout_sets
+			WHERE workout_exercise_id = $1
+		) s
+		WHERE we.id = $1`
+	_, err := r.db.Exec(query, exerciseID)
+	return err
+}
+
+// CreateProgram сохраняет программу клиента с тренировками и упражнениями в одной транзакции.
+// templateID — шаблон-источник (0 если программа создана не из шаблона)
+func (r *ProgramRepository) CreateProgram(p *models.Program, templateID int) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	status := p.Status
+	if status == "" {
+		status = models.ProgramStatusActive
+	}
+
+	var programID int
+	err = tx.QueryRow(`
+		INSERT INTO public.training_programs
+			(client_id, name, goal, description, total_weeks, days_per_week, start_date, end_date, status, template_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id`,
+		p.ClientID, p.Name, p.Goal, p.Description, p.TotalWeeks, p.DaysPerWeek,
+		p.StartDate, p.EndDate, status,
+		sql.NullInt64{Int64: int64(templateID), Valid: templateID != 0},
+	).Scan(&programID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, w := range p.Workouts {
+		orderInWeek := w.OrderInWeek
+		if orderInWeek == 0 {
+			orderInWeek = w.DayNum
+		}
+
+		var workoutID int
+		err := tx.QueryRow(`
+			INSERT INTO public.program_workouts
+				(program_id, week_num, day_num, order_in_week, name, planned_date, status, notes)
+			VALUES ($1, $2, $3, $4, $5, $6, 'pending', $7)
+			RETURNING id`,
+			programID, w.WeekNum, w.DayNum, orderInWeek, w.Name, w.Date, w.Notes,
+		).Scan(&workoutID)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, ex := range w.Exercises {
+			_, err := tx.Exec(`
+				INSERT INTO public.workout_exercises
+					(workout_id, order_num, exercise_name, sets, reps, weight, weight_percent,
+					 rest_seconds, tempo, rpe, notes)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+				workoutID, ex.OrderNum, ex.ExerciseName, ex.Sets, ex.Reps,
+				sql.NullFloat64{Float64: ex.Weight, Valid: ex.Weight > 0},
+				sql.NullFloat64{Float64: ex.WeightPercent, Valid: ex.WeightPercent > 0},
+				ex.RestSeconds,
+				sql.NullString{String: ex.Tempo, Valid: ex.Tempo != ""},
+				sql.NullFloat64{Float64: ex.RPE, Valid: ex.RPE > 0},
+				sql.NullString{String: ex.Notes, Valid: ex.Notes != ""},
+			)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return programID, tx.Commit()
+}
+
+// PauseActivePrograms ставит на паузу активные программы клиента (перед назначением новой).
+// Невыполненные тренировки приостановленных программ в ленте календаря становятся отменёнными
+func (r *ProgramRepository) PauseActivePrograms(clientID int) (int64, error) {
+	var paused int64
+	err := r.db.QueryRow(`
+		WITH paused AS (
+			UPDATE public.training_programs SET status = 'paused', updated_at = NOW()
+			WHERE client_id = $1 AND status = 'active'
+			RETURNING id
+		), bumped AS (
+			UPDATE public.program_workouts SET ics_sequence = ics_sequence + 1
+			WHERE program_id IN (SELECT id FROM paused) AND status IN ('pending', 'sent')
+		)
+		SELECT COUNT(*) FROM paused`, clientID).Scan(&paused)
+	return paused, err
+}
+
 // GetClientPrograms возвращает все программы клиента
 func (r *ProgramRepository) GetClientPrograms(clientID int) ([]models.Program, error) {
 	query := `
@@ -690,13 +958,20 @@ func (r *ProgramRepository) GetWorkoutStats(workoutID int) (*WorkoutStats, error
 			COUNT(*) FILTER (WHERE completed = true) as completed,
 			COUNT(*) FILTER (WHERE completed = false AND actual_sets = 0) as skipped,
 			COALESCE(SUM(
-				CASE WHEN completed = true OR actual_sets > 0 THEN
+				CASE WHEN ws.tonnage IS NOT NULL THEN ws.tonnage
+				WHEN completed = true OR actual_sets > 0 THEN
 					COALESCE(actual_weight, weight, 0) *
 					COALESCE(NULLIF(actual_reps, 0), NULLIF(REGEXP_REPLACE(reps, '-.*', ''), '')::int, 0) *
 					COALESCE(NULLIF(actual_sets, 0), sets, 0)
 				ELSE 0 END
 			), 0) as tonnage
-		FROM public.workout_exercises
+		FROM public.workout_exercises we
+		LEFT JOIN (
+			-- Если подходы записаны по одному, тоннаж считаем по ним
+			SELECT workout_exercise_id, SUM(reps * weight) AS tonnage
+			FROM public.workout_sets
+			GROUP BY workout_exercise_id
+		) ws ON ws.workout_exercise_id = we.id
 		WHERE workout_id = $1`
 
 	err := r.db.QueryRow(query, workoutID).Scan(
@@ -715,3 +990,262 @@ func (r *ProgramRepository) GetWorkoutStats(workoutID int) (*WorkoutStats, error
 
 	return stats, nil
 }
+
+// WeeklyTonnage тоннаж выполненных тренировок за неделю
+type WeeklyTonnage struct {
+	WeekStart time.Time // понедельник недели
+	Tonnage   float64   // кг
+	Workouts  int       // выполненных тренировок
+}
+
+// GetWeeklyTonnage возвращает тоннаж клиента по неделям начиная с since.
+// Тоннаж считается так же, как в GetWorkoutStats: по записанным подходам, если они есть
+func (r *ProgramRepository) GetWeeklyTonnage(clientID int, since time.Time) ([]WeeklyTonnage, error) {
+	rows, err := r.db.Query(`
+		SELECT
+			date_trunc('week', pw.completed_at)::date AS week,
+			COALESCE(SUM(
+				CASE WHEN ws.tonnage IS NOT NULL THEN ws.tonnage
+				WHEN we.completed = true OR we.actual_sets > 0 THEN
+					COALESCE(we.actual_weight, we.weight, 0) *
+					COALESCE(NULLIF(we.actual_reps, 0), NULLIF(REGEXP_REPLACE(we.reps, '-.*', ''), '')::int, 0) *
+					COALESCE(NULLIF(we.actual_sets, 0), we.sets, 0)
+				ELSE 0 END
+			), 0) AS tonnage,
+			COUNT(DISTINCT pw.id) AS workouts
+		FROM public.program_workouts pw
+		JOIN public.training_programs tp ON tp.id = pw.program_id
+		JOIN public.workout_exercises we ON we.workout_id = pw.id
+		LEFT JOIN (
+			SELECT workout_exercise_id, SUM(reps * weight) AS tonnage
+			FROM public.workout_sets
+			GROUP BY workout_exercise_id
+		) ws ON ws.workout_exercise_id = we.id
+		WHERE tp.client_id = $1 AND pw.status = 'completed' AND pw.completed_at >= $2
+		GROUP BY week
+		ORDER BY week`, clientID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var weeks []WeeklyTonnage
+	for rows.Next() {
+		var w WeeklyTonnage
+		if err := rows.Scan(&w.WeekStart, &w.Tonnage, &w.Workouts); err != nil {
+			return nil, err
+		}
+		weeks = append(weeks, w)
+	}
+	return weeks, rows.Err()
+}
+
+// RPESet — выполненный подход с весом и RPE
+type RPESet struct {
+	ExerciseName string
+	Date         time.Time
+	Reps         int
+	Weight       float64
+	RPE          float64
+}
+
+// GetRPESets возвращает подходы клиента с весом и RPE начиная с since: записанные
+// по одному (workout_sets) и итоги упражнений без записанных подходов
+// (actual_weight и actual_rpe в workout_exercises). Порядок — по упражнению и дате
+func (r *ProgramRepository) GetRPESets(clientID int, since time.Time) ([]RPESet, error) {
+	rows, err := r.db.Query(`
+		SELECT we.exercise_name, ws.created_at, ws.reps, ws.weight, ws.rpe
+		FROM public.workout_sets ws
+		JOIN public.workout_exercises we ON we.id = ws.workout_exercise_id
+		JOIN public.program_workouts pw ON pw.id = we.workout_id
+		JOIN public.training_programs tp ON tp.id = pw.program_id
+		WHERE tp.client_id = $1 AND ws.created_at >= $2
+		  AND ws.weight > 0 AND ws.rpe IS NOT NULL
+		UNION ALL
+		SELECT we.exercise_name, pw.completed_at, we.actual_reps, we.actual_weight, we.actual_rpe
+		FROM public.workout_exercises we
+		JOIN public.program_workouts pw ON pw.id = we.workout_id
+		JOIN public.training_programs tp ON tp.id = pw.program_id
+		WHERE tp.client_id = $1 AND pw.completed_at >= $2
+		  AND we.actual_weight > 0 AND we.actual_reps > 0 AND we.actual_rpe IS NOT NULL
+		  AND NOT EXISTS (SELECT 1 FROM public.workout_sets ws WHERE ws.workout_exercise_id = we.id)
+		ORDER BY 1, 2`, clientID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sets []RPESet
+	for rows.Next() {
+		var s RPESet
+		if err := rows.Scan(&s.ExerciseName, &s.Date, &s.Reps, &s.Weight, &s.RPE); err != nil {
+			return nil, err
+		}
+		sets = append(sets, s)
+	}
+	return sets, rows.Err()
+}
+
+// LoggedSets — выполненные подходы упражнения тренировки; Sets > 1 — итог
+// упражнения без записанных по одному подходов
+type LoggedSets struct {
+	ExerciseName string
+	WeekNum      int
+	Date         time.Time
+	Sets         int
+	Reps         int
+	Weight       float64
+	RPE          float64 // 0 — не указан
+}
+
+// GetProgramSetLog возвращает подходы выполненных тренировок программы: записанные
+// по одному (workout_sets) и итоги упражнений без них (actual_* в workout_exercises).
+// Порядок — по упражнению и дате
+func (r *ProgramRepository) GetProgramSetLog(programID int) ([]LoggedSets, error) {
+	rows, err := r.db.Query(`
+		SELECT we.exercise_name, pw.week_num, ws.created_at, 1, ws.reps, ws.weight, COALESCE(ws.rpe, 0)
+		FROM public.workout_sets ws
+		JOIN public.workout_exercises we ON we.id = ws.workout_exercise_id
+		JOIN public.program_workouts pw ON pw.id = we.workout_id
+		WHERE pw.program_id = $1 AND pw.status = 'completed'
+		UNION ALL
+		SELECT we.exercise_name, pw.week_num, pw.completed_at, we.actual_sets,
+		       COALESCE(we.actual_reps, 0), COALESCE(we.actual_weight, 0), COALESCE(we.actual_rpe, 0)
+		FROM public.workout_exercises we
+		JOIN public.program_workouts pw ON pw.id = we.workout_id
+		WHERE pw.program_id = $1 AND pw.status = 'completed' AND we.actual_sets > 0
+		  AND NOT EXISTS (SELECT 1 FROM public.workout_sets ws WHERE ws.workout_exercise_id = we.id)
+		ORDER BY 1, 3`, programID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var log []LoggedSets
+	for rows.Next() {
+		var s LoggedSets
+		if err := rows.Scan(&s.ExerciseName, &s.WeekNum, &s.Date, &s.Sets, &s.Reps, &s.Weight, &s.RPE); err != nil {
+			return nil, err
+		}
+		log = append(log, s)
+	}
+	return log, rows.Err()
+}
+
+// CountOpenWorkouts возвращает число невыполненных тренировок программы (ожидают или отправлены)
+func (r *ProgramRepository) CountOpenWorkouts(programID int) (int, error) {
+	var n int
+	err := r.db.QueryRow(`
+		SELECT COUNT(*) FROM public.program_workouts
+		WHERE program_id = $1 AND status IN ('pending', 'sent')`, programID).Scan(&n)
+	return n, err
+}
+
+// NextUnstartedWeek возвращает первую неделю программы, в которой ни одна тренировка
+// ещё не отправлена и не выполнена; 0 — такой недели нет
+func (r *ProgramRepository) NextUnstartedWeek(programID int) (int, error) {
+	var week sql.NullInt64
+	err := r.db.QueryRow(`
+		SELECT week_num FROM public.program_workouts
+		WHERE program_id = $1
+		GROUP BY week_num
+		HAVING BOOL_AND(status = 'pending')
+		ORDER BY week_num
+		LIMIT 1`, programID).Scan(&week)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return int(week.Int64), err
+}
+
+// Параметры разгрузочного микроцикла: доля подходов и веса от плана недели
+const (
+	DeloadSetsRatio   = 0.6
+	DeloadWeightRatio = 0.9
+	DeloadMaxRPE      = 6.0
+)
+
+// InsertDeloadWeek вставляет разгрузочную неделю перед неделей weekNum: её тренировки
+// копируются с 60% подходов, 90% веса и RPE не выше 6, а эта и следующие недели
+// сдвигаются на неделю позже (вместе с плановыми датами). Неделя weekNum не должна
+// быть начата. Возвращает число вставленных тренировок
+func (r *ProgramRepository) InsertDeloadWeek(programID, weekNum int, namePrefix string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var started bool
+	if err := tx.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM public.program_workouts
+		              WHERE program_id = $1 AND week_num = $2 AND status <> 'pending')`,
+		programID, weekNum).Scan(&started); err != nil {
+		return 0, err
+	}
+	if started {
+		return 0, fmt.Errorf("неделя %d программы %d уже начата", weekNum, programID)
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE public.program_workouts
+		SET week_num = week_num + 1, planned_date = planned_date + 7, ics_sequence = ics_sequence + 1
+		WHERE program_id = $1 AND week_num >= $2`, programID, weekNum); err != nil {
+		return 0, err
+	}
+
+	// Тренировки сдвинутой недели — образец для разгрузки
+	rows, err := tx.Query(`
+		SELECT id FROM public.program_workouts
+		WHERE program_id = $1 AND week_num = $2
+		ORDER BY order_in_week, id`, programID, weekNum+1)
+	if err != nil {
+		return 0, err
+	}
+	var sources []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		sources = append(sources, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, src := range sources {
+		var id int
+		if err := tx.QueryRow(`
+			INSERT INTO public.program_workouts
+				(program_id, week_num, day_num, order_in_week, name, planned_date, status, notes)
+			SELECT program_id, $2, day_num, order_in_week, $3 || name, planned_date - 7, 'pending', notes
+			FROM public.program_workouts WHERE id = $1
+			RETURNING id`, src, weekNum, namePrefix).Scan(&id); err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO public.workout_exercises
+				(workout_id, order_num, exercise_name, sets, reps, weight, weight_percent,
+				 rest_seconds, tempo, rpe, notes)
+			SELECT $1, order_num, exercise_name, GREATEST(1, ROUND(sets * $3::numeric)), reps,
+			       ROUND(weight * $4::numeric * 2) / 2, ROUND(weight_percent * $4::numeric, 1),
+			       rest_seconds, tempo, CASE WHEN rpe IS NOT NULL THEN LEAST(rpe, $5) END, notes
+			FROM public.workout_exercises
+			WHERE workout_id = $2
+			ORDER BY order_num`,
+			id, src, DeloadSetsRatio, DeloadWeightRatio, DeloadMaxRPE); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE public.training_programs
+		SET total_weeks = total_weeks + 1, end_date = end_date + 7, updated_at = NOW()
+		WHERE id = $1`, programID); err != nil {
+		return 0, err
+	}
+	return len(sources), tx.Commit()
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordDriver — database/sql драйвер, который запоминает запросы и аргументы
+// и ничего не выполняет. Запросы без строк возвращают пустой результат
+type recordDriver struct {
+	mu    sync.Mutex
+	calls []recordedCall
+}
+
+type recordedCall struct {
+	query string
+	args  []driver.Value
+}
+
+var recorder = &recordDriver{}
+
+func init() {
+	sql.Register("record", recorder)
+}
+
+func (d *recordDriver) Open(string) (driver.Conn, error) { return recordConn{d}, nil }
+
+func (d *recordDriver) last() recordedCall {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.calls[len(d.calls)-1]
+}
+
+type recordConn struct{ d *recordDriver }
+
+func (c recordConn) Prepare(query string) (driver.Stmt, error) { return recordStmt{c.d, query}, nil }
+func (c recordConn) Close() error                              { return nil }
+func (c recordConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("транзакции не поддерживаются")
+}
+
+type recordStmt struct {
+	d     *recordDriver
+	query string
+}
+
+func (s recordStmt) Close() error  { return nil }
+func (s recordStmt) NumInput() int { return -1 }
+
+func (s recordStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.record(args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s recordStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.record(args)
+	return emptyRows{}, nil
+}
+
+func (s recordStmt) record(args []driver.Value) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.calls = append(s.d.calls, recordedCall{query: s.query, args: args})
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string         { return nil }
+func (emptyRows) Close() error              { return nil }
+func (emptyRows) Next([]driver.Value) error { return io.EOF }
+
+// untypedFloatParams возвращает дробные параметры, которые стоят рядом с целым
+// литералом без приведения типа (NULLIF($n, 0), $n > 0). Postgres выводит для них
+// integer, и дробное значение не проходит
+func untypedFloatParams(call recordedCall) []string {
+	var bad []string
+	for i, arg := range call.args {
+		if _, ok := arg.(float64); !ok {
+			continue
+		}
+		param := fmt.Sprintf("$%d", i+1)
+		for pos := 0; ; {
+			idx := strings.Index(call.query[pos:], param)
+			if idx < 0 {
+				break
+			}
+			idx += pos
+			pos = idx + len(param)
+			rest := call.query[pos:]
+			if rest != "" && rest[0] >= '0' && rest[0] <= '9' {
+				continue // $10 при поиске $1
+			}
+			if strings.HasPrefix(rest, "::") {
+				continue
+			}
+			if strings.HasSuffix(call.query[:idx], "NULLIF(") ||
+				strings.HasPrefix(rest, " >") || strings.HasPrefix(rest, " <") {
+				bad = append(bad, param)
+			}
+		}
+	}
+	return bad
+}
+
+func TestProgramRepositoryFloatParams(t *testing.T) {
+	db, err := sql.Open("record", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	r := NewProgramRepository(db)
+
+	tests := []struct {
+		name string
+		run  func()
+	}{
+		{"AdjustExerciseLoad", func() { r.AdjustExerciseLoad(1, 3, 82.5, 72.5) }},
+		{"SubstituteExercise", func() { r.SubstituteExercise(1, "Фронтальный присед", 127.5, "busy") }},
+		{"ApplySubstitutionToProgram", func() {
+			r.ApplySubstitutionToProgram(1, "Присед", "Фронтальный присед", "busy", 0.75)
+		}},
+		{"AddWorkoutSet", func() { r.AddWorkoutSet(1, 5, 102.5, 8.5) }},
+	}
+	for _, tt := range tests {
+		tt.run()
+		call := recorder.last()
+		if bad := untypedFloatParams(call); len(bad) > 0 {
+			t.Errorf("%s: дробные параметры %v без ::numeric в запросе:\n%s", tt.name, bad, call.query)
+		}
+	}
+}
+
+func TestUntypedFloatParams(t *testing.T) {
+	call := recordedCall{
+		query: "SET a = NULLIF($1, 0), b = NULLIF($2::numeric, 0), c = $3, d = CASE WHEN $10 > 0 THEN 1 END",
+		args:  []driver.Value{1.5, 1.5, 1.5, int64(1), nil, nil, nil, nil, nil, 0.5},
+	}
+	got := untypedFloatParams(call)
+	if len(got) != 2 || got[0] != "$1" || got[1] != "$10" {
+		t.Errorf("untypedFloatParams = %v, want [$1 $10]", got)
+	}
+}
+
+func TestApplySubstitutionToProgramKeepsOriginal(t *testing.T) {
+	db, err := sql.Open("record", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	NewProgramRepository(db).ApplySubstitutionToProgram(1, "Присед", "Фронтальный присед", "busy", 0.75)
+	call := recorder.last()
+	for _, col := range []string{"original_exercise_name = COALESCE", "original_weight = COALESCE", "substitution_reason = $5"} {
+		if !strings.Contains(call.query, col) {
+			t.Errorf("запрос не содержит %q:\n%s", col, call.query)
+		}
+	}
+	if call.args[4] != "busy" {
+		t.Errorf("причина = %v, want busy", call.args[4])
+	}
+}
+
+func TestUpdateWorkoutSetRPEScopedByExercise(t *testing.T) {
+	db, err := sql.Open("record", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	NewProgramRepository(db).UpdateWorkoutSetRPE(7, 42, 8.5)
+	call := recorder.last()
+	if !strings.Contains(call.query, "id = $2 AND workout_exercise_id = $3") {
+		t.Errorf("подход обновляется без привязки к упражнению:\n%s", call.query)
+	}
+	if call.args[1] != int64(42) || call.args[2] != int64(7) {
+		t.Errorf("args = %v, want set 42, exercise 7", call.args)
+	}
+}
@@ -11,6 +11,9 @@ type Repository struct {
 	Appointment *AppointmentRepository
 	Schedule    *ScheduleRepository
 	Program     *ProgramRepository
+	Group       *GroupRepository
+	Template    *TemplateRepository
+	Feed        *CalendarFeedRepository
 }
 
 // New создаёт новый экземпляр Repository
@@ -23,5 +26,8 @@ func New(db *sql.DB) *Repository {
 		Appointment: NewAppointmentRepository(db),
 		Schedule:    NewScheduleRepository(db),
 		Program:     NewProgramRepository(db),
+		Group:       NewGroupRepository(db),
+		Template:    NewTemplateRepository(db),
+		Feed:        NewCalendarFeedRepository(db),
 	}
 }
@@ -116,3 +116,58 @@ func (r *ScheduleRepository) GetByID(id int) (*Schedule, error) {
 	}
 	return s, nil
 }
+
+// BusyBlock — занятое время тренера из Google Calendar
+type BusyBlock struct {
+	GoogleEventID string
+	Summary       string
+	Start         time.Time
+	End           time.Time
+}
+
+// ReplaceBusyBlocks заменяет занятость тренера, начиная с from, прочитанной из календаря
+func (r *ScheduleRepository) ReplaceBusyBlocks(trainerID int64, from time.Time, blocks []BusyBlock) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		DELETE FROM public.trainer_busy_blocks
+		WHERE trainer_id = $1 AND ends_at > $2`, trainerID, from); err != nil {
+		return err
+	}
+	for _, bb := range blocks {
+		if _, err := tx.Exec(`
+			INSERT INTO public.trainer_busy_blocks (trainer_id, google_event_id, summary, starts_at, ends_at)
+			VALUES ($1, $2, $3, $4, $5)`,
+			trainerID, bb.GoogleEventID, bb.Summary, bb.Start, bb.End); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// BusyBlocks возвращает занятость тренера, пересекающую [from, to)
+func (r *ScheduleRepository) BusyBlocks(trainerID int64, from, to time.Time) ([]BusyBlock, error) {
+	rows, err := r.db.Query(`
+		SELECT google_event_id, summary, starts_at, ends_at
+		FROM public.trainer_busy_blocks
+		WHERE trainer_id = $1 AND starts_at < $3 AND ends_at > $2
+		ORDER BY starts_at`, trainerID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blocks []BusyBlock
+	for rows.Next() {
+		var bb BusyBlock
+		if err := rows.Scan(&bb.GoogleEventID, &bb.Summary, &bb.Start, &bb.End); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, bb)
+	}
+	return blocks, rows.Err()
+}
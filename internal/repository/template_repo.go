@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"database/sql"
+
+	"workbot/internal/models"
+)
+
+// TemplateRepository работает с библиотекой шаблонов программ
+type TemplateRepository struct {
+	db *sql.DB
+}
+
+// NewTemplateRepository создаёт репозиторий шаблонов
+func NewTemplateRepository(db *sql.DB) *TemplateRepository {
+	return &TemplateRepository{db: db}
+}
+
+// Create сохраняет шаблон вместе с упражнениями
+func (r *TemplateRepository) Create(t *models.ProgramTemplate) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int
+	err = tx.QueryRow(`
+		INSERT INTO public.program_templates
+			(trainer_id, name, description, goal, total_weeks, days_per_week, is_shared, cloned_from)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`,
+		sql.NullInt64{Int64: t.TrainerID, Valid: t.TrainerID != 0},
+		t.Name, t.Description, t.Goal, t.TotalWeeks, t.DaysPerWeek, t.IsShared,
+		sql.NullInt64{Int64: int64(t.ClonedFrom), Valid: t.ClonedFrom != 0},
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, ex := range t.Exercises {
+		_, err := tx.Exec(`
+			INSERT INTO public.program_template_exercises
+				(template_id, week_num, day_num, day_name, order_num, exercise_name,
+				 sets, reps, load, rest_seconds, tempo, notes)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+			id, ex.WeekNum, ex.DayNum, ex.DayName, ex.OrderNum, ex.ExerciseName,
+			ex.Sets, ex.Reps, ex.Load, ex.RestSeconds, ex.Tempo, ex.Notes,
+		)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return id, tx.Commit()
+}
+
+// GetByID возвращает шаблон с упражнениями
+func (r *TemplateRepository) GetByID(id int) (*models.ProgramTemplate, error) {
+	t := &models.ProgramTemplate{}
+	var trainerID, clonedFrom sql.NullInt64
+	err := r.db.QueryRow(`
+		SELECT t.id, t.trainer_id, COALESCE(a.name, ''), t.name, COALESCE(t.description, ''),
+		       COALESCE(t.goal, ''), t.total_weeks, t.days_per_week, t.is_shared, t.cloned_from,
+		       t.created_at, t.updated_at
+		FROM public.program_templates t
+		LEFT JOIN public.admins a ON a.telegram_id = t.trainer_id
+		WHERE t.id = $1`, id).Scan(
+		&t.ID, &trainerID, &t.OwnerName, &t.Name, &t.Description,
+		&t.Goal, &t.TotalWeeks, &t.DaysPerWeek, &t.IsShared, &clonedFrom,
+		&t.CreatedAt, &t.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	t.TrainerID = trainerID.Int64
+	t.ClonedFrom = int(clonedFrom.Int64)
+
+	rows, err := r.db.Query(`
+		SELECT id, week_num, day_num, COALESCE(day_name, ''), order_num, exercise_name,
+		       sets, reps, COALESCE(load, ''), COALESCE(rest_seconds, 0),
+		       COALESCE(tempo, ''), COALESCE(notes, '')
+		FROM public.program_template_exercises
+		WHERE template_id = $1
+		ORDER BY week_num, day_num, order_num, id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ex models.ProgramTemplateExercise
+		if err := rows.Scan(&ex.ID, &ex.WeekNum, &ex.DayNum, &ex.DayName, &ex.OrderNum, &ex.ExerciseName,
+			&ex.Sets, &ex.Reps, &ex.Load, &ex.RestSeconds, &ex.Tempo, &ex.Notes); err != nil {
+			continue
+		}
+		t.Exercises = append(t.Exercises, ex)
+	}
+	t.ExerciseCount = len(t.Exercises)
+	return t, rows.Err()
+}
+
+// ListAvailable возвращает шаблоны, доступные тренеру: встроенные, свои и общие от других тренеров
+func (r *TemplateRepository) ListAvailable(trainerID int64) ([]models.ProgramTemplate, error) {
+	rows, err := r.db.Query(`
+		SELECT t.id, t.trainer_id, COALESCE(a.name, ''), t.name, COALESCE(t.goal, ''),
+		       t.total_weeks, t.days_per_week, t.is_shared,
+		       (SELECT COUNT(*) FROM public.program_template_exercises e WHERE e.template_id = t.id)
+		FROM public.program_templates t
+		LEFT JOIN public.admins a ON a.telegram_id = t.trainer_id
+		WHERE t.trainer_id IS NULL OR t.trainer_id = $1 OR t.is_shared = TRUE
+		ORDER BY (t.trainer_id = $1) DESC NULLS LAST, t.trainer_id IS NULL DESC, t.name`, trainerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []models.ProgramTemplate
+	for rows.Next() {
+		var t models.ProgramTemplate
+		var owner sql.NullInt64
+		if err := rows.Scan(&t.ID, &owner, &t.OwnerName, &t.Name, &t.Goal,
+			&t.TotalWeeks, &t.DaysPerWeek, &t.IsShared, &t.ExerciseCount); err != nil {
+			continue
+		}
+		t.TrainerID = owner.Int64
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+// Clone копирует шаблон в библиотеку тренера
+func (r *TemplateRepository) Clone(id int, trainerID int64, name string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var newID int
+	err = tx.QueryRow(`
+		INSERT INTO public.program_templates
+			(trainer_id, name, description, goal, total_weeks, days_per_week, is_shared, cloned_from)
+		SELECT $2, $3, description, goal, total_weeks, days_per_week, FALSE, id
+		FROM public.program_templates WHERE id = $1
+		RETURNING id`, id, trainerID, name).Scan(&newID)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO public.program_template_exercises
+			(template_id, week_num, day_num, day_name, order_num, exercise_name,
+			 sets, reps, load, rest_seconds, tempo, notes)
+		SELECT $2, week_num, day_num, day_name, order_num, exercise_name,
+		       sets, reps, load, rest_seconds, tempo, notes
+		FROM public.program_template_exercises WHERE template_id = $1`, id, newID)
+	if err != nil {
+		return 0, err
+	}
+
+	return newID, tx.Commit()
+}
+
+// SetShared открывает или закрывает доступ к шаблону для других тренеров
+func (r *TemplateRepository) SetShared(id int, trainerID int64, shared bool) error {
+	_, err := r.db.Exec(`
+		UPDATE public.program_templates SET is_shared = $1, updated_at = NOW()
+		WHERE id = $2 AND trainer_id = $3`, shared, id, trainerID)
+	return err
+}
+
+// Delete удаляет шаблон тренера (встроенные шаблоны не удаляются)
+func (r *TemplateRepository) Delete(id int, trainerID int64) error {
+	_, err := r.db.Exec(
+		"DELETE FROM public.program_templates WHERE id = $1 AND trainer_id = $2",
+		id, trainerID,
+	)
+	return err
+}
@@ -0,0 +1,391 @@
+// Package scheduler запускает фоновые задачи бота по cron-расписанию.
+//
+// Состояние задач хранится в таблице scheduled_jobs: время следующего запуска,
+// результат последнего, число неудачных попыток. Несколько экземпляров бота
+// могут работать с одной базой — задачу захватывает тот, кто первым выполнит
+// SELECT ... FOR UPDATE SKIP LOCKED, и держит аренду до locked_until.
+// Если бот был выключен, просроченная задача выполняется один раз при старте.
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/robfig/cron"
+)
+
+const (
+	defaultPollInterval = 15 * time.Second
+	defaultTimeout      = 10 * time.Minute
+	defaultMaxAttempts  = 3
+	baseBackoff         = time.Minute
+	maxBackoff          = time.Hour
+)
+
+// Run описывает текущий запуск задачи
+type Run struct {
+	ScheduledAt time.Time // на когда был запланирован запуск (может быть в прошлом после простоя)
+	LastSuccess time.Time // время последнего успешного запуска; нулевое, если их не было
+	Attempt     int       // номер попытки, начиная с 1
+}
+
+// Handler выполняет задачу. Ошибка приводит к повтору с экспоненциальной задержкой
+type Handler func(ctx context.Context, run Run) error
+
+// Job описывает фоновую задачу
+type Job struct {
+	Name        string
+	Description string
+	Schedule    string        // cron (5 полей) или дескриптор: @hourly, @every 30s
+	Timeout     time.Duration // время аренды и таймаут контекста; 0 — 10 минут
+	MaxAttempts int           // попыток до перехода к следующему плановому запуску; 0 — 3
+	Handler     Handler
+}
+
+// JobStatus — состояние задачи для просмотра тренером
+type JobStatus struct {
+	Name           string
+	Description    string
+	Schedule       string
+	NextRunAt      time.Time
+	LastRunAt      sql.NullTime
+	LastSuccessAt  sql.NullTime
+	LastError      string
+	LastDurationMs int
+	Attempts       int
+	RunCount       int
+	FailCount      int
+	Running        bool
+}
+
+type registeredJob struct {
+	Job
+	schedule cron.Schedule
+}
+
+// Scheduler управляет фоновыми задачами
+type Scheduler struct {
+	db           *sql.DB
+	instance     string
+	loc          *time.Location
+	pollInterval time.Duration
+
+	mu   sync.RWMutex
+	jobs map[string]*registeredJob
+	wake chan struct{}
+}
+
+// New создаёт планировщик. Cron-расписания вычисляются в поясе loc
+func New(db *sql.DB, loc *time.Location) *Scheduler {
+	if loc == nil {
+		loc = time.Local
+	}
+	host, _ := os.Hostname()
+	return &Scheduler{
+		db:           db,
+		instance:     fmt.Sprintf("%s-%d", host, os.Getpid()),
+		loc:          loc,
+		pollInterval: defaultPollInterval,
+		jobs:         make(map[string]*registeredJob),
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Register добавляет задачу. Вызывается до Start
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" || job.Handler == nil {
+		return fmt.Errorf("задача должна иметь имя и обработчик")
+	}
+	schedule, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("задача %s: %w", job.Name, err)
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = defaultTimeout
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = defaultMaxAttempts
+	}
+
+	s.mu.Lock()
+	s.jobs[job.Name] = &registeredJob{Job: job, schedule: schedule}
+	s.mu.Unlock()
+	return nil
+}
+
+// ParseSchedule разбирает cron-выражение из 5 полей или дескриптор
+func ParseSchedule(spec string) (cron.Schedule, error) {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("некорректное расписание %q: %w", spec, err)
+	}
+	return schedule, nil
+}
+
+// Start сохраняет задачи в БД и запускает цикл опроса до отмены ctx
+func (s *Scheduler) Start(ctx context.Context) error {
+	if err := s.sync(time.Now()); err != nil {
+		return err
+	}
+	go s.loop(ctx)
+	log.Printf("Планировщик запущен (%d задач, экземпляр %s)", len(s.jobNames()), s.instance)
+	return nil
+}
+
+// sync добавляет новые задачи в таблицу и обновляет расписание изменённых.
+// Новая задача запускается сразу; при смене расписания следующий запуск пересчитывается
+func (s *Scheduler) sync(now time.Time) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, job := range s.jobs {
+		_, err := s.db.Exec(`
+			INSERT INTO public.scheduled_jobs (name, description, schedule, next_run_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (name) DO UPDATE SET
+				description = EXCLUDED.description,
+				schedule = EXCLUDED.schedule,
+				next_run_at = CASE
+					WHEN scheduled_jobs.schedule <> EXCLUDED.schedule THEN $5
+					ELSE scheduled_jobs.next_run_at
+				END,
+				updated_at = NOW()`,
+			job.Name, job.Description, job.Schedule, now, job.schedule.Next(now.In(s.loc)))
+		if err != nil {
+			return fmt.Errorf("ошибка регистрации задачи %s: %w", job.Name, err)
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) loop(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.runDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// runDue захватывает и запускает все просроченные задачи
+func (s *Scheduler) runDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, run, err := s.claim(time.Now())
+		if err != nil {
+			log.Printf("Планировщик: ошибка захвата задачи: %v", err)
+			return
+		}
+		if job == nil {
+			return
+		}
+		go s.execute(ctx, job, run)
+	}
+}
+
+// claim захватывает одну просроченную задачу, которую не выполняет другой экземпляр
+func (s *Scheduler) claim(now time.Time) (*registeredJob, Run, error) {
+	names := s.jobNames()
+	if len(names) == 0 {
+		return nil, Run{}, nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, Run{}, err
+	}
+	defer tx.Rollback()
+
+	var (
+		name        string
+		run         Run
+		lastSuccess sql.NullTime
+	)
+	err = tx.QueryRow(`
+		SELECT name, next_run_at, last_success_at, attempts
+		FROM public.scheduled_jobs
+		WHERE name = ANY($1)
+		  AND next_run_at <= $2
+		  AND (locked_until IS NULL OR locked_until < $2)
+		ORDER BY next_run_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`, pq.Array(names), now).
+		Scan(&name, &run.ScheduledAt, &lastSuccess, &run.Attempt)
+	if err == sql.ErrNoRows {
+		return nil, Run{}, nil
+	}
+	if err != nil {
+		return nil, Run{}, err
+	}
+	run.Attempt++
+	if lastSuccess.Valid {
+		run.LastSuccess = lastSuccess.Time
+	}
+
+	s.mu.RLock()
+	job := s.jobs[name]
+	s.mu.RUnlock()
+
+	_, err = tx.Exec(`
+		UPDATE public.scheduled_jobs
+		SET locked_by = $2, locked_until = $3, last_run_at = $4, updated_at = NOW()
+		WHERE name = $1`, name, s.instance, now.Add(job.Timeout), now)
+	if err != nil {
+		return nil, Run{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, Run{}, err
+	}
+	return job, run, nil
+}
+
+// execute выполняет задачу и записывает результат
+func (s *Scheduler) execute(ctx context.Context, job *registeredJob, run Run) {
+	jobCtx, cancel := context.WithTimeout(ctx, job.Timeout)
+	defer cancel()
+
+	started := time.Now()
+	err := safeCall(jobCtx, job.Handler, run)
+	finished := time.Now()
+	duration := finished.Sub(started).Milliseconds()
+
+	if err == nil {
+		_, dbErr := s.db.Exec(`
+			UPDATE public.scheduled_jobs
+			SET next_run_at = $2, last_success_at = $3, last_error = NULL, last_duration_ms = $4,
+			    attempts = 0, run_count = run_count + 1, locked_by = NULL, locked_until = NULL,
+			    updated_at = NOW()
+			WHERE name = $1`,
+			job.Name, job.schedule.Next(finished.In(s.loc)), finished, duration)
+		if dbErr != nil {
+			log.Printf("Планировщик: ошибка сохранения результата %s: %v", job.Name, dbErr)
+		}
+		return
+	}
+
+	next, attempts := nextAfterFailure(job.schedule, finished.In(s.loc), run.Attempt, job.MaxAttempts)
+	log.Printf("Планировщик: задача %s завершилась ошибкой (попытка %d/%d): %v",
+		job.Name, run.Attempt, job.MaxAttempts, err)
+
+	_, dbErr := s.db.Exec(`
+		UPDATE public.scheduled_jobs
+		SET next_run_at = $2, last_error = $3, last_duration_ms = $4, attempts = $5,
+		    run_count = run_count + 1, fail_count = fail_count + 1,
+		    locked_by = NULL, locked_until = NULL, updated_at = NOW()
+		WHERE name = $1`,
+		job.Name, next, err.Error(), duration, attempts)
+	if dbErr != nil {
+		log.Printf("Планировщик: ошибка сохранения результата %s: %v", job.Name, dbErr)
+	}
+}
+
+// safeCall вызывает обработчик, превращая панику в ошибку
+func safeCall(ctx context.Context, handler Handler, run Run) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("паника: %v", r)
+		}
+	}()
+	return handler(ctx, run)
+}
+
+// nextAfterFailure возвращает время следующей попытки и новое число неудач подряд.
+// Пока попытки не исчерпаны — повтор с экспоненциальной задержкой (но не позже
+// планового запуска), затем счётчик сбрасывается и задача ждёт расписания
+func nextAfterFailure(schedule cron.Schedule, now time.Time, attempt, maxAttempts int) (time.Time, int) {
+	planned := schedule.Next(now)
+	if attempt >= maxAttempts {
+		return planned, 0
+	}
+	retry := now.Add(Backoff(attempt))
+	if retry.After(planned) {
+		return planned, attempt
+	}
+	return retry, attempt
+}
+
+// Backoff возвращает задержку перед повтором после attempt неудачных попыток: 1, 2, 4 … 60 минут
+func Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := baseBackoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return d
+}
+
+// TriggerNow планирует немедленный запуск задачи
+func (s *Scheduler) TriggerNow(name string) error {
+	res, err := s.db.Exec(`
+		UPDATE public.scheduled_jobs
+		SET next_run_at = NOW(), attempts = 0, updated_at = NOW()
+		WHERE name = $1`, name)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("задача %s не найдена", name)
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Status возвращает состояние зарегистрированных задач
+func (s *Scheduler) Status() ([]JobStatus, error) {
+	rows, err := s.db.Query(`
+		SELECT name, description, schedule, next_run_at, last_run_at, last_success_at,
+		       COALESCE(last_error, ''), COALESCE(last_duration_ms, 0), attempts, run_count, fail_count,
+		       locked_until IS NOT NULL AND locked_until > NOW()
+		FROM public.scheduled_jobs
+		WHERE name = ANY($1)
+		ORDER BY name`, pq.Array(s.jobNames()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statuses []JobStatus
+	for rows.Next() {
+		var st JobStatus
+		if err := rows.Scan(&st.Name, &st.Description, &st.Schedule, &st.NextRunAt, &st.LastRunAt,
+			&st.LastSuccessAt, &st.LastError, &st.LastDurationMs, &st.Attempts, &st.RunCount,
+			&st.FailCount, &st.Running); err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses, rows.Err()
+}
+
+func (s *Scheduler) jobNames() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
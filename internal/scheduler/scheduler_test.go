@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, time.Minute},
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+		{7, time.Hour},
+		{50, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := Backoff(tt.attempt); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestNextAfterFailure(t *testing.T) {
+	daily, err := ParseSchedule("0 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2030, 5, 10, 9, 0, 30, 0, time.UTC)
+
+	next, attempts := nextAfterFailure(daily, now, 1, 3)
+	if attempts != 1 || !next.Equal(now.Add(time.Minute)) {
+		t.Errorf("first failure: got %v, %d; want retry in 1m", next, attempts)
+	}
+
+	next, attempts = nextAfterFailure(daily, now, 3, 3)
+	if attempts != 0 || !next.Equal(time.Date(2030, 5, 11, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("attempts exhausted: got %v, %d; want next day 09:00 and reset", next, attempts)
+	}
+
+	// Повтор не откладывается дальше планового запуска
+	frequent, _ := ParseSchedule("*/10 * * * *")
+	next, _ = nextAfterFailure(frequent, time.Date(2030, 5, 10, 9, 9, 0, 0, time.UTC), 2, 5)
+	if !next.Equal(time.Date(2030, 5, 10, 9, 10, 0, 0, time.UTC)) {
+		t.Errorf("retry should be capped by the schedule, got %v", next)
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	for _, spec := range []string{"*/10 * * * *", "0 9 * * *", "@every 30s", "@hourly"} {
+		if _, err := ParseSchedule(spec); err != nil {
+			t.Errorf("ParseSchedule(%q): %v", spec, err)
+		}
+	}
+	for _, spec := range []string{"", "0 9 * *", "61 * * * *", "@sometimes"} {
+		if _, err := ParseSchedule(spec); err == nil {
+			t.Errorf("ParseSchedule(%q) should fail", spec)
+		}
+	}
+}
+
+func TestScheduleUsesLocation(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skip("нет данных о часовых поясах")
+	}
+	daily, _ := ParseSchedule("0 9 * * *")
+	// 07:00 UTC = 10:00 по Москве: следующий запуск — завтра в 09:00 МСК
+	next := daily.Next(time.Date(2030, 5, 10, 7, 0, 0, 0, time.UTC).In(moscow))
+	if !next.Equal(time.Date(2030, 5, 11, 6, 0, 0, 0, time.UTC)) {
+		t.Errorf("next = %v, want 2030-05-11 06:00 UTC", next.UTC())
+	}
+}
@@ -0,0 +1,164 @@
+package training
+
+import (
+	"math"
+	"time"
+)
+
+// SessionLoad — нагрузка тренировки по Фостеру: RPE всей тренировки × длительность
+// в минутах (условные единицы, AU)
+func SessionLoad(rpe, minutes int) float64 {
+	if rpe <= 0 || minutes <= 0 {
+		return 0
+	}
+	return float64(rpe * minutes)
+}
+
+// DailyLoad — нагрузка одной тренировки (или суммарная за день)
+type DailyLoad struct {
+	Date time.Time
+	Load float64
+}
+
+// ACWRZone — зона отношения острой нагрузки к хронической
+type ACWRZone string
+
+const (
+	ACWRUnknown ACWRZone = "unknown" // хроническая нагрузка ещё не сформирована
+	ACWRLow     ACWRZone = "low"     // нагрузка упала: риск растренированности
+	ACWRSafe    ACWRZone = "safe"    // «золотая середина» 0.8–1.3
+	ACWRHigh    ACWRZone = "high"    // резкий рост нагрузки
+	ACWRDanger  ACWRZone = "danger"  // скачок выше 1.5: высокий риск травмы
+)
+
+// Параметры модели нагрузки
+const (
+	// ACWR по экспоненциальным скользящим средним (Williams et al., 2017):
+	// острая нагрузка — 7 дней, хроническая — 28
+	ACWRAcuteDays   = 7
+	ACWRChronicDays = 28
+	// ACWRMinHistoryDays — с какого дня истории отношение считается надёжным
+	ACWRMinHistoryDays = 21
+
+	ACWRSafeLow  = 0.8
+	ACWRSafeHigh = 1.3
+	ACWRDangerAt = 1.5
+
+	// Модель «подготовленность — утомление» Банистера: подготовленность
+	// затухает за 42 дня, утомление — за 7, но весит вдвое больше
+	BanisterFitnessDays = 42.0
+	BanisterFatigueDays = 7.0
+	BanisterFitnessGain = 1.0
+	BanisterFatigueGain = 2.0
+)
+
+// LoadState — состояние клиента по журналу нагрузки на дату
+type LoadState struct {
+	Acute   float64 // острая нагрузка, AU в день (EWMA 7 дней)
+	Chronic float64 // хроническая нагрузка, AU в день (EWMA 28 дней)
+	ACWR    float64 // Acute / Chronic; 0 — хронической нагрузки нет
+	Zone    ACWRZone
+
+	Fitness     float64 // подготовленность по Банистеру
+	Fatigue     float64 // утомление по Банистеру
+	Performance float64 // готовность к результату: Fitness·k1 − Fatigue·k2
+
+	HistoryDays int // дней от первой тренировки в журнале до now
+}
+
+// LoadModel рассчитывает ACWR и модель Банистера по журналу нагрузок до даты now
+// включительно. Нагрузки одного дня (в часовом поясе now) суммируются, дни без
+// тренировок дают 0
+func LoadModel(loads []DailyLoad, now time.Time) LoadState {
+	today := dayStart(now)
+	byDay := make(map[time.Time]float64)
+	var first time.Time
+	for _, l := range loads {
+		day := dayStart(l.Date.In(now.Location()))
+		if day.After(today) {
+			continue
+		}
+		byDay[day] += l.Load
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+	}
+
+	state := LoadState{Zone: ACWRUnknown}
+	if first.IsZero() {
+		return state
+	}
+
+	acuteAlpha := 2.0 / (ACWRAcuteDays + 1)
+	chronicAlpha := 2.0 / (ACWRChronicDays + 1)
+	fitnessDecay := math.Exp(-1 / BanisterFitnessDays)
+	fatigueDecay := math.Exp(-1 / BanisterFatigueDays)
+
+	for day := first; !day.After(today); day = day.AddDate(0, 0, 1) {
+		load := byDay[day]
+		state.Acute = acuteAlpha*load + (1-acuteAlpha)*state.Acute
+		state.Chronic = chronicAlpha*load + (1-chronicAlpha)*state.Chronic
+		state.Fitness = state.Fitness*fitnessDecay + load
+		state.Fatigue = state.Fatigue*fatigueDecay + load
+		state.HistoryDays++
+	}
+	state.Performance = BanisterFitnessGain*state.Fitness - BanisterFatigueGain*state.Fatigue
+
+	if state.Chronic > 0 {
+		state.ACWR = math.Round(state.Acute/state.Chronic*100) / 100
+	}
+	state.Zone = acwrZone(state.ACWR, state.HistoryDays)
+	return state
+}
+
+// acwrZone определяет зону ACWR; короткая история — зона неизвестна
+func acwrZone(acwr float64, historyDays int) ACWRZone {
+	switch {
+	case historyDays < ACWRMinHistoryDays || acwr == 0:
+		return ACWRUnknown
+	case acwr < ACWRSafeLow:
+		return ACWRLow
+	case acwr <= ACWRSafeHigh:
+		return ACWRSafe
+	case acwr <= ACWRDangerAt:
+		return ACWRHigh
+	default:
+		return ACWRDanger
+	}
+}
+
+// DeloadReason — почему нужна разгрузка; пустая строка — не нужна
+type DeloadReason string
+
+const (
+	DeloadACWRDanger DeloadReason = "acwr_danger" // скачок нагрузки выше 1.5
+	DeloadACWRHigh   DeloadReason = "acwr_high"   // ACWR выше 1.3 и утомление перевешивает
+	DeloadFatigue    DeloadReason = "fatigue"     // утомление перевешивает подготовленность
+)
+
+// DeloadAdvice — рекомендация по разгрузочному микроциклу
+type DeloadAdvice struct {
+	Reason DeloadReason
+	Urgent bool // разгрузка с текущей недели, а не со следующей
+}
+
+// RecommendDeload советует разгрузку: сразу — при скачке ACWR выше 1.5,
+// со следующей недели — когда по модели Банистера утомление перевешивает
+// подготовленность (Performance < 0) при высоком или обычном ACWR
+func RecommendDeload(s LoadState) DeloadAdvice {
+	switch {
+	case s.Zone == ACWRDanger:
+		return DeloadAdvice{Reason: DeloadACWRDanger, Urgent: true}
+	case s.Zone == ACWRHigh && s.Performance < 0:
+		return DeloadAdvice{Reason: DeloadACWRHigh}
+	case s.Zone == ACWRSafe && s.Performance < 0:
+		return DeloadAdvice{Reason: DeloadFatigue}
+	}
+	return DeloadAdvice{}
+}
+
+// dayStart — начало календарного дня в часовом поясе даты
+func dayStart(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,142 @@
+package training
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionLoad(t *testing.T) {
+	if got := SessionLoad(7, 60); got != 420 {
+		t.Errorf("SessionLoad(7, 60) = %v, want 420", got)
+	}
+	if got := SessionLoad(0, 60); got != 0 {
+		t.Errorf("SessionLoad без RPE = %v, want 0", got)
+	}
+	if got := SessionLoad(8, -5); got != 0 {
+		t.Errorf("SessionLoad с отрицательной длительностью = %v, want 0", got)
+	}
+}
+
+// dailyLoads — нагрузки по дням, начиная с start: loads[i] приходится на start+i дней
+func dailyLoads(start time.Time, loads ...float64) []DailyLoad {
+	out := make([]DailyLoad, 0, len(loads))
+	for i, l := range loads {
+		if l > 0 {
+			out = append(out, DailyLoad{Date: start.AddDate(0, 0, i).Add(18 * time.Hour), Load: l})
+		}
+	}
+	return out
+}
+
+func repeatLoad(load float64, days int) []float64 {
+	out := make([]float64, days)
+	for i := range out {
+		out[i] = load
+	}
+	return out
+}
+
+func TestLoadModelZones(t *testing.T) {
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		loads []float64
+		want  ACWRZone
+	}{
+		{"короткая история", repeatLoad(300, 10), ACWRUnknown},
+		{"стабильная нагрузка", repeatLoad(300, 60), ACWRSafe},
+		{"скачок нагрузки", append(repeatLoad(200, 28), repeatLoad(600, 7)...), ACWRDanger},
+		{"перерыв в тренировках", append(repeatLoad(300, 40), repeatLoad(0, 10)...), ACWRLow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start.AddDate(0, 0, len(tt.loads)-1).Add(20 * time.Hour)
+			s := LoadModel(dailyLoads(start, tt.loads...), now)
+			if s.Zone != tt.want {
+				t.Errorf("Zone = %s (ACWR %.2f, %d дней), want %s", s.Zone, s.ACWR, s.HistoryDays, tt.want)
+			}
+			if s.HistoryDays != len(tt.loads) {
+				t.Errorf("HistoryDays = %d, want %d", s.HistoryDays, len(tt.loads))
+			}
+		})
+	}
+}
+
+func TestLoadModelSteadyState(t *testing.T) {
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	s := LoadModel(dailyLoads(start, repeatLoad(300, 60)...), start.AddDate(0, 0, 59))
+
+	if s.ACWR < 0.95 || s.ACWR > 1.05 {
+		t.Errorf("ACWR при стабильной нагрузке = %.2f, want ≈ 1", s.ACWR)
+	}
+	if s.Performance <= 0 {
+		t.Errorf("Performance при стабильной нагрузке = %.0f, want > 0", s.Performance)
+	}
+	if a := RecommendDeload(s); a.Reason != "" {
+		t.Errorf("RecommendDeload при стабильной нагрузке = %+v, want без разгрузки", a)
+	}
+}
+
+func TestLoadModelSumsDayAndSkipsFuture(t *testing.T) {
+	day := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	loads := []DailyLoad{
+		{Date: day.Add(9 * time.Hour), Load: 200},
+		{Date: day.Add(19 * time.Hour), Load: 100},
+		{Date: day.AddDate(0, 0, 1), Load: 500}, // после now
+	}
+	s := LoadModel(loads, day.Add(21*time.Hour))
+
+	if s.HistoryDays != 1 {
+		t.Fatalf("HistoryDays = %d, want 1", s.HistoryDays)
+	}
+	if s.Fitness != 300 || s.Fatigue != 300 {
+		t.Errorf("Fitness/Fatigue = %.0f/%.0f, want 300/300", s.Fitness, s.Fatigue)
+	}
+	if s.Performance != -300 {
+		t.Errorf("Performance = %.0f, want -300", s.Performance)
+	}
+
+	if empty := LoadModel(nil, day); empty.Zone != ACWRUnknown || empty.HistoryDays != 0 {
+		t.Errorf("LoadModel(nil) = %+v, want пустое состояние", empty)
+	}
+}
+
+func TestLoadModelSpikeNeedsUrgentDeload(t *testing.T) {
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	loads := append(repeatLoad(200, 28), repeatLoad(600, 7)...)
+	s := LoadModel(dailyLoads(start, loads...), start.AddDate(0, 0, len(loads)-1))
+
+	if s.ACWR <= ACWRDangerAt {
+		t.Fatalf("ACWR после скачка = %.2f, want > %.1f", s.ACWR, ACWRDangerAt)
+	}
+	if a := RecommendDeload(s); a.Reason != DeloadACWRDanger || !a.Urgent {
+		t.Errorf("RecommendDeload = %+v, want срочная разгрузка", a)
+	}
+}
+
+func TestRecommendDeload(t *testing.T) {
+	tests := []struct {
+		name   string
+		state  LoadState
+		want   DeloadReason
+		urgent bool
+	}{
+		{"опасная зона", LoadState{Zone: ACWRDanger, Performance: 500}, DeloadACWRDanger, true},
+		{"высокая зона, утомление перевешивает", LoadState{Zone: ACWRHigh, Performance: -10}, DeloadACWRHigh, false},
+		{"высокая зона, форма положительная", LoadState{Zone: ACWRHigh, Performance: 10}, "", false},
+		{"норма, утомление перевешивает", LoadState{Zone: ACWRSafe, Performance: -10}, DeloadFatigue, false},
+		{"низкая зона", LoadState{Zone: ACWRLow, Performance: -10}, "", false},
+		{"мало истории", LoadState{Zone: ACWRUnknown, Performance: -10}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := RecommendDeload(tt.state)
+			if a.Reason != tt.want || a.Urgent != tt.urgent {
+				t.Errorf("RecommendDeload = %+v, want {%s %v}", a, tt.want, tt.urgent)
+			}
+		})
+	}
+}
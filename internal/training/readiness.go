@@ -0,0 +1,124 @@
+package training
+
+import "math"
+
+// ReadinessInput — ответы анкеты готовности перед тренировкой.
+// Нулевое значение поля — клиент его не указал
+type ReadinessInput struct {
+	SleepHours float64 // сон прошлой ночью, часы
+	Soreness   int     // мышечная боль: 1 — нет, 5 — сильная
+	Stress     int     // стресс: 1 — спокойно, 5 — сильный
+	Mood       int     // настроение: 1 — плохое, 5 — отличное
+	RestingHR  int     // пульс покоя утром, уд/мин
+	BaselineHR float64 // обычный пульс покоя клиента (среднее прошлых анкет)
+}
+
+// ReadinessZone — зона готовности
+type ReadinessZone string
+
+const (
+	ReadinessHigh     ReadinessZone = "high"     // нагрузка по плану
+	ReadinessModerate ReadinessZone = "moderate" // слегка снизить интенсивность
+	ReadinessLow      ReadinessZone = "low"      // снизить интенсивность и объём
+)
+
+// Границы зон и веса ответов в оценке
+const (
+	readinessHighMin     = 70
+	readinessModerateMin = 45
+
+	sleepWeight    = 0.30
+	sorenessWeight = 0.25
+	stressWeight   = 0.20
+	moodWeight     = 0.25
+
+	// sleepMinHours и sleepFullHours — сон короче 4 часов даёт 0, от 8 часов — полный балл
+	sleepMinHours  = 4.0
+	sleepFullHours = 8.0
+)
+
+// ReadinessScore оценивает готовность от 0 до 100. Сон, боль, стресс и настроение
+// приводятся к шкале 0…1 и усредняются с весами; неуказанные ответы не учитываются.
+// Пульс покоя выше обычного на 5% снимает 7 баллов, на 10% — 15: это ранний
+// признак недовосстановления или начинающейся болезни
+func ReadinessScore(in ReadinessInput) int {
+	var sum, weights float64
+	add := func(value, weight float64) {
+		sum += value * weight
+		weights += weight
+	}
+
+	if in.SleepHours > 0 {
+		add(clamp01((in.SleepHours-sleepMinHours)/(sleepFullHours-sleepMinHours)), sleepWeight)
+	}
+	if in.Soreness > 0 {
+		add(clamp01(float64(5-in.Soreness)/4), sorenessWeight)
+	}
+	if in.Stress > 0 {
+		add(clamp01(float64(5-in.Stress)/4), stressWeight)
+	}
+	if in.Mood > 0 {
+		add(clamp01(float64(in.Mood-1)/4), moodWeight)
+	}
+	if weights == 0 {
+		return 100
+	}
+
+	score := int(math.Round(sum / weights * 100))
+	if in.RestingHR > 0 && in.BaselineHR > 0 {
+		switch elevation := (float64(in.RestingHR) - in.BaselineHR) / in.BaselineHR; {
+		case elevation >= 0.10:
+			score -= 15
+		case elevation >= 0.05:
+			score -= 7
+		}
+	}
+	if score < 0 {
+		score = 0
+	}
+	return score
+}
+
+// LoadAdjustment — корректировка тренировки по готовности
+type LoadAdjustment struct {
+	Zone            ReadinessZone
+	IntensityFactor float64 // множитель рабочего веса
+	SetsDelta       int     // изменение числа подходов
+}
+
+// AdjustmentFor подбирает корректировку: при высокой готовности — план без
+// изменений, при средней — −5% веса, при низкой — −10% веса и на подход меньше
+func AdjustmentFor(score int) LoadAdjustment {
+	switch {
+	case score >= readinessHighMin:
+		return LoadAdjustment{Zone: ReadinessHigh, IntensityFactor: 1}
+	case score >= readinessModerateMin:
+		return LoadAdjustment{Zone: ReadinessModerate, IntensityFactor: 0.95}
+	default:
+		return LoadAdjustment{Zone: ReadinessLow, IntensityFactor: 0.90, SetsDelta: -1}
+	}
+}
+
+// Changes сообщает, меняет ли корректировка тренировку
+func (a LoadAdjustment) Changes() bool {
+	return a.IntensityFactor != 1 || a.SetsDelta != 0
+}
+
+// Apply применяет корректировку к упражнению: вес округляется до 0.5 кг,
+// подходов остаётся не меньше двух (если в плане их было меньше — не меняются)
+func (a LoadAdjustment) Apply(weight float64, sets int) (float64, int) {
+	if weight > 0 && a.IntensityFactor > 0 {
+		weight = math.Round(weight*a.IntensityFactor*2) / 2
+	}
+	if sets > 2 {
+		sets += a.SetsDelta
+		if sets < 2 {
+			sets = 2
+		}
+	}
+	return weight, sets
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
@@ -0,0 +1,78 @@
+package training
+
+import "testing"
+
+func TestReadinessScore(t *testing.T) {
+	tests := []struct {
+		name string
+		in   ReadinessInput
+		want int
+	}{
+		{"all good", ReadinessInput{SleepHours: 8, Soreness: 1, Stress: 1, Mood: 5}, 100},
+		{"all bad", ReadinessInput{SleepHours: 4, Soreness: 5, Stress: 5, Mood: 1}, 0},
+		// sleep 6h → 0.5; soreness 3 → 0.5; stress 2 → 0.75; mood 4 → 0.75
+		// (0.15 + 0.125 + 0.15 + 0.1875) / 1 = 0.6125
+		{"average", ReadinessInput{SleepHours: 6, Soreness: 3, Stress: 2, Mood: 4}, 61},
+		// without sleep: (0.125 + 0.15 + 0.1875) / 0.7 = 0.66
+		{"sleep not given", ReadinessInput{Soreness: 3, Stress: 2, Mood: 4}, 66},
+		{"empty form", ReadinessInput{}, 100},
+		// resting HR 10% above baseline takes 15 points
+		{"resting HR +10%", ReadinessInput{SleepHours: 8, Soreness: 1, Stress: 1, Mood: 5, RestingHR: 66, BaselineHR: 60}, 85},
+		{"resting HR +5%", ReadinessInput{SleepHours: 8, Soreness: 1, Stress: 1, Mood: 5, RestingHR: 63, BaselineHR: 60}, 93},
+		{"HR without baseline is ignored", ReadinessInput{SleepHours: 8, Soreness: 1, Stress: 1, Mood: 5, RestingHR: 90}, 100},
+		{"more sleep than needed", ReadinessInput{SleepHours: 10, Soreness: 1, Stress: 1, Mood: 5}, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReadinessScore(tt.in); got != tt.want {
+				t.Errorf("ReadinessScore(%+v) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdjustmentFor(t *testing.T) {
+	tests := []struct {
+		score int
+		zone  ReadinessZone
+	}{
+		{100, ReadinessHigh}, {70, ReadinessHigh},
+		{69, ReadinessModerate}, {45, ReadinessModerate},
+		{44, ReadinessLow}, {0, ReadinessLow},
+	}
+	for _, tt := range tests {
+		if got := AdjustmentFor(tt.score); got.Zone != tt.zone {
+			t.Errorf("AdjustmentFor(%d).Zone = %s, want %s", tt.score, got.Zone, tt.zone)
+		}
+	}
+	if AdjustmentFor(90).Changes() {
+		t.Error("high readiness should not change the workout")
+	}
+}
+
+func TestLoadAdjustmentApply(t *testing.T) {
+	low := AdjustmentFor(30)
+	tests := []struct {
+		name       string
+		adj        LoadAdjustment
+		weight     float64
+		sets       int
+		wantWeight float64
+		wantSets   int
+	}{
+		{"low: -10% and one set less", low, 100, 4, 90, 3},
+		{"low: weight rounded to 0.5", low, 62.5, 3, 56.5, 2},
+		{"low: two sets stay", low, 80, 2, 72, 2},
+		{"low: bodyweight exercise", low, 0, 3, 0, 2},
+		{"moderate: -5%", AdjustmentFor(60), 100, 4, 95, 4},
+		{"high: unchanged", AdjustmentFor(80), 100, 4, 100, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, s := tt.adj.Apply(tt.weight, tt.sets)
+			if w != tt.wantWeight || s != tt.wantSets {
+				t.Errorf("Apply(%v, %d) = %v, %d; want %v, %d", tt.weight, tt.sets, w, s, tt.wantWeight, tt.wantSets)
+			}
+		})
+	}
+}
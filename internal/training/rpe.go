@@ -0,0 +1,133 @@
+package training
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// rpeChart — таблица RPE (Цукершер, RTS): % от 1ПМ по «повторам до отказа».
+// Подход из r повторов на RPE x — это r + (10 − x) повторов до отказа;
+// индекс таблицы — (повторы до отказа − 1) · 2, шаг 0.5. 5 повторов на
+// RPE 8 = 7 до отказа = 81.1%, столько же, сколько 7 повторов на RPE 10
+var rpeChart = []float64{
+	100, 97.8, 95.5, 93.9, 92.2, 90.7, 89.2, 87.8, 86.3, 85.0, // 1–5.5
+	83.7, 82.4, 81.1, 79.9, 78.6, 77.4, 76.2, 75.1, 73.9, 72.3, // 6–10.5
+	70.7, 69.4, 68.0, 66.7, 65.3, 64.0, 62.6, 61.3, 59.9, 58.6, // 11–15.5
+	57.4, // 16
+}
+
+// Границы таблицы: за её пределами RPE оценивается ненадёжно
+const (
+	RPEChartMinRPE  = 6.0
+	RPEChartMaxReps = 12
+)
+
+// RPEFromRIR переводит повторы в запасе (RIR) в RPE: RIR 2 = RPE 8
+func RPEFromRIR(rir float64) float64 {
+	return 10 - rir
+}
+
+// RPEPercent возвращает % от 1ПМ для подхода из reps повторов на rpe по таблице RPE.
+// Промежуточные значения RPE интерполируются. 0 — подход вне таблицы
+// (больше 12 повторов, RPE ниже 6 или выше 10)
+func RPEPercent(reps int, rpe float64) float64 {
+	if reps < 1 || reps > RPEChartMaxReps || rpe < RPEChartMinRPE || rpe > 10 {
+		return 0
+	}
+	pos := (float64(reps) + 10 - rpe - 1) * 2
+	i := int(math.Floor(pos))
+	if i >= len(rpeChart)-1 {
+		return rpeChart[len(rpeChart)-1]
+	}
+	frac := pos - float64(i)
+	return rpeChart[i] + (rpeChart[i+1]-rpeChart[i])*frac
+}
+
+// EstimateE1RM оценивает 1ПМ по подходу с RPE: вес / % по таблице.
+// 0 — подход вне таблицы или без веса
+func EstimateE1RM(weight float64, reps int, rpe float64) float64 {
+	percent := RPEPercent(reps, rpe)
+	if weight <= 0 || percent == 0 {
+		return 0
+	}
+	return math.Round(weight/percent*100*10) / 10
+}
+
+// WeightForRPE переводит цель «reps повторов на rpe» в килограммы от e1RM,
+// округляя до step (по умолчанию 2.5 кг). 0 — цель вне таблицы или e1RM неизвестен
+func WeightForRPE(e1rm float64, reps int, rpe, step float64) float64 {
+	percent := RPEPercent(reps, rpe)
+	if e1rm <= 0 || percent == 0 {
+		return 0
+	}
+	if step <= 0 {
+		step = 2.5
+	}
+	return math.Round(e1rm*percent/100/step) * step
+}
+
+// E1RMPoint — подход с оценкой 1ПМ
+type E1RMPoint struct {
+	Date   time.Time
+	Weight float64
+	Reps   int
+	RPE    float64
+	E1RM   float64
+}
+
+// Параметры скользящего e1RM
+const (
+	// RollingE1RMSessions — сколько последних тренировок усредняется
+	RollingE1RMSessions = 3
+	// RollingE1RMDays — тренировки старше не учитываются: сила меняется
+	RollingE1RMDays = 42
+)
+
+// TopSets оставляет по одному подходу на день — с наибольшим e1RM (топ-сет).
+// Подходы вне таблицы RPE отбрасываются. Результат упорядочен по дате
+func TopSets(points []E1RMPoint) []E1RMPoint {
+	best := make(map[string]E1RMPoint)
+	for _, p := range points {
+		if p.E1RM == 0 {
+			p.E1RM = EstimateE1RM(p.Weight, p.Reps, p.RPE)
+		}
+		if p.E1RM == 0 {
+			continue
+		}
+		day := p.Date.Format("2006-01-02")
+		if cur, ok := best[day]; !ok || p.E1RM > cur.E1RM {
+			best[day] = p
+		}
+	}
+
+	tops := make([]E1RMPoint, 0, len(best))
+	for _, p := range best {
+		tops = append(tops, p)
+	}
+	sort.Slice(tops, func(i, j int) bool { return tops[i].Date.Before(tops[j].Date) })
+	return tops
+}
+
+// RollingE1RM — рабочий e1RM на дату now: среднее топ-сетов последних трёх
+// тренировок за 6 недель. Среднее сглаживает день, когда RPE оценён неточно.
+// tops — результат TopSets; 0 — свежих данных нет
+func RollingE1RM(tops []E1RMPoint, now time.Time) float64 {
+	since := now.AddDate(0, 0, -RollingE1RMDays)
+	var sum float64
+	n := 0
+	for i := len(tops) - 1; i >= 0 && n < RollingE1RMSessions; i-- {
+		if tops[i].Date.After(now) {
+			continue
+		}
+		if tops[i].Date.Before(since) {
+			break
+		}
+		sum += tops[i].E1RM
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	return math.Round(sum/float64(n)*10) / 10
+}
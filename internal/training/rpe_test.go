@@ -0,0 +1,103 @@
+package training
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRPEPercent(t *testing.T) {
+	tests := []struct {
+		name string
+		reps int
+		rpe  float64
+		want float64
+	}{
+		{"1@10", 1, 10, 100},
+		{"5@8", 5, 8, 81.1},
+		{"7@10 = 5@8", 7, 10, 81.1},
+		{"3@8.5", 3, 8.5, 87.8},
+		{"interpolated 5@7.75", 5, 7.75, 80.5},
+		{"12@6", 12, 6, 57.4},
+		{"too many reps", 13, 8, 0},
+		{"RPE below chart", 5, 5.5, 0},
+		{"RPE above 10", 5, 10.5, 0},
+		{"no reps", 0, 8, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RPEPercent(tt.reps, tt.rpe); math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("RPEPercent(%d, %v) = %v, want %v", tt.reps, tt.rpe, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateE1RMAndWeightForRPE(t *testing.T) {
+	e1rm := EstimateE1RM(100, 5, 8)
+	if e1rm != 123.3 {
+		t.Fatalf("EstimateE1RM(100, 5, 8) = %v, want 123.3", e1rm)
+	}
+	if got := WeightForRPE(e1rm, 5, 8, 2.5); got != 100 {
+		t.Errorf("WeightForRPE(%v, 5, 8) = %v, want 100", e1rm, got)
+	}
+	if got := WeightForRPE(200, 3, 9, 0); got != 177.5 {
+		t.Errorf("WeightForRPE(200, 3, 9) = %v, want 177.5", got)
+	}
+	if got := EstimateE1RM(100, 15, 8); got != 0 {
+		t.Errorf("EstimateE1RM outside chart = %v, want 0", got)
+	}
+	if got := WeightForRPE(0, 5, 8, 2.5); got != 0 {
+		t.Errorf("WeightForRPE without e1RM = %v, want 0", got)
+	}
+}
+
+func TestRPEFromRIR(t *testing.T) {
+	if got := RPEFromRIR(2); got != 8 {
+		t.Errorf("RPEFromRIR(2) = %v, want 8", got)
+	}
+}
+
+func TestTopSets(t *testing.T) {
+	day1 := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 3)
+	points := []E1RMPoint{
+		{Date: day2, Weight: 105, Reps: 3, RPE: 8},
+		{Date: day1, Weight: 100, Reps: 5, RPE: 8},
+		{Date: day1.Add(time.Hour), Weight: 100, Reps: 5, RPE: 9}, // тот же день, e1RM ниже
+		{Date: day1, Weight: 60, Reps: 20, RPE: 8},                // вне таблицы
+	}
+
+	tops := TopSets(points)
+	if len(tops) != 2 {
+		t.Fatalf("TopSets = %+v, want 2 days", tops)
+	}
+	if !tops[0].Date.Equal(day1) || tops[0].E1RM != 123.3 {
+		t.Errorf("day 1 top = %+v, want 100x5@8 (123.3)", tops[0])
+	}
+	if !tops[1].Date.Equal(day2) || tops[1].Weight != 105 {
+		t.Errorf("day 2 top = %+v, want 105x3@8", tops[1])
+	}
+}
+
+func TestRollingE1RM(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	top := func(daysAgo int, e1rm float64) E1RMPoint {
+		return E1RMPoint{Date: now.AddDate(0, 0, -daysAgo), E1RM: e1rm}
+	}
+
+	tops := []E1RMPoint{top(60, 90), top(20, 100), top(10, 110), top(5, 120), top(1, 130)}
+	if got := RollingE1RM(tops, now); got != 120 {
+		t.Errorf("RollingE1RM = %v, want mean of last three (120)", got)
+	}
+
+	// Тренировка 60 дней назад выходит за окно
+	tops = []E1RMPoint{top(60, 90), top(10, 110)}
+	if got := RollingE1RM(tops, now); got != 110 {
+		t.Errorf("RollingE1RM with stale = %v, want 110", got)
+	}
+	if got := RollingE1RM([]E1RMPoint{top(50, 100)}, now); got != 0 {
+		t.Errorf("RollingE1RM only stale = %v, want 0", got)
+	}
+}
@@ -0,0 +1,135 @@
+package training
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// LoadSpec is a load placeholder used in program templates.
+// A template stores load relative to the athlete (percent of 1PM, target RPE)
+// so that it can be resolved for any client when the template is assigned.
+type LoadSpec struct {
+	Percent float64 // Percent of the exercise 1PM (0 if not set)
+	RPE     float64 // Target RPE (0 if not set)
+	Weight  float64 // Absolute weight in kg (0 if not set)
+}
+
+var (
+	loadPercentRe = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*%\s*(?:1\s*pm|1\s*пм|1rm)?`)
+	loadRPERe     = regexp.MustCompile(`(?:@\s*(?:rpe)?|rpe)\s*(\d+(?:\.\d+)?)`)
+	loadWeightRe  = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*(?:кг|kg)?$`)
+)
+
+// ParseLoadSpec parses a template load placeholder.
+// Supported forms: "75%", "75%1PM", "75% 1ПМ", "RPE 8", "@8", "75%1PM @8", "60кг", "60".
+// An empty string means no prescribed load (e.g. bodyweight).
+func ParseLoadSpec(s string) (LoadSpec, error) {
+	var spec LoadSpec
+	text := strings.ToLower(strings.TrimSpace(s))
+	text = strings.ReplaceAll(text, ",", ".")
+	if text == "" {
+		return spec, nil
+	}
+
+	if m := loadRPERe.FindStringSubmatch(text); m != nil {
+		spec.RPE, _ = strconv.ParseFloat(m[1], 64)
+		if spec.RPE < 1 || spec.RPE > 10 {
+			return LoadSpec{}, fmt.Errorf("RPE must be between 1 and 10: %q", s)
+		}
+		text = strings.TrimSpace(strings.Replace(text, m[0], "", 1))
+	}
+
+	if m := loadPercentRe.FindStringSubmatch(text); m != nil {
+		spec.Percent, _ = strconv.ParseFloat(m[1], 64)
+		if spec.Percent <= 0 || spec.Percent > 120 {
+			return LoadSpec{}, fmt.Errorf("percent of 1PM must be between 0 and 120: %q", s)
+		}
+		text = strings.TrimSpace(strings.Replace(text, m[0], "", 1))
+	}
+
+	if text != "" {
+		m := loadWeightRe.FindStringSubmatch(text)
+		if m == nil || spec.Percent > 0 {
+			return LoadSpec{}, fmt.Errorf("unrecognized load: %q", s)
+		}
+		spec.Weight, _ = strconv.ParseFloat(m[1], 64)
+	}
+
+	return spec, nil
+}
+
+// String returns the canonical placeholder form, e.g. "75%1PM @RPE8" or "60кг"
+func (l LoadSpec) String() string {
+	var parts []string
+	if l.Percent > 0 {
+		parts = append(parts, strconv.FormatFloat(l.Percent, 'f', -1, 64)+"%1PM")
+	}
+	if l.Weight > 0 {
+		parts = append(parts, strconv.FormatFloat(l.Weight, 'f', -1, 64)+"кг")
+	}
+	if l.RPE > 0 {
+		parts = append(parts, "@RPE"+strconv.FormatFloat(l.RPE, 'f', -1, 64))
+	}
+	return strings.Join(parts, " ")
+}
+
+// IsEmpty reports whether no load is prescribed
+func (l LoadSpec) IsEmpty() bool {
+	return l.Percent == 0 && l.RPE == 0 && l.Weight == 0
+}
+
+// ResolveWeight returns the working weight for the given 1PM.
+// Percent placeholders need a known 1PM; otherwise 0 is returned and the
+// percent is kept as guidance for the athlete.
+func (l LoadSpec) ResolveWeight(onePM, increment float64) float64 {
+	if l.Weight > 0 {
+		return l.Weight
+	}
+	if l.Percent > 0 && onePM > 0 {
+		if increment <= 0 {
+			return CalculateWorkingWeight(onePM, l.Percent)
+		}
+		return CalculateWorkingWeightRound(onePM, l.Percent, increment)
+	}
+	return 0
+}
+
+// LoadSpecFromPrescription builds a placeholder from a concrete prescription.
+// Percent of 1PM takes precedence over absolute weight so the template stays
+// relative to the athlete.
+func LoadSpecFromPrescription(weight, percent, rpe float64) LoadSpec {
+	spec := LoadSpec{RPE: rpe}
+	if percent > 0 {
+		spec.Percent = math.Round(percent*10) / 10
+	} else if weight > 0 {
+		spec.Weight = weight
+	}
+	return spec
+}
+
+// trainingDayOffsets spreads training days across a week (0 = first day of the week)
+var trainingDayOffsets = map[int][]int{
+	1: {0},
+	2: {0, 3},
+	3: {0, 2, 4},
+	4: {0, 1, 3, 4},
+	5: {0, 1, 2, 3, 4},
+	6: {0, 1, 2, 3, 4, 5},
+	7: {0, 1, 2, 3, 4, 5, 6},
+}
+
+// TemplateDayOffset returns the day offset from the start of a week for the
+// dayNum-th training day (1-based) of a program with daysPerWeek sessions
+func TemplateDayOffset(dayNum, daysPerWeek int) int {
+	offsets, ok := trainingDayOffsets[daysPerWeek]
+	if !ok || dayNum < 1 {
+		return 0
+	}
+	if dayNum > len(offsets) {
+		return offsets[len(offsets)-1]
+	}
+	return offsets[dayNum-1]
+}
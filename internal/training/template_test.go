@@ -0,0 +1,100 @@
+package training
+
+import "testing"
+
+func TestParseLoadSpec(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    LoadSpec
+		wantErr bool
+	}{
+		{"", LoadSpec{}, false},
+		{"75%", LoadSpec{Percent: 75}, false},
+		{"75%1PM", LoadSpec{Percent: 75}, false},
+		{"72,5% 1ПМ", LoadSpec{Percent: 72.5}, false},
+		{"RPE 8", LoadSpec{RPE: 8}, false},
+		{"@7.5", LoadSpec{RPE: 7.5}, false},
+		{"75%1PM @RPE8", LoadSpec{Percent: 75, RPE: 8}, false},
+		{"60кг", LoadSpec{Weight: 60}, false},
+		{"60 kg @8", LoadSpec{Weight: 60, RPE: 8}, false},
+		{"60", LoadSpec{Weight: 60}, false},
+		{"RPE 11", LoadSpec{}, true},
+		{"150%", LoadSpec{}, true},
+		{"тяжело", LoadSpec{}, true},
+		{"75% 60кг", LoadSpec{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseLoadSpec(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLoadSpec(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLoadSpec(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSpecRoundTrip(t *testing.T) {
+	specs := []LoadSpec{
+		{Percent: 75},
+		{Percent: 72.5, RPE: 8},
+		{RPE: 7},
+		{Weight: 60},
+	}
+
+	for _, spec := range specs {
+		got, err := ParseLoadSpec(spec.String())
+		if err != nil {
+			t.Fatalf("ParseLoadSpec(%q) error = %v", spec.String(), err)
+		}
+		if got != spec {
+			t.Errorf("round trip %q = %+v, want %+v", spec.String(), got, spec)
+		}
+	}
+}
+
+func TestLoadSpecResolveWeight(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  LoadSpec
+		onePM float64
+		want  float64
+	}{
+		{"percent with 1PM", LoadSpec{Percent: 75}, 140, 105},
+		{"percent rounds to plates", LoadSpec{Percent: 72}, 100, 72.5},
+		{"percent without 1PM", LoadSpec{Percent: 75}, 0, 0},
+		{"absolute weight", LoadSpec{Weight: 60}, 140, 60},
+		{"rpe only", LoadSpec{RPE: 8}, 140, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.spec.ResolveWeight(tt.onePM, 2.5); got != tt.want {
+				t.Errorf("ResolveWeight(%v) = %v, want %v", tt.onePM, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateDayOffset(t *testing.T) {
+	tests := []struct {
+		dayNum, daysPerWeek, want int
+	}{
+		{1, 3, 0},
+		{2, 3, 2},
+		{3, 3, 4},
+		{2, 2, 3},
+		{4, 4, 4},
+		{5, 3, 4},
+		{1, 9, 0},
+	}
+
+	for _, tt := range tests {
+		if got := TemplateDayOffset(tt.dayNum, tt.daysPerWeek); got != tt.want {
+			t.Errorf("TemplateDayOffset(%d, %d) = %d, want %d", tt.dayNum, tt.daysPerWeek, got, tt.want)
+		}
+	}
+}
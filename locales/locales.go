@@ -0,0 +1,15 @@
+// Package locales встраивает файлы локализации в бинарник.
+//
+// Чтобы добавить язык, положите рядом файл <код>.json (например, kk.json)
+// со всеми ключами из ru.json, включая language_name и language_flag, и запустите
+// go generate ./locales — проверка сообщит о недостающих и неиспользуемых ключах.
+package locales
+
+import "embed"
+
+//go:generate go run ../cmd/i18ncheck -locales . -src ..
+
+// FS содержит все файлы *.json этого каталога
+//
+//go:embed *.json
+var FS embed.FS
@@ -0,0 +1,19 @@
+-- Миграция 019: Замена упражнений во время тренировки
+-- Сохраняем исходное упражнение, чтобы тренер видел, что было заменено
+
+ALTER TABLE public.workout_exercises
+ADD COLUMN IF NOT EXISTS original_exercise_name VARCHAR(200),
+ADD COLUMN IF NOT EXISTS original_weight DECIMAL(6,2),
+ADD COLUMN IF NOT EXISTS substitution_reason VARCHAR(50),
+ADD COLUMN IF NOT EXISTS substituted_at TIMESTAMP;
+
+-- Индекс для подсчёта повторяющихся замен в программе
+CREATE INDEX IF NOT EXISTS idx_workout_exercises_substituted
+ON public.workout_exercises(original_exercise_name, exercise_name)
+WHERE original_exercise_name IS NOT NULL;
+
+-- Комментарии
+COMMENT ON COLUMN public.workout_exercises.original_exercise_name IS 'Исходное упражнение до замены клиентом';
+COMMENT ON COLUMN public.workout_exercises.original_weight IS 'Исходный рабочий вес до замены';
+COMMENT ON COLUMN public.workout_exercises.substitution_reason IS 'Причина замены: busy, noequip, knee, lower_back, shoulder, wrist';
+COMMENT ON COLUMN public.workout_exercises.substituted_at IS 'Когда упражнение было заменено';
@@ -0,0 +1,21 @@
+-- Миграция 020: Пошаговая запись подходов в трекере тренировок
+-- Каждый подход хранится отдельно: повторы, вес и RPE
+
+CREATE TABLE IF NOT EXISTS public.workout_sets (
+    id SERIAL PRIMARY KEY,
+    workout_exercise_id INTEGER NOT NULL REFERENCES public.workout_exercises(id) ON DELETE CASCADE,
+    set_num INTEGER NOT NULL,
+    reps INTEGER NOT NULL,
+    weight DECIMAL(6,2) NOT NULL DEFAULT 0,
+    rpe DECIMAL(3,1),
+    created_at TIMESTAMP DEFAULT NOW(),
+    CONSTRAINT unique_exercise_set UNIQUE (workout_exercise_id, set_num)
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_sets_exercise ON public.workout_sets(workout_exercise_id);
+
+-- Комментарии
+COMMENT ON TABLE public.workout_sets IS 'Фактически выполненные подходы упражнений тренировки';
+COMMENT ON COLUMN public.workout_sets.set_num IS 'Номер подхода (1, 2, 3...)';
+COMMENT ON COLUMN public.workout_sets.weight IS 'Вес в подходе, кг';
+COMMENT ON COLUMN public.workout_sets.rpe IS 'RPE подхода (1-10)';
@@ -0,0 +1,25 @@
+-- Миграция 021: Группы клиентов для массовых действий тренера
+-- Группа — сохранённый тег ("PL команда", "утренняя группа"), к которому привязаны клиенты
+
+CREATE TABLE IF NOT EXISTS public.client_groups (
+    id SERIAL PRIMARY KEY,
+    trainer_id BIGINT NOT NULL REFERENCES public.admins(telegram_id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    CONSTRAINT unique_trainer_group_name UNIQUE (trainer_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS public.client_group_members (
+    group_id INTEGER NOT NULL REFERENCES public.client_groups(id) ON DELETE CASCADE,
+    client_id INTEGER NOT NULL REFERENCES public.clients(id) ON DELETE CASCADE,
+    added_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (group_id, client_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_client_groups_trainer ON public.client_groups(trainer_id);
+CREATE INDEX IF NOT EXISTS idx_client_group_members_client ON public.client_group_members(client_id);
+
+-- Комментарии
+COMMENT ON TABLE public.client_groups IS 'Группы (теги) клиентов тренера для массовых действий';
+COMMENT ON COLUMN public.client_groups.name IS 'Название группы, уникально в пределах тренера';
+COMMENT ON TABLE public.client_group_members IS 'Состав групп клиентов';
@@ -0,0 +1,145 @@
+-- Миграция 022: Библиотека шаблонов программ тренера
+-- Шаблон хранит нагрузку через плейсхолдеры (% от 1ПМ, целевой RPE),
+-- которые подставляются по 1ПМ клиента при назначении
+
+CREATE TABLE IF NOT EXISTS public.program_templates (
+    id SERIAL PRIMARY KEY,
+    trainer_id BIGINT REFERENCES public.admins(telegram_id) ON DELETE CASCADE, -- NULL = встроенный шаблон
+    name VARCHAR(200) NOT NULL,
+    description TEXT,
+    goal VARCHAR(100),
+    total_weeks INTEGER NOT NULL DEFAULT 4,
+    days_per_week INTEGER NOT NULL DEFAULT 3,
+    is_shared BOOLEAN NOT NULL DEFAULT FALSE,
+    cloned_from INTEGER REFERENCES public.program_templates(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS public.program_template_exercises (
+    id SERIAL PRIMARY KEY,
+    template_id INTEGER NOT NULL REFERENCES public.program_templates(id) ON DELETE CASCADE,
+    week_num INTEGER NOT NULL,
+    day_num INTEGER NOT NULL,
+    day_name VARCHAR(200),
+    order_num INTEGER NOT NULL DEFAULT 1,
+    exercise_name VARCHAR(200) NOT NULL,
+    sets INTEGER NOT NULL DEFAULT 3,
+    reps VARCHAR(50) NOT NULL DEFAULT '10',
+    load VARCHAR(50), -- "75%1PM", "@RPE8", "75%1PM @RPE8", "60кг"
+    rest_seconds INTEGER DEFAULT 90,
+    tempo VARCHAR(20),
+    notes TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_program_templates_trainer ON public.program_templates(trainer_id);
+CREATE INDEX IF NOT EXISTS idx_program_templates_shared ON public.program_templates(is_shared) WHERE is_shared = TRUE;
+CREATE INDEX IF NOT EXISTS idx_template_exercises_position
+ON public.program_template_exercises(template_id, week_num, day_num, order_num);
+
+-- Связь программы клиента с шаблоном, из которого она создана
+ALTER TABLE public.training_programs
+ADD COLUMN IF NOT EXISTS template_id INTEGER REFERENCES public.program_templates(id) ON DELETE SET NULL;
+
+-- Комментарии
+COMMENT ON TABLE public.program_templates IS 'Шаблоны программ тренировок (библиотека тренера)';
+COMMENT ON COLUMN public.program_templates.trainer_id IS 'Владелец шаблона; NULL — встроенный шаблон, доступен всем';
+COMMENT ON COLUMN public.program_templates.is_shared IS 'Шаблон виден другим тренерам';
+COMMENT ON COLUMN public.program_templates.cloned_from IS 'Шаблон-источник при клонировании';
+COMMENT ON TABLE public.program_template_exercises IS 'Упражнения шаблона по неделям и дням';
+COMMENT ON COLUMN public.program_template_exercises.load IS 'Плейсхолдер нагрузки: % от 1ПМ, целевой RPE или вес в кг';
+COMMENT ON COLUMN public.training_programs.template_id IS 'Шаблон, из которого назначена программа';
+
+-- Встроенные шаблоны
+
+-- Новичок: всё тело 3 раза в неделю, нагрузка по RPE
+WITH t AS (
+    INSERT INTO public.program_templates (trainer_id, name, description, goal, total_weeks, days_per_week, is_shared)
+    SELECT NULL, 'Новичок', 'Всё тело 3 раза в неделю, освоение техники, нагрузка по RPE', 'general', 4, 3, TRUE
+    WHERE NOT EXISTS (SELECT 1 FROM public.program_templates WHERE trainer_id IS NULL AND name = 'Новичок')
+    RETURNING id
+)
+INSERT INTO public.program_template_exercises
+    (template_id, week_num, day_num, day_name, order_num, exercise_name, sets, reps, load, rest_seconds)
+SELECT t.id, w, d.day_num, d.day_name, d.order_num, d.exercise_name,
+       CASE WHEN w = 4 THEN GREATEST(d.sets - 1, 2) ELSE d.sets END,
+       d.reps,
+       '@RPE' || CASE WHEN w = 4 THEN d.rpe - 1 ELSE d.rpe + (w - 1) * 0.5 END,
+       d.rest
+FROM t
+CROSS JOIN generate_series(1, 4) AS w
+CROSS JOIN (VALUES
+    (1, 'День A — всё тело', 1, 'Приседания со штангой', 3, '10', 6.5, 120),
+    (1, 'День A — всё тело', 2, 'Жим гантелей лёжа', 3, '10-12', 6.5, 90),
+    (1, 'День A — всё тело', 3, 'Тяга верхнего блока', 3, '10-12', 6.5, 90),
+    (1, 'День A — всё тело', 4, 'Планка', 3, '30 сек', 6.5, 60),
+    (2, 'День B — всё тело', 1, 'Румынская тяга', 3, '10', 6.5, 120),
+    (2, 'День B — всё тело', 2, 'Жим гантелей сидя', 3, '10-12', 6.5, 90),
+    (2, 'День B — всё тело', 3, 'Тяга гантели в наклоне', 3, '10-12', 6.5, 90),
+    (2, 'День B — всё тело', 4, 'Скручивания', 3, '15', 6.5, 60),
+    (3, 'День C — всё тело', 1, 'Выпады', 3, '10', 6.5, 90),
+    (3, 'День C — всё тело', 2, 'Жим лёжа', 3, '8-10', 6.5, 120),
+    (3, 'День C — всё тело', 3, 'Тяга штанги в наклоне', 3, '10', 6.5, 90),
+    (3, 'День C — всё тело', 4, 'Гиперэкстензия', 3, '12-15', 6.5, 60)
+) AS d(day_num, day_name, order_num, exercise_name, sets, reps, rpe, rest);
+
+-- Fat Loss 30–40: круговые тренировки для клиентов 30–40 лет, умеренная интенсивность
+WITH t AS (
+    INSERT INTO public.program_templates (trainer_id, name, description, goal, total_weeks, days_per_week, is_shared)
+    SELECT NULL, 'Fat Loss 30–40', 'Жиросжигание: 3 силовые тренировки с коротким отдыхом, RPE 7–8', 'fatloss', 4, 3, TRUE
+    WHERE NOT EXISTS (SELECT 1 FROM public.program_templates WHERE trainer_id IS NULL AND name = 'Fat Loss 30–40')
+    RETURNING id
+)
+INSERT INTO public.program_template_exercises
+    (template_id, week_num, day_num, day_name, order_num, exercise_name, sets, reps, load, rest_seconds)
+SELECT t.id, w, d.day_num, d.day_name, d.order_num, d.exercise_name,
+       CASE WHEN w IN (2, 3) THEN d.sets + 1 ELSE d.sets END,
+       d.reps,
+       '@RPE' || CASE WHEN w = 4 THEN 7 ELSE d.rpe END,
+       d.rest
+FROM t
+CROSS JOIN generate_series(1, 4) AS w
+CROSS JOIN (VALUES
+    (1, 'День 1 — низ + кор', 1, 'Приседания со штангой', 3, '12', 7.5, 60),
+    (1, 'День 1 — низ + кор', 2, 'Выпады', 3, '12', 7.5, 45),
+    (1, 'День 1 — низ + кор', 3, 'Сгибания ног', 3, '15', 7.5, 45),
+    (1, 'День 1 — низ + кор', 4, 'Планка', 3, '45 сек', 7.5, 30),
+    (2, 'День 2 — верх', 1, 'Жим гантелей лёжа', 3, '12', 7.5, 60),
+    (2, 'День 2 — верх', 2, 'Тяга верхнего блока', 3, '12', 7.5, 60),
+    (2, 'День 2 — верх', 3, 'Махи гантелями в стороны', 3, '15', 7.5, 45),
+    (2, 'День 2 — верх', 4, 'Разгибания на трицепс', 3, '15', 7.5, 45),
+    (3, 'День 3 — всё тело', 1, 'Румынская тяга', 3, '12', 7.5, 60),
+    (3, 'День 3 — всё тело', 2, 'Отжимания на брусьях', 3, '10', 7.5, 60),
+    (3, 'День 3 — всё тело', 3, 'Тяга гантели в наклоне', 3, '12', 7.5, 45),
+    (3, 'День 3 — всё тело', 4, 'Скручивания', 3, '20', 7.5, 30)
+) AS d(day_num, day_name, order_num, exercise_name, sets, reps, rpe, rest);
+
+-- PL 3x/week: присед, жим, тяга по % от 1ПМ с линейным ростом и разгрузкой на 4-й неделе
+WITH t AS (
+    INSERT INTO public.program_templates (trainer_id, name, description, goal, total_weeks, days_per_week, is_shared)
+    SELECT NULL, 'PL 3x/week', 'Пауэрлифтинг 3 раза в неделю: соревновательные движения по % от 1ПМ', 'strength', 4, 3, TRUE
+    WHERE NOT EXISTS (SELECT 1 FROM public.program_templates WHERE trainer_id IS NULL AND name = 'PL 3x/week')
+    RETURNING id
+)
+INSERT INTO public.program_template_exercises
+    (template_id, week_num, day_num, day_name, order_num, exercise_name, sets, reps, load, rest_seconds)
+SELECT t.id, w, d.day_num, d.day_name, d.order_num, d.exercise_name, d.sets, d.reps,
+       CASE
+           WHEN d.percent = 0 THEN '@RPE' || d.rpe
+           WHEN w = 4 THEN (d.percent - 10) || '%1PM'
+           ELSE (d.percent + (w - 1) * 2.5) || '%1PM'
+       END,
+       d.rest
+FROM t
+CROSS JOIN generate_series(1, 4) AS w
+CROSS JOIN (VALUES
+    (1, 'День 1 — присед, жим', 1, 'Приседания со штангой', 5, '5', 70.0, 0, 180),
+    (1, 'День 1 — присед, жим', 2, 'Жим лёжа', 5, '5', 70.0, 0, 180),
+    (1, 'День 1 — присед, жим', 3, 'Тяга штанги в наклоне', 3, '8-10', 0.0, 8, 90),
+    (2, 'День 2 — тяга, жим', 1, 'Становая тяга', 4, '4', 72.5, 0, 180),
+    (2, 'День 2 — тяга, жим', 2, 'Жим лёжа', 4, '6', 67.5, 0, 150),
+    (2, 'День 2 — тяга, жим', 3, 'Подтягивания', 3, '6-8', 0.0, 8, 90),
+    (3, 'День 3 — присед, жим', 1, 'Приседания со штангой', 4, '3', 77.5, 0, 180),
+    (3, 'День 3 — присед, жим', 2, 'Жим лёжа', 5, '3', 77.5, 0, 180),
+    (3, 'День 3 — присед, жим', 3, 'Румынская тяга', 3, '8', 0.0, 7, 120)
+) AS d(day_num, day_name, order_num, exercise_name, sets, reps, percent, rpe, rest);
@@ -0,0 +1,9 @@
+-- Миграция 023: Часовые пояса клиентов и тренеров
+-- Время записей (appointment_date + start_time) и расписания хранится в поясе тренера;
+-- напоминания и отображение пересчитываются в пояс клиента
+
+ALTER TABLE public.clients ADD COLUMN IF NOT EXISTS timezone VARCHAR(64);
+ALTER TABLE public.admins ADD COLUMN IF NOT EXISTS timezone VARCHAR(64);
+
+COMMENT ON COLUMN public.clients.timezone IS 'Часовой пояс клиента (IANA, например Europe/Moscow). NULL — пояс по умолчанию';
+COMMENT ON COLUMN public.admins.timezone IS 'Часовой пояс тренера (IANA). В нём задаются расписание и время записей. NULL — пояс по умолчанию';
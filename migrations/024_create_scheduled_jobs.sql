@@ -0,0 +1,30 @@
+-- Миграция 024: Таблица фоновых задач планировщика
+-- Задачи запускаются по cron-расписанию; экземпляр бота захватывает задачу
+-- через SELECT ... FOR UPDATE SKIP LOCKED и держит аренду до locked_until
+
+CREATE TABLE IF NOT EXISTS public.scheduled_jobs (
+    name VARCHAR(100) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    schedule VARCHAR(100) NOT NULL,
+    next_run_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_run_at TIMESTAMPTZ,
+    last_success_at TIMESTAMPTZ,
+    last_error TEXT,
+    last_duration_ms INTEGER,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    run_count INTEGER NOT NULL DEFAULT 0,
+    fail_count INTEGER NOT NULL DEFAULT 0,
+    locked_by VARCHAR(100),
+    locked_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_jobs_next_run ON public.scheduled_jobs(next_run_at);
+
+COMMENT ON TABLE public.scheduled_jobs IS 'Фоновые задачи бота (напоминания, синхронизация) с cron-расписанием';
+COMMENT ON COLUMN public.scheduled_jobs.schedule IS 'Cron-выражение (5 полей) или дескриптор (@hourly, @every 30s)';
+COMMENT ON COLUMN public.scheduled_jobs.next_run_at IS 'Время следующего запуска; если в прошлом — задача просрочена и выполнится при первой возможности';
+COMMENT ON COLUMN public.scheduled_jobs.attempts IS 'Число неудачных попыток подряд; сбрасывается после успешного запуска';
+COMMENT ON COLUMN public.scheduled_jobs.locked_by IS 'Экземпляр бота, выполняющий задачу';
+COMMENT ON COLUMN public.scheduled_jobs.locked_until IS 'Окончание аренды; после него задачу может захватить другой экземпляр';
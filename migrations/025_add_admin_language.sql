@@ -0,0 +1,9 @@
+-- Миграция 025: Язык интерфейса тренера
+-- Раньше язык хранился только у клиентов; тренер всегда видел русский интерфейс.
+-- Новый язык добавляется файлом локализации, поэтому код языка не ограничен двумя символами
+
+ALTER TABLE public.admins ADD COLUMN IF NOT EXISTS language VARCHAR(8);
+ALTER TABLE public.clients ALTER COLUMN language TYPE VARCHAR(8);
+
+COMMENT ON COLUMN public.admins.language IS 'Язык интерфейса тренера (код файла локализации: ru, en...). NULL — язык по умолчанию';
+COMMENT ON COLUMN public.clients.language IS 'Язык интерфейса клиента (код файла локализации: ru, en...)';
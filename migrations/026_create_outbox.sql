@@ -0,0 +1,40 @@
+-- Миграция 026: Очередь исходящих сообщений и клиенты, заблокировавшие бота
+-- Уведомления (напоминания, сводки тренеру) сохраняются в очередь до отправки
+-- и переживают перезапуск бота. Экземпляр бота захватывает пачку сообщений
+-- через SELECT ... FOR UPDATE SKIP LOCKED и держит аренду до locked_until
+
+CREATE TABLE IF NOT EXISTS public.outbox_messages (
+    id BIGSERIAL PRIMARY KEY,
+    dedup_key VARCHAR(200),
+    chat_id BIGINT NOT NULL,
+    text TEXT NOT NULL,
+    parse_mode VARCHAR(20),
+    reply_markup JSONB,
+    disable_preview BOOLEAN NOT NULL DEFAULT false,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'sent', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    message_id INTEGER,
+    locked_by VARCHAR(100),
+    locked_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_messages_dedup ON public.outbox_messages(dedup_key);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_due ON public.outbox_messages(next_attempt_at, id)
+    WHERE status = 'pending';
+
+COMMENT ON TABLE public.outbox_messages IS 'Очередь исходящих сообщений Telegram: уведомления ждут отправки с учётом лимитов';
+COMMENT ON COLUMN public.outbox_messages.dedup_key IS 'Ключ защиты от дублей (например, напоминание о конкретной записи); NULL — без проверки';
+COMMENT ON COLUMN public.outbox_messages.reply_markup IS 'Клавиатура сообщения в формате Bot API';
+COMMENT ON COLUMN public.outbox_messages.status IS 'pending — ждёт отправки, sent — отправлено, failed — попытки исчерпаны или ошибка не исправится повтором';
+COMMENT ON COLUMN public.outbox_messages.next_attempt_at IS 'Время следующей попытки; после 429 — через retry_after, иначе с экспоненциальной задержкой';
+COMMENT ON COLUMN public.outbox_messages.locked_by IS 'Экземпляр бота, отправляющий сообщение';
+COMMENT ON COLUMN public.outbox_messages.locked_until IS 'Окончание аренды; после него сообщение может захватить другой экземпляр';
+
+ALTER TABLE public.clients ADD COLUMN IF NOT EXISTS bot_blocked_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN public.clients.bot_blocked_at IS 'Когда клиент заблокировал бота (ответ 403). NULL — бот доступен; сбрасывается, когда клиент снова пишет боту';
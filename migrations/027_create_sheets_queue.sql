@@ -0,0 +1,36 @@
+-- Миграция 027: Очередь записей в Google Sheets
+-- Если Google недоступен или исчерпана квота, изменения таблицы не теряются:
+-- они сохраняются в очередь и отправляются фоновой задачей sheets_sync.
+-- Записи одной таблицы при отправке объединяются в один набор запросов
+
+CREATE TABLE IF NOT EXISTS public.sheets_pending_writes (
+    id BIGSERIAL PRIMARY KEY,
+    spreadsheet_id VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sheets_pending_writes_due ON public.sheets_pending_writes(next_attempt_at, id)
+    WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_sheets_pending_writes_spreadsheet ON public.sheets_pending_writes(spreadsheet_id);
+
+COMMENT ON TABLE public.sheets_pending_writes IS 'Отложенные записи в Google Sheets, которые не удалось отправить сразу';
+COMMENT ON COLUMN public.sheets_pending_writes.payload IS 'Изменения таблицы: диапазоны значений, добавляемые строки и оформление (gsheets.Write)';
+COMMENT ON COLUMN public.sheets_pending_writes.status IS 'pending — ждёт отправки, failed — ошибка не исправится повтором или попытки исчерпаны';
+COMMENT ON COLUMN public.sheets_pending_writes.created_at IS 'Когда появилась самая старая из объединённых записей';
+
+CREATE TABLE IF NOT EXISTS public.sheets_sync_state (
+    spreadsheet_id VARCHAR(255) PRIMARY KEY,
+    last_synced_at TIMESTAMPTZ,
+    last_error TEXT,
+    last_error_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE public.sheets_sync_state IS 'Состояние синхронизации Google таблиц (показывается в карточке клиента)';
+COMMENT ON COLUMN public.sheets_sync_state.last_error IS 'Последняя ошибка записи; сбрасывается после успешной записи';
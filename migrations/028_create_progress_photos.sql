@@ -0,0 +1,37 @@
+-- Миграция 028: Фотографии прогресса с ракурсами
+-- Раньше у записи прогресса было одно фото (client_progress.photo_file_id).
+-- Теперь за одну дату хранится до трёх ракурсов: спереди, сбоку и сзади.
+-- Фото видят только сам клиент и тренеры; клиент может их удалить
+
+CREATE TABLE IF NOT EXISTS public.progress_photos (
+    id SERIAL PRIMARY KEY,
+    client_id INTEGER NOT NULL REFERENCES public.clients(id) ON DELETE CASCADE,
+    progress_id INTEGER REFERENCES public.client_progress(id) ON DELETE SET NULL,
+    taken_on DATE NOT NULL DEFAULT CURRENT_DATE,
+    angle VARCHAR(10) NOT NULL DEFAULT 'front'
+        CHECK (angle IN ('front', 'side', 'back')),
+    file_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    -- Один ракурс за дату: повторное фото заменяет прежнее
+    CONSTRAINT unique_progress_photo_angle UNIQUE (client_id, taken_on, angle)
+);
+
+CREATE INDEX IF NOT EXISTS idx_progress_photos_client_date ON public.progress_photos(client_id, taken_on DESC);
+
+-- Переносим существующие фото как вид спереди
+INSERT INTO public.progress_photos (client_id, progress_id, taken_on, angle, file_id)
+SELECT client_id, id, record_date, 'front', photo_file_id
+FROM public.client_progress
+WHERE photo_file_id IS NOT NULL AND photo_file_id <> ''
+ON CONFLICT (client_id, taken_on, angle) DO NOTHING;
+
+-- Старое поле больше не заполняется; копия удаляется, чтобы удалённое клиентом фото не оставалось в базе
+UPDATE public.client_progress SET photo_file_id = NULL WHERE photo_file_id IS NOT NULL;
+
+COMMENT ON TABLE public.progress_photos IS 'Фотографии прогресса клиента по датам и ракурсам';
+COMMENT ON COLUMN public.progress_photos.progress_id IS 'Запись прогресса, вместе с которой сделано фото (NULL — фото добавлено из галереи)';
+COMMENT ON COLUMN public.progress_photos.taken_on IS 'Дата фото';
+COMMENT ON COLUMN public.progress_photos.angle IS 'Ракурс: front — спереди, side — сбоку, back — сзади';
+COMMENT ON COLUMN public.progress_photos.file_id IS 'Telegram file_id фотографии наибольшего размера';
+COMMENT ON COLUMN public.client_progress.photo_file_id IS 'Устарело: фото хранятся в progress_photos';
@@ -0,0 +1,55 @@
+-- Миграция 029: Питание — цели по КБЖУ и ежедневные отметки
+-- Профиль питания хранит данные для расчёта BMR/TDEE, которых нет в карточке
+-- клиента (пол, рост, активность), и текущие цели по калориям и макронутриентам.
+-- Цели пересчитываются раз в неделю по динамике веса из client_progress
+
+CREATE TABLE IF NOT EXISTS public.nutrition_profiles (
+    client_id INTEGER PRIMARY KEY REFERENCES public.clients(id) ON DELETE CASCADE,
+    sex VARCHAR(10) NOT NULL CHECK (sex IN ('male', 'female')),
+    height_cm DECIMAL(4,1),
+    age INTEGER,
+    activity VARCHAR(20) NOT NULL DEFAULT 'light'
+        CHECK (activity IN ('sedentary', 'light', 'moderate', 'active', 'very_active')),
+    goal VARCHAR(20) NOT NULL DEFAULT 'maintain'
+        CHECK (goal IN ('fat_loss', 'maintain', 'gain')),
+    formula VARCHAR(10) NOT NULL,
+    bmr INTEGER NOT NULL,
+    tdee INTEGER NOT NULL,
+    calories INTEGER NOT NULL,
+    protein INTEGER NOT NULL,
+    fat INTEGER NOT NULL,
+    carbs INTEGER NOT NULL,
+    weekly_rate DECIMAL(4,2),
+    adjusted_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS public.nutrition_checkins (
+    id SERIAL PRIMARY KEY,
+    client_id INTEGER NOT NULL REFERENCES public.clients(id) ON DELETE CASCADE,
+    checkin_date DATE NOT NULL,
+    calories INTEGER,
+    protein INTEGER,
+    steps INTEGER,
+    sleep_hours DECIMAL(3,1),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    -- Одна отметка в день: повторная заменяет заполненные поля
+    CONSTRAINT unique_nutrition_checkin UNIQUE (client_id, checkin_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_nutrition_checkins_client_date ON public.nutrition_checkins(client_id, checkin_date DESC);
+
+COMMENT ON TABLE public.nutrition_profiles IS 'Профиль питания клиента и текущие цели по КБЖУ';
+COMMENT ON COLUMN public.nutrition_profiles.height_cm IS 'Рост, см (NULL — неизвестен, расчёт по % жира)';
+COMMENT ON COLUMN public.nutrition_profiles.age IS 'Возраст, если дата рождения в карточке не указана';
+COMMENT ON COLUMN public.nutrition_profiles.activity IS 'Уровень активности: sedentary, light, moderate, active, very_active';
+COMMENT ON COLUMN public.nutrition_profiles.goal IS 'Задача питания: fat_loss — снижение веса, maintain — поддержание, gain — набор';
+COMMENT ON COLUMN public.nutrition_profiles.formula IS 'Формула BMR: mifflin — Миффлин — Сан Жеор, katch — Кетч — МакАрдл';
+COMMENT ON COLUMN public.nutrition_profiles.calories IS 'Цель по калориям, ккал в сутки';
+COMMENT ON COLUMN public.nutrition_profiles.weekly_rate IS 'Темп изменения веса при последнем пересчёте, кг в неделю';
+COMMENT ON COLUMN public.nutrition_profiles.adjusted_at IS 'Время последнего пересчёта по динамике веса';
+COMMENT ON TABLE public.nutrition_checkins IS 'Ежедневные отметки клиента: калории, белок, шаги, сон';
+COMMENT ON COLUMN public.nutrition_checkins.sleep_hours IS 'Сон, часов';
@@ -0,0 +1,38 @@
+-- Миграция 030: Анкета готовности перед тренировкой
+-- Перед началом тренировки клиент отвечает про сон, мышечную боль, стресс,
+-- настроение и (по желанию) пульс покоя. Оценка готовности сохраняется для
+-- аналитики, а при низкой готовности вес и подходы тренировки можно снизить.
+-- Плановые значения сохраняются, чтобы тренер видел, что было изменено
+
+CREATE TABLE IF NOT EXISTS public.readiness_checkins (
+    id SERIAL PRIMARY KEY,
+    client_id INTEGER NOT NULL REFERENCES public.clients(id) ON DELETE CASCADE,
+    workout_id INTEGER REFERENCES public.program_workouts(id) ON DELETE SET NULL,
+    sleep_hours DECIMAL(3,1),
+    soreness SMALLINT CHECK (soreness BETWEEN 1 AND 5),
+    stress SMALLINT CHECK (stress BETWEEN 1 AND 5),
+    mood SMALLINT CHECK (mood BETWEEN 1 AND 5),
+    resting_hr SMALLINT,
+    score SMALLINT NOT NULL CHECK (score BETWEEN 0 AND 100),
+    zone VARCHAR(10) NOT NULL CHECK (zone IN ('high', 'moderate', 'low')),
+    adjusted BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_readiness_checkins_client_date ON public.readiness_checkins(client_id, created_at DESC);
+
+ALTER TABLE public.workout_exercises
+ADD COLUMN IF NOT EXISTS planned_sets INTEGER,
+ADD COLUMN IF NOT EXISTS planned_weight DECIMAL(6,2);
+
+COMMENT ON TABLE public.readiness_checkins IS 'Анкеты готовности перед тренировкой';
+COMMENT ON COLUMN public.readiness_checkins.sleep_hours IS 'Сон прошлой ночью, часы';
+COMMENT ON COLUMN public.readiness_checkins.soreness IS 'Мышечная боль: 1 — нет, 5 — сильная';
+COMMENT ON COLUMN public.readiness_checkins.stress IS 'Стресс: 1 — спокойно, 5 — сильный';
+COMMENT ON COLUMN public.readiness_checkins.mood IS 'Настроение: 1 — плохое, 5 — отличное';
+COMMENT ON COLUMN public.readiness_checkins.resting_hr IS 'Пульс покоя, уд/мин (NULL — не измерен)';
+COMMENT ON COLUMN public.readiness_checkins.score IS 'Оценка готовности 0–100';
+COMMENT ON COLUMN public.readiness_checkins.zone IS 'Зона: high — по плану, moderate — −5% веса, low — −10% веса и на подход меньше';
+COMMENT ON COLUMN public.readiness_checkins.adjusted IS 'Клиент согласился снизить нагрузку';
+COMMENT ON COLUMN public.workout_exercises.planned_sets IS 'Подходы по плану до корректировки по готовности';
+COMMENT ON COLUMN public.workout_exercises.planned_weight IS 'Вес по плану до корректировки по готовности';
@@ -0,0 +1,71 @@
+-- Миграция 031: Пакеты тренировок, абонементы и оплата
+-- Тренер продаёт пакеты на N тренировок или на срок (ведение на месяц).
+-- Купленный пакет становится абонементом клиента; проведённая запись списывает
+-- тренировку с абонемента (subscription_charges — одна запись на тренировку,
+-- чтобы повторная отметка не списала дважды). Суммы — в копейках
+
+CREATE TABLE IF NOT EXISTS public.billing_packages (
+    id SERIAL PRIMARY KEY,
+    trainer_id BIGINT NOT NULL REFERENCES public.admins(telegram_id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('sessions', 'period')),
+    sessions INTEGER NOT NULL DEFAULT 0 CHECK (sessions >= 0),
+    days INTEGER NOT NULL DEFAULT 0 CHECK (days >= 0),
+    price BIGINT NOT NULL CHECK (price > 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'RUB',
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_billing_packages_trainer ON public.billing_packages(trainer_id) WHERE is_active;
+
+CREATE TABLE IF NOT EXISTS public.client_subscriptions (
+    id SERIAL PRIMARY KEY,
+    client_id INTEGER NOT NULL REFERENCES public.clients(id) ON DELETE CASCADE,
+    package_id INTEGER REFERENCES public.billing_packages(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('sessions', 'period')),
+    sessions_total INTEGER NOT NULL DEFAULT 0,
+    sessions_left INTEGER NOT NULL DEFAULT 0 CHECK (sessions_left >= 0),
+    starts_on DATE NOT NULL,
+    expires_on DATE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_client_subscriptions_client ON public.client_subscriptions(client_id);
+
+CREATE TABLE IF NOT EXISTS public.payments (
+    id SERIAL PRIMARY KEY,
+    client_id INTEGER NOT NULL REFERENCES public.clients(id) ON DELETE CASCADE,
+    package_id INTEGER REFERENCES public.billing_packages(id) ON DELETE SET NULL,
+    subscription_id INTEGER REFERENCES public.client_subscriptions(id) ON DELETE SET NULL,
+    trainer_id BIGINT NOT NULL,
+    description VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL,
+    provider VARCHAR(20) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'paid', 'canceled')),
+    provider_charge_id VARCHAR(255),
+    telegram_charge_id VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    paid_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_client ON public.payments(client_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_payments_paid ON public.payments(paid_at) WHERE status = 'paid';
+
+CREATE TABLE IF NOT EXISTS public.subscription_charges (
+    appointment_id INTEGER PRIMARY KEY REFERENCES public.appointments(id) ON DELETE CASCADE,
+    subscription_id INTEGER NOT NULL REFERENCES public.client_subscriptions(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE public.billing_packages IS 'Каталог пакетов тренера';
+COMMENT ON COLUMN public.billing_packages.kind IS 'sessions — N тренировок, period — безлимит на срок';
+COMMENT ON COLUMN public.billing_packages.days IS 'Срок действия в днях; 0 у пакета тренировок — бессрочно';
+COMMENT ON COLUMN public.billing_packages.price IS 'Цена в минимальных единицах валюты (копейках)';
+COMMENT ON TABLE public.client_subscriptions IS 'Абонементы клиентов — купленные пакеты и остаток тренировок';
+COMMENT ON COLUMN public.client_subscriptions.expires_on IS 'Последний день действия; NULL — бессрочно';
+COMMENT ON TABLE public.payments IS 'Платежи: счета (pending) и оплаты (paid)';
+COMMENT ON COLUMN public.payments.provider IS 'telegram — Telegram Payments, manual — оплата, внесённая тренером';
+COMMENT ON TABLE public.subscription_charges IS 'Списания тренировок с абонементов: одна запись на проведённую тренировку';
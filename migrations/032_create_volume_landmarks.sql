@@ -0,0 +1,47 @@
+-- Миграция 032: Индивидуальные ориентиры объёма (MEV/MAV/MRV)
+-- Табличные ориентиры подходов в неделю по мышечным группам адаптируются под
+-- клиента в конце каждого мезоцикла (программы): по выполненным подходам,
+-- RPE тренировок, мышечной боли из анкет готовности и динамике e1RM.
+-- История хранит причину каждого изменения — тренер видит, почему объём сдвинулся
+
+CREATE TABLE IF NOT EXISTS public.client_volume_landmarks (
+    client_id INTEGER NOT NULL REFERENCES public.clients(id) ON DELETE CASCADE,
+    muscle_group VARCHAR(30) NOT NULL,
+    adaptation_factor DECIMAL(4,2) NOT NULL DEFAULT 1.0 CHECK (adaptation_factor BETWEEN 0.5 AND 1.5),
+    mev INTEGER NOT NULL,
+    mav INTEGER NOT NULL,
+    mrv INTEGER NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (client_id, muscle_group)
+);
+
+CREATE TABLE IF NOT EXISTS public.volume_landmark_history (
+    id SERIAL PRIMARY KEY,
+    client_id INTEGER NOT NULL REFERENCES public.clients(id) ON DELETE CASCADE,
+    program_id INTEGER REFERENCES public.training_programs(id) ON DELETE SET NULL,
+    muscle_group VARCHAR(30) NOT NULL,
+    factor_before DECIMAL(4,2) NOT NULL,
+    factor_after DECIMAL(4,2) NOT NULL,
+    weekly_sets DECIMAL(5,1) NOT NULL,
+    session_rpe DECIMAL(3,1),
+    soreness DECIMAL(3,1),
+    performance DECIMAL(5,1),
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('progress', 'fatigue', 'regression', 'stable', 'low_volume')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (program_id, muscle_group)
+);
+
+CREATE INDEX IF NOT EXISTS idx_volume_landmark_history_client ON public.volume_landmark_history(client_id, created_at DESC);
+
+-- RPE всей тренировки (анкета после тренировки); раньше хранился только в тексте feedback
+ALTER TABLE public.program_workouts
+ADD COLUMN IF NOT EXISTS session_rpe SMALLINT CHECK (session_rpe BETWEEN 1 AND 10);
+
+COMMENT ON TABLE public.client_volume_landmarks IS 'Ориентиры объёма клиента по мышечным группам';
+COMMENT ON COLUMN public.client_volume_landmarks.adaptation_factor IS 'Множитель табличных MEV/MAV/MRV (0.7–1.3)';
+COMMENT ON TABLE public.volume_landmark_history IS 'Изменения ориентиров объёма по итогам мезоциклов';
+COMMENT ON COLUMN public.volume_landmark_history.weekly_sets IS 'Рабочих подходов в неделю за блок';
+COMMENT ON COLUMN public.volume_landmark_history.soreness IS 'Средняя мышечная боль 1–5 по анкетам готовности';
+COMMENT ON COLUMN public.volume_landmark_history.performance IS 'Изменение e1RM упражнений группы за блок, %';
+COMMENT ON COLUMN public.volume_landmark_history.reason IS 'progress — объём выше, fatigue/regression — ниже, stable и low_volume — без изменений';
+COMMENT ON COLUMN public.program_workouts.session_rpe IS 'RPE тренировки по оценке клиента';
@@ -0,0 +1,33 @@
+-- Миграция 033: Журнал тренировочной нагрузки
+-- После каждой тренировки программы записывается её нагрузка: sRPE (RPE всей
+-- тренировки × минуты), тоннаж и INOL. По журналу считаются ACWR (острая
+-- нагрузка 7 дней к хронической 28 дней) и модель Банистера; состояние на момент
+-- тренировки сохраняется, чтобы тренер получал сигнал только при смене зоны ACWR
+
+CREATE TABLE IF NOT EXISTS public.training_loads (
+    id SERIAL PRIMARY KEY,
+    client_id INTEGER NOT NULL REFERENCES public.clients(id) ON DELETE CASCADE,
+    workout_id INTEGER UNIQUE REFERENCES public.program_workouts(id) ON DELETE SET NULL,
+    performed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    duration_min INTEGER NOT NULL CHECK (duration_min >= 0),
+    session_rpe SMALLINT CHECK (session_rpe BETWEEN 1 AND 10),
+    srpe_load DECIMAL(8,1) NOT NULL DEFAULT 0,
+    tonnage DECIMAL(10,1) NOT NULL DEFAULT 0,
+    inol DECIMAL(6,2) NOT NULL DEFAULT 0,
+    acute_load DECIMAL(8,1),
+    chronic_load DECIMAL(8,1),
+    acwr DECIMAL(4,2),
+    acwr_zone VARCHAR(10) NOT NULL DEFAULT 'unknown' CHECK (acwr_zone IN ('unknown', 'low', 'safe', 'high', 'danger')),
+    fitness DECIMAL(9,1),
+    fatigue DECIMAL(9,1)
+);
+
+CREATE INDEX IF NOT EXISTS idx_training_loads_client_date ON public.training_loads(client_id, performed_at DESC);
+
+COMMENT ON TABLE public.training_loads IS 'Нагрузка выполненных тренировок и состояние ACWR/Банистера после них';
+COMMENT ON COLUMN public.training_loads.srpe_load IS 'Нагрузка по Фостеру: RPE тренировки × длительность, AU';
+COMMENT ON COLUMN public.training_loads.inol IS 'Суммарный INOL подходов с известным % от 1ПМ';
+COMMENT ON COLUMN public.training_loads.acwr IS 'Отношение острой нагрузки к хронической (EWMA 7/28 дней)';
+COMMENT ON COLUMN public.training_loads.acwr_zone IS 'unknown — мало истории, low < 0.8, safe 0.8–1.3, high до 1.5, danger выше';
+COMMENT ON COLUMN public.training_loads.fitness IS 'Подготовленность по Банистеру (затухание 42 дня)';
+COMMENT ON COLUMN public.training_loads.fatigue IS 'Утомление по Банистеру (затухание 7 дней)';
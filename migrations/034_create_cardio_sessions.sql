@@ -0,0 +1,39 @@
+-- Миграция 034: Загруженные кардиотренировки
+-- Клиент присылает боту файл с часов (GPX, TCX или FIT). Бот считает длительность,
+-- дистанцию, сплиты и время в пульсовых зонах, сопоставляет запись с
+-- запланированной кардиотренировкой и оценивает соответствие назначению.
+-- Итог меняет поправку объёма следующих кардиотренировок (factor_after последней записи)
+
+CREATE TABLE IF NOT EXISTS public.cardio_sessions (
+    id SERIAL PRIMARY KEY,
+    client_id INTEGER NOT NULL REFERENCES public.clients(id) ON DELETE CASCADE,
+    workout_id INTEGER REFERENCES public.program_workouts(id) ON DELETE SET NULL,
+    source_format VARCHAR(3) NOT NULL CHECK (source_format IN ('gpx', 'tcx', 'fit')),
+    sport VARCHAR(30) NOT NULL DEFAULT '',
+    started_at TIMESTAMP NOT NULL,
+    duration_sec INTEGER NOT NULL,
+    moving_sec INTEGER NOT NULL,
+    distance_m DECIMAL(9,1) NOT NULL DEFAULT 0,
+    avg_hr SMALLINT,
+    max_hr SMALLINT,
+    splits JSONB NOT NULL DEFAULT '[]',
+    zone_seconds INTEGER[] NOT NULL DEFAULT '{}',
+    compliance SMALLINT CHECK (compliance BETWEEN 0 AND 100),
+    volume_ratio DECIMAL(4,2),
+    in_zone_pct SMALLINT,
+    pace_in_range_pct SMALLINT,
+    factor_before DECIMAL(3,2) NOT NULL DEFAULT 1.0,
+    factor_after DECIMAL(3,2) NOT NULL DEFAULT 1.0,
+    reason VARCHAR(12) CHECK (reason IN ('progress', 'incomplete', 'overreach', 'stable')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (client_id, started_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_cardio_sessions_client ON public.cardio_sessions(client_id, started_at DESC);
+
+COMMENT ON TABLE public.cardio_sessions IS 'Кардиотренировки из файлов GPX/TCX/FIT';
+COMMENT ON COLUMN public.cardio_sessions.workout_id IS 'Запланированная тренировка, с которой сопоставлена запись';
+COMMENT ON COLUMN public.cardio_sessions.splits IS 'Сплиты по километрам: [{"km", "distance_m", "moving_sec", "avg_hr"}]';
+COMMENT ON COLUMN public.cardio_sessions.zone_seconds IS 'Секунды в зонах recovery, fat_burn, aerobic, threshold, anaerobic';
+COMMENT ON COLUMN public.cardio_sessions.compliance IS 'Соответствие назначению 0–100; NULL — не с чем сравнить';
+COMMENT ON COLUMN public.cardio_sessions.factor_after IS 'Поправка объёма кардио после этой тренировки (0.7–1.3)';
@@ -0,0 +1,39 @@
+-- Миграция 035: Раскладка забега Hyrox и результаты симуляций
+-- Клиент задаёт целевое время и контрольные замеры (1 км бега, SkiErg и гребля 1000 м,
+-- сани). Бот раскладывает цель на 8 беговых отрезков, 8 станций и переходы.
+-- Раскладка не хранится: она однозначно пересчитывается из цели и замеров.
+-- Результаты симуляции сравниваются с раскладкой; акцент (focus) последней симуляции
+-- определяет станции неполных симуляций в следующих программах
+
+CREATE TABLE IF NOT EXISTS public.hyrox_plans (
+    id SERIAL PRIMARY KEY,
+    client_id INTEGER NOT NULL REFERENCES public.clients(id) ON DELETE CASCADE,
+    target_sec INTEGER NOT NULL CHECK (target_sec > 0),
+    predicted_sec INTEGER NOT NULL CHECK (predicted_sec > 0),
+    benchmarks JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_hyrox_plans_client ON public.hyrox_plans(client_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS public.hyrox_simulations (
+    id SERIAL PRIMARY KEY,
+    client_id INTEGER NOT NULL REFERENCES public.clients(id) ON DELETE CASCADE,
+    plan_id INTEGER NOT NULL REFERENCES public.hyrox_plans(id) ON DELETE CASCADE,
+    splits INTEGER[] NOT NULL,
+    total_sec INTEGER NOT NULL,
+    delta_sec INTEGER NOT NULL,
+    run_fade DECIMAL(4,3) NOT NULL DEFAULT 0,
+    focus TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_hyrox_simulations_client ON public.hyrox_simulations(client_id, created_at DESC);
+
+COMMENT ON TABLE public.hyrox_plans IS 'Целевое время и контрольные замеры Hyrox';
+COMMENT ON COLUMN public.hyrox_plans.benchmarks IS 'Замеры в секундах: {"run", "ski_erg", "rowing", "sled_push", ...}';
+COMMENT ON TABLE public.hyrox_simulations IS 'Результаты симуляций Hyrox относительно раскладки';
+COMMENT ON COLUMN public.hyrox_simulations.splits IS 'Секунды отрезков в порядке забега: бег, станция, ..., roxzone (0 — не записан)';
+COMMENT ON COLUMN public.hyrox_simulations.delta_sec IS 'Отставание от раскладки по записанным отрезкам (меньше нуля — быстрее)';
+COMMENT ON COLUMN public.hyrox_simulations.run_fade IS 'Насколько последние километры отстают от плана сильнее первых (доля)';
+COMMENT ON COLUMN public.hyrox_simulations.focus IS 'Акцент следующих симуляций: run или ID станций';
@@ -0,0 +1,28 @@
+-- Миграция 036: Подписка на календарь по секретной ссылке
+-- Клиент или тренер получает в настройках ссылку на ленту .ics: записи на тренировки,
+-- у клиента — ещё тренировки программы по плановым датам. Календарь перечитывает
+-- ленту сам и сверяет события по UID: изменённое событие должно прийти
+-- с большим SEQUENCE, поэтому записи и тренировки программ хранят счётчик изменений.
+-- Ссылку можно отозвать: строка остаётся с revoked_at, лента по токену отдаёт 404
+
+ALTER TABLE public.appointments ADD COLUMN IF NOT EXISTS ics_sequence INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE public.program_workouts ADD COLUMN IF NOT EXISTS ics_sequence INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS public.calendar_feeds (
+    token VARCHAR(64) PRIMARY KEY,
+    telegram_id BIGINT NOT NULL,
+    role VARCHAR(10) NOT NULL CHECK (role IN ('client', 'trainer')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP,
+    last_fetched_at TIMESTAMP
+);
+
+-- Действующая ссылка у пользователя одна
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_feeds_active
+    ON public.calendar_feeds(telegram_id) WHERE revoked_at IS NULL;
+
+COMMENT ON TABLE public.calendar_feeds IS 'Секретные ссылки на ленты календаря (.ics)';
+COMMENT ON COLUMN public.calendar_feeds.role IS 'client — свои записи и тренировки программ, trainer — записи клиентов к тренеру';
+COMMENT ON COLUMN public.calendar_feeds.last_fetched_at IS 'Когда календарь последний раз забирал ленту';
+COMMENT ON COLUMN public.appointments.ics_sequence IS 'SEQUENCE события в ленте: растёт при переносе и смене статуса';
+COMMENT ON COLUMN public.program_workouts.ics_sequence IS 'SEQUENCE события в ленте: растёт при сдвиге даты, пропуске и паузе программы';
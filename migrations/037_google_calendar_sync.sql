@@ -0,0 +1,23 @@
+-- Миграция 037: Синхронизация записей с Google Calendar тренера
+-- Бот создаёт, меняет и удаляет события записей (ID события — appointments.google_event_id,
+-- миграция 015). Запись ждёт отправки, пока updated_at новее google_synced_at: задача
+-- gcal_push отправляет такие записи и повторяет неудачные попытки.
+-- События, которые тренер добавил в календарь сам, задача gcal_busy переносит
+-- в trainer_busy_blocks — на это время запись недоступна
+
+ALTER TABLE public.appointments ADD COLUMN IF NOT EXISTS google_synced_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS public.trainer_busy_blocks (
+    id SERIAL PRIMARY KEY,
+    trainer_id BIGINT NOT NULL,
+    google_event_id VARCHAR(255) NOT NULL,
+    summary TEXT NOT NULL DEFAULT '',
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL CHECK (ends_at > starts_at),
+    synced_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_trainer_busy_blocks_trainer ON public.trainer_busy_blocks(trainer_id, starts_at);
+
+COMMENT ON COLUMN public.appointments.google_synced_at IS 'updated_at записи, отправленной в Google Calendar; NULL — не отправлялась';
+COMMENT ON TABLE public.trainer_busy_blocks IS 'Занятость тренера из Google Calendar (события, созданные не ботом)';